@@ -2,24 +2,12 @@ package main
 
 import (
 	"log"
-	"os"
+	"time"
+	_ "time/tzdata"
 
+	"github.com/raflytch/careerly-server/internal/app"
 	"github.com/raflytch/careerly-server/internal/config"
-	"github.com/raflytch/careerly-server/internal/database"
-	"github.com/raflytch/careerly-server/internal/handler"
-	"github.com/raflytch/careerly-server/internal/middleware"
-	"github.com/raflytch/careerly-server/internal/repository"
-	"github.com/raflytch/careerly-server/internal/routes"
-	"github.com/raflytch/careerly-server/internal/service"
-	"github.com/raflytch/careerly-server/pkg/genai"
-	"github.com/raflytch/careerly-server/pkg/imagekit"
-	"github.com/raflytch/careerly-server/pkg/jwt"
-	"github.com/raflytch/careerly-server/pkg/midtrans"
 
-	"github.com/gofiber/fiber/v2"
-	"github.com/gofiber/fiber/v2/middleware/cors"
-	"github.com/gofiber/fiber/v2/middleware/logger"
-	"github.com/gofiber/fiber/v2/middleware/recover"
 	"github.com/joho/godotenv"
 )
 
@@ -30,142 +18,17 @@ func main() {
 
 	cfg := config.Load()
 
-	db, err := database.NewPostgresConnection(cfg.Database)
-	if err != nil {
-		log.Fatalf("Failed to connect to database: %v", err)
-	}
-	defer db.Close()
+	// Timestamps are stored and compared in UTC; user-facing dates are
+	// converted to each user's timezone in the services.
+	time.Local = time.UTC
 
-	redisClient, err := database.NewRedisConnection(cfg.Redis)
+	application, cleanup, err := app.Initialize(cfg)
 	if err != nil {
-		log.Fatalf("Failed to connect to redis: %v", err)
-	}
-	defer redisClient.Close()
-
-	jwtManager := jwt.NewJWTManager(cfg.JWT.Secret, cfg.JWT.ExpiryHours)
-
-	imagekitClient := imagekit.NewClient(imagekit.Config{
-		PublicKey:   cfg.ImageKit.PublicKey,
-		PrivateKey:  cfg.ImageKit.PrivateKey,
-		URLEndpoint: cfg.ImageKit.URLEndpoint,
-	})
-
-	var genaiClient *genai.Client
-	if cfg.GenAI.APIKey != "" {
-		var err error
-		genaiClient, err = genai.NewClient(genai.Config{
-			APIKey: cfg.GenAI.APIKey,
-			Model:  cfg.GenAI.Model,
-		})
-		if err != nil {
-			log.Printf("Warning: Failed to initialize GenAI client: %v", err)
-		}
-	}
-
-	// Initialize Midtrans client for payment gateway
-	var midtransClient *midtrans.Client
-	if cfg.Midtrans.ServerKey != "" {
-		midtransClient = midtrans.NewClient(midtrans.Config{
-			ServerKey:  cfg.Midtrans.ServerKey,
-			ClientKey:  cfg.Midtrans.ClientKey,
-			IsSandbox:  cfg.Midtrans.IsSandbox,
-			MerchantID: cfg.Midtrans.MerchantID,
-		})
-		log.Println("Midtrans client initialized")
-	} else {
-		log.Println("Warning: Midtrans server key not configured, payment features disabled")
-	}
-
-	// Initialize repositories
-	userRepo := repository.NewUserRepository(db)
-	cacheRepo := repository.NewCacheRepository(redisClient)
-	planRepo := repository.NewPlanRepository(db)
-	subscriptionRepo := repository.NewSubscriptionRepository(db)
-	usageRepo := repository.NewUsageRepository(db)
-	resumeRepo := repository.NewResumeRepository(db)
-	interviewRepo := repository.NewInterviewRepository(db)
-	atsCheckRepo := repository.NewATSCheckRepository(db)
-	transactionRepo := repository.NewTransactionRepository(db)
-
-	// Initialize services
-	emailService := service.NewEmailService(cfg.SMTP)
-	authService := service.NewAuthService(userRepo, cacheRepo, emailService, cfg.Google, jwtManager)
-	userService := service.NewUserService(userRepo, cacheRepo, subscriptionRepo, usageRepo, emailService)
-	planService := service.NewPlanService(planRepo, cacheRepo)
-	quotaService := service.NewQuotaService(subscriptionRepo, usageRepo)
-	resumeService := service.NewResumeService(resumeRepo, quotaService, genaiClient, cacheRepo)
-	interviewService := service.NewInterviewService(interviewRepo, quotaService, genaiClient)
-	atsCheckService := service.NewATSCheckService(atsCheckRepo, quotaService, genaiClient)
-	transactionService := service.NewTransactionService(
-		transactionRepo,
-		planRepo,
-		subscriptionRepo,
-		userRepo,
-		cacheRepo,
-		midtransClient,
-	)
-
-	// Initialize middleware
-	authMiddleware := middleware.NewAuthMiddleware(authService)
-
-	// Initialize handlers
-	authHandler := handler.NewAuthHandler(authService, cfg.Google.FrontendURL)
-	userHandler := handler.NewUserHandler(userService, imagekitClient)
-	planHandler := handler.NewPlanHandler(planService)
-	resumeHandler := handler.NewResumeHandler(resumeService, quotaService)
-	interviewHandler := handler.NewInterviewHandler(interviewService, quotaService)
-	atsCheckHandler := handler.NewATSCheckHandler(atsCheckService, quotaService)
-	transactionHandler := handler.NewTransactionHandler(transactionService)
-
-	app := fiber.New(fiber.Config{
-		AppName:      "Careerly API",
-		ErrorHandler: customErrorHandler,
-	})
-
-	app.Use(recover.New())
-	app.Use(logger.New(logger.Config{
-		Format: "[${time}] ${status} - ${latency} ${method} ${path}\n",
-	}))
-	app.Use(cors.New(cors.Config{
-		AllowOrigins:     cfg.CORS.AllowOrigins,
-		AllowHeaders:     "Origin, Content-Type, Accept, Authorization",
-		AllowMethods:     "GET, POST, PUT, DELETE, PATCH, OPTIONS",
-		AllowCredentials: true,
-	}))
-
-	routes.Setup(app, routes.Handlers{
-		Auth:        authHandler,
-		User:        userHandler,
-		Plan:        planHandler,
-		Resume:      resumeHandler,
-		Interview:   interviewHandler,
-		ATSCheck:    atsCheckHandler,
-		Transaction: transactionHandler,
-	}, routes.Middlewares{
-		Auth: authMiddleware,
-	})
-
-	port := cfg.App.Port
-	if port == "" {
-		port = "3000"
+		log.Fatalf("Failed to initialize application: %v", err)
 	}
+	defer cleanup()
 
-	log.Printf("Server starting on port %s", port)
-	if err := app.Listen(":" + port); err != nil {
+	if err := application.Run(); err != nil {
 		log.Fatalf("Failed to start server: %v", err)
-		os.Exit(1)
 	}
 }
-
-func customErrorHandler(c *fiber.Ctx, err error) error {
-	code := fiber.StatusInternalServerError
-
-	if e, ok := err.(*fiber.Error); ok {
-		code = e.Code
-	}
-
-	return c.Status(code).JSON(fiber.Map{
-		"success": false,
-		"error":   err.Error(),
-	})
-}
@@ -0,0 +1,62 @@
+// Command migrateresumes rewrites resume content stored in an older schema
+// version in the current one. Reads already upgrade old content on the fly, so
+// this is optional, but it lets old migration steps eventually be retired. It
+// is safe to run repeatedly and while the server is serving traffic.
+package main
+
+import (
+	"context"
+	"flag"
+	"log"
+
+	"github.com/raflytch/careerly-server/internal/config"
+	"github.com/raflytch/careerly-server/internal/database"
+	"github.com/raflytch/careerly-server/internal/repository"
+	"github.com/raflytch/careerly-server/pkg/fieldcrypt"
+
+	"github.com/google/uuid"
+	"github.com/joho/godotenv"
+)
+
+func main() {
+	batchSize := flag.Int("batch", 200, "number of resumes to read per batch")
+	flag.Parse()
+
+	if err := godotenv.Load(".env"); err != nil {
+		log.Println("No .env file found, using environment variables")
+	}
+
+	cfg := config.Load()
+
+	cipher, err := fieldcrypt.NewFromSpec(cfg.Encryption.PIIActiveKey, cfg.Encryption.PIIKeys)
+	if err != nil {
+		log.Fatalf("Failed to load PII encryption keys: %v", err)
+	}
+
+	db, err := database.NewPostgresConnection(cfg.Database)
+	if err != nil {
+		log.Fatalf("Failed to connect to database: %v", err)
+	}
+	defer db.Close()
+
+	resumeRepo := repository.NewResumeRepository(database.NewRouter(db, nil), cipher, cfg.DataRegion)
+
+	ctx := context.Background()
+	cursor := uuid.Nil
+	var scanned, updated int
+	for {
+		result, err := resumeRepo.MigrateSchemaBatch(ctx, cursor, *batchSize)
+		if err != nil {
+			log.Fatalf("Migration stopped after %s: %v", cursor, err)
+		}
+		scanned += result.Scanned
+		updated += result.Updated
+		if result.Scanned == 0 {
+			break
+		}
+		cursor = result.LastID
+		log.Printf("Migrated %d of %d resumes so far", updated, scanned)
+	}
+
+	log.Printf("Done: %d resumes scanned, %d migrated", scanned, updated)
+}
@@ -0,0 +1,75 @@
+// Command reencrypt encrypts resume and draft contact details and saved card
+// tokens that are still stored in plaintext, and re-seals values encrypted
+// with a key that is no longer active. It is safe to run repeatedly and while
+// the server is serving traffic.
+package main
+
+import (
+	"context"
+	"flag"
+	"log"
+
+	"github.com/raflytch/careerly-server/internal/config"
+	"github.com/raflytch/careerly-server/internal/database"
+	"github.com/raflytch/careerly-server/internal/domain"
+	"github.com/raflytch/careerly-server/internal/repository"
+	"github.com/raflytch/careerly-server/pkg/fieldcrypt"
+
+	"github.com/google/uuid"
+	"github.com/joho/godotenv"
+)
+
+func main() {
+	batchSize := flag.Int("batch", 200, "number of records to read per batch")
+	flag.Parse()
+
+	if err := godotenv.Load(".env"); err != nil {
+		log.Println("No .env file found, using environment variables")
+	}
+
+	cfg := config.Load()
+
+	cipher, err := fieldcrypt.NewFromSpec(cfg.Encryption.PIIActiveKey, cfg.Encryption.PIIKeys)
+	if err != nil {
+		log.Fatalf("Failed to load PII encryption keys: %v", err)
+	}
+	if cipher == nil {
+		log.Fatal("PII_ENCRYPTION_KEYS is not set, nothing to encrypt with")
+	}
+
+	db, err := database.NewPostgresConnection(cfg.Database)
+	if err != nil {
+		log.Fatalf("Failed to connect to database: %v", err)
+	}
+	defer db.Close()
+
+	resumeRepo := repository.NewResumeRepository(database.NewRouter(db, nil), cipher, cfg.DataRegion)
+	draftRepo := repository.NewResumeDraftRepository(db, cipher, cfg.DataRegion)
+	paymentMethodRepo := repository.NewPaymentMethodRepository(db, cipher)
+
+	ctx := context.Background()
+	reencrypt(ctx, "resumes", *batchSize, resumeRepo.ReencryptBatch)
+	reencrypt(ctx, "drafts", *batchSize, draftRepo.ReencryptBatch)
+	reencrypt(ctx, "saved cards", *batchSize, paymentMethodRepo.ReencryptBatch)
+}
+
+// reencrypt runs batch over every record of one kind, logging progress.
+func reencrypt(ctx context.Context, kind string, batchSize int, batch func(ctx context.Context, afterID uuid.UUID, limit int) (*domain.ResumeBatchResult, error)) {
+	cursor := uuid.Nil
+	var scanned, updated int
+	for {
+		result, err := batch(ctx, cursor, batchSize)
+		if err != nil {
+			log.Fatalf("Re-encryption of %s stopped after %s: %v", kind, cursor, err)
+		}
+		scanned += result.Scanned
+		updated += result.Updated
+		if result.Scanned == 0 {
+			break
+		}
+		cursor = result.LastID
+		log.Printf("Re-encrypted %d of %d %s so far", updated, scanned, kind)
+	}
+
+	log.Printf("Done: %d %s scanned, %d re-encrypted", scanned, kind, updated)
+}
@@ -0,0 +1,134 @@
+package app
+
+import (
+	"time"
+
+	"github.com/raflytch/careerly-server/internal/config"
+	"github.com/raflytch/careerly-server/internal/domain"
+	"github.com/raflytch/careerly-server/internal/handler"
+	"github.com/raflytch/careerly-server/internal/service"
+	"github.com/raflytch/careerly-server/pkg/genai"
+	"github.com/raflytch/careerly-server/pkg/media"
+	"github.com/raflytch/careerly-server/pkg/signedtoken"
+	"github.com/raflytch/careerly-server/pkg/storage"
+
+	"github.com/google/wire"
+)
+
+// AIFeatureSet covers resumes, ATS checks, interviews and the AI plumbing
+// behind them: prompts, experiments, usage and feedback.
+var AIFeatureSet = wire.NewSet(
+	provideAIUsageService,
+	service.NewPromptService,
+	wire.Bind(new(domain.PromptProvider), new(domain.PromptService)),
+	service.NewPromptExperimentService,
+	provideResumeService,
+	service.NewResumeLintService,
+	service.NewResumeDraftService,
+	service.NewResumeLibraryService,
+	provideResumeShareService,
+	service.NewATSProgressBroker,
+	service.NewATSCheckService,
+	service.NewInterviewPackService,
+	service.NewQuestionBankService,
+	service.NewInterviewProgressBroker,
+	provideInterviewService,
+	provideInterviewShareService,
+	provideInterviewSchedulerService,
+	service.NewStudyPlanService,
+	service.NewCareerInsightService,
+	service.NewAIFeedbackService,
+	handler.NewAIUsageHandler,
+	handler.NewPromptHandler,
+	handler.NewPromptExperimentHandler,
+	handler.NewResumeHandler,
+	handler.NewResumeDraftHandler,
+	handler.NewResumeLibraryHandler,
+	handler.NewResumeShareHandler,
+	handler.NewATSCheckHandler,
+	handler.NewInterviewPackHandler,
+	handler.NewQuestionBankHandler,
+	provideInterviewHandler,
+	handler.NewInterviewShareHandler,
+	handler.NewStudyPlanHandler,
+	handler.NewCareerInsightHandler,
+	handler.NewAIFeedbackHandler,
+)
+
+// provideAIUsageService also hooks the AI clients up to it, so every call
+// they make is metered.
+func provideAIUsageService(aiUsageRepo domain.AIUsageRepository, cacheRepo domain.CacheRepository, budget config.AIBudgetConfig, genaiClient *genai.Client, fallbackAIClient *genai.OpenAIClient) domain.AIUsageService {
+	aiUsageService := service.NewAIUsageService(aiUsageRepo, cacheRepo, budget)
+	if genaiClient != nil {
+		genaiClient.SetUsageHook(service.NewGenAIUsageHook(aiUsageService))
+	}
+	if fallbackAIClient != nil {
+		fallbackAIClient.SetUsageHook(service.NewGenAIUsageHook(aiUsageService))
+	}
+	return aiUsageService
+}
+
+func provideResumeService(
+	cfg *config.Config,
+	resumeRepo domain.ResumeRepository,
+	quotaService domain.QuotaService,
+	aiClient domain.AIClient,
+	prompts domain.PromptProvider,
+	cacheRepo domain.CacheRepository,
+	webhooks domain.WebhookPublisher,
+	artifactRepo domain.ArtifactRepository,
+	artifactStorage storage.Storage,
+	regionalStorage regionalArtifactStorage,
+) domain.ResumeService {
+	return service.NewResumeService(
+		resumeRepo,
+		quotaService,
+		aiClient,
+		prompts,
+		cacheRepo,
+		webhooks,
+		artifactRepo,
+		artifactStorage,
+		regionalStorage,
+		time.Duration(cfg.Artifact.URLTTLMinutes)*time.Minute,
+	)
+}
+
+func provideResumeShareService(cfg *config.Config, shareRepo domain.ResumeShareRepository, analyticsRepo domain.ResumeShareAnalyticsRepository, resumeRepo domain.ResumeRepository, resumeService domain.ResumeService, signer *signedtoken.Signer) domain.ResumeShareService {
+	return service.NewResumeShareService(shareRepo, analyticsRepo, resumeRepo, resumeService, signer, cfg.App.FrontendURL)
+}
+
+func provideInterviewService(
+	cfg *config.Config,
+	interviewRepo domain.InterviewRepository,
+	packRepo domain.InterviewPackRepository,
+	bankRepo domain.QuestionBankRepository,
+	quotaService domain.QuotaService,
+	cacheRepo domain.CacheRepository,
+	progressBroker domain.InterviewProgressBroker,
+	aiClient domain.AIClient,
+	prompts domain.PromptProvider,
+	webhooks domain.WebhookPublisher,
+	videos videoStorage,
+	aiLimiter domain.ConcurrencyLimiter,
+) domain.InterviewService {
+	return service.NewInterviewService(interviewRepo, packRepo, bankRepo, quotaService, cacheRepo, progressBroker, aiClient, prompts, webhooks, videos, media.FFmpegPath(cfg.Interview.FFmpegPath), aiLimiter, cfg.AILimit)
+}
+
+func provideInterviewShareService(cfg *config.Config, shareRepo domain.InterviewShareRepository, interviewRepo domain.InterviewRepository, signer *signedtoken.Signer) domain.InterviewShareService {
+	return service.NewInterviewShareService(shareRepo, interviewRepo, signer, cfg.App.FrontendURL)
+}
+
+func provideInterviewSchedulerService(cfg *config.Config, interviewRepo domain.InterviewRepository, userRepo domain.UserRepository, emailService domain.EmailService, jobs domain.JobEnqueuer) domain.InterviewSchedulerService {
+	return service.NewInterviewSchedulerService(
+		interviewRepo,
+		userRepo,
+		emailService,
+		jobs,
+		time.Duration(cfg.Interview.ReminderLeadMinutes)*time.Minute,
+	)
+}
+
+func provideInterviewHandler(cfg *config.Config, interviewService domain.InterviewService, quotaService domain.QuotaService, progressBroker domain.InterviewProgressBroker) *handler.InterviewHandler {
+	return handler.NewInterviewHandler(interviewService, quotaService, progressBroker, megabytes(cfg.Interview.VideoMaxSizeMB))
+}
@@ -0,0 +1,84 @@
+package app
+
+import (
+	"context"
+	"log"
+	"time"
+
+	"github.com/raflytch/careerly-server/internal/config"
+	"github.com/raflytch/careerly-server/internal/database"
+	"github.com/raflytch/careerly-server/internal/domain"
+	"github.com/raflytch/careerly-server/internal/worker"
+	"github.com/raflytch/careerly-server/pkg/jobqueue"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+// App is the assembled server: the HTTP app plus what the background
+// workers and job handlers run against.
+type App struct {
+	Config       *config.Config
+	Server       *fiber.App
+	Router       *database.Router
+	JobQueue     *jobqueue.Queue
+	Transactions domain.TransactionService
+	Provisioning domain.ProvisioningService
+	Subscription domain.SubscriptionService
+	Webhooks     domain.WebhookService
+	Scheduler    domain.InterviewSchedulerService
+	CacheWarm    domain.CacheWarmService
+	Trash        domain.TrashService
+	Retention    domain.RetentionService
+	Deletions    domain.AccountDeletionService
+	ResumeShares domain.ResumeShareService
+}
+
+// Run starts the job queue and background workers, then serves HTTP until
+// the listener fails.
+func (a *App) Run() error {
+	cfg := a.Config
+
+	a.JobQueue.Register(domain.JobInterviewReminder, jobqueue.Typed(func(ctx context.Context, job domain.InterviewReminderJob) error {
+		return a.Scheduler.SendReminder(ctx, job.InterviewID)
+	}), jobqueue.DefaultRetryPolicy)
+	a.JobQueue.Register(domain.JobPaymentNotification, jobqueue.Typed(func(ctx context.Context, job domain.PaymentNotificationJob) error {
+		return a.Transactions.ProcessNotification(database.WithPrimary(ctx), &job)
+	}), jobqueue.DefaultRetryPolicy)
+	a.JobQueue.Register(domain.JobAccountPurgeNotice, jobqueue.Typed(func(ctx context.Context, job domain.AccountPurgeJob) error {
+		return a.Deletions.SendNotice(ctx, job.UserID)
+	}), jobqueue.DefaultRetryPolicy)
+	a.JobQueue.Register(domain.JobAccountPurge, jobqueue.Typed(func(ctx context.Context, job domain.AccountPurgeJob) error {
+		return a.Deletions.Purge(ctx, job.UserID)
+	}), jobqueue.DefaultRetryPolicy)
+
+	if err := a.JobQueue.Start(context.Background()); err != nil {
+		return err
+	}
+	if cfg.CacheWarm.Enabled {
+		worker.StartCacheWarmer(context.Background(), a.CacheWarm, time.Duration(cfg.CacheWarm.IntervalMinutes)*time.Minute)
+	}
+	worker.StartProvisioningRetrier(context.Background(), a.Provisioning, time.Duration(cfg.Midtrans.ProvisioningRetrySeconds)*time.Second)
+	worker.StartReplicaMonitor(context.Background(), a.Router, seconds(cfg.Database.ReplicaCheckSeconds))
+	worker.StartPaymentReconciler(
+		database.WithPrimary(context.Background()),
+		a.Transactions,
+		time.Duration(cfg.Midtrans.ReconcileIntervalSeconds)*time.Second,
+		time.Duration(cfg.Midtrans.ReconcileAfterMinutes)*time.Minute,
+	)
+	worker.StartSubscriptionRollover(context.Background(), a.Subscription, time.Duration(cfg.Subscription.RolloverIntervalSeconds)*time.Second)
+	worker.StartWebhookDispatcher(context.Background(), a.Webhooks, time.Duration(cfg.Webhook.DeliveryIntervalSeconds)*time.Second)
+	if cfg.Interview.SchedulerEnabled {
+		worker.StartInterviewScheduler(context.Background(), a.Scheduler, time.Duration(cfg.Interview.SchedulerIntervalSeconds)*time.Second)
+	}
+	worker.StartTrashPurger(context.Background(), a.Trash, time.Duration(cfg.Trash.PurgeIntervalMinutes)*time.Minute)
+	worker.StartRetentionPurger(context.Background(), a.Retention, time.Duration(cfg.Retention.PurgeIntervalMinutes)*time.Minute)
+	worker.StartShareStatsAggregator(context.Background(), a.ResumeShares, time.Duration(cfg.ShareStats.AggregateIntervalMinutes)*time.Minute)
+
+	port := cfg.App.Port
+	if port == "" {
+		port = "3000"
+	}
+
+	log.Printf("Server starting on port %s", port)
+	return a.Server.Listen(":" + port)
+}
@@ -0,0 +1,57 @@
+package app
+
+import (
+	"time"
+
+	"github.com/raflytch/careerly-server/internal/config"
+	"github.com/raflytch/careerly-server/internal/domain"
+	"github.com/raflytch/careerly-server/internal/handler"
+	"github.com/raflytch/careerly-server/internal/middleware"
+	"github.com/raflytch/careerly-server/internal/service"
+	"github.com/raflytch/careerly-server/pkg/imagekit"
+	"github.com/raflytch/careerly-server/pkg/storage"
+
+	"github.com/google/wire"
+)
+
+// AuthSet covers sign-in, sessions and the user's own account.
+var AuthSet = wire.NewSet(
+	service.NewSessionService,
+	service.NewAuthService,
+	service.NewUserService,
+	provideAccountDeletionService,
+	service.NewCompletenessService,
+	service.NewOnboardingService,
+	service.NewActivityService,
+	middleware.NewAuthMiddleware,
+	provideAuthHandler,
+	handler.NewUserHandler,
+)
+
+func provideAccountDeletionService(
+	cfg *config.Config,
+	deletionRepo domain.AccountDeletionRepository,
+	emailService domain.EmailService,
+	jobs domain.JobScheduler,
+	artifactStorage storage.Storage,
+	regionalStorage regionalArtifactStorage,
+	videos videoStorage,
+	imagekitClient *imagekit.Client,
+) domain.AccountDeletionService {
+	day := 24 * time.Hour
+	return service.NewAccountDeletionService(
+		deletionRepo,
+		emailService,
+		jobs,
+		artifactStorage,
+		regionalStorage,
+		videos,
+		imagekitClient,
+		time.Duration(cfg.AccountPurge.GraceDays)*day,
+		time.Duration(cfg.AccountPurge.NoticeDays)*day,
+	)
+}
+
+func provideAuthHandler(cfg *config.Config, authService domain.AuthService) *handler.AuthHandler {
+	return handler.NewAuthHandler(authService, cfg.Google.FrontendURL)
+}
@@ -0,0 +1,112 @@
+package app
+
+import (
+	"time"
+
+	"github.com/raflytch/careerly-server/internal/config"
+	"github.com/raflytch/careerly-server/internal/domain"
+	"github.com/raflytch/careerly-server/internal/handler"
+	"github.com/raflytch/careerly-server/internal/service"
+	"github.com/raflytch/careerly-server/pkg/midtrans"
+
+	"github.com/google/wire"
+)
+
+// BillingSet covers plans, quotas, payments and subscriptions.
+var BillingSet = wire.NewSet(
+	service.NewPlanService,
+	service.NewPricingService,
+	service.NewAddonService,
+	provideQuotaService,
+	service.NewQuotaOverrideService,
+	provideReferralService,
+	provideTransactionService,
+	service.NewPaymentMethodService,
+	provideOrganizationService,
+	service.NewSubscriptionService,
+	service.NewReconciliationService,
+	service.NewProvisioningService,
+	handler.NewPlanHandler,
+	handler.NewAddonHandler,
+	handler.NewTransactionHandler,
+	handler.NewPaymentMethodHandler,
+	handler.NewSubscriptionHandler,
+	handler.NewReconciliationHandler,
+	handler.NewProvisioningHandler,
+	handler.NewQuotaOverrideHandler,
+	handler.NewReferralHandler,
+	handler.NewOrganizationHandler,
+	providePaymentSimulationHandler,
+)
+
+func provideQuotaService(
+	cfg *config.Config,
+	subscriptionRepo domain.SubscriptionRepository,
+	usageRepo domain.UsageRepository,
+	userRepo domain.UserRepository,
+	addonRepo domain.AddonRepository,
+	overrideRepo domain.QuotaOverrideRepository,
+	orgRepo domain.OrganizationRepository,
+	planRepo domain.PlanRepository,
+	notifications domain.NotificationService,
+) domain.QuotaService {
+	return service.NewQuotaService(subscriptionRepo, usageRepo, userRepo, addonRepo, overrideRepo, orgRepo, planRepo, notifications, cfg.Retention.FreePlanDays)
+}
+
+func provideReferralService(cfg *config.Config, referralRepo domain.ReferralRepository, subscriptionRepo domain.SubscriptionRepository) domain.ReferralService {
+	return service.NewReferralService(referralRepo, subscriptionRepo, cfg.Referral, cfg.App.FrontendURL)
+}
+
+func provideOrganizationService(cfg *config.Config, orgRepo domain.OrganizationRepository, userRepo domain.UserRepository, emailService domain.EmailService) domain.OrganizationService {
+	return service.NewOrganizationService(orgRepo, userRepo, emailService, cfg.App.FrontendURL)
+}
+
+func provideTransactionService(
+	cfg *config.Config,
+	transactionRepo domain.TransactionRepository,
+	planRepo domain.PlanRepository,
+	addonRepo domain.AddonRepository,
+	giftRepo domain.GiftRepository,
+	subscriptionRepo domain.SubscriptionRepository,
+	userRepo domain.UserRepository,
+	provisioningJobRepo domain.ProvisioningJobRepository,
+	notificationRepo domain.PaymentNotificationRepository,
+	paymentMethodRepo domain.PaymentMethodRepository,
+	orgRepo domain.OrganizationRepository,
+	cacheRepo domain.CacheRepository,
+	referralService domain.ReferralService,
+	emailService domain.EmailService,
+	paymentGateway domain.PaymentGateway,
+	webhooks domain.WebhookPublisher,
+	jobs domain.JobEnqueuer,
+) domain.TransactionService {
+	return service.NewTransactionService(
+		transactionRepo,
+		planRepo,
+		addonRepo,
+		giftRepo,
+		subscriptionRepo,
+		userRepo,
+		provisioningJobRepo,
+		notificationRepo,
+		paymentMethodRepo,
+		orgRepo,
+		cacheRepo,
+		referralService,
+		emailService,
+		paymentGateway,
+		webhooks,
+		jobs,
+		time.Duration(cfg.Midtrans.NotificationWindowMinutes)*time.Minute,
+		cfg.Midtrans.RequireSignature || !cfg.Midtrans.IsSandbox,
+	)
+}
+
+// providePaymentSimulationHandler is nil unless payments can be simulated,
+// which keeps the dev routes unregistered.
+func providePaymentSimulationHandler(simulator *midtrans.Simulator, transactionRepo domain.TransactionRepository, transactionService domain.TransactionService) *handler.PaymentSimulationHandler {
+	if simulator == nil {
+		return nil
+	}
+	return handler.NewPaymentSimulationHandler(service.NewPaymentSimulationService(transactionRepo, transactionService, simulator))
+}
@@ -0,0 +1,357 @@
+package app
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"log"
+	"time"
+
+	"github.com/raflytch/careerly-server/internal/config"
+	"github.com/raflytch/careerly-server/internal/database"
+	"github.com/raflytch/careerly-server/internal/domain"
+	"github.com/raflytch/careerly-server/internal/repository"
+	"github.com/raflytch/careerly-server/internal/service"
+	"github.com/raflytch/careerly-server/pkg/fieldcrypt"
+	"github.com/raflytch/careerly-server/pkg/genai"
+	"github.com/raflytch/careerly-server/pkg/geoip"
+	"github.com/raflytch/careerly-server/pkg/imagekit"
+	"github.com/raflytch/careerly-server/pkg/jobqueue"
+	"github.com/raflytch/careerly-server/pkg/jwt"
+	"github.com/raflytch/careerly-server/pkg/mailer"
+	"github.com/raflytch/careerly-server/pkg/midtrans"
+	"github.com/raflytch/careerly-server/pkg/semaphore"
+	"github.com/raflytch/careerly-server/pkg/signedtoken"
+	"github.com/raflytch/careerly-server/pkg/storage"
+
+	"github.com/google/wire"
+	"github.com/redis/go-redis/v9"
+	"github.com/shopspring/decimal"
+)
+
+// InfraSet provides the connections and third-party clients the services
+// are built on. Optional integrations come out nil when unconfigured, which
+// the services treat as the feature being disabled.
+var InfraSet = wire.NewSet(
+	wire.FieldsOf(new(*config.Config), "AIBudget", "JWT", "Google", "ATSCheck", "Referral", "DataRegion", "AILimit"),
+	providePostgres,
+	provideRouter,
+	provideRedis,
+	provideCacheRepository,
+	provideAILimiter,
+	provideJWTManager,
+	provideImageKit,
+	provideGenAI,
+	provideFallbackAI,
+	provideAIClient,
+	provideMidtrans,
+	providePaymentSimulator,
+	providePaymentGateway,
+	provideGeoResolver,
+	provideExchangeRates,
+	provideMailer,
+	provideVideoStorage,
+	provideRegionalArtifactStorage,
+	provideArtifactStorage,
+	provideLocalStorage,
+	providePIICipher,
+	provideJobQueue,
+	wire.Bind(new(domain.JobEnqueuer), new(*jobqueue.Queue)),
+	wire.Bind(new(domain.JobScheduler), new(*jobqueue.Queue)),
+	wire.Bind(new(domain.Transactor), new(*database.Router)),
+	provideSigner,
+)
+
+// videoStorage holds interview video answers. It is a type of its own so
+// it is not mixed up with the artifact storage.
+type videoStorage storage.Storage
+
+// regionalArtifactStorage holds the generated files of users pinned to a
+// data region, keyed by region.
+type regionalArtifactStorage map[string]storage.Storage
+
+func providePostgres(cfg *config.Config) (*sql.DB, func(), error) {
+	db, err := database.NewPostgresConnection(cfg.Database)
+	if err != nil {
+		return nil, nil, err
+	}
+	return db, func() { db.Close() }, nil
+}
+
+func provideRouter(cfg *config.Config, db *sql.DB) (*database.Router, func(), error) {
+	replica, err := database.NewPostgresReplica(cfg.Database)
+	if err != nil {
+		return nil, nil, err
+	}
+	cleanup := func() {}
+	if replica != nil {
+		cleanup = func() { replica.Close() }
+	}
+	return database.NewRouter(db, replica), cleanup, nil
+}
+
+func provideRedis(cfg *config.Config) (*redis.Client, func(), error) {
+	client, err := database.NewRedisConnection(cfg.Redis)
+	if err != nil {
+		return nil, nil, err
+	}
+	return client, func() { client.Close() }, nil
+}
+
+func provideCacheRepository(cfg *config.Config, client *redis.Client) domain.CacheRepository {
+	return repository.NewCacheRepository(context.Background(), client, cfg.Cache)
+}
+
+func provideJWTManager(cfg *config.Config) *jwt.JWTManager {
+	return jwt.NewJWTManager(cfg.JWT.Secret, cfg.JWT.ExpiryHours)
+}
+
+func provideImageKit(cfg *config.Config) *imagekit.Client {
+	return imagekit.NewClient(imagekit.Config{
+		PublicKey:   cfg.ImageKit.PublicKey,
+		PrivateKey:  cfg.ImageKit.PrivateKey,
+		URLEndpoint: cfg.ImageKit.URLEndpoint,
+	})
+}
+
+func aiTimeouts(cfg *config.Config) map[string]time.Duration {
+	return map[string]time.Duration{
+		domain.AIFeatureResumeConversion:    seconds(cfg.Timeout.ResumeEnhanceSeconds),
+		domain.AIFeatureATSAnalysis:         seconds(cfg.Timeout.ATSAnalyzeSeconds),
+		domain.AIFeatureInterviewQuestions:  seconds(cfg.Timeout.InterviewGenerateSeconds),
+		domain.AIFeatureInterviewEvaluation: seconds(cfg.Timeout.InterviewEvaluateSeconds),
+	}
+}
+
+func provideGenAI(cfg *config.Config) *genai.Client {
+	if cfg.GenAI.APIKey == "" {
+		return nil
+	}
+	client, err := genai.NewClient(genai.Config{
+		APIKey:                  cfg.GenAI.APIKey,
+		Model:                   cfg.GenAI.Model,
+		Timeout:                 seconds(cfg.Timeout.AIDefaultSeconds),
+		FeatureTimeouts:         aiTimeouts(cfg),
+		BreakerFailureThreshold: cfg.Breaker.FailureThreshold,
+		BreakerOpenTimeout:      seconds(cfg.Breaker.OpenSeconds),
+	})
+	if err != nil {
+		log.Printf("Warning: Failed to initialize GenAI client: %v", err)
+		return nil
+	}
+	return client
+}
+
+func provideFallbackAI(cfg *config.Config) *genai.OpenAIClient {
+	if cfg.GenAI.FallbackAPIKey == "" {
+		return nil
+	}
+	client := genai.NewOpenAIClient(genai.OpenAIConfig{
+		BaseURL:                 cfg.GenAI.FallbackBaseURL,
+		APIKey:                  cfg.GenAI.FallbackAPIKey,
+		Model:                   cfg.GenAI.FallbackModel,
+		Timeout:                 seconds(cfg.Timeout.AIDefaultSeconds),
+		FeatureTimeouts:         aiTimeouts(cfg),
+		BreakerFailureThreshold: cfg.Breaker.FailureThreshold,
+		BreakerOpenTimeout:      seconds(cfg.Breaker.OpenSeconds),
+	})
+	log.Println("Fallback AI provider initialized")
+	return client
+}
+
+// provideAIClient returns nil when no provider is configured, which
+// services treat as AI being disabled.
+func provideAIClient(genaiClient *genai.Client, fallbackAIClient *genai.OpenAIClient) domain.AIClient {
+	switch {
+	case genaiClient != nil && fallbackAIClient != nil:
+		return genai.NewFallback(genaiClient, fallbackAIClient)
+	case genaiClient != nil:
+		return genaiClient
+	case fallbackAIClient != nil:
+		return fallbackAIClient
+	}
+	return nil
+}
+
+func provideMidtrans(cfg *config.Config) *midtrans.Client {
+	if cfg.Midtrans.ServerKey == "" {
+		log.Println("Warning: Midtrans server key not configured, payment features disabled")
+		return nil
+	}
+	client := midtrans.NewClient(midtrans.Config{
+		ServerKey:               cfg.Midtrans.ServerKey,
+		ClientKey:               cfg.Midtrans.ClientKey,
+		IsSandbox:               cfg.Midtrans.IsSandbox,
+		MerchantID:              cfg.Midtrans.MerchantID,
+		BreakerFailureThreshold: cfg.Breaker.FailureThreshold,
+		BreakerOpenTimeout:      seconds(cfg.Breaker.OpenSeconds),
+	})
+	log.Println("Midtrans client initialized")
+	return client
+}
+
+// providePaymentSimulator lets payments be simulated only in development
+// against the Midtrans sandbox, and is nil everywhere else.
+func providePaymentSimulator(cfg *config.Config, client *midtrans.Client) *midtrans.Simulator {
+	if client == nil || !cfg.Midtrans.IsSandbox || cfg.App.Env != "development" {
+		return nil
+	}
+	log.Println("Warning: Payment simulation enabled at /api/v1/dev/simulate-payment")
+	return midtrans.NewSimulator(client)
+}
+
+// providePaymentGateway only assigns a configured client, so a missing one
+// stays a nil interface. When payments can be simulated the simulator stands
+// in for the client so simulated orders read as settled.
+func providePaymentGateway(client *midtrans.Client, simulator *midtrans.Simulator) domain.PaymentGateway {
+	if simulator != nil {
+		return simulator
+	}
+	if client == nil {
+		return nil
+	}
+	return client
+}
+
+func provideGeoResolver(cfg *config.Config) domain.GeoResolver {
+	if cfg.GeoIP.DatabasePath == "" {
+		return nil
+	}
+	geoDatabase, err := geoip.Open(cfg.GeoIP.DatabasePath)
+	if err != nil {
+		log.Printf("Warning: Failed to load GeoIP database, country detection disabled: %v", err)
+		return nil
+	}
+	log.Println("GeoIP database loaded")
+	return geoDatabase
+}
+
+func provideExchangeRates(cfg *config.Config) (map[string]decimal.Decimal, error) {
+	return service.ParseExchangeRates(cfg.Pricing.ExchangeRates)
+}
+
+func provideMailer(cfg *config.Config) (mailer.Sender, error) {
+	return mailer.New(mailer.Config{
+		Driver:             cfg.Email.Driver,
+		From:               cfg.Email.From,
+		SMTPHost:           cfg.SMTP.Host,
+		SMTPPort:           cfg.SMTP.Port,
+		SMTPUsername:       cfg.SMTP.Username,
+		SMTPPassword:       cfg.SMTP.Password,
+		SendGridAPIKey:     cfg.Email.SendGridAPIKey,
+		SESRegion:          cfg.Email.SESRegion,
+		SESAccessKeyID:     cfg.Email.SESAccessKeyID,
+		SESSecretAccessKey: cfg.Email.SESSecretAccessKey,
+	})
+}
+
+func provideVideoStorage(cfg *config.Config) (videoStorage, error) {
+	if cfg.ImageKit.PrivateKey == "" && cfg.Storage.S3Bucket == "" && cfg.Storage.GCSBucket == "" {
+		log.Println("Warning: File storage not configured, video answers disabled")
+		return nil, nil
+	}
+	return storage.New(storage.Config{
+		Driver:             cfg.Storage.Driver,
+		ImageKitPrivateKey: cfg.ImageKit.PrivateKey,
+		S3Endpoint:         cfg.Storage.S3Endpoint,
+		S3Region:           cfg.Storage.S3Region,
+		S3Bucket:           cfg.Storage.S3Bucket,
+		S3AccessKeyID:      cfg.Storage.S3AccessKeyID,
+		S3SecretAccessKey:  cfg.Storage.S3SecretAccessKey,
+		S3PublicURL:        cfg.Storage.S3PublicURL,
+		GCSBucket:          cfg.Storage.GCSBucket,
+		GCSAccessKeyID:     cfg.Storage.GCSAccessKeyID,
+		GCSSecretAccessKey: cfg.Storage.GCSSecretAccessKey,
+		GCSPublicURL:       cfg.Storage.GCSPublicURL,
+	})
+}
+
+// provideArtifactStorage holds generated files such as resume PDFs.
+func provideArtifactStorage(cfg *config.Config) (storage.Storage, error) {
+	return storage.New(storage.Config{
+		Driver:             cfg.Artifact.Driver,
+		S3Endpoint:         cfg.Storage.S3Endpoint,
+		S3Region:           cfg.Storage.S3Region,
+		S3Bucket:           cfg.Storage.S3Bucket,
+		S3AccessKeyID:      cfg.Storage.S3AccessKeyID,
+		S3SecretAccessKey:  cfg.Storage.S3SecretAccessKey,
+		S3PublicURL:        cfg.Storage.S3PublicURL,
+		GCSBucket:          cfg.Storage.GCSBucket,
+		GCSAccessKeyID:     cfg.Storage.GCSAccessKeyID,
+		GCSSecretAccessKey: cfg.Storage.GCSSecretAccessKey,
+		GCSPublicURL:       cfg.Storage.GCSPublicURL,
+		LocalDir:           cfg.Artifact.LocalDir,
+		LocalBaseURL:       cfg.Artifact.LocalBaseURL,
+		LocalSigningSecret: cfg.JWT.Secret,
+	})
+}
+
+// provideRegionalArtifactStorage opens the S3 bucket of every data region
+// that has one. A region without a bucket has no artifact storage.
+func provideRegionalArtifactStorage(cfg *config.Config) (regionalArtifactStorage, error) {
+	regional := make(regionalArtifactStorage)
+	for name, region := range cfg.DataRegion.Regions {
+		if region.S3Bucket == "" {
+			continue
+		}
+		store, err := storage.New(storage.Config{
+			Driver:            storage.DriverS3,
+			S3Endpoint:        cfg.Storage.S3Endpoint,
+			S3Region:          region.S3Region,
+			S3Bucket:          region.S3Bucket,
+			S3AccessKeyID:     cfg.Storage.S3AccessKeyID,
+			S3SecretAccessKey: cfg.Storage.S3SecretAccessKey,
+		})
+		if err != nil {
+			return nil, fmt.Errorf("data region %s: %w", name, err)
+		}
+		regional[name] = store
+	}
+	return regional, nil
+}
+
+// provideLocalStorage is the artifact storage when it is on local disk,
+// whose files the API serves itself, and nil otherwise.
+func provideLocalStorage(artifactStorage storage.Storage) *storage.LocalStorage {
+	local, _ := artifactStorage.(*storage.LocalStorage)
+	return local
+}
+
+func providePIICipher(cfg *config.Config) (*fieldcrypt.Cipher, error) {
+	cipher, err := fieldcrypt.NewFromSpec(cfg.Encryption.PIIActiveKey, cfg.Encryption.PIIKeys)
+	if err != nil {
+		return nil, err
+	}
+	if cipher == nil {
+		log.Println("Warning: PII_ENCRYPTION_KEYS is not set, resume contact details are stored in plaintext")
+	}
+	return cipher, nil
+}
+
+// provideAILimiter holds a slot a minute past the longer of the AI request
+// timeout and the three minutes a background ATS upload analysis may run,
+// in case whatever holds it never gives it back.
+func provideAILimiter(cfg *config.Config, client *redis.Client) domain.ConcurrencyLimiter {
+	lease := max(seconds(cfg.Timeout.AIRequestSeconds), 3*time.Minute)
+	return semaphore.New(client, "ai_concurrency", lease+time.Minute)
+}
+
+func provideJobQueue(cfg *config.Config, client *redis.Client) *jobqueue.Queue {
+	return jobqueue.New(client, jobqueue.Config{
+		Workers:   cfg.JobQueue.Workers,
+		Timeout:   seconds(cfg.JobQueue.TimeoutSeconds),
+		ClaimIdle: time.Duration(cfg.JobQueue.ClaimIdleMinutes) * time.Minute,
+	})
+}
+
+func provideSigner(cfg *config.Config) *signedtoken.Signer {
+	return signedtoken.New(cfg.JWT.Secret)
+}
+
+func seconds(n int) time.Duration {
+	return time.Duration(n) * time.Second
+}
+
+func megabytes(n int) int64 {
+	return int64(n) * 1024 * 1024
+}
@@ -0,0 +1,83 @@
+package app
+
+import (
+	"time"
+
+	"github.com/raflytch/careerly-server/internal/config"
+	"github.com/raflytch/careerly-server/internal/domain"
+	"github.com/raflytch/careerly-server/internal/graph"
+	"github.com/raflytch/careerly-server/internal/handler"
+	"github.com/raflytch/careerly-server/internal/service"
+	"github.com/raflytch/careerly-server/pkg/circuitbreaker"
+	"github.com/raflytch/careerly-server/pkg/genai"
+	"github.com/raflytch/careerly-server/pkg/mailer"
+	"github.com/raflytch/careerly-server/pkg/midtrans"
+
+	"github.com/google/wire"
+)
+
+// PlatformSet covers the cross-cutting services: email, notifications,
+// auditing, webhooks, jobs, caching and the operator endpoints.
+var PlatformSet = wire.NewSet(
+	provideEmailService,
+	service.NewNotificationService,
+	service.NewAuditService,
+	service.NewWebhookService,
+	wire.Bind(new(domain.WebhookPublisher), new(domain.WebhookService)),
+	service.NewJobService,
+	provideCacheWarmService,
+	provideTrashService,
+	provideRetentionService,
+	service.NewDataTransferService,
+	service.NewDataRegionService,
+	graph.NewResolver,
+	handler.NewEmailHandler,
+	handler.NewNotificationHandler,
+	handler.NewAuditLogHandler,
+	handler.NewWebhookHandler,
+	handler.NewJobHandler,
+	handler.NewCacheHandler,
+	handler.NewDataTransferHandler,
+	handler.NewDataRegionHandler,
+	handler.NewSchemaHandler,
+	handler.NewDocsHandler,
+	handler.NewFileHandler,
+	provideMetricsHandler,
+	provideGraphQLHandler,
+)
+
+func provideEmailService(cfg *config.Config, sender mailer.Sender, emailRepo domain.EmailRepository, userRepo domain.UserRepository) domain.EmailService {
+	return service.NewEmailService(sender, emailRepo, userRepo, cfg.Email.CallbackToken)
+}
+
+func provideCacheWarmService(cfg *config.Config, planService domain.PlanService, userRepo domain.UserRepository, cacheRepo domain.CacheRepository) domain.CacheWarmService {
+	return service.NewCacheWarmService(planService, userRepo, cacheRepo, cfg.CacheWarm.RecentUsers)
+}
+
+func provideTrashService(cfg *config.Config, resumeRepo domain.ResumeRepository, interviewRepo domain.InterviewRepository) domain.TrashService {
+	return service.NewTrashService(resumeRepo, interviewRepo, time.Duration(cfg.Trash.RetentionDays)*24*time.Hour)
+}
+
+func provideRetentionService(cfg *config.Config, atsCheckRepo domain.ATSCheckRepository, interviewRepo domain.InterviewRepository) domain.RetentionService {
+	return service.NewRetentionService(atsCheckRepo, interviewRepo, cfg.Retention.FreePlanDays)
+}
+
+// provideMetricsHandler reports the breakers of whichever external clients
+// are configured.
+func provideMetricsHandler(genaiClient *genai.Client, fallbackAIClient *genai.OpenAIClient, midtransClient *midtrans.Client) *handler.MetricsHandler {
+	var breakers []*circuitbreaker.Breaker
+	if genaiClient != nil {
+		breakers = append(breakers, genaiClient.Breaker())
+	}
+	if fallbackAIClient != nil {
+		breakers = append(breakers, fallbackAIClient.Breaker())
+	}
+	if midtransClient != nil {
+		breakers = append(breakers, midtransClient.Breaker())
+	}
+	return handler.NewMetricsHandler(breakers...)
+}
+
+func provideGraphQLHandler(cfg *config.Config, resolver *graph.Resolver) *handler.GraphQLHandler {
+	return handler.NewGraphQLHandler(graph.NewServer(resolver, cfg.App.Env != "production"))
+}
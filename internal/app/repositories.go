@@ -0,0 +1,48 @@
+package app
+
+import (
+	"github.com/raflytch/careerly-server/internal/repository"
+
+	"github.com/google/wire"
+)
+
+var RepositorySet = wire.NewSet(
+	repository.NewUserRepository,
+	repository.NewPlanRepository,
+	repository.NewAddonRepository,
+	repository.NewGiftRepository,
+	repository.NewSubscriptionRepository,
+	repository.NewUsageRepository,
+	repository.NewResumeRepository,
+	repository.NewInterviewRepository,
+	repository.NewInterviewPackRepository,
+	repository.NewEmailRepository,
+	repository.NewATSCheckRepository,
+	repository.NewTransactionRepository,
+	repository.NewAIUsageRepository,
+	repository.NewProvisioningJobRepository,
+	repository.NewPaymentNotificationRepository,
+	repository.NewReferralRepository,
+	repository.NewAuditLogRepository,
+	repository.NewSessionRepository,
+	repository.NewAuthIdentityRepository,
+	repository.NewInterviewShareRepository,
+	repository.NewStudyPlanRepository,
+	repository.NewResumeShareRepository,
+	repository.NewResumeShareAnalyticsRepository,
+	repository.NewResumeDraftRepository,
+	repository.NewResumeLibraryRepository,
+	repository.NewQuotaOverrideRepository,
+	repository.NewOnboardingRepository,
+	repository.NewActivityRepository,
+	repository.NewWebhookRepository,
+	repository.NewArtifactRepository,
+	repository.NewPromptRepository,
+	repository.NewPromptExperimentRepository,
+	repository.NewAIFeedbackRepository,
+	repository.NewNotificationRepository,
+	repository.NewPaymentMethodRepository,
+	repository.NewOrganizationRepository,
+	repository.NewAccountDeletionRepository,
+	repository.NewQuestionBankRepository,
+)
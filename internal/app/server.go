@@ -0,0 +1,79 @@
+package app
+
+import (
+	"github.com/raflytch/careerly-server/internal/config"
+	"github.com/raflytch/careerly-server/internal/domain"
+	"github.com/raflytch/careerly-server/internal/middleware"
+	"github.com/raflytch/careerly-server/internal/routes"
+	"github.com/raflytch/careerly-server/pkg/response"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/gofiber/fiber/v2/middleware/cors"
+	"github.com/gofiber/fiber/v2/middleware/logger"
+	"github.com/gofiber/fiber/v2/middleware/recover"
+	"github.com/google/wire"
+)
+
+// ServerSet builds the Fiber app with its middleware stack and routes.
+var ServerSet = wire.NewSet(
+	wire.Struct(new(routes.Handlers), "*"),
+	provideMiddlewares,
+	provideFiber,
+)
+
+func provideMiddlewares(cfg *config.Config, authMiddleware *middleware.AuthMiddleware, aiLimiter domain.ConcurrencyLimiter, quotaService domain.QuotaService) routes.Middlewares {
+	return routes.Middlewares{
+		Auth:           authMiddleware,
+		RequestTimeout: middleware.Timeout(seconds(cfg.Timeout.RequestSeconds)),
+		AITimeout:      middleware.Timeout(seconds(cfg.Timeout.AIRequestSeconds)),
+		AILimit:        middleware.AIConcurrency(aiLimiter, quotaService, cfg.AILimit),
+	}
+}
+
+// provideFiber streams request bodies and parses multipart forms on demand,
+// so uploaded files spill to temp files instead of being held in memory.
+func provideFiber(cfg *config.Config, geoResolver domain.GeoResolver, handlers routes.Handlers, middlewares routes.Middlewares) *fiber.App {
+	server := fiber.New(fiber.Config{
+		AppName:                      "Careerly API",
+		ErrorHandler:                 customErrorHandler,
+		BodyLimit:                    bodyLimit(cfg),
+		StreamRequestBody:            true,
+		DisablePreParseMultipartForm: true,
+	})
+
+	server.Use(recover.New())
+	server.Use(middleware.Geo(geoResolver))
+	server.Use(middleware.Locale())
+	server.Use(middleware.BodyLimit(bodyLimit(cfg)))
+	server.Use(logger.New(logger.Config{
+		Format: "[${time}] ${status} - ${latency} ${method} ${path}\n",
+	}))
+	server.Use(cors.New(cors.Config{
+		AllowOrigins:     cfg.CORS.AllowOrigins,
+		AllowHeaders:     "Origin, Content-Type, Accept, Authorization",
+		AllowMethods:     "GET, POST, PUT, DELETE, PATCH, OPTIONS",
+		AllowCredentials: true,
+	}))
+
+	routes.Setup(server, handlers, middlewares)
+	return server
+}
+
+// bodyLimit returns the configured limit, or by default raises Fiber's 4MB
+// so video answers fit, leaving room for the multipart envelope.
+func bodyLimit(cfg *config.Config) int {
+	if cfg.App.BodyLimitMB > 0 {
+		return int(megabytes(cfg.App.BodyLimitMB))
+	}
+	return int(max(megabytes(cfg.Interview.VideoMaxSizeMB)+megabytes(1), fiber.DefaultBodyLimit))
+}
+
+func customErrorHandler(c *fiber.Ctx, err error) error {
+	code := fiber.StatusInternalServerError
+
+	if e, ok := err.(*fiber.Error); ok {
+		code = e.Code
+	}
+
+	return response.Error(c, code, err.Error())
+}
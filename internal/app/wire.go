@@ -0,0 +1,25 @@
+//go:build wireinject
+
+package app
+
+import (
+	"github.com/raflytch/careerly-server/internal/config"
+
+	"github.com/google/wire"
+)
+
+// Initialize builds the whole application from its config. The returned
+// cleanup closes the database and Redis connections.
+func Initialize(cfg *config.Config) (*App, func(), error) {
+	wire.Build(
+		InfraSet,
+		RepositorySet,
+		AuthSet,
+		BillingSet,
+		AIFeatureSet,
+		PlatformSet,
+		ServerSet,
+		wire.Struct(new(App), "*"),
+	)
+	return nil, nil, nil
+}
@@ -0,0 +1,302 @@
+// Code generated by Wire. DO NOT EDIT.
+
+//go:generate go run -mod=mod github.com/google/wire/cmd/wire
+//go:build !wireinject
+// +build !wireinject
+
+package app
+
+import (
+	"github.com/raflytch/careerly-server/internal/config"
+	"github.com/raflytch/careerly-server/internal/graph"
+	"github.com/raflytch/careerly-server/internal/handler"
+	"github.com/raflytch/careerly-server/internal/middleware"
+	"github.com/raflytch/careerly-server/internal/repository"
+	"github.com/raflytch/careerly-server/internal/routes"
+	"github.com/raflytch/careerly-server/internal/service"
+)
+
+// Injectors from wire.go:
+
+// Initialize builds the whole application from its config. The returned
+// cleanup closes the database and Redis connections.
+func Initialize(cfg *config.Config) (*App, func(), error) {
+	geoResolver := provideGeoResolver(cfg)
+	db, cleanup, err := providePostgres(cfg)
+	if err != nil {
+		return nil, nil, err
+	}
+	router, cleanup2, err := provideRouter(cfg, db)
+	if err != nil {
+		cleanup()
+		return nil, nil, err
+	}
+	userRepository := repository.NewUserRepository(router)
+	authIdentityRepository := repository.NewAuthIdentityRepository(db)
+	client, cleanup3, err := provideRedis(cfg)
+	if err != nil {
+		cleanup2()
+		cleanup()
+		return nil, nil, err
+	}
+	cacheRepository := provideCacheRepository(cfg, client)
+	sender, err := provideMailer(cfg)
+	if err != nil {
+		cleanup3()
+		cleanup2()
+		cleanup()
+		return nil, nil, err
+	}
+	emailRepository := repository.NewEmailRepository(db)
+	emailService := provideEmailService(cfg, sender, emailRepository, userRepository)
+	referralRepository := repository.NewReferralRepository(db)
+	subscriptionRepository := repository.NewSubscriptionRepository(db)
+	referralService := provideReferralService(cfg, referralRepository, subscriptionRepository)
+	sessionRepository := repository.NewSessionRepository(db)
+	jwtConfig := cfg.JWT
+	sessionService := service.NewSessionService(sessionRepository, cacheRepository, jwtConfig)
+	auditLogRepository := repository.NewAuditLogRepository(router)
+	auditService := service.NewAuditService(auditLogRepository)
+	dataRegionConfig := cfg.DataRegion
+	accountDeletionRepository := repository.NewAccountDeletionRepository(db, dataRegionConfig)
+	queue := provideJobQueue(cfg, client)
+	storage, err := provideArtifactStorage(cfg)
+	if err != nil {
+		cleanup3()
+		cleanup2()
+		cleanup()
+		return nil, nil, err
+	}
+	appRegionalArtifactStorage, err := provideRegionalArtifactStorage(cfg)
+	if err != nil {
+		cleanup3()
+		cleanup2()
+		cleanup()
+		return nil, nil, err
+	}
+	appVideoStorage, err := provideVideoStorage(cfg)
+	if err != nil {
+		cleanup3()
+		cleanup2()
+		cleanup()
+		return nil, nil, err
+	}
+	imagekitClient := provideImageKit(cfg)
+	accountDeletionService := provideAccountDeletionService(cfg, accountDeletionRepository, emailService, queue, storage, appRegionalArtifactStorage, appVideoStorage, imagekitClient)
+	googleConfig := cfg.Google
+	jwtManager := provideJWTManager(cfg)
+	authService := service.NewAuthService(userRepository, authIdentityRepository, cacheRepository, emailService, referralService, sessionService, auditService, accountDeletionService, googleConfig, jwtConfig, jwtManager)
+	authHandler := provideAuthHandler(cfg, authService)
+	usageRepository := repository.NewUsageRepository(db)
+	userService := service.NewUserService(userRepository, cacheRepository, subscriptionRepository, usageRepository, emailService, auditService, accountDeletionService)
+	cipher, err := providePIICipher(cfg)
+	if err != nil {
+		cleanup3()
+		cleanup2()
+		cleanup()
+		return nil, nil, err
+	}
+	resumeRepository := repository.NewResumeRepository(router, cipher, dataRegionConfig)
+	completenessService := service.NewCompletenessService(resumeRepository)
+	onboardingRepository := repository.NewOnboardingRepository(db)
+	onboardingService := service.NewOnboardingService(onboardingRepository, cacheRepository)
+	activityRepository := repository.NewActivityRepository(router)
+	activityService := service.NewActivityService(activityRepository)
+	userHandler := handler.NewUserHandler(userService, completenessService, onboardingService, activityService, sessionService, imagekitClient)
+	planRepository := repository.NewPlanRepository(db)
+	planService := service.NewPlanService(planRepository, cacheRepository, auditService)
+	v, err := provideExchangeRates(cfg)
+	if err != nil {
+		cleanup3()
+		cleanup2()
+		cleanup()
+		return nil, nil, err
+	}
+	pricingService := service.NewPricingService(v)
+	planHandler := handler.NewPlanHandler(planService, pricingService)
+	addonRepository := repository.NewAddonRepository(db)
+	quotaOverrideRepository := repository.NewQuotaOverrideRepository(db)
+	organizationRepository := repository.NewOrganizationRepository(db)
+	notificationRepository := repository.NewNotificationRepository(db)
+	notificationService := service.NewNotificationService(notificationRepository, userRepository, emailService)
+	quotaService := provideQuotaService(cfg, subscriptionRepository, usageRepository, userRepository, addonRepository, quotaOverrideRepository, organizationRepository, planRepository, notificationService)
+	genaiClient := provideGenAI(cfg)
+	openAIClient := provideFallbackAI(cfg)
+	aiClient := provideAIClient(genaiClient, openAIClient)
+	promptRepository := repository.NewPromptRepository(db)
+	promptExperimentRepository := repository.NewPromptExperimentRepository(db)
+	promptService := service.NewPromptService(promptRepository, promptExperimentRepository, cacheRepository, auditService)
+	webhookRepository := repository.NewWebhookRepository(db)
+	webhookService := service.NewWebhookService(webhookRepository, auditService)
+	artifactRepository := repository.NewArtifactRepository(db)
+	resumeService := provideResumeService(cfg, resumeRepository, quotaService, aiClient, promptService, cacheRepository, webhookService, artifactRepository, storage, appRegionalArtifactStorage)
+	resumeLintService := service.NewResumeLintService(resumeService)
+	resumeHandler := handler.NewResumeHandler(resumeService, resumeLintService, quotaService, imagekitClient)
+	interviewRepository := repository.NewInterviewRepository(router)
+	interviewPackRepository := repository.NewInterviewPackRepository(db)
+	questionBankRepository := repository.NewQuestionBankRepository(db)
+	interviewProgressBroker := service.NewInterviewProgressBroker()
+	concurrencyLimiter := provideAILimiter(cfg, client)
+	interviewService := provideInterviewService(cfg, interviewRepository, interviewPackRepository, questionBankRepository, quotaService, cacheRepository, interviewProgressBroker, aiClient, promptService, webhookService, appVideoStorage, concurrencyLimiter)
+	interviewHandler := provideInterviewHandler(cfg, interviewService, quotaService, interviewProgressBroker)
+	atsCheckRepository := repository.NewATSCheckRepository(router, dataRegionConfig)
+	atsProgressBroker := service.NewATSProgressBroker()
+	atsCheckConfig := cfg.ATSCheck
+	aiLimitConfig := cfg.AILimit
+	atsCheckService := service.NewATSCheckService(atsCheckRepository, quotaService, resumeService, cacheRepository, atsProgressBroker, aiClient, promptService, atsCheckConfig, concurrencyLimiter, aiLimitConfig)
+	atsCheckHandler := handler.NewATSCheckHandler(atsCheckService, quotaService, atsProgressBroker)
+	transactionRepository := repository.NewTransactionRepository(router)
+	giftRepository := repository.NewGiftRepository(db)
+	provisioningJobRepository := repository.NewProvisioningJobRepository(db)
+	paymentNotificationRepository := repository.NewPaymentNotificationRepository(db)
+	paymentMethodRepository := repository.NewPaymentMethodRepository(db, cipher)
+	midtransClient := provideMidtrans(cfg)
+	simulator := providePaymentSimulator(cfg, midtransClient)
+	paymentGateway := providePaymentGateway(midtransClient, simulator)
+	transactionService := provideTransactionService(cfg, transactionRepository, planRepository, addonRepository, giftRepository, subscriptionRepository, userRepository, provisioningJobRepository, paymentNotificationRepository, paymentMethodRepository, organizationRepository, cacheRepository, referralService, emailService, paymentGateway, webhookService, queue)
+	transactionHandler := handler.NewTransactionHandler(transactionService)
+	dataTransferService := service.NewDataTransferService(router, userRepository, resumeRepository, interviewRepository, atsCheckRepository)
+	dataTransferHandler := handler.NewDataTransferHandler(dataTransferService)
+	schemaHandler := handler.NewSchemaHandler()
+	cacheWarmService := provideCacheWarmService(cfg, planService, userRepository, cacheRepository)
+	cacheHandler := handler.NewCacheHandler(cacheWarmService)
+	aiUsageRepository := repository.NewAIUsageRepository(router)
+	aiBudgetConfig := cfg.AIBudget
+	aiUsageService := provideAIUsageService(aiUsageRepository, cacheRepository, aiBudgetConfig, genaiClient, openAIClient)
+	aiUsageHandler := handler.NewAIUsageHandler(aiUsageService)
+	provisioningService := service.NewProvisioningService(provisioningJobRepository, transactionService, auditService)
+	provisioningHandler := handler.NewProvisioningHandler(provisioningService)
+	referralHandler := handler.NewReferralHandler(referralService)
+	auditLogHandler := handler.NewAuditLogHandler(auditService)
+	careerInsightService := service.NewCareerInsightService(resumeRepository, interviewRepository, atsCheckRepository, cacheRepository, aiClient)
+	careerInsightHandler := handler.NewCareerInsightHandler(careerInsightService)
+	metricsHandler := provideMetricsHandler(genaiClient, openAIClient, midtransClient)
+	interviewShareRepository := repository.NewInterviewShareRepository(db)
+	signer := provideSigner(cfg)
+	interviewShareService := provideInterviewShareService(cfg, interviewShareRepository, interviewRepository, signer)
+	interviewShareHandler := handler.NewInterviewShareHandler(interviewShareService)
+	resolver := graph.NewResolver(userService, quotaService, resumeService, interviewService, atsCheckService)
+	graphQLHandler := provideGraphQLHandler(cfg, resolver)
+	docsHandler, err := handler.NewDocsHandler()
+	if err != nil {
+		cleanup3()
+		cleanup2()
+		cleanup()
+		return nil, nil, err
+	}
+	webhookHandler := handler.NewWebhookHandler(webhookService)
+	interviewPackService := service.NewInterviewPackService(interviewPackRepository, auditService)
+	interviewPackHandler := handler.NewInterviewPackHandler(interviewPackService)
+	questionBankService := service.NewQuestionBankService(questionBankRepository)
+	questionBankHandler := handler.NewQuestionBankHandler(questionBankService)
+	emailHandler := handler.NewEmailHandler(emailService)
+	reconciliationService := service.NewReconciliationService(transactionRepository, paymentGateway)
+	reconciliationHandler := handler.NewReconciliationHandler(reconciliationService)
+	addonService := service.NewAddonService(addonRepository, auditService)
+	addonHandler := handler.NewAddonHandler(addonService)
+	subscriptionService := service.NewSubscriptionService(subscriptionRepository, planRepository, giftRepository, userRepository, webhookService, auditService)
+	subscriptionHandler := handler.NewSubscriptionHandler(subscriptionService)
+	localStorage := provideLocalStorage(storage)
+	fileHandler := handler.NewFileHandler(localStorage)
+	resumeShareRepository := repository.NewResumeShareRepository(db)
+	resumeShareAnalyticsRepository := repository.NewResumeShareAnalyticsRepository(db)
+	resumeShareService := provideResumeShareService(cfg, resumeShareRepository, resumeShareAnalyticsRepository, resumeRepository, resumeService, signer)
+	resumeShareHandler := handler.NewResumeShareHandler(resumeShareService)
+	jobService := service.NewJobService(queue, auditService)
+	jobHandler := handler.NewJobHandler(jobService)
+	promptHandler := handler.NewPromptHandler(promptService)
+	aiFeedbackRepository := repository.NewAIFeedbackRepository(db)
+	aiFeedbackService := service.NewAIFeedbackService(aiFeedbackRepository, promptRepository, resumeRepository, interviewRepository, atsCheckRepository)
+	aiFeedbackHandler := handler.NewAIFeedbackHandler(aiFeedbackService)
+	resumeDraftRepository := repository.NewResumeDraftRepository(db, cipher, dataRegionConfig)
+	resumeDraftService := service.NewResumeDraftService(resumeDraftRepository, resumeService)
+	resumeDraftHandler := handler.NewResumeDraftHandler(resumeDraftService)
+	resumeLibraryRepository := repository.NewResumeLibraryRepository(db)
+	resumeLibraryService := service.NewResumeLibraryService(resumeLibraryRepository, resumeService)
+	resumeLibraryHandler := handler.NewResumeLibraryHandler(resumeLibraryService)
+	quotaOverrideService := service.NewQuotaOverrideService(quotaOverrideRepository, userRepository, auditService)
+	quotaOverrideHandler := handler.NewQuotaOverrideHandler(quotaOverrideService)
+	dataRegionService := service.NewDataRegionService(dataRegionConfig, userRepository, cacheRepository, auditService)
+	dataRegionHandler := handler.NewDataRegionHandler(dataRegionService)
+	studyPlanRepository := repository.NewStudyPlanRepository(db)
+	studyPlanService := service.NewStudyPlanService(studyPlanRepository, interviewRepository, aiClient)
+	studyPlanHandler := handler.NewStudyPlanHandler(studyPlanService)
+	promptExperimentService := service.NewPromptExperimentService(promptExperimentRepository, promptRepository, cacheRepository, auditService)
+	promptExperimentHandler := handler.NewPromptExperimentHandler(promptExperimentService)
+	notificationHandler := handler.NewNotificationHandler(notificationService)
+	paymentMethodService := service.NewPaymentMethodService(paymentMethodRepository)
+	paymentMethodHandler := handler.NewPaymentMethodHandler(paymentMethodService)
+	organizationService := provideOrganizationService(cfg, organizationRepository, userRepository, emailService)
+	organizationHandler := handler.NewOrganizationHandler(organizationService, quotaService, transactionService)
+	paymentSimulationHandler := providePaymentSimulationHandler(simulator, transactionRepository, transactionService)
+	handlers := routes.Handlers{
+		Auth:              authHandler,
+		User:              userHandler,
+		Plan:              planHandler,
+		Resume:            resumeHandler,
+		Interview:         interviewHandler,
+		ATSCheck:          atsCheckHandler,
+		Transaction:       transactionHandler,
+		DataTransfer:      dataTransferHandler,
+		Schema:            schemaHandler,
+		Cache:             cacheHandler,
+		AIUsage:           aiUsageHandler,
+		Provisioning:      provisioningHandler,
+		Referral:          referralHandler,
+		AuditLog:          auditLogHandler,
+		CareerInsight:     careerInsightHandler,
+		Metrics:           metricsHandler,
+		InterviewShare:    interviewShareHandler,
+		GraphQL:           graphQLHandler,
+		Docs:              docsHandler,
+		Webhook:           webhookHandler,
+		InterviewPack:     interviewPackHandler,
+		QuestionBank:      questionBankHandler,
+		Email:             emailHandler,
+		Reconciliation:    reconciliationHandler,
+		Addon:             addonHandler,
+		Subscription:      subscriptionHandler,
+		File:              fileHandler,
+		ResumeShare:       resumeShareHandler,
+		Job:               jobHandler,
+		Prompt:            promptHandler,
+		AIFeedback:        aiFeedbackHandler,
+		ResumeDraft:       resumeDraftHandler,
+		ResumeLibrary:     resumeLibraryHandler,
+		QuotaOverride:     quotaOverrideHandler,
+		DataRegion:        dataRegionHandler,
+		StudyPlan:         studyPlanHandler,
+		Experiment:        promptExperimentHandler,
+		Notification:      notificationHandler,
+		PaymentMethod:     paymentMethodHandler,
+		Organization:      organizationHandler,
+		PaymentSimulation: paymentSimulationHandler,
+	}
+	authMiddleware := middleware.NewAuthMiddleware(authService, auditService)
+	middlewares := provideMiddlewares(cfg, authMiddleware, concurrencyLimiter, quotaService)
+	app := provideFiber(cfg, geoResolver, handlers, middlewares)
+	interviewSchedulerService := provideInterviewSchedulerService(cfg, interviewRepository, userRepository, emailService, queue)
+	trashService := provideTrashService(cfg, resumeRepository, interviewRepository)
+	retentionService := provideRetentionService(cfg, atsCheckRepository, interviewRepository)
+	appApp := &App{
+		Config:       cfg,
+		Server:       app,
+		Router:       router,
+		JobQueue:     queue,
+		Transactions: transactionService,
+		Provisioning: provisioningService,
+		Subscription: subscriptionService,
+		Webhooks:     webhookService,
+		Scheduler:    interviewSchedulerService,
+		CacheWarm:    cacheWarmService,
+		Trash:        trashService,
+		Retention:    retentionService,
+		Deletions:    accountDeletionService,
+		ResumeShares: resumeShareService,
+	}
+	return appApp, func() {
+		cleanup3()
+		cleanup2()
+		cleanup()
+	}, nil
+}
@@ -3,19 +3,187 @@ package config
 import (
 	"os"
 	"strconv"
+	"strings"
 )
 
 type Config struct {
-	App      AppConfig
-	Database DatabaseConfig
-	Redis    RedisConfig
-	JWT      JWTConfig
-	Google   GoogleConfig
-	ImageKit ImageKitConfig
-	GenAI    GenAIConfig
-	SMTP     SMTPConfig
-	Midtrans MidtransConfig
-	CORS     CORSConfig
+	App          AppConfig
+	Database     DatabaseConfig
+	Redis        RedisConfig
+	JWT          JWTConfig
+	Google       GoogleConfig
+	ImageKit     ImageKitConfig
+	GenAI        GenAIConfig
+	SMTP         SMTPConfig
+	Email        EmailConfig
+	Midtrans     MidtransConfig
+	CORS         CORSConfig
+	Cache        CacheConfig
+	CacheWarm    CacheWarmConfig
+	AIBudget     AIBudgetConfig
+	AILimit      AILimitConfig
+	Interview    InterviewConfig
+	Referral     ReferralConfig
+	ATSCheck     ATSCheckConfig
+	Timeout      TimeoutConfig
+	Breaker      BreakerConfig
+	Webhook      WebhookConfig
+	Encryption   EncryptionConfig
+	Trash        TrashConfig
+	Retention    RetentionConfig
+	AccountPurge AccountPurgeConfig
+	ShareStats   ShareStatsConfig
+	Storage      StorageConfig
+	Artifact     ArtifactConfig
+	DataRegion   DataRegionConfig
+	Subscription SubscriptionConfig
+	JobQueue     JobQueueConfig
+	GeoIP        GeoIPConfig
+	Pricing      PricingConfig
+}
+
+type BreakerConfig struct {
+	FailureThreshold int
+	OpenSeconds      int
+}
+
+type TimeoutConfig struct {
+	RequestSeconds           int
+	AIRequestSeconds         int
+	AIDefaultSeconds         int
+	ResumeEnhanceSeconds     int
+	ATSAnalyzeSeconds        int
+	InterviewGenerateSeconds int
+	InterviewEvaluateSeconds int
+}
+
+type ATSCheckConfig struct {
+	BatchMaxJobs     int
+	BatchConcurrency int
+}
+
+type WebhookConfig struct {
+	DeliveryIntervalSeconds int
+}
+
+type EncryptionConfig struct {
+	PIIKeys      string
+	PIIActiveKey string
+}
+
+type ReferralConfig struct {
+	RewardDays int
+}
+
+type InterviewConfig struct {
+	SchedulerEnabled         bool
+	SchedulerIntervalSeconds int
+	ReminderLeadMinutes      int
+	VideoMaxSizeMB           int
+	FFmpegPath               string
+}
+
+type StorageConfig struct {
+	Driver             string
+	S3Endpoint         string
+	S3Region           string
+	S3Bucket           string
+	S3AccessKeyID      string
+	S3SecretAccessKey  string
+	S3PublicURL        string
+	GCSBucket          string
+	GCSAccessKeyID     string
+	GCSSecretAccessKey string
+	GCSPublicURL       string
+}
+
+type ArtifactConfig struct {
+	Driver        string
+	LocalDir      string
+	LocalBaseURL  string
+	URLTTLMinutes int
+}
+
+// DataRegionConfig lists the regions a user can be pinned to. Users without
+// a region keep their data in the default schema and buckets.
+type DataRegionConfig struct {
+	Regions map[string]DataRegion
+}
+
+// DataRegion keeps resume content in its own Postgres schema and resume PDFs
+// in its own S3 bucket, reached with the default storage credentials.
+type DataRegion struct {
+	Schema   string
+	S3Region string
+	S3Bucket string
+}
+
+type JobQueueConfig struct {
+	Workers          int
+	TimeoutSeconds   int
+	ClaimIdleMinutes int
+}
+
+type GeoIPConfig struct {
+	DatabasePath string
+}
+
+type PricingConfig struct {
+	// Comma-separated CURRENCY=rate pairs, each the amount of that currency
+	// worth one rupiah.
+	ExchangeRates string
+}
+
+type SubscriptionConfig struct {
+	RolloverIntervalSeconds int
+}
+
+type TrashConfig struct {
+	RetentionDays        int
+	PurgeIntervalMinutes int
+}
+
+type RetentionConfig struct {
+	FreePlanDays         int
+	PurgeIntervalMinutes int
+}
+
+// AccountPurgeConfig controls how long a self-deleted account can be
+// restored before it is permanently removed, and how long before that the
+// user is warned.
+type AccountPurgeConfig struct {
+	GraceDays  int
+	NoticeDays int
+}
+
+type ShareStatsConfig struct {
+	AggregateIntervalMinutes int
+}
+
+type AIBudgetConfig struct {
+	MonthlyBudget        float64
+	InputCostPerMillion  float64
+	OutputCostPerMillion float64
+}
+
+// AILimitConfig caps the AI requests one user may have in flight at once.
+// A limit of zero or less turns the cap off for that tier.
+type AILimitConfig struct {
+	FreeConcurrent    int
+	PaidConcurrent    int
+	RetryAfterSeconds int
+}
+
+type CacheConfig struct {
+	LocalTTLSeconds     int
+	LocalMaxEntries     int
+	InvalidationChannel string
+}
+
+type CacheWarmConfig struct {
+	Enabled         bool
+	IntervalMinutes int
+	RecentUsers     int
 }
 
 type CORSConfig struct {
@@ -23,15 +191,27 @@ type CORSConfig struct {
 }
 
 type MidtransConfig struct {
-	ServerKey  string
-	ClientKey  string
-	IsSandbox  bool
-	MerchantID string
+	ServerKey                 string
+	ClientKey                 string
+	IsSandbox                 bool
+	MerchantID                string
+	ProvisioningRetrySeconds  int
+	ReconcileIntervalSeconds  int
+	ReconcileAfterMinutes     int
+	NotificationWindowMinutes int
+	// Unsigned notifications are only ever accepted in sandbox mode, and
+	// only when this is off.
+	RequireSignature bool
 }
 
 type GenAIConfig struct {
 	APIKey string
 	Model  string
+	// An OpenAI-compatible provider tried when Gemini fails. Disabled when
+	// FallbackAPIKey is empty.
+	FallbackBaseURL string
+	FallbackAPIKey  string
+	FallbackModel   string
 }
 
 type SMTPConfig struct {
@@ -42,6 +222,16 @@ type SMTPConfig struct {
 	From     string
 }
 
+type EmailConfig struct {
+	Driver             string
+	From               string
+	CallbackToken      string
+	SendGridAPIKey     string
+	SESRegion          string
+	SESAccessKeyID     string
+	SESSecretAccessKey string
+}
+
 type ImageKitConfig struct {
 	PublicKey   string
 	PrivateKey  string
@@ -52,6 +242,9 @@ type AppConfig struct {
 	Port        string
 	Env         string
 	FrontendURL string
+	// BodyLimitMB caps request bodies; zero derives it from the largest
+	// upload the API accepts.
+	BodyLimitMB int
 }
 
 type DatabaseConfig struct {
@@ -61,6 +254,11 @@ type DatabaseConfig struct {
 	Password string
 	Name     string
 	SSLMode  string
+
+	// ReplicaDSN points list and count queries at a read replica; empty
+	// keeps every query on the primary.
+	ReplicaDSN          string
+	ReplicaCheckSeconds int
 }
 
 type RedisConfig struct {
@@ -73,6 +271,8 @@ type RedisConfig struct {
 type JWTConfig struct {
 	Secret      string
 	ExpiryHours int
+
+	ImpersonationMinutes int
 }
 
 type GoogleConfig struct {
@@ -90,6 +290,7 @@ func Load() *Config {
 			Port:        getEnv("APP_PORT", "3000"),
 			Env:         getEnv("APP_ENV", "development"),
 			FrontendURL: frontendURL,
+			BodyLimitMB: getEnvAsInt("APP_BODY_LIMIT_MB", 0),
 		},
 		Database: DatabaseConfig{
 			Host:     getEnv("DB_HOST", "localhost"),
@@ -98,6 +299,9 @@ func Load() *Config {
 			Password: getEnv("DB_PASSWORD", "postgres"),
 			Name:     getEnv("DB_NAME", "careerly"),
 			SSLMode:  getEnv("DB_SSLMODE", "disable"),
+
+			ReplicaDSN:          getEnv("DB_REPLICA_DSN", ""),
+			ReplicaCheckSeconds: getEnvAsInt("DB_REPLICA_CHECK_SECONDS", 15),
 		},
 		Redis: RedisConfig{
 			Host:     getEnv("REDIS_HOST", "localhost"),
@@ -108,6 +312,8 @@ func Load() *Config {
 		JWT: JWTConfig{
 			Secret:      getEnv("JWT_SECRET", "secret"),
 			ExpiryHours: getEnvAsInt("JWT_EXPIRY_HOURS", 24),
+
+			ImpersonationMinutes: getEnvAsInt("JWT_IMPERSONATION_MINUTES", 15),
 		},
 		Google: GoogleConfig{
 			ClientID:     getEnv("GOOGLE_CLIENT_ID", ""),
@@ -123,6 +329,10 @@ func Load() *Config {
 		GenAI: GenAIConfig{
 			APIKey: getEnv("GOOGLE_GEN_AI_API_KEY", ""),
 			Model:  getEnv("GOOGLE_GEN_AI_MODEL", "gemini-2.0-flash"),
+
+			FallbackBaseURL: getEnv("AI_FALLBACK_BASE_URL", "https://api.openai.com/v1"),
+			FallbackAPIKey:  getEnv("AI_FALLBACK_API_KEY", ""),
+			FallbackModel:   getEnv("AI_FALLBACK_MODEL", "gpt-4o-mini"),
 		},
 		SMTP: SMTPConfig{
 			Host:     getEnv("SMTP_HOST", "smtp.gmail.com"),
@@ -131,18 +341,154 @@ func Load() *Config {
 			Password: getEnv("SMTP_PASSWORD", ""),
 			From:     getEnv("SMTP_FROM", ""),
 		},
+		Email: EmailConfig{
+			Driver:             getEnv("EMAIL_DRIVER", "smtp"),
+			From:               getEnv("EMAIL_FROM", getEnv("SMTP_FROM", "")),
+			CallbackToken:      getEnv("EMAIL_CALLBACK_TOKEN", ""),
+			SendGridAPIKey:     getEnv("SENDGRID_API_KEY", ""),
+			SESRegion:          getEnv("AWS_SES_REGION", "us-east-1"),
+			SESAccessKeyID:     getEnv("AWS_SES_ACCESS_KEY_ID", ""),
+			SESSecretAccessKey: getEnv("AWS_SES_SECRET_ACCESS_KEY", ""),
+		},
 		Midtrans: MidtransConfig{
-			ServerKey:  getEnv("MIDTRANS_SERVER_KEY", ""),
-			ClientKey:  getEnv("MIDTRANS_CLIENT_KEY", ""),
-			IsSandbox:  getEnvAsBool("MIDTRANS_IS_SANDBOX", true),
-			MerchantID: getEnv("MIDTRANS_MERCHANT_ID", ""),
+			ServerKey:                 getEnv("MIDTRANS_SERVER_KEY", ""),
+			ClientKey:                 getEnv("MIDTRANS_CLIENT_KEY", ""),
+			IsSandbox:                 getEnvAsBool("MIDTRANS_IS_SANDBOX", true),
+			MerchantID:                getEnv("MIDTRANS_MERCHANT_ID", ""),
+			ProvisioningRetrySeconds:  getEnvAsInt("PROVISIONING_RETRY_INTERVAL_SECONDS", 30),
+			ReconcileIntervalSeconds:  getEnvAsInt("PAYMENT_RECONCILE_INTERVAL_SECONDS", 300),
+			ReconcileAfterMinutes:     getEnvAsInt("PAYMENT_RECONCILE_AFTER_MINUTES", 15),
+			NotificationWindowMinutes: getEnvAsInt("MIDTRANS_NOTIFICATION_WINDOW_MINUTES", 1440),
+			RequireSignature:          getEnvAsBool("MIDTRANS_REQUIRE_SIGNATURE", true),
 		},
 		CORS: CORSConfig{
 			AllowOrigins: frontendURL,
 		},
+		Cache: CacheConfig{
+			LocalTTLSeconds:     getEnvAsInt("CACHE_LOCAL_TTL_SECONDS", 30),
+			LocalMaxEntries:     getEnvAsInt("CACHE_LOCAL_MAX_ENTRIES", 10000),
+			InvalidationChannel: getEnv("CACHE_INVALIDATION_CHANNEL", "careerly:cache:invalidate"),
+		},
+		CacheWarm: CacheWarmConfig{
+			Enabled:         getEnvAsBool("CACHE_WARM_ENABLED", true),
+			IntervalMinutes: getEnvAsInt("CACHE_WARM_INTERVAL_MINUTES", 30),
+			RecentUsers:     getEnvAsInt("CACHE_WARM_RECENT_USERS", 100),
+		},
+		AIBudget: AIBudgetConfig{
+			MonthlyBudget:        getEnvAsFloat("AI_MONTHLY_BUDGET_USD", 0),
+			InputCostPerMillion:  getEnvAsFloat("AI_INPUT_COST_PER_MILLION_TOKENS", 0.075),
+			OutputCostPerMillion: getEnvAsFloat("AI_OUTPUT_COST_PER_MILLION_TOKENS", 0.30),
+		},
+		AILimit: AILimitConfig{
+			FreeConcurrent:    getEnvAsInt("AI_CONCURRENT_REQUESTS_FREE", 1),
+			PaidConcurrent:    getEnvAsInt("AI_CONCURRENT_REQUESTS_PAID", 3),
+			RetryAfterSeconds: getEnvAsInt("AI_CONCURRENT_RETRY_AFTER_SECONDS", 5),
+		},
+		Interview: InterviewConfig{
+			SchedulerEnabled:         getEnvAsBool("INTERVIEW_SCHEDULER_ENABLED", true),
+			SchedulerIntervalSeconds: getEnvAsInt("INTERVIEW_SCHEDULER_INTERVAL_SECONDS", 60),
+			ReminderLeadMinutes:      getEnvAsInt("INTERVIEW_REMINDER_LEAD_MINUTES", 15),
+			VideoMaxSizeMB:           getEnvAsInt("INTERVIEW_VIDEO_MAX_SIZE_MB", 15),
+			FFmpegPath:               getEnv("FFMPEG_PATH", ""),
+		},
+		Storage: StorageConfig{
+			Driver:             getEnv("STORAGE_DRIVER", "imagekit"),
+			S3Endpoint:         getEnv("S3_ENDPOINT", ""),
+			S3Region:           getEnv("S3_REGION", "us-east-1"),
+			S3Bucket:           getEnv("S3_BUCKET", ""),
+			S3AccessKeyID:      getEnv("S3_ACCESS_KEY_ID", ""),
+			S3SecretAccessKey:  getEnv("S3_SECRET_ACCESS_KEY", ""),
+			S3PublicURL:        getEnv("S3_PUBLIC_URL", ""),
+			GCSBucket:          getEnv("GCS_BUCKET", ""),
+			GCSAccessKeyID:     getEnv("GCS_HMAC_ACCESS_ID", ""),
+			GCSSecretAccessKey: getEnv("GCS_HMAC_SECRET", ""),
+			GCSPublicURL:       getEnv("GCS_PUBLIC_URL", ""),
+		},
+		Artifact: ArtifactConfig{
+			Driver:        getEnv("ARTIFACT_STORAGE_DRIVER", "local"),
+			LocalDir:      getEnv("ARTIFACT_LOCAL_DIR", "./storage/artifacts"),
+			LocalBaseURL:  getEnv("ARTIFACT_LOCAL_BASE_URL", "http://localhost:"+getEnv("APP_PORT", "3000")+"/api/v1/files"),
+			URLTTLMinutes: getEnvAsInt("ARTIFACT_URL_TTL_MINUTES", 15),
+		},
+		DataRegion: loadDataRegions(),
+		Trash: TrashConfig{
+			RetentionDays:        getEnvAsInt("TRASH_RETENTION_DAYS", 30),
+			PurgeIntervalMinutes: getEnvAsInt("TRASH_PURGE_INTERVAL_MINUTES", 60),
+		},
+		Retention: RetentionConfig{
+			FreePlanDays:         getEnvAsInt("RETENTION_FREE_PLAN_DAYS", 90),
+			PurgeIntervalMinutes: getEnvAsInt("RETENTION_PURGE_INTERVAL_MINUTES", 360),
+		},
+		AccountPurge: AccountPurgeConfig{
+			GraceDays:  getEnvAsInt("ACCOUNT_PURGE_GRACE_DAYS", 30),
+			NoticeDays: getEnvAsInt("ACCOUNT_PURGE_NOTICE_DAYS", 7),
+		},
+		ShareStats: ShareStatsConfig{
+			AggregateIntervalMinutes: getEnvAsInt("SHARE_STATS_AGGREGATE_INTERVAL_MINUTES", 60),
+		},
+		JobQueue: JobQueueConfig{
+			Workers:          getEnvAsInt("JOB_QUEUE_WORKERS", 4),
+			TimeoutSeconds:   getEnvAsInt("JOB_QUEUE_TIMEOUT_SECONDS", 300),
+			ClaimIdleMinutes: getEnvAsInt("JOB_QUEUE_CLAIM_IDLE_MINUTES", 10),
+		},
+		GeoIP: GeoIPConfig{
+			DatabasePath: getEnv("GEOIP_DATABASE_PATH", ""),
+		},
+		Pricing: PricingConfig{
+			ExchangeRates: getEnv("PRICING_EXCHANGE_RATES", ""),
+		},
+		Subscription: SubscriptionConfig{
+			RolloverIntervalSeconds: getEnvAsInt("SUBSCRIPTION_ROLLOVER_INTERVAL_SECONDS", 300),
+		},
+		Referral: ReferralConfig{
+			RewardDays: getEnvAsInt("REFERRAL_REWARD_DAYS", 7),
+		},
+		ATSCheck: ATSCheckConfig{
+			BatchMaxJobs:     getEnvAsInt("ATS_BATCH_MAX_JOBS", 5),
+			BatchConcurrency: getEnvAsInt("ATS_BATCH_CONCURRENCY", 3),
+		},
+		Webhook: WebhookConfig{
+			DeliveryIntervalSeconds: getEnvAsInt("WEBHOOK_DELIVERY_INTERVAL_SECONDS", 10),
+		},
+		Encryption: EncryptionConfig{
+			PIIKeys:      getEnv("PII_ENCRYPTION_KEYS", ""),
+			PIIActiveKey: getEnv("PII_ENCRYPTION_ACTIVE_KEY", ""),
+		},
+		Breaker: BreakerConfig{
+			FailureThreshold: getEnvAsInt("CIRCUIT_BREAKER_FAILURE_THRESHOLD", 5),
+			OpenSeconds:      getEnvAsInt("CIRCUIT_BREAKER_OPEN_SECONDS", 30),
+		},
+		Timeout: TimeoutConfig{
+			RequestSeconds:           getEnvAsInt("REQUEST_TIMEOUT_SECONDS", 30),
+			AIRequestSeconds:         getEnvAsInt("AI_REQUEST_TIMEOUT_SECONDS", 150),
+			AIDefaultSeconds:         getEnvAsInt("AI_TIMEOUT_SECONDS", 60),
+			ResumeEnhanceSeconds:     getEnvAsInt("AI_RESUME_ENHANCE_TIMEOUT_SECONDS", 45),
+			ATSAnalyzeSeconds:        getEnvAsInt("AI_ATS_ANALYZE_TIMEOUT_SECONDS", 60),
+			InterviewGenerateSeconds: getEnvAsInt("AI_INTERVIEW_GENERATE_TIMEOUT_SECONDS", 45),
+			InterviewEvaluateSeconds: getEnvAsInt("AI_INTERVIEW_EVALUATE_TIMEOUT_SECONDS", 90),
+		},
 	}
 }
 
+// loadDataRegions reads DATA_REGIONS, a comma-separated list of region
+// names, and the DATA_REGION_<NAME>_* settings of each.
+func loadDataRegions() DataRegionConfig {
+	regions := make(map[string]DataRegion)
+	for _, name := range strings.Split(getEnv("DATA_REGIONS", ""), ",") {
+		name = strings.ToLower(strings.TrimSpace(name))
+		if name == "" {
+			continue
+		}
+		prefix := "DATA_REGION_" + strings.ToUpper(name) + "_"
+		regions[name] = DataRegion{
+			Schema:   getEnv(prefix+"SCHEMA", name),
+			S3Region: getEnv(prefix+"S3_REGION", getEnv("S3_REGION", "us-east-1")),
+			S3Bucket: getEnv(prefix+"S3_BUCKET", ""),
+		}
+	}
+	return DataRegionConfig{Regions: regions}
+}
+
 func getEnv(key, defaultValue string) string {
 	if value := os.Getenv(key); value != "" {
 		return value
@@ -167,3 +513,12 @@ func getEnvAsBool(key string, defaultValue bool) bool {
 	}
 	return defaultValue
 }
+
+func getEnvAsFloat(key string, defaultValue float64) float64 {
+	if value := os.Getenv(key); value != "" {
+		if floatValue, err := strconv.ParseFloat(value, 64); err == nil {
+			return floatValue
+		}
+	}
+	return defaultValue
+}
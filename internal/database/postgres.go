@@ -11,19 +11,38 @@ import (
 
 func NewPostgresConnection(cfg config.DatabaseConfig) (*sql.DB, error) {
 	dsn := fmt.Sprintf(
-		"host=%s port=%s user=%s password=%s dbname=%s sslmode=%s",
+		"host=%s port=%s user=%s password=%s dbname=%s sslmode=%s timezone=UTC",
 		cfg.Host, cfg.Port, cfg.User, cfg.Password, cfg.Name, cfg.SSLMode,
 	)
 
-	db, err := sql.Open("postgres", dsn)
+	db, err := openPostgres(dsn)
 	if err != nil {
-		return nil, fmt.Errorf("failed to open database connection: %w", err)
+		return nil, err
 	}
 
 	if err := db.Ping(); err != nil {
 		return nil, fmt.Errorf("failed to ping database: %w", err)
 	}
 
+	return db, nil
+}
+
+// NewPostgresReplica opens the read replica without pinging it, so a replica
+// that is down at startup does not stop the server; the Router's health
+// checks bring it into rotation once it answers.
+func NewPostgresReplica(cfg config.DatabaseConfig) (*sql.DB, error) {
+	if cfg.ReplicaDSN == "" {
+		return nil, nil
+	}
+	return openPostgres(cfg.ReplicaDSN)
+}
+
+func openPostgres(dsn string) (*sql.DB, error) {
+	db, err := sql.Open("postgres", dsn)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open database connection: %w", err)
+	}
+
 	db.SetMaxOpenConns(25)
 	db.SetMaxIdleConns(5)
 
@@ -0,0 +1,159 @@
+package database
+
+import (
+	"context"
+	"database/sql"
+	"database/sql/driver"
+	"errors"
+	"log"
+	"net"
+	"sync/atomic"
+)
+
+type primaryKey struct{}
+
+// WithPrimary pins every read made with ctx to the primary, for work such as
+// payment webhooks that must not act on replica lag.
+func WithPrimary(ctx context.Context) context.Context {
+	return context.WithValue(ctx, primaryKey{}, true)
+}
+
+func pinnedToPrimary(ctx context.Context) bool {
+	pinned, _ := ctx.Value(primaryKey{}).(bool)
+	return pinned
+}
+
+type txKey struct{}
+
+func txFromContext(ctx context.Context) *sql.Tx {
+	tx, _ := ctx.Value(txKey{}).(*sql.Tx)
+	return tx
+}
+
+// Router stands in for *sql.DB in repositories that serve list endpoints.
+// The *sql.DB methods all go to the primary; QueryReadContext and
+// QueryRowReadContext go to the replica while it is configured and healthy.
+// Within InTx every query made with its context runs in its transaction.
+type Router struct {
+	primary *sql.DB
+	replica *sql.DB
+
+	replicaUp atomic.Bool
+}
+
+// NewRouter wraps the primary and an optional replica. The replica takes no
+// reads until the first CheckReplica succeeds.
+func NewRouter(primary, replica *sql.DB) *Router {
+	return &Router{primary: primary, replica: replica}
+}
+
+func (r *Router) ExecContext(ctx context.Context, query string, args ...interface{}) (sql.Result, error) {
+	if tx := txFromContext(ctx); tx != nil {
+		return tx.ExecContext(ctx, query, args...)
+	}
+	return r.primary.ExecContext(ctx, query, args...)
+}
+
+func (r *Router) QueryContext(ctx context.Context, query string, args ...interface{}) (*sql.Rows, error) {
+	if tx := txFromContext(ctx); tx != nil {
+		return tx.QueryContext(ctx, query, args...)
+	}
+	return r.primary.QueryContext(ctx, query, args...)
+}
+
+func (r *Router) QueryRowContext(ctx context.Context, query string, args ...interface{}) *sql.Row {
+	if tx := txFromContext(ctx); tx != nil {
+		return tx.QueryRowContext(ctx, query, args...)
+	}
+	return r.primary.QueryRowContext(ctx, query, args...)
+}
+
+func (r *Router) BeginTx(ctx context.Context, opts *sql.TxOptions) (*sql.Tx, error) {
+	return r.primary.BeginTx(ctx, opts)
+}
+
+// InTx runs fn in one transaction on the primary, so writes made through
+// several repositories sharing this Router succeed or fail together. The
+// transaction commits when fn returns nil. Calling InTx inside fn joins the
+// transaction already open.
+func (r *Router) InTx(ctx context.Context, fn func(ctx context.Context) error) error {
+	if txFromContext(ctx) != nil {
+		return fn(ctx)
+	}
+
+	tx, err := r.primary.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	if err := fn(context.WithValue(ctx, txKey{}, tx)); err != nil {
+		return err
+	}
+	return tx.Commit()
+}
+
+// QueryReadContext runs a read that tolerates replica lag. A connection
+// failure on the replica takes it out of rotation and retries on the primary.
+func (r *Router) QueryReadContext(ctx context.Context, query string, args ...interface{}) (*sql.Rows, error) {
+	if tx := txFromContext(ctx); tx != nil {
+		return tx.QueryContext(ctx, query, args...)
+	}
+	db := r.reader(ctx)
+	rows, err := db.QueryContext(ctx, query, args...)
+	if err != nil && db != r.primary && isConnectionError(err) {
+		r.markReplicaDown(err)
+		return r.primary.QueryContext(ctx, query, args...)
+	}
+	return rows, err
+}
+
+// QueryRowReadContext is the single-row form of QueryReadContext. Its error
+// only surfaces on Scan, so it relies on CheckReplica for failover.
+func (r *Router) QueryRowReadContext(ctx context.Context, query string, args ...interface{}) *sql.Row {
+	if tx := txFromContext(ctx); tx != nil {
+		return tx.QueryRowContext(ctx, query, args...)
+	}
+	return r.reader(ctx).QueryRowContext(ctx, query, args...)
+}
+
+// CheckReplica pings the replica and puts it in or out of rotation.
+func (r *Router) CheckReplica(ctx context.Context) {
+	if r.replica == nil {
+		return
+	}
+
+	if err := r.replica.PingContext(ctx); err != nil {
+		r.markReplicaDown(err)
+		return
+	}
+
+	if !r.replicaUp.Swap(true) {
+		log.Printf("Database replica is available, routing reads to it")
+	}
+}
+
+func (r *Router) HasReplica() bool {
+	return r.replica != nil
+}
+
+func (r *Router) reader(ctx context.Context) *sql.DB {
+	if r.replica == nil || !r.replicaUp.Load() || pinnedToPrimary(ctx) {
+		return r.primary
+	}
+	return r.replica
+}
+
+func (r *Router) markReplicaDown(err error) {
+	if r.replicaUp.Swap(false) {
+		log.Printf("Database replica unavailable, routing reads to primary: %v", err)
+	}
+}
+
+func isConnectionError(err error) bool {
+	if errors.Is(err, driver.ErrBadConn) {
+		return true
+	}
+	var netErr net.Error
+	return errors.As(err, &netErr)
+}
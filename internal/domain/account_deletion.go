@@ -0,0 +1,47 @@
+package domain
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// AccountDeletion is a self-deleted account waiting out its grace period.
+// The row exists only while the account can still be restored.
+type AccountDeletion struct {
+	UserID      uuid.UUID `json:"user_id"`
+	NoticeEmail string    `json:"notice_email"`
+	PurgeAt     time.Time `json:"purge_at"`
+	PurgeJobID  string    `json:"purge_job_id"`
+	NoticeJobID string    `json:"notice_job_id"`
+	CreatedAt   time.Time `json:"created_at"`
+}
+
+// AccountFiles are what a user has in object storage, which the database
+// purge cannot reach. Images are only known by their URL.
+type AccountFiles struct {
+	Artifacts []Artifact
+	VideoIDs  []string
+	ImageURLs []string
+}
+
+// ImageRemover deletes uploaded images by the URL they are served at.
+type ImageRemover interface {
+	DeleteFileByURL(ctx context.Context, fileURL string) error
+}
+
+type AccountDeletionRepository interface {
+	Upsert(ctx context.Context, deletion *AccountDeletion) error
+	FindByUserID(ctx context.Context, userID uuid.UUID) (*AccountDeletion, error)
+	Delete(ctx context.Context, userID uuid.UUID) (bool, error)
+	FindPurgeFiles(ctx context.Context, userID uuid.UUID, now time.Time) (*AccountFiles, error)
+	Purge(ctx context.Context, userID uuid.UUID, now time.Time) (bool, error)
+}
+
+type AccountDeletionService interface {
+	Schedule(ctx context.Context, user *User) (*AccountDeletion, error)
+	Cancel(ctx context.Context, userID uuid.UUID) error
+	SendNotice(ctx context.Context, userID uuid.UUID) error
+	Purge(ctx context.Context, userID uuid.UUID) error
+}
@@ -0,0 +1,40 @@
+package domain
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/shopspring/decimal"
+)
+
+const (
+	ActivityResumeCreated      = "resume_created"
+	ActivityResumeUpdated      = "resume_updated"
+	ActivityATSCheckCompleted  = "ats_check_completed"
+	ActivityInterviewCompleted = "interview_completed"
+	ActivityPaymentSucceeded   = "payment_succeeded"
+)
+
+type Activity struct {
+	Type       string           `json:"type"`
+	ResourceID uuid.UUID        `json:"resource_id"`
+	Title      string           `json:"title"`
+	Score      *float64         `json:"score,omitempty"`
+	Amount     *decimal.Decimal `json:"amount,omitempty"`
+	OccurredAt time.Time        `json:"occurred_at"`
+}
+
+type PaginatedActivities struct {
+	Activities []Activity `json:"activities"`
+	Pagination Pagination `json:"pagination"`
+}
+
+type ActivityRepository interface {
+	FindByUserID(ctx context.Context, userID uuid.UUID, limit, offset int) ([]Activity, error)
+	CountByUserID(ctx context.Context, userID uuid.UUID) (int64, error)
+}
+
+type ActivityService interface {
+	GetFeed(ctx context.Context, userID uuid.UUID, page, limit int) (*PaginatedActivities, error)
+}
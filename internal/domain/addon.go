@@ -0,0 +1,74 @@
+package domain
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/shopspring/decimal"
+)
+
+type Addon struct {
+	ID          uuid.UUID       `json:"id"`
+	Name        string          `json:"name"`
+	DisplayName string          `json:"display_name"`
+	Feature     FeatureType     `json:"feature"`
+	Quantity    int             `json:"quantity"`
+	Price       decimal.Decimal `json:"price"`
+	IsActive    bool            `json:"is_active"`
+	CreatedAt   time.Time       `json:"created_at"`
+	DeletedAt   *time.Time      `json:"deleted_at,omitempty"`
+}
+
+type AddonCredit struct {
+	ID            uuid.UUID   `json:"id"`
+	UserID        uuid.UUID   `json:"user_id"`
+	AddonID       uuid.UUID   `json:"addon_id"`
+	TransactionID uuid.UUID   `json:"transaction_id"`
+	Feature       FeatureType `json:"feature"`
+	Quantity      int         `json:"quantity"`
+	PeriodMonth   time.Time   `json:"period_month"`
+	CreatedAt     time.Time   `json:"created_at"`
+}
+
+type CreateAddonRequest struct {
+	Name        string          `json:"name" validate:"required,min=2,max=50"`
+	DisplayName string          `json:"display_name" validate:"required,min=2,max=100"`
+	Feature     FeatureType     `json:"feature" validate:"required,oneof=resume ats_check interview"`
+	Quantity    int             `json:"quantity" validate:"required,min=1"`
+	Price       decimal.Decimal `json:"price"`
+	IsActive    *bool           `json:"is_active"`
+}
+
+type UpdateAddonRequest struct {
+	Name        *string          `json:"name" validate:"omitempty,min=2,max=50"`
+	DisplayName *string          `json:"display_name" validate:"omitempty,min=2,max=100"`
+	Quantity    *int             `json:"quantity" validate:"omitempty,min=1"`
+	Price       *decimal.Decimal `json:"price"`
+	IsActive    *bool            `json:"is_active"`
+}
+
+type PaginatedAddons struct {
+	Addons     []Addon    `json:"addons"`
+	Pagination Pagination `json:"pagination"`
+}
+
+type AddonRepository interface {
+	Create(ctx context.Context, addon *Addon) error
+	FindByID(ctx context.Context, id uuid.UUID) (*Addon, error)
+	FindByName(ctx context.Context, name string) (*Addon, error)
+	FindAll(ctx context.Context, limit, offset int, includeInactive bool) ([]Addon, error)
+	Count(ctx context.Context, includeInactive bool) (int64, error)
+	Update(ctx context.Context, addon *Addon) error
+	SoftDelete(ctx context.Context, id uuid.UUID) error
+	CreateCredit(ctx context.Context, credit *AddonCredit) (bool, error)
+	SumCredits(ctx context.Context, userID uuid.UUID, feature FeatureType, periodMonth time.Time) (int, error)
+}
+
+type AddonService interface {
+	Create(ctx context.Context, req *CreateAddonRequest) (*Addon, error)
+	GetByID(ctx context.Context, id uuid.UUID) (*Addon, error)
+	GetAll(ctx context.Context, page, limit int, includeInactive bool) (*PaginatedAddons, error)
+	Update(ctx context.Context, id uuid.UUID, req *UpdateAddonRequest) (*Addon, error)
+	Delete(ctx context.Context, id uuid.UUID) error
+}
@@ -0,0 +1,24 @@
+package domain
+
+import (
+	"context"
+
+	"github.com/raflytch/careerly-server/pkg/genai"
+)
+
+type AIClient interface {
+	Available() bool
+	GenerateText(ctx context.Context, prompt string) (string, error)
+	GenerateTextWithSystemPrompt(ctx context.Context, systemPrompt, userPrompt string) (string, error)
+	GenerateFromFile(ctx context.Context, file genai.File, prompt string) (string, error)
+	GenerateFromFileWithSystemPrompt(ctx context.Context, file genai.File, systemPrompt, userPrompt string) (string, error)
+	GenerateJSONFromData(ctx context.Context, data []byte, mimeType, prompt string) (string, error)
+	GenerateJSON(ctx context.Context, prompt string) (string, error)
+	GenerateJSONWithSystemPrompt(ctx context.Context, systemPrompt, userPrompt string) (string, error)
+}
+
+var (
+	_ AIClient = (*genai.Client)(nil)
+	_ AIClient = (*genai.OpenAIClient)(nil)
+	_ AIClient = (*genai.Fallback)(nil)
+)
@@ -0,0 +1,67 @@
+package domain
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type AIFeedbackFeature string
+
+const (
+	AIFeedbackResumeEnhancement AIFeedbackFeature = "resume_enhancement"
+	AIFeedbackInterviewFeedback AIFeedbackFeature = "interview_feedback"
+	AIFeedbackATSAnalysis       AIFeedbackFeature = "ats_analysis"
+)
+
+type AIFeedbackRating string
+
+const (
+	AIFeedbackThumbsUp   AIFeedbackRating = "up"
+	AIFeedbackThumbsDown AIFeedbackRating = "down"
+)
+
+type AIFeedback struct {
+	ID            uuid.UUID         `json:"id"`
+	UserID        uuid.UUID         `json:"user_id"`
+	Feature       AIFeedbackFeature `json:"feature"`
+	EntityID      uuid.UUID         `json:"entity_id"`
+	Rating        AIFeedbackRating  `json:"rating"`
+	Comment       *string           `json:"comment,omitempty"`
+	PromptVersion *int              `json:"prompt_version,omitempty"`
+	CreatedAt     time.Time         `json:"created_at"`
+	UpdatedAt     time.Time         `json:"updated_at"`
+}
+
+type AIFeedbackRequest struct {
+	Rating  AIFeedbackRating `json:"rating" validate:"required,oneof=up down"`
+	Comment *string          `json:"comment" validate:"omitempty,max=1000"`
+}
+
+type AIFeedbackSummary struct {
+	Feature       AIFeedbackFeature `json:"feature"`
+	PromptVersion *int              `json:"prompt_version"`
+	Ratings       int64             `json:"ratings"`
+	ThumbsUp      int64             `json:"thumbs_up"`
+	ThumbsDown    int64             `json:"thumbs_down"`
+	Comments      int64             `json:"comments"`
+	Satisfaction  float64           `json:"satisfaction"`
+}
+
+type AIQualityReport struct {
+	From            time.Time           `json:"from"`
+	To              time.Time           `json:"to"`
+	ByFeature       []AIFeedbackSummary `json:"by_feature"`
+	ByPromptVersion []AIFeedbackSummary `json:"by_prompt_version"`
+}
+
+type AIFeedbackRepository interface {
+	Upsert(ctx context.Context, feedback *AIFeedback) error
+	Summarize(ctx context.Context, from, to time.Time, byPromptVersion bool) ([]AIFeedbackSummary, error)
+}
+
+type AIFeedbackService interface {
+	Submit(ctx context.Context, userID uuid.UUID, feature AIFeedbackFeature, entityID uuid.UUID, req *AIFeedbackRequest) (*AIFeedback, error)
+	GetQualityReport(ctx context.Context, from, to time.Time) (*AIQualityReport, error)
+}
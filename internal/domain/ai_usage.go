@@ -0,0 +1,103 @@
+package domain
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/shopspring/decimal"
+)
+
+const (
+	AIFeatureResumeConversion    = "resume_conversion"
+	AIFeatureInterviewQuestions  = "interview_questions"
+	AIFeatureInterviewEvaluation = "interview_evaluation"
+	AIFeatureATSAnalysis         = "ats_analysis"
+	AIFeatureCareerInsights      = "career_insights"
+	AIFeatureResumeOptimization  = "resume_optimization"
+	AIFeatureAnswerProvenance    = "answer_provenance"
+	AIFeatureVideoTranscription  = "video_transcription"
+	AIFeatureBulletGeneration    = "bullet_generation"
+	AIFeatureStudyPlan           = "study_plan"
+)
+
+type AIUsage struct {
+	ID               uuid.UUID       `json:"id"`
+	UserID           *uuid.UUID      `json:"user_id"`
+	Feature          string          `json:"feature"`
+	Model            string          `json:"model"`
+	PromptTokens     int             `json:"prompt_tokens"`
+	CompletionTokens int             `json:"completion_tokens"`
+	TotalTokens      int             `json:"total_tokens"`
+	EstimatedCost    decimal.Decimal `json:"estimated_cost"`
+	LatencyMs        int64           `json:"latency_ms"`
+	QuotaCost        int             `json:"quota_cost"`
+	Success          bool            `json:"success"`
+	ErrorMessage     *string         `json:"error_message,omitempty"`
+	CreatedAt        time.Time       `json:"created_at"`
+}
+
+type AIUsageSummary struct {
+	Feature          string          `json:"feature,omitempty"`
+	Calls            int64           `json:"calls"`
+	FailedCalls      int64           `json:"failed_calls"`
+	PromptTokens     int64           `json:"prompt_tokens"`
+	CompletionTokens int64           `json:"completion_tokens"`
+	TotalTokens      int64           `json:"total_tokens"`
+	EstimatedCost    decimal.Decimal `json:"estimated_cost"`
+	AvgLatencyMs     float64         `json:"avg_latency_ms"`
+}
+
+type AIUsageReport struct {
+	From            time.Time        `json:"from"`
+	To              time.Time        `json:"to"`
+	Totals          AIUsageSummary   `json:"totals"`
+	ByFeature       []AIUsageSummary `json:"by_feature"`
+	MonthToDateCost decimal.Decimal  `json:"month_to_date_cost"`
+	MonthlyBudget   decimal.Decimal  `json:"monthly_budget"`
+	BudgetExceeded  bool             `json:"budget_exceeded"`
+}
+
+type PaginatedAIUsage struct {
+	Logs       []AIUsage  `json:"logs"`
+	Pagination Pagination `json:"pagination"`
+}
+
+type AIHistoryStatus string
+
+const (
+	AIHistoryStatusSuccess AIHistoryStatus = "success"
+	AIHistoryStatusFailed  AIHistoryStatus = "failed"
+)
+
+type AIHistoryEntry struct {
+	ID        uuid.UUID       `json:"id"`
+	Feature   string          `json:"feature"`
+	Status    AIHistoryStatus `json:"status"`
+	QuotaCost int             `json:"quota_cost"`
+	LatencyMs int64           `json:"latency_ms"`
+	CreatedAt time.Time       `json:"created_at"`
+}
+
+type PaginatedAIHistory struct {
+	Entries    []AIHistoryEntry `json:"entries"`
+	Pagination Pagination       `json:"pagination"`
+}
+
+type AIUsageRepository interface {
+	Create(ctx context.Context, usage *AIUsage) error
+	FindAll(ctx context.Context, feature string, limit, offset int) ([]AIUsage, error)
+	Count(ctx context.Context, feature string) (int64, error)
+	FindByUserID(ctx context.Context, userID uuid.UUID, feature string, limit, offset int) ([]AIUsage, error)
+	CountByUserID(ctx context.Context, userID uuid.UUID, feature string) (int64, error)
+	SummarizeByFeature(ctx context.Context, from, to time.Time) ([]AIUsageSummary, error)
+	SumCostSince(ctx context.Context, since time.Time) (decimal.Decimal, error)
+}
+
+type AIUsageService interface {
+	Record(ctx context.Context, usage *AIUsage) error
+	CheckBudget(ctx context.Context) error
+	GetReport(ctx context.Context, from, to time.Time) (*AIUsageReport, error)
+	GetLogs(ctx context.Context, feature string, page, limit int) (*PaginatedAIUsage, error)
+	GetUserHistory(ctx context.Context, userID uuid.UUID, feature string, page, limit int) (*PaginatedAIHistory, error)
+}
@@ -0,0 +1,32 @@
+package domain
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+const ArtifactResumePDF = "resume_pdf"
+
+type Artifact struct {
+	EntityType  string    `json:"entity_type"`
+	EntityID    uuid.UUID `json:"entity_id"`
+	Version     string    `json:"version"`
+	Driver      string    `json:"driver"`
+	Region      string    `json:"region,omitempty"`
+	StorageKey  string    `json:"storage_key"`
+	ContentType string    `json:"content_type"`
+	Size        int64     `json:"size"`
+	CreatedAt   time.Time `json:"created_at"`
+}
+
+type ArtifactLink struct {
+	URL       string    `json:"url"`
+	ExpiresAt time.Time `json:"expires_at"`
+}
+
+type ArtifactRepository interface {
+	Find(ctx context.Context, entityType string, entityID uuid.UUID) (*Artifact, error)
+	Save(ctx context.Context, artifact *Artifact) error
+}
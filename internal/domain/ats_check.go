@@ -31,15 +31,26 @@ type ATSKeywords struct {
 }
 
 type ATSImprovement struct {
-	Priority   string `json:"priority"`
-	Category   string `json:"category"`
-	Issue      string `json:"issue"`
-	Suggestion string `json:"suggestion"`
+	Priority   string       `json:"priority"`
+	Category   string       `json:"category"`
+	Issue      string       `json:"issue"`
+	Suggestion string       `json:"suggestion"`
+	Location   *ATSLocation `json:"location,omitempty"`
+}
+
+type ATSLocation struct {
+	Page      int     `json:"page"`
+	LineStart int     `json:"line_start,omitempty"`
+	LineEnd   int     `json:"line_end,omitempty"`
+	Top       float64 `json:"top"`
+	Bottom    float64 `json:"bottom"`
+	Excerpt   string  `json:"excerpt,omitempty"`
 }
 
 type ATSCheck struct {
 	ID        uuid.UUID    `json:"id"`
 	UserID    uuid.UUID    `json:"user_id"`
+	ResumeID  *uuid.UUID   `json:"resume_id,omitempty"`
 	Score     *float64     `json:"score,omitempty"`
 	Analysis  *ATSAnalysis `json:"analysis,omitempty"`
 	CreatedAt time.Time    `json:"created_at"`
@@ -51,22 +62,123 @@ type ATSCheckResponse struct {
 	AIAnalysisStatus string    `json:"ai_analysis_status"`
 }
 
+type ATSAnalysisStatus string
+
+const (
+	ATSAnalysisUploaded  ATSAnalysisStatus = "uploaded"
+	ATSAnalysisAnalyzing ATSAnalysisStatus = "analyzing"
+	ATSAnalysisScoring   ATSAnalysisStatus = "scoring"
+	ATSAnalysisDone      ATSAnalysisStatus = "done"
+	ATSAnalysisFailed    ATSAnalysisStatus = "failed"
+)
+
+// ATSAnalysisJob tracks an uploaded PDF through analysis. Its ID becomes the
+// ID of the ATS check, and Result is set once the check is saved.
+type ATSAnalysisJob struct {
+	ID        uuid.UUID         `json:"id"`
+	UserID    uuid.UUID         `json:"user_id"`
+	Status    ATSAnalysisStatus `json:"status"`
+	Result    *ATSCheckResponse `json:"result,omitempty"`
+	Error     string            `json:"error,omitempty"`
+	UpdatedAt time.Time         `json:"updated_at"`
+}
+
+type ATSProgressBroker interface {
+	Publish(job ATSAnalysisJob)
+	Subscribe(id uuid.UUID) (<-chan ATSAnalysisJob, func())
+}
+
+type ATSJobDescription struct {
+	Title       string `json:"title" validate:"max=255"`
+	Description string `json:"description" validate:"required,min=50,max=10000"`
+}
+
+type ATSBatchRequest struct {
+	JobDescriptions []ATSJobDescription `json:"job_descriptions" validate:"required,min=1,dive"`
+}
+
+type ATSJobMatch struct {
+	Rank            int      `json:"rank"`
+	Index           int      `json:"index"`
+	Title           string   `json:"title,omitempty"`
+	MatchScore      float64  `json:"match_score"`
+	Verdict         string   `json:"verdict"`
+	MatchedKeywords []string `json:"matched_keywords"`
+	MissingKeywords []string `json:"missing_keywords"`
+	Recommendation  string   `json:"recommendation,omitempty"`
+	AIStatus        string   `json:"ai_status"`
+}
+
+type ATSBatchResponse struct {
+	Results   []ATSJobMatch `json:"results"`
+	Processed int           `json:"processed"`
+	Failed    int           `json:"failed"`
+}
+
 type PaginatedATSChecks struct {
 	ATSChecks  []ATSCheck `json:"ats_checks"`
 	Pagination Pagination `json:"pagination"`
 }
 
+type ATSCompareQuery struct {
+	From string `query:"from" validate:"required,uuid"`
+	To   string `query:"to" validate:"required,uuid"`
+}
+
+type ATSCheckRef struct {
+	ID        uuid.UUID `json:"id"`
+	Score     float64   `json:"score"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+type ATSSectionDelta struct {
+	Name      string   `json:"name"`
+	FromScore *float64 `json:"from_score"`
+	ToScore   *float64 `json:"to_score"`
+	MaxScore  float64  `json:"max_score"`
+	Delta     float64  `json:"delta"`
+}
+
+type ATSKeywordDiff struct {
+	NewlyFound   []string `json:"newly_found"`
+	StillMissing []string `json:"still_missing"`
+	NewlyMissing []string `json:"newly_missing"`
+}
+
+type ATSImprovementDiff struct {
+	Resolved  []ATSImprovement `json:"resolved"`
+	New       []ATSImprovement `json:"new"`
+	Remaining []ATSImprovement `json:"remaining"`
+}
+
+type ATSComparison struct {
+	From         ATSCheckRef        `json:"from"`
+	To           ATSCheckRef        `json:"to"`
+	ScoreDelta   float64            `json:"score_delta"`
+	Sections     []ATSSectionDelta  `json:"sections"`
+	Keywords     ATSKeywordDiff     `json:"keywords"`
+	Improvements ATSImprovementDiff `json:"improvements"`
+}
+
 type ATSCheckRepository interface {
 	Create(ctx context.Context, check *ATSCheck) error
 	FindByID(ctx context.Context, id uuid.UUID) (*ATSCheck, error)
 	FindByUserID(ctx context.Context, userID uuid.UUID, limit, offset int) ([]ATSCheck, error)
 	CountByUserID(ctx context.Context, userID uuid.UUID) (int64, error)
 	SoftDelete(ctx context.Context, id uuid.UUID) error
+	SaveSource(ctx context.Context, checkID uuid.UUID, data []byte) error
+	FindSource(ctx context.Context, checkID uuid.UUID) ([]byte, error)
+	PurgeFreeBefore(ctx context.Context, before time.Time) (int64, error)
 }
 
 type ATSCheckService interface {
-	AnalyzeFromFile(ctx context.Context, userID uuid.UUID, file *multipart.FileHeader) (*ATSCheckResponse, error)
+	AnalyzeFromFile(ctx context.Context, userID uuid.UUID, file *multipart.FileHeader) (*ATSAnalysisJob, error)
+	GetAnalysisJob(ctx context.Context, userID uuid.UUID, id uuid.UUID) (*ATSAnalysisJob, error)
+	AnalyzeResume(ctx context.Context, userID uuid.UUID, resumeID uuid.UUID) (*ATSCheckResponse, error)
+	AnalyzeBatch(ctx context.Context, userID uuid.UUID, file *multipart.FileHeader, req *ATSBatchRequest) (*ATSBatchResponse, error)
 	GetByID(ctx context.Context, userID uuid.UUID, id uuid.UUID) (*ATSCheck, error)
 	GetByUserID(ctx context.Context, userID uuid.UUID, page, limit int) (*PaginatedATSChecks, error)
+	Compare(ctx context.Context, userID uuid.UUID, fromID, toID uuid.UUID) (*ATSComparison, error)
+	GetAnnotatedPDF(ctx context.Context, userID uuid.UUID, id uuid.UUID) ([]byte, error)
 	Delete(ctx context.Context, userID uuid.UUID, id uuid.UUID) error
 }
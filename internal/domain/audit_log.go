@@ -0,0 +1,117 @@
+package domain
+
+import (
+	"context"
+	"encoding/json"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type AuditAction string
+
+const (
+	AuditActionPlanCreate          AuditAction = "plan.create"
+	AuditActionPlanUpdate          AuditAction = "plan.update"
+	AuditActionPlanDelete          AuditAction = "plan.delete"
+	AuditActionPlanArchive         AuditAction = "plan.archive"
+	AuditActionPlanUnarchive       AuditAction = "plan.unarchive"
+	AuditActionUserDelete          AuditAction = "user.delete"
+	AuditActionProvisioningReplay  AuditAction = "provisioning.replay"
+	AuditActionUserImpersonate     AuditAction = "user.impersonate"
+	AuditActionImpersonatedAction  AuditAction = "impersonation.request"
+	AuditActionWebhookCreate       AuditAction = "webhook.create"
+	AuditActionWebhookUpdate       AuditAction = "webhook.update"
+	AuditActionWebhookDelete       AuditAction = "webhook.delete"
+	AuditActionWebhookRotate       AuditAction = "webhook.rotate_secret"
+	AuditActionIdentityLink        AuditAction = "identity.link"
+	AuditActionIdentityUnlink      AuditAction = "identity.unlink"
+	AuditActionInterviewPackCreate AuditAction = "interview_pack.create"
+	AuditActionInterviewPackUpdate AuditAction = "interview_pack.update"
+	AuditActionInterviewPackDelete AuditAction = "interview_pack.delete"
+	AuditActionAddonCreate         AuditAction = "addon.create"
+	AuditActionAddonUpdate         AuditAction = "addon.update"
+	AuditActionAddonDelete         AuditAction = "addon.delete"
+	AuditActionJobRetry            AuditAction = "job.retry"
+	AuditActionJobDelete           AuditAction = "job.delete"
+	AuditActionPromptCreate        AuditAction = "prompt.create"
+	AuditActionPromptActivate      AuditAction = "prompt.activate"
+	AuditActionPromptReset         AuditAction = "prompt.reset"
+	AuditActionPromptDelete        AuditAction = "prompt.delete"
+	AuditActionExperimentCreate    AuditAction = "experiment.create"
+	AuditActionExperimentStop      AuditAction = "experiment.stop"
+	AuditActionQuotaOverrideCreate AuditAction = "quota_override.create"
+	AuditActionQuotaOverrideRevoke AuditAction = "quota_override.revoke"
+	AuditActionUserDataRegion      AuditAction = "user.data_region"
+	AuditActionSubscriptionGrant   AuditAction = "subscription.grant"
+)
+
+const (
+	AuditTargetPlan            = "plan"
+	AuditTargetUser            = "user"
+	AuditTargetProvisioningJob = "provisioning_job"
+	AuditTargetWebhookEndpoint = "webhook_endpoint"
+	AuditTargetAuthIdentity    = "auth_identity"
+	AuditTargetInterviewPack   = "interview_pack"
+	AuditTargetAddon           = "addon"
+	AuditTargetJob             = "job"
+	AuditTargetPrompt          = "prompt"
+	AuditTargetExperiment      = "experiment"
+	AuditTargetQuotaOverride   = "quota_override"
+	AuditTargetSubscription    = "subscription"
+)
+
+type AuditLog struct {
+	ID         uuid.UUID       `json:"id"`
+	ActorID    *uuid.UUID      `json:"actor_id"`
+	Action     AuditAction     `json:"action"`
+	TargetType string          `json:"target_type"`
+	TargetID   uuid.UUID       `json:"target_id"`
+	Before     json.RawMessage `json:"before,omitempty"`
+	After      json.RawMessage `json:"after,omitempty"`
+	IPAddress  string          `json:"ip_address"`
+	UserAgent  string          `json:"user_agent"`
+	CreatedAt  time.Time       `json:"created_at"`
+}
+
+type AuditActor struct {
+	UserID    uuid.UUID
+	IPAddress string
+	UserAgent string
+}
+
+type AuditLogFilter struct {
+	ActorID    *uuid.UUID
+	Action     AuditAction
+	TargetType string
+	TargetID   *uuid.UUID
+	From       *time.Time
+	To         *time.Time
+}
+
+type PaginatedAuditLogs struct {
+	Logs       []AuditLog `json:"logs"`
+	Pagination Pagination `json:"pagination"`
+}
+
+type auditActorKey struct{}
+
+func WithAuditActor(ctx context.Context, actor AuditActor) context.Context {
+	return context.WithValue(ctx, auditActorKey{}, actor)
+}
+
+func AuditActorFromContext(ctx context.Context) (AuditActor, bool) {
+	actor, ok := ctx.Value(auditActorKey{}).(AuditActor)
+	return actor, ok
+}
+
+type AuditLogRepository interface {
+	Create(ctx context.Context, log *AuditLog) error
+	FindAll(ctx context.Context, filter AuditLogFilter, limit, offset int) ([]AuditLog, error)
+	Count(ctx context.Context, filter AuditLogFilter) (int64, error)
+}
+
+type AuditService interface {
+	Record(ctx context.Context, action AuditAction, targetType string, targetID uuid.UUID, before, after interface{})
+	GetAll(ctx context.Context, filter AuditLogFilter, page, limit int) (*PaginatedAuditLogs, error)
+}
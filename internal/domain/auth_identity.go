@@ -0,0 +1,49 @@
+package domain
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type AuthProvider string
+
+const (
+	AuthProviderGoogle AuthProvider = "google"
+)
+
+var (
+	ErrIdentityNotFound      = errors.New("linked account not found")
+	ErrIdentityInUse         = errors.New("this account is already linked to another user")
+	ErrProviderAlreadyLinked = errors.New("a login of this provider is already linked, unlink it first")
+	ErrLastIdentity          = errors.New("cannot unlink the only login method of this account")
+	ErrUnsupportedProvider   = errors.New("unsupported auth provider")
+	ErrInvalidLinkState      = errors.New("account link request is invalid or expired")
+	ErrEmailBelongsToAnother = errors.New("an account with this email already exists, sign in and link this login from settings")
+)
+
+type AuthIdentity struct {
+	ID             uuid.UUID    `json:"id"`
+	UserID         uuid.UUID    `json:"user_id"`
+	Provider       AuthProvider `json:"provider"`
+	ProviderUserID string       `json:"-"`
+	Email          string       `json:"email"`
+	CreatedAt      time.Time    `json:"created_at"`
+	LastUsedAt     *time.Time   `json:"last_used_at,omitempty"`
+}
+
+type LinkProviderResponse struct {
+	Provider AuthProvider `json:"provider"`
+	AuthURL  string       `json:"auth_url"`
+}
+
+type AuthIdentityRepository interface {
+	Create(ctx context.Context, identity *AuthIdentity) error
+	FindByID(ctx context.Context, id uuid.UUID) (*AuthIdentity, error)
+	FindByProvider(ctx context.Context, provider AuthProvider, providerUserID string) (*AuthIdentity, error)
+	FindByUserID(ctx context.Context, userID uuid.UUID) ([]AuthIdentity, error)
+	UpdateLastUsed(ctx context.Context, id uuid.UUID) error
+	Delete(ctx context.Context, id uuid.UUID) error
+}
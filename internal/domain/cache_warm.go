@@ -0,0 +1,19 @@
+package domain
+
+import (
+	"context"
+	"time"
+)
+
+type CacheWarmResult struct {
+	PlanPages   int       `json:"plan_pages"`
+	Users       int       `json:"users"`
+	Errors      []string  `json:"errors,omitempty"`
+	StartedAt   time.Time `json:"started_at"`
+	CompletedAt time.Time `json:"completed_at"`
+	DurationMs  int64     `json:"duration_ms"`
+}
+
+type CacheWarmService interface {
+	Warm(ctx context.Context) (*CacheWarmResult, error)
+}
@@ -0,0 +1,34 @@
+package domain
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type SkillGap struct {
+	Skill    string `json:"skill"`
+	Priority string `json:"priority"`
+	Evidence string `json:"evidence"`
+}
+
+type LearningTopic struct {
+	Topic     string   `json:"topic"`
+	Reason    string   `json:"reason"`
+	Resources []string `json:"resources,omitempty"`
+}
+
+type SkillGapReport struct {
+	Summary        string          `json:"summary"`
+	Strengths      []string        `json:"strengths"`
+	SkillGaps      []SkillGap      `json:"skill_gaps"`
+	LearningTopics []LearningTopic `json:"learning_topics"`
+	AIStatus       string          `json:"ai_status"`
+	GeneratedAt    time.Time       `json:"generated_at"`
+	ExpiresAt      time.Time       `json:"expires_at"`
+}
+
+type CareerInsightService interface {
+	GetSkillGapReport(ctx context.Context, userID uuid.UUID) (*SkillGapReport, error)
+}
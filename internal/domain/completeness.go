@@ -0,0 +1,28 @@
+package domain
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+)
+
+type CompletenessItem struct {
+	Key       string `json:"key"`
+	Label     string `json:"label"`
+	Weight    int    `json:"weight"`
+	Completed bool   `json:"completed"`
+	Hint      string `json:"hint,omitempty"`
+}
+
+type ProfileCompleteness struct {
+	Score       int                `json:"score"`
+	MaxScore    int                `json:"max_score"`
+	Percentage  int                `json:"percentage"`
+	ResumeID    *uuid.UUID         `json:"resume_id"`
+	ResumeTitle string             `json:"resume_title,omitempty"`
+	Checklist   []CompletenessItem `json:"checklist"`
+}
+
+type CompletenessService interface {
+	GetCompleteness(ctx context.Context, user *User) (*ProfileCompleteness, error)
+}
@@ -0,0 +1,13 @@
+package domain
+
+import (
+	"context"
+
+	"github.com/raflytch/careerly-server/pkg/semaphore"
+)
+
+type ConcurrencyLimiter interface {
+	Acquire(ctx context.Context, key string, limit int) (release func(), acquired bool, err error)
+}
+
+var _ ConcurrencyLimiter = (*semaphore.Semaphore)(nil)
@@ -0,0 +1,18 @@
+package domain
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+)
+
+// SetDataRegionRequest pins a user to a region. An empty region moves the
+// user back to the default one.
+type SetDataRegionRequest struct {
+	DataRegion string `json:"data_region" validate:"max=32"`
+}
+
+type DataRegionService interface {
+	Regions() []string
+	SetUserRegion(ctx context.Context, userID uuid.UUID, region string) (*User, error)
+}
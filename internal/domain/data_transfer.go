@@ -0,0 +1,93 @@
+package domain
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+const DataBundleVersion = 1
+
+var (
+	ErrUnsupportedBundleVersion = errors.New("unsupported data bundle version")
+	ErrEmptyDataBundle          = errors.New("data bundle has no content to import")
+)
+
+type DataBundle struct {
+	Version      int               `json:"version"`
+	ExportedAt   time.Time         `json:"exported_at"`
+	SourceUserID uuid.UUID         `json:"source_user_id"`
+	PIIRedacted  bool              `json:"pii_redacted"`
+	User         BundleUser        `json:"user"`
+	Resumes      []BundleResume    `json:"resumes"`
+	Interviews   []BundleInterview `json:"interviews"`
+	ATSChecks    []BundleATSCheck  `json:"ats_checks"`
+}
+
+type BundleUser struct {
+	Email string `json:"email"`
+	Name  string `json:"name"`
+	Role  Role   `json:"role"`
+}
+
+type BundleResume struct {
+	ID        uuid.UUID     `json:"id"`
+	Title     string        `json:"title"`
+	Content   ResumeContent `json:"content"`
+	IsActive  bool          `json:"is_active"`
+	CreatedAt time.Time     `json:"created_at"`
+	UpdatedAt time.Time     `json:"updated_at"`
+}
+
+type BundleInterview struct {
+	ID           uuid.UUID         `json:"id"`
+	JobPosition  string            `json:"job_position"`
+	Language     InterviewLanguage `json:"language,omitempty"`
+	Questions    []BundleQuestion  `json:"questions"`
+	Status       InterviewStatus   `json:"status"`
+	OverallScore *float64          `json:"overall_score,omitempty"`
+	CreatedAt    time.Time         `json:"created_at"`
+	CompletedAt  *time.Time        `json:"completed_at,omitempty"`
+}
+
+type BundleQuestion struct {
+	ID            int          `json:"id"`
+	Type          QuestionType `json:"type"`
+	Question      string       `json:"question"`
+	Options       []Option     `json:"options,omitempty"`
+	CorrectAnswer string       `json:"correct_answer"`
+	UserAnswer    string       `json:"user_answer,omitempty"`
+	IsCorrect     *bool        `json:"is_correct,omitempty"`
+	Score         *float64     `json:"score,omitempty"`
+	Feedback      string       `json:"feedback,omitempty"`
+}
+
+type BundleATSCheck struct {
+	ID        uuid.UUID    `json:"id"`
+	Score     *float64     `json:"score,omitempty"`
+	Analysis  *ATSAnalysis `json:"analysis,omitempty"`
+	CreatedAt time.Time    `json:"created_at"`
+}
+
+type ExportOptions struct {
+	RedactPII bool
+}
+
+type ImportOptions struct {
+	RedactPII bool
+}
+
+type ImportResult struct {
+	TargetUserID uuid.UUID            `json:"target_user_id"`
+	Resumes      int                  `json:"resumes"`
+	Interviews   int                  `json:"interviews"`
+	ATSChecks    int                  `json:"ats_checks"`
+	IDMapping    map[string]uuid.UUID `json:"id_mapping"`
+}
+
+type DataTransferService interface {
+	ExportUserData(ctx context.Context, userID uuid.UUID, opts ExportOptions) (*DataBundle, error)
+	ImportUserData(ctx context.Context, targetUserID uuid.UUID, bundle *DataBundle, opts ImportOptions) (*ImportResult, error)
+}
@@ -0,0 +1,71 @@
+package domain
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type EmailStatus string
+
+const (
+	EmailStatusSent       EmailStatus = "sent"
+	EmailStatusDelivered  EmailStatus = "delivered"
+	EmailStatusBounced    EmailStatus = "bounced"
+	EmailStatusComplained EmailStatus = "complained"
+	EmailStatusFailed     EmailStatus = "failed"
+	EmailStatusSuppressed EmailStatus = "suppressed"
+)
+
+type EmailMessage struct {
+	ID                uuid.UUID   `json:"id"`
+	UserID            *uuid.UUID  `json:"user_id,omitempty"`
+	Recipient         string      `json:"recipient"`
+	Subject           string      `json:"subject"`
+	Provider          string      `json:"provider"`
+	ProviderMessageID string      `json:"provider_message_id,omitempty"`
+	Status            EmailStatus `json:"status"`
+	Detail            string      `json:"detail,omitempty"`
+	CreatedAt         time.Time   `json:"created_at"`
+	UpdatedAt         time.Time   `json:"updated_at"`
+}
+
+type EmailSuppression struct {
+	Email     string      `json:"email"`
+	UserID    *uuid.UUID  `json:"user_id,omitempty"`
+	Reason    EmailStatus `json:"reason"`
+	Provider  string      `json:"provider"`
+	Detail    string      `json:"detail,omitempty"`
+	CreatedAt time.Time   `json:"created_at"`
+}
+
+type PaginatedEmailSuppressions struct {
+	Suppressions []EmailSuppression `json:"suppressions"`
+	Pagination   Pagination         `json:"pagination"`
+}
+
+type EmailRepository interface {
+	CreateMessage(ctx context.Context, message *EmailMessage) error
+	UpdateMessageStatus(ctx context.Context, provider, providerMessageID string, status EmailStatus, detail string) error
+	Suppress(ctx context.Context, suppression *EmailSuppression) error
+	IsSuppressed(ctx context.Context, email string) (bool, error)
+	FindSuppressions(ctx context.Context, limit, offset int) ([]EmailSuppression, error)
+	CountSuppressions(ctx context.Context) (int64, error)
+	DeleteSuppression(ctx context.Context, email string) (bool, error)
+}
+
+type EmailService interface {
+	SendOTP(ctx context.Context, email, otp string) error
+	SendDeleteOTP(ctx context.Context, email, otp string) error
+	SendAccountPurgeNotice(ctx context.Context, email string, purgeAt time.Time) error
+	SendLoginOTP(ctx context.Context, email, otp string) error
+	SendContactEmailOTP(ctx context.Context, email, otp string) error
+	SendInterviewReminder(ctx context.Context, email, jobPosition string, scheduledAt time.Time) error
+	SendGiftCode(ctx context.Context, email, senderName, planName, code string) error
+	SendQuotaWarning(ctx context.Context, email, feature string, threshold, used, limit int) error
+	SendOrganizationInvite(ctx context.Context, email, inviterName, organizationName, inviteURL string) error
+	HandleProviderEvents(ctx context.Context, provider, token string, body []byte) error
+	GetSuppressions(ctx context.Context, page, limit int) (*PaginatedEmailSuppressions, error)
+	RemoveSuppression(ctx context.Context, email string) error
+}
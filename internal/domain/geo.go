@@ -0,0 +1,9 @@
+package domain
+
+import "github.com/raflytch/careerly-server/pkg/geoip"
+
+type GeoResolver interface {
+	Country(ip string) string
+}
+
+var _ GeoResolver = (*geoip.Database)(nil)
@@ -0,0 +1,42 @@
+package domain
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type Gift struct {
+	ID             uuid.UUID  `json:"id"`
+	TransactionID  uuid.UUID  `json:"transaction_id"`
+	PurchaserID    uuid.UUID  `json:"purchaser_id"`
+	PlanID         uuid.UUID  `json:"plan_id"`
+	RecipientEmail string     `json:"recipient_email"`
+	Code           *string    `json:"code,omitempty"`
+	IssuedAt       *time.Time `json:"issued_at,omitempty"`
+	RedeemedBy     *uuid.UUID `json:"redeemed_by,omitempty"`
+	SubscriptionID *uuid.UUID `json:"-"`
+	RedeemedAt     *time.Time `json:"redeemed_at,omitempty"`
+	CreatedAt      time.Time  `json:"created_at"`
+	Plan           *Plan      `json:"plan,omitempty"`
+}
+
+type CreateGiftTransactionRequest struct {
+	PlanID          uuid.UUID `json:"plan_id" validate:"required"`
+	RecipientEmails []string  `json:"recipient_emails" validate:"required,min=1,max=10,unique,dive,required,email,max=255"`
+}
+
+type RedeemGiftRequest struct {
+	Code string `json:"code" validate:"required,max=32"`
+}
+
+type GiftRepository interface {
+	CreateBatch(ctx context.Context, gifts []Gift) error
+	FindByTransactionID(ctx context.Context, transactionID uuid.UUID) ([]Gift, error)
+	FindByPurchaserID(ctx context.Context, purchaserID uuid.UUID) ([]Gift, error)
+	FindByCode(ctx context.Context, code string) (*Gift, error)
+	Issue(ctx context.Context, id uuid.UUID, code string, issuedAt time.Time) (bool, error)
+	Redeem(ctx context.Context, id, userID, subscriptionID uuid.UUID, redeemedAt time.Time) (bool, error)
+	Unredeem(ctx context.Context, id uuid.UUID) error
+}
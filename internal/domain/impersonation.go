@@ -0,0 +1,27 @@
+package domain
+
+import (
+	"errors"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+var (
+	ErrCannotImpersonateAdmin = errors.New("admin accounts cannot be impersonated")
+	ErrCannotImpersonateSelf  = errors.New("cannot impersonate your own account")
+	ErrImpersonationForbidden = errors.New("this action is not allowed while impersonating a user")
+)
+
+type TokenIdentity struct {
+	User           *User
+	SessionID      uuid.UUID
+	ImpersonatorID *uuid.UUID
+}
+
+type ImpersonationResponse struct {
+	Token          string    `json:"token"`
+	ExpiresAt      time.Time `json:"expires_at"`
+	ImpersonatorID uuid.UUID `json:"impersonator_id"`
+	User           User      `json:"user"`
+}
@@ -2,6 +2,7 @@ package domain
 
 import (
 	"context"
+	"mime/multipart"
 	"time"
 
 	"github.com/google/uuid"
@@ -13,6 +14,29 @@ const (
 	InterviewStatusInProgress InterviewStatus = "in_progress"
 	InterviewStatusCompleted  InterviewStatus = "completed"
 	InterviewStatusCanceled   InterviewStatus = "canceled"
+	InterviewStatusScheduled  InterviewStatus = "scheduled"
+	InterviewStatusReady      InterviewStatus = "ready"
+	InterviewStatusEvaluating InterviewStatus = "evaluating"
+)
+
+type EvaluationJobStatus string
+
+const (
+	EvaluationJobStatusQueued    EvaluationJobStatus = "queued"
+	EvaluationJobStatusRunning   EvaluationJobStatus = "running"
+	EvaluationJobStatusCompleted EvaluationJobStatus = "completed"
+	EvaluationJobStatusFailed    EvaluationJobStatus = "failed"
+)
+
+type InterviewProgressEventType string
+
+const (
+	InterviewProgressSnapshot          InterviewProgressEventType = "snapshot"
+	InterviewProgressStarted           InterviewProgressEventType = "evaluation_started"
+	InterviewProgressQuestionEvaluated InterviewProgressEventType = "question_evaluated"
+	InterviewProgressScoreComputed     InterviewProgressEventType = "score_computed"
+	InterviewProgressCompleted         InterviewProgressEventType = "completed"
+	InterviewProgressFailed            InterviewProgressEventType = "failed"
 )
 
 type QuestionType string
@@ -22,16 +46,83 @@ const (
 	QuestionTypeMultipleChoice QuestionType = "multiple_choice"
 )
 
+type QuestionDifficulty string
+
+const (
+	QuestionDifficultyEasy   QuestionDifficulty = "easy"
+	QuestionDifficultyMedium QuestionDifficulty = "medium"
+	QuestionDifficultyHard   QuestionDifficulty = "hard"
+)
+
+type InterviewLanguage string
+
+const (
+	InterviewLanguageEnglish    InterviewLanguage = "en"
+	InterviewLanguageIndonesian InterviewLanguage = "id"
+)
+
+type AnswerProvenanceVerdict string
+
+const (
+	AnswerProvenanceOriginal          AnswerProvenanceVerdict = "original"
+	AnswerProvenanceSuspicious        AnswerProvenanceVerdict = "suspicious"
+	AnswerProvenanceLikelyAIGenerated AnswerProvenanceVerdict = "likely_ai_generated"
+	AnswerProvenanceLikelyPlagiarized AnswerProvenanceVerdict = "likely_plagiarized"
+)
+
+type AnswerTelemetry struct {
+	TypingDurationMs int64 `json:"typing_duration_ms" validate:"min=0"`
+	PasteCount       int   `json:"paste_count" validate:"min=0"`
+	PastedChars      int   `json:"pasted_chars" validate:"min=0"`
+}
+
+type AnswerProvenance struct {
+	Verdict    AnswerProvenanceVerdict `json:"verdict"`
+	Confidence float64                 `json:"confidence"`
+	Reason     string                  `json:"reason,omitempty"`
+	Source     string                  `json:"source"`
+}
+
+// RubricCriterion is one thing essay answers are scored on. Weights are
+// relative to the other criteria of the rubric.
+type RubricCriterion struct {
+	Name   string  `json:"name" validate:"required,min=2,max=100"`
+	Weight float64 `json:"weight" validate:"required,gt=0,lte=100"`
+}
+
+type CriterionScore struct {
+	Name   string  `json:"name"`
+	Weight float64 `json:"weight"`
+	Score  float64 `json:"score"`
+}
+
 type Question struct {
-	ID            int          `json:"id"`
-	Type          QuestionType `json:"type"`
-	Question      string       `json:"question"`
-	Options       []Option     `json:"options,omitempty"`
-	CorrectAnswer string       `json:"-"`
-	UserAnswer    string       `json:"user_answer,omitempty"`
-	IsCorrect     *bool        `json:"is_correct,omitempty"`
-	Score         *float64     `json:"score,omitempty"`
-	Feedback      string       `json:"feedback,omitempty"`
+	ID              int                `json:"id"`
+	Type            QuestionType       `json:"type"`
+	Question        string             `json:"question"`
+	Options         []Option           `json:"options,omitempty"`
+	Difficulty      QuestionDifficulty `json:"difficulty,omitempty"`
+	Round           int                `json:"round,omitempty"`
+	CorrectAnswer   string             `json:"-"`
+	UserAnswer      string             `json:"user_answer,omitempty"`
+	IsCorrect       *bool              `json:"is_correct,omitempty"`
+	Score           *float64           `json:"score,omitempty"`
+	CriterionScores []CriterionScore   `json:"criterion_scores,omitempty"`
+	Feedback        string             `json:"feedback,omitempty"`
+	Telemetry       *AnswerTelemetry   `json:"telemetry,omitempty"`
+	Provenance      *AnswerProvenance  `json:"provenance,omitempty"`
+	VideoAnswer     *VideoAnswer       `json:"video_answer,omitempty"`
+}
+
+type VideoAnswer struct {
+	URL             string    `json:"url"`
+	StorageID       string    `json:"storage_id,omitempty"`
+	ContentType     string    `json:"content_type"`
+	Size            int64     `json:"size"`
+	Transcript      string    `json:"transcript"`
+	Summary         string    `json:"summary,omitempty"`
+	ContentFeedback string    `json:"content_feedback,omitempty"`
+	UploadedAt      time.Time `json:"uploaded_at"`
 }
 
 type Option struct {
@@ -40,43 +131,93 @@ type Option struct {
 }
 
 type Interview struct {
-	ID           uuid.UUID       `json:"id"`
-	UserID       uuid.UUID       `json:"user_id"`
-	JobPosition  string          `json:"job_position"`
-	Questions    []Question      `json:"questions"`
-	Status       InterviewStatus `json:"status"`
-	OverallScore *float64        `json:"overall_score,omitempty"`
-	CreatedAt    time.Time       `json:"created_at"`
-	CompletedAt  *time.Time      `json:"completed_at,omitempty"`
-	DeletedAt    *time.Time      `json:"deleted_at,omitempty"`
+	ID                  uuid.UUID         `json:"id"`
+	UserID              uuid.UUID         `json:"user_id"`
+	JobPosition         string            `json:"job_position"`
+	Language            InterviewLanguage `json:"language"`
+	Questions           []Question        `json:"questions"`
+	Status              InterviewStatus   `json:"status"`
+	Adaptive            bool              `json:"adaptive"`
+	TargetQuestionCount int               `json:"target_question_count"`
+	PackID              *uuid.UUID        `json:"pack_id,omitempty"`
+	Rubric              []RubricCriterion `json:"rubric,omitempty"`
+	OverallScore        *float64          `json:"overall_score,omitempty"`
+	ScheduledAt         *time.Time        `json:"scheduled_at,omitempty"`
+	ReminderSentAt      *time.Time        `json:"reminder_sent_at,omitempty"`
+	CreatedAt           time.Time         `json:"created_at"`
+	CompletedAt         *time.Time        `json:"completed_at,omitempty"`
+	DeletedAt           *time.Time        `json:"deleted_at,omitempty"`
 }
 
 type InterviewForUser struct {
-	ID           uuid.UUID         `json:"id"`
-	UserID       uuid.UUID         `json:"user_id"`
-	JobPosition  string            `json:"job_position"`
-	Questions    []QuestionForUser `json:"questions"`
-	Status       InterviewStatus   `json:"status"`
-	OverallScore *float64          `json:"overall_score,omitempty"`
-	CreatedAt    time.Time         `json:"created_at"`
-	CompletedAt  *time.Time        `json:"completed_at,omitempty"`
+	ID                  uuid.UUID         `json:"id"`
+	UserID              uuid.UUID         `json:"user_id"`
+	JobPosition         string            `json:"job_position"`
+	Language            InterviewLanguage `json:"language"`
+	Questions           []QuestionForUser `json:"questions"`
+	Status              InterviewStatus   `json:"status"`
+	Adaptive            bool              `json:"adaptive"`
+	TargetQuestionCount int               `json:"target_question_count"`
+	PackID              *uuid.UUID        `json:"pack_id,omitempty"`
+	Rubric              []RubricCriterion `json:"rubric,omitempty"`
+	OverallScore        *float64          `json:"overall_score,omitempty"`
+	ScheduledAt         *time.Time        `json:"scheduled_at,omitempty"`
+	CreatedAt           time.Time         `json:"created_at"`
+	CompletedAt         *time.Time        `json:"completed_at,omitempty"`
+	DeletedAt           *time.Time        `json:"deleted_at,omitempty"`
+}
+
+type InterviewExportFormat string
+
+const (
+	InterviewExportJSON     InterviewExportFormat = "json"
+	InterviewExportMarkdown InterviewExportFormat = "markdown"
+)
+
+type InterviewTranscript struct {
+	ExportedAt time.Time         `json:"exported_at"`
+	Interview  *InterviewForUser `json:"interview"`
+}
+
+type InterviewExport struct {
+	Filename    string
+	ContentType string
+	Data        []byte
 }
 
 type QuestionForUser struct {
-	ID         int          `json:"id"`
-	Type       QuestionType `json:"type"`
-	Question   string       `json:"question"`
-	Options    []Option     `json:"options,omitempty"`
-	UserAnswer string       `json:"user_answer,omitempty"`
-	IsCorrect  *bool        `json:"is_correct,omitempty"`
-	Score      *float64     `json:"score,omitempty"`
-	Feedback   string       `json:"feedback,omitempty"`
+	ID              int                `json:"id"`
+	Type            QuestionType       `json:"type"`
+	Question        string             `json:"question"`
+	Options         []Option           `json:"options,omitempty"`
+	Difficulty      QuestionDifficulty `json:"difficulty,omitempty"`
+	Round           int                `json:"round,omitempty"`
+	UserAnswer      string             `json:"user_answer,omitempty"`
+	IsCorrect       *bool              `json:"is_correct,omitempty"`
+	Score           *float64           `json:"score,omitempty"`
+	CriterionScores []CriterionScore   `json:"criterion_scores,omitempty"`
+	Feedback        string             `json:"feedback,omitempty"`
+	Provenance      *AnswerProvenance  `json:"provenance,omitempty"`
+	VideoAnswer     *VideoAnswer       `json:"video_answer,omitempty"`
 }
 
 type CreateInterviewRequest struct {
-	JobPosition   string       `json:"job_position" validate:"required,min=3,max=255"`
-	QuestionType  QuestionType `json:"question_type" validate:"required,oneof=essay multiple_choice"`
-	QuestionCount int          `json:"question_count" validate:"required,min=1,max=20"`
+	JobPosition   string            `json:"job_position" validate:"required,min=3,max=255"`
+	QuestionType  QuestionType      `json:"question_type" validate:"required,oneof=essay multiple_choice"`
+	QuestionCount int               `json:"question_count" validate:"required,min=1,max=20"`
+	Adaptive      bool              `json:"adaptive"`
+	Language      InterviewLanguage `json:"language" validate:"omitempty,oneof=en id"`
+	Rubric        []RubricCriterion `json:"rubric" validate:"omitempty,max=10,dive"`
+}
+
+type ScheduleInterviewRequest struct {
+	JobPosition   string            `json:"job_position" validate:"required,min=3,max=255"`
+	QuestionType  QuestionType      `json:"question_type" validate:"required,oneof=essay multiple_choice"`
+	QuestionCount int               `json:"question_count" validate:"required,min=1,max=20"`
+	Adaptive      bool              `json:"adaptive"`
+	Language      InterviewLanguage `json:"language" validate:"omitempty,oneof=en id"`
+	Rubric        []RubricCriterion `json:"rubric" validate:"omitempty,max=10,dive"`
+	ScheduledAt   time.Time         `json:"scheduled_at" validate:"required"`
 }
 
 type SubmitAnswerRequest struct {
@@ -84,8 +225,9 @@ type SubmitAnswerRequest struct {
 }
 
 type AnswerSubmission struct {
-	QuestionID int    `json:"question_id" validate:"required,min=1"`
-	Answer     string `json:"answer" validate:"required"`
+	QuestionID int              `json:"question_id" validate:"required,min=1"`
+	Answer     string           `json:"answer" validate:"required"`
+	Telemetry  *AnswerTelemetry `json:"telemetry,omitempty"`
 }
 
 type PaginatedInterviews struct {
@@ -99,19 +241,112 @@ type InterviewResponse struct {
 	AIEvaluationStatus string            `json:"ai_evaluation_status,omitempty"`
 }
 
+type EvaluationJob struct {
+	InterviewID        uuid.UUID           `json:"interview_id"`
+	Status             EvaluationJobStatus `json:"status"`
+	Evaluated          int                 `json:"evaluated"`
+	Total              int                 `json:"total"`
+	AIEvaluationStatus string              `json:"ai_evaluation_status,omitempty"`
+	Error              string              `json:"error,omitempty"`
+	UpdatedAt          time.Time           `json:"updated_at"`
+}
+
+type InterviewProgressEvent struct {
+	Type         InterviewProgressEventType `json:"type"`
+	InterviewID  uuid.UUID                  `json:"interview_id"`
+	QuestionID   int                        `json:"question_id,omitempty"`
+	Evaluated    int                        `json:"evaluated"`
+	Total        int                        `json:"total"`
+	OverallScore *float64                   `json:"overall_score,omitempty"`
+	Job          *EvaluationJob             `json:"job,omitempty"`
+	Timestamp    time.Time                  `json:"timestamp"`
+}
+
+type InterviewProgressBroker interface {
+	Publish(event InterviewProgressEvent)
+	Subscribe(interviewID uuid.UUID) (<-chan InterviewProgressEvent, func())
+}
+
 type InterviewRepository interface {
 	Create(ctx context.Context, interview *Interview) error
 	FindByID(ctx context.Context, id uuid.UUID) (*Interview, error)
 	FindByUserID(ctx context.Context, userID uuid.UUID, limit, offset int) ([]Interview, error)
+	FindCompletedByPosition(ctx context.Context, userID uuid.UUID, position string, limit int) ([]Interview, error)
 	CountByUserID(ctx context.Context, userID uuid.UUID) (int64, error)
+	FindScheduledForReminder(ctx context.Context, before time.Time, limit int) ([]Interview, error)
+	MarkReminderSent(ctx context.Context, id uuid.UUID, sentAt time.Time) error
+	MarkScheduledReady(ctx context.Context, now time.Time) (int64, error)
 	Update(ctx context.Context, interview *Interview) error
 	SoftDelete(ctx context.Context, id uuid.UUID) error
+	FindDeletedByID(ctx context.Context, id uuid.UUID) (*Interview, error)
+	FindDeletedByUserID(ctx context.Context, userID uuid.UUID, limit, offset int) ([]Interview, error)
+	CountDeletedByUserID(ctx context.Context, userID uuid.UUID) (int64, error)
+	Restore(ctx context.Context, id uuid.UUID) error
+	PurgeDeletedBefore(ctx context.Context, before time.Time) (int64, error)
+	PurgeFreeBefore(ctx context.Context, before time.Time) (int64, error)
 }
 
 type InterviewService interface {
 	Create(ctx context.Context, userID uuid.UUID, req *CreateInterviewRequest) (*InterviewResponse, error)
+	Schedule(ctx context.Context, userID uuid.UUID, req *ScheduleInterviewRequest) (*InterviewResponse, error)
+	StartFromPack(ctx context.Context, userID uuid.UUID, packID uuid.UUID) (*InterviewResponse, error)
 	GetByID(ctx context.Context, userID uuid.UUID, id uuid.UUID) (*InterviewForUser, error)
 	GetByUserID(ctx context.Context, userID uuid.UUID, page, limit int) (*PaginatedInterviews, error)
+	GetPositionProgress(ctx context.Context, userID uuid.UUID, position string) (*InterviewPositionProgress, error)
 	SubmitAnswers(ctx context.Context, userID uuid.UUID, id uuid.UUID, req *SubmitAnswerRequest) (*InterviewResponse, error)
+	SubmitRound(ctx context.Context, userID uuid.UUID, id uuid.UUID, req *SubmitAnswerRequest) (*InterviewResponse, error)
+	UploadVideoAnswer(ctx context.Context, userID uuid.UUID, id uuid.UUID, questionID int, file *multipart.FileHeader) (*QuestionForUser, error)
+	GetEvaluationJob(ctx context.Context, userID uuid.UUID, id uuid.UUID) (*EvaluationJob, error)
+	Export(ctx context.Context, userID uuid.UUID, id uuid.UUID, format InterviewExportFormat) (*InterviewExport, error)
 	Delete(ctx context.Context, userID uuid.UUID, id uuid.UUID) error
+	GetTrash(ctx context.Context, userID uuid.UUID, page, limit int) (*PaginatedInterviews, error)
+	Restore(ctx context.Context, userID uuid.UUID, id uuid.UUID) (*InterviewForUser, error)
+}
+
+type InterviewScheduleResult struct {
+	RemindersQueued int   `json:"reminders_queued"`
+	MarkedReady     int64 `json:"marked_ready"`
+}
+
+type InterviewSchedulerService interface {
+	ProcessDue(ctx context.Context) (*InterviewScheduleResult, error)
+	SendReminder(ctx context.Context, interviewID uuid.UUID) error
+}
+
+type InterviewTrend string
+
+const (
+	InterviewTrendImproving InterviewTrend = "improving"
+	InterviewTrendSteady    InterviewTrend = "steady"
+	InterviewTrendDeclining InterviewTrend = "declining"
+)
+
+type InterviewScoreStats struct {
+	Count   int            `json:"count"`
+	Average float64        `json:"average"`
+	Best    float64        `json:"best"`
+	Latest  float64        `json:"latest"`
+	Slope   float64        `json:"slope"`
+	Trend   InterviewTrend `json:"trend"`
+}
+
+type InterviewScorePoint struct {
+	InterviewID uuid.UUID `json:"interview_id"`
+	CompletedAt time.Time `json:"completed_at"`
+	Score       float64   `json:"score"`
+}
+
+type QuestionTypeProgress struct {
+	QuestionType QuestionType          `json:"question_type"`
+	Stats        InterviewScoreStats   `json:"stats"`
+	Points       []InterviewScorePoint `json:"points"`
+}
+
+// InterviewPositionProgress is the score history of the completed
+// interviews for one job position, oldest first.
+type InterviewPositionProgress struct {
+	JobPosition    string                 `json:"job_position"`
+	Stats          InterviewScoreStats    `json:"stats"`
+	Points         []InterviewScorePoint  `json:"points"`
+	ByQuestionType []QuestionTypeProgress `json:"by_question_type"`
 }
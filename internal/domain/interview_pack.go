@@ -0,0 +1,85 @@
+package domain
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type InterviewPackQuestion struct {
+	Type          QuestionType       `json:"type" validate:"required,oneof=essay multiple_choice"`
+	Question      string             `json:"question" validate:"required,min=5,max=2000"`
+	Options       []Option           `json:"options,omitempty" validate:"omitempty,min=2,max=6,dive"`
+	Difficulty    QuestionDifficulty `json:"difficulty,omitempty" validate:"omitempty,oneof=easy medium hard"`
+	CorrectAnswer string             `json:"correct_answer" validate:"required,max=2000"`
+}
+
+type InterviewPack struct {
+	ID          uuid.UUID               `json:"id"`
+	Title       string                  `json:"title"`
+	Description string                  `json:"description"`
+	JobPosition string                  `json:"job_position"`
+	Questions   []InterviewPackQuestion `json:"questions"`
+	Rubric      []RubricCriterion       `json:"rubric,omitempty"`
+	IsActive    bool                    `json:"is_active"`
+	CreatedAt   time.Time               `json:"created_at"`
+	UpdatedAt   time.Time               `json:"updated_at"`
+	DeletedAt   *time.Time              `json:"deleted_at,omitempty"`
+}
+
+type InterviewPackSummary struct {
+	ID            uuid.UUID         `json:"id"`
+	Title         string            `json:"title"`
+	Description   string            `json:"description"`
+	JobPosition   string            `json:"job_position"`
+	QuestionCount int               `json:"question_count"`
+	QuestionTypes []QuestionType    `json:"question_types"`
+	Rubric        []RubricCriterion `json:"rubric,omitempty"`
+}
+
+type CreateInterviewPackRequest struct {
+	Title       string                  `json:"title" validate:"required,min=3,max=100"`
+	Description string                  `json:"description" validate:"max=1000"`
+	JobPosition string                  `json:"job_position" validate:"required,min=3,max=255"`
+	Questions   []InterviewPackQuestion `json:"questions" validate:"required,min=1,max=50,dive"`
+	Rubric      []RubricCriterion       `json:"rubric" validate:"omitempty,max=10,dive"`
+	IsActive    *bool                   `json:"is_active"`
+}
+
+type UpdateInterviewPackRequest struct {
+	Title       *string                 `json:"title" validate:"omitempty,min=3,max=100"`
+	Description *string                 `json:"description" validate:"omitempty,max=1000"`
+	JobPosition *string                 `json:"job_position" validate:"omitempty,min=3,max=255"`
+	Questions   []InterviewPackQuestion `json:"questions" validate:"omitempty,min=1,max=50,dive"`
+	Rubric      []RubricCriterion       `json:"rubric" validate:"omitempty,max=10,dive"`
+	IsActive    *bool                   `json:"is_active"`
+}
+
+type PaginatedInterviewPacks struct {
+	Packs      []InterviewPack `json:"packs"`
+	Pagination Pagination      `json:"pagination"`
+}
+
+type PaginatedInterviewPackSummaries struct {
+	Packs      []InterviewPackSummary `json:"packs"`
+	Pagination Pagination             `json:"pagination"`
+}
+
+type InterviewPackRepository interface {
+	Create(ctx context.Context, pack *InterviewPack) error
+	FindByID(ctx context.Context, id uuid.UUID) (*InterviewPack, error)
+	FindAll(ctx context.Context, limit, offset int, includeInactive bool) ([]InterviewPack, error)
+	Count(ctx context.Context, includeInactive bool) (int64, error)
+	Update(ctx context.Context, pack *InterviewPack) error
+	SoftDelete(ctx context.Context, id uuid.UUID) error
+}
+
+type InterviewPackService interface {
+	Create(ctx context.Context, req *CreateInterviewPackRequest) (*InterviewPack, error)
+	GetByID(ctx context.Context, id uuid.UUID) (*InterviewPack, error)
+	GetAll(ctx context.Context, page, limit int, includeInactive bool) (*PaginatedInterviewPacks, error)
+	ListAvailable(ctx context.Context, page, limit int) (*PaginatedInterviewPackSummaries, error)
+	Update(ctx context.Context, id uuid.UUID, req *UpdateInterviewPackRequest) (*InterviewPack, error)
+	Delete(ctx context.Context, id uuid.UUID) error
+}
@@ -0,0 +1,78 @@
+package domain
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+var (
+	ErrInterviewShareNotFound = errors.New("share link not found")
+	ErrInterviewShareExpired  = errors.New("share link has expired or been revoked")
+	ErrInterviewNotShareable  = errors.New("only completed interviews can be shared")
+	ErrShareCommentsDisabled  = errors.New("comments are disabled for this share link")
+	ErrShareCommentLimit      = errors.New("comment limit reached for this share link")
+)
+
+type InterviewShare struct {
+	ID            uuid.UUID  `json:"id"`
+	InterviewID   uuid.UUID  `json:"interview_id"`
+	UserID        uuid.UUID  `json:"user_id"`
+	AllowComments bool       `json:"allow_comments"`
+	ExpiresAt     time.Time  `json:"expires_at"`
+	RevokedAt     *time.Time `json:"revoked_at,omitempty"`
+	CreatedAt     time.Time  `json:"created_at"`
+}
+
+type MentorComment struct {
+	ID          uuid.UUID `json:"id"`
+	InterviewID uuid.UUID `json:"interview_id"`
+	ShareID     uuid.UUID `json:"share_id"`
+	MentorName  string    `json:"mentor_name"`
+	QuestionID  *int      `json:"question_id,omitempty"`
+	Comment     string    `json:"comment"`
+	CreatedAt   time.Time `json:"created_at"`
+}
+
+type CreateInterviewShareRequest struct {
+	ExpiresInHours int  `json:"expires_in_hours" validate:"omitempty,min=1,max=720"`
+	AllowComments  bool `json:"allow_comments"`
+}
+
+type InterviewShareResponse struct {
+	Share *InterviewShare `json:"share"`
+	Token string          `json:"token"`
+	URL   string          `json:"url"`
+}
+
+type MentorCommentRequest struct {
+	MentorName string `json:"mentor_name" validate:"required,min=1,max=100"`
+	QuestionID *int   `json:"question_id" validate:"omitempty,min=1"`
+	Comment    string `json:"comment" validate:"required,min=1,max=5000"`
+}
+
+type SharedInterviewReport struct {
+	Interview     *InterviewForUser `json:"interview"`
+	AllowComments bool              `json:"allow_comments"`
+	ExpiresAt     time.Time         `json:"expires_at"`
+	Comments      []MentorComment   `json:"comments"`
+}
+
+type InterviewShareRepository interface {
+	Create(ctx context.Context, share *InterviewShare) error
+	FindByID(ctx context.Context, id uuid.UUID) (*InterviewShare, error)
+	Revoke(ctx context.Context, id uuid.UUID, revokedAt time.Time) error
+	CreateComment(ctx context.Context, comment *MentorComment) error
+	FindCommentsByInterviewID(ctx context.Context, interviewID uuid.UUID) ([]MentorComment, error)
+	CountCommentsByShareID(ctx context.Context, shareID uuid.UUID) (int64, error)
+}
+
+type InterviewShareService interface {
+	Create(ctx context.Context, userID, interviewID uuid.UUID, req *CreateInterviewShareRequest) (*InterviewShareResponse, error)
+	Revoke(ctx context.Context, userID, interviewID, shareID uuid.UUID) error
+	GetSharedReport(ctx context.Context, token string) (*SharedInterviewReport, error)
+	AddComment(ctx context.Context, token string, req *MentorCommentRequest) (*MentorComment, error)
+	GetComments(ctx context.Context, userID, interviewID uuid.UUID) ([]MentorComment, error)
+}
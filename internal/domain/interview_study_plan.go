@@ -0,0 +1,67 @@
+package domain
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+var (
+	ErrStudyPlanNotFound       = errors.New("study plan not found")
+	ErrStudyPlanNotAvailable   = errors.New("a study plan can only be generated for a completed interview")
+	ErrStudyPlanTaskNotFound   = errors.New("study plan task not found")
+	ErrStudyPlanGenerateFailed = errors.New("could not generate a study plan, please try again")
+)
+
+type StudyPlanResource struct {
+	Title string `json:"title"`
+	Type  string `json:"type"`
+	Notes string `json:"notes,omitempty"`
+}
+
+type StudyPlanTopic struct {
+	Name      string              `json:"name"`
+	Reason    string              `json:"reason"`
+	Resources []StudyPlanResource `json:"resources"`
+}
+
+type StudyPlanTask struct {
+	ID          int        `json:"id"`
+	Day         int        `json:"day"`
+	Topic       string     `json:"topic"`
+	Title       string     `json:"title"`
+	Description string     `json:"description"`
+	Completed   bool       `json:"completed"`
+	CompletedAt *time.Time `json:"completed_at,omitempty"`
+}
+
+type StudyPlan struct {
+	ID           uuid.UUID        `json:"id"`
+	InterviewID  uuid.UUID        `json:"interview_id"`
+	UserID       uuid.UUID        `json:"user_id"`
+	Summary      string           `json:"summary"`
+	WeakAreas    []string         `json:"weak_areas"`
+	Topics       []StudyPlanTopic `json:"topics"`
+	Tasks        []StudyPlanTask  `json:"tasks"`
+	DurationDays int              `json:"duration_days"`
+	CreatedAt    time.Time        `json:"created_at"`
+	UpdatedAt    time.Time        `json:"updated_at"`
+}
+
+type UpdateStudyPlanTaskRequest struct {
+	Completed *bool `json:"completed" validate:"required"`
+}
+
+type StudyPlanRepository interface {
+	Upsert(ctx context.Context, plan *StudyPlan) error
+	FindByInterviewID(ctx context.Context, interviewID uuid.UUID) (*StudyPlan, error)
+	UpdateTasks(ctx context.Context, id uuid.UUID, tasks []StudyPlanTask, updatedAt time.Time) error
+}
+
+type StudyPlanService interface {
+	Generate(ctx context.Context, userID, interviewID uuid.UUID, regenerate bool) (*StudyPlan, error)
+	Get(ctx context.Context, userID, interviewID uuid.UUID) (*StudyPlan, error)
+	SetTaskCompleted(ctx context.Context, userID, interviewID uuid.UUID, taskID int, completed bool) (*StudyPlan, error)
+}
@@ -0,0 +1,68 @@
+package domain
+
+import (
+	"context"
+	"encoding/json"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+const (
+	JobInterviewReminder   = "interview.reminder"
+	JobPaymentNotification = "payment.notification"
+	JobAccountPurge        = "account.purge"
+	JobAccountPurgeNotice  = "account.purge_notice"
+)
+
+type QueuedJob struct {
+	ID         string          `json:"id"`
+	Type       string          `json:"type"`
+	Payload    json.RawMessage `json:"payload"`
+	Attempts   int             `json:"attempts"`
+	LastError  string          `json:"last_error,omitempty"`
+	EnqueuedAt time.Time       `json:"enqueued_at"`
+	FailedAt   *time.Time      `json:"failed_at,omitempty"`
+}
+
+type JobQueueStats struct {
+	Queued    int64 `json:"queued"`
+	Running   int64 `json:"running"`
+	Scheduled int64 `json:"scheduled"`
+	Dead      int64 `json:"dead"`
+}
+
+type PaginatedQueuedJobs struct {
+	Jobs       []QueuedJob `json:"jobs"`
+	Pagination Pagination  `json:"pagination"`
+}
+
+type InterviewReminderJob struct {
+	InterviewID uuid.UUID `json:"interview_id"`
+}
+
+type PaymentNotificationJob struct {
+	OrderID string                 `json:"order_id"`
+	Payload map[string]interface{} `json:"payload"`
+}
+
+type AccountPurgeJob struct {
+	UserID uuid.UUID `json:"user_id"`
+}
+
+type JobEnqueuer interface {
+	Enqueue(ctx context.Context, jobType string, payload interface{}) (string, error)
+}
+
+type JobScheduler interface {
+	EnqueueAt(ctx context.Context, jobType string, payload interface{}, at time.Time) (string, error)
+	Cancel(ctx context.Context, id string) error
+}
+
+type JobService interface {
+	GetStats(ctx context.Context) (*JobQueueStats, error)
+	GetDeadJobs(ctx context.Context, page, limit int) (*PaginatedQueuedJobs, error)
+	GetDeadJob(ctx context.Context, id uuid.UUID) (*QueuedJob, error)
+	RetryDeadJob(ctx context.Context, id uuid.UUID) (*QueuedJob, error)
+	DeleteDeadJob(ctx context.Context, id uuid.UUID) error
+}
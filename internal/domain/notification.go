@@ -0,0 +1,54 @@
+package domain
+
+import (
+	"context"
+	"encoding/json"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+const (
+	NotificationQuotaWarning   = "quota_warning"
+	NotificationQuotaExhausted = "quota_exhausted"
+)
+
+// QuotaWarningThresholds are the percentages of a monthly limit at which the
+// user is notified, once each per feature and period.
+var QuotaWarningThresholds = []int{80, 100}
+
+type Notification struct {
+	ID        uuid.UUID       `json:"id"`
+	UserID    uuid.UUID       `json:"user_id"`
+	Type      string          `json:"type"`
+	Title     string          `json:"title"`
+	Body      string          `json:"body"`
+	Data      json.RawMessage `json:"data,omitempty"`
+	DedupeKey string          `json:"-"`
+	CreatedAt time.Time       `json:"created_at"`
+}
+
+type QuotaThresholdEvent struct {
+	UserID      uuid.UUID   `json:"user_id"`
+	Feature     FeatureType `json:"feature"`
+	PeriodMonth time.Time   `json:"period_month"`
+	Threshold   int         `json:"threshold"`
+	Used        int         `json:"used"`
+	Limit       int         `json:"limit"`
+}
+
+type PaginatedNotifications struct {
+	Notifications []Notification `json:"notifications"`
+	Pagination    Pagination     `json:"pagination"`
+}
+
+type NotificationRepository interface {
+	CreateOnce(ctx context.Context, notification *Notification) (bool, error)
+	FindByUserID(ctx context.Context, userID uuid.UUID, limit, offset int) ([]Notification, error)
+	CountByUserID(ctx context.Context, userID uuid.UUID) (int64, error)
+}
+
+type NotificationService interface {
+	NotifyQuotaThreshold(ctx context.Context, event QuotaThresholdEvent) error
+	GetByUserID(ctx context.Context, userID uuid.UUID, page, limit int) (*PaginatedNotifications, error)
+}
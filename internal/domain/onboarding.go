@@ -0,0 +1,44 @@
+package domain
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+const (
+	OnboardingStepFirstResume    = "first_resume"
+	OnboardingStepFirstATSCheck  = "first_ats_check"
+	OnboardingStepFirstInterview = "first_interview"
+	OnboardingStepSubscribed     = "subscribed"
+)
+
+type OnboardingMilestones struct {
+	FirstResumeAt       *time.Time
+	FirstATSCheckAt     *time.Time
+	FirstInterviewAt    *time.Time
+	FirstSubscriptionAt *time.Time
+}
+
+type OnboardingStep struct {
+	Key         string     `json:"key"`
+	Label       string     `json:"label"`
+	Completed   bool       `json:"completed"`
+	CompletedAt *time.Time `json:"completed_at"`
+}
+
+type OnboardingStatus struct {
+	Completed      bool             `json:"completed"`
+	CompletedSteps int              `json:"completed_steps"`
+	TotalSteps     int              `json:"total_steps"`
+	Steps          []OnboardingStep `json:"steps"`
+}
+
+type OnboardingRepository interface {
+	GetMilestones(ctx context.Context, userID uuid.UUID) (*OnboardingMilestones, error)
+}
+
+type OnboardingService interface {
+	GetStatus(ctx context.Context, userID uuid.UUID) (*OnboardingStatus, error)
+}
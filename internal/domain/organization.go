@@ -0,0 +1,126 @@
+package domain
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type OrganizationRole string
+
+const (
+	OrganizationRoleOwner  OrganizationRole = "owner"
+	OrganizationRoleMember OrganizationRole = "member"
+)
+
+// Organization is a team account. Its plan is bought per seat and every
+// member draws on one quota pool of the plan's limits times the seats.
+type Organization struct {
+	ID          uuid.UUID  `json:"id"`
+	Name        string     `json:"name"`
+	OwnerID     uuid.UUID  `json:"owner_id"`
+	PlanID      *uuid.UUID `json:"plan_id,omitempty"`
+	Seats       int        `json:"seats"`
+	PeriodStart *time.Time `json:"period_start,omitempty"`
+	PeriodEnd   *time.Time `json:"period_end,omitempty"`
+	CreatedAt   time.Time  `json:"created_at"`
+	UpdatedAt   time.Time  `json:"updated_at"`
+	Plan        *Plan      `json:"plan,omitempty"`
+}
+
+type OrganizationMember struct {
+	OrganizationID uuid.UUID        `json:"organization_id"`
+	UserID         uuid.UUID        `json:"user_id"`
+	Role           OrganizationRole `json:"role"`
+	JoinedAt       time.Time        `json:"joined_at"`
+	Name           string           `json:"name,omitempty"`
+	Email          string           `json:"email,omitempty"`
+}
+
+type OrganizationInvitation struct {
+	ID             uuid.UUID  `json:"id"`
+	OrganizationID uuid.UUID  `json:"organization_id"`
+	Email          string     `json:"email"`
+	Token          string     `json:"-"`
+	InvitedBy      uuid.UUID  `json:"invited_by"`
+	ExpiresAt      time.Time  `json:"expires_at"`
+	AcceptedAt     *time.Time `json:"accepted_at,omitempty"`
+	CreatedAt      time.Time  `json:"created_at"`
+}
+
+// OrganizationPurchase records the seats a transaction buys, applied to the
+// organization once the payment succeeds.
+type OrganizationPurchase struct {
+	TransactionID  uuid.UUID  `json:"transaction_id"`
+	OrganizationID uuid.UUID  `json:"organization_id"`
+	PlanID         uuid.UUID  `json:"plan_id"`
+	Seats          int        `json:"seats"`
+	AppliedAt      *time.Time `json:"applied_at,omitempty"`
+	CreatedAt      time.Time  `json:"created_at"`
+}
+
+type OrganizationDetail struct {
+	Organization
+	Role        OrganizationRole         `json:"role"`
+	Members     []OrganizationMember     `json:"members"`
+	Invitations []OrganizationInvitation `json:"invitations,omitempty"`
+}
+
+type OrganizationMemberUsage struct {
+	UserID         uuid.UUID `json:"user_id"`
+	Name           string    `json:"name"`
+	UsedResumes    int       `json:"used_resumes"`
+	UsedATSChecks  int       `json:"used_ats_checks"`
+	UsedInterviews int       `json:"used_interviews"`
+}
+
+type OrganizationQuota struct {
+	UserQuota
+	Members []OrganizationMemberUsage `json:"members"`
+}
+
+type CreateOrganizationRequest struct {
+	Name string `json:"name" validate:"required,min=2,max=100"`
+}
+
+type InviteOrganizationMemberRequest struct {
+	Email string `json:"email" validate:"required,email,max=255"`
+}
+
+type AcceptOrganizationInvitationRequest struct {
+	Token string `json:"token" validate:"required,max=128"`
+}
+
+type CreateSeatTransactionRequest struct {
+	PlanID uuid.UUID `json:"plan_id" validate:"required"`
+	Seats  int       `json:"seats" validate:"required,min=1,max=500"`
+}
+
+type OrganizationRepository interface {
+	Create(ctx context.Context, organization *Organization) error
+	FindByID(ctx context.Context, id uuid.UUID) (*Organization, error)
+	FindMembership(ctx context.Context, userID uuid.UUID) (*OrganizationMember, error)
+	FindMembers(ctx context.Context, organizationID uuid.UUID) ([]OrganizationMember, error)
+	CountMembers(ctx context.Context, organizationID uuid.UUID) (int, error)
+	AddMember(ctx context.Context, member *OrganizationMember) error
+	RemoveMember(ctx context.Context, organizationID, userID uuid.UUID) (bool, error)
+	CreateInvitation(ctx context.Context, invitation *OrganizationInvitation) error
+	FindInvitationByToken(ctx context.Context, token string) (*OrganizationInvitation, error)
+	FindPendingInvitations(ctx context.Context, organizationID uuid.UUID, now time.Time) ([]OrganizationInvitation, error)
+	AcceptInvitation(ctx context.Context, id uuid.UUID, acceptedAt time.Time) (bool, error)
+	CreatePurchase(ctx context.Context, purchase *OrganizationPurchase) error
+	ApplyPurchase(ctx context.Context, transactionID uuid.UUID, periodStart, periodEnd time.Time) (bool, error)
+	IncrementUsageWithinLimit(ctx context.Context, organizationID uuid.UUID, feature FeatureType, periodMonth time.Time, amount, limit int) (int, error)
+	GetUsage(ctx context.Context, organizationID uuid.UUID, periodMonth time.Time) (map[FeatureType]int, error)
+	GetMemberUsage(ctx context.Context, organizationID uuid.UUID, periodMonth time.Time) ([]OrganizationMemberUsage, error)
+}
+
+type OrganizationService interface {
+	Create(ctx context.Context, userID uuid.UUID, req *CreateOrganizationRequest) (*Organization, error)
+	GetForUser(ctx context.Context, userID uuid.UUID) (*OrganizationDetail, error)
+	Invite(ctx context.Context, userID uuid.UUID, req *InviteOrganizationMemberRequest) (*OrganizationInvitation, error)
+	AcceptInvitation(ctx context.Context, userID uuid.UUID, req *AcceptOrganizationInvitationRequest) (*Organization, error)
+	RemoveMember(ctx context.Context, userID, memberID uuid.UUID) error
+	Leave(ctx context.Context, userID uuid.UUID) error
+}
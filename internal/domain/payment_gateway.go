@@ -0,0 +1,34 @@
+package domain
+
+import (
+	"context"
+
+	"github.com/raflytch/careerly-server/pkg/midtrans"
+
+	"github.com/google/uuid"
+)
+
+type PaymentGateway interface {
+	CreateSnapTransaction(req midtrans.CreateTransactionRequest) (*midtrans.CreateTransactionResponse, error)
+	ChargeCard(req midtrans.ChargeCardRequest) (*midtrans.ChargeResponse, error)
+	CheckTransaction(orderID string) (*midtrans.TransactionStatusResponse, error)
+	VerifySignatureKey(orderID, statusCode, grossAmount, signatureKey string) bool
+}
+
+// PaymentSimulator settles orders without a real payment. It only exists in
+// development against the Midtrans sandbox.
+type PaymentSimulator interface {
+	Settle(orderID, grossAmount string) midtrans.TransactionStatusResponse
+	Sign(orderID, statusCode, grossAmount string) string
+}
+
+type PaymentSimulationService interface {
+	SimulatePayment(ctx context.Context, userID uuid.UUID, orderID string) error
+}
+
+var (
+	_ PaymentGateway   = (*midtrans.Client)(nil)
+	_ PaymentGateway   = (*midtrans.Mock)(nil)
+	_ PaymentGateway   = (*midtrans.Simulator)(nil)
+	_ PaymentSimulator = (*midtrans.Simulator)(nil)
+)
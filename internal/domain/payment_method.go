@@ -0,0 +1,43 @@
+package domain
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// PaymentMethod is a card the user agreed to save on a Midtrans card
+// payment. Token is the saved_token_id Midtrans charges it with and is never
+// returned to clients.
+type PaymentMethod struct {
+	ID             uuid.UUID  `json:"id"`
+	UserID         uuid.UUID  `json:"user_id"`
+	Token          string     `json:"-"`
+	MaskedCard     string     `json:"masked_card"`
+	CardType       string     `json:"card_type,omitempty"`
+	Bank           string     `json:"bank,omitempty"`
+	TokenExpiresAt *time.Time `json:"token_expires_at,omitempty"`
+	LastUsedAt     *time.Time `json:"last_used_at,omitempty"`
+	CreatedAt      time.Time  `json:"created_at"`
+	UpdatedAt      time.Time  `json:"updated_at"`
+}
+
+type ChargeSavedCardRequest struct {
+	PlanID          uuid.UUID `json:"plan_id" validate:"required"`
+	PaymentMethodID uuid.UUID `json:"payment_method_id" validate:"required"`
+}
+
+type PaymentMethodRepository interface {
+	Upsert(ctx context.Context, method *PaymentMethod) error
+	FindByID(ctx context.Context, id uuid.UUID) (*PaymentMethod, error)
+	FindByUserID(ctx context.Context, userID uuid.UUID) ([]PaymentMethod, error)
+	MarkUsed(ctx context.Context, id uuid.UUID, usedAt time.Time) error
+	Delete(ctx context.Context, id uuid.UUID) error
+	ReencryptBatch(ctx context.Context, afterID uuid.UUID, limit int) (*ResumeBatchResult, error)
+}
+
+type PaymentMethodService interface {
+	GetByUserID(ctx context.Context, userID uuid.UUID) ([]PaymentMethod, error)
+	Delete(ctx context.Context, userID uuid.UUID, id uuid.UUID) error
+}
@@ -9,39 +9,43 @@ import (
 )
 
 type Plan struct {
-	ID            uuid.UUID       `json:"id"`
-	Name          string          `json:"name"`
-	DisplayName   string          `json:"display_name"`
-	Price         decimal.Decimal `json:"price"`
-	DurationDays  *int            `json:"duration_days"`
-	MaxResumes    *int            `json:"max_resumes"`
-	MaxATSChecks  *int            `json:"max_ats_checks"`
-	MaxInterviews *int            `json:"max_interviews"`
-	IsActive      bool            `json:"is_active"`
-	CreatedAt     time.Time       `json:"created_at"`
-	DeletedAt     *time.Time      `json:"deleted_at,omitempty"`
+	ID             uuid.UUID       `json:"id"`
+	Name           string          `json:"name"`
+	DisplayName    string          `json:"display_name"`
+	Price          decimal.Decimal `json:"price"`
+	DurationDays   *int            `json:"duration_days"`
+	MaxResumes     *int            `json:"max_resumes"`
+	MaxATSChecks   *int            `json:"max_ats_checks"`
+	MaxInterviews  *int            `json:"max_interviews"`
+	CustomBranding bool            `json:"custom_branding"`
+	IsActive       bool            `json:"is_active"`
+	ArchivedAt     *time.Time      `json:"archived_at,omitempty"`
+	CreatedAt      time.Time       `json:"created_at"`
+	DeletedAt      *time.Time      `json:"deleted_at,omitempty"`
 }
 
 type CreatePlanRequest struct {
-	Name          string          `json:"name"`
-	DisplayName   string          `json:"display_name"`
-	Price         decimal.Decimal `json:"price"`
-	DurationDays  *int            `json:"duration_days"`
-	MaxResumes    *int            `json:"max_resumes"`
-	MaxATSChecks  *int            `json:"max_ats_checks"`
-	MaxInterviews *int            `json:"max_interviews"`
-	IsActive      *bool           `json:"is_active"`
+	Name           string          `json:"name" validate:"required,min=2,max=50"`
+	DisplayName    string          `json:"display_name" validate:"required,min=2,max=100"`
+	Price          decimal.Decimal `json:"price"`
+	DurationDays   *int            `json:"duration_days" validate:"omitempty,min=1"`
+	MaxResumes     *int            `json:"max_resumes" validate:"omitempty,min=0"`
+	MaxATSChecks   *int            `json:"max_ats_checks" validate:"omitempty,min=0"`
+	MaxInterviews  *int            `json:"max_interviews" validate:"omitempty,min=0"`
+	CustomBranding *bool           `json:"custom_branding"`
+	IsActive       *bool           `json:"is_active"`
 }
 
 type UpdatePlanRequest struct {
-	Name          *string          `json:"name"`
-	DisplayName   *string          `json:"display_name"`
-	Price         *decimal.Decimal `json:"price"`
-	DurationDays  *int             `json:"duration_days"`
-	MaxResumes    *int             `json:"max_resumes"`
-	MaxATSChecks  *int             `json:"max_ats_checks"`
-	MaxInterviews *int             `json:"max_interviews"`
-	IsActive      *bool            `json:"is_active"`
+	Name           *string          `json:"name" validate:"omitempty,min=2,max=50"`
+	DisplayName    *string          `json:"display_name" validate:"omitempty,min=2,max=100"`
+	Price          *decimal.Decimal `json:"price"`
+	DurationDays   *int             `json:"duration_days" validate:"omitempty,min=1"`
+	MaxResumes     *int             `json:"max_resumes" validate:"omitempty,min=0"`
+	MaxATSChecks   *int             `json:"max_ats_checks" validate:"omitempty,min=0"`
+	MaxInterviews  *int             `json:"max_interviews" validate:"omitempty,min=0"`
+	CustomBranding *bool            `json:"custom_branding"`
+	IsActive       *bool            `json:"is_active"`
 }
 
 type PaginatedPlans struct {
@@ -49,6 +53,25 @@ type PaginatedPlans struct {
 	Pagination Pagination `json:"pagination"`
 }
 
+type PlanPricing struct {
+	Country        string          `json:"country,omitempty"`
+	Currency       string          `json:"currency"`
+	ChargeCurrency string          `json:"charge_currency"`
+	ExchangeRate   decimal.Decimal `json:"exchange_rate"`
+	TaxHint        string          `json:"tax_hint,omitempty"`
+}
+
+type LocalizedPlan struct {
+	Plan
+	LocalPrice decimal.Decimal `json:"local_price"`
+}
+
+type LocalizedPlans struct {
+	Plans      []LocalizedPlan `json:"plans"`
+	Pagination Pagination      `json:"pagination"`
+	Pricing    PlanPricing     `json:"pricing"`
+}
+
 type PlanRepository interface {
 	Create(ctx context.Context, plan *Plan) error
 	FindByID(ctx context.Context, id uuid.UUID) (*Plan, error)
@@ -57,6 +80,8 @@ type PlanRepository interface {
 	Count(ctx context.Context, includeInactive bool) (int64, error)
 	Update(ctx context.Context, plan *Plan) error
 	SoftDelete(ctx context.Context, id uuid.UUID) error
+	SetArchived(ctx context.Context, id uuid.UUID, archivedAt *time.Time) error
+	CountUsage(ctx context.Context, id uuid.UUID) (int64, error)
 }
 
 type PlanService interface {
@@ -65,4 +90,10 @@ type PlanService interface {
 	GetAll(ctx context.Context, page, limit int, includeInactive bool) (*PaginatedPlans, error)
 	Update(ctx context.Context, id uuid.UUID, req *UpdatePlanRequest) (*Plan, error)
 	Delete(ctx context.Context, id uuid.UUID) error
+	Archive(ctx context.Context, id uuid.UUID) (*Plan, error)
+	Unarchive(ctx context.Context, id uuid.UUID) (*Plan, error)
+}
+
+type PricingService interface {
+	Localize(plans *PaginatedPlans, country string) *LocalizedPlans
 }
@@ -0,0 +1,75 @@
+package domain
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type PromptKey string
+
+const (
+	PromptResumeRewrite       PromptKey = "resume.rewrite"
+	PromptATSAnalysis         PromptKey = "ats.analysis"
+	PromptInterviewQuestions  PromptKey = "interview.questions"
+	PromptInterviewEvaluation PromptKey = "interview.evaluation"
+)
+
+type Prompt struct {
+	ID        uuid.UUID `json:"id"`
+	Key       PromptKey `json:"key"`
+	Version   int       `json:"version"`
+	Content   string    `json:"content"`
+	Note      string    `json:"note,omitempty"`
+	IsActive  bool      `json:"is_active"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+type PromptSummary struct {
+	Key           PromptKey `json:"key"`
+	ActiveVersion *int      `json:"active_version"`
+	LatestVersion int       `json:"latest_version"`
+	UsesDefault   bool      `json:"uses_default"`
+}
+
+type PromptDetail struct {
+	Key      PromptKey `json:"key"`
+	Default  string    `json:"default"`
+	Active   *Prompt   `json:"active,omitempty"`
+	Versions []Prompt  `json:"versions"`
+}
+
+type CreatePromptVersionRequest struct {
+	Content  string `json:"content" validate:"required,min=20,max=20000"`
+	Note     string `json:"note" validate:"max=255"`
+	Activate bool   `json:"activate"`
+}
+
+type PromptRepository interface {
+	Create(ctx context.Context, prompt *Prompt) error
+	FindByKey(ctx context.Context, key PromptKey) ([]Prompt, error)
+	FindActive(ctx context.Context, key PromptKey) (*Prompt, error)
+	FindVersion(ctx context.Context, key PromptKey, version int) (*Prompt, error)
+	Activate(ctx context.Context, key PromptKey, version int) error
+	Deactivate(ctx context.Context, key PromptKey) error
+	Delete(ctx context.Context, key PromptKey, version int) error
+	SaveUsage(ctx context.Context, key PromptKey, entityID uuid.UUID, version int, usedAt time.Time) error
+	FindUsage(ctx context.Context, key PromptKey, entityID uuid.UUID) (int, error)
+}
+
+type PromptProvider interface {
+	Prompt(ctx context.Context, key PromptKey) (string, int)
+	PromptForUser(ctx context.Context, key PromptKey, userID uuid.UUID) (string, int)
+	RecordUse(ctx context.Context, key PromptKey, userID, entityID uuid.UUID, version int)
+}
+
+type PromptService interface {
+	PromptProvider
+	List(ctx context.Context) ([]PromptSummary, error)
+	Get(ctx context.Context, key PromptKey) (*PromptDetail, error)
+	CreateVersion(ctx context.Context, key PromptKey, req *CreatePromptVersionRequest) (*Prompt, error)
+	Activate(ctx context.Context, key PromptKey, version int) (*Prompt, error)
+	Reset(ctx context.Context, key PromptKey) error
+	DeleteVersion(ctx context.Context, key PromptKey, version int) error
+}
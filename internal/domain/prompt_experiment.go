@@ -0,0 +1,74 @@
+package domain
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type PromptExperimentStatus string
+
+const (
+	PromptExperimentRunning PromptExperimentStatus = "running"
+	PromptExperimentStopped PromptExperimentStatus = "stopped"
+)
+
+// PromptVariant is a stored prompt version, or 0 for the built-in default,
+// and its share of the experiment's users relative to the other variants.
+type PromptVariant struct {
+	Version int `json:"version" validate:"min=0"`
+	Weight  int `json:"weight" validate:"required,min=1,max=100"`
+}
+
+type PromptExperiment struct {
+	ID        uuid.UUID              `json:"id"`
+	Key       PromptKey              `json:"key"`
+	Name      string                 `json:"name"`
+	Variants  []PromptVariant        `json:"variants"`
+	Status    PromptExperimentStatus `json:"status"`
+	StartedAt time.Time              `json:"started_at"`
+	EndedAt   *time.Time             `json:"ended_at,omitempty"`
+}
+
+type CreatePromptExperimentRequest struct {
+	Key      PromptKey       `json:"key" validate:"required,oneof=interview.evaluation ats.analysis"`
+	Name     string          `json:"name" validate:"required,min=3,max=100"`
+	Variants []PromptVariant `json:"variants" validate:"required,min=2,max=4,dive"`
+}
+
+type PromptVariantResult struct {
+	Version      int      `json:"version"`
+	Weight       int      `json:"weight"`
+	Users        int64    `json:"users"`
+	Outputs      int64    `json:"outputs"`
+	Ratings      int64    `json:"ratings"`
+	ThumbsUp     int64    `json:"thumbs_up"`
+	ThumbsDown   int64    `json:"thumbs_down"`
+	Satisfaction float64  `json:"satisfaction"`
+	AverageScore *float64 `json:"average_score"`
+}
+
+type PromptExperimentResults struct {
+	Experiment *PromptExperiment     `json:"experiment"`
+	Variants   []PromptVariantResult `json:"variants"`
+}
+
+type PromptExperimentRepository interface {
+	Create(ctx context.Context, experiment *PromptExperiment) error
+	FindByID(ctx context.Context, id uuid.UUID) (*PromptExperiment, error)
+	FindAll(ctx context.Context) ([]PromptExperiment, error)
+	FindRunning(ctx context.Context, key PromptKey) (*PromptExperiment, error)
+	Stop(ctx context.Context, id uuid.UUID, endedAt time.Time) error
+	Assign(ctx context.Context, experimentID, userID uuid.UUID, version int, assignedAt time.Time) (int, error)
+	SaveOutput(ctx context.Context, experimentID, userID, entityID uuid.UUID, version int, createdAt time.Time) error
+	CountAssignments(ctx context.Context, experimentID uuid.UUID) (map[int]int64, error)
+	SummarizeOutputs(ctx context.Context, experimentID uuid.UUID) ([]PromptVariantResult, error)
+}
+
+type PromptExperimentService interface {
+	Create(ctx context.Context, req *CreatePromptExperimentRequest) (*PromptExperiment, error)
+	List(ctx context.Context) ([]PromptExperiment, error)
+	GetResults(ctx context.Context, id uuid.UUID) (*PromptExperimentResults, error)
+	Stop(ctx context.Context, id uuid.UUID) (*PromptExperiment, error)
+}
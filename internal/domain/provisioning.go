@@ -0,0 +1,58 @@
+package domain
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type ProvisioningJobStatus string
+
+const (
+	ProvisioningJobStatusPending   ProvisioningJobStatus = "pending"
+	ProvisioningJobStatusSucceeded ProvisioningJobStatus = "succeeded"
+	ProvisioningJobStatusFailed    ProvisioningJobStatus = "failed"
+)
+
+type ProvisioningJob struct {
+	ID            uuid.UUID             `json:"id"`
+	TransactionID uuid.UUID             `json:"transaction_id"`
+	OrderID       string                `json:"order_id"`
+	UserID        uuid.UUID             `json:"user_id"`
+	PlanID        uuid.UUID             `json:"plan_id"`
+	Status        ProvisioningJobStatus `json:"status"`
+	Attempts      int                   `json:"attempts"`
+	MaxAttempts   int                   `json:"max_attempts"`
+	LastError     *string               `json:"last_error,omitempty"`
+	NextAttemptAt time.Time             `json:"next_attempt_at"`
+	CreatedAt     time.Time             `json:"created_at"`
+	UpdatedAt     time.Time             `json:"updated_at"`
+}
+
+type PaginatedProvisioningJobs struct {
+	Jobs       []ProvisioningJob `json:"jobs"`
+	Pagination Pagination        `json:"pagination"`
+}
+
+type ProvisioningRunResult struct {
+	Processed int `json:"processed"`
+	Succeeded int `json:"succeeded"`
+	Failed    int `json:"failed"`
+}
+
+type ProvisioningJobRepository interface {
+	Create(ctx context.Context, job *ProvisioningJob) error
+	FindByID(ctx context.Context, id uuid.UUID) (*ProvisioningJob, error)
+	FindAll(ctx context.Context, status ProvisioningJobStatus, limit, offset int) ([]ProvisioningJob, error)
+	Count(ctx context.Context, status ProvisioningJobStatus) (int64, error)
+	FindDue(ctx context.Context, now time.Time, limit int) ([]ProvisioningJob, error)
+	Update(ctx context.Context, job *ProvisioningJob) error
+}
+
+type ProvisioningService interface {
+	ProcessDue(ctx context.Context) (*ProvisioningRunResult, error)
+	GetAll(ctx context.Context, status ProvisioningJobStatus, page, limit int) (*PaginatedProvisioningJobs, error)
+	GetByID(ctx context.Context, id uuid.UUID) (*ProvisioningJob, error)
+	Replay(ctx context.Context, id uuid.UUID) (*ProvisioningJob, error)
+}
@@ -0,0 +1,64 @@
+package domain
+
+import (
+	"context"
+	"mime/multipart"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// BankQuestion is a curated question for a job position. Interviews for a
+// position with enough matching questions draw from the bank instead of
+// asking the model.
+type BankQuestion struct {
+	ID            uuid.UUID          `json:"id"`
+	JobPosition   string             `json:"job_position"`
+	Type          QuestionType       `json:"type"`
+	Question      string             `json:"question"`
+	Options       []Option           `json:"options,omitempty"`
+	Difficulty    QuestionDifficulty `json:"difficulty,omitempty"`
+	CorrectAnswer string             `json:"correct_answer"`
+	DedupeKey     string             `json:"-"`
+	CreatedAt     time.Time          `json:"created_at"`
+}
+
+type QuestionBankFilter struct {
+	JobPosition string       `query:"position" validate:"omitempty,max=255"`
+	Type        QuestionType `query:"type" validate:"omitempty,oneof=essay multiple_choice"`
+}
+
+type PaginatedBankQuestions struct {
+	Questions  []BankQuestion `json:"questions"`
+	Pagination Pagination     `json:"pagination"`
+}
+
+// QuestionImportError explains why a CSV row was rejected. Row counts the
+// header as row 1, so it matches what a spreadsheet shows.
+type QuestionImportError struct {
+	Row     int    `json:"row"`
+	Field   string `json:"field,omitempty"`
+	Message string `json:"message"`
+}
+
+type QuestionImportResult struct {
+	Rows       int                   `json:"rows"`
+	Imported   int                   `json:"imported"`
+	Duplicates int                   `json:"duplicates"`
+	Invalid    int                   `json:"invalid"`
+	Errors     []QuestionImportError `json:"errors"`
+}
+
+type QuestionBankRepository interface {
+	CreateBatch(ctx context.Context, questions []BankQuestion) (int, error)
+	FindAll(ctx context.Context, filter QuestionBankFilter, limit, offset int) ([]BankQuestion, error)
+	Count(ctx context.Context, filter QuestionBankFilter) (int64, error)
+	FindRandom(ctx context.Context, jobPosition string, questionType QuestionType, difficulty QuestionDifficulty, limit int) ([]BankQuestion, error)
+	Delete(ctx context.Context, id uuid.UUID) (bool, error)
+}
+
+type QuestionBankService interface {
+	Import(ctx context.Context, file *multipart.FileHeader) (*QuestionImportResult, error)
+	GetAll(ctx context.Context, filter QuestionBankFilter, page, limit int) (*PaginatedBankQuestions, error)
+	Delete(ctx context.Context, id uuid.UUID) error
+}
@@ -0,0 +1,48 @@
+package domain
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type QuotaOverride struct {
+	ID        uuid.UUID   `json:"id"`
+	UserID    uuid.UUID   `json:"user_id"`
+	Feature   FeatureType `json:"feature"`
+	Amount    int         `json:"amount"`
+	Unlimited bool        `json:"unlimited"`
+	Reason    string      `json:"reason,omitempty"`
+	ExpiresAt time.Time   `json:"expires_at"`
+	CreatedBy uuid.UUID   `json:"created_by"`
+	CreatedAt time.Time   `json:"created_at"`
+	RevokedAt *time.Time  `json:"revoked_at,omitempty"`
+}
+
+type QuotaGrant struct {
+	Amount    int
+	Unlimited bool
+}
+
+type CreateQuotaOverrideRequest struct {
+	Feature   FeatureType `json:"feature" validate:"required,oneof=resume ats_check interview"`
+	Amount    int         `json:"amount" validate:"omitempty,min=1,max=100000"`
+	Unlimited bool        `json:"unlimited"`
+	ExpiresAt time.Time   `json:"expires_at" validate:"required"`
+	Reason    string      `json:"reason" validate:"omitempty,max=255"`
+}
+
+type QuotaOverrideRepository interface {
+	Create(ctx context.Context, override *QuotaOverride) error
+	FindByID(ctx context.Context, id uuid.UUID) (*QuotaOverride, error)
+	FindByUserID(ctx context.Context, userID uuid.UUID) ([]QuotaOverride, error)
+	SumActive(ctx context.Context, userID uuid.UUID, feature FeatureType, at time.Time) (*QuotaGrant, error)
+	Revoke(ctx context.Context, id uuid.UUID, revokedAt time.Time) error
+}
+
+type QuotaOverrideService interface {
+	Create(ctx context.Context, adminID, userID uuid.UUID, req *CreateQuotaOverrideRequest) (*QuotaOverride, error)
+	GetByUserID(ctx context.Context, userID uuid.UUID) ([]QuotaOverride, error)
+	Revoke(ctx context.Context, userID, overrideID uuid.UUID) (*QuotaOverride, error)
+}
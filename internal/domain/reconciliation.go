@@ -0,0 +1,53 @@
+package domain
+
+import (
+	"context"
+	"time"
+
+	"github.com/shopspring/decimal"
+)
+
+type ReconciliationResult string
+
+const (
+	ReconciliationMatched              ReconciliationResult = "matched"
+	ReconciliationMissingSettlement    ReconciliationResult = "missing_settlement"
+	ReconciliationAmountMismatch       ReconciliationResult = "amount_mismatch"
+	ReconciliationUnrecordedSettlement ReconciliationResult = "unrecorded_settlement"
+	ReconciliationLookupFailed         ReconciliationResult = "lookup_failed"
+)
+
+type ReconciliationEntry struct {
+	OrderID        string               `json:"order_id"`
+	LocalStatus    TransactionStatus    `json:"local_status"`
+	LocalAmount    decimal.Decimal      `json:"local_amount"`
+	PaidAt         *time.Time           `json:"paid_at,omitempty"`
+	GatewayStatus  string               `json:"gateway_status,omitempty"`
+	GatewayAmount  *decimal.Decimal     `json:"gateway_amount,omitempty"`
+	SettlementTime *time.Time           `json:"settlement_time,omitempty"`
+	PaymentType    string               `json:"payment_type,omitempty"`
+	Result         ReconciliationResult `json:"result"`
+	Detail         string               `json:"detail,omitempty"`
+}
+
+type ReconciliationReport struct {
+	Month        string                `json:"month"`
+	GeneratedAt  time.Time             `json:"generated_at"`
+	Checked      int                   `json:"checked"`
+	Matched      int                   `json:"matched"`
+	Mismatched   int                   `json:"mismatched"`
+	LocalTotal   decimal.Decimal       `json:"local_total"`
+	SettledTotal decimal.Decimal       `json:"settled_total"`
+	Entries      []ReconciliationEntry `json:"entries"`
+}
+
+type ReconciliationExport struct {
+	Filename    string
+	ContentType string
+	Data        []byte
+}
+
+type ReconciliationService interface {
+	GenerateReport(ctx context.Context, month time.Time) (*ReconciliationReport, error)
+	ExportCSV(ctx context.Context, month time.Time) (*ReconciliationExport, error)
+}
@@ -0,0 +1,70 @@
+package domain
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type ReferralStatus string
+
+const (
+	ReferralStatusPending   ReferralStatus = "pending"
+	ReferralStatusQualified ReferralStatus = "qualified"
+	ReferralStatusRewarded  ReferralStatus = "rewarded"
+)
+
+var (
+	ErrReferralCodeNotFound = errors.New("referral code not found")
+	ErrSelfReferral         = errors.New("users cannot refer themselves")
+	ErrAlreadyReferred      = errors.New("user has already been referred")
+)
+
+type ReferralCode struct {
+	UserID    uuid.UUID `json:"user_id"`
+	Code      string    `json:"code"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+type Referral struct {
+	ID          uuid.UUID      `json:"id"`
+	ReferrerID  uuid.UUID      `json:"referrer_id"`
+	ReferredID  uuid.UUID      `json:"referred_id"`
+	Code        string         `json:"code"`
+	Status      ReferralStatus `json:"status"`
+	RewardDays  int            `json:"reward_days"`
+	QualifiedAt *time.Time     `json:"qualified_at,omitempty"`
+	RewardedAt  *time.Time     `json:"rewarded_at,omitempty"`
+	CreatedAt   time.Time      `json:"created_at"`
+}
+
+type ReferralStats struct {
+	Code            string `json:"code"`
+	ShareURL        string `json:"share_url"`
+	TotalReferred   int64  `json:"total_referred"`
+	Pending         int64  `json:"pending"`
+	Qualified       int64  `json:"qualified"`
+	Rewarded        int64  `json:"rewarded"`
+	TotalRewardDays int64  `json:"total_reward_days"`
+	RewardDays      int    `json:"reward_days_per_referral"`
+}
+
+type ReferralRepository interface {
+	CreateCode(ctx context.Context, code *ReferralCode) error
+	FindCodeByUserID(ctx context.Context, userID uuid.UUID) (*ReferralCode, error)
+	FindCodeByCode(ctx context.Context, code string) (*ReferralCode, error)
+	Create(ctx context.Context, referral *Referral) error
+	FindByReferredID(ctx context.Context, referredID uuid.UUID) (*Referral, error)
+	FindByReferrerIDAndStatus(ctx context.Context, referrerID uuid.UUID, status ReferralStatus) ([]Referral, error)
+	CountByStatus(ctx context.Context, referrerID uuid.UUID) (map[ReferralStatus]int64, error)
+	SumRewardedDays(ctx context.Context, referrerID uuid.UUID) (int64, error)
+	Update(ctx context.Context, referral *Referral) error
+}
+
+type ReferralService interface {
+	GetStats(ctx context.Context, userID uuid.UUID) (*ReferralStats, error)
+	AttributeSignup(ctx context.Context, referredID uuid.UUID, code string) error
+	HandleSubscriptionCreated(ctx context.Context, userID uuid.UUID, subscription *Subscription) error
+}
@@ -17,15 +17,17 @@ const (
 )
 
 type Subscription struct {
-	ID        uuid.UUID          `json:"id"`
-	UserID    uuid.UUID          `json:"user_id"`
-	PlanID    uuid.UUID          `json:"plan_id"`
-	StartDate time.Time          `json:"start_date"`
-	EndDate   time.Time          `json:"end_date"`
-	Status    SubscriptionStatus `json:"status"`
-	CreatedAt time.Time          `json:"created_at"`
-	DeletedAt *time.Time         `json:"deleted_at,omitempty"`
-	Plan      *Plan              `json:"plan,omitempty"`
+	ID              uuid.UUID          `json:"id"`
+	UserID          uuid.UUID          `json:"user_id"`
+	PlanID          uuid.UUID          `json:"plan_id"`
+	ScheduledPlanID *uuid.UUID         `json:"scheduled_plan_id,omitempty"`
+	StartDate       time.Time          `json:"start_date"`
+	EndDate         time.Time          `json:"end_date"`
+	Status          SubscriptionStatus `json:"status"`
+	CreatedAt       time.Time          `json:"created_at"`
+	DeletedAt       *time.Time         `json:"deleted_at,omitempty"`
+	Plan            *Plan              `json:"plan,omitempty"`
+	ScheduledPlan   *Plan              `json:"scheduled_plan,omitempty"`
 }
 
 type SubscriptionRepository interface {
@@ -33,8 +35,11 @@ type SubscriptionRepository interface {
 	FindByID(ctx context.Context, id uuid.UUID) (*Subscription, error)
 	FindActiveByUserID(ctx context.Context, userID uuid.UUID) (*Subscription, error)
 	FindAllByUserID(ctx context.Context, userID uuid.UUID, limit, offset int) ([]Subscription, error)
+	FindEndedActive(ctx context.Context, before time.Time, limit int) ([]Subscription, error)
 	CountByUserID(ctx context.Context, userID uuid.UUID) (int64, error)
 	Update(ctx context.Context, subscription *Subscription) error
+	Grant(ctx context.Context, subscription *Subscription, replaces *uuid.UUID, transaction *Transaction) error
+	Expire(ctx context.Context, id uuid.UUID) (bool, error)
 	SoftDelete(ctx context.Context, id uuid.UUID) error
 }
 
@@ -58,21 +63,59 @@ type Usage struct {
 
 type UsageRepository interface {
 	FindOrCreate(ctx context.Context, userID uuid.UUID, feature FeatureType, periodMonth time.Time) (*Usage, error)
-	IncrementCount(ctx context.Context, id uuid.UUID) error
-	GetCurrentMonthUsage(ctx context.Context, userID uuid.UUID, feature FeatureType) (*Usage, error)
-	GetAllCurrentMonthUsage(ctx context.Context, userID uuid.UUID) ([]Usage, error)
+	IncrementWithinLimit(ctx context.Context, id uuid.UUID, amount, limit int) (int, error)
+	GetMonthUsage(ctx context.Context, userID uuid.UUID, feature FeatureType, periodMonth time.Time) (*Usage, error)
+	GetAllMonthUsage(ctx context.Context, userID uuid.UUID, periodMonth time.Time) ([]Usage, error)
 }
 
 type ResumeContent struct {
-	PersonalInfo PersonalInfo `json:"personal_info"`
-	Summary      string       `json:"summary"`
-	Experience   []Experience `json:"experience"`
-	Education    []Education  `json:"education"`
-	Skills       []string     `json:"skills"`
-	Achievements []string     `json:"achievements,omitempty"`
-	Volunteer    []Volunteer  `json:"volunteer,omitempty"`
-	Languages    []Language   `json:"languages,omitempty"`
-	Hobbies      []string     `json:"hobbies,omitempty"`
+	PersonalInfo   PersonalInfo    `json:"personal_info"`
+	Summary        string          `json:"summary"`
+	Experience     []Experience    `json:"experience"`
+	Education      []Education     `json:"education"`
+	Skills         []string        `json:"skills"`
+	Achievements   []string        `json:"achievements,omitempty"`
+	Volunteer      []Volunteer     `json:"volunteer,omitempty"`
+	Languages      []Language      `json:"languages,omitempty"`
+	Hobbies        []string        `json:"hobbies,omitempty"`
+	SectionOrder   []string        `json:"section_order,omitempty"`
+	CustomSections []CustomSection `json:"custom_sections,omitempty"`
+	SchemaVersion  int             `json:"schema_version"`
+}
+
+const (
+	SectionSummary      = "summary"
+	SectionExperience   = "experience"
+	SectionEducation    = "education"
+	SectionSkills       = "skills"
+	SectionAchievements = "achievements"
+	SectionVolunteer    = "volunteer"
+	SectionLanguages    = "languages"
+	SectionHobbies      = "hobbies"
+)
+
+var DefaultSectionOrder = []string{
+	SectionSummary,
+	SectionExperience,
+	SectionEducation,
+	SectionSkills,
+	SectionAchievements,
+	SectionVolunteer,
+	SectionLanguages,
+	SectionHobbies,
+}
+
+type CustomSection struct {
+	Key     string               `json:"key" validate:"required,min=1,max=50"`
+	Title   string               `json:"title" validate:"required,min=1,max=100"`
+	Entries []CustomSectionEntry `json:"entries" validate:"omitempty,dive"`
+}
+
+type CustomSectionEntry struct {
+	Heading    string `json:"heading,omitempty" validate:"omitempty,max=255"`
+	Subheading string `json:"subheading,omitempty" validate:"omitempty,max=255"`
+	Date       string `json:"date,omitempty" validate:"omitempty,max=100"`
+	Content    string `json:"content" validate:"omitempty,max=5000"`
 }
 
 type PersonalInfo struct {
@@ -83,6 +126,11 @@ type PersonalInfo struct {
 	LinkedIn    string `json:"linkedin,omitempty"`
 	Portfolio   string `json:"portfolio,omitempty"`
 	DateOfBirth string `json:"date_of_birth,omitempty"`
+	PhotoURL    string `json:"photo_url,omitempty"`
+	ShowPhoto   bool   `json:"show_photo"`
+	// RedactPersonal is the resume's default for PDFs and share links:
+	// no date of birth or phone, and the location cut down to its region.
+	RedactPersonal bool `json:"redact_personal"`
 }
 
 type Experience struct {
@@ -118,14 +166,17 @@ type Language struct {
 }
 
 type Resume struct {
-	ID        uuid.UUID     `json:"id"`
-	UserID    uuid.UUID     `json:"user_id"`
-	Title     string        `json:"title"`
-	Content   ResumeContent `json:"content"`
-	IsActive  bool          `json:"is_active"`
-	CreatedAt time.Time     `json:"created_at"`
-	UpdatedAt time.Time     `json:"updated_at"`
-	DeletedAt *time.Time    `json:"deleted_at,omitempty"`
+	ID         uuid.UUID     `json:"id"`
+	UserID     uuid.UUID     `json:"user_id"`
+	Title      string        `json:"title"`
+	Content    ResumeContent `json:"content"`
+	IsActive   bool          `json:"is_active"`
+	CreatedAt  time.Time     `json:"created_at"`
+	UpdatedAt  time.Time     `json:"updated_at"`
+	DeletedAt  *time.Time    `json:"deleted_at,omitempty"`
+	FolderID   *uuid.UUID    `json:"folder_id,omitempty"`
+	DataRegion string        `json:"data_region,omitempty"`
+	Tags       []ResumeLabel `json:"tags,omitempty"`
 }
 
 type ResumeRaw struct {
@@ -140,30 +191,64 @@ type ResumeRaw struct {
 }
 
 type CreateResumeRequest struct {
-	Title        string       `json:"title" validate:"required,min=3,max=255"`
-	PersonalInfo PersonalInfo `json:"personal_info" validate:"required"`
-	Summary      string       `json:"summary" validate:"omitempty"`
-	Experience   []Experience `json:"experience" validate:"omitempty,dive"`
-	Education    []Education  `json:"education" validate:"omitempty,dive"`
-	Skills       []string     `json:"skills" validate:"omitempty"`
-	Achievements []string     `json:"achievements" validate:"omitempty"`
-	Volunteer    []Volunteer  `json:"volunteer" validate:"omitempty,dive"`
-	Languages    []Language   `json:"languages" validate:"omitempty,dive"`
-	Hobbies      []string     `json:"hobbies" validate:"omitempty"`
+	Title          string          `json:"title" validate:"required,min=3,max=255"`
+	PersonalInfo   PersonalInfo    `json:"personal_info" validate:"required"`
+	Summary        string          `json:"summary" validate:"omitempty"`
+	Experience     []Experience    `json:"experience" validate:"omitempty,dive"`
+	Education      []Education     `json:"education" validate:"omitempty,dive"`
+	Skills         []string        `json:"skills" validate:"omitempty"`
+	Achievements   []string        `json:"achievements" validate:"omitempty"`
+	Volunteer      []Volunteer     `json:"volunteer" validate:"omitempty,dive"`
+	Languages      []Language      `json:"languages" validate:"omitempty,dive"`
+	Hobbies        []string        `json:"hobbies" validate:"omitempty"`
+	SectionOrder   []string        `json:"section_order" validate:"omitempty,max=50"`
+	CustomSections []CustomSection `json:"custom_sections" validate:"omitempty,max=20,dive"`
+	Force          bool            `json:"force"`
+}
+
+type DuplicateResume struct {
+	ResumeID   uuid.UUID `json:"resume_id"`
+	Title      string    `json:"title"`
+	Similarity float64   `json:"similarity"`
 }
 
 type UpdateResumeRequest struct {
-	Title        *string       `json:"title" validate:"omitempty,min=3,max=255"`
-	PersonalInfo *PersonalInfo `json:"personal_info" validate:"omitempty"`
-	Summary      *string       `json:"summary" validate:"omitempty"`
-	Experience   []Experience  `json:"experience" validate:"omitempty,dive"`
-	Education    []Education   `json:"education" validate:"omitempty,dive"`
-	Skills       []string      `json:"skills" validate:"omitempty"`
-	Achievements []string      `json:"achievements" validate:"omitempty"`
-	Volunteer    []Volunteer   `json:"volunteer" validate:"omitempty,dive"`
-	Languages    []Language    `json:"languages" validate:"omitempty,dive"`
-	Hobbies      []string      `json:"hobbies" validate:"omitempty"`
-	IsActive     *bool         `json:"is_active" validate:"omitempty"`
+	Title          *string         `json:"title" validate:"omitempty,min=3,max=255"`
+	PersonalInfo   *PersonalInfo   `json:"personal_info" validate:"omitempty"`
+	Summary        *string         `json:"summary" validate:"omitempty"`
+	Experience     []Experience    `json:"experience" validate:"omitempty,dive"`
+	Education      []Education     `json:"education" validate:"omitempty,dive"`
+	Skills         []string        `json:"skills" validate:"omitempty"`
+	Achievements   []string        `json:"achievements" validate:"omitempty"`
+	Volunteer      []Volunteer     `json:"volunteer" validate:"omitempty,dive"`
+	Languages      []Language      `json:"languages" validate:"omitempty,dive"`
+	Hobbies        []string        `json:"hobbies" validate:"omitempty"`
+	SectionOrder   []string        `json:"section_order" validate:"omitempty,max=50"`
+	CustomSections []CustomSection `json:"custom_sections" validate:"omitempty,max=20,dive"`
+	IsActive       *bool           `json:"is_active" validate:"omitempty"`
+}
+
+type ResumePhotoVisibilityRequest struct {
+	ShowPhoto *bool `json:"show_photo" validate:"required"`
+}
+
+type ResumeRedactionRequest struct {
+	RedactPersonal *bool `json:"redact_personal" validate:"required"`
+}
+
+type PDFFont string
+
+const (
+	PDFFontHelvetica PDFFont = "helvetica"
+	PDFFontTimes     PDFFont = "times"
+	PDFFontCourier   PDFFont = "courier"
+)
+
+type PDFStyleOptions struct {
+	AccentColor string  `query:"accent_color" validate:"omitempty,hexcolor,len=7"`
+	Font        PDFFont `query:"font" validate:"omitempty,oneof=helvetica times courier"`
+	Condensed   bool    `query:"condensed"`
+	Redact      *bool   `query:"redact"`
 }
 
 type PaginatedResumes struct {
@@ -171,40 +256,103 @@ type PaginatedResumes struct {
 	Pagination Pagination `json:"pagination"`
 }
 
+type ResumeSearchResult struct {
+	Resume         Resume  `json:"resume"`
+	Rank           float64 `json:"rank"`
+	TitleHighlight string  `json:"title_highlight"`
+	Snippet        string  `json:"snippet"`
+}
+
+type PaginatedResumeSearch struct {
+	Query      string               `json:"query"`
+	Results    []ResumeSearchResult `json:"results"`
+	Pagination Pagination           `json:"pagination"`
+}
+
+// ResumeSectionAIStatus reports how the AI rewrite went for one part of the
+// resume. Index is set for experience entries.
+type ResumeSectionAIStatus struct {
+	Section string `json:"section"`
+	Index   *int   `json:"index,omitempty"`
+	Status  string `json:"status"`
+}
+
 type ResumeResponse struct {
-	Resume             *Resume `json:"resume"`
-	AIConversionStatus string  `json:"ai_conversion_status"`
+	Resume             *Resume                 `json:"resume"`
+	AIConversionStatus string                  `json:"ai_conversion_status"`
+	AISections         []ResumeSectionAIStatus `json:"ai_sections,omitempty"`
+}
+
+type ResumeBatchResult struct {
+	LastID  uuid.UUID `json:"last_id"`
+	Scanned int       `json:"scanned"`
+	Updated int       `json:"updated"`
 }
 
 type ResumeRepository interface {
 	Create(ctx context.Context, resume *Resume) error
 	FindByID(ctx context.Context, id uuid.UUID) (*Resume, error)
-	FindByUserID(ctx context.Context, userID uuid.UUID, limit, offset int) ([]Resume, error)
-	CountByUserID(ctx context.Context, userID uuid.UUID) (int64, error)
+	FindByUserID(ctx context.Context, userID uuid.UUID, filter ResumeFilter, limit, offset int) ([]Resume, error)
+	FindLatestActiveByUserID(ctx context.Context, userID uuid.UUID) (*Resume, error)
+	CountByUserID(ctx context.Context, userID uuid.UUID, filter ResumeFilter) (int64, error)
+	Search(ctx context.Context, userID uuid.UUID, query string, limit, offset int) ([]ResumeSearchResult, error)
+	CountSearch(ctx context.Context, userID uuid.UUID, query string) (int64, error)
 	Update(ctx context.Context, resume *Resume) error
 	SoftDelete(ctx context.Context, id uuid.UUID) error
+	FindDeletedByID(ctx context.Context, id uuid.UUID) (*Resume, error)
+	FindDeletedByUserID(ctx context.Context, userID uuid.UUID, limit, offset int) ([]Resume, error)
+	CountDeletedByUserID(ctx context.Context, userID uuid.UUID) (int64, error)
+	Restore(ctx context.Context, id uuid.UUID) error
+	PurgeDeletedBefore(ctx context.Context, before time.Time) (int64, error)
+	ReencryptBatch(ctx context.Context, afterID uuid.UUID, limit int) (*ResumeBatchResult, error)
+	MigrateSchemaBatch(ctx context.Context, afterID uuid.UUID, limit int) (*ResumeBatchResult, error)
 }
 
 type ResumeService interface {
 	Create(ctx context.Context, userID uuid.UUID, req *CreateResumeRequest) (*ResumeResponse, error)
 	GetByID(ctx context.Context, userID uuid.UUID, id uuid.UUID) (*Resume, error)
-	GetByUserID(ctx context.Context, userID uuid.UUID, page, limit int) (*PaginatedResumes, error)
+	GetByUserID(ctx context.Context, userID uuid.UUID, filter ResumeFilter, page, limit int) (*PaginatedResumes, error)
+	Search(ctx context.Context, userID uuid.UUID, query string, page, limit int) (*PaginatedResumeSearch, error)
 	Update(ctx context.Context, userID uuid.UUID, id uuid.UUID, req *UpdateResumeRequest) (*ResumeResponse, error)
 	Delete(ctx context.Context, userID uuid.UUID, id uuid.UUID) error
-	GeneratePDF(ctx context.Context, userID uuid.UUID, id uuid.UUID) ([]byte, error)
+	GetTrash(ctx context.Context, userID uuid.UUID, page, limit int) (*PaginatedResumes, error)
+	Restore(ctx context.Context, userID uuid.UUID, id uuid.UUID) (*Resume, error)
+	SetPhoto(ctx context.Context, userID uuid.UUID, id uuid.UUID, photoURL string) (*Resume, error)
+	SetPhotoVisibility(ctx context.Context, userID uuid.UUID, id uuid.UUID, show bool) (*Resume, error)
+	SetRedaction(ctx context.Context, userID uuid.UUID, id uuid.UUID, redact bool) (*Resume, error)
+	GeneratePDF(ctx context.Context, userID uuid.UUID, id uuid.UUID, opts *PDFStyleOptions) ([]byte, error)
+	GetPDFLink(ctx context.Context, userID uuid.UUID, id uuid.UUID, opts *PDFStyleOptions) (*ArtifactLink, error)
+	Optimize(ctx context.Context, userID uuid.UUID, id uuid.UUID, req *OptimizeResumeRequest) (*ResumeOptimization, error)
+	ApplySuggestions(ctx context.Context, userID uuid.UUID, id uuid.UUID, req *ApplySuggestionsRequest) (*ApplySuggestionsResult, error)
+	GenerateBullets(ctx context.Context, userID uuid.UUID, req *GenerateBulletsRequest) (*GeneratedBullets, error)
 }
 
+const UnlimitedQuota = -1
+
 type QuotaService interface {
-	CheckAndIncrementUsage(ctx context.Context, userID uuid.UUID, feature FeatureType) error
+	CheckAndIncrementUsage(ctx context.Context, userID uuid.UUID, feature FeatureType) (int, error)
+	ConsumeUsage(ctx context.Context, userID uuid.UUID, feature FeatureType, amount int) (int, error)
 	GetUserQuota(ctx context.Context, userID uuid.UUID) (*UserQuota, error)
+	GetOrganizationQuota(ctx context.Context, userID uuid.UUID) (*OrganizationQuota, error)
+	GetActivePlan(ctx context.Context, userID uuid.UUID) (*Plan, error)
 }
 
 type UserQuota struct {
-	PlanName       string `json:"plan_name"`
-	MaxResumes     int    `json:"max_resumes"`
-	MaxATSChecks   int    `json:"max_ats_checks"`
-	MaxInterviews  int    `json:"max_interviews"`
-	UsedResumes    int    `json:"used_resumes"`
-	UsedATSChecks  int    `json:"used_ats_checks"`
-	UsedInterviews int    `json:"used_interviews"`
+	PlanName             string     `json:"plan_name"`
+	OrganizationID       *uuid.UUID `json:"organization_id,omitempty"`
+	Seats                int        `json:"seats,omitempty"`
+	MaxResumes           int        `json:"max_resumes"`
+	MaxATSChecks         int        `json:"max_ats_checks"`
+	MaxInterviews        int        `json:"max_interviews"`
+	UsedResumes          int        `json:"used_resumes"`
+	UsedATSChecks        int        `json:"used_ats_checks"`
+	UsedInterviews       int        `json:"used_interviews"`
+	HistoryRetentionDays int        `json:"history_retention_days"`
+	Timezone             string     `json:"timezone"`
+	PeriodStart          time.Time  `json:"period_start"`
+	ResetsAt             time.Time  `json:"resets_at"`
+	// Unavailable lists the features the user cannot use at all, which
+	// happens without a plan for features no override grants. Their Max
+	// fields are 0 but do not mean unlimited.
+	Unavailable []FeatureType `json:"unavailable,omitempty"`
 }
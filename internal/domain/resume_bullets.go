@@ -0,0 +1,18 @@
+package domain
+
+type GenerateBulletsRequest struct {
+	Description    string `json:"description" validate:"required,min=10,max=2000"`
+	Position       string `json:"position" validate:"omitempty,max=255"`
+	Company        string `json:"company" validate:"omitempty,max=255"`
+	Industry       string `json:"industry" validate:"omitempty,max=255"`
+	JobDescription string `json:"job_description" validate:"omitempty,max=10000"`
+}
+
+type ResumeBullet struct {
+	Text                  string   `json:"text"`
+	QuantificationPrompts []string `json:"quantification_prompts"`
+}
+
+type GeneratedBullets struct {
+	Bullets []ResumeBullet `json:"bullets"`
+}
@@ -0,0 +1,56 @@
+package domain
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type ResumeDraft struct {
+	ID         uuid.UUID     `json:"id"`
+	UserID     uuid.UUID     `json:"user_id"`
+	Title      string        `json:"title"`
+	Content    ResumeContent `json:"content"`
+	CreatedAt  time.Time     `json:"created_at"`
+	UpdatedAt  time.Time     `json:"updated_at"`
+	DataRegion string        `json:"data_region,omitempty"`
+}
+
+type CreateResumeDraftRequest struct {
+	Title string `json:"title" validate:"omitempty,max=255"`
+}
+
+type UpdateResumeDraftRequest struct {
+	Title          *string         `json:"title" validate:"omitempty,max=255"`
+	PersonalInfo   *PersonalInfo   `json:"personal_info" validate:"omitempty"`
+	Summary        *string         `json:"summary" validate:"omitempty"`
+	Experience     []Experience    `json:"experience" validate:"omitempty,dive"`
+	Education      []Education     `json:"education" validate:"omitempty,dive"`
+	Skills         []string        `json:"skills" validate:"omitempty"`
+	Achievements   []string        `json:"achievements" validate:"omitempty"`
+	Volunteer      []Volunteer     `json:"volunteer" validate:"omitempty,dive"`
+	Languages      []Language      `json:"languages" validate:"omitempty,dive"`
+	Hobbies        []string        `json:"hobbies" validate:"omitempty"`
+	SectionOrder   []string        `json:"section_order" validate:"omitempty,max=50"`
+	CustomSections []CustomSection `json:"custom_sections" validate:"omitempty,max=20,dive"`
+}
+
+type ResumeDraftRepository interface {
+	Create(ctx context.Context, draft *ResumeDraft) error
+	FindByID(ctx context.Context, id uuid.UUID) (*ResumeDraft, error)
+	FindByUserID(ctx context.Context, userID uuid.UUID) ([]ResumeDraft, error)
+	CountByUserID(ctx context.Context, userID uuid.UUID) (int64, error)
+	Update(ctx context.Context, draft *ResumeDraft) error
+	Delete(ctx context.Context, id uuid.UUID) error
+	ReencryptBatch(ctx context.Context, afterID uuid.UUID, limit int) (*ResumeBatchResult, error)
+}
+
+type ResumeDraftService interface {
+	Create(ctx context.Context, userID uuid.UUID, req *CreateResumeDraftRequest) (*ResumeDraft, error)
+	GetByID(ctx context.Context, userID, id uuid.UUID) (*ResumeDraft, error)
+	GetByUserID(ctx context.Context, userID uuid.UUID) ([]ResumeDraft, error)
+	Update(ctx context.Context, userID, id uuid.UUID, req *UpdateResumeDraftRequest) (*ResumeDraft, error)
+	Delete(ctx context.Context, userID, id uuid.UUID) error
+	Publish(ctx context.Context, userID, id uuid.UUID, force bool) (*ResumeResponse, error)
+}
@@ -0,0 +1,85 @@
+package domain
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// ResumeTag is a user-defined label; a resume may carry several.
+type ResumeTag struct {
+	ID          uuid.UUID `json:"id"`
+	UserID      uuid.UUID `json:"user_id"`
+	Name        string    `json:"name"`
+	ResumeCount int       `json:"resume_count"`
+	CreatedAt   time.Time `json:"created_at"`
+}
+
+// ResumeFolder groups resumes; a resume is in at most one folder.
+type ResumeFolder struct {
+	ID          uuid.UUID `json:"id"`
+	UserID      uuid.UUID `json:"user_id"`
+	Name        string    `json:"name"`
+	ResumeCount int       `json:"resume_count"`
+	CreatedAt   time.Time `json:"created_at"`
+}
+
+// ResumeLabel is the short form of a tag shown on each resume.
+type ResumeLabel struct {
+	ID   uuid.UUID `json:"id"`
+	Name string    `json:"name"`
+}
+
+// ResumeFilter narrows a resume listing. Nil fields match every resume.
+type ResumeFilter struct {
+	TagID    *uuid.UUID
+	FolderID *uuid.UUID
+}
+
+type ResumeTagRequest struct {
+	Name string `json:"name" validate:"required,min=1,max=50"`
+}
+
+type ResumeFolderRequest struct {
+	Name string `json:"name" validate:"required,min=1,max=100"`
+}
+
+type SetResumeTagsRequest struct {
+	TagIDs []uuid.UUID `json:"tag_ids" validate:"max=20"`
+}
+
+// MoveResumeRequest puts a resume in a folder; a null folder_id takes it out
+// of its folder.
+type MoveResumeRequest struct {
+	FolderID *uuid.UUID `json:"folder_id"`
+}
+
+type ResumeLibraryRepository interface {
+	CreateTag(ctx context.Context, tag *ResumeTag) (bool, error)
+	FindTagByID(ctx context.Context, id uuid.UUID) (*ResumeTag, error)
+	FindTagsByUserID(ctx context.Context, userID uuid.UUID) ([]ResumeTag, error)
+	CountOwnedTags(ctx context.Context, userID uuid.UUID, ids []uuid.UUID) (int, error)
+	RenameTag(ctx context.Context, tag *ResumeTag) (bool, error)
+	DeleteTag(ctx context.Context, id uuid.UUID) error
+	SetResumeTags(ctx context.Context, resumeID uuid.UUID, tagIDs []uuid.UUID) error
+	CreateFolder(ctx context.Context, folder *ResumeFolder) (bool, error)
+	FindFolderByID(ctx context.Context, id uuid.UUID) (*ResumeFolder, error)
+	FindFoldersByUserID(ctx context.Context, userID uuid.UUID) ([]ResumeFolder, error)
+	RenameFolder(ctx context.Context, folder *ResumeFolder) (bool, error)
+	DeleteFolder(ctx context.Context, id uuid.UUID) error
+	MoveResume(ctx context.Context, resumeID uuid.UUID, folderID *uuid.UUID) error
+}
+
+type ResumeLibraryService interface {
+	CreateTag(ctx context.Context, userID uuid.UUID, req *ResumeTagRequest) (*ResumeTag, error)
+	GetTags(ctx context.Context, userID uuid.UUID) ([]ResumeTag, error)
+	RenameTag(ctx context.Context, userID, id uuid.UUID, req *ResumeTagRequest) (*ResumeTag, error)
+	DeleteTag(ctx context.Context, userID, id uuid.UUID) error
+	SetResumeTags(ctx context.Context, userID, resumeID uuid.UUID, req *SetResumeTagsRequest) (*Resume, error)
+	CreateFolder(ctx context.Context, userID uuid.UUID, req *ResumeFolderRequest) (*ResumeFolder, error)
+	GetFolders(ctx context.Context, userID uuid.UUID) ([]ResumeFolder, error)
+	RenameFolder(ctx context.Context, userID, id uuid.UUID, req *ResumeFolderRequest) (*ResumeFolder, error)
+	DeleteFolder(ctx context.Context, userID, id uuid.UUID) error
+	MoveResume(ctx context.Context, userID, resumeID uuid.UUID, req *MoveResumeRequest) (*Resume, error)
+}
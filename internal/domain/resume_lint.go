@@ -0,0 +1,50 @@
+package domain
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type LintSeverity string
+
+const (
+	LintSeverityWarning LintSeverity = "warning"
+	LintSeverityInfo    LintSeverity = "info"
+)
+
+type LintRule string
+
+const (
+	LintRuleMissingDate     LintRule = "missing_date"
+	LintRuleInvalidDate     LintRule = "invalid_date"
+	LintRuleDateOrder       LintRule = "date_order"
+	LintRuleOverlappingJobs LintRule = "overlapping_employment"
+	LintRuleFirstPerson     LintRule = "first_person"
+	LintRulePassiveVoice    LintRule = "passive_voice"
+	LintRuleLongBullet      LintRule = "long_bullet"
+	LintRuleMissingMetrics  LintRule = "missing_metrics"
+)
+
+type LintWarning struct {
+	Rule     LintRule     `json:"rule"`
+	Severity LintSeverity `json:"severity"`
+	Section  string       `json:"section"`
+	Index    *int         `json:"index,omitempty"`
+	Field    string       `json:"field,omitempty"`
+	Message  string       `json:"message"`
+	Excerpt  string       `json:"excerpt,omitempty"`
+}
+
+type ResumeLintReport struct {
+	ResumeID     uuid.UUID     `json:"resume_id"`
+	Warnings     []LintWarning `json:"warnings"`
+	WarningCount int           `json:"warning_count"`
+	InfoCount    int           `json:"info_count"`
+	CheckedAt    time.Time     `json:"checked_at"`
+}
+
+type ResumeLintService interface {
+	Lint(ctx context.Context, userID uuid.UUID, id uuid.UUID) (*ResumeLintReport, error)
+}
@@ -0,0 +1,56 @@
+package domain
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type ATSVendor string
+
+const (
+	ATSVendorWorkday    ATSVendor = "workday"
+	ATSVendorGreenhouse ATSVendor = "greenhouse"
+	ATSVendorLever      ATSVendor = "lever"
+)
+
+type OptimizeResumeRequest struct {
+	Vendor         ATSVendor `json:"vendor" validate:"required,oneof=workday greenhouse lever"`
+	JobDescription string    `json:"job_description" validate:"omitempty,max=10000"`
+}
+
+type ResumeSuggestion struct {
+	ID        string `json:"id"`
+	Section   string `json:"section"`
+	Index     *int   `json:"index,omitempty"`
+	Field     string `json:"field,omitempty"`
+	Original  string `json:"original"`
+	Suggested string `json:"suggested"`
+	Reason    string `json:"reason"`
+}
+
+type ResumeOptimization struct {
+	ID          uuid.UUID          `json:"id"`
+	ResumeID    uuid.UUID          `json:"resume_id"`
+	UserID      uuid.UUID          `json:"user_id"`
+	Vendor      ATSVendor          `json:"vendor"`
+	Suggestions []ResumeSuggestion `json:"suggestions"`
+	CreatedAt   time.Time          `json:"created_at"`
+	ExpiresAt   time.Time          `json:"expires_at"`
+}
+
+type ApplySuggestionsRequest struct {
+	OptimizationID uuid.UUID `json:"optimization_id" validate:"required"`
+	SuggestionIDs  []string  `json:"suggestion_ids" validate:"required,min=1,max=100"`
+}
+
+type SkippedSuggestion struct {
+	ID     string `json:"id"`
+	Reason string `json:"reason"`
+}
+
+type ApplySuggestionsResult struct {
+	Resume  *Resume             `json:"resume"`
+	Applied []string            `json:"applied"`
+	Skipped []SkippedSuggestion `json:"skipped"`
+}
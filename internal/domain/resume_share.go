@@ -0,0 +1,90 @@
+package domain
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+var (
+	ErrResumeShareNotFound   = errors.New("share link not found")
+	ErrResumeShareExpired    = errors.New("share link has expired or been revoked")
+	ErrResumeCommentNotFound = errors.New("comment not found")
+)
+
+type ResumeShare struct {
+	ID             uuid.UUID  `json:"id"`
+	ResumeID       uuid.UUID  `json:"resume_id"`
+	UserID         uuid.UUID  `json:"user_id"`
+	RedactPersonal bool       `json:"redact_personal"`
+	ExpiresAt      time.Time  `json:"expires_at"`
+	RevokedAt      *time.Time `json:"revoked_at,omitempty"`
+	CreatedAt      time.Time  `json:"created_at"`
+}
+
+type ResumeComment struct {
+	ID           uuid.UUID  `json:"id"`
+	ResumeID     uuid.UUID  `json:"resume_id"`
+	ShareID      uuid.UUID  `json:"share_id"`
+	ReviewerName string     `json:"reviewer_name"`
+	Section      *string    `json:"section,omitempty"`
+	Comment      string     `json:"comment"`
+	ResolvedAt   *time.Time `json:"resolved_at,omitempty"`
+	CreatedAt    time.Time  `json:"created_at"`
+}
+
+type CreateResumeShareRequest struct {
+	ExpiresInHours int   `json:"expires_in_hours" validate:"omitempty,min=1,max=720"`
+	RedactPersonal *bool `json:"redact_personal"`
+}
+
+type ResumeShareResponse struct {
+	Share *ResumeShare `json:"share"`
+	Token string       `json:"token"`
+	URL   string       `json:"url"`
+}
+
+type ResumeCommentRequest struct {
+	ReviewerName string  `json:"reviewer_name" validate:"required,min=1,max=100"`
+	Section      *string `json:"section" validate:"omitempty,min=1,max=50"`
+	Comment      string  `json:"comment" validate:"required,min=1,max=5000"`
+}
+
+type ResolveResumeCommentRequest struct {
+	Resolved *bool `json:"resolved" validate:"required"`
+}
+
+type ResumeCommentFilter struct {
+	Resolved *bool `query:"resolved"`
+}
+
+type SharedResume struct {
+	Resume    *Resume         `json:"resume"`
+	ExpiresAt time.Time       `json:"expires_at"`
+	Comments  []ResumeComment `json:"comments"`
+}
+
+type ResumeShareRepository interface {
+	Create(ctx context.Context, share *ResumeShare) error
+	FindByID(ctx context.Context, id uuid.UUID) (*ResumeShare, error)
+	Revoke(ctx context.Context, id uuid.UUID, revokedAt time.Time) error
+	CreateComment(ctx context.Context, comment *ResumeComment) error
+	FindCommentByID(ctx context.Context, id uuid.UUID) (*ResumeComment, error)
+	FindCommentsByResumeID(ctx context.Context, resumeID uuid.UUID, resolved *bool) ([]ResumeComment, error)
+	CountCommentsByShareID(ctx context.Context, shareID uuid.UUID) (int64, error)
+	SetCommentResolved(ctx context.Context, id uuid.UUID, resolvedAt *time.Time) error
+}
+
+type ResumeShareService interface {
+	Create(ctx context.Context, userID, resumeID uuid.UUID, req *CreateResumeShareRequest) (*ResumeShareResponse, error)
+	Revoke(ctx context.Context, userID, resumeID, shareID uuid.UUID) error
+	GetSharedResume(ctx context.Context, token string, visit *ShareVisit) (*SharedResume, error)
+	GetSharedPDF(ctx context.Context, token string, visit *ShareVisit) ([]byte, error)
+	GetAnalytics(ctx context.Context, userID, resumeID uuid.UUID) (*ResumeAnalytics, error)
+	AggregateAnalytics(ctx context.Context) (int64, error)
+	AddComment(ctx context.Context, token string, req *ResumeCommentRequest) (*ResumeComment, error)
+	GetComments(ctx context.Context, userID, resumeID uuid.UUID, filter *ResumeCommentFilter) ([]ResumeComment, error)
+	ResolveComment(ctx context.Context, userID, resumeID, commentID uuid.UUID, resolved bool) (*ResumeComment, error)
+}
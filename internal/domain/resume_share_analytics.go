@@ -0,0 +1,54 @@
+package domain
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+const (
+	ShareEventView     = "view"
+	ShareEventDownload = "download"
+
+	ShareReferrerDirect = "direct"
+	ShareCountryUnknown = "unknown"
+)
+
+type ShareVisit struct {
+	Referrer string
+	Country  string
+}
+
+type ResumeShareEvent struct {
+	ID         uuid.UUID
+	ShareID    uuid.UUID
+	ResumeID   uuid.UUID
+	Type       string
+	Referrer   string
+	Country    string
+	OccurredAt time.Time
+}
+
+type ResumeShareCount struct {
+	Key       string `json:"key"`
+	Views     int64  `json:"views"`
+	Downloads int64  `json:"downloads"`
+}
+
+type ResumeAnalytics struct {
+	ResumeID  uuid.UUID          `json:"resume_id"`
+	Views     int64              `json:"views"`
+	Downloads int64              `json:"downloads"`
+	Daily     []ResumeShareCount `json:"daily"`
+	Referrers []ResumeShareCount `json:"referrers"`
+	Countries []ResumeShareCount `json:"countries"`
+}
+
+type ResumeShareAnalyticsRepository interface {
+	RecordEvent(ctx context.Context, event *ResumeShareEvent) error
+	AggregateBefore(ctx context.Context, before time.Time) (int64, error)
+	CountByDay(ctx context.Context, resumeID uuid.UUID, since time.Time) ([]ResumeShareCount, error)
+	CountByReferrer(ctx context.Context, resumeID uuid.UUID) ([]ResumeShareCount, error)
+	CountByCountry(ctx context.Context, resumeID uuid.UUID) ([]ResumeShareCount, error)
+}
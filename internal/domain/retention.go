@@ -0,0 +1,12 @@
+package domain
+
+import "context"
+
+type RetentionPurgeResult struct {
+	ATSChecks  int64 `json:"ats_checks"`
+	Interviews int64 `json:"interviews"`
+}
+
+type RetentionService interface {
+	Purge(ctx context.Context) (*RetentionPurgeResult, error)
+}
@@ -0,0 +1,50 @@
+package domain
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+var (
+	ErrSessionNotFound = errors.New("session not found")
+	ErrSessionRevoked  = errors.New("session has been revoked or expired")
+)
+
+type Session struct {
+	ID         uuid.UUID  `json:"id"`
+	UserID     uuid.UUID  `json:"user_id"`
+	Device     string     `json:"device"`
+	IPAddress  string     `json:"ip_address"`
+	UserAgent  string     `json:"user_agent"`
+	Current    bool       `json:"current"`
+	CreatedAt  time.Time  `json:"created_at"`
+	LastSeenAt time.Time  `json:"last_seen_at"`
+	ExpiresAt  time.Time  `json:"expires_at"`
+	RevokedAt  *time.Time `json:"revoked_at,omitempty"`
+
+	ImpersonatorID *uuid.UUID `json:"impersonator_id,omitempty"`
+}
+
+type ClientInfo struct {
+	IPAddress string
+	UserAgent string
+}
+
+type SessionRepository interface {
+	Create(ctx context.Context, session *Session) error
+	FindByID(ctx context.Context, id uuid.UUID) (*Session, error)
+	FindActiveByUserID(ctx context.Context, userID uuid.UUID) ([]Session, error)
+	UpdateLastSeen(ctx context.Context, id uuid.UUID, lastSeenAt time.Time) error
+	Revoke(ctx context.Context, id uuid.UUID) error
+}
+
+type SessionService interface {
+	Create(ctx context.Context, userID uuid.UUID, client ClientInfo) (*Session, error)
+	CreateImpersonation(ctx context.Context, userID, impersonatorID uuid.UUID, client ClientInfo, ttl time.Duration) (*Session, error)
+	Validate(ctx context.Context, userID, sessionID uuid.UUID) error
+	GetActiveByUserID(ctx context.Context, userID, currentSessionID uuid.UUID) ([]Session, error)
+	Revoke(ctx context.Context, userID, sessionID uuid.UUID) error
+}
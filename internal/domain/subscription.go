@@ -0,0 +1,41 @@
+package domain
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+)
+
+type ScheduleChangeRequest struct {
+	PlanID uuid.UUID `json:"plan_id" validate:"required"`
+}
+
+// GrantSubscriptionRequest gives a user a plan without payment. Duration
+// defaults to the plan's own.
+type GrantSubscriptionRequest struct {
+	PlanID       uuid.UUID `json:"plan_id" validate:"required"`
+	DurationDays int       `json:"duration_days" validate:"omitempty,min=1,max=3650"`
+	Reason       string    `json:"reason" validate:"required,max=255"`
+}
+
+type SubscriptionGrant struct {
+	Subscription *Subscription `json:"subscription"`
+	Transaction  *Transaction  `json:"transaction"`
+	Reason       string        `json:"reason"`
+	GrantedBy    uuid.UUID     `json:"granted_by"`
+}
+
+type SubscriptionRolloverResult struct {
+	Expired  int `json:"expired"`
+	Switched int `json:"switched"`
+	Failed   int `json:"failed"`
+}
+
+type SubscriptionService interface {
+	ScheduleChange(ctx context.Context, userID uuid.UUID, req *ScheduleChangeRequest) (*Subscription, error)
+	CancelScheduledChange(ctx context.Context, userID uuid.UUID) (*Subscription, error)
+	Rollover(ctx context.Context) (*SubscriptionRolloverResult, error)
+	RedeemGift(ctx context.Context, userID uuid.UUID, req *RedeemGiftRequest) (*Subscription, error)
+	GetPurchasedGifts(ctx context.Context, userID uuid.UUID) ([]Gift, error)
+	Grant(ctx context.Context, adminID, userID uuid.UUID, req *GrantSubscriptionRequest) (*SubscriptionGrant, error)
+}
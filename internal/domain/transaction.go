@@ -20,6 +20,10 @@ const (
 	TransactionStatusCancel  TransactionStatus = "cancel"
 )
 
+// PaymentTypeManual marks zero-amount transactions recording a
+// subscription granted by an admin rather than bought.
+const PaymentTypeManual = "manual"
+
 var (
 	ErrTransactionNotFound      = errors.New("transaction not found")
 	ErrTransactionAlreadyPaid   = errors.New("transaction has already been paid")
@@ -50,12 +54,20 @@ type Transaction struct {
 	CreatedAt         time.Time         `json:"created_at"`
 	UpdatedAt         time.Time         `json:"updated_at"`
 	DeletedAt         *time.Time        `json:"-"`
+	AddonID           *uuid.UUID        `json:"addon_id,omitempty"`
 	Plan              *Plan             `json:"plan,omitempty"`
+	Addon             *Addon            `json:"addon,omitempty"`
+	Gifts             []Gift            `json:"gifts,omitempty"`
 	User              *User             `json:"-"`
 }
 
 type CreateTransactionRequest struct {
-	PlanID uuid.UUID `json:"plan_id" validate:"required"`
+	PlanID   uuid.UUID `json:"plan_id" validate:"required"`
+	SaveCard bool      `json:"save_card"`
+}
+
+type CreateAddonTransactionRequest struct {
+	AddonID uuid.UUID `json:"addon_id" validate:"required"`
 }
 
 type TransactionResponse struct {
@@ -64,6 +76,13 @@ type TransactionResponse struct {
 	RedirectURL string       `json:"redirect_url"`
 }
 
+type TransactionReconcileResult struct {
+	Checked int `json:"checked"`
+	Updated int `json:"updated"`
+	Expired int `json:"expired"`
+	Failed  int `json:"failed"`
+}
+
 type PaginatedTransactions struct {
 	Transactions []Transaction `json:"transactions"`
 	Pagination   Pagination    `json:"pagination"`
@@ -85,11 +104,29 @@ type MidtransWebhookPayload struct {
 	Currency          string `json:"currency"`
 }
 
+type PaymentNotification struct {
+	ID                uuid.UUID `json:"id"`
+	OrderID           string    `json:"order_id"`
+	SignatureKey      string    `json:"signature_key"`
+	TransactionStatus string    `json:"transaction_status"`
+	StatusCode        string    `json:"status_code"`
+	EventTime         time.Time `json:"event_time"`
+	ReceivedAt        time.Time `json:"received_at"`
+}
+
+type PaymentNotificationRepository interface {
+	Claim(ctx context.Context, notification *PaymentNotification) (bool, error)
+	Release(ctx context.Context, id uuid.UUID) error
+}
+
 type TransactionRepository interface {
 	Create(ctx context.Context, transaction *Transaction) error
+	CreateWithGifts(ctx context.Context, transaction *Transaction, gifts []Gift) error
 	FindByID(ctx context.Context, id uuid.UUID) (*Transaction, error)
 	FindByOrderID(ctx context.Context, orderID string) (*Transaction, error)
 	FindByUserID(ctx context.Context, userID uuid.UUID, limit, offset int) ([]Transaction, error)
+	FindPendingCreatedBefore(ctx context.Context, before time.Time, limit int) ([]Transaction, error)
+	FindCreatedBetween(ctx context.Context, from, to time.Time) ([]Transaction, error)
 	CountByUserID(ctx context.Context, userID uuid.UUID) (int64, error)
 	Update(ctx context.Context, transaction *Transaction) error
 	UpdateStatus(ctx context.Context, orderID string, status TransactionStatus, midtransResponse json.RawMessage) error
@@ -98,9 +135,16 @@ type TransactionRepository interface {
 
 type TransactionService interface {
 	CreateTransaction(ctx context.Context, userID uuid.UUID, req *CreateTransactionRequest) (*TransactionResponse, error)
+	CreateAddonTransaction(ctx context.Context, userID uuid.UUID, req *CreateAddonTransactionRequest) (*TransactionResponse, error)
+	CreateGiftTransaction(ctx context.Context, userID uuid.UUID, req *CreateGiftTransactionRequest) (*TransactionResponse, error)
+	ChargeSavedCard(ctx context.Context, userID uuid.UUID, req *ChargeSavedCardRequest) (*TransactionResponse, error)
+	CreateSeatTransaction(ctx context.Context, userID uuid.UUID, req *CreateSeatTransactionRequest) (*TransactionResponse, error)
 	GetByID(ctx context.Context, userID uuid.UUID, id uuid.UUID) (*Transaction, error)
 	GetByOrderID(ctx context.Context, orderID string) (*Transaction, error)
 	GetUserTransactions(ctx context.Context, userID uuid.UUID, page, limit int) (*PaginatedTransactions, error)
 	HandleWebhook(ctx context.Context, payload map[string]interface{}) error
+	ProcessNotification(ctx context.Context, job *PaymentNotificationJob) error
 	CheckTransactionStatus(ctx context.Context, orderID string) (*Transaction, error)
+	ProvisionSubscription(ctx context.Context, transactionID uuid.UUID) error
+	ReconcilePending(ctx context.Context, olderThan time.Duration) (*TransactionReconcileResult, error)
 }
@@ -0,0 +1,10 @@
+package domain
+
+import "context"
+
+// Transactor runs fn in one database transaction. Repositories called with
+// the context fn receives take part in it, and an error from fn rolls back
+// everything they wrote.
+type Transactor interface {
+	InTx(ctx context.Context, fn func(ctx context.Context) error) error
+}
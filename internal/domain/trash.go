@@ -0,0 +1,12 @@
+package domain
+
+import "context"
+
+type TrashPurgeResult struct {
+	Resumes    int64 `json:"resumes"`
+	Interviews int64 `json:"interviews"`
+}
+
+type TrashService interface {
+	Purge(ctx context.Context) (*TrashPurgeResult, error)
+}
@@ -15,6 +15,8 @@ const (
 	RoleAdmin Role = "admin"
 )
 
+const DefaultTimezone = "UTC"
+
 var (
 	ErrUserNotFound       = errors.New("user not found")
 	ErrUserDeleted        = errors.New("user account has been deleted, please restore your account")
@@ -23,19 +25,24 @@ var (
 	ErrNoDeletedUserFound = errors.New("no deleted account found with this email")
 	ErrUserAlreadyActive  = errors.New("user account is already active")
 	ErrCannotDeleteAdmin  = errors.New("admin account cannot be self-deleted")
+	ErrTwoFactorSession   = errors.New("two-factor session is invalid or expired, please login again")
 )
 
 type User struct {
-	ID          uuid.UUID  `json:"id"`
-	GoogleID    string     `json:"google_id"`
-	Email       string     `json:"email"`
-	Name        string     `json:"name"`
-	AvatarURL   *string    `json:"avatar_url"`
-	Role        Role       `json:"role"`
-	IsActive    bool       `json:"is_active"`
-	CreatedAt   time.Time  `json:"created_at"`
-	LastLoginAt *time.Time `json:"last_login_at"`
-	DeletedAt   *time.Time `json:"deleted_at,omitempty"`
+	ID               uuid.UUID  `json:"id"`
+	GoogleID         string     `json:"google_id"`
+	Email            string     `json:"email"`
+	ContactEmail     *string    `json:"contact_email,omitempty"`
+	Name             string     `json:"name"`
+	AvatarURL        *string    `json:"avatar_url"`
+	Role             Role       `json:"role"`
+	IsActive         bool       `json:"is_active"`
+	TwoFactorEnabled bool       `json:"two_factor_enabled"`
+	Timezone         string     `json:"timezone"`
+	DataRegion       string     `json:"data_region,omitempty"`
+	CreatedAt        time.Time  `json:"created_at"`
+	LastLoginAt      *time.Time `json:"last_login_at"`
+	DeletedAt        *time.Time `json:"deleted_at,omitempty"`
 }
 
 type GoogleUserInfo struct {
@@ -51,13 +58,49 @@ type AuthResponse struct {
 	User  User   `json:"user"`
 }
 
+type LoginResult struct {
+	Token             string       `json:"token,omitempty"`
+	TwoFactorRequired bool         `json:"two_factor_required"`
+	PendingToken      string       `json:"pending_token,omitempty"`
+	LinkedProvider    AuthProvider `json:"linked_provider,omitempty"`
+}
+
+type TwoFactorVerifyRequest struct {
+	PendingToken string `json:"pending_token" validate:"required"`
+	OTP          string `json:"otp" validate:"required,len=6,numeric"`
+}
+
+type TwoFactorResendRequest struct {
+	PendingToken string `json:"pending_token" validate:"required"`
+}
+
+type TwoFactorSettingRequest struct {
+	Enabled *bool `json:"enabled" validate:"required"`
+}
+
+type TimezoneSettingRequest struct {
+	Timezone string `json:"timezone" validate:"required,timezone"`
+}
+
 type OTPRequest struct {
 	Email string `json:"email" validate:"required,email"`
 }
 
 type OTPVerifyRequest struct {
 	Email string `json:"email" validate:"required,email"`
-	OTP   string `json:"otp" validate:"required,len=6"`
+	OTP   string `json:"otp" validate:"required,len=6,numeric"`
+}
+
+type DeleteOTPVerifyRequest struct {
+	OTP string `json:"otp" validate:"required,len=6,numeric"`
+}
+
+type ContactEmailChangeRequest struct {
+	Email string `json:"email" validate:"required,email,max=255"`
+}
+
+type ContactEmailVerifyRequest struct {
+	OTP string `json:"otp" validate:"required,len=6,numeric"`
 }
 
 type OTPResponse struct {
@@ -77,13 +120,27 @@ type Pagination struct {
 	TotalPages int   `json:"total_pages"`
 }
 
+// UserFilter narrows the admin user list. Zero values leave a filter out;
+// date ranges include From and exclude To.
+type UserFilter struct {
+	Query         string
+	Role          Role
+	IsActive      *bool
+	PlanID        *uuid.UUID
+	CreatedFrom   *time.Time
+	CreatedTo     *time.Time
+	LastLoginFrom *time.Time
+	LastLoginTo   *time.Time
+}
+
 type PaginatedUsers struct {
 	Users      []User     `json:"users"`
 	Pagination Pagination `json:"pagination"`
 }
 
 type DeleteAccountResponse struct {
-	Message string `json:"message"`
+	Message string     `json:"message"`
+	PurgeAt *time.Time `json:"purge_at,omitempty"`
 }
 
 type UserProfileResponse struct {
@@ -99,10 +156,15 @@ type UserRepository interface {
 	FindByEmail(ctx context.Context, email string) (*User, error)
 	FindDeletedByGoogleID(ctx context.Context, googleID string) (*User, error)
 	FindDeletedByEmail(ctx context.Context, email string) (*User, error)
-	FindAll(ctx context.Context, limit, offset int) ([]User, error)
-	Count(ctx context.Context) (int64, error)
+	FindAll(ctx context.Context, filter UserFilter, limit, offset int) ([]User, error)
+	FindRecentlyActive(ctx context.Context, limit int) ([]User, error)
+	Count(ctx context.Context, filter UserFilter) (int64, error)
 	Update(ctx context.Context, user *User) error
 	UpdateAvatar(ctx context.Context, id uuid.UUID, avatarURL string) error
+	UpdateTwoFactor(ctx context.Context, id uuid.UUID, enabled bool) error
+	UpdateTimezone(ctx context.Context, id uuid.UUID, timezone string) error
+	UpdateDataRegion(ctx context.Context, id uuid.UUID, region string) (bool, error)
+	UpdateContactEmail(ctx context.Context, id uuid.UUID, contactEmail *string) error
 	SoftDelete(ctx context.Context, id uuid.UUID) error
 	Restore(ctx context.Context, id uuid.UUID) error
 	UpdateLastLogin(ctx context.Context, id uuid.UUID) error
@@ -110,6 +172,7 @@ type UserRepository interface {
 
 type CacheRepository interface {
 	Get(ctx context.Context, key string) (string, error)
+	GetWithTTL(ctx context.Context, key string) (string, time.Duration, error)
 	Set(ctx context.Context, key string, value interface{}, expiration time.Duration) error
 	Delete(ctx context.Context, key string) error
 	DeleteByPattern(ctx context.Context, pattern string) error
@@ -118,25 +181,31 @@ type CacheRepository interface {
 type UserService interface {
 	GetByID(ctx context.Context, id uuid.UUID) (*User, error)
 	GetProfile(ctx context.Context, id uuid.UUID) (*UserProfileResponse, error)
-	GetAll(ctx context.Context, page, limit int) (*PaginatedUsers, error)
+	GetAll(ctx context.Context, filter UserFilter, page, limit int) (*PaginatedUsers, error)
 	Update(ctx context.Context, id uuid.UUID, name string) (*User, error)
 	UpdateAvatar(ctx context.Context, id uuid.UUID, avatarURL string) (*User, error)
+	SetTwoFactor(ctx context.Context, id uuid.UUID, enabled bool) (*User, error)
+	SetTimezone(ctx context.Context, id uuid.UUID, timezone string) (*User, error)
 	Delete(ctx context.Context, id uuid.UUID, requestingUserRole Role) error
 	RequestDeleteOTP(ctx context.Context, user *User) (*OTPResponse, error)
 	VerifyDeleteOTP(ctx context.Context, user *User, otp string) (*DeleteAccountResponse, error)
 	ResendDeleteOTP(ctx context.Context, user *User) (*OTPResponse, error)
+	RequestContactEmailChange(ctx context.Context, user *User, email string) (*OTPResponse, error)
+	VerifyContactEmailChange(ctx context.Context, user *User, otp string) (*User, error)
+	ClearContactEmail(ctx context.Context, user *User) (*User, error)
 }
 
 type AuthService interface {
 	GetGoogleLoginURL(state string) string
-	HandleGoogleCallback(ctx context.Context, code string) (string, error)
-	ValidateToken(ctx context.Context, tokenString string) (*User, error)
+	HandleGoogleCallback(ctx context.Context, code, state, referralCode string, client ClientInfo) (*LoginResult, error)
+	StartLink(ctx context.Context, userID uuid.UUID, provider AuthProvider, state string) (*LinkProviderResponse, error)
+	GetIdentities(ctx context.Context, userID uuid.UUID) ([]AuthIdentity, error)
+	Unlink(ctx context.Context, userID, identityID uuid.UUID) error
+	VerifyTwoFactor(ctx context.Context, pendingToken, otp string, client ClientInfo) (*AuthResponse, error)
+	ResendTwoFactorOTP(ctx context.Context, pendingToken string) (*OTPResponse, error)
+	ValidateToken(ctx context.Context, tokenString string) (*TokenIdentity, error)
+	Impersonate(ctx context.Context, adminID, targetID uuid.UUID, client ClientInfo) (*ImpersonationResponse, error)
 	RequestRestoreOTP(ctx context.Context, email string) (*OTPResponse, error)
 	VerifyRestoreOTP(ctx context.Context, email, otp string) (*RestoreUserResponse, error)
 	ResendRestoreOTP(ctx context.Context, email string) (*OTPResponse, error)
 }
-
-type EmailService interface {
-	SendOTP(ctx context.Context, email, otp string) error
-	SendDeleteOTP(ctx context.Context, email, otp string) error
-}
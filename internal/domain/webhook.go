@@ -0,0 +1,143 @@
+package domain
+
+import (
+	"context"
+	"encoding/json"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type WebhookEvent string
+
+const (
+	WebhookEventResumeCreated         WebhookEvent = "resume.created"
+	WebhookEventInterviewCompleted    WebhookEvent = "interview.completed"
+	WebhookEventSubscriptionActivated WebhookEvent = "subscription.activated"
+)
+
+type WebhookDeliveryStatus string
+
+const (
+	WebhookDeliveryStatusPending   WebhookDeliveryStatus = "pending"
+	WebhookDeliveryStatusSucceeded WebhookDeliveryStatus = "succeeded"
+	WebhookDeliveryStatusFailed    WebhookDeliveryStatus = "failed"
+)
+
+type WebhookEndpoint struct {
+	ID          uuid.UUID      `json:"id"`
+	URL         string         `json:"url"`
+	Description string         `json:"description,omitempty"`
+	Events      []WebhookEvent `json:"events"`
+	Secret      string         `json:"-"`
+	IsActive    bool           `json:"is_active"`
+	CreatedBy   uuid.UUID      `json:"created_by"`
+	CreatedAt   time.Time      `json:"created_at"`
+	UpdatedAt   time.Time      `json:"updated_at"`
+	DeletedAt   *time.Time     `json:"deleted_at,omitempty"`
+}
+
+type WebhookDelivery struct {
+	ID             uuid.UUID             `json:"id"`
+	EndpointID     uuid.UUID             `json:"endpoint_id"`
+	Event          WebhookEvent          `json:"event"`
+	Payload        json.RawMessage       `json:"payload"`
+	Status         WebhookDeliveryStatus `json:"status"`
+	Attempts       int                   `json:"attempts"`
+	MaxAttempts    int                   `json:"max_attempts"`
+	ResponseStatus *int                  `json:"response_status,omitempty"`
+	LastError      *string               `json:"last_error,omitempty"`
+	NextAttemptAt  time.Time             `json:"next_attempt_at"`
+	DeliveredAt    *time.Time            `json:"delivered_at,omitempty"`
+	CreatedAt      time.Time             `json:"created_at"`
+	UpdatedAt      time.Time             `json:"updated_at"`
+}
+
+type WebhookPayload struct {
+	ID        uuid.UUID    `json:"id"`
+	Event     WebhookEvent `json:"event"`
+	CreatedAt time.Time    `json:"created_at"`
+	Data      interface{}  `json:"data"`
+}
+
+type ResumeCreatedEvent struct {
+	ResumeID  uuid.UUID `json:"resume_id"`
+	UserID    uuid.UUID `json:"user_id"`
+	Title     string    `json:"title"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+type InterviewCompletedEvent struct {
+	InterviewID  uuid.UUID `json:"interview_id"`
+	UserID       uuid.UUID `json:"user_id"`
+	JobPosition  string    `json:"job_position"`
+	OverallScore *float64  `json:"overall_score,omitempty"`
+	CompletedAt  time.Time `json:"completed_at"`
+}
+
+type CreateWebhookEndpointRequest struct {
+	URL         string   `json:"url" validate:"required,url,max=2048"`
+	Description string   `json:"description" validate:"omitempty,max=255"`
+	Events      []string `json:"events" validate:"required,min=1,dive,oneof=resume.created interview.completed subscription.activated"`
+}
+
+type UpdateWebhookEndpointRequest struct {
+	URL         *string  `json:"url" validate:"omitempty,url,max=2048"`
+	Description *string  `json:"description" validate:"omitempty,max=255"`
+	Events      []string `json:"events" validate:"omitempty,min=1,dive,oneof=resume.created interview.completed subscription.activated"`
+	IsActive    *bool    `json:"is_active"`
+}
+
+type WebhookEndpointSecret struct {
+	Endpoint *WebhookEndpoint `json:"endpoint"`
+	Secret   string           `json:"secret"`
+}
+
+type PaginatedWebhookEndpoints struct {
+	Endpoints  []WebhookEndpoint `json:"endpoints"`
+	Pagination Pagination        `json:"pagination"`
+}
+
+type PaginatedWebhookDeliveries struct {
+	Deliveries []WebhookDelivery `json:"deliveries"`
+	Pagination Pagination        `json:"pagination"`
+}
+
+type WebhookDispatchResult struct {
+	Processed int `json:"processed"`
+	Succeeded int `json:"succeeded"`
+	Failed    int `json:"failed"`
+}
+
+type WebhookRepository interface {
+	CreateEndpoint(ctx context.Context, endpoint *WebhookEndpoint) error
+	FindEndpointByID(ctx context.Context, id uuid.UUID) (*WebhookEndpoint, error)
+	FindEndpoints(ctx context.Context, limit, offset int) ([]WebhookEndpoint, error)
+	CountEndpoints(ctx context.Context) (int64, error)
+	FindActiveEndpointsByEvent(ctx context.Context, event WebhookEvent) ([]WebhookEndpoint, error)
+	UpdateEndpoint(ctx context.Context, endpoint *WebhookEndpoint) error
+	SoftDeleteEndpoint(ctx context.Context, id uuid.UUID) error
+	CreateDelivery(ctx context.Context, delivery *WebhookDelivery) error
+	FindDeliveryByID(ctx context.Context, id uuid.UUID) (*WebhookDelivery, error)
+	FindDeliveries(ctx context.Context, endpointID uuid.UUID, status WebhookDeliveryStatus, limit, offset int) ([]WebhookDelivery, error)
+	CountDeliveries(ctx context.Context, endpointID uuid.UUID, status WebhookDeliveryStatus) (int64, error)
+	FindDueDeliveries(ctx context.Context, now time.Time, limit int) ([]WebhookDelivery, error)
+	UpdateDelivery(ctx context.Context, delivery *WebhookDelivery) error
+}
+
+type WebhookPublisher interface {
+	Publish(ctx context.Context, event WebhookEvent, data interface{})
+}
+
+type WebhookService interface {
+	WebhookPublisher
+	CreateEndpoint(ctx context.Context, createdBy uuid.UUID, req *CreateWebhookEndpointRequest) (*WebhookEndpointSecret, error)
+	GetEndpoints(ctx context.Context, page, limit int) (*PaginatedWebhookEndpoints, error)
+	GetEndpoint(ctx context.Context, id uuid.UUID) (*WebhookEndpoint, error)
+	UpdateEndpoint(ctx context.Context, id uuid.UUID, req *UpdateWebhookEndpointRequest) (*WebhookEndpoint, error)
+	RotateSecret(ctx context.Context, id uuid.UUID) (*WebhookEndpointSecret, error)
+	DeleteEndpoint(ctx context.Context, id uuid.UUID) error
+	GetDeliveries(ctx context.Context, endpointID uuid.UUID, status WebhookDeliveryStatus, page, limit int) (*PaginatedWebhookDeliveries, error)
+	Redeliver(ctx context.Context, endpointID, deliveryID uuid.UUID) (*WebhookDelivery, error)
+	ProcessDue(ctx context.Context) (*WebhookDispatchResult, error)
+}
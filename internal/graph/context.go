@@ -0,0 +1,24 @@
+package graph
+
+import (
+	"context"
+	"errors"
+
+	"github.com/raflytch/careerly-server/internal/domain"
+)
+
+var errUnauthenticated = errors.New("user not authenticated")
+
+type userContextKey struct{}
+
+func WithUser(ctx context.Context, user *domain.User) context.Context {
+	return context.WithValue(ctx, userContextKey{}, user)
+}
+
+func currentUser(ctx context.Context) (*domain.User, error) {
+	user, ok := ctx.Value(userContextKey{}).(*domain.User)
+	if !ok || user == nil {
+		return nil, errUnauthenticated
+	}
+	return user, nil
+}
@@ -0,0 +1,31 @@
+package graph
+
+//go:generate go run github.com/99designs/gqlgen@v0.17.84 generate --config ../../gqlgen.yml
+
+import (
+	"github.com/raflytch/careerly-server/internal/domain"
+)
+
+type Resolver struct {
+	userService      domain.UserService
+	quotaService     domain.QuotaService
+	resumeService    domain.ResumeService
+	interviewService domain.InterviewService
+	atsCheckService  domain.ATSCheckService
+}
+
+func NewResolver(
+	userService domain.UserService,
+	quotaService domain.QuotaService,
+	resumeService domain.ResumeService,
+	interviewService domain.InterviewService,
+	atsCheckService domain.ATSCheckService,
+) *Resolver {
+	return &Resolver{
+		userService:      userService,
+		quotaService:     quotaService,
+		resumeService:    resumeService,
+		interviewService: interviewService,
+		atsCheckService:  atsCheckService,
+	}
+}
@@ -0,0 +1,140 @@
+package graph
+
+// This file will be automatically regenerated based on the schema, any resolver
+// implementations
+// will be copied through when generating and any unknown code will be moved to the end.
+// Code generated by github.com/99designs/gqlgen version v0.17.84
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+	"github.com/raflytch/careerly-server/internal/domain"
+)
+
+// Status is the resolver for the status field.
+func (r *interviewResolver) Status(ctx context.Context, obj *domain.InterviewForUser) (string, error) {
+	return string(obj.Status), nil
+}
+
+// Me is the resolver for the me field.
+func (r *queryResolver) Me(ctx context.Context) (*domain.UserProfileResponse, error) {
+	user, err := currentUser(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return r.userService.GetProfile(ctx, user.ID)
+}
+
+// Quota is the resolver for the quota field.
+func (r *queryResolver) Quota(ctx context.Context) (*domain.UserQuota, error) {
+	user, err := currentUser(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return r.quotaService.GetUserQuota(ctx, user.ID)
+}
+
+// Resumes is the resolver for the resumes field.
+func (r *queryResolver) Resumes(ctx context.Context, page int, limit int) (*domain.PaginatedResumes, error) {
+	user, err := currentUser(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return r.resumeService.GetByUserID(ctx, user.ID, domain.ResumeFilter{}, page, limit)
+}
+
+// Resume is the resolver for the resume field.
+func (r *queryResolver) Resume(ctx context.Context, id uuid.UUID) (*domain.Resume, error) {
+	user, err := currentUser(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return r.resumeService.GetByID(ctx, user.ID, id)
+}
+
+// Interviews is the resolver for the interviews field.
+func (r *queryResolver) Interviews(ctx context.Context, page int, limit int) (*domain.PaginatedInterviews, error) {
+	user, err := currentUser(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return r.interviewService.GetByUserID(ctx, user.ID, page, limit)
+}
+
+// Interview is the resolver for the interview field.
+func (r *queryResolver) Interview(ctx context.Context, id uuid.UUID) (*domain.InterviewForUser, error) {
+	user, err := currentUser(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return r.interviewService.GetByID(ctx, user.ID, id)
+}
+
+// AtsChecks is the resolver for the atsChecks field.
+func (r *queryResolver) AtsChecks(ctx context.Context, page int, limit int) (*domain.PaginatedATSChecks, error) {
+	user, err := currentUser(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return r.atsCheckService.GetByUserID(ctx, user.ID, page, limit)
+}
+
+// AtsCheck is the resolver for the atsCheck field.
+func (r *queryResolver) AtsCheck(ctx context.Context, id uuid.UUID) (*domain.ATSCheck, error) {
+	user, err := currentUser(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return r.atsCheckService.GetByID(ctx, user.ID, id)
+}
+
+// Type is the resolver for the type field.
+func (r *questionResolver) Type(ctx context.Context, obj *domain.QuestionForUser) (string, error) {
+	return string(obj.Type), nil
+}
+
+// Difficulty is the resolver for the difficulty field.
+func (r *questionResolver) Difficulty(ctx context.Context, obj *domain.QuestionForUser) (string, error) {
+	return string(obj.Difficulty), nil
+}
+
+// Feature is the resolver for the feature field.
+func (r *usageResolver) Feature(ctx context.Context, obj *domain.Usage) (string, error) {
+	return string(obj.Feature), nil
+}
+
+// Role is the resolver for the role field.
+func (r *userResolver) Role(ctx context.Context, obj *domain.User) (string, error) {
+	return string(obj.Role), nil
+}
+
+// Status is the resolver for the status field.
+func (r *userSubscriptionResolver) Status(ctx context.Context, obj *domain.Subscription) (string, error) {
+	return string(obj.Status), nil
+}
+
+// Interview returns InterviewResolver implementation.
+func (r *Resolver) Interview() InterviewResolver { return &interviewResolver{r} }
+
+// Query returns QueryResolver implementation.
+func (r *Resolver) Query() QueryResolver { return &queryResolver{r} }
+
+// Question returns QuestionResolver implementation.
+func (r *Resolver) Question() QuestionResolver { return &questionResolver{r} }
+
+// Usage returns UsageResolver implementation.
+func (r *Resolver) Usage() UsageResolver { return &usageResolver{r} }
+
+// User returns UserResolver implementation.
+func (r *Resolver) User() UserResolver { return &userResolver{r} }
+
+// UserSubscription returns UserSubscriptionResolver implementation.
+func (r *Resolver) UserSubscription() UserSubscriptionResolver { return &userSubscriptionResolver{r} }
+
+type interviewResolver struct{ *Resolver }
+type queryResolver struct{ *Resolver }
+type questionResolver struct{ *Resolver }
+type usageResolver struct{ *Resolver }
+type userResolver struct{ *Resolver }
+type userSubscriptionResolver struct{ *Resolver }
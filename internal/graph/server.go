@@ -0,0 +1,22 @@
+package graph
+
+import (
+	"github.com/99designs/gqlgen/graphql/handler"
+	"github.com/99designs/gqlgen/graphql/handler/extension"
+	"github.com/99designs/gqlgen/graphql/handler/transport"
+)
+
+const maxQueryComplexity = 300
+
+// NewServer builds the GraphQL HTTP handler. Introspection is left off in
+// production so the schema is only discoverable from the repo.
+func NewServer(resolver *Resolver, introspection bool) *handler.Server {
+	srv := handler.New(NewExecutableSchema(Config{Resolvers: resolver}))
+	srv.AddTransport(transport.GET{})
+	srv.AddTransport(transport.POST{})
+	srv.Use(extension.FixedComplexityLimit(maxQueryComplexity))
+	if introspection {
+		srv.Use(extension.Introspection{})
+	}
+	return srv
+}
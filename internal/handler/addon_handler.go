@@ -0,0 +1,107 @@
+package handler
+
+import (
+	"github.com/raflytch/careerly-server/internal/domain"
+	"github.com/raflytch/careerly-server/pkg/response"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/google/uuid"
+)
+
+type AddonHandler struct {
+	addonService domain.AddonService
+}
+
+func NewAddonHandler(addonService domain.AddonService) *AddonHandler {
+	return &AddonHandler{
+		addonService: addonService,
+	}
+}
+
+func (h *AddonHandler) ListAvailable(c *fiber.Ctx) error {
+	page := c.QueryInt("page", 1)
+	limit := c.QueryInt("limit", 10)
+
+	result, err := h.addonService.GetAll(c.UserContext(), page, limit, false)
+	if err != nil {
+		return respondError(c, err)
+	}
+
+	return response.Success(c, fiber.StatusOK, "add-ons retrieved", result)
+}
+
+func (h *AddonHandler) Create(c *fiber.Ctx) error {
+	var req domain.CreateAddonRequest
+	if err := bindAndValidate(c, &req); err != nil {
+		return validationFailed(c, err)
+	}
+
+	addon, err := h.addonService.Create(c.UserContext(), &req)
+	if err != nil {
+		return respondError(c, err)
+	}
+
+	return response.Success(c, fiber.StatusCreated, "add-on created", addon)
+}
+
+func (h *AddonHandler) GetAll(c *fiber.Ctx) error {
+	page := c.QueryInt("page", 1)
+	limit := c.QueryInt("limit", 10)
+	includeInactive := c.QueryBool("include_inactive", true)
+
+	result, err := h.addonService.GetAll(c.UserContext(), page, limit, includeInactive)
+	if err != nil {
+		return respondError(c, err)
+	}
+
+	return response.Success(c, fiber.StatusOK, "add-ons retrieved", result)
+}
+
+func (h *AddonHandler) GetByID(c *fiber.Ctx) error {
+	idParam := c.Params("id")
+	id, err := uuid.Parse(idParam)
+	if err != nil {
+		return response.BadRequest(c, "invalid add-on id")
+	}
+
+	addon, err := h.addonService.GetByID(c.UserContext(), id)
+	if err != nil {
+		return respondError(c, err)
+	}
+
+	return response.Success(c, fiber.StatusOK, "add-on retrieved", addon)
+}
+
+func (h *AddonHandler) Update(c *fiber.Ctx) error {
+	idParam := c.Params("id")
+	id, err := uuid.Parse(idParam)
+	if err != nil {
+		return response.BadRequest(c, "invalid add-on id")
+	}
+
+	var req domain.UpdateAddonRequest
+	if err := bindAndValidate(c, &req); err != nil {
+		return validationFailed(c, err)
+	}
+
+	addon, err := h.addonService.Update(c.UserContext(), id, &req)
+	if err != nil {
+		return respondError(c, err)
+	}
+
+	return response.Success(c, fiber.StatusOK, "add-on updated", addon)
+}
+
+func (h *AddonHandler) Delete(c *fiber.Ctx) error {
+	idParam := c.Params("id")
+	id, err := uuid.Parse(idParam)
+	if err != nil {
+		return response.BadRequest(c, "invalid add-on id")
+	}
+
+	if err := h.addonService.Delete(c.UserContext(), id); err != nil {
+		return respondError(c, err)
+	}
+
+	return response.Success(c, fiber.StatusOK, "add-on deleted", nil)
+}
@@ -0,0 +1,89 @@
+package handler
+
+import (
+	"time"
+
+	"github.com/raflytch/careerly-server/internal/domain"
+	"github.com/raflytch/careerly-server/internal/middleware"
+	"github.com/raflytch/careerly-server/pkg/response"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/google/uuid"
+)
+
+type AIFeedbackHandler struct {
+	feedbackService domain.AIFeedbackService
+}
+
+func NewAIFeedbackHandler(feedbackService domain.AIFeedbackService) *AIFeedbackHandler {
+	return &AIFeedbackHandler{
+		feedbackService: feedbackService,
+	}
+}
+
+func (h *AIFeedbackHandler) RateResume(c *fiber.Ctx) error {
+	return h.submit(c, domain.AIFeedbackResumeEnhancement, "invalid resume id")
+}
+
+func (h *AIFeedbackHandler) RateInterview(c *fiber.Ctx) error {
+	return h.submit(c, domain.AIFeedbackInterviewFeedback, "invalid interview id")
+}
+
+func (h *AIFeedbackHandler) RateATSCheck(c *fiber.Ctx) error {
+	return h.submit(c, domain.AIFeedbackATSAnalysis, "invalid ats check id")
+}
+
+func (h *AIFeedbackHandler) GetQualityReport(c *fiber.Ctx) error {
+	now := time.Now().UTC()
+	from := time.Date(now.Year(), now.Month(), 1, 0, 0, 0, 0, time.UTC)
+	to := from.AddDate(0, 1, 0)
+
+	if raw := c.Query("from"); raw != "" {
+		parsed, err := time.Parse(reportDateLayout, raw)
+		if err != nil {
+			return response.BadRequest(c, "from must be in YYYY-MM-DD format")
+		}
+		from = parsed
+	}
+	if raw := c.Query("to"); raw != "" {
+		parsed, err := time.Parse(reportDateLayout, raw)
+		if err != nil {
+			return response.BadRequest(c, "to must be in YYYY-MM-DD format")
+		}
+		to = parsed.AddDate(0, 0, 1)
+	}
+	if !to.After(from) {
+		return response.BadRequest(c, "to must be after from")
+	}
+
+	report, err := h.feedbackService.GetQualityReport(c.UserContext(), from, to)
+	if err != nil {
+		return respondError(c, err)
+	}
+
+	return response.Success(c, fiber.StatusOK, "ai quality report retrieved", report)
+}
+
+func (h *AIFeedbackHandler) submit(c *fiber.Ctx, feature domain.AIFeedbackFeature, invalidID string) error {
+	user := middleware.GetUserFromContext(c)
+	if user == nil {
+		return response.Unauthorized(c, "user not authenticated")
+	}
+
+	id, err := uuid.Parse(c.Params("id"))
+	if err != nil {
+		return response.BadRequest(c, invalidID)
+	}
+
+	var req domain.AIFeedbackRequest
+	if err := bindAndValidate(c, &req); err != nil {
+		return validationFailed(c, err)
+	}
+
+	feedback, err := h.feedbackService.Submit(c.UserContext(), user.ID, feature, id, &req)
+	if err != nil {
+		return respondError(c, err)
+	}
+
+	return response.Success(c, fiber.StatusOK, "feedback saved", feedback)
+}
@@ -0,0 +1,85 @@
+package handler
+
+import (
+	"time"
+
+	"github.com/raflytch/careerly-server/internal/domain"
+	"github.com/raflytch/careerly-server/internal/middleware"
+	"github.com/raflytch/careerly-server/pkg/response"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+const reportDateLayout = "2006-01-02"
+
+type AIUsageHandler struct {
+	aiUsageService domain.AIUsageService
+}
+
+func NewAIUsageHandler(aiUsageService domain.AIUsageService) *AIUsageHandler {
+	return &AIUsageHandler{
+		aiUsageService: aiUsageService,
+	}
+}
+
+func (h *AIUsageHandler) GetReport(c *fiber.Ctx) error {
+	now := time.Now().UTC()
+	from := time.Date(now.Year(), now.Month(), 1, 0, 0, 0, 0, time.UTC)
+	to := from.AddDate(0, 1, 0)
+
+	if raw := c.Query("from"); raw != "" {
+		parsed, err := time.Parse(reportDateLayout, raw)
+		if err != nil {
+			return response.BadRequest(c, "from must be in YYYY-MM-DD format")
+		}
+		from = parsed
+	}
+	if raw := c.Query("to"); raw != "" {
+		parsed, err := time.Parse(reportDateLayout, raw)
+		if err != nil {
+			return response.BadRequest(c, "to must be in YYYY-MM-DD format")
+		}
+		to = parsed.AddDate(0, 0, 1)
+	}
+	if !to.After(from) {
+		return response.BadRequest(c, "to must be after from")
+	}
+
+	report, err := h.aiUsageService.GetReport(c.UserContext(), from, to)
+	if err != nil {
+		return respondError(c, err)
+	}
+
+	return response.Success(c, fiber.StatusOK, "ai usage report retrieved successfully", report)
+}
+
+func (h *AIUsageHandler) GetMyHistory(c *fiber.Ctx) error {
+	user := middleware.GetUserFromContext(c)
+	if user == nil {
+		return response.Unauthorized(c, "user not authenticated")
+	}
+
+	page := c.QueryInt("page", 1)
+	limit := c.QueryInt("limit", 10)
+	feature := c.Query("feature")
+
+	result, err := h.aiUsageService.GetUserHistory(c.UserContext(), user.ID, feature, page, limit)
+	if err != nil {
+		return respondError(c, err)
+	}
+
+	return response.Success(c, fiber.StatusOK, "ai history retrieved successfully", result)
+}
+
+func (h *AIUsageHandler) GetLogs(c *fiber.Ctx) error {
+	page := c.QueryInt("page", 1)
+	limit := c.QueryInt("limit", 10)
+	feature := c.Query("feature")
+
+	result, err := h.aiUsageService.GetLogs(c.UserContext(), feature, page, limit)
+	if err != nil {
+		return respondError(c, err)
+	}
+
+	return response.Success(c, fiber.StatusOK, "ai usage logs retrieved successfully", result)
+}
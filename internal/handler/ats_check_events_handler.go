@@ -0,0 +1,115 @@
+package handler
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/raflytch/careerly-server/internal/domain"
+	"github.com/raflytch/careerly-server/internal/middleware"
+	"github.com/raflytch/careerly-server/pkg/response"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/google/uuid"
+)
+
+const (
+	// atsEventsPollInterval is how often the stream re-reads the cached job,
+	// both as a keepalive and to catch transitions published by another
+	// instance.
+	atsEventsPollInterval = 5 * time.Second
+	atsEventsMaxDuration  = 10 * time.Minute
+)
+
+// StreamEvents sends the analysis job's status as server-sent events: the
+// current state first, then each transition, ending after done or failed.
+func (h *ATSCheckHandler) StreamEvents(c *fiber.Ctx) error {
+	user := middleware.GetUserFromContext(c)
+	if user == nil {
+		return response.Unauthorized(c, "user not authenticated")
+	}
+
+	id, err := uuid.Parse(c.Params("id"))
+	if err != nil {
+		return response.BadRequest(c, "invalid ats check id")
+	}
+
+	events, unsubscribe := h.progressBroker.Subscribe(id)
+
+	job, err := h.atsCheckService.GetAnalysisJob(c.UserContext(), user.ID, id)
+	if err != nil {
+		unsubscribe()
+		return respondError(c, err)
+	}
+
+	c.Set("Content-Type", "text/event-stream")
+	c.Set("Cache-Control", "no-cache")
+	c.Set("Connection", "keep-alive")
+	c.Set("X-Accel-Buffering", "no")
+
+	userID := user.ID
+	c.Context().SetBodyStreamWriter(func(w *bufio.Writer) {
+		defer unsubscribe()
+
+		if err := writeATSEvent(w, job); err != nil || atsAnalysisFinished(job.Status) {
+			return
+		}
+		last := job.Status
+
+		ticker := time.NewTicker(atsEventsPollInterval)
+		defer ticker.Stop()
+		deadline := time.After(atsEventsMaxDuration)
+
+		for {
+			select {
+			case <-deadline:
+				return
+			case event, ok := <-events:
+				if !ok {
+					return
+				}
+				if event.Status == last {
+					continue
+				}
+				if err := writeATSEvent(w, &event); err != nil || atsAnalysisFinished(event.Status) {
+					return
+				}
+				last = event.Status
+			case <-ticker.C:
+				current, err := h.atsCheckService.GetAnalysisJob(context.Background(), userID, id)
+				if err == nil && current.Status != last {
+					if err := writeATSEvent(w, current); err != nil || atsAnalysisFinished(current.Status) {
+						return
+					}
+					last = current.Status
+					continue
+				}
+				if _, err := fmt.Fprint(w, ": keepalive\n\n"); err != nil {
+					return
+				}
+				if err := w.Flush(); err != nil {
+					return
+				}
+			}
+		}
+	})
+
+	return nil
+}
+
+func writeATSEvent(w *bufio.Writer, job *domain.ATSAnalysisJob) error {
+	data, err := json.Marshal(job)
+	if err != nil {
+		return err
+	}
+	if _, err := fmt.Fprintf(w, "event: %s\ndata: %s\n\n", job.Status, data); err != nil {
+		return err
+	}
+	return w.Flush()
+}
+
+func atsAnalysisFinished(status domain.ATSAnalysisStatus) bool {
+	return status == domain.ATSAnalysisDone || status == domain.ATSAnalysisFailed
+}
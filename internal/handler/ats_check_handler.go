@@ -1,11 +1,11 @@
 package handler
 
 import (
-	"errors"
+	"encoding/json"
+	"fmt"
 
 	"github.com/raflytch/careerly-server/internal/domain"
 	"github.com/raflytch/careerly-server/internal/middleware"
-	"github.com/raflytch/careerly-server/internal/service"
 	"github.com/raflytch/careerly-server/pkg/response"
 	"github.com/raflytch/careerly-server/pkg/validator"
 
@@ -16,13 +16,15 @@ import (
 type ATSCheckHandler struct {
 	atsCheckService domain.ATSCheckService
 	quotaService    domain.QuotaService
+	progressBroker  domain.ATSProgressBroker
 	fileValidator   *validator.FileValidator
 }
 
-func NewATSCheckHandler(atsCheckService domain.ATSCheckService, quotaService domain.QuotaService) *ATSCheckHandler {
+func NewATSCheckHandler(atsCheckService domain.ATSCheckService, quotaService domain.QuotaService, progressBroker domain.ATSProgressBroker) *ATSCheckHandler {
 	return &ATSCheckHandler{
 		atsCheckService: atsCheckService,
 		quotaService:    quotaService,
+		progressBroker:  progressBroker,
 		fileValidator: validator.NewFileValidator(
 			validator.WithMaxSize(validator.MaxSize5MB),
 			validator.WithAllowedTypes([]string{".pdf"}),
@@ -45,23 +47,66 @@ func (h *ATSCheckHandler) Analyze(c *fiber.Ctx) error {
 		return response.BadRequest(c, err.Error())
 	}
 
-	result, err := h.atsCheckService.AnalyzeFromFile(c.UserContext(), user.ID, file)
+	job, err := h.atsCheckService.AnalyzeFromFile(c.UserContext(), user.ID, file)
 	if err != nil {
-		if errors.Is(err, service.ErrAIClientUnavailable) {
-			return response.InternalError(c, "ai service is unavailable, cannot analyze pdf")
-		}
-		if errors.Is(err, service.ErrNoActiveSubscription) {
-			return response.Forbidden(c, "no active subscription found")
-		}
-		if errors.Is(err, service.ErrQuotaExceeded) {
-			return response.Forbidden(c, "ats check quota exceeded for this month")
-		}
-		return response.InternalError(c, err.Error())
+		return respondError(c, err)
+	}
+
+	return response.Success(c, fiber.StatusAccepted, "ats analysis started", job)
+}
+
+func (h *ATSCheckHandler) AnalyzeResume(c *fiber.Ctx) error {
+	user := middleware.GetUserFromContext(c)
+	if user == nil {
+		return response.Unauthorized(c, "user not authenticated")
+	}
+
+	id, err := uuid.Parse(c.Params("id"))
+	if err != nil {
+		return response.BadRequest(c, "invalid resume id")
+	}
+
+	result, err := h.atsCheckService.AnalyzeResume(c.UserContext(), user.ID, id)
+	if err != nil {
+		return respondError(c, err)
 	}
 
 	return response.Success(c, fiber.StatusCreated, "ats analysis completed", result)
 }
 
+func (h *ATSCheckHandler) AnalyzeBatch(c *fiber.Ctx) error {
+	user := middleware.GetUserFromContext(c)
+	if user == nil {
+		return response.Unauthorized(c, "user not authenticated")
+	}
+
+	file, err := c.FormFile("file")
+	if err != nil {
+		return response.BadRequest(c, "pdf file is required, use form field 'file'")
+	}
+
+	if err := h.fileValidator.Validate(file); err != nil {
+		return response.BadRequest(c, err.Error())
+	}
+
+	var req domain.ATSBatchRequest
+	if raw := c.FormValue("job_descriptions"); raw != "" {
+		if err := json.Unmarshal([]byte(raw), &req.JobDescriptions); err != nil {
+			return response.BadRequest(c, "job_descriptions must be a JSON array of {title, description}")
+		}
+	}
+	if err := validator.ValidateStruct(&req); err != nil {
+		return validationFailed(c, err)
+	}
+
+	result, err := h.atsCheckService.AnalyzeBatch(c.UserContext(), user.ID, file, &req)
+	if err != nil {
+		return respondError(c, err)
+	}
+
+	return response.Success(c, fiber.StatusOK, "batch ats analysis completed", result)
+}
+
 func (h *ATSCheckHandler) GetByID(c *fiber.Ctx) error {
 	user := middleware.GetUserFromContext(c)
 	if user == nil {
@@ -76,18 +121,52 @@ func (h *ATSCheckHandler) GetByID(c *fiber.Ctx) error {
 
 	check, err := h.atsCheckService.GetByID(c.UserContext(), user.ID, id)
 	if err != nil {
-		if errors.Is(err, service.ErrATSCheckNotFound) {
-			return response.NotFound(c, "ats check not found")
-		}
-		if errors.Is(err, service.ErrATSCheckUnauthorized) {
-			return response.Forbidden(c, "unauthorized access to ats check")
-		}
-		return response.InternalError(c, err.Error())
+		return respondError(c, err)
 	}
 
 	return response.Success(c, fiber.StatusOK, "ats check retrieved", check)
 }
 
+func (h *ATSCheckHandler) Compare(c *fiber.Ctx) error {
+	user := middleware.GetUserFromContext(c)
+	if user == nil {
+		return response.Unauthorized(c, "user not authenticated")
+	}
+
+	var query domain.ATSCompareQuery
+	if err := bindQueryAndValidate(c, &query); err != nil {
+		return validationFailed(c, err)
+	}
+
+	comparison, err := h.atsCheckService.Compare(c.UserContext(), user.ID, uuid.MustParse(query.From), uuid.MustParse(query.To))
+	if err != nil {
+		return respondError(c, err)
+	}
+
+	return response.Success(c, fiber.StatusOK, "ats checks compared", comparison)
+}
+
+func (h *ATSCheckHandler) GetAnnotatedPDF(c *fiber.Ctx) error {
+	user := middleware.GetUserFromContext(c)
+	if user == nil {
+		return response.Unauthorized(c, "user not authenticated")
+	}
+
+	id, err := uuid.Parse(c.Params("id"))
+	if err != nil {
+		return response.BadRequest(c, "invalid ats check id")
+	}
+
+	data, err := h.atsCheckService.GetAnnotatedPDF(c.UserContext(), user.ID, id)
+	if err != nil {
+		return respondError(c, err)
+	}
+
+	c.Set(fiber.HeaderContentType, "application/pdf")
+	c.Set("Content-Disposition", fmt.Sprintf("attachment; filename=ats_check_%s_annotated.pdf", id.String()))
+	return c.Send(data)
+}
+
 func (h *ATSCheckHandler) GetMyATSChecks(c *fiber.Ctx) error {
 	user := middleware.GetUserFromContext(c)
 	if user == nil {
@@ -99,7 +178,7 @@ func (h *ATSCheckHandler) GetMyATSChecks(c *fiber.Ctx) error {
 
 	result, err := h.atsCheckService.GetByUserID(c.UserContext(), user.ID, page, limit)
 	if err != nil {
-		return response.InternalError(c, err.Error())
+		return respondError(c, err)
 	}
 
 	return response.Success(c, fiber.StatusOK, "ats checks retrieved", result)
@@ -118,13 +197,7 @@ func (h *ATSCheckHandler) Delete(c *fiber.Ctx) error {
 	}
 
 	if err := h.atsCheckService.Delete(c.UserContext(), user.ID, id); err != nil {
-		if errors.Is(err, service.ErrATSCheckNotFound) {
-			return response.NotFound(c, "ats check not found")
-		}
-		if errors.Is(err, service.ErrATSCheckUnauthorized) {
-			return response.Forbidden(c, "unauthorized access to ats check")
-		}
-		return response.InternalError(c, err.Error())
+		return respondError(c, err)
 	}
 
 	return response.Success(c, fiber.StatusOK, "ats check deleted", nil)
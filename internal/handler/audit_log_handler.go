@@ -0,0 +1,68 @@
+package handler
+
+import (
+	"time"
+
+	"github.com/raflytch/careerly-server/internal/domain"
+	"github.com/raflytch/careerly-server/pkg/response"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/google/uuid"
+)
+
+type AuditLogHandler struct {
+	auditService domain.AuditService
+}
+
+func NewAuditLogHandler(auditService domain.AuditService) *AuditLogHandler {
+	return &AuditLogHandler{
+		auditService: auditService,
+	}
+}
+
+func (h *AuditLogHandler) GetAll(c *fiber.Ctx) error {
+	page := c.QueryInt("page", 1)
+	limit := c.QueryInt("limit", 10)
+
+	filter := domain.AuditLogFilter{
+		Action:     domain.AuditAction(c.Query("action")),
+		TargetType: c.Query("target_type"),
+	}
+
+	if raw := c.Query("actor_id"); raw != "" {
+		actorID, err := uuid.Parse(raw)
+		if err != nil {
+			return response.BadRequest(c, "invalid actor id")
+		}
+		filter.ActorID = &actorID
+	}
+	if raw := c.Query("target_id"); raw != "" {
+		targetID, err := uuid.Parse(raw)
+		if err != nil {
+			return response.BadRequest(c, "invalid target id")
+		}
+		filter.TargetID = &targetID
+	}
+	if raw := c.Query("from"); raw != "" {
+		from, err := time.Parse(reportDateLayout, raw)
+		if err != nil {
+			return response.BadRequest(c, "from must be in YYYY-MM-DD format")
+		}
+		filter.From = &from
+	}
+	if raw := c.Query("to"); raw != "" {
+		to, err := time.Parse(reportDateLayout, raw)
+		if err != nil {
+			return response.BadRequest(c, "to must be in YYYY-MM-DD format")
+		}
+		to = to.AddDate(0, 0, 1)
+		filter.To = &to
+	}
+
+	result, err := h.auditService.GetAll(c.UserContext(), filter, page, limit)
+	if err != nil {
+		return respondError(c, err)
+	}
+
+	return response.Success(c, fiber.StatusOK, "audit logs retrieved successfully", result)
+}
@@ -6,12 +6,20 @@ import (
 	"errors"
 	"fmt"
 	"net/url"
+	"time"
 
 	"github.com/raflytch/careerly-server/internal/domain"
+	"github.com/raflytch/careerly-server/internal/middleware"
 	"github.com/raflytch/careerly-server/internal/service"
 	"github.com/raflytch/careerly-server/pkg/response"
 
 	"github.com/gofiber/fiber/v2"
+	"github.com/google/uuid"
+)
+
+const (
+	referralCookieName   = "referral_code"
+	oauthStateCookieName = "oauth_state"
 )
 
 type AuthHandler struct {
@@ -28,13 +36,18 @@ func NewAuthHandler(authService domain.AuthService, frontendURL string) *AuthHan
 
 func (h *AuthHandler) GoogleLogin(c *fiber.Ctx) error {
 	state := generateState()
-	c.Cookie(&fiber.Cookie{
-		Name:     "oauth_state",
-		Value:    state,
-		HTTPOnly: true,
-		Secure:   true,
-		SameSite: "Lax",
-	})
+	setOAuthStateCookie(c, state)
+
+	if ref := c.Query("ref"); ref != "" {
+		c.Cookie(&fiber.Cookie{
+			Name:     referralCookieName,
+			Value:    ref,
+			Expires:  time.Now().Add(time.Hour),
+			HTTPOnly: true,
+			Secure:   true,
+			SameSite: "Lax",
+		})
+	}
 
 	url := h.authService.GetGoogleLoginURL(state)
 	return c.Redirect(url)
@@ -46,21 +59,142 @@ func (h *AuthHandler) GoogleCallback(c *fiber.Ctx) error {
 		return h.redirectWithError(c, "missing authorization code")
 	}
 
-	token, err := h.authService.HandleGoogleCallback(c.UserContext(), code)
+	// The state must come back to the browser that started the flow, so a
+	// callback URL from someone else's login or link attempt is refused.
+	state := c.Query("state")
+	if state == "" || state != c.Cookies(oauthStateCookieName) {
+		return h.redirectWithError(c, "invalid oauth state")
+	}
+
+	referralCode := c.Cookies(referralCookieName)
+	if referralCode != "" {
+		c.ClearCookie(referralCookieName)
+	}
+
+	result, err := h.authService.HandleGoogleCallback(c.UserContext(), code, state, referralCode, clientInfo(c))
 	if err != nil {
-		if errors.Is(err, domain.ErrUserDeleted) {
-			return h.redirectWithError(c, err.Error())
-		}
-		if errors.Is(err, service.ErrUserNotActive) {
+		if errors.Is(err, domain.ErrUserDeleted) ||
+			errors.Is(err, service.ErrUserNotActive) ||
+			errors.Is(err, domain.ErrEmailBelongsToAnother) ||
+			errors.Is(err, domain.ErrIdentityInUse) ||
+			errors.Is(err, domain.ErrProviderAlreadyLinked) {
 			return h.redirectWithError(c, err.Error())
 		}
 		return h.redirectWithError(c, "authentication failed")
 	}
 
-	redirectURL := fmt.Sprintf("%s?token=%s", h.frontendURL, url.QueryEscape(token))
+	if result.LinkedProvider != "" {
+		redirectURL := fmt.Sprintf("%s?linked=%s", h.frontendURL, url.QueryEscape(string(result.LinkedProvider)))
+		return c.Redirect(redirectURL)
+	}
+
+	if result.TwoFactorRequired {
+		redirectURL := fmt.Sprintf("%s?two_factor_token=%s", h.frontendURL, url.QueryEscape(result.PendingToken))
+		return c.Redirect(redirectURL)
+	}
+
+	redirectURL := fmt.Sprintf("%s?token=%s", h.frontendURL, url.QueryEscape(result.Token))
 	return c.Redirect(redirectURL)
 }
 
+func (h *AuthHandler) VerifyTwoFactor(c *fiber.Ctx) error {
+	var req domain.TwoFactorVerifyRequest
+	if err := bindAndValidate(c, &req); err != nil {
+		return validationFailed(c, err)
+	}
+
+	authResponse, err := h.authService.VerifyTwoFactor(c.UserContext(), req.PendingToken, req.OTP, clientInfo(c))
+	if err != nil {
+		return respondError(c, err)
+	}
+
+	return response.Success(c, fiber.StatusOK, "two-factor verification successful", authResponse)
+}
+
+func (h *AuthHandler) ResendTwoFactorOTP(c *fiber.Ctx) error {
+	var req domain.TwoFactorResendRequest
+	if err := bindAndValidate(c, &req); err != nil {
+		return validationFailed(c, err)
+	}
+
+	otpResponse, err := h.authService.ResendTwoFactorOTP(c.UserContext(), req.PendingToken)
+	if err != nil {
+		return respondError(c, err)
+	}
+
+	return response.Success(c, fiber.StatusOK, "OTP resent successfully", otpResponse)
+}
+
+func (h *AuthHandler) Impersonate(c *fiber.Ctx) error {
+	admin := middleware.GetUserFromContext(c)
+	if admin == nil {
+		return response.Unauthorized(c, "user not authenticated")
+	}
+
+	targetID, err := uuid.Parse(c.Params("id"))
+	if err != nil {
+		return response.BadRequest(c, "invalid user id")
+	}
+
+	result, err := h.authService.Impersonate(c.UserContext(), admin.ID, targetID, clientInfo(c))
+	if err != nil {
+		if errors.Is(err, service.ErrUserNotActive) {
+			return respondErrorStatus(c, fiber.StatusBadRequest, err)
+		}
+		return respondError(c, err)
+	}
+
+	return response.Success(c, fiber.StatusOK, "impersonation token issued", result)
+}
+
+func (h *AuthHandler) GetIdentities(c *fiber.Ctx) error {
+	user := middleware.GetUserFromContext(c)
+	if user == nil {
+		return response.Unauthorized(c, "user not authenticated")
+	}
+
+	identities, err := h.authService.GetIdentities(c.UserContext(), user.ID)
+	if err != nil {
+		return respondError(c, err)
+	}
+
+	return response.Success(c, fiber.StatusOK, "linked accounts retrieved successfully", identities)
+}
+
+func (h *AuthHandler) LinkProvider(c *fiber.Ctx) error {
+	user := middleware.GetUserFromContext(c)
+	if user == nil {
+		return response.Unauthorized(c, "user not authenticated")
+	}
+
+	state := generateState()
+	result, err := h.authService.StartLink(c.UserContext(), user.ID, domain.AuthProvider(c.Params("provider")), state)
+	if err != nil {
+		return respondError(c, err)
+	}
+	setOAuthStateCookie(c, state)
+
+	return response.Success(c, fiber.StatusOK, "continue at auth_url to link the account", result)
+}
+
+func (h *AuthHandler) UnlinkIdentity(c *fiber.Ctx) error {
+	user := middleware.GetUserFromContext(c)
+	if user == nil {
+		return response.Unauthorized(c, "user not authenticated")
+	}
+
+	id, err := uuid.Parse(c.Params("id"))
+	if err != nil {
+		return response.BadRequest(c, "invalid linked account id")
+	}
+
+	if err := h.authService.Unlink(c.UserContext(), user.ID, id); err != nil {
+		return respondError(c, err)
+	}
+
+	return response.Success(c, fiber.StatusOK, "account unlinked", nil)
+}
+
 func (h *AuthHandler) redirectWithError(c *fiber.Ctx, message string) error {
 	redirectURL := fmt.Sprintf("%s?error=%s", h.frontendURL, url.QueryEscape(message))
 	return c.Redirect(redirectURL)
@@ -68,26 +202,13 @@ func (h *AuthHandler) redirectWithError(c *fiber.Ctx, message string) error {
 
 func (h *AuthHandler) RequestRestoreOTP(c *fiber.Ctx) error {
 	var req domain.OTPRequest
-	if err := c.BodyParser(&req); err != nil {
-		return response.BadRequest(c, "invalid request body")
-	}
-
-	if req.Email == "" {
-		return response.BadRequest(c, "email is required")
+	if err := bindAndValidate(c, &req); err != nil {
+		return validationFailed(c, err)
 	}
 
 	otpResponse, err := h.authService.RequestRestoreOTP(c.UserContext(), req.Email)
 	if err != nil {
-		switch {
-		case errors.Is(err, domain.ErrNoDeletedUserFound):
-			return response.NotFound(c, err.Error())
-		case errors.Is(err, domain.ErrUserAlreadyActive):
-			return response.BadRequest(c, err.Error())
-		case errors.Is(err, domain.ErrOTPAlreadySent):
-			return response.Error(c, fiber.StatusTooManyRequests, err.Error())
-		default:
-			return response.InternalError(c, err.Error())
-		}
+		return respondError(c, err)
 	}
 
 	return response.Success(c, fiber.StatusOK, "OTP sent successfully", otpResponse)
@@ -95,28 +216,13 @@ func (h *AuthHandler) RequestRestoreOTP(c *fiber.Ctx) error {
 
 func (h *AuthHandler) VerifyRestoreOTP(c *fiber.Ctx) error {
 	var req domain.OTPVerifyRequest
-	if err := c.BodyParser(&req); err != nil {
-		return response.BadRequest(c, "invalid request body")
-	}
-
-	if req.Email == "" {
-		return response.BadRequest(c, "email is required")
-	}
-
-	if req.OTP == "" || len(req.OTP) != 6 {
-		return response.BadRequest(c, "OTP must be 6 digits")
+	if err := bindAndValidate(c, &req); err != nil {
+		return validationFailed(c, err)
 	}
 
 	restoreResponse, err := h.authService.VerifyRestoreOTP(c.UserContext(), req.Email, req.OTP)
 	if err != nil {
-		switch {
-		case errors.Is(err, domain.ErrInvalidOTP):
-			return response.BadRequest(c, err.Error())
-		case errors.Is(err, domain.ErrNoDeletedUserFound):
-			return response.NotFound(c, err.Error())
-		default:
-			return response.InternalError(c, err.Error())
-		}
+		return respondError(c, err)
 	}
 
 	return response.Success(c, fiber.StatusOK, "account restored successfully", restoreResponse)
@@ -124,24 +230,13 @@ func (h *AuthHandler) VerifyRestoreOTP(c *fiber.Ctx) error {
 
 func (h *AuthHandler) ResendRestoreOTP(c *fiber.Ctx) error {
 	var req domain.OTPRequest
-	if err := c.BodyParser(&req); err != nil {
-		return response.BadRequest(c, "invalid request body")
-	}
-
-	if req.Email == "" {
-		return response.BadRequest(c, "email is required")
+	if err := bindAndValidate(c, &req); err != nil {
+		return validationFailed(c, err)
 	}
 
 	otpResponse, err := h.authService.ResendRestoreOTP(c.UserContext(), req.Email)
 	if err != nil {
-		switch {
-		case errors.Is(err, domain.ErrNoDeletedUserFound):
-			return response.NotFound(c, err.Error())
-		case errors.Is(err, domain.ErrUserAlreadyActive):
-			return response.BadRequest(c, err.Error())
-		default:
-			return response.InternalError(c, err.Error())
-		}
+		return respondError(c, err)
 	}
 
 	return response.Success(c, fiber.StatusOK, "OTP resent successfully", otpResponse)
@@ -152,3 +247,24 @@ func generateState() string {
 	rand.Read(b)
 	return base64.URLEncoding.EncodeToString(b)
 }
+
+// setOAuthStateCookie remembers the state in the browser for the callback to
+// check. It is set for the whole site because linking starts outside the
+// auth routes.
+func setOAuthStateCookie(c *fiber.Ctx, state string) {
+	c.Cookie(&fiber.Cookie{
+		Name:     oauthStateCookieName,
+		Value:    state,
+		Path:     "/",
+		HTTPOnly: true,
+		Secure:   true,
+		SameSite: "Lax",
+	})
+}
+
+func clientInfo(c *fiber.Ctx) domain.ClientInfo {
+	return domain.ClientInfo{
+		IPAddress: c.IP(),
+		UserAgent: c.Get(fiber.HeaderUserAgent),
+	}
+}
@@ -0,0 +1,27 @@
+package handler
+
+import (
+	"github.com/raflytch/careerly-server/internal/domain"
+	"github.com/raflytch/careerly-server/pkg/response"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+type CacheHandler struct {
+	cacheWarmService domain.CacheWarmService
+}
+
+func NewCacheHandler(cacheWarmService domain.CacheWarmService) *CacheHandler {
+	return &CacheHandler{
+		cacheWarmService: cacheWarmService,
+	}
+}
+
+func (h *CacheHandler) Warm(c *fiber.Ctx) error {
+	result, err := h.cacheWarmService.Warm(c.UserContext())
+	if err != nil {
+		return respondError(c, err)
+	}
+
+	return response.Success(c, fiber.StatusOK, "cache warmed successfully", result)
+}
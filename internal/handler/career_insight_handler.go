@@ -0,0 +1,33 @@
+package handler
+
+import (
+	"github.com/raflytch/careerly-server/internal/domain"
+	"github.com/raflytch/careerly-server/internal/middleware"
+	"github.com/raflytch/careerly-server/pkg/response"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+type CareerInsightHandler struct {
+	careerInsightService domain.CareerInsightService
+}
+
+func NewCareerInsightHandler(careerInsightService domain.CareerInsightService) *CareerInsightHandler {
+	return &CareerInsightHandler{
+		careerInsightService: careerInsightService,
+	}
+}
+
+func (h *CareerInsightHandler) GetSkillGap(c *fiber.Ctx) error {
+	user := middleware.GetUserFromContext(c)
+	if user == nil {
+		return response.Unauthorized(c, "user not authenticated")
+	}
+
+	report, err := h.careerInsightService.GetSkillGapReport(c.UserContext(), user.ID)
+	if err != nil {
+		return respondError(c, err)
+	}
+
+	return response.Success(c, fiber.StatusOK, "skill gap report retrieved successfully", report)
+}
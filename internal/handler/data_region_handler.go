@@ -0,0 +1,42 @@
+package handler
+
+import (
+	"github.com/raflytch/careerly-server/internal/domain"
+	"github.com/raflytch/careerly-server/pkg/response"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/google/uuid"
+)
+
+type DataRegionHandler struct {
+	regionService domain.DataRegionService
+}
+
+func NewDataRegionHandler(regionService domain.DataRegionService) *DataRegionHandler {
+	return &DataRegionHandler{
+		regionService: regionService,
+	}
+}
+
+func (h *DataRegionHandler) GetRegions(c *fiber.Ctx) error {
+	return response.Success(c, fiber.StatusOK, "data regions retrieved", h.regionService.Regions())
+}
+
+func (h *DataRegionHandler) SetUserRegion(c *fiber.Ctx) error {
+	userID, err := uuid.Parse(c.Params("id"))
+	if err != nil {
+		return response.BadRequest(c, "invalid user id")
+	}
+
+	var req domain.SetDataRegionRequest
+	if err := bindAndValidate(c, &req); err != nil {
+		return validationFailed(c, err)
+	}
+
+	user, err := h.regionService.SetUserRegion(c.UserContext(), userID, req.DataRegion)
+	if err != nil {
+		return respondError(c, err)
+	}
+
+	return response.Success(c, fiber.StatusOK, "data region updated", user)
+}
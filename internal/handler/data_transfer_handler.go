@@ -0,0 +1,69 @@
+package handler
+
+import (
+	"fmt"
+
+	"github.com/raflytch/careerly-server/internal/domain"
+	"github.com/raflytch/careerly-server/pkg/response"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/google/uuid"
+)
+
+type DataTransferHandler struct {
+	dataTransferService domain.DataTransferService
+}
+
+func NewDataTransferHandler(dataTransferService domain.DataTransferService) *DataTransferHandler {
+	return &DataTransferHandler{
+		dataTransferService: dataTransferService,
+	}
+}
+
+func (h *DataTransferHandler) Export(c *fiber.Ctx) error {
+	idParam := c.Params("id")
+	id, err := uuid.Parse(idParam)
+	if err != nil {
+		return response.BadRequest(c, "invalid user id")
+	}
+
+	opts := domain.ExportOptions{
+		RedactPII: c.QueryBool("redact_pii", false),
+	}
+
+	bundle, err := h.dataTransferService.ExportUserData(c.UserContext(), id, opts)
+	if err != nil {
+		return respondError(c, err)
+	}
+
+	if c.QueryBool("download", false) {
+		c.Set("Content-Disposition", fmt.Sprintf("attachment; filename=careerly_export_%s.json", id.String()))
+		return c.JSON(bundle)
+	}
+
+	return response.Success(c, fiber.StatusOK, "user data exported", bundle)
+}
+
+func (h *DataTransferHandler) Import(c *fiber.Ctx) error {
+	idParam := c.Params("id")
+	id, err := uuid.Parse(idParam)
+	if err != nil {
+		return response.BadRequest(c, "invalid user id")
+	}
+
+	var bundle domain.DataBundle
+	if err := c.BodyParser(&bundle); err != nil {
+		return response.BadRequest(c, "invalid data bundle")
+	}
+
+	opts := domain.ImportOptions{
+		RedactPII: c.QueryBool("redact_pii", false),
+	}
+
+	result, err := h.dataTransferService.ImportUserData(c.UserContext(), id, &bundle, opts)
+	if err != nil {
+		return respondError(c, err)
+	}
+
+	return response.Success(c, fiber.StatusCreated, "user data imported", result)
+}
@@ -0,0 +1,39 @@
+package handler
+
+import (
+	_ "embed"
+	"encoding/json"
+
+	"github.com/raflytch/careerly-server/pkg/openapi"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+//go:embed docs/swagger.html
+var swaggerUI []byte
+
+type DocsHandler struct {
+	spec []byte
+}
+
+func NewDocsHandler() (*DocsHandler, error) {
+	doc := openapi.New("Careerly API", "Resume builder, AI interviews and ATS checks.", "1.0.0")
+	doc.Add(apiRoutes()...)
+
+	spec, err := json.Marshal(doc)
+	if err != nil {
+		return nil, err
+	}
+
+	return &DocsHandler{spec: spec}, nil
+}
+
+func (h *DocsHandler) UI(c *fiber.Ctx) error {
+	c.Set(fiber.HeaderContentType, fiber.MIMETextHTMLCharsetUTF8)
+	return c.Send(swaggerUI)
+}
+
+func (h *DocsHandler) Spec(c *fiber.Ctx) error {
+	c.Set(fiber.HeaderContentType, fiber.MIMEApplicationJSON)
+	return c.Send(h.spec)
+}
@@ -0,0 +1,252 @@
+package handler
+
+import (
+	"net/http"
+
+	"github.com/raflytch/careerly-server/internal/domain"
+	"github.com/raflytch/careerly-server/pkg/openapi"
+)
+
+const apiPrefix = "/api/v1"
+
+var paging = []openapi.Param{
+	{Name: "page", Type: "integer"},
+	{Name: "limit", Type: "integer", Description: "1-100, defaults to 10"},
+}
+
+type graphQLRequest struct {
+	Query         string                 `json:"query" validate:"required"`
+	OperationName string                 `json:"operationName,omitempty"`
+	Variables     map[string]interface{} `json:"variables,omitempty"`
+}
+
+// apiRoutes documents every endpoint registered in internal/routes. Keep it
+// in the same order as the route files when adding endpoints.
+func apiRoutes() []openapi.Route {
+	routes := []openapi.Route{
+		{Method: http.MethodGet, Path: "/health", Tag: "system", Summary: "Health check"},
+		{Method: http.MethodGet, Path: "/metrics", Tag: "system", Summary: "Prometheus metrics", ContentType: "text/plain"},
+		{Method: http.MethodGet, Path: "/ws/interviews/:id", Tag: "interviews", Summary: "Stream interview evaluation progress over WebSocket", Auth: true, Status: http.StatusSwitchingProtocols},
+	}
+
+	api := []openapi.Route{
+		{Method: http.MethodGet, Path: "/auth/google/login", Tag: "auth", Summary: "Redirect to Google sign-in", Status: http.StatusFound, Query: []openapi.Param{{Name: "ref", Description: "referral code"}}},
+		{Method: http.MethodGet, Path: "/auth/google/callback", Tag: "auth", Summary: "Google OAuth callback, redirects to the frontend", Status: http.StatusFound, Query: []openapi.Param{{Name: "code"}}},
+		{Method: http.MethodPost, Path: "/auth/2fa/verify", Tag: "auth", Summary: "Complete a two-factor login", Request: domain.TwoFactorVerifyRequest{}, Response: domain.AuthResponse{}},
+		{Method: http.MethodPost, Path: "/auth/2fa/resend", Tag: "auth", Summary: "Resend the two-factor login code", Request: domain.TwoFactorResendRequest{}, Response: domain.OTPResponse{}},
+		{Method: http.MethodPost, Path: "/auth/restore/request-otp", Tag: "auth", Summary: "Request an OTP to restore a deleted account", Request: domain.OTPRequest{}, Response: domain.OTPResponse{}},
+		{Method: http.MethodPost, Path: "/auth/restore/verify-otp", Tag: "auth", Summary: "Restore a deleted account", Request: domain.OTPVerifyRequest{}, Response: domain.RestoreUserResponse{}},
+		{Method: http.MethodPost, Path: "/auth/restore/resend-otp", Tag: "auth", Summary: "Resend the account restore OTP", Request: domain.OTPRequest{}, Response: domain.OTPResponse{}},
+
+		{Method: http.MethodGet, Path: "/users/profile", Tag: "users", Summary: "Get the current user's profile", Auth: true, Response: domain.UserProfileResponse{}},
+		{Method: http.MethodPut, Path: "/users/profile", Tag: "users", Summary: "Update name, or upload an avatar with multipart field 'avatar'", Auth: true, Request: UpdateUserRequest{}, Response: domain.User{}},
+		{Method: http.MethodGet, Path: "/users/me/completeness", Tag: "users", Summary: "Get profile completeness", Auth: true, Response: domain.ProfileCompleteness{}},
+		{Method: http.MethodGet, Path: "/users/me/onboarding", Tag: "users", Summary: "Get onboarding checklist", Auth: true, Response: domain.OnboardingStatus{}},
+		{Method: http.MethodGet, Path: "/users/me/activity", Tag: "users", Summary: "List recent resume, ATS, interview and payment events for the dashboard", Auth: true, Query: paging, Response: domain.PaginatedActivities{}},
+		{Method: http.MethodPut, Path: "/users/me/2fa", Tag: "users", Summary: "Enable or disable two-factor login", Auth: true, Request: domain.TwoFactorSettingRequest{}, Response: domain.User{}},
+		{Method: http.MethodPut, Path: "/users/me/timezone", Tag: "users", Summary: "Set the IANA timezone used for quota periods and subscription dates", Auth: true, Request: domain.TimezoneSettingRequest{}, Response: domain.User{}},
+		{Method: http.MethodPost, Path: "/users/me/email", Tag: "users", Summary: "Request a contact email change, sends an OTP to the new address", Auth: true, Request: domain.ContactEmailChangeRequest{}, Response: domain.OTPResponse{}},
+		{Method: http.MethodPost, Path: "/users/me/email/verify", Tag: "users", Summary: "Confirm the contact email change with the OTP", Auth: true, Request: domain.ContactEmailVerifyRequest{}, Response: domain.User{}},
+		{Method: http.MethodDelete, Path: "/users/me/email", Tag: "users", Summary: "Remove the contact email and fall back to the login email", Auth: true, Response: domain.User{}},
+		{Method: http.MethodGet, Path: "/users/me/ai-history", Tag: "users", Summary: "List the current user's AI activity", Auth: true, Query: append([]openapi.Param{{Name: "feature"}}, paging...), Response: domain.PaginatedAIHistory{}},
+		{Method: http.MethodGet, Path: "/users/me/sessions", Tag: "users", Summary: "List active sessions", Auth: true, Response: []domain.Session{}},
+		{Method: http.MethodDelete, Path: "/users/me/sessions/:id", Tag: "users", Summary: "Revoke a session", Auth: true},
+		{Method: http.MethodGet, Path: "/users/me/identities", Tag: "users", Summary: "List login providers linked to the account", Auth: true, Response: []domain.AuthIdentity{}},
+		{Method: http.MethodPost, Path: "/users/me/identities/:provider", Tag: "users", Summary: "Start linking another login provider, returns the consent URL", Auth: true, Response: domain.LinkProviderResponse{}},
+		{Method: http.MethodDelete, Path: "/users/me/identities/:id", Tag: "users", Summary: "Unlink a login provider", Auth: true},
+		{Method: http.MethodPost, Path: "/users/delete/request-otp", Tag: "users", Summary: "Request an OTP to delete the account", Auth: true, Response: domain.OTPResponse{}},
+		{Method: http.MethodPost, Path: "/users/delete/verify-otp", Tag: "users", Summary: "Delete the account, which stays restorable until its scheduled removal", Auth: true, Request: domain.DeleteOTPVerifyRequest{}, Response: domain.DeleteAccountResponse{}},
+		{Method: http.MethodPost, Path: "/users/delete/resend-otp", Tag: "users", Summary: "Resend the account deletion OTP", Auth: true, Response: domain.OTPResponse{}},
+		{Method: http.MethodGet, Path: "/users", Tag: "admin", Summary: "List and search users", Auth: true, Query: append([]openapi.Param{{Name: "q", Description: "matches part of the name or email"}, {Name: "role", Description: "user or admin"}, {Name: "is_active", Type: "boolean"}, {Name: "plan_id", Description: "users whose current subscription is on this plan"}, {Name: "created_from", Description: "YYYY-MM-DD"}, {Name: "created_to", Description: "YYYY-MM-DD, inclusive"}, {Name: "last_login_from", Description: "YYYY-MM-DD"}, {Name: "last_login_to", Description: "YYYY-MM-DD, inclusive"}}, paging...), Response: domain.PaginatedUsers{}},
+		{Method: http.MethodGet, Path: "/users/:id", Tag: "admin", Summary: "Get a user", Auth: true, Response: domain.User{}},
+		{Method: http.MethodDelete, Path: "/users/:id", Tag: "admin", Summary: "Delete a user", Auth: true},
+
+		{Method: http.MethodPost, Path: "/plans", Tag: "plans", Summary: "Create a plan", Auth: true, Status: http.StatusCreated, Request: domain.CreatePlanRequest{}, Response: domain.Plan{}},
+		{Method: http.MethodGet, Path: "/plans", Tag: "plans", Summary: "List plans priced for the caller's country", Auth: true, Query: append([]openapi.Param{{Name: "include_inactive", Type: "boolean"}, {Name: "country"}}, paging...), Response: domain.LocalizedPlans{}},
+		{Method: http.MethodGet, Path: "/plans/:id", Tag: "plans", Summary: "Get a plan (admin)", Auth: true, Response: domain.Plan{}},
+		{Method: http.MethodPut, Path: "/plans/:id", Tag: "plans", Summary: "Update a plan (admin)", Auth: true, Request: domain.UpdatePlanRequest{}, Response: domain.Plan{}},
+		{Method: http.MethodDelete, Path: "/plans/:id", Tag: "plans", Summary: "Delete a plan nobody has bought (admin)", Auth: true},
+		{Method: http.MethodPost, Path: "/plans/:id/archive", Tag: "plans", Summary: "Take a plan off sale, keeping it for current subscribers (admin)", Auth: true, Response: domain.Plan{}},
+		{Method: http.MethodPost, Path: "/plans/:id/unarchive", Tag: "plans", Summary: "Put an archived plan back on sale (admin)", Auth: true, Response: domain.Plan{}},
+
+		{Method: http.MethodPost, Path: "/resumes", Tag: "resumes", Summary: "Create a resume; returns 409 DUPLICATE_RESUME with the matching resume unless force is set", Auth: true, Status: http.StatusCreated, Request: domain.CreateResumeRequest{}, Response: domain.ResumeResponse{}},
+		{Method: http.MethodGet, Path: "/resumes", Tag: "resumes", Summary: "List resumes", Auth: true, Query: append([]openapi.Param{{Name: "tag", Description: "only resumes with this tag id"}, {Name: "folder", Description: "only resumes in this folder id"}}, paging...), Response: domain.PaginatedResumes{}},
+		{Method: http.MethodPost, Path: "/resumes/drafts", Tag: "resumes", Summary: "Start a resume draft, saved as it is filled in without using quota", Auth: true, Status: http.StatusCreated, Request: domain.CreateResumeDraftRequest{}, Response: domain.ResumeDraft{}},
+		{Method: http.MethodGet, Path: "/resumes/drafts", Tag: "resumes", Summary: "List resume drafts", Auth: true, Response: []domain.ResumeDraft{}},
+		{Method: http.MethodGet, Path: "/resumes/drafts/:id", Tag: "resumes", Summary: "Get a resume draft", Auth: true, Response: domain.ResumeDraft{}},
+		{Method: http.MethodPatch, Path: "/resumes/drafts/:id", Tag: "resumes", Summary: "Save changes to a resume draft", Auth: true, Request: domain.UpdateResumeDraftRequest{}, Response: domain.ResumeDraft{}},
+		{Method: http.MethodDelete, Path: "/resumes/drafts/:id", Tag: "resumes", Summary: "Discard a resume draft", Auth: true},
+		{Method: http.MethodPost, Path: "/resumes/drafts/:id/publish", Tag: "resumes", Summary: "Publish a draft as a resume with AI enhancement, uses resume quota", Auth: true, Query: []openapi.Param{{Name: "force", Type: "boolean", Description: "publish even when the draft duplicates an existing resume"}}, Status: http.StatusCreated, Response: domain.ResumeResponse{}},
+		{Method: http.MethodPut, Path: "/resumes/:id/tags", Tag: "resumes", Summary: "Replace the tags of a resume, an empty list clears them", Auth: true, Request: domain.SetResumeTagsRequest{}, Response: domain.Resume{}},
+		{Method: http.MethodPut, Path: "/resumes/:id/folder", Tag: "resumes", Summary: "Move a resume into a folder, or out of its folder with a null folder_id", Auth: true, Request: domain.MoveResumeRequest{}, Response: domain.Resume{}},
+		{Method: http.MethodPost, Path: "/resume-tags", Tag: "resumes", Summary: "Create a resume tag", Auth: true, Status: http.StatusCreated, Request: domain.ResumeTagRequest{}, Response: domain.ResumeTag{}},
+		{Method: http.MethodGet, Path: "/resume-tags", Tag: "resumes", Summary: "List resume tags with how many resumes carry each", Auth: true, Response: []domain.ResumeTag{}},
+		{Method: http.MethodPatch, Path: "/resume-tags/:id", Tag: "resumes", Summary: "Rename a resume tag", Auth: true, Request: domain.ResumeTagRequest{}, Response: domain.ResumeTag{}},
+		{Method: http.MethodDelete, Path: "/resume-tags/:id", Tag: "resumes", Summary: "Delete a resume tag and remove it from its resumes", Auth: true},
+		{Method: http.MethodPost, Path: "/resume-folders", Tag: "resumes", Summary: "Create a resume folder", Auth: true, Status: http.StatusCreated, Request: domain.ResumeFolderRequest{}, Response: domain.ResumeFolder{}},
+		{Method: http.MethodGet, Path: "/resume-folders", Tag: "resumes", Summary: "List resume folders with how many resumes each holds", Auth: true, Response: []domain.ResumeFolder{}},
+		{Method: http.MethodPatch, Path: "/resume-folders/:id", Tag: "resumes", Summary: "Rename a resume folder", Auth: true, Request: domain.ResumeFolderRequest{}, Response: domain.ResumeFolder{}},
+		{Method: http.MethodDelete, Path: "/resume-folders/:id", Tag: "resumes", Summary: "Delete a resume folder, keeping its resumes", Auth: true},
+		{Method: http.MethodGet, Path: "/resumes/quota", Tag: "resumes", Summary: "Get the current month's quota", Auth: true, Response: domain.UserQuota{}},
+		{Method: http.MethodGet, Path: "/resumes/search", Tag: "resumes", Summary: "Full-text search resumes", Auth: true, Query: append([]openapi.Param{{Name: "q"}}, paging...), Response: domain.PaginatedResumeSearch{}},
+		{Method: http.MethodGet, Path: "/resumes/trash", Tag: "resumes", Summary: "List deleted resumes that can still be restored", Auth: true, Query: paging, Response: domain.PaginatedResumes{}},
+		{Method: http.MethodGet, Path: "/resumes/:id", Tag: "resumes", Summary: "Get a resume", Auth: true, Response: domain.Resume{}},
+		{Method: http.MethodPut, Path: "/resumes/:id", Tag: "resumes", Summary: "Update a resume", Auth: true, Request: domain.UpdateResumeRequest{}, Response: domain.ResumeResponse{}},
+		{Method: http.MethodDelete, Path: "/resumes/:id", Tag: "resumes", Summary: "Delete a resume", Auth: true},
+		{Method: http.MethodPost, Path: "/resumes/:id/restore", Tag: "resumes", Summary: "Restore a deleted resume", Auth: true, Response: domain.Resume{}},
+		{Method: http.MethodGet, Path: "/resumes/:id/pdf", Tag: "resumes", Summary: "Download a resume as PDF", Auth: true, Query: []openapi.Param{{Name: "accent_color", Description: "#RRGGBB, plans with custom branding only"}, {Name: "font", Description: "helvetica, times or courier, plans with custom branding only"}, {Name: "condensed", Type: "boolean", Description: "shrink fonts so the resume fits on one page"}, {Name: "redact", Type: "boolean", Description: "leave out date of birth and phone and show only the region of the address, defaults to the resume's redact_personal setting"}}, ContentType: "application/pdf"},
+		{Method: http.MethodGet, Path: "/resumes/:id/pdf/link", Tag: "resumes", Summary: "Get a short-lived link to the stored resume PDF, rendering it only when the resume changed", Auth: true, Query: []openapi.Param{{Name: "accent_color", Description: "#RRGGBB, plans with custom branding only"}, {Name: "font", Description: "helvetica, times or courier, plans with custom branding only"}, {Name: "condensed", Type: "boolean", Description: "shrink fonts so the resume fits on one page"}, {Name: "redact", Type: "boolean", Description: "leave out date of birth and phone and show only the region of the address, defaults to the resume's redact_personal setting"}}, Response: domain.ArtifactLink{}},
+		{Method: http.MethodPost, Path: "/resumes/:id/share", Tag: "resumes", Summary: "Create a read-only review link", Auth: true, Status: http.StatusCreated, Request: domain.CreateResumeShareRequest{}, Response: domain.ResumeShareResponse{}},
+		{Method: http.MethodDelete, Path: "/resumes/:id/share/:shareId", Tag: "resumes", Summary: "Revoke a review link", Auth: true},
+		{Method: http.MethodGet, Path: "/resumes/:id/comments", Tag: "resumes", Summary: "List reviewer comments", Auth: true, Query: []openapi.Param{{Name: "resolved", Description: "true or false, omit for all comments"}}, Response: []domain.ResumeComment{}},
+		{Method: http.MethodPost, Path: "/resumes/:id/feedback", Tag: "resumes", Summary: "Rate the AI enhancement of a resume", Auth: true, Request: domain.AIFeedbackRequest{}, Response: domain.AIFeedback{}},
+		{Method: http.MethodPatch, Path: "/resumes/:id/comments/:commentId", Tag: "resumes", Summary: "Resolve or reopen a reviewer comment", Auth: true, Request: domain.ResolveResumeCommentRequest{}, Response: domain.ResumeComment{}},
+		{Method: http.MethodGet, Path: "/resumes/:id/analytics", Tag: "resumes", Summary: "View and download counts of the resume's share links, by day, referrer and country", Auth: true, Response: domain.ResumeAnalytics{}},
+		{Method: http.MethodGet, Path: "/shared/resumes/:token", Tag: "resumes", Summary: "View a shared resume with its comments", Response: domain.SharedResume{}},
+		{Method: http.MethodGet, Path: "/shared/resumes/:token/pdf", Tag: "resumes", Summary: "Download a shared resume as PDF", ContentType: "application/pdf"},
+		{Method: http.MethodPost, Path: "/shared/resumes/:token/comments", Tag: "resumes", Summary: "Comment on a shared resume or one of its sections", Status: http.StatusCreated, Request: domain.ResumeCommentRequest{}, Response: domain.ResumeComment{}},
+		{Method: http.MethodGet, Path: "/resumes/:id/lint", Tag: "resumes", Summary: "Check a resume for common issues without using AI quota", Auth: true, Response: domain.ResumeLintReport{}},
+		{Method: http.MethodPut, Path: "/resumes/:id/photo", Tag: "resumes", Summary: "Upload a resume photo", Auth: true, Form: map[string]string{"photo": "binary"}, Response: domain.Resume{}},
+		{Method: http.MethodPatch, Path: "/resumes/:id/photo", Tag: "resumes", Summary: "Show or hide the resume photo", Auth: true, Request: domain.ResumePhotoVisibilityRequest{}, Response: domain.Resume{}},
+		{Method: http.MethodPatch, Path: "/resumes/:id/redaction", Tag: "resumes", Summary: "Set whether PDFs and share links redact personal details by default", Auth: true, Request: domain.ResumeRedactionRequest{}, Response: domain.Resume{}},
+		{Method: http.MethodDelete, Path: "/resumes/:id/photo", Tag: "resumes", Summary: "Remove the resume photo", Auth: true, Response: domain.Resume{}},
+		{Method: http.MethodPost, Path: "/resumes/bullets/generate", Tag: "resumes", Summary: "Generate alternative bullet points from a casual description", Auth: true, Request: domain.GenerateBulletsRequest{}, Response: domain.GeneratedBullets{}},
+		{Method: http.MethodPost, Path: "/resumes/:id/optimize", Tag: "resumes", Summary: "Generate ATS vendor optimization suggestions", Auth: true, Request: domain.OptimizeResumeRequest{}, Response: domain.ResumeOptimization{}},
+		{Method: http.MethodPost, Path: "/resumes/:id/apply-suggestions", Tag: "resumes", Summary: "Apply selected optimization suggestions", Auth: true, Request: domain.ApplySuggestionsRequest{}, Response: domain.ApplySuggestionsResult{}},
+		{Method: http.MethodPost, Path: "/resumes/:id/ats-check", Tag: "resumes", Summary: "Run an ATS analysis on a stored resume", Auth: true, Status: http.StatusCreated, Response: domain.ATSCheckResponse{}},
+
+		{Method: http.MethodPost, Path: "/interviews", Tag: "interviews", Summary: "Create an interview", Auth: true, Status: http.StatusCreated, Request: domain.CreateInterviewRequest{}, Response: domain.InterviewResponse{}},
+		{Method: http.MethodPost, Path: "/interviews/schedule", Tag: "interviews", Summary: "Schedule an interview", Auth: true, Status: http.StatusCreated, Request: domain.ScheduleInterviewRequest{}, Response: domain.InterviewResponse{}},
+		{Method: http.MethodPost, Path: "/interviews/from-pack/:id", Tag: "interviews", Summary: "Start an interview from a curated pack", Auth: true, Status: http.StatusCreated, Response: domain.InterviewResponse{}},
+		{Method: http.MethodGet, Path: "/interview-packs", Tag: "interviews", Summary: "List available interview packs", Auth: true, Query: paging, Response: domain.PaginatedInterviewPackSummaries{}},
+		{Method: http.MethodGet, Path: "/interviews", Tag: "interviews", Summary: "List interviews", Auth: true, Query: paging, Response: domain.PaginatedInterviews{}},
+		{Method: http.MethodGet, Path: "/interviews/trash", Tag: "interviews", Summary: "List deleted interviews that can still be restored", Auth: true, Query: paging, Response: domain.PaginatedInterviews{}},
+		{Method: http.MethodGet, Path: "/interviews/progress", Tag: "interviews", Summary: "Score progression of completed interviews for a job position", Auth: true, Query: []openapi.Param{{Name: "position", Description: "Job position, matched ignoring case"}}, Response: domain.InterviewPositionProgress{}},
+		{Method: http.MethodGet, Path: "/interviews/:id", Tag: "interviews", Summary: "Get an interview", Auth: true, Response: domain.InterviewForUser{}},
+		{Method: http.MethodPost, Path: "/interviews/:id/feedback", Tag: "interviews", Summary: "Rate the AI feedback on an interview", Auth: true, Request: domain.AIFeedbackRequest{}, Response: domain.AIFeedback{}},
+		{Method: http.MethodPost, Path: "/interviews/:id/submit", Tag: "interviews", Summary: "Submit answers for evaluation", Auth: true, Status: http.StatusAccepted, Request: domain.SubmitAnswerRequest{}, Response: domain.InterviewResponse{}},
+		{Method: http.MethodPost, Path: "/interviews/:id/rounds", Tag: "interviews", Summary: "Submit an adaptive interview round", Auth: true, Request: domain.SubmitAnswerRequest{}, Response: domain.InterviewResponse{}},
+		{Method: http.MethodPost, Path: "/interviews/:id/questions/:questionId/video", Tag: "interviews", Summary: "Upload and transcribe a video answer to an essay question", Auth: true, Status: http.StatusCreated, Form: map[string]string{"video": "binary"}, Response: domain.QuestionForUser{}},
+		{Method: http.MethodGet, Path: "/interviews/:id/evaluation", Tag: "interviews", Summary: "Get evaluation job status", Auth: true, Response: domain.EvaluationJob{}},
+		{Method: http.MethodGet, Path: "/interviews/:id/export", Tag: "interviews", Summary: "Download questions, answers, feedback and scores", Auth: true, Query: []openapi.Param{{Name: "format", Description: "json (default) or markdown"}}, ContentType: "application/octet-stream"},
+		{Method: http.MethodDelete, Path: "/interviews/:id", Tag: "interviews", Summary: "Delete an interview", Auth: true},
+		{Method: http.MethodPost, Path: "/interviews/:id/restore", Tag: "interviews", Summary: "Restore a deleted interview", Auth: true, Response: domain.InterviewForUser{}},
+		{Method: http.MethodPost, Path: "/interviews/:id/share", Tag: "interviews", Summary: "Create a mentor share link", Auth: true, Status: http.StatusCreated, Request: domain.CreateInterviewShareRequest{}, Response: domain.InterviewShareResponse{}},
+		{Method: http.MethodDelete, Path: "/interviews/:id/share/:shareId", Tag: "interviews", Summary: "Revoke a share link", Auth: true},
+		{Method: http.MethodGet, Path: "/interviews/:id/comments", Tag: "interviews", Summary: "List mentor comments", Auth: true, Response: []domain.MentorComment{}},
+		{Method: http.MethodPost, Path: "/interviews/:id/study-plan", Tag: "interviews", Summary: "Generate a 14-day study plan from the weak areas of a completed interview, or return the saved one", Auth: true, Query: []openapi.Param{{Name: "regenerate", Type: "boolean", Description: "replace the saved plan and its progress"}}, Response: domain.StudyPlan{}},
+		{Method: http.MethodGet, Path: "/interviews/:id/study-plan", Tag: "interviews", Summary: "Get the saved study plan", Auth: true, Response: domain.StudyPlan{}},
+		{Method: http.MethodPatch, Path: "/interviews/:id/study-plan/tasks/:taskId", Tag: "interviews", Summary: "Check off or reopen a study plan task", Auth: true, Request: domain.UpdateStudyPlanTaskRequest{}, Response: domain.StudyPlan{}},
+		{Method: http.MethodGet, Path: "/shared/interviews/:token", Tag: "shared", Summary: "View a shared interview report", Response: domain.SharedInterviewReport{}},
+		{Method: http.MethodPost, Path: "/shared/interviews/:token/comments", Tag: "shared", Summary: "Leave a mentor comment", Status: http.StatusCreated, Request: domain.MentorCommentRequest{}, Response: domain.MentorComment{}},
+
+		{Method: http.MethodPost, Path: "/ats-checks/analyze", Tag: "ats-checks", Summary: "Start analyzing a PDF resume, follow it on /ats-checks/:id/events", Auth: true, Status: http.StatusAccepted, Form: map[string]string{"file": "binary"}, Response: domain.ATSAnalysisJob{}},
+		{Method: http.MethodPost, Path: "/ats-checks/batch", Tag: "ats-checks", Summary: "Analyze a PDF resume against several job descriptions", Auth: true, Form: map[string]string{"file": "binary", "job_descriptions": "string"}, Response: domain.ATSBatchResponse{}},
+		{Method: http.MethodGet, Path: "/ats-checks", Tag: "ats-checks", Summary: "List ATS checks", Auth: true, Query: paging, Response: domain.PaginatedATSChecks{}},
+		{Method: http.MethodGet, Path: "/ats-checks/compare", Tag: "ats-checks", Summary: "Compare two ATS checks: score, section, keyword and improvement changes", Auth: true, Query: []openapi.Param{{Name: "from", Description: "ID of the earlier check"}, {Name: "to", Description: "ID of the later check"}}, Response: domain.ATSComparison{}},
+		{Method: http.MethodGet, Path: "/ats-checks/:id", Tag: "ats-checks", Summary: "Get an ATS check", Auth: true, Response: domain.ATSCheck{}},
+		{Method: http.MethodGet, Path: "/ats-checks/:id/events", Tag: "ats-checks", Summary: "Stream an analysis as server-sent events: uploaded, analyzing, scoring, then done with the result or failed", Auth: true, ContentType: "text/event-stream"},
+		{Method: http.MethodGet, Path: "/ats-checks/:id/annotated-pdf", Tag: "ats-checks", Summary: "Download the uploaded resume with issues highlighted", Auth: true, ContentType: "application/pdf"},
+		{Method: http.MethodPost, Path: "/ats-checks/:id/feedback", Tag: "ats-checks", Summary: "Rate an ATS analysis", Auth: true, Request: domain.AIFeedbackRequest{}, Response: domain.AIFeedback{}},
+		{Method: http.MethodDelete, Path: "/ats-checks/:id", Tag: "ats-checks", Summary: "Delete an ATS check", Auth: true},
+
+		{Method: http.MethodPost, Path: "/email/events/:provider", Tag: "email", Summary: "Delivery status callback from SendGrid or SES (via SNS)", Query: []openapi.Param{{Name: "token", Description: "EMAIL_CALLBACK_TOKEN"}}, Request: map[string]interface{}{}},
+		{Method: http.MethodPost, Path: "/transactions/webhook", Tag: "transactions", Summary: "Midtrans payment notification", Request: map[string]interface{}{}},
+		{Method: http.MethodPost, Path: "/dev/simulate-payment/:orderID", Tag: "transactions", Summary: "Settle the caller's order through the webhook pipeline without paying; only with APP_ENV=development and the Midtrans sandbox", Auth: true, Status: http.StatusAccepted},
+		{Method: http.MethodPost, Path: "/transactions", Tag: "transactions", Summary: "Create a transaction", Auth: true, Status: http.StatusCreated, Request: domain.CreateTransactionRequest{}, Response: domain.TransactionResponse{}},
+		{Method: http.MethodPost, Path: "/transactions/addons", Tag: "transactions", Summary: "Buy a one-time add-on pack for the current usage period", Auth: true, Status: http.StatusCreated, Request: domain.CreateAddonTransactionRequest{}, Response: domain.TransactionResponse{}},
+		{Method: http.MethodPost, Path: "/transactions/gifts", Tag: "transactions", Summary: "Buy a plan for up to 10 recipients, who get redemption codes by email once paid", Auth: true, Status: http.StatusCreated, Request: domain.CreateGiftTransactionRequest{}, Response: domain.TransactionResponse{}},
+		{Method: http.MethodPost, Path: "/transactions/saved-card", Tag: "transactions", Summary: "Buy a plan with a saved card; returns 202 with a redirect URL when the bank asks for 3D Secure", Auth: true, Status: http.StatusCreated, Request: domain.ChargeSavedCardRequest{}, Response: domain.TransactionResponse{}},
+		{Method: http.MethodGet, Path: "/addons", Tag: "transactions", Summary: "List add-on packs available for purchase", Auth: true, Query: paging, Response: domain.PaginatedAddons{}},
+		{Method: http.MethodPost, Path: "/subscriptions/schedule-change", Tag: "transactions", Summary: "Switch to a free plan when the current subscription ends", Auth: true, Request: domain.ScheduleChangeRequest{}, Response: domain.Subscription{}},
+		{Method: http.MethodDelete, Path: "/subscriptions/schedule-change", Tag: "transactions", Summary: "Cancel a scheduled plan change", Auth: true, Response: domain.Subscription{}},
+		{Method: http.MethodPost, Path: "/subscriptions/redeem", Tag: "transactions", Summary: "Activate a gifted plan with its redemption code", Auth: true, Request: domain.RedeemGiftRequest{}, Response: domain.Subscription{}},
+		{Method: http.MethodGet, Path: "/subscriptions/gifts", Tag: "transactions", Summary: "List gift codes issued for the caller's purchases", Auth: true, Response: []domain.Gift{}},
+		{Method: http.MethodGet, Path: "/transactions", Tag: "transactions", Summary: "List transactions", Auth: true, Query: paging, Response: domain.PaginatedTransactions{}},
+		{Method: http.MethodGet, Path: "/transactions/:id", Tag: "transactions", Summary: "Get a transaction", Auth: true, Response: domain.Transaction{}},
+		{Method: http.MethodGet, Path: "/transactions/:id/status", Tag: "transactions", Summary: "Refresh status from the payment gateway", Auth: true, Response: domain.Transaction{}},
+
+		{Method: http.MethodGet, Path: "/files", Tag: "files", Summary: "Download a locally stored file through a signed link", Query: []openapi.Param{{Name: "token", Description: "signed token from the link"}}, ContentType: "application/octet-stream"},
+		{Method: http.MethodGet, Path: "/schema", Tag: "schema", Summary: "List resources with request schemas", Response: []string{}},
+		{Method: http.MethodGet, Path: "/schema/:resource", Tag: "schema", Summary: "Get request schemas for a resource", Response: resourceSchema{}},
+		{Method: http.MethodGet, Path: "/referrals/stats", Tag: "referrals", Summary: "Get referral stats", Auth: true, Response: domain.ReferralStats{}},
+		{Method: http.MethodGet, Path: "/notifications", Tag: "notifications", Summary: "List in-app notifications, newest first", Auth: true, Query: paging, Response: domain.PaginatedNotifications{}},
+		{Method: http.MethodGet, Path: "/payment-methods", Tag: "payment-methods", Summary: "List cards saved on earlier card payments", Auth: true, Response: []domain.PaymentMethod{}},
+		{Method: http.MethodDelete, Path: "/payment-methods/:id", Tag: "payment-methods", Summary: "Delete a saved card", Auth: true},
+		{Method: http.MethodPost, Path: "/organizations", Tag: "organizations", Summary: "Create an organization owned by the caller", Auth: true, Status: http.StatusCreated, Request: domain.CreateOrganizationRequest{}, Response: domain.Organization{}},
+		{Method: http.MethodPost, Path: "/organizations/invitations/accept", Tag: "organizations", Summary: "Join an organization with an emailed invitation", Auth: true, Request: domain.AcceptOrganizationInvitationRequest{}, Response: domain.Organization{}},
+		{Method: http.MethodGet, Path: "/organizations/current", Tag: "organizations", Summary: "Get the caller's organization and members", Auth: true, Response: domain.OrganizationDetail{}},
+		{Method: http.MethodGet, Path: "/organizations/current/quota", Tag: "organizations", Summary: "Pooled quota and per-member usage (owner only)", Auth: true, Response: domain.OrganizationQuota{}},
+		{Method: http.MethodPost, Path: "/organizations/current/seats", Tag: "organizations", Summary: "Buy a plan for the organization, charged per seat (owner only)", Auth: true, Status: http.StatusCreated, Request: domain.CreateSeatTransactionRequest{}, Response: domain.TransactionResponse{}},
+		{Method: http.MethodPost, Path: "/organizations/current/invitations", Tag: "organizations", Summary: "Invite a member by email (owner only)", Auth: true, Status: http.StatusCreated, Request: domain.InviteOrganizationMemberRequest{}, Response: domain.OrganizationInvitation{}},
+		{Method: http.MethodDelete, Path: "/organizations/current/members/:userId", Tag: "organizations", Summary: "Remove a member (owner only)", Auth: true},
+		{Method: http.MethodPost, Path: "/organizations/current/leave", Tag: "organizations", Summary: "Leave the organization", Auth: true},
+		{Method: http.MethodGet, Path: "/insights/skill-gap", Tag: "insights", Summary: "Get a skill gap report", Auth: true, Response: domain.SkillGapReport{}},
+		{Method: http.MethodGet, Path: "/graphql", Tag: "graphql", Summary: "GraphQL query over GET", Auth: true, Query: []openapi.Param{{Name: "query"}, {Name: "variables"}, {Name: "operationName"}}, Response: map[string]interface{}{}},
+		{Method: http.MethodPost, Path: "/graphql", Tag: "graphql", Summary: "GraphQL query", Auth: true, Request: graphQLRequest{}, Response: map[string]interface{}{}},
+
+		{Method: http.MethodGet, Path: "/admin/users/:id/export", Tag: "admin", Summary: "Export a user's data", Auth: true, Query: []openapi.Param{{Name: "redact_pii", Type: "boolean"}, {Name: "download", Type: "boolean"}}, Response: domain.DataBundle{}},
+		{Method: http.MethodPost, Path: "/admin/users/:id/import", Tag: "admin", Summary: "Import a data bundle into a user", Auth: true, Status: http.StatusCreated, Query: []openapi.Param{{Name: "redact_pii", Type: "boolean"}}, Request: domain.DataBundle{}, Response: domain.ImportResult{}},
+		{Method: http.MethodPost, Path: "/admin/cache/warm", Tag: "admin", Summary: "Warm the cache", Auth: true, Response: domain.CacheWarmResult{}},
+		{Method: http.MethodGet, Path: "/admin/ai-usage", Tag: "admin", Summary: "AI usage report", Auth: true, Query: []openapi.Param{{Name: "from", Description: "YYYY-MM-DD"}, {Name: "to", Description: "YYYY-MM-DD"}}, Response: domain.AIUsageReport{}},
+		{Method: http.MethodGet, Path: "/admin/ai-quality", Tag: "admin", Summary: "User satisfaction with AI outputs per feature and prompt version", Auth: true, Query: []openapi.Param{{Name: "from", Description: "YYYY-MM-DD"}, {Name: "to", Description: "YYYY-MM-DD"}}, Response: domain.AIQualityReport{}},
+		{Method: http.MethodGet, Path: "/admin/ai-usage/logs", Tag: "admin", Summary: "AI usage logs", Auth: true, Query: append([]openapi.Param{{Name: "feature"}}, paging...), Response: domain.PaginatedAIUsage{}},
+		{Method: http.MethodGet, Path: "/admin/provisioning-jobs", Tag: "admin", Summary: "List provisioning jobs", Auth: true, Query: append([]openapi.Param{{Name: "status"}}, paging...), Response: domain.PaginatedProvisioningJobs{}},
+		{Method: http.MethodGet, Path: "/admin/provisioning-jobs/:id", Tag: "admin", Summary: "Get a provisioning job", Auth: true, Response: domain.ProvisioningJob{}},
+		{Method: http.MethodPost, Path: "/admin/provisioning-jobs/:id/replay", Tag: "admin", Summary: "Replay a provisioning job", Auth: true, Response: domain.ProvisioningJob{}},
+		{Method: http.MethodPost, Path: "/admin/webhooks", Tag: "admin", Summary: "Register a webhook endpoint", Auth: true, Status: http.StatusCreated, Request: domain.CreateWebhookEndpointRequest{}, Response: domain.WebhookEndpointSecret{}},
+		{Method: http.MethodGet, Path: "/admin/webhooks", Tag: "admin", Summary: "List webhook endpoints", Auth: true, Query: paging, Response: domain.PaginatedWebhookEndpoints{}},
+		{Method: http.MethodGet, Path: "/admin/webhooks/:id", Tag: "admin", Summary: "Get a webhook endpoint", Auth: true, Response: domain.WebhookEndpoint{}},
+		{Method: http.MethodPut, Path: "/admin/webhooks/:id", Tag: "admin", Summary: "Update a webhook endpoint", Auth: true, Request: domain.UpdateWebhookEndpointRequest{}, Response: domain.WebhookEndpoint{}},
+		{Method: http.MethodDelete, Path: "/admin/webhooks/:id", Tag: "admin", Summary: "Delete a webhook endpoint", Auth: true},
+		{Method: http.MethodPost, Path: "/admin/webhooks/:id/rotate-secret", Tag: "admin", Summary: "Rotate a webhook signing secret", Auth: true, Response: domain.WebhookEndpointSecret{}},
+		{Method: http.MethodGet, Path: "/admin/webhooks/:id/deliveries", Tag: "admin", Summary: "List webhook delivery logs", Auth: true, Query: append([]openapi.Param{{Name: "status"}}, paging...), Response: domain.PaginatedWebhookDeliveries{}},
+		{Method: http.MethodPost, Path: "/admin/webhooks/:id/deliveries/:deliveryId/redeliver", Tag: "admin", Summary: "Retry a webhook delivery now", Auth: true, Response: domain.WebhookDelivery{}},
+		{Method: http.MethodPost, Path: "/admin/interview-packs", Tag: "admin", Summary: "Create an interview pack", Auth: true, Status: http.StatusCreated, Request: domain.CreateInterviewPackRequest{}, Response: domain.InterviewPack{}},
+		{Method: http.MethodGet, Path: "/admin/interview-packs", Tag: "admin", Summary: "List interview packs", Auth: true, Query: append([]openapi.Param{{Name: "include_inactive", Description: "true (default) or false"}}, paging...), Response: domain.PaginatedInterviewPacks{}},
+		{Method: http.MethodGet, Path: "/admin/interview-packs/:id", Tag: "admin", Summary: "Get an interview pack", Auth: true, Response: domain.InterviewPack{}},
+		{Method: http.MethodPut, Path: "/admin/interview-packs/:id", Tag: "admin", Summary: "Update an interview pack", Auth: true, Request: domain.UpdateInterviewPackRequest{}, Response: domain.InterviewPack{}},
+		{Method: http.MethodDelete, Path: "/admin/interview-packs/:id", Tag: "admin", Summary: "Delete an interview pack", Auth: true},
+		{Method: http.MethodPost, Path: "/admin/question-bank/import", Tag: "admin", Summary: "Import curated interview questions from a CSV with question, type, options, correct_answer, position and difficulty columns; reports rejected rows and skips duplicates", Auth: true, Status: http.StatusCreated, Form: map[string]string{"file": "binary"}, Response: domain.QuestionImportResult{}},
+		{Method: http.MethodGet, Path: "/admin/question-bank", Tag: "admin", Summary: "List question bank entries", Auth: true, Query: append([]openapi.Param{{Name: "position", Description: "Job position, case-insensitive"}, {Name: "type", Description: "essay or multiple_choice"}}, paging...), Response: domain.PaginatedBankQuestions{}},
+		{Method: http.MethodDelete, Path: "/admin/question-bank/:id", Tag: "admin", Summary: "Delete a question bank entry", Auth: true},
+		{Method: http.MethodPost, Path: "/admin/users/:id/quota-override", Tag: "admin", Summary: "Grant a user extra or unlimited quota for a feature until a given time", Auth: true, Status: http.StatusCreated, Request: domain.CreateQuotaOverrideRequest{}, Response: domain.QuotaOverride{}},
+		{Method: http.MethodGet, Path: "/admin/users/:id/quota-override", Tag: "admin", Summary: "List a user's quota overrides", Auth: true, Response: []domain.QuotaOverride{}},
+		{Method: http.MethodDelete, Path: "/admin/users/:id/quota-override/:overrideId", Tag: "admin", Summary: "Revoke a quota override", Auth: true, Response: domain.QuotaOverride{}},
+		{Method: http.MethodGet, Path: "/admin/data-regions", Tag: "admin", Summary: "List the data regions users can be pinned to", Auth: true, Response: []string{}},
+		{Method: http.MethodPut, Path: "/admin/users/:id/data-region", Tag: "admin", Summary: "Pin a user's resume content and PDFs to a data region (empty for the default); only while the user has no resumes", Auth: true, Request: domain.SetDataRegionRequest{}, Response: domain.User{}},
+		{Method: http.MethodPost, Path: "/admin/users/:id/subscriptions", Tag: "admin", Summary: "Grant a user a plan without payment, recorded as a zero-amount manual transaction", Auth: true, Status: http.StatusCreated, Request: domain.GrantSubscriptionRequest{}, Response: domain.SubscriptionGrant{}},
+		{Method: http.MethodPost, Path: "/admin/addons", Tag: "admin", Summary: "Create an add-on pack", Auth: true, Status: http.StatusCreated, Request: domain.CreateAddonRequest{}, Response: domain.Addon{}},
+		{Method: http.MethodGet, Path: "/admin/addons", Tag: "admin", Summary: "List add-on packs", Auth: true, Query: append([]openapi.Param{{Name: "include_inactive", Description: "true (default) or false"}}, paging...), Response: domain.PaginatedAddons{}},
+		{Method: http.MethodGet, Path: "/admin/addons/:id", Tag: "admin", Summary: "Get an add-on pack", Auth: true, Response: domain.Addon{}},
+		{Method: http.MethodPut, Path: "/admin/addons/:id", Tag: "admin", Summary: "Update an add-on pack", Auth: true, Request: domain.UpdateAddonRequest{}, Response: domain.Addon{}},
+		{Method: http.MethodDelete, Path: "/admin/addons/:id", Tag: "admin", Summary: "Delete an add-on pack", Auth: true},
+		{Method: http.MethodGet, Path: "/admin/email-suppressions", Tag: "admin", Summary: "List addresses suppressed after bounces or complaints", Auth: true, Query: paging, Response: domain.PaginatedEmailSuppressions{}},
+		{Method: http.MethodDelete, Path: "/admin/email-suppressions/:email", Tag: "admin", Summary: "Allow sending to a suppressed address again", Auth: true},
+		{Method: http.MethodGet, Path: "/admin/reconciliation", Tag: "admin", Summary: "Reconcile a month of transactions against Midtrans settlements", Auth: true, Query: []openapi.Param{{Name: "month", Description: "YYYY-MM, defaults to the current month"}, {Name: "format", Description: "csv (default) or json"}}, ContentType: "text/csv"},
+		{Method: http.MethodGet, Path: "/admin/jobs", Tag: "admin", Summary: "Get background job queue stats", Auth: true, Response: domain.JobQueueStats{}},
+		{Method: http.MethodGet, Path: "/admin/jobs/dead", Tag: "admin", Summary: "List jobs that ran out of attempts", Auth: true, Query: paging, Response: domain.PaginatedQueuedJobs{}},
+		{Method: http.MethodGet, Path: "/admin/jobs/dead/:id", Tag: "admin", Summary: "Get a dead job", Auth: true, Response: domain.QueuedJob{}},
+		{Method: http.MethodPost, Path: "/admin/jobs/dead/:id/retry", Tag: "admin", Summary: "Requeue a dead job with fresh attempts", Auth: true, Response: domain.QueuedJob{}},
+		{Method: http.MethodDelete, Path: "/admin/jobs/dead/:id", Tag: "admin", Summary: "Discard a dead job", Auth: true},
+		{Method: http.MethodGet, Path: "/admin/prompts", Tag: "admin", Summary: "List AI prompts and their active versions", Auth: true, Response: []domain.PromptSummary{}},
+		{Method: http.MethodGet, Path: "/admin/prompts/:key", Tag: "admin", Summary: "Get a prompt's built-in default and stored versions", Auth: true, Response: domain.PromptDetail{}},
+		{Method: http.MethodPost, Path: "/admin/prompts/:key/versions", Tag: "admin", Summary: "Store a new prompt version, optionally activating it", Auth: true, Status: http.StatusCreated, Request: domain.CreatePromptVersionRequest{}, Response: domain.Prompt{}},
+		{Method: http.MethodPost, Path: "/admin/prompts/:key/versions/:version/activate", Tag: "admin", Summary: "Make a stored version the active prompt", Auth: true, Response: domain.Prompt{}},
+		{Method: http.MethodDelete, Path: "/admin/prompts/:key/versions/:version", Tag: "admin", Summary: "Delete an inactive prompt version", Auth: true},
+		{Method: http.MethodPost, Path: "/admin/prompts/:key/reset", Tag: "admin", Summary: "Go back to the built-in default prompt", Auth: true},
+		{Method: http.MethodGet, Path: "/admin/experiments", Tag: "admin", Summary: "List prompt A/B experiments", Auth: true, Response: []domain.PromptExperiment{}},
+		{Method: http.MethodPost, Path: "/admin/experiments", Tag: "admin", Summary: "Start an experiment splitting users between prompt versions (0 is the built-in default)", Auth: true, Status: http.StatusCreated, Request: domain.CreatePromptExperimentRequest{}, Response: domain.PromptExperiment{}},
+		{Method: http.MethodGet, Path: "/admin/experiments/:id", Tag: "admin", Summary: "Users, outputs, feedback and average score per variant", Auth: true, Response: domain.PromptExperimentResults{}},
+		{Method: http.MethodPost, Path: "/admin/experiments/:id/stop", Tag: "admin", Summary: "Stop an experiment; users go back to the active prompt", Auth: true, Response: domain.PromptExperiment{}},
+		{Method: http.MethodGet, Path: "/admin/audit-logs", Tag: "admin", Summary: "List audit logs", Auth: true, Query: append([]openapi.Param{{Name: "action"}, {Name: "target_type"}, {Name: "actor_id"}, {Name: "target_id"}, {Name: "from"}, {Name: "to"}}, paging...), Response: domain.PaginatedAuditLogs{}},
+		{Method: http.MethodPost, Path: "/admin/users/:id/impersonate", Tag: "admin", Summary: "Issue a short-lived impersonation token", Auth: true, Response: domain.ImpersonationResponse{}},
+	}
+
+	for _, route := range api {
+		route.Path = apiPrefix + route.Path
+		routes = append(routes, route)
+	}
+
+	return routes
+}
@@ -0,0 +1,59 @@
+package handler
+
+import (
+	"log"
+	"net/url"
+
+	"github.com/raflytch/careerly-server/internal/domain"
+	"github.com/raflytch/careerly-server/pkg/response"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+type EmailHandler struct {
+	emailService domain.EmailService
+}
+
+func NewEmailHandler(emailService domain.EmailService) *EmailHandler {
+	return &EmailHandler{
+		emailService: emailService,
+	}
+}
+
+func (h *EmailHandler) ProviderEvents(c *fiber.Ctx) error {
+	provider := c.Params("provider")
+
+	if err := h.emailService.HandleProviderEvents(c.UserContext(), provider, c.Query("token"), c.Body()); err != nil {
+		if _, ok := lookupErrorCode(err); !ok {
+			log.Printf("Email callback from %s failed: %v", provider, err)
+		}
+		return respondError(c, err)
+	}
+
+	return response.Success(c, fiber.StatusOK, "events processed", nil)
+}
+
+func (h *EmailHandler) GetSuppressions(c *fiber.Ctx) error {
+	page := c.QueryInt("page", 1)
+	limit := c.QueryInt("limit", 10)
+
+	result, err := h.emailService.GetSuppressions(c.UserContext(), page, limit)
+	if err != nil {
+		return respondError(c, err)
+	}
+
+	return response.Success(c, fiber.StatusOK, "email suppressions retrieved", result)
+}
+
+func (h *EmailHandler) RemoveSuppression(c *fiber.Ctx) error {
+	email, err := url.PathUnescape(c.Params("email"))
+	if err != nil || email == "" {
+		return response.BadRequest(c, "invalid email")
+	}
+
+	if err := h.emailService.RemoveSuppression(c.UserContext(), email); err != nil {
+		return respondError(c, err)
+	}
+
+	return response.Success(c, fiber.StatusOK, "email suppression removed", nil)
+}
@@ -0,0 +1,228 @@
+package handler
+
+import (
+	"errors"
+
+	"github.com/raflytch/careerly-server/internal/domain"
+	"github.com/raflytch/careerly-server/internal/service"
+	"github.com/raflytch/careerly-server/pkg/genai"
+	"github.com/raflytch/careerly-server/pkg/response"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+type errorCode struct {
+	err    error
+	status int
+	code   string
+}
+
+// errorCodes gives every service and domain error a client sees its status
+// and code. Codes are part of the API contract, so existing ones must not
+// be renamed; their messages live in pkg/i18n.
+var errorCodes = []errorCode{
+	{domain.ErrUserNotFound, fiber.StatusNotFound, "USER_NOT_FOUND"},
+	{domain.ErrUserDeleted, fiber.StatusForbidden, "USER_DELETED"},
+	{domain.ErrUserAlreadyActive, fiber.StatusBadRequest, "USER_ALREADY_ACTIVE"},
+	{domain.ErrNoDeletedUserFound, fiber.StatusNotFound, "NO_DELETED_USER"},
+	{domain.ErrCannotDeleteAdmin, fiber.StatusForbidden, "CANNOT_DELETE_ADMIN"},
+	{domain.ErrInvalidOTP, fiber.StatusBadRequest, "INVALID_OTP"},
+	{domain.ErrOTPAlreadySent, fiber.StatusTooManyRequests, "OTP_ALREADY_SENT"},
+	{domain.ErrTwoFactorSession, fiber.StatusUnauthorized, "TWO_FACTOR_SESSION_INVALID"},
+	{service.ErrUserNotActive, fiber.StatusForbidden, "USER_NOT_ACTIVE"},
+	{service.ErrForbiddenAction, fiber.StatusForbidden, "ADMIN_ONLY"},
+	{service.ErrContactEmailUnchanged, fiber.StatusBadRequest, "CONTACT_EMAIL_UNCHANGED"},
+	{domain.ErrSessionNotFound, fiber.StatusNotFound, "SESSION_NOT_FOUND"},
+	{domain.ErrIdentityNotFound, fiber.StatusNotFound, "IDENTITY_NOT_FOUND"},
+	{domain.ErrIdentityInUse, fiber.StatusConflict, "IDENTITY_IN_USE"},
+	{domain.ErrProviderAlreadyLinked, fiber.StatusConflict, "PROVIDER_ALREADY_LINKED"},
+	{domain.ErrLastIdentity, fiber.StatusBadRequest, "LAST_IDENTITY"},
+	{domain.ErrUnsupportedProvider, fiber.StatusBadRequest, "UNSUPPORTED_PROVIDER"},
+	{domain.ErrEmailBelongsToAnother, fiber.StatusConflict, "EMAIL_BELONGS_TO_ANOTHER"},
+	{domain.ErrCannotImpersonateAdmin, fiber.StatusForbidden, "CANNOT_IMPERSONATE_ADMIN"},
+	{domain.ErrCannotImpersonateSelf, fiber.StatusForbidden, "CANNOT_IMPERSONATE_SELF"},
+	{domain.ErrImpersonationForbidden, fiber.StatusForbidden, "IMPERSONATION_FORBIDDEN"},
+
+	{service.ErrResumeNotFound, fiber.StatusNotFound, "RESUME_NOT_FOUND"},
+	{service.ErrUnauthorized, fiber.StatusForbidden, "RESUME_ACCESS_DENIED"},
+	{service.ErrInvalidSearch, fiber.StatusBadRequest, "INVALID_SEARCH"},
+	{service.ErrNoResumePhoto, fiber.StatusBadRequest, "RESUME_NO_PHOTO"},
+	{service.ErrDuplicateResume, fiber.StatusConflict, "DUPLICATE_RESUME"},
+	{service.ErrCustomBrandingNotAllowed, fiber.StatusForbidden, "CUSTOM_BRANDING_NOT_ALLOWED"},
+	{service.ErrOptimizationNotFound, fiber.StatusNotFound, "OPTIMIZATION_NOT_FOUND"},
+	{service.ErrBulletGenerationFailed, fiber.StatusUnprocessableEntity, "BULLET_GENERATION_FAILED"},
+	{service.ErrDuplicateSection, fiber.StatusBadRequest, "DUPLICATE_SECTION"},
+	{service.ErrUnknownSection, fiber.StatusBadRequest, "UNKNOWN_SECTION"},
+	{service.ErrDuplicateCustomSection, fiber.StatusBadRequest, "DUPLICATE_CUSTOM_SECTION"},
+	{service.ErrResumeDraftNotFound, fiber.StatusNotFound, "RESUME_DRAFT_NOT_FOUND"},
+	{service.ErrResumeDraftLimit, fiber.StatusConflict, "RESUME_DRAFT_LIMIT"},
+	{service.ErrResumeDraftIncomplete, fiber.StatusBadRequest, "RESUME_DRAFT_INCOMPLETE"},
+	{service.ErrResumeTagNotFound, fiber.StatusNotFound, "RESUME_TAG_NOT_FOUND"},
+	{service.ErrResumeTagExists, fiber.StatusConflict, "RESUME_TAG_EXISTS"},
+	{service.ErrResumeFolderNotFound, fiber.StatusNotFound, "RESUME_FOLDER_NOT_FOUND"},
+	{service.ErrResumeFolderExists, fiber.StatusConflict, "RESUME_FOLDER_EXISTS"},
+	{domain.ErrResumeShareNotFound, fiber.StatusNotFound, "SHARE_LINK_NOT_FOUND"},
+	{domain.ErrResumeShareExpired, fiber.StatusGone, "SHARE_LINK_EXPIRED"},
+	{domain.ErrResumeCommentNotFound, fiber.StatusNotFound, "COMMENT_NOT_FOUND"},
+
+	{service.ErrInterviewNotFound, fiber.StatusNotFound, "INTERVIEW_NOT_FOUND"},
+	{service.ErrInterviewUnauthorized, fiber.StatusForbidden, "INTERVIEW_ACCESS_DENIED"},
+	{service.ErrInterviewCompleted, fiber.StatusBadRequest, "INTERVIEW_COMPLETED"},
+	{service.ErrInterviewNotReady, fiber.StatusBadRequest, "INTERVIEW_NOT_READY"},
+	{service.ErrInterviewEvaluating, fiber.StatusConflict, "INTERVIEW_EVALUATING"},
+	{service.ErrInterviewAdaptive, fiber.StatusBadRequest, "INTERVIEW_ADAPTIVE"},
+	{service.ErrInterviewNotAdaptive, fiber.StatusBadRequest, "INTERVIEW_NOT_ADAPTIVE"},
+	{service.ErrIncompleteRound, fiber.StatusBadRequest, "INCOMPLETE_ROUND"},
+	{service.ErrInvalidQuestionID, fiber.StatusBadRequest, "INVALID_QUESTION_ID"},
+	{service.ErrInvalidScheduleTime, fiber.StatusBadRequest, "INVALID_SCHEDULE_TIME"},
+	{service.ErrEvaluationJobNotFound, fiber.StatusNotFound, "EVALUATION_NOT_FOUND"},
+	{service.ErrUnsupportedExportFormat, fiber.StatusBadRequest, "UNSUPPORTED_EXPORT_FORMAT"},
+	{service.ErrVideoAnswersDisabled, fiber.StatusServiceUnavailable, "VIDEO_ANSWERS_DISABLED"},
+	{service.ErrVideoAnswerNotAllowed, fiber.StatusBadRequest, "VIDEO_ANSWER_NOT_ALLOWED"},
+	{service.ErrVideoNoSpeech, fiber.StatusBadRequest, "VIDEO_NO_SPEECH"},
+	{service.ErrInterviewPackNotFound, fiber.StatusNotFound, "INTERVIEW_PACK_NOT_FOUND"},
+	{service.ErrInvalidPackQuestion, fiber.StatusBadRequest, "INVALID_PACK_QUESTION"},
+	{service.ErrInvalidRubric, fiber.StatusBadRequest, "INVALID_RUBRIC"},
+	{service.ErrBankQuestionNotFound, fiber.StatusNotFound, "BANK_QUESTION_NOT_FOUND"},
+	{service.ErrQuestionImportHeader, fiber.StatusBadRequest, "QUESTION_IMPORT_HEADER"},
+	{service.ErrQuestionImportEmpty, fiber.StatusBadRequest, "QUESTION_IMPORT_EMPTY"},
+	{service.ErrQuestionImportTooLarge, fiber.StatusBadRequest, "QUESTION_IMPORT_TOO_LARGE"},
+	{service.ErrQuestionImportMalformed, fiber.StatusBadRequest, "QUESTION_IMPORT_MALFORMED"},
+	{domain.ErrInterviewShareNotFound, fiber.StatusNotFound, "SHARE_LINK_NOT_FOUND"},
+	{domain.ErrInterviewShareExpired, fiber.StatusGone, "SHARE_LINK_EXPIRED"},
+	{domain.ErrInterviewNotShareable, fiber.StatusBadRequest, "INTERVIEW_NOT_SHAREABLE"},
+	{domain.ErrShareCommentsDisabled, fiber.StatusForbidden, "SHARE_COMMENTS_DISABLED"},
+	{domain.ErrShareCommentLimit, fiber.StatusTooManyRequests, "SHARE_COMMENT_LIMIT"},
+	{domain.ErrStudyPlanNotFound, fiber.StatusNotFound, "STUDY_PLAN_NOT_FOUND"},
+	{domain.ErrStudyPlanNotAvailable, fiber.StatusBadRequest, "STUDY_PLAN_NOT_AVAILABLE"},
+	{domain.ErrStudyPlanTaskNotFound, fiber.StatusNotFound, "STUDY_PLAN_TASK_NOT_FOUND"},
+	{domain.ErrStudyPlanGenerateFailed, fiber.StatusUnprocessableEntity, "STUDY_PLAN_FAILED"},
+
+	{service.ErrATSCheckNotFound, fiber.StatusNotFound, "ATS_CHECK_NOT_FOUND"},
+	{service.ErrATSCheckUnauthorized, fiber.StatusForbidden, "ATS_CHECK_ACCESS_DENIED"},
+	{service.ErrTooManyJobs, fiber.StatusBadRequest, "TOO_MANY_JOBS"},
+	{service.ErrATSCheckNoSource, fiber.StatusNotFound, "ATS_CHECK_NO_SOURCE"},
+	{service.ErrATSAnalysisNotFound, fiber.StatusNotFound, "ATS_ANALYSIS_NOT_FOUND"},
+	{service.ErrPDFNotAnnotatable, fiber.StatusUnprocessableEntity, "PDF_NOT_ANNOTATABLE"},
+	{service.ErrPDFNoText, fiber.StatusUnprocessableEntity, "PDF_NO_TEXT"},
+
+	{service.ErrAIClientUnavailable, fiber.StatusInternalServerError, "AI_CLIENT_UNAVAILABLE"},
+	{service.ErrAIServiceUnavailable, fiber.StatusServiceUnavailable, "AI_SERVICE_UNAVAILABLE"},
+	{genai.ErrUnavailable, fiber.StatusServiceUnavailable, "AI_SERVICE_UNAVAILABLE"},
+	{genai.ErrBudgetExceeded, fiber.StatusTooManyRequests, "AI_BUDGET_EXCEEDED"},
+	{service.ErrAIConcurrencyLimit, fiber.StatusTooManyRequests, "AI_CONCURRENCY_LIMIT"},
+	{genai.ErrTimeout, fiber.StatusGatewayTimeout, "AI_TIMEOUT"},
+	{service.ErrInsufficientInsightData, fiber.StatusBadRequest, "INSUFFICIENT_INSIGHT_DATA"},
+	{service.ErrAIFeedbackNotReady, fiber.StatusConflict, "AI_FEEDBACK_NOT_READY"},
+
+	{service.ErrNoActiveSubscription, fiber.StatusForbidden, "SUBSCRIPTION_REQUIRED"},
+	{service.ErrQuotaExceeded, fiber.StatusForbidden, "QUOTA_EXCEEDED"},
+	{service.ErrPlanNotFound, fiber.StatusNotFound, "PLAN_NOT_FOUND"},
+	{service.ErrPlanNameExists, fiber.StatusBadRequest, "PLAN_NAME_EXISTS"},
+	{service.ErrInvalidPlanData, fiber.StatusBadRequest, "INVALID_PLAN_DATA"},
+	{service.ErrPlanInUse, fiber.StatusConflict, "PLAN_IN_USE"},
+	{service.ErrPlanNotAvailable, fiber.StatusBadRequest, "PLAN_NOT_AVAILABLE"},
+	{service.ErrPlanChangeNotDowngrade, fiber.StatusBadRequest, "PLAN_CHANGE_NOT_DOWNGRADE"},
+	{service.ErrPlanChangeSamePlan, fiber.StatusBadRequest, "PLAN_CHANGE_SAME_PLAN"},
+	{service.ErrNoScheduledPlanChange, fiber.StatusNotFound, "NO_SCHEDULED_PLAN_CHANGE"},
+	{service.ErrGiftCodeInvalid, fiber.StatusNotFound, "GIFT_CODE_INVALID"},
+	{service.ErrGiftAlreadyRedeemed, fiber.StatusConflict, "GIFT_ALREADY_REDEEMED"},
+	{service.ErrGiftPaidSubscriptionActive, fiber.StatusConflict, "GIFT_PAID_SUBSCRIPTION_ACTIVE"},
+	{service.ErrAddonNotFound, fiber.StatusNotFound, "ADDON_NOT_FOUND"},
+	{service.ErrAddonNameExists, fiber.StatusBadRequest, "ADDON_NAME_EXISTS"},
+	{service.ErrAddonNotAvailable, fiber.StatusBadRequest, "ADDON_NOT_AVAILABLE"},
+	{service.ErrAddonNotNeeded, fiber.StatusBadRequest, "ADDON_NOT_NEEDED"},
+	{service.ErrTransactionNotFound, fiber.StatusNotFound, "TRANSACTION_NOT_FOUND"},
+	{service.ErrTransactionAlreadyPaid, fiber.StatusConflict, "TRANSACTION_ALREADY_PAID"},
+	{service.ErrActiveSubscriptionExists, fiber.StatusBadRequest, "ACTIVE_SUBSCRIPTION_EXISTS"},
+	{service.ErrInvalidSignature, fiber.StatusUnauthorized, "INVALID_SIGNATURE"},
+	{service.ErrMissingSignature, fiber.StatusUnauthorized, "MISSING_SIGNATURE"},
+	{service.ErrStaleNotification, fiber.StatusBadRequest, "STALE_NOTIFICATION"},
+	{service.ErrPaymentGatewayDown, fiber.StatusServiceUnavailable, "PAYMENT_GATEWAY_DOWN"},
+	{service.ErrPaymentGatewayNotConfigured, fiber.StatusServiceUnavailable, "PAYMENT_GATEWAY_NOT_CONFIGURED"},
+	{service.ErrNotificationNotQueued, fiber.StatusServiceUnavailable, "NOTIFICATION_NOT_QUEUED"},
+	{service.ErrPaymentMethodNotFound, fiber.StatusNotFound, "PAYMENT_METHOD_NOT_FOUND"},
+	{service.ErrPaymentMethodExpired, fiber.StatusBadRequest, "PAYMENT_METHOD_EXPIRED"},
+	{service.ErrSavedCardDeclined, fiber.StatusPaymentRequired, "SAVED_CARD_DECLINED"},
+	{service.ErrOrganizationNotFound, fiber.StatusNotFound, "ORGANIZATION_NOT_FOUND"},
+	{service.ErrAlreadyInOrganization, fiber.StatusConflict, "ALREADY_IN_ORGANIZATION"},
+	{service.ErrOrganizationOwnerOnly, fiber.StatusForbidden, "ORGANIZATION_OWNER_ONLY"},
+	{service.ErrOrganizationNoSeats, fiber.StatusConflict, "ORGANIZATION_NO_SEATS"},
+	{service.ErrOrganizationSeatsTooFew, fiber.StatusBadRequest, "ORGANIZATION_SEATS_TOO_FEW"},
+	{service.ErrOrganizationMemberMissing, fiber.StatusNotFound, "ORGANIZATION_MEMBER_NOT_FOUND"},
+	{service.ErrOrganizationOwnerLeave, fiber.StatusBadRequest, "ORGANIZATION_OWNER_LEAVE"},
+	{service.ErrInvitationInvalid, fiber.StatusNotFound, "INVITATION_INVALID"},
+	{service.ErrInvitationEmailMismatch, fiber.StatusForbidden, "INVITATION_EMAIL_MISMATCH"},
+	{service.ErrQuotaOverrideNotFound, fiber.StatusNotFound, "QUOTA_OVERRIDE_NOT_FOUND"},
+	{service.ErrQuotaOverrideAmount, fiber.StatusBadRequest, "QUOTA_OVERRIDE_AMOUNT"},
+	{service.ErrQuotaOverrideExpiry, fiber.StatusBadRequest, "QUOTA_OVERRIDE_EXPIRY"},
+	{service.ErrUnknownDataRegion, fiber.StatusBadRequest, "UNKNOWN_DATA_REGION"},
+	{service.ErrDataRegionLocked, fiber.StatusConflict, "DATA_REGION_LOCKED"},
+	{service.ErrProvisioningJobNotFound, fiber.StatusNotFound, "PROVISIONING_JOB_NOT_FOUND"},
+	{service.ErrProvisioningJobSucceeded, fiber.StatusBadRequest, "PROVISIONING_JOB_SUCCEEDED"},
+
+	{service.ErrJobNotFound, fiber.StatusNotFound, "JOB_NOT_FOUND"},
+	{service.ErrWebhookEndpointNotFound, fiber.StatusNotFound, "WEBHOOK_ENDPOINT_NOT_FOUND"},
+	{service.ErrWebhookDeliveryNotFound, fiber.StatusNotFound, "WEBHOOK_DELIVERY_NOT_FOUND"},
+	{service.ErrUnknownPrompt, fiber.StatusNotFound, "UNKNOWN_PROMPT"},
+	{service.ErrPromptVersionNotFound, fiber.StatusNotFound, "PROMPT_VERSION_NOT_FOUND"},
+	{service.ErrPromptPlaceholders, fiber.StatusBadRequest, "PROMPT_PLACEHOLDERS"},
+	{service.ErrPromptVersionActive, fiber.StatusConflict, "PROMPT_VERSION_ACTIVE"},
+	{service.ErrPromptVersionInExperiment, fiber.StatusConflict, "PROMPT_VERSION_IN_EXPERIMENT"},
+	{service.ErrExperimentNotFound, fiber.StatusNotFound, "EXPERIMENT_NOT_FOUND"},
+	{service.ErrExperimentRunning, fiber.StatusConflict, "EXPERIMENT_RUNNING"},
+	{service.ErrExperimentStopped, fiber.StatusConflict, "EXPERIMENT_STOPPED"},
+	{service.ErrExperimentVariants, fiber.StatusBadRequest, "EXPERIMENT_VARIANTS"},
+	{service.ErrEmailSuppressed, fiber.StatusUnprocessableEntity, "EMAIL_SUPPRESSED"},
+	{service.ErrEmailSuppressionNotFound, fiber.StatusNotFound, "EMAIL_SUPPRESSION_NOT_FOUND"},
+	{service.ErrInvalidEmailCallbackToken, fiber.StatusUnauthorized, "INVALID_EMAIL_CALLBACK_TOKEN"},
+	{service.ErrUnsupportedEmailProvider, fiber.StatusNotFound, "UNSUPPORTED_EMAIL_PROVIDER"},
+	{service.ErrInvalidEmailCallbackFormat, fiber.StatusBadRequest, "INVALID_EMAIL_CALLBACK_PAYLOAD"},
+	{service.ErrArtifactStorageDisabled, fiber.StatusServiceUnavailable, "ARTIFACT_STORAGE_DISABLED"},
+	{domain.ErrUnsupportedBundleVersion, fiber.StatusBadRequest, "UNSUPPORTED_BUNDLE_VERSION"},
+	{domain.ErrEmptyDataBundle, fiber.StatusBadRequest, "EMPTY_DATA_BUNDLE"},
+}
+
+// detailedError is implemented by errors that carry data for the client
+// beyond their code, sent as the response's data.
+type detailedError interface {
+	Details() interface{}
+}
+
+func lookupErrorCode(err error) (errorCode, bool) {
+	for _, entry := range errorCodes {
+		if errors.Is(err, entry.err) {
+			return entry, true
+		}
+	}
+	return errorCode{}, false
+}
+
+// respondError replies with the status and code registered for err. The
+// catalog message is translated to the request locale unless err wraps the
+// registered error with more detail, in which case its own text is kept.
+// Unregistered errors are internal errors.
+func respondError(c *fiber.Ctx, err error) error {
+	entry, ok := lookupErrorCode(err)
+	if !ok {
+		return response.InternalError(c, err.Error())
+	}
+	return respondErrorStatus(c, entry.status, err)
+}
+
+// respondErrorStatus is respondError for the few endpoints where an error
+// means something else, such as a missing subscription on a lookup.
+func respondErrorStatus(c *fiber.Ctx, status int, err error) error {
+	entry, ok := lookupErrorCode(err)
+	if !ok {
+		return response.Error(c, status, err.Error())
+	}
+	var detailed detailedError
+	if errors.As(err, &detailed) {
+		return response.ErrorCodeWithData(c, status, entry.code, detailed.Details())
+	}
+	if err != entry.err {
+		return response.ErrorWithCode(c, status, entry.code, err.Error())
+	}
+	return response.ErrorCode(c, status, entry.code)
+}
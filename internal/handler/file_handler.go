@@ -0,0 +1,50 @@
+package handler
+
+import (
+	"errors"
+	"mime"
+	"os"
+	"path"
+
+	"github.com/raflytch/careerly-server/pkg/response"
+	"github.com/raflytch/careerly-server/pkg/storage"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+// FileHandler serves files kept on local disk through the signed links the
+// local storage driver issues.
+type FileHandler struct {
+	local *storage.LocalStorage
+}
+
+func NewFileHandler(local *storage.LocalStorage) *FileHandler {
+	return &FileHandler{
+		local: local,
+	}
+}
+
+func (h *FileHandler) Get(c *fiber.Ctx) error {
+	if h.local == nil {
+		return response.NotFound(c, "file not found")
+	}
+
+	key, filePath, err := h.local.Open(c.Query("token"))
+	if err != nil {
+		return response.Forbidden(c, "invalid or expired link")
+	}
+
+	data, err := os.ReadFile(filePath)
+	if err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return response.NotFound(c, "file not found")
+		}
+		return respondError(c, err)
+	}
+
+	if contentType := mime.TypeByExtension(path.Ext(key)); contentType != "" {
+		c.Set("Content-Type", contentType)
+	}
+	c.Set("Cache-Control", "private, no-store")
+	return c.Send(data)
+}
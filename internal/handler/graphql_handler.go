@@ -0,0 +1,34 @@
+package handler
+
+import (
+	"net/http"
+
+	"github.com/raflytch/careerly-server/internal/graph"
+	"github.com/raflytch/careerly-server/internal/middleware"
+	"github.com/raflytch/careerly-server/pkg/response"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/valyala/fasthttp/fasthttpadaptor"
+)
+
+type GraphQLHandler struct {
+	server http.Handler
+}
+
+func NewGraphQLHandler(server http.Handler) *GraphQLHandler {
+	return &GraphQLHandler{server: server}
+}
+
+func (h *GraphQLHandler) Serve(c *fiber.Ctx) error {
+	user := middleware.GetUserFromContext(c)
+	if user == nil {
+		return response.Unauthorized(c, "user not authenticated")
+	}
+
+	ctx := graph.WithUser(c.UserContext(), user)
+	serve := fasthttpadaptor.NewFastHTTPHandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		h.server.ServeHTTP(w, r.WithContext(ctx))
+	})
+	serve(c.Context())
+	return nil
+}
@@ -2,13 +2,15 @@ package handler
 
 import (
 	"errors"
+	"fmt"
+	"strings"
 
 	"github.com/raflytch/careerly-server/internal/domain"
 	"github.com/raflytch/careerly-server/internal/middleware"
 	"github.com/raflytch/careerly-server/internal/service"
 	"github.com/raflytch/careerly-server/pkg/response"
+	"github.com/raflytch/careerly-server/pkg/validator"
 
-	"github.com/go-playground/validator/v10"
 	"github.com/gofiber/fiber/v2"
 	"github.com/google/uuid"
 )
@@ -16,12 +18,19 @@ import (
 type InterviewHandler struct {
 	interviewService domain.InterviewService
 	quotaService     domain.QuotaService
+	progressBroker   domain.InterviewProgressBroker
+	videoValidator   *validator.FileValidator
 }
 
-func NewInterviewHandler(interviewService domain.InterviewService, quotaService domain.QuotaService) *InterviewHandler {
+func NewInterviewHandler(interviewService domain.InterviewService, quotaService domain.QuotaService, progressBroker domain.InterviewProgressBroker, videoMaxSize int64) *InterviewHandler {
 	return &InterviewHandler{
 		interviewService: interviewService,
 		quotaService:     quotaService,
+		progressBroker:   progressBroker,
+		videoValidator: validator.NewFileValidator(
+			validator.WithMaxSize(videoMaxSize),
+			validator.WithAllowedTypes([]string{".mp4", ".webm", ".mov"}),
+		),
 	}
 }
 
@@ -32,23 +41,52 @@ func (h *InterviewHandler) Create(c *fiber.Ctx) error {
 	}
 
 	var req domain.CreateInterviewRequest
-	if err := c.BodyParser(&req); err != nil {
-		return response.BadRequest(c, "invalid request body")
+	if err := bindAndValidate(c, &req); err != nil {
+		return validationFailed(c, err)
 	}
 
-	if err := validateInterviewRequest(&req); err != nil {
-		return response.BadRequest(c, err.Error())
+	result, err := h.interviewService.Create(c.UserContext(), user.ID, &req)
+	if err != nil {
+		return respondError(c, err)
 	}
 
-	result, err := h.interviewService.Create(c.UserContext(), user.ID, &req)
+	return response.Success(c, fiber.StatusCreated, "interview created", result)
+}
+
+func (h *InterviewHandler) Schedule(c *fiber.Ctx) error {
+	user := middleware.GetUserFromContext(c)
+	if user == nil {
+		return response.Unauthorized(c, "user not authenticated")
+	}
+
+	var req domain.ScheduleInterviewRequest
+	if err := bindAndValidate(c, &req); err != nil {
+		return validationFailed(c, err)
+	}
+
+	result, err := h.interviewService.Schedule(c.UserContext(), user.ID, &req)
 	if err != nil {
-		if errors.Is(err, service.ErrNoActiveSubscription) {
-			return response.Forbidden(c, "no active subscription found")
-		}
-		if errors.Is(err, service.ErrQuotaExceeded) {
-			return response.Forbidden(c, "interview quota exceeded for this month")
-		}
-		return response.InternalError(c, err.Error())
+		return respondError(c, err)
+	}
+
+	return response.Success(c, fiber.StatusCreated, "interview scheduled", result)
+}
+
+func (h *InterviewHandler) StartFromPack(c *fiber.Ctx) error {
+	user := middleware.GetUserFromContext(c)
+	if user == nil {
+		return response.Unauthorized(c, "user not authenticated")
+	}
+
+	idParam := c.Params("id")
+	packID, err := uuid.Parse(idParam)
+	if err != nil {
+		return response.BadRequest(c, "invalid interview pack id")
+	}
+
+	result, err := h.interviewService.StartFromPack(c.UserContext(), user.ID, packID)
+	if err != nil {
+		return respondError(c, err)
 	}
 
 	return response.Success(c, fiber.StatusCreated, "interview created", result)
@@ -68,13 +106,7 @@ func (h *InterviewHandler) GetByID(c *fiber.Ctx) error {
 
 	interview, err := h.interviewService.GetByID(c.UserContext(), user.ID, id)
 	if err != nil {
-		if errors.Is(err, service.ErrInterviewNotFound) {
-			return response.NotFound(c, "interview not found")
-		}
-		if errors.Is(err, service.ErrInterviewUnauthorized) {
-			return response.Forbidden(c, "unauthorized access to interview")
-		}
-		return response.InternalError(c, err.Error())
+		return respondError(c, err)
 	}
 
 	return response.Success(c, fiber.StatusOK, "interview retrieved", interview)
@@ -91,12 +123,31 @@ func (h *InterviewHandler) GetMyInterviews(c *fiber.Ctx) error {
 
 	result, err := h.interviewService.GetByUserID(c.UserContext(), user.ID, page, limit)
 	if err != nil {
-		return response.InternalError(c, err.Error())
+		return respondError(c, err)
 	}
 
 	return response.Success(c, fiber.StatusOK, "interviews retrieved", result)
 }
 
+func (h *InterviewHandler) GetPositionProgress(c *fiber.Ctx) error {
+	user := middleware.GetUserFromContext(c)
+	if user == nil {
+		return response.Unauthorized(c, "user not authenticated")
+	}
+
+	position := strings.TrimSpace(c.Query("position"))
+	if position == "" {
+		return response.BadRequest(c, "position is required")
+	}
+
+	progress, err := h.interviewService.GetPositionProgress(c.UserContext(), user.ID, position)
+	if err != nil {
+		return respondError(c, err)
+	}
+
+	return response.Success(c, fiber.StatusOK, "interview progress retrieved", progress)
+}
+
 func (h *InterviewHandler) SubmitAnswers(c *fiber.Ctx) error {
 	user := middleware.GetUserFromContext(c)
 	if user == nil {
@@ -110,29 +161,86 @@ func (h *InterviewHandler) SubmitAnswers(c *fiber.Ctx) error {
 	}
 
 	var req domain.SubmitAnswerRequest
-	if err := c.BodyParser(&req); err != nil {
-		return response.BadRequest(c, "invalid request body")
+	if err := bindAndValidate(c, &req); err != nil {
+		return validationFailed(c, err)
 	}
 
-	if err := validateSubmitAnswerRequest(&req); err != nil {
-		return response.BadRequest(c, err.Error())
+	result, err := h.interviewService.SubmitAnswers(c.UserContext(), user.ID, id, &req)
+	if err != nil {
+		return respondError(c, err)
 	}
 
-	result, err := h.interviewService.SubmitAnswers(c.UserContext(), user.ID, id, &req)
+	return response.Success(c, fiber.StatusAccepted, "answers submitted, evaluation in progress", result)
+}
+
+func (h *InterviewHandler) SubmitRound(c *fiber.Ctx) error {
+	user := middleware.GetUserFromContext(c)
+	if user == nil {
+		return response.Unauthorized(c, "user not authenticated")
+	}
+
+	idParam := c.Params("id")
+	id, err := uuid.Parse(idParam)
 	if err != nil {
-		if errors.Is(err, service.ErrInterviewNotFound) {
-			return response.NotFound(c, "interview not found")
-		}
-		if errors.Is(err, service.ErrInterviewUnauthorized) {
-			return response.Forbidden(c, "unauthorized access to interview")
-		}
-		if errors.Is(err, service.ErrInterviewCompleted) {
-			return response.BadRequest(c, "interview already completed")
-		}
-		return response.InternalError(c, err.Error())
+		return response.BadRequest(c, "invalid interview id")
+	}
+
+	var req domain.SubmitAnswerRequest
+	if err := bindAndValidate(c, &req); err != nil {
+		return validationFailed(c, err)
+	}
+
+	result, err := h.interviewService.SubmitRound(c.UserContext(), user.ID, id, &req)
+	if err != nil {
+		return respondError(c, err)
 	}
 
-	return response.Success(c, fiber.StatusOK, "answers submitted and evaluated", result)
+	if result.Interview.Status == domain.InterviewStatusCompleted {
+		return response.Success(c, fiber.StatusOK, "final round evaluated, interview completed", result)
+	}
+	return response.Success(c, fiber.StatusOK, "round evaluated, next round generated", result)
+}
+
+func (h *InterviewHandler) GetEvaluation(c *fiber.Ctx) error {
+	user := middleware.GetUserFromContext(c)
+	if user == nil {
+		return response.Unauthorized(c, "user not authenticated")
+	}
+
+	id, err := uuid.Parse(c.Params("id"))
+	if err != nil {
+		return response.BadRequest(c, "invalid interview id")
+	}
+
+	job, err := h.interviewService.GetEvaluationJob(c.UserContext(), user.ID, id)
+	if err != nil {
+		return respondError(c, err)
+	}
+
+	return response.Success(c, fiber.StatusOK, "evaluation status retrieved", job)
+}
+
+func (h *InterviewHandler) Export(c *fiber.Ctx) error {
+	user := middleware.GetUserFromContext(c)
+	if user == nil {
+		return response.Unauthorized(c, "user not authenticated")
+	}
+
+	id, err := uuid.Parse(c.Params("id"))
+	if err != nil {
+		return response.BadRequest(c, "invalid interview id")
+	}
+
+	format := domain.InterviewExportFormat(c.Query("format", string(domain.InterviewExportJSON)))
+
+	export, err := h.interviewService.Export(c.UserContext(), user.ID, id, format)
+	if err != nil {
+		return respondError(c, err)
+	}
+
+	c.Set("Content-Type", export.ContentType)
+	c.Set("Content-Disposition", fmt.Sprintf("attachment; filename=%s", export.Filename))
+	return c.Send(export.Data)
 }
 
 func (h *InterviewHandler) Delete(c *fiber.Ctx) error {
@@ -148,24 +256,81 @@ func (h *InterviewHandler) Delete(c *fiber.Ctx) error {
 	}
 
 	if err := h.interviewService.Delete(c.UserContext(), user.ID, id); err != nil {
-		if errors.Is(err, service.ErrInterviewNotFound) {
-			return response.NotFound(c, "interview not found")
-		}
-		if errors.Is(err, service.ErrInterviewUnauthorized) {
-			return response.Forbidden(c, "unauthorized access to interview")
-		}
-		return response.InternalError(c, err.Error())
+		return respondError(c, err)
 	}
 
 	return response.Success(c, fiber.StatusOK, "interview deleted", nil)
 }
 
-func validateInterviewRequest(req *domain.CreateInterviewRequest) error {
-	validate := validator.New()
-	return validate.Struct(req)
+func (h *InterviewHandler) GetTrash(c *fiber.Ctx) error {
+	user := middleware.GetUserFromContext(c)
+	if user == nil {
+		return response.Unauthorized(c, "user not authenticated")
+	}
+
+	page := c.QueryInt("page", 1)
+	limit := c.QueryInt("limit", 10)
+
+	result, err := h.interviewService.GetTrash(c.UserContext(), user.ID, page, limit)
+	if err != nil {
+		return respondError(c, err)
+	}
+
+	return response.Success(c, fiber.StatusOK, "deleted interviews retrieved", result)
 }
 
-func validateSubmitAnswerRequest(req *domain.SubmitAnswerRequest) error {
-	validate := validator.New()
-	return validate.Struct(req)
+func (h *InterviewHandler) Restore(c *fiber.Ctx) error {
+	user := middleware.GetUserFromContext(c)
+	if user == nil {
+		return response.Unauthorized(c, "user not authenticated")
+	}
+
+	id, err := uuid.Parse(c.Params("id"))
+	if err != nil {
+		return response.BadRequest(c, "invalid interview id")
+	}
+
+	interview, err := h.interviewService.Restore(c.UserContext(), user.ID, id)
+	if err != nil {
+		return respondError(c, err)
+	}
+
+	return response.Success(c, fiber.StatusOK, "interview restored", interview)
+}
+
+func (h *InterviewHandler) UploadVideoAnswer(c *fiber.Ctx) error {
+	user := middleware.GetUserFromContext(c)
+	if user == nil {
+		return response.Unauthorized(c, "user not authenticated")
+	}
+
+	idParam := c.Params("id")
+	id, err := uuid.Parse(idParam)
+	if err != nil {
+		return response.BadRequest(c, "invalid interview id")
+	}
+
+	questionID, err := c.ParamsInt("questionId")
+	if err != nil || questionID < 1 {
+		return response.BadRequest(c, "invalid question id")
+	}
+
+	file, err := c.FormFile("video")
+	if err != nil {
+		return response.BadRequest(c, "video file is required, use form field 'video'")
+	}
+
+	if err := h.videoValidator.Validate(file); err != nil {
+		return response.BadRequest(c, err.Error())
+	}
+
+	result, err := h.interviewService.UploadVideoAnswer(c.UserContext(), user.ID, id, questionID, file)
+	if err != nil {
+		if errors.Is(err, service.ErrInvalidQuestionID) {
+			return respondErrorStatus(c, fiber.StatusNotFound, err)
+		}
+		return respondError(c, err)
+	}
+
+	return response.Success(c, fiber.StatusCreated, "video answer uploaded", result)
 }
@@ -0,0 +1,107 @@
+package handler
+
+import (
+	"github.com/raflytch/careerly-server/internal/domain"
+	"github.com/raflytch/careerly-server/pkg/response"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/google/uuid"
+)
+
+type InterviewPackHandler struct {
+	packService domain.InterviewPackService
+}
+
+func NewInterviewPackHandler(packService domain.InterviewPackService) *InterviewPackHandler {
+	return &InterviewPackHandler{
+		packService: packService,
+	}
+}
+
+func (h *InterviewPackHandler) ListAvailable(c *fiber.Ctx) error {
+	page := c.QueryInt("page", 1)
+	limit := c.QueryInt("limit", 10)
+
+	result, err := h.packService.ListAvailable(c.UserContext(), page, limit)
+	if err != nil {
+		return respondError(c, err)
+	}
+
+	return response.Success(c, fiber.StatusOK, "interview packs retrieved", result)
+}
+
+func (h *InterviewPackHandler) Create(c *fiber.Ctx) error {
+	var req domain.CreateInterviewPackRequest
+	if err := bindAndValidate(c, &req); err != nil {
+		return validationFailed(c, err)
+	}
+
+	pack, err := h.packService.Create(c.UserContext(), &req)
+	if err != nil {
+		return respondError(c, err)
+	}
+
+	return response.Success(c, fiber.StatusCreated, "interview pack created", pack)
+}
+
+func (h *InterviewPackHandler) GetAll(c *fiber.Ctx) error {
+	page := c.QueryInt("page", 1)
+	limit := c.QueryInt("limit", 10)
+	includeInactive := c.QueryBool("include_inactive", true)
+
+	result, err := h.packService.GetAll(c.UserContext(), page, limit, includeInactive)
+	if err != nil {
+		return respondError(c, err)
+	}
+
+	return response.Success(c, fiber.StatusOK, "interview packs retrieved", result)
+}
+
+func (h *InterviewPackHandler) GetByID(c *fiber.Ctx) error {
+	idParam := c.Params("id")
+	id, err := uuid.Parse(idParam)
+	if err != nil {
+		return response.BadRequest(c, "invalid interview pack id")
+	}
+
+	pack, err := h.packService.GetByID(c.UserContext(), id)
+	if err != nil {
+		return respondError(c, err)
+	}
+
+	return response.Success(c, fiber.StatusOK, "interview pack retrieved", pack)
+}
+
+func (h *InterviewPackHandler) Update(c *fiber.Ctx) error {
+	idParam := c.Params("id")
+	id, err := uuid.Parse(idParam)
+	if err != nil {
+		return response.BadRequest(c, "invalid interview pack id")
+	}
+
+	var req domain.UpdateInterviewPackRequest
+	if err := bindAndValidate(c, &req); err != nil {
+		return validationFailed(c, err)
+	}
+
+	pack, err := h.packService.Update(c.UserContext(), id, &req)
+	if err != nil {
+		return respondError(c, err)
+	}
+
+	return response.Success(c, fiber.StatusOK, "interview pack updated", pack)
+}
+
+func (h *InterviewPackHandler) Delete(c *fiber.Ctx) error {
+	idParam := c.Params("id")
+	id, err := uuid.Parse(idParam)
+	if err != nil {
+		return response.BadRequest(c, "invalid interview pack id")
+	}
+
+	if err := h.packService.Delete(c.UserContext(), id); err != nil {
+		return respondError(c, err)
+	}
+
+	return response.Success(c, fiber.StatusOK, "interview pack deleted", nil)
+}
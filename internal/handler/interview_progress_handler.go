@@ -0,0 +1,98 @@
+package handler
+
+import (
+	"context"
+
+	"github.com/raflytch/careerly-server/internal/domain"
+	"github.com/raflytch/careerly-server/internal/middleware"
+	"github.com/raflytch/careerly-server/pkg/response"
+
+	"github.com/gofiber/contrib/websocket"
+	"github.com/gofiber/fiber/v2"
+	"github.com/google/uuid"
+)
+
+const progressInterviewIDKey = "progress_interview_id"
+
+// UpgradeProgress authorizes the interview before the WebSocket handshake so
+// ownership errors are returned as regular JSON responses.
+func (h *InterviewHandler) UpgradeProgress(c *fiber.Ctx) error {
+	if !websocket.IsWebSocketUpgrade(c) {
+		return response.Error(c, fiber.StatusUpgradeRequired, "websocket upgrade required")
+	}
+
+	user := middleware.GetUserFromContext(c)
+	if user == nil {
+		return response.Unauthorized(c, "user not authenticated")
+	}
+
+	id, err := uuid.Parse(c.Params("id"))
+	if err != nil {
+		return response.BadRequest(c, "invalid interview id")
+	}
+
+	if _, err := h.interviewService.GetByID(c.UserContext(), user.ID, id); err != nil {
+		return respondError(c, err)
+	}
+
+	c.Locals(progressInterviewIDKey, id)
+	return c.Next()
+}
+
+func (h *InterviewHandler) StreamProgress() fiber.Handler {
+	return websocket.New(func(conn *websocket.Conn) {
+		id, ok := conn.Locals(progressInterviewIDKey).(uuid.UUID)
+		user, userOK := conn.Locals(middleware.UserContextKey).(*domain.User)
+		if !ok || !userOK {
+			return
+		}
+
+		events, unsubscribe := h.progressBroker.Subscribe(id)
+		defer unsubscribe()
+
+		job, err := h.interviewService.GetEvaluationJob(context.Background(), user.ID, id)
+		if err == nil {
+			snapshot := domain.InterviewProgressEvent{
+				Type:        domain.InterviewProgressSnapshot,
+				InterviewID: id,
+				Evaluated:   job.Evaluated,
+				Total:       job.Total,
+				Job:         job,
+				Timestamp:   job.UpdatedAt,
+			}
+			if err := conn.WriteJSON(snapshot); err != nil {
+				return
+			}
+			if job.Status == domain.EvaluationJobStatusCompleted || job.Status == domain.EvaluationJobStatusFailed {
+				return
+			}
+		}
+
+		closed := make(chan struct{})
+		go func() {
+			defer close(closed)
+			for {
+				if _, _, err := conn.ReadMessage(); err != nil {
+					return
+				}
+			}
+		}()
+
+		for {
+			select {
+			case <-closed:
+				return
+			case event, ok := <-events:
+				if !ok {
+					return
+				}
+				if err := conn.WriteJSON(event); err != nil {
+					return
+				}
+				if event.Type == domain.InterviewProgressCompleted || event.Type == domain.InterviewProgressFailed {
+					return
+				}
+			}
+		}
+	})
+}
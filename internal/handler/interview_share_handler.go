@@ -0,0 +1,111 @@
+package handler
+
+import (
+	"github.com/raflytch/careerly-server/internal/domain"
+	"github.com/raflytch/careerly-server/internal/middleware"
+	"github.com/raflytch/careerly-server/pkg/response"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/google/uuid"
+)
+
+type InterviewShareHandler struct {
+	shareService domain.InterviewShareService
+}
+
+func NewInterviewShareHandler(shareService domain.InterviewShareService) *InterviewShareHandler {
+	return &InterviewShareHandler{
+		shareService: shareService,
+	}
+}
+
+func (h *InterviewShareHandler) Create(c *fiber.Ctx) error {
+	user := middleware.GetUserFromContext(c)
+	if user == nil {
+		return response.Unauthorized(c, "user not authenticated")
+	}
+
+	id, err := uuid.Parse(c.Params("id"))
+	if err != nil {
+		return response.BadRequest(c, "invalid interview id")
+	}
+
+	var req domain.CreateInterviewShareRequest
+	if len(c.Body()) > 0 {
+		if err := bindAndValidate(c, &req); err != nil {
+			return validationFailed(c, err)
+		}
+	}
+
+	result, err := h.shareService.Create(c.UserContext(), user.ID, id, &req)
+	if err != nil {
+		return respondError(c, err)
+	}
+
+	return response.Success(c, fiber.StatusCreated, "share link created", result)
+}
+
+func (h *InterviewShareHandler) Revoke(c *fiber.Ctx) error {
+	user := middleware.GetUserFromContext(c)
+	if user == nil {
+		return response.Unauthorized(c, "user not authenticated")
+	}
+
+	id, err := uuid.Parse(c.Params("id"))
+	if err != nil {
+		return response.BadRequest(c, "invalid interview id")
+	}
+
+	shareID, err := uuid.Parse(c.Params("shareId"))
+	if err != nil {
+		return response.BadRequest(c, "invalid share id")
+	}
+
+	if err := h.shareService.Revoke(c.UserContext(), user.ID, id, shareID); err != nil {
+		return respondError(c, err)
+	}
+
+	return response.Success(c, fiber.StatusOK, "share link revoked", nil)
+}
+
+func (h *InterviewShareHandler) GetComments(c *fiber.Ctx) error {
+	user := middleware.GetUserFromContext(c)
+	if user == nil {
+		return response.Unauthorized(c, "user not authenticated")
+	}
+
+	id, err := uuid.Parse(c.Params("id"))
+	if err != nil {
+		return response.BadRequest(c, "invalid interview id")
+	}
+
+	comments, err := h.shareService.GetComments(c.UserContext(), user.ID, id)
+	if err != nil {
+		return respondError(c, err)
+	}
+
+	return response.Success(c, fiber.StatusOK, "mentor comments retrieved", comments)
+}
+
+func (h *InterviewShareHandler) GetSharedReport(c *fiber.Ctx) error {
+	report, err := h.shareService.GetSharedReport(c.UserContext(), c.Params("token"))
+	if err != nil {
+		return respondError(c, err)
+	}
+
+	return response.Success(c, fiber.StatusOK, "shared interview retrieved", report)
+}
+
+func (h *InterviewShareHandler) AddComment(c *fiber.Ctx) error {
+	var req domain.MentorCommentRequest
+	if err := bindAndValidate(c, &req); err != nil {
+		return validationFailed(c, err)
+	}
+
+	comment, err := h.shareService.AddComment(c.UserContext(), c.Params("token"), &req)
+	if err != nil {
+		return respondError(c, err)
+	}
+
+	return response.Success(c, fiber.StatusCreated, "comment added", comment)
+}
@@ -0,0 +1,87 @@
+package handler
+
+import (
+	"github.com/raflytch/careerly-server/internal/domain"
+	"github.com/raflytch/careerly-server/internal/middleware"
+	"github.com/raflytch/careerly-server/pkg/response"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/google/uuid"
+)
+
+type StudyPlanHandler struct {
+	studyPlanService domain.StudyPlanService
+}
+
+func NewStudyPlanHandler(studyPlanService domain.StudyPlanService) *StudyPlanHandler {
+	return &StudyPlanHandler{
+		studyPlanService: studyPlanService,
+	}
+}
+
+func (h *StudyPlanHandler) Generate(c *fiber.Ctx) error {
+	user := middleware.GetUserFromContext(c)
+	if user == nil {
+		return response.Unauthorized(c, "user not authenticated")
+	}
+
+	id, err := uuid.Parse(c.Params("id"))
+	if err != nil {
+		return response.BadRequest(c, "invalid interview id")
+	}
+
+	plan, err := h.studyPlanService.Generate(c.UserContext(), user.ID, id, c.QueryBool("regenerate", false))
+	if err != nil {
+		return respondError(c, err)
+	}
+
+	return response.Success(c, fiber.StatusOK, "study plan generated", plan)
+}
+
+func (h *StudyPlanHandler) Get(c *fiber.Ctx) error {
+	user := middleware.GetUserFromContext(c)
+	if user == nil {
+		return response.Unauthorized(c, "user not authenticated")
+	}
+
+	id, err := uuid.Parse(c.Params("id"))
+	if err != nil {
+		return response.BadRequest(c, "invalid interview id")
+	}
+
+	plan, err := h.studyPlanService.Get(c.UserContext(), user.ID, id)
+	if err != nil {
+		return respondError(c, err)
+	}
+
+	return response.Success(c, fiber.StatusOK, "study plan retrieved", plan)
+}
+
+func (h *StudyPlanHandler) UpdateTask(c *fiber.Ctx) error {
+	user := middleware.GetUserFromContext(c)
+	if user == nil {
+		return response.Unauthorized(c, "user not authenticated")
+	}
+
+	id, err := uuid.Parse(c.Params("id"))
+	if err != nil {
+		return response.BadRequest(c, "invalid interview id")
+	}
+
+	taskID, err := c.ParamsInt("taskId")
+	if err != nil || taskID < 1 {
+		return response.BadRequest(c, "invalid task id")
+	}
+
+	var req domain.UpdateStudyPlanTaskRequest
+	if err := bindAndValidate(c, &req); err != nil {
+		return validationFailed(c, err)
+	}
+
+	plan, err := h.studyPlanService.SetTaskCompleted(c.UserContext(), user.ID, id, taskID, *req.Completed)
+	if err != nil {
+		return respondError(c, err)
+	}
+
+	return response.Success(c, fiber.StatusOK, "study plan task updated", plan)
+}
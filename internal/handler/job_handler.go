@@ -0,0 +1,81 @@
+package handler
+
+import (
+	"github.com/raflytch/careerly-server/internal/domain"
+	"github.com/raflytch/careerly-server/pkg/response"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/google/uuid"
+)
+
+type JobHandler struct {
+	jobService domain.JobService
+}
+
+func NewJobHandler(jobService domain.JobService) *JobHandler {
+	return &JobHandler{
+		jobService: jobService,
+	}
+}
+
+func (h *JobHandler) GetStats(c *fiber.Ctx) error {
+	stats, err := h.jobService.GetStats(c.UserContext())
+	if err != nil {
+		return respondError(c, err)
+	}
+
+	return response.Success(c, fiber.StatusOK, "job queue stats retrieved successfully", stats)
+}
+
+func (h *JobHandler) GetDeadJobs(c *fiber.Ctx) error {
+	page := c.QueryInt("page", 1)
+	limit := c.QueryInt("limit", 10)
+
+	result, err := h.jobService.GetDeadJobs(c.UserContext(), page, limit)
+	if err != nil {
+		return respondError(c, err)
+	}
+
+	return response.Success(c, fiber.StatusOK, "dead jobs retrieved successfully", result)
+}
+
+func (h *JobHandler) GetDeadJob(c *fiber.Ctx) error {
+	id, err := uuid.Parse(c.Params("id"))
+	if err != nil {
+		return response.BadRequest(c, "invalid job id")
+	}
+
+	job, err := h.jobService.GetDeadJob(c.UserContext(), id)
+	if err != nil {
+		return respondError(c, err)
+	}
+
+	return response.Success(c, fiber.StatusOK, "dead job retrieved successfully", job)
+}
+
+func (h *JobHandler) RetryDeadJob(c *fiber.Ctx) error {
+	id, err := uuid.Parse(c.Params("id"))
+	if err != nil {
+		return response.BadRequest(c, "invalid job id")
+	}
+
+	job, err := h.jobService.RetryDeadJob(c.UserContext(), id)
+	if err != nil {
+		return respondError(c, err)
+	}
+
+	return response.Success(c, fiber.StatusOK, "job requeued", job)
+}
+
+func (h *JobHandler) DeleteDeadJob(c *fiber.Ctx) error {
+	id, err := uuid.Parse(c.Params("id"))
+	if err != nil {
+		return response.BadRequest(c, "invalid job id")
+	}
+
+	if err := h.jobService.DeleteDeadJob(c.UserContext(), id); err != nil {
+		return respondError(c, err)
+	}
+
+	return response.Success(c, fiber.StatusOK, "dead job deleted", nil)
+}
@@ -0,0 +1,58 @@
+package handler
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/raflytch/careerly-server/pkg/circuitbreaker"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+type MetricsHandler struct {
+	breakers []*circuitbreaker.Breaker
+}
+
+func NewMetricsHandler(breakers ...*circuitbreaker.Breaker) *MetricsHandler {
+	configured := make([]*circuitbreaker.Breaker, 0, len(breakers))
+	for _, breaker := range breakers {
+		if breaker != nil {
+			configured = append(configured, breaker)
+		}
+	}
+	return &MetricsHandler{breakers: configured}
+}
+
+// Get renders metrics in the Prometheus text exposition format.
+func (h *MetricsHandler) Get(c *fiber.Ctx) error {
+	var b strings.Builder
+
+	b.WriteString("# HELP careerly_circuit_breaker_state Circuit breaker state (0=closed, 1=open, 2=half_open).\n")
+	b.WriteString("# TYPE careerly_circuit_breaker_state gauge\n")
+	snapshots := make([]circuitbreaker.Snapshot, len(h.breakers))
+	for i, breaker := range h.breakers {
+		snapshots[i] = breaker.Snapshot()
+		fmt.Fprintf(&b, "careerly_circuit_breaker_state{name=%q} %d\n", snapshots[i].Name, snapshots[i].StateValue)
+	}
+
+	b.WriteString("# HELP careerly_circuit_breaker_consecutive_failures Consecutive failures recorded by the breaker.\n")
+	b.WriteString("# TYPE careerly_circuit_breaker_consecutive_failures gauge\n")
+	for _, snapshot := range snapshots {
+		fmt.Fprintf(&b, "careerly_circuit_breaker_consecutive_failures{name=%q} %d\n", snapshot.Name, snapshot.ConsecutiveFailures)
+	}
+
+	b.WriteString("# HELP careerly_circuit_breaker_rejected_total Calls rejected while the breaker was open.\n")
+	b.WriteString("# TYPE careerly_circuit_breaker_rejected_total counter\n")
+	for _, snapshot := range snapshots {
+		fmt.Fprintf(&b, "careerly_circuit_breaker_rejected_total{name=%q} %d\n", snapshot.Name, snapshot.Rejected)
+	}
+
+	b.WriteString("# HELP careerly_circuit_breaker_opened_total Times the breaker has tripped open.\n")
+	b.WriteString("# TYPE careerly_circuit_breaker_opened_total counter\n")
+	for _, snapshot := range snapshots {
+		fmt.Fprintf(&b, "careerly_circuit_breaker_opened_total{name=%q} %d\n", snapshot.Name, snapshot.Opened)
+	}
+
+	c.Set(fiber.HeaderContentType, "text/plain; version=0.0.4; charset=utf-8")
+	return c.SendString(b.String())
+}
@@ -0,0 +1,159 @@
+package handler
+
+import (
+	"github.com/raflytch/careerly-server/internal/domain"
+	"github.com/raflytch/careerly-server/internal/middleware"
+	"github.com/raflytch/careerly-server/pkg/response"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/google/uuid"
+)
+
+type OrganizationHandler struct {
+	organizationService domain.OrganizationService
+	quotaService        domain.QuotaService
+	transactionService  domain.TransactionService
+}
+
+func NewOrganizationHandler(organizationService domain.OrganizationService, quotaService domain.QuotaService, transactionService domain.TransactionService) *OrganizationHandler {
+	return &OrganizationHandler{
+		organizationService: organizationService,
+		quotaService:        quotaService,
+		transactionService:  transactionService,
+	}
+}
+
+func (h *OrganizationHandler) Create(c *fiber.Ctx) error {
+	user := middleware.GetUserFromContext(c)
+	if user == nil {
+		return response.Unauthorized(c, "user not authenticated")
+	}
+
+	var req domain.CreateOrganizationRequest
+	if err := bindAndValidate(c, &req); err != nil {
+		return validationFailed(c, err)
+	}
+
+	org, err := h.organizationService.Create(c.UserContext(), user.ID, &req)
+	if err != nil {
+		return respondError(c, err)
+	}
+
+	return response.Success(c, fiber.StatusCreated, "organization created", org)
+}
+
+func (h *OrganizationHandler) Get(c *fiber.Ctx) error {
+	user := middleware.GetUserFromContext(c)
+	if user == nil {
+		return response.Unauthorized(c, "user not authenticated")
+	}
+
+	detail, err := h.organizationService.GetForUser(c.UserContext(), user.ID)
+	if err != nil {
+		return respondError(c, err)
+	}
+
+	return response.Success(c, fiber.StatusOK, "organization retrieved", detail)
+}
+
+func (h *OrganizationHandler) GetQuota(c *fiber.Ctx) error {
+	user := middleware.GetUserFromContext(c)
+	if user == nil {
+		return response.Unauthorized(c, "user not authenticated")
+	}
+
+	quota, err := h.quotaService.GetOrganizationQuota(c.UserContext(), user.ID)
+	if err != nil {
+		return respondError(c, err)
+	}
+
+	return response.Success(c, fiber.StatusOK, "organization quota retrieved", quota)
+}
+
+func (h *OrganizationHandler) PurchaseSeats(c *fiber.Ctx) error {
+	user := middleware.GetUserFromContext(c)
+	if user == nil {
+		return response.Unauthorized(c, "user not authenticated")
+	}
+
+	var req domain.CreateSeatTransactionRequest
+	if err := bindAndValidate(c, &req); err != nil {
+		return validationFailed(c, err)
+	}
+
+	result, err := h.transactionService.CreateSeatTransaction(c.UserContext(), user.ID, &req)
+	if err != nil {
+		return respondError(c, err)
+	}
+
+	return response.Success(c, fiber.StatusCreated, "seat transaction created", result)
+}
+
+func (h *OrganizationHandler) Invite(c *fiber.Ctx) error {
+	user := middleware.GetUserFromContext(c)
+	if user == nil {
+		return response.Unauthorized(c, "user not authenticated")
+	}
+
+	var req domain.InviteOrganizationMemberRequest
+	if err := bindAndValidate(c, &req); err != nil {
+		return validationFailed(c, err)
+	}
+
+	invitation, err := h.organizationService.Invite(c.UserContext(), user.ID, &req)
+	if err != nil {
+		return respondError(c, err)
+	}
+
+	return response.Success(c, fiber.StatusCreated, "invitation sent", invitation)
+}
+
+func (h *OrganizationHandler) AcceptInvitation(c *fiber.Ctx) error {
+	user := middleware.GetUserFromContext(c)
+	if user == nil {
+		return response.Unauthorized(c, "user not authenticated")
+	}
+
+	var req domain.AcceptOrganizationInvitationRequest
+	if err := bindAndValidate(c, &req); err != nil {
+		return validationFailed(c, err)
+	}
+
+	org, err := h.organizationService.AcceptInvitation(c.UserContext(), user.ID, &req)
+	if err != nil {
+		return respondError(c, err)
+	}
+
+	return response.Success(c, fiber.StatusOK, "joined organization", org)
+}
+
+func (h *OrganizationHandler) RemoveMember(c *fiber.Ctx) error {
+	user := middleware.GetUserFromContext(c)
+	if user == nil {
+		return response.Unauthorized(c, "user not authenticated")
+	}
+
+	memberID, err := uuid.Parse(c.Params("userId"))
+	if err != nil {
+		return response.BadRequest(c, "invalid user id")
+	}
+
+	if err := h.organizationService.RemoveMember(c.UserContext(), user.ID, memberID); err != nil {
+		return respondError(c, err)
+	}
+
+	return response.Success(c, fiber.StatusOK, "member removed", nil)
+}
+
+func (h *OrganizationHandler) Leave(c *fiber.Ctx) error {
+	user := middleware.GetUserFromContext(c)
+	if user == nil {
+		return response.Unauthorized(c, "user not authenticated")
+	}
+
+	if err := h.organizationService.Leave(c.UserContext(), user.ID); err != nil {
+		return respondError(c, err)
+	}
+
+	return response.Success(c, fiber.StatusOK, "left organization", nil)
+}
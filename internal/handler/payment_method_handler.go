@@ -0,0 +1,52 @@
+package handler
+
+import (
+	"github.com/raflytch/careerly-server/internal/domain"
+	"github.com/raflytch/careerly-server/internal/middleware"
+	"github.com/raflytch/careerly-server/pkg/response"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/google/uuid"
+)
+
+type PaymentMethodHandler struct {
+	paymentMethodService domain.PaymentMethodService
+}
+
+func NewPaymentMethodHandler(paymentMethodService domain.PaymentMethodService) *PaymentMethodHandler {
+	return &PaymentMethodHandler{
+		paymentMethodService: paymentMethodService,
+	}
+}
+
+func (h *PaymentMethodHandler) GetAll(c *fiber.Ctx) error {
+	user := middleware.GetUserFromContext(c)
+	if user == nil {
+		return response.Unauthorized(c, "user not authenticated")
+	}
+
+	methods, err := h.paymentMethodService.GetByUserID(c.UserContext(), user.ID)
+	if err != nil {
+		return respondError(c, err)
+	}
+
+	return response.Success(c, fiber.StatusOK, "payment methods retrieved successfully", methods)
+}
+
+func (h *PaymentMethodHandler) Delete(c *fiber.Ctx) error {
+	user := middleware.GetUserFromContext(c)
+	if user == nil {
+		return response.Unauthorized(c, "user not authenticated")
+	}
+
+	id, err := uuid.Parse(c.Params("id"))
+	if err != nil {
+		return response.BadRequest(c, "invalid payment method id")
+	}
+
+	if err := h.paymentMethodService.Delete(c.UserContext(), user.ID, id); err != nil {
+		return respondError(c, err)
+	}
+
+	return response.Success(c, fiber.StatusOK, "payment method deleted successfully", nil)
+}
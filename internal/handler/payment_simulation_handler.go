@@ -0,0 +1,35 @@
+package handler
+
+import (
+	"github.com/raflytch/careerly-server/internal/domain"
+	"github.com/raflytch/careerly-server/internal/middleware"
+	"github.com/raflytch/careerly-server/pkg/response"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+type PaymentSimulationHandler struct {
+	simulationService domain.PaymentSimulationService
+}
+
+func NewPaymentSimulationHandler(simulationService domain.PaymentSimulationService) *PaymentSimulationHandler {
+	return &PaymentSimulationHandler{
+		simulationService: simulationService,
+	}
+}
+
+// SimulatePayment answers once the notification is queued; the transaction
+// and subscription update when the queue processes it.
+func (h *PaymentSimulationHandler) SimulatePayment(c *fiber.Ctx) error {
+	user := middleware.GetUserFromContext(c)
+	if user == nil {
+		return response.Unauthorized(c, "user not authenticated")
+	}
+
+	orderID := c.Params("orderID")
+	if err := h.simulationService.SimulatePayment(c.UserContext(), user.ID, orderID); err != nil {
+		return respondError(c, err)
+	}
+
+	return response.Success(c, fiber.StatusAccepted, "payment simulated", fiber.Map{"order_id": orderID})
+}
@@ -1,41 +1,43 @@
 package handler
 
 import (
-	"errors"
+	"fmt"
+	"strings"
+	"time"
 
 	"github.com/raflytch/careerly-server/internal/domain"
-	"github.com/raflytch/careerly-server/internal/service"
+	"github.com/raflytch/careerly-server/internal/middleware"
 	"github.com/raflytch/careerly-server/pkg/response"
 
 	"github.com/gofiber/fiber/v2"
 	"github.com/google/uuid"
 )
 
+// planListMaxAge is how long browsers and CDNs may reuse the plan list.
+// Admin changes reach Redis immediately but edge copies only after this.
+const planListMaxAge = 5 * time.Minute
+
 type PlanHandler struct {
-	planService domain.PlanService
+	planService    domain.PlanService
+	pricingService domain.PricingService
 }
 
-func NewPlanHandler(planService domain.PlanService) *PlanHandler {
+func NewPlanHandler(planService domain.PlanService, pricingService domain.PricingService) *PlanHandler {
 	return &PlanHandler{
-		planService: planService,
+		planService:    planService,
+		pricingService: pricingService,
 	}
 }
 
 func (h *PlanHandler) Create(c *fiber.Ctx) error {
 	var req domain.CreatePlanRequest
-	if err := c.BodyParser(&req); err != nil {
-		return response.BadRequest(c, "invalid request body")
+	if err := bindAndValidate(c, &req); err != nil {
+		return validationFailed(c, err)
 	}
 
 	plan, err := h.planService.Create(c.UserContext(), &req)
 	if err != nil {
-		if errors.Is(err, service.ErrPlanNameExists) {
-			return response.BadRequest(c, "plan name already exists")
-		}
-		if errors.Is(err, service.ErrInvalidPlanData) {
-			return response.BadRequest(c, "name and display_name are required")
-		}
-		return response.InternalError(c, err.Error())
+		return respondError(c, err)
 	}
 
 	return response.Success(c, fiber.StatusCreated, "plan created", plan)
@@ -50,10 +52,7 @@ func (h *PlanHandler) GetByID(c *fiber.Ctx) error {
 
 	plan, err := h.planService.GetByID(c.UserContext(), id)
 	if err != nil {
-		if errors.Is(err, service.ErrPlanNotFound) {
-			return response.NotFound(c, "plan not found")
-		}
-		return response.InternalError(c, err.Error())
+		return respondError(c, err)
 	}
 
 	return response.Success(c, fiber.StatusOK, "plan retrieved", plan)
@@ -64,12 +63,33 @@ func (h *PlanHandler) GetAll(c *fiber.Ctx) error {
 	limit := c.QueryInt("limit", 10)
 	includeInactive := c.QueryBool("include_inactive", false)
 
+	// ?country= overrides the country detected from the client IP
+	country := middleware.GetCountryFromContext(c)
+	override := c.Query("country")
+	if override != "" {
+		if !isCountryCode(override) {
+			return response.BadRequest(c, "country must be a two-letter ISO 3166 code")
+		}
+		country = strings.ToUpper(override)
+	}
+
 	result, err := h.planService.GetAll(c.UserContext(), page, limit, includeInactive)
 	if err != nil {
-		return response.InternalError(c, err.Error())
+		return respondError(c, err)
+	}
+
+	// Prices detected from the client IP are not in the URL, so only the
+	// browser may keep those; a shared cache would serve them to everyone.
+	switch {
+	case includeInactive:
+		c.Set(fiber.HeaderCacheControl, "private, no-store")
+	case override != "" || country == "":
+		c.Set(fiber.HeaderCacheControl, fmt.Sprintf("public, max-age=%d", int(planListMaxAge.Seconds())))
+	default:
+		c.Set(fiber.HeaderCacheControl, fmt.Sprintf("private, max-age=%d", int(planListMaxAge.Seconds())))
 	}
 
-	return response.Success(c, fiber.StatusOK, "plans retrieved", result)
+	return response.Success(c, fiber.StatusOK, "plans retrieved", h.pricingService.Localize(result, country))
 }
 
 func (h *PlanHandler) Update(c *fiber.Ctx) error {
@@ -80,19 +100,13 @@ func (h *PlanHandler) Update(c *fiber.Ctx) error {
 	}
 
 	var req domain.UpdatePlanRequest
-	if err := c.BodyParser(&req); err != nil {
-		return response.BadRequest(c, "invalid request body")
+	if err := bindAndValidate(c, &req); err != nil {
+		return validationFailed(c, err)
 	}
 
 	plan, err := h.planService.Update(c.UserContext(), id, &req)
 	if err != nil {
-		if errors.Is(err, service.ErrPlanNotFound) {
-			return response.NotFound(c, "plan not found")
-		}
-		if errors.Is(err, service.ErrPlanNameExists) {
-			return response.BadRequest(c, "plan name already exists")
-		}
-		return response.InternalError(c, err.Error())
+		return respondError(c, err)
 	}
 
 	return response.Success(c, fiber.StatusOK, "plan updated", plan)
@@ -106,11 +120,48 @@ func (h *PlanHandler) Delete(c *fiber.Ctx) error {
 	}
 
 	if err := h.planService.Delete(c.UserContext(), id); err != nil {
-		if errors.Is(err, service.ErrPlanNotFound) {
-			return response.NotFound(c, "plan not found")
-		}
-		return response.InternalError(c, err.Error())
+		return respondError(c, err)
 	}
 
 	return response.Success(c, fiber.StatusOK, "plan deleted", nil)
 }
+
+func (h *PlanHandler) Archive(c *fiber.Ctx) error {
+	id, err := uuid.Parse(c.Params("id"))
+	if err != nil {
+		return response.BadRequest(c, "invalid plan id")
+	}
+
+	plan, err := h.planService.Archive(c.UserContext(), id)
+	if err != nil {
+		return respondError(c, err)
+	}
+
+	return response.Success(c, fiber.StatusOK, "plan archived", plan)
+}
+
+func (h *PlanHandler) Unarchive(c *fiber.Ctx) error {
+	id, err := uuid.Parse(c.Params("id"))
+	if err != nil {
+		return response.BadRequest(c, "invalid plan id")
+	}
+
+	plan, err := h.planService.Unarchive(c.UserContext(), id)
+	if err != nil {
+		return respondError(c, err)
+	}
+
+	return response.Success(c, fiber.StatusOK, "plan unarchived", plan)
+}
+
+func isCountryCode(value string) bool {
+	if len(value) != 2 {
+		return false
+	}
+	for _, r := range value {
+		if (r < 'a' || r > 'z') && (r < 'A' || r > 'Z') {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,70 @@
+package handler
+
+import (
+	"github.com/raflytch/careerly-server/internal/domain"
+	"github.com/raflytch/careerly-server/pkg/response"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/google/uuid"
+)
+
+type PromptExperimentHandler struct {
+	experimentService domain.PromptExperimentService
+}
+
+func NewPromptExperimentHandler(experimentService domain.PromptExperimentService) *PromptExperimentHandler {
+	return &PromptExperimentHandler{
+		experimentService: experimentService,
+	}
+}
+
+func (h *PromptExperimentHandler) List(c *fiber.Ctx) error {
+	experiments, err := h.experimentService.List(c.UserContext())
+	if err != nil {
+		return respondError(c, err)
+	}
+
+	return response.Success(c, fiber.StatusOK, "experiments retrieved", experiments)
+}
+
+func (h *PromptExperimentHandler) Create(c *fiber.Ctx) error {
+	var req domain.CreatePromptExperimentRequest
+	if err := bindAndValidate(c, &req); err != nil {
+		return validationFailed(c, err)
+	}
+
+	experiment, err := h.experimentService.Create(c.UserContext(), &req)
+	if err != nil {
+		return respondError(c, err)
+	}
+
+	return response.Success(c, fiber.StatusCreated, "experiment started", experiment)
+}
+
+func (h *PromptExperimentHandler) GetResults(c *fiber.Ctx) error {
+	id, err := uuid.Parse(c.Params("id"))
+	if err != nil {
+		return response.BadRequest(c, "invalid experiment id")
+	}
+
+	results, err := h.experimentService.GetResults(c.UserContext(), id)
+	if err != nil {
+		return respondError(c, err)
+	}
+
+	return response.Success(c, fiber.StatusOK, "experiment results retrieved", results)
+}
+
+func (h *PromptExperimentHandler) Stop(c *fiber.Ctx) error {
+	id, err := uuid.Parse(c.Params("id"))
+	if err != nil {
+		return response.BadRequest(c, "invalid experiment id")
+	}
+
+	experiment, err := h.experimentService.Stop(c.UserContext(), id)
+	if err != nil {
+		return respondError(c, err)
+	}
+
+	return response.Success(c, fiber.StatusOK, "experiment stopped", experiment)
+}
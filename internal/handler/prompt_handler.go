@@ -0,0 +1,93 @@
+package handler
+
+import (
+	"github.com/raflytch/careerly-server/internal/domain"
+	"github.com/raflytch/careerly-server/pkg/response"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+type PromptHandler struct {
+	promptService domain.PromptService
+}
+
+func NewPromptHandler(promptService domain.PromptService) *PromptHandler {
+	return &PromptHandler{
+		promptService: promptService,
+	}
+}
+
+func (h *PromptHandler) List(c *fiber.Ctx) error {
+	prompts, err := h.promptService.List(c.UserContext())
+	if err != nil {
+		return respondError(c, err)
+	}
+
+	return response.Success(c, fiber.StatusOK, "prompts retrieved", prompts)
+}
+
+func (h *PromptHandler) Get(c *fiber.Ctx) error {
+	key := domain.PromptKey(c.Params("key"))
+
+	prompt, err := h.promptService.Get(c.UserContext(), key)
+	if err != nil {
+		return respondError(c, err)
+	}
+
+	return response.Success(c, fiber.StatusOK, "prompt retrieved", prompt)
+}
+
+func (h *PromptHandler) CreateVersion(c *fiber.Ctx) error {
+	key := domain.PromptKey(c.Params("key"))
+
+	var req domain.CreatePromptVersionRequest
+	if err := bindAndValidate(c, &req); err != nil {
+		return validationFailed(c, err)
+	}
+
+	prompt, err := h.promptService.CreateVersion(c.UserContext(), key, &req)
+	if err != nil {
+		return respondError(c, err)
+	}
+
+	return response.Success(c, fiber.StatusCreated, "prompt version created", prompt)
+}
+
+func (h *PromptHandler) Activate(c *fiber.Ctx) error {
+	key := domain.PromptKey(c.Params("key"))
+	version, err := c.ParamsInt("version")
+	if err != nil || version < 1 {
+		return response.BadRequest(c, "invalid prompt version")
+	}
+
+	prompt, err := h.promptService.Activate(c.UserContext(), key, version)
+	if err != nil {
+		return respondError(c, err)
+	}
+
+	return response.Success(c, fiber.StatusOK, "prompt version activated", prompt)
+}
+
+func (h *PromptHandler) Reset(c *fiber.Ctx) error {
+	key := domain.PromptKey(c.Params("key"))
+
+	if err := h.promptService.Reset(c.UserContext(), key); err != nil {
+		return respondError(c, err)
+	}
+
+	return response.Success(c, fiber.StatusOK, "prompt reset to default", nil)
+}
+
+func (h *PromptHandler) DeleteVersion(c *fiber.Ctx) error {
+	key := domain.PromptKey(c.Params("key"))
+	version, err := c.ParamsInt("version")
+	if err != nil || version < 1 {
+		return response.BadRequest(c, "invalid prompt version")
+	}
+
+	if err := h.promptService.DeleteVersion(c.UserContext(), key, version); err != nil {
+		return respondError(c, err)
+	}
+
+	return response.Success(c, fiber.StatusOK, "prompt version deleted", nil)
+}
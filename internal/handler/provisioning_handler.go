@@ -0,0 +1,60 @@
+package handler
+
+import (
+	"github.com/raflytch/careerly-server/internal/domain"
+	"github.com/raflytch/careerly-server/pkg/response"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/google/uuid"
+)
+
+type ProvisioningHandler struct {
+	provisioningService domain.ProvisioningService
+}
+
+func NewProvisioningHandler(provisioningService domain.ProvisioningService) *ProvisioningHandler {
+	return &ProvisioningHandler{
+		provisioningService: provisioningService,
+	}
+}
+
+func (h *ProvisioningHandler) GetAll(c *fiber.Ctx) error {
+	page := c.QueryInt("page", 1)
+	limit := c.QueryInt("limit", 10)
+	status := domain.ProvisioningJobStatus(c.Query("status"))
+
+	result, err := h.provisioningService.GetAll(c.UserContext(), status, page, limit)
+	if err != nil {
+		return respondError(c, err)
+	}
+
+	return response.Success(c, fiber.StatusOK, "provisioning jobs retrieved successfully", result)
+}
+
+func (h *ProvisioningHandler) GetByID(c *fiber.Ctx) error {
+	id, err := uuid.Parse(c.Params("id"))
+	if err != nil {
+		return response.BadRequest(c, "invalid provisioning job id")
+	}
+
+	job, err := h.provisioningService.GetByID(c.UserContext(), id)
+	if err != nil {
+		return respondError(c, err)
+	}
+
+	return response.Success(c, fiber.StatusOK, "provisioning job retrieved successfully", job)
+}
+
+func (h *ProvisioningHandler) Replay(c *fiber.Ctx) error {
+	id, err := uuid.Parse(c.Params("id"))
+	if err != nil {
+		return response.BadRequest(c, "invalid provisioning job id")
+	}
+
+	job, err := h.provisioningService.Replay(c.UserContext(), id)
+	if err != nil {
+		return respondError(c, err)
+	}
+
+	return response.Success(c, fiber.StatusOK, "provisioning job replayed", job)
+}
@@ -0,0 +1,73 @@
+package handler
+
+import (
+	"github.com/raflytch/careerly-server/internal/domain"
+	"github.com/raflytch/careerly-server/pkg/response"
+	"github.com/raflytch/careerly-server/pkg/validator"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/google/uuid"
+)
+
+type QuestionBankHandler struct {
+	bankService   domain.QuestionBankService
+	fileValidator *validator.FileValidator
+}
+
+func NewQuestionBankHandler(bankService domain.QuestionBankService) *QuestionBankHandler {
+	return &QuestionBankHandler{
+		bankService: bankService,
+		fileValidator: validator.NewFileValidator(
+			validator.WithMaxSize(validator.MaxSize2MB),
+			validator.WithAllowedTypes([]string{".csv"}),
+		),
+	}
+}
+
+func (h *QuestionBankHandler) Import(c *fiber.Ctx) error {
+	file, err := c.FormFile("file")
+	if err != nil {
+		return response.BadRequest(c, "csv file is required, use form field 'file'")
+	}
+
+	if err := h.fileValidator.Validate(file); err != nil {
+		return response.BadRequest(c, err.Error())
+	}
+
+	result, err := h.bankService.Import(c.UserContext(), file)
+	if err != nil {
+		return respondError(c, err)
+	}
+
+	return response.Success(c, fiber.StatusCreated, "questions imported", result)
+}
+
+func (h *QuestionBankHandler) GetAll(c *fiber.Ctx) error {
+	var filter domain.QuestionBankFilter
+	if err := bindQueryAndValidate(c, &filter); err != nil {
+		return validationFailed(c, err)
+	}
+
+	page := c.QueryInt("page", 1)
+	limit := c.QueryInt("limit", 10)
+
+	result, err := h.bankService.GetAll(c.UserContext(), filter, page, limit)
+	if err != nil {
+		return respondError(c, err)
+	}
+
+	return response.Success(c, fiber.StatusOK, "bank questions retrieved", result)
+}
+
+func (h *QuestionBankHandler) Delete(c *fiber.Ctx) error {
+	id, err := uuid.Parse(c.Params("id"))
+	if err != nil {
+		return response.BadRequest(c, "invalid question id")
+	}
+
+	if err := h.bankService.Delete(c.UserContext(), id); err != nil {
+		return respondError(c, err)
+	}
+
+	return response.Success(c, fiber.StatusOK, "bank question deleted", nil)
+}
@@ -0,0 +1,77 @@
+package handler
+
+import (
+	"github.com/raflytch/careerly-server/internal/domain"
+	"github.com/raflytch/careerly-server/internal/middleware"
+	"github.com/raflytch/careerly-server/pkg/response"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/google/uuid"
+)
+
+type QuotaOverrideHandler struct {
+	overrideService domain.QuotaOverrideService
+}
+
+func NewQuotaOverrideHandler(overrideService domain.QuotaOverrideService) *QuotaOverrideHandler {
+	return &QuotaOverrideHandler{
+		overrideService: overrideService,
+	}
+}
+
+func (h *QuotaOverrideHandler) Create(c *fiber.Ctx) error {
+	admin := middleware.GetUserFromContext(c)
+	if admin == nil {
+		return response.Unauthorized(c, "user not authenticated")
+	}
+
+	userID, err := uuid.Parse(c.Params("id"))
+	if err != nil {
+		return response.BadRequest(c, "invalid user id")
+	}
+
+	var req domain.CreateQuotaOverrideRequest
+	if err := bindAndValidate(c, &req); err != nil {
+		return validationFailed(c, err)
+	}
+
+	override, err := h.overrideService.Create(c.UserContext(), admin.ID, userID, &req)
+	if err != nil {
+		return respondError(c, err)
+	}
+
+	return response.Success(c, fiber.StatusCreated, "quota override created", override)
+}
+
+func (h *QuotaOverrideHandler) GetByUser(c *fiber.Ctx) error {
+	userID, err := uuid.Parse(c.Params("id"))
+	if err != nil {
+		return response.BadRequest(c, "invalid user id")
+	}
+
+	overrides, err := h.overrideService.GetByUserID(c.UserContext(), userID)
+	if err != nil {
+		return respondError(c, err)
+	}
+
+	return response.Success(c, fiber.StatusOK, "quota overrides retrieved", overrides)
+}
+
+func (h *QuotaOverrideHandler) Revoke(c *fiber.Ctx) error {
+	userID, err := uuid.Parse(c.Params("id"))
+	if err != nil {
+		return response.BadRequest(c, "invalid user id")
+	}
+
+	overrideID, err := uuid.Parse(c.Params("overrideId"))
+	if err != nil {
+		return response.BadRequest(c, "invalid quota override id")
+	}
+
+	override, err := h.overrideService.Revoke(c.UserContext(), userID, overrideID)
+	if err != nil {
+		return respondError(c, err)
+	}
+
+	return response.Success(c, fiber.StatusOK, "quota override revoked", override)
+}
@@ -0,0 +1,56 @@
+package handler
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/raflytch/careerly-server/internal/domain"
+	"github.com/raflytch/careerly-server/pkg/response"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+const reconciliationMonthLayout = "2006-01"
+
+type ReconciliationHandler struct {
+	reconciliationService domain.ReconciliationService
+}
+
+func NewReconciliationHandler(reconciliationService domain.ReconciliationService) *ReconciliationHandler {
+	return &ReconciliationHandler{
+		reconciliationService: reconciliationService,
+	}
+}
+
+func (h *ReconciliationHandler) GetReport(c *fiber.Ctx) error {
+	now := time.Now().UTC()
+	month := time.Date(now.Year(), now.Month(), 1, 0, 0, 0, 0, time.UTC)
+	if raw := c.Query("month"); raw != "" {
+		parsed, err := time.Parse(reconciliationMonthLayout, raw)
+		if err != nil {
+			return response.BadRequest(c, "month must be in YYYY-MM format")
+		}
+		month = parsed
+	}
+
+	switch c.Query("format", "csv") {
+	case "csv":
+		export, err := h.reconciliationService.ExportCSV(c.UserContext(), month)
+		if err != nil {
+			return respondError(c, err)
+		}
+
+		c.Set("Content-Type", export.ContentType)
+		c.Set("Content-Disposition", fmt.Sprintf("attachment; filename=%s", export.Filename))
+		return c.Send(export.Data)
+	case "json":
+		report, err := h.reconciliationService.GenerateReport(c.UserContext(), month)
+		if err != nil {
+			return respondError(c, err)
+		}
+
+		return response.Success(c, fiber.StatusOK, "reconciliation report generated", report)
+	default:
+		return response.BadRequest(c, "format must be csv or json")
+	}
+}
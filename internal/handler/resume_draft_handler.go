@@ -0,0 +1,135 @@
+package handler
+
+import (
+	"github.com/raflytch/careerly-server/internal/domain"
+	"github.com/raflytch/careerly-server/internal/middleware"
+	"github.com/raflytch/careerly-server/pkg/response"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/google/uuid"
+)
+
+type ResumeDraftHandler struct {
+	draftService domain.ResumeDraftService
+}
+
+func NewResumeDraftHandler(draftService domain.ResumeDraftService) *ResumeDraftHandler {
+	return &ResumeDraftHandler{
+		draftService: draftService,
+	}
+}
+
+func (h *ResumeDraftHandler) Create(c *fiber.Ctx) error {
+	user := middleware.GetUserFromContext(c)
+	if user == nil {
+		return response.Unauthorized(c, "user not authenticated")
+	}
+
+	var req domain.CreateResumeDraftRequest
+	if len(c.Body()) > 0 {
+		if err := bindAndValidate(c, &req); err != nil {
+			return validationFailed(c, err)
+		}
+	}
+
+	draft, err := h.draftService.Create(c.UserContext(), user.ID, &req)
+	if err != nil {
+		return respondError(c, err)
+	}
+
+	return response.Success(c, fiber.StatusCreated, "draft created", draft)
+}
+
+func (h *ResumeDraftHandler) GetMyDrafts(c *fiber.Ctx) error {
+	user := middleware.GetUserFromContext(c)
+	if user == nil {
+		return response.Unauthorized(c, "user not authenticated")
+	}
+
+	drafts, err := h.draftService.GetByUserID(c.UserContext(), user.ID)
+	if err != nil {
+		return respondError(c, err)
+	}
+
+	return response.Success(c, fiber.StatusOK, "drafts retrieved", drafts)
+}
+
+func (h *ResumeDraftHandler) GetByID(c *fiber.Ctx) error {
+	user := middleware.GetUserFromContext(c)
+	if user == nil {
+		return response.Unauthorized(c, "user not authenticated")
+	}
+
+	id, err := uuid.Parse(c.Params("id"))
+	if err != nil {
+		return response.BadRequest(c, "invalid draft id")
+	}
+
+	draft, err := h.draftService.GetByID(c.UserContext(), user.ID, id)
+	if err != nil {
+		return respondError(c, err)
+	}
+
+	return response.Success(c, fiber.StatusOK, "draft retrieved", draft)
+}
+
+func (h *ResumeDraftHandler) Update(c *fiber.Ctx) error {
+	user := middleware.GetUserFromContext(c)
+	if user == nil {
+		return response.Unauthorized(c, "user not authenticated")
+	}
+
+	id, err := uuid.Parse(c.Params("id"))
+	if err != nil {
+		return response.BadRequest(c, "invalid draft id")
+	}
+
+	var req domain.UpdateResumeDraftRequest
+	if err := bindAndValidate(c, &req); err != nil {
+		return validationFailed(c, err)
+	}
+
+	draft, err := h.draftService.Update(c.UserContext(), user.ID, id, &req)
+	if err != nil {
+		return respondError(c, err)
+	}
+
+	return response.Success(c, fiber.StatusOK, "draft saved", draft)
+}
+
+func (h *ResumeDraftHandler) Delete(c *fiber.Ctx) error {
+	user := middleware.GetUserFromContext(c)
+	if user == nil {
+		return response.Unauthorized(c, "user not authenticated")
+	}
+
+	id, err := uuid.Parse(c.Params("id"))
+	if err != nil {
+		return response.BadRequest(c, "invalid draft id")
+	}
+
+	if err := h.draftService.Delete(c.UserContext(), user.ID, id); err != nil {
+		return respondError(c, err)
+	}
+
+	return response.Success(c, fiber.StatusOK, "draft deleted", nil)
+}
+
+func (h *ResumeDraftHandler) Publish(c *fiber.Ctx) error {
+	user := middleware.GetUserFromContext(c)
+	if user == nil {
+		return response.Unauthorized(c, "user not authenticated")
+	}
+
+	id, err := uuid.Parse(c.Params("id"))
+	if err != nil {
+		return response.BadRequest(c, "invalid draft id")
+	}
+
+	result, err := h.draftService.Publish(c.UserContext(), user.ID, id, c.QueryBool("force", false))
+	if err != nil {
+		return respondError(c, err)
+	}
+
+	return response.Success(c, fiber.StatusCreated, "draft published", result)
+}
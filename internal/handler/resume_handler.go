@@ -1,28 +1,30 @@
 package handler
 
 import (
-	"errors"
 	"fmt"
 
 	"github.com/raflytch/careerly-server/internal/domain"
 	"github.com/raflytch/careerly-server/internal/middleware"
-	"github.com/raflytch/careerly-server/internal/service"
+	"github.com/raflytch/careerly-server/pkg/imagekit"
 	"github.com/raflytch/careerly-server/pkg/response"
 
-	"github.com/go-playground/validator/v10"
 	"github.com/gofiber/fiber/v2"
 	"github.com/google/uuid"
 )
 
 type ResumeHandler struct {
-	resumeService domain.ResumeService
-	quotaService  domain.QuotaService
+	resumeService  domain.ResumeService
+	lintService    domain.ResumeLintService
+	quotaService   domain.QuotaService
+	imagekitClient *imagekit.Client
 }
 
-func NewResumeHandler(resumeService domain.ResumeService, quotaService domain.QuotaService) *ResumeHandler {
+func NewResumeHandler(resumeService domain.ResumeService, lintService domain.ResumeLintService, quotaService domain.QuotaService, imagekitClient *imagekit.Client) *ResumeHandler {
 	return &ResumeHandler{
-		resumeService: resumeService,
-		quotaService:  quotaService,
+		resumeService:  resumeService,
+		lintService:    lintService,
+		quotaService:   quotaService,
+		imagekitClient: imagekitClient,
 	}
 }
 
@@ -33,23 +35,13 @@ func (h *ResumeHandler) Create(c *fiber.Ctx) error {
 	}
 
 	var req domain.CreateResumeRequest
-	if err := c.BodyParser(&req); err != nil {
-		return response.BadRequest(c, "invalid request body")
-	}
-
-	if err := validateResumeRequest(&req); err != nil {
-		return response.BadRequest(c, err.Error())
+	if err := bindAndValidate(c, &req); err != nil {
+		return validationFailed(c, err)
 	}
 
 	result, err := h.resumeService.Create(c.UserContext(), user.ID, &req)
 	if err != nil {
-		if errors.Is(err, service.ErrNoActiveSubscription) {
-			return response.Forbidden(c, "no active subscription found")
-		}
-		if errors.Is(err, service.ErrQuotaExceeded) {
-			return response.Forbidden(c, "resume quota exceeded for this month")
-		}
-		return response.InternalError(c, err.Error())
+		return respondError(c, err)
 	}
 
 	return response.Success(c, fiber.StatusCreated, "resume created", result)
@@ -69,13 +61,7 @@ func (h *ResumeHandler) GetByID(c *fiber.Ctx) error {
 
 	resume, err := h.resumeService.GetByID(c.UserContext(), user.ID, id)
 	if err != nil {
-		if errors.Is(err, service.ErrResumeNotFound) {
-			return response.NotFound(c, "resume not found")
-		}
-		if errors.Is(err, service.ErrUnauthorized) {
-			return response.Forbidden(c, "unauthorized access to resume")
-		}
-		return response.InternalError(c, err.Error())
+		return respondError(c, err)
 	}
 
 	return response.Success(c, fiber.StatusOK, "resume retrieved", resume)
@@ -90,14 +76,47 @@ func (h *ResumeHandler) GetMyResumes(c *fiber.Ctx) error {
 	page := c.QueryInt("page", 1)
 	limit := c.QueryInt("limit", 10)
 
-	result, err := h.resumeService.GetByUserID(c.UserContext(), user.ID, page, limit)
+	var filter domain.ResumeFilter
+	if raw := c.Query("tag"); raw != "" {
+		tagID, err := uuid.Parse(raw)
+		if err != nil {
+			return response.BadRequest(c, "invalid tag id")
+		}
+		filter.TagID = &tagID
+	}
+	if raw := c.Query("folder"); raw != "" {
+		folderID, err := uuid.Parse(raw)
+		if err != nil {
+			return response.BadRequest(c, "invalid folder id")
+		}
+		filter.FolderID = &folderID
+	}
+
+	result, err := h.resumeService.GetByUserID(c.UserContext(), user.ID, filter, page, limit)
 	if err != nil {
-		return response.InternalError(c, err.Error())
+		return respondError(c, err)
 	}
 
 	return response.Success(c, fiber.StatusOK, "resumes retrieved", result)
 }
 
+func (h *ResumeHandler) Search(c *fiber.Ctx) error {
+	user := middleware.GetUserFromContext(c)
+	if user == nil {
+		return response.Unauthorized(c, "user not authenticated")
+	}
+
+	page := c.QueryInt("page", 1)
+	limit := c.QueryInt("limit", 10)
+
+	result, err := h.resumeService.Search(c.UserContext(), user.ID, c.Query("q"), page, limit)
+	if err != nil {
+		return respondError(c, err)
+	}
+
+	return response.Success(c, fiber.StatusOK, "resumes found", result)
+}
+
 func (h *ResumeHandler) Update(c *fiber.Ctx) error {
 	user := middleware.GetUserFromContext(c)
 	if user == nil {
@@ -111,19 +130,13 @@ func (h *ResumeHandler) Update(c *fiber.Ctx) error {
 	}
 
 	var req domain.UpdateResumeRequest
-	if err := c.BodyParser(&req); err != nil {
-		return response.BadRequest(c, "invalid request body")
+	if err := bindAndValidate(c, &req); err != nil {
+		return validationFailed(c, err)
 	}
 
 	result, err := h.resumeService.Update(c.UserContext(), user.ID, id, &req)
 	if err != nil {
-		if errors.Is(err, service.ErrResumeNotFound) {
-			return response.NotFound(c, "resume not found")
-		}
-		if errors.Is(err, service.ErrUnauthorized) {
-			return response.Forbidden(c, "unauthorized access to resume")
-		}
-		return response.InternalError(c, err.Error())
+		return respondError(c, err)
 	}
 
 	return response.Success(c, fiber.StatusOK, "resume updated", result)
@@ -142,18 +155,219 @@ func (h *ResumeHandler) Delete(c *fiber.Ctx) error {
 	}
 
 	if err := h.resumeService.Delete(c.UserContext(), user.ID, id); err != nil {
-		if errors.Is(err, service.ErrResumeNotFound) {
-			return response.NotFound(c, "resume not found")
-		}
-		if errors.Is(err, service.ErrUnauthorized) {
-			return response.Forbidden(c, "unauthorized access to resume")
-		}
-		return response.InternalError(c, err.Error())
+		return respondError(c, err)
 	}
 
 	return response.Success(c, fiber.StatusOK, "resume deleted", nil)
 }
 
+func (h *ResumeHandler) GetTrash(c *fiber.Ctx) error {
+	user := middleware.GetUserFromContext(c)
+	if user == nil {
+		return response.Unauthorized(c, "user not authenticated")
+	}
+
+	page := c.QueryInt("page", 1)
+	limit := c.QueryInt("limit", 10)
+
+	result, err := h.resumeService.GetTrash(c.UserContext(), user.ID, page, limit)
+	if err != nil {
+		return respondError(c, err)
+	}
+
+	return response.Success(c, fiber.StatusOK, "deleted resumes retrieved", result)
+}
+
+func (h *ResumeHandler) Restore(c *fiber.Ctx) error {
+	user := middleware.GetUserFromContext(c)
+	if user == nil {
+		return response.Unauthorized(c, "user not authenticated")
+	}
+
+	id, err := uuid.Parse(c.Params("id"))
+	if err != nil {
+		return response.BadRequest(c, "invalid resume id")
+	}
+
+	resume, err := h.resumeService.Restore(c.UserContext(), user.ID, id)
+	if err != nil {
+		return respondError(c, err)
+	}
+
+	return response.Success(c, fiber.StatusOK, "resume restored", resume)
+}
+
+func (h *ResumeHandler) UploadPhoto(c *fiber.Ctx) error {
+	user := middleware.GetUserFromContext(c)
+	if user == nil {
+		return response.Unauthorized(c, "user not authenticated")
+	}
+
+	id, err := uuid.Parse(c.Params("id"))
+	if err != nil {
+		return response.BadRequest(c, "invalid resume id")
+	}
+
+	if _, err := h.resumeService.GetByID(c.UserContext(), user.ID, id); err != nil {
+		return respondError(c, err)
+	}
+
+	file, err := c.FormFile("photo")
+	if err != nil {
+		return response.BadRequest(c, "photo file is required, use form field 'photo'")
+	}
+
+	if err := h.imagekitClient.ValidateImage(file); err != nil {
+		return response.BadRequest(c, err.Error())
+	}
+
+	uploadResult, err := h.imagekitClient.UploadFile(c.UserContext(), file, "resume-photos")
+	if err != nil {
+		return response.InternalError(c, "failed to upload photo: "+err.Error())
+	}
+
+	resume, err := h.resumeService.SetPhoto(c.UserContext(), user.ID, id, uploadResult.URL)
+	if err != nil {
+		return respondError(c, err)
+	}
+
+	return response.Success(c, fiber.StatusOK, "resume photo updated", resume)
+}
+
+func (h *ResumeHandler) UpdatePhotoVisibility(c *fiber.Ctx) error {
+	user := middleware.GetUserFromContext(c)
+	if user == nil {
+		return response.Unauthorized(c, "user not authenticated")
+	}
+
+	id, err := uuid.Parse(c.Params("id"))
+	if err != nil {
+		return response.BadRequest(c, "invalid resume id")
+	}
+
+	var req domain.ResumePhotoVisibilityRequest
+	if err := bindAndValidate(c, &req); err != nil {
+		return validationFailed(c, err)
+	}
+
+	resume, err := h.resumeService.SetPhotoVisibility(c.UserContext(), user.ID, id, *req.ShowPhoto)
+	if err != nil {
+		return respondError(c, err)
+	}
+
+	return response.Success(c, fiber.StatusOK, "resume photo visibility updated", resume)
+}
+
+func (h *ResumeHandler) UpdateRedaction(c *fiber.Ctx) error {
+	user := middleware.GetUserFromContext(c)
+	if user == nil {
+		return response.Unauthorized(c, "user not authenticated")
+	}
+
+	id, err := uuid.Parse(c.Params("id"))
+	if err != nil {
+		return response.BadRequest(c, "invalid resume id")
+	}
+
+	var req domain.ResumeRedactionRequest
+	if err := bindAndValidate(c, &req); err != nil {
+		return validationFailed(c, err)
+	}
+
+	resume, err := h.resumeService.SetRedaction(c.UserContext(), user.ID, id, *req.RedactPersonal)
+	if err != nil {
+		return respondError(c, err)
+	}
+
+	return response.Success(c, fiber.StatusOK, "resume redaction updated", resume)
+}
+
+func (h *ResumeHandler) DeletePhoto(c *fiber.Ctx) error {
+	user := middleware.GetUserFromContext(c)
+	if user == nil {
+		return response.Unauthorized(c, "user not authenticated")
+	}
+
+	id, err := uuid.Parse(c.Params("id"))
+	if err != nil {
+		return response.BadRequest(c, "invalid resume id")
+	}
+
+	resume, err := h.resumeService.SetPhoto(c.UserContext(), user.ID, id, "")
+	if err != nil {
+		return respondError(c, err)
+	}
+
+	return response.Success(c, fiber.StatusOK, "resume photo removed", resume)
+}
+
+func (h *ResumeHandler) Optimize(c *fiber.Ctx) error {
+	user := middleware.GetUserFromContext(c)
+	if user == nil {
+		return response.Unauthorized(c, "user not authenticated")
+	}
+
+	id, err := uuid.Parse(c.Params("id"))
+	if err != nil {
+		return response.BadRequest(c, "invalid resume id")
+	}
+
+	var req domain.OptimizeResumeRequest
+	if err := bindAndValidate(c, &req); err != nil {
+		return validationFailed(c, err)
+	}
+
+	optimization, err := h.resumeService.Optimize(c.UserContext(), user.ID, id, &req)
+	if err != nil {
+		return respondError(c, err)
+	}
+
+	return response.Success(c, fiber.StatusOK, "resume optimization suggestions generated", optimization)
+}
+
+func (h *ResumeHandler) GenerateBullets(c *fiber.Ctx) error {
+	user := middleware.GetUserFromContext(c)
+	if user == nil {
+		return response.Unauthorized(c, "user not authenticated")
+	}
+
+	var req domain.GenerateBulletsRequest
+	if err := bindAndValidate(c, &req); err != nil {
+		return validationFailed(c, err)
+	}
+
+	bullets, err := h.resumeService.GenerateBullets(c.UserContext(), user.ID, &req)
+	if err != nil {
+		return respondError(c, err)
+	}
+
+	return response.Success(c, fiber.StatusOK, "bullet points generated", bullets)
+}
+
+func (h *ResumeHandler) ApplySuggestions(c *fiber.Ctx) error {
+	user := middleware.GetUserFromContext(c)
+	if user == nil {
+		return response.Unauthorized(c, "user not authenticated")
+	}
+
+	id, err := uuid.Parse(c.Params("id"))
+	if err != nil {
+		return response.BadRequest(c, "invalid resume id")
+	}
+
+	var req domain.ApplySuggestionsRequest
+	if err := bindAndValidate(c, &req); err != nil {
+		return validationFailed(c, err)
+	}
+
+	result, err := h.resumeService.ApplySuggestions(c.UserContext(), user.ID, id, &req)
+	if err != nil {
+		return respondError(c, err)
+	}
+
+	return response.Success(c, fiber.StatusOK, "suggestions applied", result)
+}
+
 func (h *ResumeHandler) DownloadPDF(c *fiber.Ctx) error {
 	user := middleware.GetUserFromContext(c)
 	if user == nil {
@@ -166,15 +380,14 @@ func (h *ResumeHandler) DownloadPDF(c *fiber.Ctx) error {
 		return response.BadRequest(c, "invalid resume id")
 	}
 
-	pdfBytes, err := h.resumeService.GeneratePDF(c.UserContext(), user.ID, id)
+	var opts domain.PDFStyleOptions
+	if err := bindQueryAndValidate(c, &opts); err != nil {
+		return validationFailed(c, err)
+	}
+
+	pdfBytes, err := h.resumeService.GeneratePDF(c.UserContext(), user.ID, id, &opts)
 	if err != nil {
-		if errors.Is(err, service.ErrResumeNotFound) {
-			return response.NotFound(c, "resume not found")
-		}
-		if errors.Is(err, service.ErrUnauthorized) {
-			return response.Forbidden(c, "unauthorized access to resume")
-		}
-		return response.InternalError(c, err.Error())
+		return respondError(c, err)
 	}
 
 	c.Set("Content-Type", "application/pdf")
@@ -182,43 +395,60 @@ func (h *ResumeHandler) DownloadPDF(c *fiber.Ctx) error {
 	return c.Send(pdfBytes)
 }
 
-func (h *ResumeHandler) GetQuota(c *fiber.Ctx) error {
+func (h *ResumeHandler) GetPDFLink(c *fiber.Ctx) error {
 	user := middleware.GetUserFromContext(c)
 	if user == nil {
 		return response.Unauthorized(c, "user not authenticated")
 	}
 
-	quota, err := h.quotaService.GetUserQuota(c.UserContext(), user.ID)
+	idParam := c.Params("id")
+	id, err := uuid.Parse(idParam)
 	if err != nil {
-		if errors.Is(err, service.ErrNoActiveSubscription) {
-			return response.Forbidden(c, "no active subscription found")
-		}
-		return response.InternalError(c, err.Error())
+		return response.BadRequest(c, "invalid resume id")
 	}
 
-	return response.Success(c, fiber.StatusOK, "quota retrieved", quota)
+	var opts domain.PDFStyleOptions
+	if err := bindQueryAndValidate(c, &opts); err != nil {
+		return validationFailed(c, err)
+	}
+
+	link, err := h.resumeService.GetPDFLink(c.UserContext(), user.ID, id, &opts)
+	if err != nil {
+		return respondError(c, err)
+	}
+
+	return response.Success(c, fiber.StatusOK, "resume pdf link created", link)
 }
 
-var resumeValidator = validator.New()
-
-func validateResumeRequest(req interface{}) error {
-	if err := resumeValidator.Struct(req); err != nil {
-		if validationErrors, ok := err.(validator.ValidationErrors); ok {
-			for _, e := range validationErrors {
-				field := e.Field()
-				switch e.Tag() {
-				case "required":
-					return fmt.Errorf("%s is required", field)
-				case "min":
-					return fmt.Errorf("%s must be at least %s characters", field, e.Param())
-				case "max":
-					return fmt.Errorf("%s must be at most %s characters", field, e.Param())
-				default:
-					return fmt.Errorf("%s is invalid", field)
-				}
-			}
-		}
-		return err
+func (h *ResumeHandler) Lint(c *fiber.Ctx) error {
+	user := middleware.GetUserFromContext(c)
+	if user == nil {
+		return response.Unauthorized(c, "user not authenticated")
+	}
+
+	id, err := uuid.Parse(c.Params("id"))
+	if err != nil {
+		return response.BadRequest(c, "invalid resume id")
+	}
+
+	report, err := h.lintService.Lint(c.UserContext(), user.ID, id)
+	if err != nil {
+		return respondError(c, err)
+	}
+
+	return response.Success(c, fiber.StatusOK, "resume lint completed", report)
+}
+
+func (h *ResumeHandler) GetQuota(c *fiber.Ctx) error {
+	user := middleware.GetUserFromContext(c)
+	if user == nil {
+		return response.Unauthorized(c, "user not authenticated")
 	}
-	return nil
+
+	quota, err := h.quotaService.GetUserQuota(c.UserContext(), user.ID)
+	if err != nil {
+		return respondError(c, err)
+	}
+
+	return response.Success(c, fiber.StatusOK, "quota retrieved", quota)
 }
@@ -0,0 +1,218 @@
+package handler
+
+import (
+	"github.com/raflytch/careerly-server/internal/domain"
+	"github.com/raflytch/careerly-server/internal/middleware"
+	"github.com/raflytch/careerly-server/pkg/response"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/google/uuid"
+)
+
+type ResumeLibraryHandler struct {
+	libraryService domain.ResumeLibraryService
+}
+
+func NewResumeLibraryHandler(libraryService domain.ResumeLibraryService) *ResumeLibraryHandler {
+	return &ResumeLibraryHandler{
+		libraryService: libraryService,
+	}
+}
+
+func (h *ResumeLibraryHandler) CreateTag(c *fiber.Ctx) error {
+	user := middleware.GetUserFromContext(c)
+	if user == nil {
+		return response.Unauthorized(c, "user not authenticated")
+	}
+
+	var req domain.ResumeTagRequest
+	if err := bindAndValidate(c, &req); err != nil {
+		return validationFailed(c, err)
+	}
+
+	tag, err := h.libraryService.CreateTag(c.UserContext(), user.ID, &req)
+	if err != nil {
+		return respondError(c, err)
+	}
+
+	return response.Success(c, fiber.StatusCreated, "tag created", tag)
+}
+
+func (h *ResumeLibraryHandler) GetTags(c *fiber.Ctx) error {
+	user := middleware.GetUserFromContext(c)
+	if user == nil {
+		return response.Unauthorized(c, "user not authenticated")
+	}
+
+	tags, err := h.libraryService.GetTags(c.UserContext(), user.ID)
+	if err != nil {
+		return respondError(c, err)
+	}
+
+	return response.Success(c, fiber.StatusOK, "tags retrieved", tags)
+}
+
+func (h *ResumeLibraryHandler) RenameTag(c *fiber.Ctx) error {
+	user := middleware.GetUserFromContext(c)
+	if user == nil {
+		return response.Unauthorized(c, "user not authenticated")
+	}
+
+	id, err := uuid.Parse(c.Params("id"))
+	if err != nil {
+		return response.BadRequest(c, "invalid tag id")
+	}
+
+	var req domain.ResumeTagRequest
+	if err := bindAndValidate(c, &req); err != nil {
+		return validationFailed(c, err)
+	}
+
+	tag, err := h.libraryService.RenameTag(c.UserContext(), user.ID, id, &req)
+	if err != nil {
+		return respondError(c, err)
+	}
+
+	return response.Success(c, fiber.StatusOK, "tag renamed", tag)
+}
+
+func (h *ResumeLibraryHandler) DeleteTag(c *fiber.Ctx) error {
+	user := middleware.GetUserFromContext(c)
+	if user == nil {
+		return response.Unauthorized(c, "user not authenticated")
+	}
+
+	id, err := uuid.Parse(c.Params("id"))
+	if err != nil {
+		return response.BadRequest(c, "invalid tag id")
+	}
+
+	if err := h.libraryService.DeleteTag(c.UserContext(), user.ID, id); err != nil {
+		return respondError(c, err)
+	}
+
+	return response.Success(c, fiber.StatusOK, "tag deleted", nil)
+}
+
+func (h *ResumeLibraryHandler) SetResumeTags(c *fiber.Ctx) error {
+	user := middleware.GetUserFromContext(c)
+	if user == nil {
+		return response.Unauthorized(c, "user not authenticated")
+	}
+
+	id, err := uuid.Parse(c.Params("id"))
+	if err != nil {
+		return response.BadRequest(c, "invalid resume id")
+	}
+
+	var req domain.SetResumeTagsRequest
+	if err := bindAndValidate(c, &req); err != nil {
+		return validationFailed(c, err)
+	}
+
+	resume, err := h.libraryService.SetResumeTags(c.UserContext(), user.ID, id, &req)
+	if err != nil {
+		return respondError(c, err)
+	}
+
+	return response.Success(c, fiber.StatusOK, "resume tags updated", resume)
+}
+
+func (h *ResumeLibraryHandler) CreateFolder(c *fiber.Ctx) error {
+	user := middleware.GetUserFromContext(c)
+	if user == nil {
+		return response.Unauthorized(c, "user not authenticated")
+	}
+
+	var req domain.ResumeFolderRequest
+	if err := bindAndValidate(c, &req); err != nil {
+		return validationFailed(c, err)
+	}
+
+	folder, err := h.libraryService.CreateFolder(c.UserContext(), user.ID, &req)
+	if err != nil {
+		return respondError(c, err)
+	}
+
+	return response.Success(c, fiber.StatusCreated, "folder created", folder)
+}
+
+func (h *ResumeLibraryHandler) GetFolders(c *fiber.Ctx) error {
+	user := middleware.GetUserFromContext(c)
+	if user == nil {
+		return response.Unauthorized(c, "user not authenticated")
+	}
+
+	folders, err := h.libraryService.GetFolders(c.UserContext(), user.ID)
+	if err != nil {
+		return respondError(c, err)
+	}
+
+	return response.Success(c, fiber.StatusOK, "folders retrieved", folders)
+}
+
+func (h *ResumeLibraryHandler) RenameFolder(c *fiber.Ctx) error {
+	user := middleware.GetUserFromContext(c)
+	if user == nil {
+		return response.Unauthorized(c, "user not authenticated")
+	}
+
+	id, err := uuid.Parse(c.Params("id"))
+	if err != nil {
+		return response.BadRequest(c, "invalid folder id")
+	}
+
+	var req domain.ResumeFolderRequest
+	if err := bindAndValidate(c, &req); err != nil {
+		return validationFailed(c, err)
+	}
+
+	folder, err := h.libraryService.RenameFolder(c.UserContext(), user.ID, id, &req)
+	if err != nil {
+		return respondError(c, err)
+	}
+
+	return response.Success(c, fiber.StatusOK, "folder renamed", folder)
+}
+
+func (h *ResumeLibraryHandler) DeleteFolder(c *fiber.Ctx) error {
+	user := middleware.GetUserFromContext(c)
+	if user == nil {
+		return response.Unauthorized(c, "user not authenticated")
+	}
+
+	id, err := uuid.Parse(c.Params("id"))
+	if err != nil {
+		return response.BadRequest(c, "invalid folder id")
+	}
+
+	if err := h.libraryService.DeleteFolder(c.UserContext(), user.ID, id); err != nil {
+		return respondError(c, err)
+	}
+
+	return response.Success(c, fiber.StatusOK, "folder deleted", nil)
+}
+
+func (h *ResumeLibraryHandler) MoveResume(c *fiber.Ctx) error {
+	user := middleware.GetUserFromContext(c)
+	if user == nil {
+		return response.Unauthorized(c, "user not authenticated")
+	}
+
+	id, err := uuid.Parse(c.Params("id"))
+	if err != nil {
+		return response.BadRequest(c, "invalid resume id")
+	}
+
+	var req domain.MoveResumeRequest
+	if err := bindAndValidate(c, &req); err != nil {
+		return validationFailed(c, err)
+	}
+
+	resume, err := h.libraryService.MoveResume(c.UserContext(), user.ID, id, &req)
+	if err != nil {
+		return respondError(c, err)
+	}
+
+	return response.Success(c, fiber.StatusOK, "resume moved", resume)
+}
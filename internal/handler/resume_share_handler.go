@@ -0,0 +1,182 @@
+package handler
+
+import (
+	"github.com/raflytch/careerly-server/internal/domain"
+	"github.com/raflytch/careerly-server/internal/middleware"
+	"github.com/raflytch/careerly-server/pkg/response"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/google/uuid"
+)
+
+type ResumeShareHandler struct {
+	shareService domain.ResumeShareService
+}
+
+func NewResumeShareHandler(shareService domain.ResumeShareService) *ResumeShareHandler {
+	return &ResumeShareHandler{
+		shareService: shareService,
+	}
+}
+
+func (h *ResumeShareHandler) Create(c *fiber.Ctx) error {
+	user := middleware.GetUserFromContext(c)
+	if user == nil {
+		return response.Unauthorized(c, "user not authenticated")
+	}
+
+	id, err := uuid.Parse(c.Params("id"))
+	if err != nil {
+		return response.BadRequest(c, "invalid resume id")
+	}
+
+	var req domain.CreateResumeShareRequest
+	if len(c.Body()) > 0 {
+		if err := bindAndValidate(c, &req); err != nil {
+			return validationFailed(c, err)
+		}
+	}
+
+	result, err := h.shareService.Create(c.UserContext(), user.ID, id, &req)
+	if err != nil {
+		return respondError(c, err)
+	}
+
+	return response.Success(c, fiber.StatusCreated, "share link created", result)
+}
+
+func (h *ResumeShareHandler) Revoke(c *fiber.Ctx) error {
+	user := middleware.GetUserFromContext(c)
+	if user == nil {
+		return response.Unauthorized(c, "user not authenticated")
+	}
+
+	id, err := uuid.Parse(c.Params("id"))
+	if err != nil {
+		return response.BadRequest(c, "invalid resume id")
+	}
+
+	shareID, err := uuid.Parse(c.Params("shareId"))
+	if err != nil {
+		return response.BadRequest(c, "invalid share id")
+	}
+
+	if err := h.shareService.Revoke(c.UserContext(), user.ID, id, shareID); err != nil {
+		return respondError(c, err)
+	}
+
+	return response.Success(c, fiber.StatusOK, "share link revoked", nil)
+}
+
+func (h *ResumeShareHandler) GetComments(c *fiber.Ctx) error {
+	user := middleware.GetUserFromContext(c)
+	if user == nil {
+		return response.Unauthorized(c, "user not authenticated")
+	}
+
+	id, err := uuid.Parse(c.Params("id"))
+	if err != nil {
+		return response.BadRequest(c, "invalid resume id")
+	}
+
+	var filter domain.ResumeCommentFilter
+	if err := bindQueryAndValidate(c, &filter); err != nil {
+		return validationFailed(c, err)
+	}
+
+	comments, err := h.shareService.GetComments(c.UserContext(), user.ID, id, &filter)
+	if err != nil {
+		return respondError(c, err)
+	}
+
+	return response.Success(c, fiber.StatusOK, "resume comments retrieved", comments)
+}
+
+func (h *ResumeShareHandler) ResolveComment(c *fiber.Ctx) error {
+	user := middleware.GetUserFromContext(c)
+	if user == nil {
+		return response.Unauthorized(c, "user not authenticated")
+	}
+
+	id, err := uuid.Parse(c.Params("id"))
+	if err != nil {
+		return response.BadRequest(c, "invalid resume id")
+	}
+
+	commentID, err := uuid.Parse(c.Params("commentId"))
+	if err != nil {
+		return response.BadRequest(c, "invalid comment id")
+	}
+
+	var req domain.ResolveResumeCommentRequest
+	if err := bindAndValidate(c, &req); err != nil {
+		return validationFailed(c, err)
+	}
+
+	comment, err := h.shareService.ResolveComment(c.UserContext(), user.ID, id, commentID, *req.Resolved)
+	if err != nil {
+		return respondError(c, err)
+	}
+
+	return response.Success(c, fiber.StatusOK, "comment updated", comment)
+}
+
+func (h *ResumeShareHandler) GetSharedResume(c *fiber.Ctx) error {
+	shared, err := h.shareService.GetSharedResume(c.UserContext(), c.Params("token"), shareVisit(c))
+	if err != nil {
+		return respondError(c, err)
+	}
+
+	return response.Success(c, fiber.StatusOK, "shared resume retrieved", shared)
+}
+
+func (h *ResumeShareHandler) DownloadSharedPDF(c *fiber.Ctx) error {
+	pdfBytes, err := h.shareService.GetSharedPDF(c.UserContext(), c.Params("token"), shareVisit(c))
+	if err != nil {
+		return respondError(c, err)
+	}
+
+	c.Set("Content-Type", "application/pdf")
+	c.Set("Content-Disposition", "attachment; filename=resume.pdf")
+	return c.Send(pdfBytes)
+}
+
+func (h *ResumeShareHandler) GetAnalytics(c *fiber.Ctx) error {
+	user := middleware.GetUserFromContext(c)
+	if user == nil {
+		return response.Unauthorized(c, "user not authenticated")
+	}
+
+	id, err := uuid.Parse(c.Params("id"))
+	if err != nil {
+		return response.BadRequest(c, "invalid resume id")
+	}
+
+	analytics, err := h.shareService.GetAnalytics(c.UserContext(), user.ID, id)
+	if err != nil {
+		return respondError(c, err)
+	}
+
+	return response.Success(c, fiber.StatusOK, "resume analytics retrieved", analytics)
+}
+
+func shareVisit(c *fiber.Ctx) *domain.ShareVisit {
+	return &domain.ShareVisit{
+		Referrer: c.Get(fiber.HeaderReferer),
+		Country:  middleware.GetCountryFromContext(c),
+	}
+}
+
+func (h *ResumeShareHandler) AddComment(c *fiber.Ctx) error {
+	var req domain.ResumeCommentRequest
+	if err := bindAndValidate(c, &req); err != nil {
+		return validationFailed(c, err)
+	}
+
+	comment, err := h.shareService.AddComment(c.UserContext(), c.Params("token"), &req)
+	if err != nil {
+		return respondError(c, err)
+	}
+
+	return response.Success(c, fiber.StatusCreated, "comment added", comment)
+}
@@ -0,0 +1,88 @@
+package handler
+
+import (
+	"sort"
+
+	"github.com/raflytch/careerly-server/internal/domain"
+	"github.com/raflytch/careerly-server/pkg/jsonschema"
+	"github.com/raflytch/careerly-server/pkg/response"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+type SchemaHandler struct {
+	resources map[string]map[string]interface{}
+}
+
+func NewSchemaHandler() *SchemaHandler {
+	return &SchemaHandler{
+		resources: map[string]map[string]interface{}{
+			"resumes": {
+				"create":            domain.CreateResumeRequest{},
+				"update":            domain.UpdateResumeRequest{},
+				"photo":             domain.ResumePhotoVisibilityRequest{},
+				"optimize":          domain.OptimizeResumeRequest{},
+				"apply_suggestions": domain.ApplySuggestionsRequest{},
+			},
+			"interviews": {
+				"create":   domain.CreateInterviewRequest{},
+				"schedule": domain.ScheduleInterviewRequest{},
+				"submit":   domain.SubmitAnswerRequest{},
+				"share":    domain.CreateInterviewShareRequest{},
+				"comment":  domain.MentorCommentRequest{},
+			},
+			"plans": {
+				"create": domain.CreatePlanRequest{},
+				"update": domain.UpdatePlanRequest{},
+			},
+			"transactions": {
+				"create": domain.CreateTransactionRequest{},
+			},
+			"otp": {
+				"request": domain.OTPRequest{},
+				"verify":  domain.OTPVerifyRequest{},
+			},
+			"users": {
+				"update": UpdateUserRequest{},
+				"2fa":    domain.TwoFactorSettingRequest{},
+			},
+			"auth": {
+				"2fa_verify": domain.TwoFactorVerifyRequest{},
+				"2fa_resend": domain.TwoFactorResendRequest{},
+			},
+		},
+	}
+}
+
+type resourceSchema struct {
+	Resource string                        `json:"resource"`
+	Schemas  map[string]*jsonschema.Schema `json:"schemas"`
+}
+
+func (h *SchemaHandler) ListResources(c *fiber.Ctx) error {
+	names := make([]string, 0, len(h.resources))
+	for name := range h.resources {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	return response.Success(c, fiber.StatusOK, "schema resources retrieved", names)
+}
+
+func (h *SchemaHandler) GetSchema(c *fiber.Ctx) error {
+	resource := c.Params("resource")
+	payloads, ok := h.resources[resource]
+	if !ok {
+		return response.NotFound(c, "schema resource not found")
+	}
+
+	result := resourceSchema{
+		Resource: resource,
+		Schemas:  make(map[string]*jsonschema.Schema, len(payloads)),
+	}
+	for action, payload := range payloads {
+		result.Schemas[action] = jsonschema.Generate(resource+"."+action, payload)
+	}
+
+	return response.Success(c, fiber.StatusOK, "schema retrieved", result)
+}
@@ -0,0 +1,119 @@
+package handler
+
+import (
+	"errors"
+
+	"github.com/raflytch/careerly-server/internal/domain"
+	"github.com/raflytch/careerly-server/internal/middleware"
+	"github.com/raflytch/careerly-server/internal/service"
+	"github.com/raflytch/careerly-server/pkg/response"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/google/uuid"
+)
+
+type SubscriptionHandler struct {
+	subscriptionService domain.SubscriptionService
+}
+
+func NewSubscriptionHandler(subscriptionService domain.SubscriptionService) *SubscriptionHandler {
+	return &SubscriptionHandler{
+		subscriptionService: subscriptionService,
+	}
+}
+
+func (h *SubscriptionHandler) ScheduleChange(c *fiber.Ctx) error {
+	user := middleware.GetUserFromContext(c)
+	if user == nil {
+		return response.Unauthorized(c, "user not authenticated")
+	}
+
+	var req domain.ScheduleChangeRequest
+	if err := bindAndValidate(c, &req); err != nil {
+		return validationFailed(c, err)
+	}
+
+	subscription, err := h.subscriptionService.ScheduleChange(c.UserContext(), user.ID, &req)
+	if err != nil {
+		if errors.Is(err, service.ErrNoActiveSubscription) {
+			return respondErrorStatus(c, fiber.StatusNotFound, err)
+		}
+		return respondError(c, err)
+	}
+
+	return response.Success(c, fiber.StatusOK, "plan change scheduled", subscription)
+}
+
+func (h *SubscriptionHandler) CancelScheduledChange(c *fiber.Ctx) error {
+	user := middleware.GetUserFromContext(c)
+	if user == nil {
+		return response.Unauthorized(c, "user not authenticated")
+	}
+
+	subscription, err := h.subscriptionService.CancelScheduledChange(c.UserContext(), user.ID)
+	if err != nil {
+		if errors.Is(err, service.ErrNoActiveSubscription) {
+			return respondErrorStatus(c, fiber.StatusNotFound, err)
+		}
+		return respondError(c, err)
+	}
+
+	return response.Success(c, fiber.StatusOK, "scheduled plan change canceled", subscription)
+}
+
+func (h *SubscriptionHandler) RedeemGift(c *fiber.Ctx) error {
+	user := middleware.GetUserFromContext(c)
+	if user == nil {
+		return response.Unauthorized(c, "user not authenticated")
+	}
+
+	var req domain.RedeemGiftRequest
+	if err := bindAndValidate(c, &req); err != nil {
+		return validationFailed(c, err)
+	}
+
+	subscription, err := h.subscriptionService.RedeemGift(c.UserContext(), user.ID, &req)
+	if err != nil {
+		return respondError(c, err)
+	}
+
+	return response.Success(c, fiber.StatusOK, "gift redeemed", subscription)
+}
+
+func (h *SubscriptionHandler) GetGifts(c *fiber.Ctx) error {
+	user := middleware.GetUserFromContext(c)
+	if user == nil {
+		return response.Unauthorized(c, "user not authenticated")
+	}
+
+	gifts, err := h.subscriptionService.GetPurchasedGifts(c.UserContext(), user.ID)
+	if err != nil {
+		return respondError(c, err)
+	}
+
+	return response.Success(c, fiber.StatusOK, "gifts retrieved", gifts)
+}
+
+func (h *SubscriptionHandler) Grant(c *fiber.Ctx) error {
+	admin := middleware.GetUserFromContext(c)
+	if admin == nil {
+		return response.Unauthorized(c, "user not authenticated")
+	}
+
+	userID, err := uuid.Parse(c.Params("id"))
+	if err != nil {
+		return response.BadRequest(c, "invalid user id")
+	}
+
+	var req domain.GrantSubscriptionRequest
+	if err := bindAndValidate(c, &req); err != nil {
+		return validationFailed(c, err)
+	}
+
+	grant, err := h.subscriptionService.Grant(c.UserContext(), admin.ID, userID, &req)
+	if err != nil {
+		return respondError(c, err)
+	}
+
+	return response.Success(c, fiber.StatusCreated, "subscription granted", grant)
+}
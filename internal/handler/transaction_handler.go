@@ -3,6 +3,7 @@ package handler
 import (
 	"errors"
 	"log"
+	"log/slog"
 
 	"github.com/raflytch/careerly-server/internal/domain"
 	"github.com/raflytch/careerly-server/internal/middleware"
@@ -30,29 +31,82 @@ func (h *TransactionHandler) CreateTransaction(c *fiber.Ctx) error {
 	}
 
 	var req domain.CreateTransactionRequest
-	if err := c.BodyParser(&req); err != nil {
-		return response.BadRequest(c, "invalid request body")
+	if err := bindAndValidate(c, &req); err != nil {
+		return validationFailed(c, err)
 	}
 
-	if req.PlanID == uuid.Nil {
-		return response.BadRequest(c, "plan_id is required")
+	result, err := h.transactionService.CreateTransaction(c.UserContext(), user.ID, &req)
+	if err != nil {
+		return respondError(c, err)
 	}
 
-	result, err := h.transactionService.CreateTransaction(c.UserContext(), user.ID, &req)
+	return response.Success(c, fiber.StatusCreated, "transaction created, redirect to payment page", result)
+}
+
+func (h *TransactionHandler) CreateAddonTransaction(c *fiber.Ctx) error {
+	user := middleware.GetUserFromContext(c)
+	if user == nil {
+		return response.Unauthorized(c, "unauthorized")
+	}
+
+	var req domain.CreateAddonTransactionRequest
+	if err := bindAndValidate(c, &req); err != nil {
+		return validationFailed(c, err)
+	}
+
+	result, err := h.transactionService.CreateAddonTransaction(c.UserContext(), user.ID, &req)
 	if err != nil {
-		switch {
-		case errors.Is(err, service.ErrPlanNotAvailable):
-			return response.BadRequest(c, "plan is not available for purchase")
-		case errors.Is(err, service.ErrActiveSubscriptionExists):
-			return response.BadRequest(c, "you already have an active subscription for this plan")
-		default:
-			return response.InternalError(c, err.Error())
-		}
+		return respondError(c, err)
+	}
+
+	return response.Success(c, fiber.StatusCreated, "transaction created, redirect to payment page", result)
+}
+
+func (h *TransactionHandler) CreateGiftTransaction(c *fiber.Ctx) error {
+	user := middleware.GetUserFromContext(c)
+	if user == nil {
+		return response.Unauthorized(c, "unauthorized")
+	}
+
+	var req domain.CreateGiftTransactionRequest
+	if err := bindAndValidate(c, &req); err != nil {
+		return validationFailed(c, err)
+	}
+
+	result, err := h.transactionService.CreateGiftTransaction(c.UserContext(), user.ID, &req)
+	if err != nil {
+		return respondError(c, err)
 	}
 
 	return response.Success(c, fiber.StatusCreated, "transaction created, redirect to payment page", result)
 }
 
+// ChargeSavedCard buys a plan with a saved card. A settled charge returns
+// 201 with the paid transaction; one that needs 3D Secure returns 202 with
+// the redirect URL.
+func (h *TransactionHandler) ChargeSavedCard(c *fiber.Ctx) error {
+	user := middleware.GetUserFromContext(c)
+	if user == nil {
+		return response.Unauthorized(c, "unauthorized")
+	}
+
+	var req domain.ChargeSavedCardRequest
+	if err := bindAndValidate(c, &req); err != nil {
+		return validationFailed(c, err)
+	}
+
+	result, err := h.transactionService.ChargeSavedCard(c.UserContext(), user.ID, &req)
+	if err != nil {
+		return respondError(c, err)
+	}
+
+	if result.Transaction.Status != domain.TransactionStatusSuccess {
+		return response.Success(c, fiber.StatusAccepted, "card verification required, redirect to complete payment", result)
+	}
+
+	return response.Success(c, fiber.StatusCreated, "payment successful", result)
+}
+
 func (h *TransactionHandler) GetTransaction(c *fiber.Ctx) error {
 	user := middleware.GetUserFromContext(c)
 	if user == nil {
@@ -67,10 +121,7 @@ func (h *TransactionHandler) GetTransaction(c *fiber.Ctx) error {
 
 	transaction, err := h.transactionService.GetByID(c.UserContext(), user.ID, id)
 	if err != nil {
-		if errors.Is(err, service.ErrTransactionNotFound) {
-			return response.NotFound(c, "transaction not found")
-		}
-		return response.InternalError(c, err.Error())
+		return respondError(c, err)
 	}
 
 	return response.Success(c, fiber.StatusOK, "transaction retrieved", transaction)
@@ -87,7 +138,7 @@ func (h *TransactionHandler) GetUserTransactions(c *fiber.Ctx) error {
 
 	result, err := h.transactionService.GetUserTransactions(c.UserContext(), user.ID, page, limit)
 	if err != nil {
-		return response.InternalError(c, err.Error())
+		return respondError(c, err)
 	}
 
 	return response.Success(c, fiber.StatusOK, "transactions retrieved", result)
@@ -107,15 +158,12 @@ func (h *TransactionHandler) CheckTransactionStatus(c *fiber.Ctx) error {
 
 	transaction, err := h.transactionService.GetByID(c.UserContext(), user.ID, id)
 	if err != nil {
-		if errors.Is(err, service.ErrTransactionNotFound) {
-			return response.NotFound(c, "transaction not found")
-		}
-		return response.InternalError(c, err.Error())
+		return respondError(c, err)
 	}
 
 	updated, err := h.transactionService.CheckTransactionStatus(c.UserContext(), transaction.OrderID)
 	if err != nil {
-		return response.InternalError(c, err.Error())
+		return respondError(c, err)
 	}
 
 	return response.Success(c, fiber.StatusOK, "transaction status updated", updated)
@@ -144,18 +192,39 @@ func (h *TransactionHandler) MidtransWebhook(c *fiber.Ctx) error {
 
 		switch {
 		case errors.Is(err, service.ErrInvalidSignature):
-			log.Printf("[WEBHOOK] Invalid signature for order %s", orderID)
-			return response.Unauthorized(c, "invalid signature")
-		case errors.Is(err, service.ErrTransactionNotFound):
-			log.Printf("[WEBHOOK] Order not found in database: %s", orderID)
-			return c.Status(fiber.StatusOK).JSON(fiber.Map{"status": "ignored", "message": "order not found"})
+			logRejectedWebhook(c, orderID, "invalid_signature")
+			return respondError(c, err)
+		case errors.Is(err, service.ErrMissingSignature):
+			logRejectedWebhook(c, orderID, "missing_signature")
+			return respondError(c, err)
+		case errors.Is(err, service.ErrStaleNotification):
+			logRejectedWebhook(c, orderID, "stale")
+			return respondError(c, err)
+		case errors.Is(err, service.ErrDuplicateNotification):
+			logRejectedWebhook(c, orderID, "replayed")
+			return c.Status(fiber.StatusOK).JSON(fiber.Map{"status": "ignored", "message": "duplicate notification"})
+		case errors.Is(err, service.ErrNotificationNotQueued):
+			// Non-2xx makes Midtrans redeliver the notification later
+			return respondError(c, err)
 		default:
 			log.Printf("[WEBHOOK] Internal error for order %s: %v", orderID, err)
 			return c.Status(fiber.StatusOK).JSON(fiber.Map{"status": "error", "message": err.Error()})
 		}
 	}
 
-	log.Printf("[WEBHOOK] Successfully processed order %s", orderID)
+	log.Printf("[WEBHOOK] Queued notification for order %s", orderID)
 	return c.Status(fiber.StatusOK).JSON(fiber.Map{"status": "ok"})
 }
 
+// logRejectedWebhook writes a structured security event for a refused
+// notification, so it can be alerted on apart from the request log.
+func logRejectedWebhook(c *fiber.Ctx, orderID, reason string) {
+	slog.Warn("webhook rejected",
+		"event", "security.webhook_rejected",
+		"provider", "midtrans",
+		"reason", reason,
+		"order_id", orderID,
+		"ip", c.IP(),
+		"user_agent", c.Get(fiber.HeaderUserAgent),
+	)
+}
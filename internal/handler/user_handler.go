@@ -1,11 +1,13 @@
 package handler
 
 import (
-	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
 
 	"github.com/raflytch/careerly-server/internal/domain"
 	"github.com/raflytch/careerly-server/internal/middleware"
-	"github.com/raflytch/careerly-server/internal/service"
 	"github.com/raflytch/careerly-server/pkg/imagekit"
 	"github.com/raflytch/careerly-server/pkg/response"
 
@@ -14,19 +16,27 @@ import (
 )
 
 type UserHandler struct {
-	userService    domain.UserService
-	imagekitClient *imagekit.Client
+	userService         domain.UserService
+	completenessService domain.CompletenessService
+	onboardingService   domain.OnboardingService
+	activityService     domain.ActivityService
+	sessionService      domain.SessionService
+	imagekitClient      *imagekit.Client
 }
 
-func NewUserHandler(userService domain.UserService, imagekitClient *imagekit.Client) *UserHandler {
+func NewUserHandler(userService domain.UserService, completenessService domain.CompletenessService, onboardingService domain.OnboardingService, activityService domain.ActivityService, sessionService domain.SessionService, imagekitClient *imagekit.Client) *UserHandler {
 	return &UserHandler{
-		userService:    userService,
-		imagekitClient: imagekitClient,
+		userService:         userService,
+		completenessService: completenessService,
+		onboardingService:   onboardingService,
+		activityService:     activityService,
+		sessionService:      sessionService,
+		imagekitClient:      imagekitClient,
 	}
 }
 
 type UpdateUserRequest struct {
-	Name string `json:"name"`
+	Name string `json:"name" validate:"required,min=1,max=255"`
 }
 
 func (h *UserHandler) GetProfile(c *fiber.Ctx) error {
@@ -37,7 +47,7 @@ func (h *UserHandler) GetProfile(c *fiber.Ctx) error {
 
 	profile, err := h.userService.GetProfile(c.UserContext(), user.ID)
 	if err != nil {
-		return response.InternalError(c, err.Error())
+		return respondError(c, err)
 	}
 
 	return response.Success(c, fiber.StatusOK, "profile retrieved", profile)
@@ -52,10 +62,7 @@ func (h *UserHandler) GetByID(c *fiber.Ctx) error {
 
 	user, err := h.userService.GetByID(c.UserContext(), id)
 	if err != nil {
-		if errors.Is(err, domain.ErrUserNotFound) {
-			return response.NotFound(c, "user not found")
-		}
-		return response.InternalError(c, err.Error())
+		return respondError(c, err)
 	}
 
 	return response.Success(c, fiber.StatusOK, "user retrieved", user)
@@ -65,14 +72,67 @@ func (h *UserHandler) GetAll(c *fiber.Ctx) error {
 	page := c.QueryInt("page", 1)
 	limit := c.QueryInt("limit", 10)
 
-	result, err := h.userService.GetAll(c.UserContext(), page, limit)
+	filter := domain.UserFilter{
+		Query: strings.TrimSpace(c.Query("q")),
+		Role:  domain.Role(c.Query("role")),
+	}
+
+	if filter.Role != "" && filter.Role != domain.RoleUser && filter.Role != domain.RoleAdmin {
+		return response.BadRequest(c, "role must be user or admin")
+	}
+	if raw := c.Query("is_active"); raw != "" {
+		isActive, err := strconv.ParseBool(raw)
+		if err != nil {
+			return response.BadRequest(c, "is_active must be true or false")
+		}
+		filter.IsActive = &isActive
+	}
+	if raw := c.Query("plan_id"); raw != "" {
+		planID, err := uuid.Parse(raw)
+		if err != nil {
+			return response.BadRequest(c, "invalid plan id")
+		}
+		filter.PlanID = &planID
+	}
+
+	var err error
+	if filter.CreatedFrom, filter.CreatedTo, err = parseDateRange(c, "created_from", "created_to"); err != nil {
+		return response.BadRequest(c, err.Error())
+	}
+	if filter.LastLoginFrom, filter.LastLoginTo, err = parseDateRange(c, "last_login_from", "last_login_to"); err != nil {
+		return response.BadRequest(c, err.Error())
+	}
+
+	result, err := h.userService.GetAll(c.UserContext(), filter, page, limit)
 	if err != nil {
-		return response.InternalError(c, err.Error())
+		return respondError(c, err)
 	}
 
 	return response.Success(c, fiber.StatusOK, "users retrieved", result)
 }
 
+// parseDateRange reads a YYYY-MM-DD range from two query params, returning
+// the end as the start of the following day so the range includes it.
+func parseDateRange(c *fiber.Ctx, fromParam, toParam string) (*time.Time, *time.Time, error) {
+	var from, to *time.Time
+	if raw := c.Query(fromParam); raw != "" {
+		parsed, err := time.Parse(reportDateLayout, raw)
+		if err != nil {
+			return nil, nil, fmt.Errorf("%s must be in YYYY-MM-DD format", fromParam)
+		}
+		from = &parsed
+	}
+	if raw := c.Query(toParam); raw != "" {
+		parsed, err := time.Parse(reportDateLayout, raw)
+		if err != nil {
+			return nil, nil, fmt.Errorf("%s must be in YYYY-MM-DD format", toParam)
+		}
+		parsed = parsed.AddDate(0, 0, 1)
+		to = &parsed
+	}
+	return from, to, nil
+}
+
 func (h *UserHandler) Update(c *fiber.Ctx) error {
 	user := middleware.GetUserFromContext(c)
 	if user == nil {
@@ -98,30 +158,20 @@ func (h *UserHandler) Update(c *fiber.Ctx) error {
 
 		updatedUser, err := h.userService.UpdateAvatar(c.UserContext(), user.ID, uploadResult.URL)
 		if err != nil {
-			if errors.Is(err, domain.ErrUserNotFound) {
-				return response.NotFound(c, "user not found")
-			}
-			return response.InternalError(c, err.Error())
+			return respondError(c, err)
 		}
 
 		return response.Success(c, fiber.StatusOK, "avatar updated", updatedUser)
 	}
 
 	var req UpdateUserRequest
-	if err := c.BodyParser(&req); err != nil {
-		return response.BadRequest(c, "invalid request body")
-	}
-
-	if req.Name == "" {
-		return response.BadRequest(c, "name is required")
+	if err := bindAndValidate(c, &req); err != nil {
+		return validationFailed(c, err)
 	}
 
 	updatedUser, err := h.userService.Update(c.UserContext(), user.ID, req.Name)
 	if err != nil {
-		if errors.Is(err, domain.ErrUserNotFound) {
-			return response.NotFound(c, "user not found")
-		}
-		return response.InternalError(c, err.Error())
+		return respondError(c, err)
 	}
 
 	return response.Success(c, fiber.StatusOK, "user updated", updatedUser)
@@ -145,13 +195,7 @@ func (h *UserHandler) Delete(c *fiber.Ctx) error {
 
 	err = h.userService.Delete(c.UserContext(), id, currentUser.Role)
 	if err != nil {
-		if errors.Is(err, domain.ErrUserNotFound) {
-			return response.NotFound(c, "user not found")
-		}
-		if errors.Is(err, service.ErrForbiddenAction) {
-			return response.Forbidden(c, "only admin can delete users")
-		}
-		return response.InternalError(c, err.Error())
+		return respondError(c, err)
 	}
 
 	return response.Success(c, fiber.StatusOK, "user deleted", nil)
@@ -165,14 +209,7 @@ func (h *UserHandler) RequestDeleteOTP(c *fiber.Ctx) error {
 
 	otpResponse, err := h.userService.RequestDeleteOTP(c.UserContext(), user)
 	if err != nil {
-		switch {
-		case errors.Is(err, domain.ErrCannotDeleteAdmin):
-			return response.Forbidden(c, err.Error())
-		case errors.Is(err, domain.ErrOTPAlreadySent):
-			return response.Error(c, fiber.StatusTooManyRequests, err.Error())
-		default:
-			return response.InternalError(c, err.Error())
-		}
+		return respondError(c, err)
 	}
 
 	return response.Success(c, fiber.StatusOK, "OTP sent successfully", otpResponse)
@@ -184,25 +221,14 @@ func (h *UserHandler) VerifyDeleteOTP(c *fiber.Ctx) error {
 		return response.Unauthorized(c, "user not authenticated")
 	}
 
-	var req domain.OTPVerifyRequest
-	if err := c.BodyParser(&req); err != nil {
-		return response.BadRequest(c, "invalid request body")
-	}
-
-	if req.OTP == "" || len(req.OTP) != 6 {
-		return response.BadRequest(c, "OTP must be 6 digits")
+	var req domain.DeleteOTPVerifyRequest
+	if err := bindAndValidate(c, &req); err != nil {
+		return validationFailed(c, err)
 	}
 
 	deleteResponse, err := h.userService.VerifyDeleteOTP(c.UserContext(), user, req.OTP)
 	if err != nil {
-		switch {
-		case errors.Is(err, domain.ErrInvalidOTP):
-			return response.BadRequest(c, err.Error())
-		case errors.Is(err, domain.ErrCannotDeleteAdmin):
-			return response.Forbidden(c, err.Error())
-		default:
-			return response.InternalError(c, err.Error())
-		}
+		return respondError(c, err)
 	}
 
 	return response.Success(c, fiber.StatusOK, "account deleted successfully", deleteResponse)
@@ -216,13 +242,175 @@ func (h *UserHandler) ResendDeleteOTP(c *fiber.Ctx) error {
 
 	otpResponse, err := h.userService.ResendDeleteOTP(c.UserContext(), user)
 	if err != nil {
-		switch {
-		case errors.Is(err, domain.ErrCannotDeleteAdmin):
-			return response.Forbidden(c, err.Error())
-		default:
-			return response.InternalError(c, err.Error())
-		}
+		return respondError(c, err)
 	}
 
 	return response.Success(c, fiber.StatusOK, "OTP resent successfully", otpResponse)
 }
+
+func (h *UserHandler) UpdateTwoFactor(c *fiber.Ctx) error {
+	user := middleware.GetUserFromContext(c)
+	if user == nil {
+		return response.Unauthorized(c, "user not authenticated")
+	}
+
+	var req domain.TwoFactorSettingRequest
+	if err := bindAndValidate(c, &req); err != nil {
+		return validationFailed(c, err)
+	}
+
+	updatedUser, err := h.userService.SetTwoFactor(c.UserContext(), user.ID, *req.Enabled)
+	if err != nil {
+		return respondError(c, err)
+	}
+
+	return response.Success(c, fiber.StatusOK, "two-factor setting updated", updatedUser)
+}
+
+func (h *UserHandler) UpdateTimezone(c *fiber.Ctx) error {
+	user := middleware.GetUserFromContext(c)
+	if user == nil {
+		return response.Unauthorized(c, "user not authenticated")
+	}
+
+	var req domain.TimezoneSettingRequest
+	if err := bindAndValidate(c, &req); err != nil {
+		return validationFailed(c, err)
+	}
+
+	updatedUser, err := h.userService.SetTimezone(c.UserContext(), user.ID, req.Timezone)
+	if err != nil {
+		return respondError(c, err)
+	}
+
+	return response.Success(c, fiber.StatusOK, "timezone updated", updatedUser)
+}
+
+func (h *UserHandler) RequestContactEmailChange(c *fiber.Ctx) error {
+	user := middleware.GetUserFromContext(c)
+	if user == nil {
+		return response.Unauthorized(c, "user not authenticated")
+	}
+
+	var req domain.ContactEmailChangeRequest
+	if err := bindAndValidate(c, &req); err != nil {
+		return validationFailed(c, err)
+	}
+
+	otpResponse, err := h.userService.RequestContactEmailChange(c.UserContext(), user, req.Email)
+	if err != nil {
+		return respondError(c, err)
+	}
+
+	return response.Success(c, fiber.StatusOK, "OTP sent successfully", otpResponse)
+}
+
+func (h *UserHandler) VerifyContactEmailChange(c *fiber.Ctx) error {
+	user := middleware.GetUserFromContext(c)
+	if user == nil {
+		return response.Unauthorized(c, "user not authenticated")
+	}
+
+	var req domain.ContactEmailVerifyRequest
+	if err := bindAndValidate(c, &req); err != nil {
+		return validationFailed(c, err)
+	}
+
+	updatedUser, err := h.userService.VerifyContactEmailChange(c.UserContext(), user, req.OTP)
+	if err != nil {
+		return respondError(c, err)
+	}
+
+	return response.Success(c, fiber.StatusOK, "contact email updated", updatedUser)
+}
+
+func (h *UserHandler) ClearContactEmail(c *fiber.Ctx) error {
+	user := middleware.GetUserFromContext(c)
+	if user == nil {
+		return response.Unauthorized(c, "user not authenticated")
+	}
+
+	updatedUser, err := h.userService.ClearContactEmail(c.UserContext(), user)
+	if err != nil {
+		return respondError(c, err)
+	}
+
+	return response.Success(c, fiber.StatusOK, "contact email removed", updatedUser)
+}
+
+func (h *UserHandler) GetSessions(c *fiber.Ctx) error {
+	user := middleware.GetUserFromContext(c)
+	if user == nil {
+		return response.Unauthorized(c, "user not authenticated")
+	}
+
+	sessions, err := h.sessionService.GetActiveByUserID(c.UserContext(), user.ID, middleware.GetSessionIDFromContext(c))
+	if err != nil {
+		return respondError(c, err)
+	}
+
+	return response.Success(c, fiber.StatusOK, "sessions retrieved", sessions)
+}
+
+func (h *UserHandler) RevokeSession(c *fiber.Ctx) error {
+	user := middleware.GetUserFromContext(c)
+	if user == nil {
+		return response.Unauthorized(c, "user not authenticated")
+	}
+
+	sessionID, err := uuid.Parse(c.Params("id"))
+	if err != nil {
+		return response.BadRequest(c, "invalid session id")
+	}
+
+	if err := h.sessionService.Revoke(c.UserContext(), user.ID, sessionID); err != nil {
+		return respondError(c, err)
+	}
+
+	return response.Success(c, fiber.StatusOK, "session revoked", nil)
+}
+
+func (h *UserHandler) GetCompleteness(c *fiber.Ctx) error {
+	user := middleware.GetUserFromContext(c)
+	if user == nil {
+		return response.Unauthorized(c, "user not authenticated")
+	}
+
+	result, err := h.completenessService.GetCompleteness(c.UserContext(), user)
+	if err != nil {
+		return respondError(c, err)
+	}
+
+	return response.Success(c, fiber.StatusOK, "profile completeness retrieved successfully", result)
+}
+
+func (h *UserHandler) GetOnboarding(c *fiber.Ctx) error {
+	user := middleware.GetUserFromContext(c)
+	if user == nil {
+		return response.Unauthorized(c, "user not authenticated")
+	}
+
+	result, err := h.onboardingService.GetStatus(c.UserContext(), user.ID)
+	if err != nil {
+		return respondError(c, err)
+	}
+
+	return response.Success(c, fiber.StatusOK, "onboarding status retrieved successfully", result)
+}
+
+func (h *UserHandler) GetActivity(c *fiber.Ctx) error {
+	user := middleware.GetUserFromContext(c)
+	if user == nil {
+		return response.Unauthorized(c, "user not authenticated")
+	}
+
+	page := c.QueryInt("page", 1)
+	limit := c.QueryInt("limit", 10)
+
+	result, err := h.activityService.GetFeed(c.UserContext(), user.ID, page, limit)
+	if err != nil {
+		return respondError(c, err)
+	}
+
+	return response.Success(c, fiber.StatusOK, "activity retrieved", result)
+}
@@ -0,0 +1,37 @@
+package handler
+
+import (
+	"errors"
+
+	"github.com/raflytch/careerly-server/pkg/response"
+	"github.com/raflytch/careerly-server/pkg/validator"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+var (
+	errInvalidRequestBody  = errors.New("invalid request body")
+	errInvalidRequestQuery = errors.New("invalid query parameters")
+)
+
+func bindAndValidate(c *fiber.Ctx, req interface{}) error {
+	if err := c.BodyParser(req); err != nil {
+		return errInvalidRequestBody
+	}
+	return validator.ValidateStruct(req)
+}
+
+func bindQueryAndValidate(c *fiber.Ctx, req interface{}) error {
+	if err := c.QueryParser(req); err != nil {
+		return errInvalidRequestQuery
+	}
+	return validator.ValidateStruct(req)
+}
+
+func validationFailed(c *fiber.Ctx, err error) error {
+	var fieldErrors validator.ValidationErrors
+	if errors.As(err, &fieldErrors) {
+		return response.ValidationError(c, fieldErrors)
+	}
+	return response.BadRequest(c, err.Error())
+}
@@ -0,0 +1,147 @@
+package handler
+
+import (
+	"github.com/raflytch/careerly-server/internal/domain"
+	"github.com/raflytch/careerly-server/internal/middleware"
+	"github.com/raflytch/careerly-server/pkg/response"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/google/uuid"
+)
+
+type WebhookHandler struct {
+	webhookService domain.WebhookService
+}
+
+func NewWebhookHandler(webhookService domain.WebhookService) *WebhookHandler {
+	return &WebhookHandler{
+		webhookService: webhookService,
+	}
+}
+
+func (h *WebhookHandler) Create(c *fiber.Ctx) error {
+	user := middleware.GetUserFromContext(c)
+	if user == nil {
+		return response.Unauthorized(c, "user not authenticated")
+	}
+
+	var req domain.CreateWebhookEndpointRequest
+	if err := bindAndValidate(c, &req); err != nil {
+		return validationFailed(c, err)
+	}
+
+	result, err := h.webhookService.CreateEndpoint(c.UserContext(), user.ID, &req)
+	if err != nil {
+		return respondError(c, err)
+	}
+
+	return response.Success(c, fiber.StatusCreated, "webhook endpoint created", result)
+}
+
+func (h *WebhookHandler) GetAll(c *fiber.Ctx) error {
+	page := c.QueryInt("page", 1)
+	limit := c.QueryInt("limit", 10)
+
+	result, err := h.webhookService.GetEndpoints(c.UserContext(), page, limit)
+	if err != nil {
+		return respondError(c, err)
+	}
+
+	return response.Success(c, fiber.StatusOK, "webhook endpoints retrieved successfully", result)
+}
+
+func (h *WebhookHandler) GetByID(c *fiber.Ctx) error {
+	id, err := uuid.Parse(c.Params("id"))
+	if err != nil {
+		return response.BadRequest(c, "invalid webhook endpoint id")
+	}
+
+	endpoint, err := h.webhookService.GetEndpoint(c.UserContext(), id)
+	if err != nil {
+		return respondError(c, err)
+	}
+
+	return response.Success(c, fiber.StatusOK, "webhook endpoint retrieved successfully", endpoint)
+}
+
+func (h *WebhookHandler) Update(c *fiber.Ctx) error {
+	id, err := uuid.Parse(c.Params("id"))
+	if err != nil {
+		return response.BadRequest(c, "invalid webhook endpoint id")
+	}
+
+	var req domain.UpdateWebhookEndpointRequest
+	if err := bindAndValidate(c, &req); err != nil {
+		return validationFailed(c, err)
+	}
+
+	endpoint, err := h.webhookService.UpdateEndpoint(c.UserContext(), id, &req)
+	if err != nil {
+		return respondError(c, err)
+	}
+
+	return response.Success(c, fiber.StatusOK, "webhook endpoint updated", endpoint)
+}
+
+func (h *WebhookHandler) Delete(c *fiber.Ctx) error {
+	id, err := uuid.Parse(c.Params("id"))
+	if err != nil {
+		return response.BadRequest(c, "invalid webhook endpoint id")
+	}
+
+	if err := h.webhookService.DeleteEndpoint(c.UserContext(), id); err != nil {
+		return respondError(c, err)
+	}
+
+	return response.Success(c, fiber.StatusOK, "webhook endpoint deleted", nil)
+}
+
+func (h *WebhookHandler) RotateSecret(c *fiber.Ctx) error {
+	id, err := uuid.Parse(c.Params("id"))
+	if err != nil {
+		return response.BadRequest(c, "invalid webhook endpoint id")
+	}
+
+	result, err := h.webhookService.RotateSecret(c.UserContext(), id)
+	if err != nil {
+		return respondError(c, err)
+	}
+
+	return response.Success(c, fiber.StatusOK, "webhook secret rotated", result)
+}
+
+func (h *WebhookHandler) GetDeliveries(c *fiber.Ctx) error {
+	id, err := uuid.Parse(c.Params("id"))
+	if err != nil {
+		return response.BadRequest(c, "invalid webhook endpoint id")
+	}
+
+	page := c.QueryInt("page", 1)
+	limit := c.QueryInt("limit", 10)
+	status := domain.WebhookDeliveryStatus(c.Query("status"))
+
+	result, err := h.webhookService.GetDeliveries(c.UserContext(), id, status, page, limit)
+	if err != nil {
+		return respondError(c, err)
+	}
+
+	return response.Success(c, fiber.StatusOK, "webhook deliveries retrieved successfully", result)
+}
+
+func (h *WebhookHandler) Redeliver(c *fiber.Ctx) error {
+	id, err := uuid.Parse(c.Params("id"))
+	if err != nil {
+		return response.BadRequest(c, "invalid webhook endpoint id")
+	}
+	deliveryID, err := uuid.Parse(c.Params("deliveryId"))
+	if err != nil {
+		return response.BadRequest(c, "invalid webhook delivery id")
+	}
+
+	delivery, err := h.webhookService.Redeliver(c.UserContext(), id, deliveryID)
+	if err != nil {
+		return respondError(c, err)
+	}
+
+	return response.Success(c, fiber.StatusOK, "webhook delivery attempted", delivery)
+}
@@ -0,0 +1,48 @@
+package middleware
+
+import (
+	"log"
+	"strconv"
+
+	"github.com/raflytch/careerly-server/internal/config"
+	"github.com/raflytch/careerly-server/internal/domain"
+	"github.com/raflytch/careerly-server/pkg/response"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+// AIConcurrency caps how many AI requests a user may have in flight, so one
+// user cannot use up the shared AI quota. Paid plans get the higher limit.
+// It must run after Authenticate. When Redis cannot be reached the request
+// is let through rather than failing every AI route.
+func AIConcurrency(limiter domain.ConcurrencyLimiter, plans domain.QuotaService, cfg config.AILimitConfig) fiber.Handler {
+	retryAfter := strconv.Itoa(max(cfg.RetryAfterSeconds, 1))
+
+	return func(c *fiber.Ctx) error {
+		user := GetUserFromContext(c)
+		if limiter == nil || user == nil {
+			return c.Next()
+		}
+
+		limit := cfg.FreeConcurrent
+		if plan, err := plans.GetActivePlan(c.UserContext(), user.ID); err == nil && plan != nil && plan.Price.IsPositive() {
+			limit = cfg.PaidConcurrent
+		}
+		if limit <= 0 {
+			return c.Next()
+		}
+
+		release, acquired, err := limiter.Acquire(c.UserContext(), user.ID.String(), limit)
+		if err != nil {
+			log.Printf("AI concurrency check failed for user %s: %v", user.ID, err)
+			return c.Next()
+		}
+		if !acquired {
+			c.Set(fiber.HeaderRetryAfter, retryAfter)
+			return response.ErrorCode(c, fiber.StatusTooManyRequests, "AI_CONCURRENCY_LIMIT")
+		}
+		defer release()
+
+		return c.Next()
+	}
+}
@@ -0,0 +1,25 @@
+package middleware
+
+import (
+	"github.com/raflytch/careerly-server/internal/domain"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+func AuditContext() fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		if _, ok := domain.AuditActorFromContext(c.UserContext()); ok {
+			return c.Next()
+		}
+
+		user := GetUserFromContext(c)
+		if user != nil {
+			c.SetUserContext(domain.WithAuditActor(c.UserContext(), domain.AuditActor{
+				UserID:    user.ID,
+				IPAddress: c.IP(),
+				UserAgent: c.Get(fiber.HeaderUserAgent),
+			}))
+		}
+		return c.Next()
+	}
+}
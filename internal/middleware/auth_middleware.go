@@ -1,22 +1,34 @@
 package middleware
 
 import (
+	"context"
 	"strings"
 
 	"github.com/raflytch/careerly-server/internal/domain"
 	"github.com/raflytch/careerly-server/pkg/response"
 
 	"github.com/gofiber/fiber/v2"
+	"github.com/google/uuid"
 )
 
-const UserContextKey = "user"
+const (
+	UserContextKey         = "user"
+	SessionContextKey      = "session_id"
+	ImpersonatorContextKey = "impersonator_id"
+
+	ImpersonatedByHeader = "X-Impersonated-By"
+)
 
 type AuthMiddleware struct {
-	authService domain.AuthService
+	authService  domain.AuthService
+	auditService domain.AuditService
 }
 
-func NewAuthMiddleware(authService domain.AuthService) *AuthMiddleware {
-	return &AuthMiddleware{authService: authService}
+func NewAuthMiddleware(authService domain.AuthService, auditService domain.AuditService) *AuthMiddleware {
+	return &AuthMiddleware{
+		authService:  authService,
+		auditService: auditService,
+	}
 }
 
 func (m *AuthMiddleware) Authenticate() fiber.Handler {
@@ -32,16 +44,72 @@ func (m *AuthMiddleware) Authenticate() fiber.Handler {
 		}
 
 		token := parts[1]
-		user, err := m.authService.ValidateToken(c.UserContext(), token)
+		identity, err := m.authService.ValidateToken(c.UserContext(), token)
 		if err != nil {
 			return response.Unauthorized(c, "invalid or expired token")
 		}
 
-		c.Locals(UserContextKey, user)
+		c.Locals(UserContextKey, identity.User)
+		c.Locals(SessionContextKey, identity.SessionID)
+
+		if identity.ImpersonatorID == nil {
+			return c.Next()
+		}
+		return m.impersonated(c, identity)
+	}
+}
+
+// impersonated attributes the request to the admin behind the token and
+// records every state-changing call in the audit log.
+func (m *AuthMiddleware) impersonated(c *fiber.Ctx, identity *domain.TokenIdentity) error {
+	impersonatorID := *identity.ImpersonatorID
+	c.Locals(ImpersonatorContextKey, impersonatorID)
+	c.Set(ImpersonatedByHeader, impersonatorID.String())
+	c.SetUserContext(domain.WithAuditActor(c.UserContext(), domain.AuditActor{
+		UserID:    impersonatorID,
+		IPAddress: c.IP(),
+		UserAgent: c.Get(fiber.HeaderUserAgent),
+	}))
+
+	err := c.Next()
+
+	if c.Method() != fiber.MethodGet && c.Method() != fiber.MethodHead {
+		m.auditService.Record(context.WithoutCancel(c.UserContext()), domain.AuditActionImpersonatedAction, domain.AuditTargetUser, identity.User.ID, nil, map[string]interface{}{
+			"session_id": identity.SessionID,
+			"method":     c.Method(),
+			"path":       c.Path(),
+			"status":     c.Response().StatusCode(),
+		})
+	}
+
+	return err
+}
+
+// DenyImpersonation blocks account-level actions that support staff should
+// never perform on a user's behalf.
+func DenyImpersonation() fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		if GetImpersonatorIDFromContext(c) != nil {
+			return response.Forbidden(c, domain.ErrImpersonationForbidden.Error())
+		}
 		return c.Next()
 	}
 }
 
+// AuthenticateWebSocket also accepts the token from the "token" query
+// parameter, since browsers cannot set headers on WebSocket handshakes.
+func (m *AuthMiddleware) AuthenticateWebSocket() fiber.Handler {
+	authenticate := m.Authenticate()
+	return func(c *fiber.Ctx) error {
+		if c.Get("Authorization") == "" {
+			if token := c.Query("token"); token != "" {
+				c.Request().Header.Set("Authorization", "Bearer "+token)
+			}
+		}
+		return authenticate(c)
+	}
+}
+
 func GetUserFromContext(c *fiber.Ctx) *domain.User {
 	user, ok := c.Locals(UserContextKey).(*domain.User)
 	if !ok {
@@ -49,3 +117,19 @@ func GetUserFromContext(c *fiber.Ctx) *domain.User {
 	}
 	return user
 }
+
+func GetImpersonatorIDFromContext(c *fiber.Ctx) *uuid.UUID {
+	impersonatorID, ok := c.Locals(ImpersonatorContextKey).(uuid.UUID)
+	if !ok {
+		return nil
+	}
+	return &impersonatorID
+}
+
+func GetSessionIDFromContext(c *fiber.Ctx) uuid.UUID {
+	sessionID, ok := c.Locals(SessionContextKey).(uuid.UUID)
+	if !ok {
+		return uuid.Nil
+	}
+	return sessionID
+}
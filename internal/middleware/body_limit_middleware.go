@@ -0,0 +1,28 @@
+package middleware
+
+import (
+	"github.com/raflytch/careerly-server/pkg/response"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+// BodyLimit rejects requests whose body is larger than limit bytes. Fiber
+// stops enforcing Config.BodyLimit once StreamRequestBody is on, so this
+// takes its place. Chunked bodies have no length to check up front and are
+// refused; every client the API serves sends Content-Length. The unread
+// body is left on the wire, so the connection is closed after the reply.
+func BodyLimit(limit int) fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		length := c.Request().Header.ContentLength()
+		if length == -1 || length > limit {
+			c.Context().SetConnectionClose()
+		}
+		if length == -1 {
+			return response.Error(c, fiber.StatusLengthRequired, "content length is required")
+		}
+		if length > limit {
+			return response.Error(c, fiber.StatusRequestEntityTooLarge, "request body is too large")
+		}
+		return c.Next()
+	}
+}
@@ -0,0 +1,27 @@
+package middleware
+
+import (
+	"github.com/raflytch/careerly-server/internal/domain"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+const CountryContextKey = "country"
+
+// Geo resolves the client IP to a country code for later handlers. It does
+// nothing when no resolver is configured.
+func Geo(resolver domain.GeoResolver) fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		if resolver != nil {
+			if country := resolver.Country(c.IP()); country != "" {
+				c.Locals(CountryContextKey, country)
+			}
+		}
+		return c.Next()
+	}
+}
+
+func GetCountryFromContext(c *fiber.Ctx) string {
+	country, _ := c.Locals(CountryContextKey).(string)
+	return country
+}
@@ -0,0 +1,24 @@
+package middleware
+
+import (
+	"github.com/raflytch/careerly-server/pkg/i18n"
+	"github.com/raflytch/careerly-server/pkg/response"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+// Locale negotiates the language for error messages from Accept-Language
+// and reports it back in Content-Language.
+func Locale() fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		lang := i18n.Negotiate(c.Get(fiber.HeaderAcceptLanguage))
+		c.Locals(i18n.ContextKey, lang)
+		c.Set(fiber.HeaderContentLanguage, lang)
+		c.Vary(fiber.HeaderAcceptLanguage)
+		return c.Next()
+	}
+}
+
+func GetLocaleFromContext(c *fiber.Ctx) string {
+	return response.Locale(c)
+}
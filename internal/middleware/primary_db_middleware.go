@@ -0,0 +1,16 @@
+package middleware
+
+import (
+	"github.com/raflytch/careerly-server/internal/database"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+// PrimaryDB keeps every query of the request on the primary database, for
+// routes such as payment webhooks that act on what they read.
+func PrimaryDB() fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		c.SetUserContext(database.WithPrimary(c.UserContext()))
+		return c.Next()
+	}
+}
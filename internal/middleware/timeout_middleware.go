@@ -0,0 +1,32 @@
+package middleware
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/raflytch/careerly-server/pkg/response"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+// Timeout attaches a deadline to the request context. It replaces any
+// deadline set by an outer Timeout, so a route can extend its group default.
+// When the deadline passes, the handler's response is replaced with a 504.
+func Timeout(d time.Duration) fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		if d <= 0 {
+			return c.Next()
+		}
+
+		ctx, cancel := context.WithTimeout(context.WithoutCancel(c.UserContext()), d)
+		defer cancel()
+		c.SetUserContext(ctx)
+
+		err := c.Next()
+		if errors.Is(c.UserContext().Err(), context.DeadlineExceeded) {
+			return response.Error(c, fiber.StatusGatewayTimeout, "request timed out, please try again")
+		}
+		return err
+	}
+}
@@ -0,0 +1,297 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/raflytch/careerly-server/internal/config"
+	"github.com/raflytch/careerly-server/internal/domain"
+
+	"github.com/google/uuid"
+)
+
+const accountDeletionColumns = `user_id, notice_email, purge_at, purge_job_id, notice_job_id, created_at`
+
+type accountDeletionRepository struct {
+	db      *sql.DB
+	resumes regionTables
+	drafts  regionTables
+}
+
+func NewAccountDeletionRepository(db *sql.DB, regions config.DataRegionConfig) domain.AccountDeletionRepository {
+	return &accountDeletionRepository{
+		db:      db,
+		resumes: newRegionTables(regions, "resume_contents"),
+		drafts:  newRegionTables(regions, "resume_draft_contents"),
+	}
+}
+
+func (r *accountDeletionRepository) Upsert(ctx context.Context, deletion *domain.AccountDeletion) error {
+	query := `
+		INSERT INTO account_deletions (` + accountDeletionColumns + `)
+		VALUES ($1, $2, $3, $4, $5, $6)
+		ON CONFLICT (user_id) DO UPDATE SET
+			notice_email = EXCLUDED.notice_email,
+			purge_at = EXCLUDED.purge_at,
+			purge_job_id = EXCLUDED.purge_job_id,
+			notice_job_id = EXCLUDED.notice_job_id,
+			created_at = EXCLUDED.created_at
+	`
+	_, err := r.db.ExecContext(ctx, query,
+		deletion.UserID,
+		deletion.NoticeEmail,
+		deletion.PurgeAt,
+		deletion.PurgeJobID,
+		deletion.NoticeJobID,
+		deletion.CreatedAt,
+	)
+	return err
+}
+
+func (r *accountDeletionRepository) FindByUserID(ctx context.Context, userID uuid.UUID) (*domain.AccountDeletion, error) {
+	query := `
+		SELECT ` + accountDeletionColumns + `
+		FROM account_deletions
+		WHERE user_id = $1
+	`
+	var deletion domain.AccountDeletion
+	err := r.db.QueryRowContext(ctx, query, userID).Scan(
+		&deletion.UserID,
+		&deletion.NoticeEmail,
+		&deletion.PurgeAt,
+		&deletion.PurgeJobID,
+		&deletion.NoticeJobID,
+		&deletion.CreatedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+	return &deletion, nil
+}
+
+func (r *accountDeletionRepository) Delete(ctx context.Context, userID uuid.UUID) (bool, error) {
+	result, err := r.db.ExecContext(ctx, `DELETE FROM account_deletions WHERE user_id = $1`, userID)
+	if err != nil {
+		return false, err
+	}
+	affected, err := result.RowsAffected()
+	if err != nil {
+		return false, err
+	}
+	return affected > 0, nil
+}
+
+// FindPurgeFiles lists what a user due for purging has in object storage:
+// generated resume PDFs, interview video answers, and the avatar and resume
+// photos. It returns sql.ErrNoRows when the account is not due, so nothing
+// is removed from a restored account.
+func (r *accountDeletionRepository) FindPurgeFiles(ctx context.Context, userID uuid.UUID, now time.Time) (*domain.AccountFiles, error) {
+	dueQuery := `
+		SELECT u.id
+		FROM users u
+		JOIN account_deletions d ON d.user_id = u.id
+		WHERE u.id = $1 AND u.deleted_at IS NOT NULL AND d.purge_at <= $2
+	`
+	var id uuid.UUID
+	if err := r.db.QueryRowContext(ctx, dueQuery, userID, now).Scan(&id); err != nil {
+		return nil, err
+	}
+
+	files := &domain.AccountFiles{}
+
+	artifactQuery := `
+		SELECT ` + artifactColumns + `
+		FROM artifacts
+		WHERE entity_type = $2 AND entity_id IN (SELECT id FROM resumes WHERE user_id = $1)
+	`
+	rows, err := r.db.QueryContext(ctx, artifactQuery, userID, domain.ArtifactResumePDF)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	for rows.Next() {
+		var artifact domain.Artifact
+		if err := rows.Scan(
+			&artifact.EntityType,
+			&artifact.EntityID,
+			&artifact.Version,
+			&artifact.Driver,
+			&artifact.StorageKey,
+			&artifact.ContentType,
+			&artifact.Size,
+			&artifact.CreatedAt,
+			&artifact.Region,
+		); err != nil {
+			return nil, err
+		}
+		files.Artifacts = append(files.Artifacts, artifact)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	videoQuery := `
+		SELECT DISTINCT v #>> '{}'
+		FROM interviews, jsonb_path_query(questions, '$[*].video_answer.storage_id') AS v
+		WHERE user_id = $1
+	`
+	if files.VideoIDs, err = r.queryStrings(ctx, videoQuery, userID); err != nil {
+		return nil, err
+	}
+
+	imageQuery := `
+		SELECT avatar_url FROM users WHERE id = $1 AND avatar_url IS NOT NULL
+		UNION SELECT content #>> '{personal_info,photo_url}' FROM resumes WHERE user_id = $1
+		UNION SELECT content #>> '{personal_info,photo_url}' FROM resume_drafts WHERE user_id = $1
+	`
+	for _, table := range r.resumes {
+		imageQuery += `
+		UNION SELECT c.content #>> '{personal_info,photo_url}' FROM ` + table + ` c JOIN resumes r ON r.id = c.resume_id WHERE r.user_id = $1`
+	}
+	for _, table := range r.drafts {
+		imageQuery += `
+		UNION SELECT c.content #>> '{personal_info,photo_url}' FROM ` + table + ` c JOIN resume_drafts d ON d.id = c.draft_id WHERE d.user_id = $1`
+	}
+	images, err := r.queryStrings(ctx, imageQuery, userID)
+	if err != nil {
+		return nil, err
+	}
+	for _, image := range images {
+		if image != "" {
+			files.ImageURLs = append(files.ImageURLs, image)
+		}
+	}
+	return files, nil
+}
+
+func (r *accountDeletionRepository) queryStrings(ctx context.Context, query string, args ...interface{}) ([]string, error) {
+	rows, err := r.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var values []string
+	for rows.Next() {
+		var value sql.NullString
+		if err := rows.Scan(&value); err != nil {
+			return nil, err
+		}
+		if value.Valid {
+			values = append(values, value.String)
+		}
+	}
+	return values, rows.Err()
+}
+
+// accountPurgeStatements remove everything tied to a user, children before
+// parents, ending with the users row. Each takes the user ID as $1. Tables
+// whose user foreign key cascades, such as notifications, payment methods
+// and resume folders, are left to the final delete. Records other people
+// depend on are kept without the reference: transactions for bookkeeping,
+// gifts for their recipients, AI usage for the monthly budget, email
+// suppressions so a bouncing address stays blocked, and what admins created.
+// Organizations the user owns pass to their longest-standing member, or are
+// deleted when the user was the only one.
+var accountPurgeStatements = []string{
+	`WITH successors AS (
+		SELECT DISTINCT ON (m.organization_id) m.organization_id, m.user_id
+		FROM organization_members m
+		JOIN organizations o ON o.id = m.organization_id
+		WHERE o.owner_id = $1 AND m.user_id <> $1
+		ORDER BY m.organization_id, m.joined_at
+	),
+	promoted AS (
+		UPDATE organization_members m SET role = 'owner'
+		FROM successors s
+		WHERE m.organization_id = s.organization_id AND m.user_id = s.user_id
+	)
+	UPDATE organizations o SET owner_id = s.user_id, updated_at = NOW()
+	FROM successors s
+	WHERE o.id = s.organization_id`,
+	`DELETE FROM organization_purchases WHERE organization_id IN (SELECT id FROM organizations WHERE owner_id = $1)`,
+	`DELETE FROM organizations WHERE owner_id = $1`,
+	`DELETE FROM organization_invitations WHERE invited_by = $1`,
+	`DELETE FROM organization_members WHERE user_id = $1`,
+
+	`DELETE FROM prompt_usages WHERE entity_id IN (
+		SELECT id FROM resumes WHERE user_id = $1
+		UNION SELECT id FROM interviews WHERE user_id = $1
+		UNION SELECT id FROM ats_checks WHERE user_id = $1
+	)`,
+	`DELETE FROM ai_feedback WHERE user_id = $1`,
+	`DELETE FROM ats_checks WHERE user_id = $1`,
+	`DELETE FROM interview_mentor_comments WHERE interview_id IN (SELECT id FROM interviews WHERE user_id = $1)`,
+	`DELETE FROM interview_shares WHERE user_id = $1 OR interview_id IN (SELECT id FROM interviews WHERE user_id = $1)`,
+	`DELETE FROM interviews WHERE user_id = $1`,
+	`DELETE FROM resume_share_events WHERE resume_id IN (SELECT id FROM resumes WHERE user_id = $1)`,
+	`DELETE FROM resume_share_daily_stats WHERE resume_id IN (SELECT id FROM resumes WHERE user_id = $1)`,
+	`DELETE FROM resume_shares WHERE user_id = $1`,
+	`DELETE FROM artifacts WHERE entity_id IN (SELECT id FROM resumes WHERE user_id = $1)`,
+	`DELETE FROM resumes WHERE user_id = $1`,
+	`DELETE FROM resume_drafts WHERE user_id = $1`,
+
+	`DELETE FROM addon_credits WHERE user_id = $1`,
+	`DELETE FROM provisioning_jobs WHERE user_id = $1`,
+	`UPDATE gifts SET purchaser_id = NULL WHERE purchaser_id = $1`,
+	`UPDATE gifts SET redeemed_by = NULL WHERE redeemed_by = $1`,
+	`UPDATE transactions SET user_id = NULL, subscription_id = NULL WHERE user_id = $1`,
+	`DELETE FROM subscriptions WHERE user_id = $1`,
+	`DELETE FROM usage WHERE user_id = $1`,
+	`DELETE FROM quota_overrides WHERE user_id = $1`,
+	`UPDATE quota_overrides SET created_by = NULL WHERE created_by = $1`,
+	`DELETE FROM referrals WHERE referrer_id = $1 OR referred_id = $1`,
+	`DELETE FROM referral_codes WHERE user_id = $1`,
+
+	`DELETE FROM user_sessions WHERE user_id = $1`,
+	`UPDATE user_sessions SET impersonator_id = NULL WHERE impersonator_id = $1`,
+	`DELETE FROM auth_identities WHERE user_id = $1`,
+	`DELETE FROM email_messages WHERE user_id = $1`,
+	`UPDATE email_suppressions SET user_id = NULL WHERE user_id = $1`,
+	`UPDATE ai_usage SET user_id = NULL WHERE user_id = $1`,
+	`UPDATE audit_logs SET actor_id = NULL WHERE actor_id = $1`,
+	`UPDATE webhook_endpoints SET created_by = NULL WHERE created_by = $1`,
+
+	`DELETE FROM users WHERE id = $1`,
+}
+
+// Purge permanently removes a user whose grace period has ended, in one
+// transaction. Nothing changes if the account was restored or the deletion
+// is not yet due.
+func (r *accountDeletionRepository) Purge(ctx context.Context, userID uuid.UUID, now time.Time) (bool, error) {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return false, err
+	}
+	defer tx.Rollback()
+
+	lockQuery := `
+		SELECT u.id
+		FROM users u
+		JOIN account_deletions d ON d.user_id = u.id
+		WHERE u.id = $1 AND u.deleted_at IS NOT NULL AND d.purge_at <= $2
+		FOR UPDATE OF u
+	`
+	var id uuid.UUID
+	if err := tx.QueryRowContext(ctx, lockQuery, userID, now).Scan(&id); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return false, nil
+		}
+		return false, err
+	}
+
+	for _, statement := range accountPurgeStatements {
+		if _, err := tx.ExecContext(ctx, statement, userID); err != nil {
+			return false, fmt.Errorf("%s: %w", strings.SplitN(statement, "\n", 2)[0], err)
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		return false, err
+	}
+	return true, nil
+}
@@ -0,0 +1,101 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+
+	"github.com/raflytch/careerly-server/internal/database"
+	"github.com/raflytch/careerly-server/internal/domain"
+
+	"github.com/google/uuid"
+	"github.com/shopspring/decimal"
+)
+
+// activityFeedQuery merges the user's events from each source table. Every
+// branch yields the same columns so the feed can be sorted and paged as one.
+// $1 is the user, $2 the completed interview status and $3 the successful
+// transaction status.
+const activityFeedQuery = `
+	SELECT $4::text AS type, id, title, NULL::float8 AS score, NULL::numeric AS amount, created_at AS occurred_at
+	FROM resumes
+	WHERE user_id = $1 AND deleted_at IS NULL
+	UNION ALL
+	SELECT $5::text, id, title, NULL::float8, NULL::numeric, updated_at
+	FROM resumes
+	WHERE user_id = $1 AND deleted_at IS NULL AND updated_at > created_at
+	UNION ALL
+	SELECT $6::text, a.id, COALESCE(r.title, ''), a.score::float8, NULL::numeric, a.created_at
+	FROM ats_checks a
+	LEFT JOIN resumes r ON r.id = a.resume_id
+	WHERE a.user_id = $1 AND a.deleted_at IS NULL
+	UNION ALL
+	SELECT $7::text, id, job_position, overall_score::float8, NULL::numeric, COALESCE(completed_at, created_at)
+	FROM interviews
+	WHERE user_id = $1 AND status = $2 AND deleted_at IS NULL
+	UNION ALL
+	SELECT $8::text, t.id, COALESCE(p.display_name, ad.display_name, ''), NULL::float8, t.gross_amount, COALESCE(t.paid_at, t.created_at)
+	FROM transactions t
+	LEFT JOIN plans p ON p.id = t.plan_id
+	LEFT JOIN addons ad ON ad.id = t.addon_id
+	WHERE t.user_id = $1 AND t.status = $3 AND t.deleted_at IS NULL
+`
+
+type activityRepository struct {
+	db *database.Router
+}
+
+func NewActivityRepository(db *database.Router) domain.ActivityRepository {
+	return &activityRepository{db: db}
+}
+
+func activityFeedArgs(userID uuid.UUID) []interface{} {
+	return []interface{}{
+		userID,
+		domain.InterviewStatusCompleted,
+		domain.TransactionStatusSuccess,
+		domain.ActivityResumeCreated,
+		domain.ActivityResumeUpdated,
+		domain.ActivityATSCheckCompleted,
+		domain.ActivityInterviewCompleted,
+		domain.ActivityPaymentSucceeded,
+	}
+}
+
+func (r *activityRepository) FindByUserID(ctx context.Context, userID uuid.UUID, limit, offset int) ([]domain.Activity, error) {
+	query := `SELECT type, id, title, score, amount, occurred_at FROM (` + activityFeedQuery + `) feed
+		ORDER BY occurred_at DESC, id
+		LIMIT $9 OFFSET $10`
+
+	rows, err := r.db.QueryReadContext(ctx, query, append(activityFeedArgs(userID), limit, offset)...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	activities := []domain.Activity{}
+	for rows.Next() {
+		var activity domain.Activity
+		var score sql.NullFloat64
+		var amount decimal.NullDecimal
+		if err := rows.Scan(&activity.Type, &activity.ResourceID, &activity.Title, &score, &amount, &activity.OccurredAt); err != nil {
+			return nil, err
+		}
+		if score.Valid {
+			activity.Score = &score.Float64
+		}
+		if amount.Valid {
+			activity.Amount = &amount.Decimal
+		}
+		activities = append(activities, activity)
+	}
+
+	return activities, rows.Err()
+}
+
+func (r *activityRepository) CountByUserID(ctx context.Context, userID uuid.UUID) (int64, error) {
+	query := `SELECT COUNT(*) FROM (` + activityFeedQuery + `) feed`
+
+	var count int64
+	err := r.db.QueryRowReadContext(ctx, query, activityFeedArgs(userID)...).Scan(&count)
+	return count, err
+}
@@ -0,0 +1,210 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"time"
+
+	"github.com/raflytch/careerly-server/internal/domain"
+
+	"github.com/google/uuid"
+	"github.com/shopspring/decimal"
+)
+
+const (
+	addonColumns = `id, name, display_name, feature, quantity, price, is_active, created_at, deleted_at`
+)
+
+type addonRepository struct {
+	db *sql.DB
+}
+
+func NewAddonRepository(db *sql.DB) domain.AddonRepository {
+	return &addonRepository{db: db}
+}
+
+func (r *addonRepository) Create(ctx context.Context, addon *domain.Addon) error {
+	query := `
+		INSERT INTO addons (id, name, display_name, feature, quantity, price, is_active, created_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8)
+	`
+	_, err := r.db.ExecContext(ctx, query,
+		addon.ID,
+		addon.Name,
+		addon.DisplayName,
+		addon.Feature,
+		addon.Quantity,
+		addon.Price,
+		addon.IsActive,
+		addon.CreatedAt,
+	)
+	return err
+}
+
+func (r *addonRepository) FindByID(ctx context.Context, id uuid.UUID) (*domain.Addon, error) {
+	query := `
+		SELECT ` + addonColumns + `
+		FROM addons
+		WHERE id = $1 AND deleted_at IS NULL
+	`
+	return r.scanAddon(r.db.QueryRowContext(ctx, query, id))
+}
+
+func (r *addonRepository) FindByName(ctx context.Context, name string) (*domain.Addon, error) {
+	query := `
+		SELECT ` + addonColumns + `
+		FROM addons
+		WHERE name = $1 AND deleted_at IS NULL
+	`
+	return r.scanAddon(r.db.QueryRowContext(ctx, query, name))
+}
+
+func (r *addonRepository) FindAll(ctx context.Context, limit, offset int, includeInactive bool) ([]domain.Addon, error) {
+	query := `
+		SELECT ` + addonColumns + `
+		FROM addons
+		WHERE deleted_at IS NULL
+	`
+	if !includeInactive {
+		query += ` AND is_active = true`
+	}
+	query += `
+		ORDER BY feature ASC, price ASC
+		LIMIT $1 OFFSET $2
+	`
+
+	rows, err := r.db.QueryContext(ctx, query, limit, offset)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	addons := make([]domain.Addon, 0)
+	for rows.Next() {
+		addon, err := r.scanAddonFromRows(rows)
+		if err != nil {
+			return nil, err
+		}
+		addons = append(addons, *addon)
+	}
+	return addons, rows.Err()
+}
+
+func (r *addonRepository) Count(ctx context.Context, includeInactive bool) (int64, error) {
+	query := `SELECT COUNT(id) FROM addons WHERE deleted_at IS NULL`
+	if !includeInactive {
+		query += ` AND is_active = true`
+	}
+	var count int64
+	err := r.db.QueryRowContext(ctx, query).Scan(&count)
+	return count, err
+}
+
+func (r *addonRepository) Update(ctx context.Context, addon *domain.Addon) error {
+	query := `
+		UPDATE addons
+		SET name = $1, display_name = $2, quantity = $3, price = $4, is_active = $5
+		WHERE id = $6 AND deleted_at IS NULL
+	`
+	_, err := r.db.ExecContext(ctx, query,
+		addon.Name,
+		addon.DisplayName,
+		addon.Quantity,
+		addon.Price,
+		addon.IsActive,
+		addon.ID,
+	)
+	return err
+}
+
+func (r *addonRepository) SoftDelete(ctx context.Context, id uuid.UUID) error {
+	query := `
+		UPDATE addons
+		SET deleted_at = $1
+		WHERE id = $2 AND deleted_at IS NULL
+	`
+	_, err := r.db.ExecContext(ctx, query, time.Now(), id)
+	return err
+}
+
+// CreateCredit records the allowance bought by a transaction. It reports
+// false when the transaction was already credited, so a retried
+// fulfilment never grants the same pack twice.
+func (r *addonRepository) CreateCredit(ctx context.Context, credit *domain.AddonCredit) (bool, error) {
+	query := `
+		INSERT INTO addon_credits (id, user_id, addon_id, transaction_id, feature, quantity, period_month, created_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8)
+		ON CONFLICT (transaction_id) DO NOTHING
+	`
+	result, err := r.db.ExecContext(ctx, query,
+		credit.ID,
+		credit.UserID,
+		credit.AddonID,
+		credit.TransactionID,
+		credit.Feature,
+		credit.Quantity,
+		credit.PeriodMonth,
+		credit.CreatedAt,
+	)
+	if err != nil {
+		return false, err
+	}
+	rows, err := result.RowsAffected()
+	if err != nil {
+		return false, err
+	}
+	return rows > 0, nil
+}
+
+func (r *addonRepository) SumCredits(ctx context.Context, userID uuid.UUID, feature domain.FeatureType, periodMonth time.Time) (int, error) {
+	query := `
+		SELECT COALESCE(SUM(quantity), 0)
+		FROM addon_credits
+		WHERE user_id = $1 AND feature = $2 AND period_month = $3
+	`
+	var total int
+	err := r.db.QueryRowContext(ctx, query, userID, feature, periodMonth).Scan(&total)
+	return total, err
+}
+
+func (r *addonRepository) scanAddon(row *sql.Row) (*domain.Addon, error) {
+	var addon domain.Addon
+	var price decimal.Decimal
+	err := row.Scan(
+		&addon.ID,
+		&addon.Name,
+		&addon.DisplayName,
+		&addon.Feature,
+		&addon.Quantity,
+		&price,
+		&addon.IsActive,
+		&addon.CreatedAt,
+		&addon.DeletedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+	addon.Price = price
+	return &addon, nil
+}
+
+func (r *addonRepository) scanAddonFromRows(rows *sql.Rows) (*domain.Addon, error) {
+	var addon domain.Addon
+	var price decimal.Decimal
+	err := rows.Scan(
+		&addon.ID,
+		&addon.Name,
+		&addon.DisplayName,
+		&addon.Feature,
+		&addon.Quantity,
+		&price,
+		&addon.IsActive,
+		&addon.CreatedAt,
+		&addon.DeletedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+	addon.Price = price
+	return &addon, nil
+}
@@ -0,0 +1,86 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"time"
+
+	"github.com/raflytch/careerly-server/internal/domain"
+)
+
+type aiFeedbackRepository struct {
+	db *sql.DB
+}
+
+func NewAIFeedbackRepository(db *sql.DB) domain.AIFeedbackRepository {
+	return &aiFeedbackRepository{db: db}
+}
+
+// Upsert keeps one rating per user and output; rating the same output again
+// replaces the earlier rating but keeps its ID and creation time.
+func (r *aiFeedbackRepository) Upsert(ctx context.Context, feedback *domain.AIFeedback) error {
+	query := `
+		INSERT INTO ai_feedback (id, user_id, feature, entity_id, rating, comment, prompt_version, created_at, updated_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9)
+		ON CONFLICT (user_id, feature, entity_id) DO UPDATE
+		SET rating = EXCLUDED.rating,
+			comment = EXCLUDED.comment,
+			prompt_version = EXCLUDED.prompt_version,
+			updated_at = EXCLUDED.updated_at
+		RETURNING id, created_at
+	`
+	return r.db.QueryRowContext(ctx, query,
+		feedback.ID,
+		feedback.UserID,
+		feedback.Feature,
+		feedback.EntityID,
+		feedback.Rating,
+		feedback.Comment,
+		feedback.PromptVersion,
+		feedback.CreatedAt,
+		feedback.UpdatedAt,
+	).Scan(&feedback.ID, &feedback.CreatedAt)
+}
+
+func (r *aiFeedbackRepository) Summarize(ctx context.Context, from, to time.Time, byPromptVersion bool) ([]domain.AIFeedbackSummary, error) {
+	version := `NULL::INT`
+	if byPromptVersion {
+		version = `prompt_version`
+	}
+
+	query := `
+		SELECT
+			feature,
+			` + version + ` AS version,
+			COUNT(*),
+			COUNT(*) FILTER (WHERE rating = 'up'),
+			COUNT(*) FILTER (WHERE rating = 'down'),
+			COUNT(*) FILTER (WHERE comment IS NOT NULL AND comment <> '')
+		FROM ai_feedback
+		WHERE updated_at >= $1 AND updated_at < $2
+		GROUP BY feature, version
+		ORDER BY feature, version NULLS FIRST
+	`
+	rows, err := r.db.QueryContext(ctx, query, from, to)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	summaries := make([]domain.AIFeedbackSummary, 0)
+	for rows.Next() {
+		var summary domain.AIFeedbackSummary
+		if err := rows.Scan(
+			&summary.Feature,
+			&summary.PromptVersion,
+			&summary.Ratings,
+			&summary.ThumbsUp,
+			&summary.ThumbsDown,
+			&summary.Comments,
+		); err != nil {
+			return nil, err
+		}
+		summaries = append(summaries, summary)
+	}
+	return summaries, rows.Err()
+}
@@ -0,0 +1,175 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"time"
+
+	"github.com/raflytch/careerly-server/internal/database"
+	"github.com/raflytch/careerly-server/internal/domain"
+
+	"github.com/google/uuid"
+	"github.com/shopspring/decimal"
+)
+
+const (
+	aiUsageColumns = `id, user_id, feature, model, prompt_tokens, completion_tokens, total_tokens, estimated_cost, latency_ms, quota_cost, success, error_message, created_at`
+)
+
+type aiUsageRepository struct {
+	db *database.Router
+}
+
+func NewAIUsageRepository(db *database.Router) domain.AIUsageRepository {
+	return &aiUsageRepository{db: db}
+}
+
+func (r *aiUsageRepository) Create(ctx context.Context, usage *domain.AIUsage) error {
+	query := `
+		INSERT INTO ai_usage (` + aiUsageColumns + `)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13)
+	`
+	_, err := r.db.ExecContext(ctx, query,
+		usage.ID,
+		usage.UserID,
+		usage.Feature,
+		usage.Model,
+		usage.PromptTokens,
+		usage.CompletionTokens,
+		usage.TotalTokens,
+		usage.EstimatedCost,
+		usage.LatencyMs,
+		usage.QuotaCost,
+		usage.Success,
+		usage.ErrorMessage,
+		usage.CreatedAt,
+	)
+	return err
+}
+
+func (r *aiUsageRepository) FindAll(ctx context.Context, feature string, limit, offset int) ([]domain.AIUsage, error) {
+	query := `
+		SELECT ` + aiUsageColumns + `
+		FROM ai_usage
+		WHERE ($1 = '' OR feature = $1)
+		ORDER BY created_at DESC
+		LIMIT $2 OFFSET $3
+	`
+	rows, err := r.db.QueryReadContext(ctx, query, feature, limit, offset)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	return r.scanAIUsageRows(rows)
+}
+
+func (r *aiUsageRepository) FindByUserID(ctx context.Context, userID uuid.UUID, feature string, limit, offset int) ([]domain.AIUsage, error) {
+	query := `
+		SELECT ` + aiUsageColumns + `
+		FROM ai_usage
+		WHERE user_id = $1 AND ($2 = '' OR feature = $2)
+		ORDER BY created_at DESC
+		LIMIT $3 OFFSET $4
+	`
+	rows, err := r.db.QueryReadContext(ctx, query, userID, feature, limit, offset)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	return r.scanAIUsageRows(rows)
+}
+
+func (r *aiUsageRepository) scanAIUsageRows(rows *sql.Rows) ([]domain.AIUsage, error) {
+	logs := make([]domain.AIUsage, 0)
+	for rows.Next() {
+		var usage domain.AIUsage
+		var cost decimal.Decimal
+		if err := rows.Scan(
+			&usage.ID,
+			&usage.UserID,
+			&usage.Feature,
+			&usage.Model,
+			&usage.PromptTokens,
+			&usage.CompletionTokens,
+			&usage.TotalTokens,
+			&cost,
+			&usage.LatencyMs,
+			&usage.QuotaCost,
+			&usage.Success,
+			&usage.ErrorMessage,
+			&usage.CreatedAt,
+		); err != nil {
+			return nil, err
+		}
+		usage.EstimatedCost = cost
+		logs = append(logs, usage)
+	}
+	return logs, rows.Err()
+}
+
+func (r *aiUsageRepository) Count(ctx context.Context, feature string) (int64, error) {
+	query := `SELECT COUNT(*) FROM ai_usage WHERE ($1 = '' OR feature = $1)`
+	var count int64
+	err := r.db.QueryRowReadContext(ctx, query, feature).Scan(&count)
+	return count, err
+}
+
+func (r *aiUsageRepository) CountByUserID(ctx context.Context, userID uuid.UUID, feature string) (int64, error) {
+	query := `SELECT COUNT(*) FROM ai_usage WHERE user_id = $1 AND ($2 = '' OR feature = $2)`
+	var count int64
+	err := r.db.QueryRowReadContext(ctx, query, userID, feature).Scan(&count)
+	return count, err
+}
+
+func (r *aiUsageRepository) SummarizeByFeature(ctx context.Context, from, to time.Time) ([]domain.AIUsageSummary, error) {
+	query := `
+		SELECT
+			feature,
+			COUNT(*),
+			COUNT(*) FILTER (WHERE success = false),
+			COALESCE(SUM(prompt_tokens), 0),
+			COALESCE(SUM(completion_tokens), 0),
+			COALESCE(SUM(total_tokens), 0),
+			COALESCE(SUM(estimated_cost), 0),
+			COALESCE(AVG(latency_ms), 0)
+		FROM ai_usage
+		WHERE created_at >= $1 AND created_at < $2
+		GROUP BY feature
+		ORDER BY feature
+	`
+	rows, err := r.db.QueryContext(ctx, query, from, to)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	summaries := make([]domain.AIUsageSummary, 0)
+	for rows.Next() {
+		var summary domain.AIUsageSummary
+		var cost decimal.Decimal
+		if err := rows.Scan(
+			&summary.Feature,
+			&summary.Calls,
+			&summary.FailedCalls,
+			&summary.PromptTokens,
+			&summary.CompletionTokens,
+			&summary.TotalTokens,
+			&cost,
+			&summary.AvgLatencyMs,
+		); err != nil {
+			return nil, err
+		}
+		summary.EstimatedCost = cost
+		summaries = append(summaries, summary)
+	}
+	return summaries, rows.Err()
+}
+
+func (r *aiUsageRepository) SumCostSince(ctx context.Context, since time.Time) (decimal.Decimal, error) {
+	query := `SELECT COALESCE(SUM(estimated_cost), 0) FROM ai_usage WHERE created_at >= $1`
+	var total decimal.Decimal
+	err := r.db.QueryRowContext(ctx, query, since).Scan(&total)
+	return total, err
+}
@@ -0,0 +1,73 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+
+	"github.com/raflytch/careerly-server/internal/domain"
+
+	"github.com/google/uuid"
+)
+
+const (
+	artifactColumns = `entity_type, entity_id, version, driver, storage_key, content_type, size, created_at, data_region`
+)
+
+type artifactRepository struct {
+	db *sql.DB
+}
+
+func NewArtifactRepository(db *sql.DB) domain.ArtifactRepository {
+	return &artifactRepository{db: db}
+}
+
+func (r *artifactRepository) Find(ctx context.Context, entityType string, entityID uuid.UUID) (*domain.Artifact, error) {
+	query := `
+		SELECT ` + artifactColumns + `
+		FROM artifacts
+		WHERE entity_type = $1 AND entity_id = $2
+	`
+	var artifact domain.Artifact
+	err := r.db.QueryRowContext(ctx, query, entityType, entityID).Scan(
+		&artifact.EntityType,
+		&artifact.EntityID,
+		&artifact.Version,
+		&artifact.Driver,
+		&artifact.StorageKey,
+		&artifact.ContentType,
+		&artifact.Size,
+		&artifact.CreatedAt,
+		&artifact.Region,
+	)
+	if err != nil {
+		return nil, err
+	}
+	return &artifact, nil
+}
+
+func (r *artifactRepository) Save(ctx context.Context, artifact *domain.Artifact) error {
+	query := `
+		INSERT INTO artifacts (` + artifactColumns + `)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9)
+		ON CONFLICT (entity_type, entity_id) DO UPDATE
+		SET version = EXCLUDED.version,
+			driver = EXCLUDED.driver,
+			storage_key = EXCLUDED.storage_key,
+			content_type = EXCLUDED.content_type,
+			size = EXCLUDED.size,
+			created_at = EXCLUDED.created_at,
+			data_region = EXCLUDED.data_region
+	`
+	_, err := r.db.ExecContext(ctx, query,
+		artifact.EntityType,
+		artifact.EntityID,
+		artifact.Version,
+		artifact.Driver,
+		artifact.StorageKey,
+		artifact.ContentType,
+		artifact.Size,
+		artifact.CreatedAt,
+		artifact.Region,
+	)
+	return err
+}
@@ -6,21 +6,41 @@ import (
 	"encoding/json"
 	"time"
 
+	"github.com/raflytch/careerly-server/internal/config"
+	"github.com/raflytch/careerly-server/internal/database"
 	"github.com/raflytch/careerly-server/internal/domain"
 
 	"github.com/google/uuid"
 )
 
 const (
-	atsCheckColumns = `id, user_id, score, analysis, created_at, deleted_at`
+	atsCheckColumns = `id, user_id, resume_id, score, analysis, created_at, deleted_at`
+
+	// onPaidPlan matches rows of t whose user has a paid subscription or
+	// belongs to an organization on a paid plan at $2.
+	onPaidPlan = `(
+		EXISTS (
+			SELECT 1 FROM subscriptions s
+			JOIN plans p ON p.id = s.plan_id
+			WHERE s.user_id = t.user_id AND s.status = 'active' AND s.end_date > $2
+			  AND s.deleted_at IS NULL AND p.price > 0
+		)
+		OR EXISTS (
+			SELECT 1 FROM organization_members m
+			JOIN organizations o ON o.id = m.organization_id
+			JOIN plans p ON p.id = o.plan_id
+			WHERE m.user_id = t.user_id AND o.period_end > $2 AND p.price > 0
+		)
+	)`
 )
 
 type atsCheckRepository struct {
-	db *sql.DB
+	db      *database.Router
+	sources regionTables
 }
 
-func NewATSCheckRepository(db *sql.DB) domain.ATSCheckRepository {
-	return &atsCheckRepository{db: db}
+func NewATSCheckRepository(db *database.Router, regions config.DataRegionConfig) domain.ATSCheckRepository {
+	return &atsCheckRepository{db: db, sources: newRegionTables(regions, "ats_check_sources")}
 }
 
 func (r *atsCheckRepository) Create(ctx context.Context, check *domain.ATSCheck) error {
@@ -30,12 +50,13 @@ func (r *atsCheckRepository) Create(ctx context.Context, check *domain.ATSCheck)
 	}
 
 	query := `
-		INSERT INTO ats_checks (id, user_id, score, analysis, created_at)
-		VALUES ($1, $2, $3, $4, $5)
+		INSERT INTO ats_checks (id, user_id, resume_id, score, analysis, created_at)
+		VALUES ($1, $2, $3, $4, $5, $6)
 	`
 	_, err = r.db.ExecContext(ctx, query,
 		check.ID,
 		check.UserID,
+		check.ResumeID,
 		check.Score,
 		analysisJSON,
 		check.CreatedAt,
@@ -60,7 +81,7 @@ func (r *atsCheckRepository) FindByUserID(ctx context.Context, userID uuid.UUID,
 		ORDER BY created_at DESC
 		LIMIT $2 OFFSET $3
 	`
-	rows, err := r.db.QueryContext(ctx, query, userID, limit, offset)
+	rows, err := r.db.QueryReadContext(ctx, query, userID, limit, offset)
 	if err != nil {
 		return nil, err
 	}
@@ -80,7 +101,7 @@ func (r *atsCheckRepository) FindByUserID(ctx context.Context, userID uuid.UUID,
 func (r *atsCheckRepository) CountByUserID(ctx context.Context, userID uuid.UUID) (int64, error) {
 	query := `SELECT COUNT(id) FROM ats_checks WHERE user_id = $1 AND deleted_at IS NULL`
 	var count int64
-	err := r.db.QueryRowContext(ctx, query, userID).Scan(&count)
+	err := r.db.QueryRowReadContext(ctx, query, userID).Scan(&count)
 	return count, err
 }
 
@@ -94,6 +115,64 @@ func (r *atsCheckRepository) SoftDelete(ctx context.Context, id uuid.UUID) error
 	return err
 }
 
+// PurgeFreeBefore permanently removes checks created before the cutoff by
+// users who are not on a paid plan.
+func (r *atsCheckRepository) PurgeFreeBefore(ctx context.Context, before time.Time) (int64, error) {
+	query := `
+		DELETE FROM ats_checks t
+		WHERE t.created_at < $1 AND NOT ` + onPaidPlan + `
+	`
+	result, err := r.db.ExecContext(ctx, query, before, time.Now())
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected()
+}
+
+// SaveSource keeps the uploaded file a check was run on, so it can be
+// annotated later, in the data region of the check's owner. It is removed
+// along with the check.
+func (r *atsCheckRepository) SaveSource(ctx context.Context, checkID uuid.UUID, data []byte) error {
+	regionQuery := `
+		SELECT u.data_region
+		FROM ats_checks c
+		JOIN users u ON u.id = c.user_id
+		WHERE c.id = $1
+	`
+	var region string
+	if err := r.db.QueryRowContext(ctx, regionQuery, checkID).Scan(&region); err != nil {
+		return err
+	}
+
+	table := "ats_check_sources"
+	if region != "" {
+		var err error
+		if table, err = r.sources.table(region); err != nil {
+			return err
+		}
+	}
+
+	query := `
+		INSERT INTO ` + table + ` (ats_check_id, content, created_at)
+		VALUES ($1, $2, $3)
+		ON CONFLICT (ats_check_id) DO UPDATE SET content = EXCLUDED.content
+	`
+	_, err := r.db.ExecContext(ctx, query, checkID, data, time.Now())
+	return err
+}
+
+// FindSource looks in every region, since the owner's region may have
+// changed since the source was saved.
+func (r *atsCheckRepository) FindSource(ctx context.Context, checkID uuid.UUID) ([]byte, error) {
+	query := `SELECT content FROM ats_check_sources WHERE ats_check_id = $1`
+	for _, table := range r.sources {
+		query += ` UNION ALL SELECT content FROM ` + table + ` WHERE ats_check_id = $1`
+	}
+	var data []byte
+	err := r.db.QueryRowContext(ctx, query+` LIMIT 1`, checkID).Scan(&data)
+	return data, err
+}
+
 func (r *atsCheckRepository) scanATSCheck(row *sql.Row) (*domain.ATSCheck, error) {
 	var check domain.ATSCheck
 	var analysisJSON []byte
@@ -101,6 +180,7 @@ func (r *atsCheckRepository) scanATSCheck(row *sql.Row) (*domain.ATSCheck, error
 	err := row.Scan(
 		&check.ID,
 		&check.UserID,
+		&check.ResumeID,
 		&check.Score,
 		&analysisJSON,
 		&check.CreatedAt,
@@ -128,6 +208,7 @@ func (r *atsCheckRepository) scanATSCheckFromRows(rows *sql.Rows) (*domain.ATSCh
 	err := rows.Scan(
 		&check.ID,
 		&check.UserID,
+		&check.ResumeID,
 		&check.Score,
 		&analysisJSON,
 		&check.CreatedAt,
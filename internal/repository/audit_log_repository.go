@@ -0,0 +1,125 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+
+	"github.com/raflytch/careerly-server/internal/database"
+	"github.com/raflytch/careerly-server/internal/domain"
+)
+
+const (
+	auditLogColumns = `id, actor_id, action, target_type, target_id, before_snapshot, after_snapshot, ip_address, user_agent, created_at`
+	auditLogFilter  = `
+		WHERE ($1::uuid IS NULL OR actor_id = $1)
+		AND ($2 = '' OR action = $2)
+		AND ($3 = '' OR target_type = $3)
+		AND ($4::uuid IS NULL OR target_id = $4)
+		AND ($5::timestamptz IS NULL OR created_at >= $5)
+		AND ($6::timestamptz IS NULL OR created_at < $6)
+	`
+)
+
+type auditLogRepository struct {
+	db *database.Router
+}
+
+func NewAuditLogRepository(db *database.Router) domain.AuditLogRepository {
+	return &auditLogRepository{db: db}
+}
+
+func (r *auditLogRepository) Create(ctx context.Context, log *domain.AuditLog) error {
+	query := `
+		INSERT INTO audit_logs (` + auditLogColumns + `)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10)
+	`
+	_, err := r.db.ExecContext(ctx, query,
+		log.ID,
+		log.ActorID,
+		log.Action,
+		log.TargetType,
+		log.TargetID,
+		nullableJSON(log.Before),
+		nullableJSON(log.After),
+		log.IPAddress,
+		log.UserAgent,
+		log.CreatedAt,
+	)
+	return err
+}
+
+func (r *auditLogRepository) FindAll(ctx context.Context, filter domain.AuditLogFilter, limit, offset int) ([]domain.AuditLog, error) {
+	query := `
+		SELECT ` + auditLogColumns + `
+		FROM audit_logs
+	` + auditLogFilter + `
+		ORDER BY created_at DESC
+		LIMIT $7 OFFSET $8
+	`
+	args := append(auditLogFilterArgs(filter), limit, offset)
+	rows, err := r.db.QueryReadContext(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	logs := make([]domain.AuditLog, 0)
+	for rows.Next() {
+		log, err := r.scanAuditLogFromRows(rows)
+		if err != nil {
+			return nil, err
+		}
+		logs = append(logs, *log)
+	}
+	return logs, rows.Err()
+}
+
+func (r *auditLogRepository) Count(ctx context.Context, filter domain.AuditLogFilter) (int64, error) {
+	query := `SELECT COUNT(id) FROM audit_logs ` + auditLogFilter
+	var count int64
+	err := r.db.QueryRowReadContext(ctx, query, auditLogFilterArgs(filter)...).Scan(&count)
+	return count, err
+}
+
+func auditLogFilterArgs(filter domain.AuditLogFilter) []interface{} {
+	return []interface{}{
+		filter.ActorID,
+		filter.Action,
+		filter.TargetType,
+		filter.TargetID,
+		filter.From,
+		filter.To,
+	}
+}
+
+func nullableJSON(data []byte) interface{} {
+	if len(data) == 0 {
+		return nil
+	}
+	return data
+}
+
+func (r *auditLogRepository) scanAuditLogFromRows(rows *sql.Rows) (*domain.AuditLog, error) {
+	var log domain.AuditLog
+	var action string
+	var before, after []byte
+	err := rows.Scan(
+		&log.ID,
+		&log.ActorID,
+		&action,
+		&log.TargetType,
+		&log.TargetID,
+		&before,
+		&after,
+		&log.IPAddress,
+		&log.UserAgent,
+		&log.CreatedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+	log.Action = domain.AuditAction(action)
+	log.Before = before
+	log.After = after
+	return &log, nil
+}
@@ -0,0 +1,120 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"time"
+
+	"github.com/raflytch/careerly-server/internal/domain"
+
+	"github.com/google/uuid"
+)
+
+const (
+	authIdentityColumns = `id, user_id, provider, provider_user_id, email, created_at, last_used_at`
+)
+
+type authIdentityRepository struct {
+	db *sql.DB
+}
+
+func NewAuthIdentityRepository(db *sql.DB) domain.AuthIdentityRepository {
+	return &authIdentityRepository{db: db}
+}
+
+func (r *authIdentityRepository) Create(ctx context.Context, identity *domain.AuthIdentity) error {
+	query := `
+		INSERT INTO auth_identities (` + authIdentityColumns + `)
+		VALUES ($1, $2, $3, $4, $5, $6, $7)
+	`
+	_, err := r.db.ExecContext(ctx, query,
+		identity.ID,
+		identity.UserID,
+		identity.Provider,
+		identity.ProviderUserID,
+		identity.Email,
+		identity.CreatedAt,
+		identity.LastUsedAt,
+	)
+	return err
+}
+
+func (r *authIdentityRepository) FindByID(ctx context.Context, id uuid.UUID) (*domain.AuthIdentity, error) {
+	query := `
+		SELECT ` + authIdentityColumns + `
+		FROM auth_identities
+		WHERE id = $1
+	`
+	return r.scanIdentity(r.db.QueryRowContext(ctx, query, id))
+}
+
+func (r *authIdentityRepository) FindByProvider(ctx context.Context, provider domain.AuthProvider, providerUserID string) (*domain.AuthIdentity, error) {
+	query := `
+		SELECT ` + authIdentityColumns + `
+		FROM auth_identities
+		WHERE provider = $1 AND provider_user_id = $2
+	`
+	return r.scanIdentity(r.db.QueryRowContext(ctx, query, provider, providerUserID))
+}
+
+func (r *authIdentityRepository) FindByUserID(ctx context.Context, userID uuid.UUID) ([]domain.AuthIdentity, error) {
+	query := `
+		SELECT ` + authIdentityColumns + `
+		FROM auth_identities
+		WHERE user_id = $1
+		ORDER BY created_at ASC
+	`
+	rows, err := r.db.QueryContext(ctx, query, userID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	identities := make([]domain.AuthIdentity, 0)
+	for rows.Next() {
+		var identity domain.AuthIdentity
+		err := rows.Scan(
+			&identity.ID,
+			&identity.UserID,
+			&identity.Provider,
+			&identity.ProviderUserID,
+			&identity.Email,
+			&identity.CreatedAt,
+			&identity.LastUsedAt,
+		)
+		if err != nil {
+			return nil, err
+		}
+		identities = append(identities, identity)
+	}
+	return identities, rows.Err()
+}
+
+func (r *authIdentityRepository) UpdateLastUsed(ctx context.Context, id uuid.UUID) error {
+	query := `UPDATE auth_identities SET last_used_at = $1 WHERE id = $2`
+	_, err := r.db.ExecContext(ctx, query, time.Now(), id)
+	return err
+}
+
+func (r *authIdentityRepository) Delete(ctx context.Context, id uuid.UUID) error {
+	query := `DELETE FROM auth_identities WHERE id = $1`
+	_, err := r.db.ExecContext(ctx, query, id)
+	return err
+}
+
+func (r *authIdentityRepository) scanIdentity(row *sql.Row) (*domain.AuthIdentity, error) {
+	var identity domain.AuthIdentity
+	err := row.Scan(
+		&identity.ID,
+		&identity.UserID,
+		&identity.Provider,
+		&identity.ProviderUserID,
+		&identity.Email,
+		&identity.CreatedAt,
+		&identity.LastUsedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+	return &identity, nil
+}
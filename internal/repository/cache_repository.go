@@ -3,23 +3,99 @@ package repository
 import (
 	"context"
 	"encoding/json"
+	"log"
 	"time"
 
+	"github.com/raflytch/careerly-server/internal/config"
 	"github.com/raflytch/careerly-server/internal/domain"
 
+	"github.com/google/uuid"
 	"github.com/redis/go-redis/v9"
 )
 
+type cacheInvalidation struct {
+	Origin  string `json:"origin"`
+	Key     string `json:"key,omitempty"`
+	Pattern string `json:"pattern,omitempty"`
+}
+
 type cacheRepository struct {
-	client *redis.Client
+	client     *redis.Client
+	local      *localCache
+	channel    string
+	instanceID string
 }
 
-func NewCacheRepository(client *redis.Client) domain.CacheRepository {
-	return &cacheRepository{client: client}
+// NewCacheRepository returns a Redis-backed cache. When a local TTL is
+// configured, reads are also served from an in-process copy, and every write
+// or delete is broadcast on the invalidation channel so other replicas drop
+// their stale copies.
+func NewCacheRepository(ctx context.Context, client *redis.Client, cfg config.CacheConfig) domain.CacheRepository {
+	r := &cacheRepository{
+		client:     client,
+		channel:    cfg.InvalidationChannel,
+		instanceID: uuid.NewString(),
+	}
+
+	if cfg.LocalTTLSeconds > 0 {
+		r.local = newLocalCache(time.Duration(cfg.LocalTTLSeconds)*time.Second, cfg.LocalMaxEntries)
+		go r.listenForInvalidations(ctx)
+	}
+
+	return r
 }
 
 func (r *cacheRepository) Get(ctx context.Context, key string) (string, error) {
-	return r.client.Get(ctx, key).Result()
+	if r.local != nil {
+		if value, ok := r.local.get(key); ok {
+			return value, nil
+		}
+	}
+
+	value, err := r.client.Get(ctx, key).Result()
+	if err != nil {
+		return "", err
+	}
+
+	if r.local != nil {
+		ttl, ttlErr := r.client.TTL(ctx, key).Result()
+		if ttlErr == nil && ttl != -2 {
+			r.local.set(key, value, ttl)
+		}
+	}
+	return value, nil
+}
+
+// GetWithTTL returns the value along with the time left before it expires,
+// or -1 when the key has no expiry.
+func (r *cacheRepository) GetWithTTL(ctx context.Context, key string) (string, time.Duration, error) {
+	if r.local != nil {
+		if value, ttl, ok := r.local.getWithTTL(key); ok {
+			return value, ttl, nil
+		}
+	}
+
+	var getCmd *redis.StringCmd
+	var ttlCmd *redis.DurationCmd
+	_, err := r.client.Pipelined(ctx, func(pipe redis.Pipeliner) error {
+		getCmd = pipe.Get(ctx, key)
+		ttlCmd = pipe.PTTL(ctx, key)
+		return nil
+	})
+	if err != nil {
+		return "", 0, err
+	}
+
+	value := getCmd.Val()
+	ttl := ttlCmd.Val()
+	if ttl < 0 {
+		ttl = -1
+	}
+
+	if r.local != nil {
+		r.local.set(key, value, ttl)
+	}
+	return value, ttl, nil
 }
 
 func (r *cacheRepository) Set(ctx context.Context, key string, value interface{}, expiration time.Duration) error {
@@ -27,14 +103,31 @@ func (r *cacheRepository) Set(ctx context.Context, key string, value interface{}
 	if err != nil {
 		return err
 	}
-	return r.client.Set(ctx, key, data, expiration).Err()
+	if err := r.client.Set(ctx, key, data, expiration).Err(); err != nil {
+		return err
+	}
+
+	if r.local != nil {
+		r.local.set(key, string(data), expiration)
+		r.publish(ctx, cacheInvalidation{Key: key})
+	}
+	return nil
 }
 
 func (r *cacheRepository) Delete(ctx context.Context, key string) error {
+	if r.local != nil {
+		r.local.delete(key)
+		defer r.publish(ctx, cacheInvalidation{Key: key})
+	}
 	return r.client.Del(ctx, key).Err()
 }
 
 func (r *cacheRepository) DeleteByPattern(ctx context.Context, pattern string) error {
+	if r.local != nil {
+		r.local.deletePattern(pattern)
+		defer r.publish(ctx, cacheInvalidation{Pattern: pattern})
+	}
+
 	iter := r.client.Scan(ctx, 0, pattern, 0).Iterator()
 	for iter.Next(ctx) {
 		if err := r.client.Del(ctx, iter.Val()).Err(); err != nil {
@@ -43,3 +136,50 @@ func (r *cacheRepository) DeleteByPattern(ctx context.Context, pattern string) e
 	}
 	return iter.Err()
 }
+
+func (r *cacheRepository) publish(ctx context.Context, msg cacheInvalidation) {
+	msg.Origin = r.instanceID
+	payload, err := json.Marshal(msg)
+	if err != nil {
+		return
+	}
+	if err := r.client.Publish(context.WithoutCancel(ctx), r.channel, payload).Err(); err != nil {
+		log.Printf("Failed to publish cache invalidation: %v", err)
+	}
+}
+
+// listenForInvalidations keeps the local cache coherent with writes made by
+// other instances. go-redis resubscribes on its own after a dropped
+// connection, but anything published in between is missed, so the whole
+// local cache is cleared whenever the subscription is (re)established.
+func (r *cacheRepository) listenForInvalidations(ctx context.Context) {
+	pubsub := r.client.Subscribe(ctx, r.channel)
+	defer pubsub.Close()
+
+	for {
+		msg, err := pubsub.Receive(ctx)
+		if err != nil {
+			if ctx.Err() != nil {
+				return
+			}
+			r.local.deletePattern("*")
+			time.Sleep(time.Second)
+			continue
+		}
+
+		switch m := msg.(type) {
+		case *redis.Subscription:
+			r.local.deletePattern("*")
+		case *redis.Message:
+			var inv cacheInvalidation
+			if err := json.Unmarshal([]byte(m.Payload), &inv); err != nil || inv.Origin == r.instanceID {
+				continue
+			}
+			if inv.Pattern != "" {
+				r.local.deletePattern(inv.Pattern)
+			} else {
+				r.local.delete(inv.Key)
+			}
+		}
+	}
+}
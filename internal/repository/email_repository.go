@@ -0,0 +1,132 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"strings"
+	"time"
+
+	"github.com/raflytch/careerly-server/internal/domain"
+)
+
+const (
+	emailSuppressionColumns = `email, user_id, reason, provider, detail, created_at`
+)
+
+type emailRepository struct {
+	db *sql.DB
+}
+
+func NewEmailRepository(db *sql.DB) domain.EmailRepository {
+	return &emailRepository{db: db}
+}
+
+func (r *emailRepository) CreateMessage(ctx context.Context, message *domain.EmailMessage) error {
+	query := `
+		INSERT INTO email_messages (id, user_id, recipient, subject, provider, provider_message_id, status, detail, created_at, updated_at)
+		VALUES ($1, $2, $3, $4, $5, NULLIF($6, ''), $7, $8, $9, $10)
+	`
+	_, err := r.db.ExecContext(ctx, query,
+		message.ID,
+		message.UserID,
+		message.Recipient,
+		message.Subject,
+		message.Provider,
+		message.ProviderMessageID,
+		message.Status,
+		message.Detail,
+		message.CreatedAt,
+		message.UpdatedAt,
+	)
+	return err
+}
+
+func (r *emailRepository) UpdateMessageStatus(ctx context.Context, provider, providerMessageID string, status domain.EmailStatus, detail string) error {
+	query := `
+		UPDATE email_messages
+		SET status = $1, detail = $2, updated_at = $3
+		WHERE provider = $4 AND provider_message_id = $5
+	`
+	_, err := r.db.ExecContext(ctx, query, status, detail, time.Now(), provider, providerMessageID)
+	return err
+}
+
+// Suppress stops further mail to the address. Repeated bounces keep the
+// first record.
+func (r *emailRepository) Suppress(ctx context.Context, suppression *domain.EmailSuppression) error {
+	query := `
+		INSERT INTO email_suppressions (email, user_id, reason, provider, detail, created_at)
+		VALUES ($1, $2, $3, $4, $5, $6)
+		ON CONFLICT (email) DO NOTHING
+	`
+	_, err := r.db.ExecContext(ctx, query,
+		strings.ToLower(suppression.Email),
+		suppression.UserID,
+		suppression.Reason,
+		suppression.Provider,
+		suppression.Detail,
+		suppression.CreatedAt,
+	)
+	return err
+}
+
+func (r *emailRepository) IsSuppressed(ctx context.Context, email string) (bool, error) {
+	query := `SELECT EXISTS (SELECT 1 FROM email_suppressions WHERE email = $1)`
+	var exists bool
+	err := r.db.QueryRowContext(ctx, query, strings.ToLower(email)).Scan(&exists)
+	return exists, err
+}
+
+func (r *emailRepository) FindSuppressions(ctx context.Context, limit, offset int) ([]domain.EmailSuppression, error) {
+	query := `
+		SELECT ` + emailSuppressionColumns + `
+		FROM email_suppressions
+		ORDER BY created_at DESC
+		LIMIT $1 OFFSET $2
+	`
+	rows, err := r.db.QueryContext(ctx, query, limit, offset)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	suppressions := make([]domain.EmailSuppression, 0)
+	for rows.Next() {
+		var suppression domain.EmailSuppression
+		var reason string
+		err := rows.Scan(
+			&suppression.Email,
+			&suppression.UserID,
+			&reason,
+			&suppression.Provider,
+			&suppression.Detail,
+			&suppression.CreatedAt,
+		)
+		if err != nil {
+			return nil, err
+		}
+		suppression.Reason = domain.EmailStatus(reason)
+		suppressions = append(suppressions, suppression)
+	}
+	return suppressions, rows.Err()
+}
+
+func (r *emailRepository) CountSuppressions(ctx context.Context) (int64, error) {
+	query := `SELECT COUNT(email) FROM email_suppressions`
+	var count int64
+	err := r.db.QueryRowContext(ctx, query).Scan(&count)
+	return count, err
+}
+
+func (r *emailRepository) DeleteSuppression(ctx context.Context, email string) (bool, error) {
+	query := `DELETE FROM email_suppressions WHERE email = $1`
+	result, err := r.db.ExecContext(ctx, query, strings.ToLower(email))
+	if err != nil {
+		return false, err
+	}
+	rows, err := result.RowsAffected()
+	if err != nil {
+		return false, err
+	}
+	return rows > 0, nil
+}
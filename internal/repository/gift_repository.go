@@ -0,0 +1,188 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/raflytch/careerly-server/internal/domain"
+
+	"github.com/google/uuid"
+)
+
+const (
+	giftColumns = `id, transaction_id, purchaser_id, plan_id, recipient_email, code, issued_at, redeemed_by, subscription_id, redeemed_at, created_at`
+)
+
+type giftRepository struct {
+	db *sql.DB
+}
+
+func NewGiftRepository(db *sql.DB) domain.GiftRepository {
+	return &giftRepository{db: db}
+}
+
+func (r *giftRepository) CreateBatch(ctx context.Context, gifts []domain.Gift) error {
+	return insertGifts(ctx, r.db, gifts)
+}
+
+// sqlExecer is met by a connection and a transaction alike, so an insert can
+// run alone or as part of a larger write.
+type sqlExecer interface {
+	ExecContext(ctx context.Context, query string, args ...interface{}) (sql.Result, error)
+}
+
+func insertGifts(ctx context.Context, db sqlExecer, gifts []domain.Gift) error {
+	if len(gifts) == 0 {
+		return nil
+	}
+
+	values := make([]string, 0, len(gifts))
+	args := make([]interface{}, 0, len(gifts)*6)
+	for i, gift := range gifts {
+		n := i * 6
+		values = append(values, fmt.Sprintf("($%d, $%d, $%d, $%d, $%d, $%d)", n+1, n+2, n+3, n+4, n+5, n+6))
+		args = append(args, gift.ID, gift.TransactionID, gift.PurchaserID, gift.PlanID, gift.RecipientEmail, gift.CreatedAt)
+	}
+
+	query := `
+		INSERT INTO gifts (id, transaction_id, purchaser_id, plan_id, recipient_email, created_at)
+		VALUES ` + strings.Join(values, ", ")
+	_, err := db.ExecContext(ctx, query, args...)
+	return err
+}
+
+func (r *giftRepository) FindByTransactionID(ctx context.Context, transactionID uuid.UUID) ([]domain.Gift, error) {
+	query := `
+		SELECT ` + giftColumns + `
+		FROM gifts
+		WHERE transaction_id = $1
+		ORDER BY created_at ASC, recipient_email ASC
+	`
+	return r.queryGifts(ctx, query, transactionID)
+}
+
+func (r *giftRepository) FindByPurchaserID(ctx context.Context, purchaserID uuid.UUID) ([]domain.Gift, error) {
+	query := `
+		SELECT ` + giftColumns + `
+		FROM gifts
+		WHERE purchaser_id = $1 AND issued_at IS NOT NULL
+		ORDER BY issued_at DESC, recipient_email ASC
+	`
+	return r.queryGifts(ctx, query, purchaserID)
+}
+
+func (r *giftRepository) FindByCode(ctx context.Context, code string) (*domain.Gift, error) {
+	query := `
+		SELECT ` + giftColumns + `
+		FROM gifts
+		WHERE code = $1
+	`
+	return r.scanGift(r.db.QueryRowContext(ctx, query, code))
+}
+
+// Issue sets the redemption code once; a gift that already has one is left
+// alone so re-running provisioning does not send new codes.
+func (r *giftRepository) Issue(ctx context.Context, id uuid.UUID, code string, issuedAt time.Time) (bool, error) {
+	query := `
+		UPDATE gifts
+		SET code = $2, issued_at = $3
+		WHERE id = $1 AND code IS NULL
+	`
+	return r.execAffected(ctx, query, id, code, issuedAt)
+}
+
+// Redeem claims the gift for userID. It reports false when another request
+// redeemed it first.
+func (r *giftRepository) Redeem(ctx context.Context, id, userID, subscriptionID uuid.UUID, redeemedAt time.Time) (bool, error) {
+	query := `
+		UPDATE gifts
+		SET redeemed_by = $2, subscription_id = $3, redeemed_at = $4
+		WHERE id = $1 AND redeemed_at IS NULL
+	`
+	return r.execAffected(ctx, query, id, userID, subscriptionID, redeemedAt)
+}
+
+func (r *giftRepository) Unredeem(ctx context.Context, id uuid.UUID) error {
+	query := `
+		UPDATE gifts
+		SET redeemed_by = NULL, subscription_id = NULL, redeemed_at = NULL
+		WHERE id = $1
+	`
+	_, err := r.db.ExecContext(ctx, query, id)
+	return err
+}
+
+func (r *giftRepository) execAffected(ctx context.Context, query string, args ...interface{}) (bool, error) {
+	result, err := r.db.ExecContext(ctx, query, args...)
+	if err != nil {
+		return false, err
+	}
+	rows, err := result.RowsAffected()
+	if err != nil {
+		return false, err
+	}
+	return rows > 0, nil
+}
+
+func (r *giftRepository) queryGifts(ctx context.Context, query string, args ...interface{}) ([]domain.Gift, error) {
+	rows, err := r.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	gifts := make([]domain.Gift, 0)
+	for rows.Next() {
+		gift, err := r.scanGiftFromRows(rows)
+		if err != nil {
+			return nil, err
+		}
+		gifts = append(gifts, *gift)
+	}
+	return gifts, rows.Err()
+}
+
+func (r *giftRepository) scanGift(row *sql.Row) (*domain.Gift, error) {
+	var gift domain.Gift
+	err := row.Scan(
+		&gift.ID,
+		&gift.TransactionID,
+		&gift.PurchaserID,
+		&gift.PlanID,
+		&gift.RecipientEmail,
+		&gift.Code,
+		&gift.IssuedAt,
+		&gift.RedeemedBy,
+		&gift.SubscriptionID,
+		&gift.RedeemedAt,
+		&gift.CreatedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+	return &gift, nil
+}
+
+func (r *giftRepository) scanGiftFromRows(rows *sql.Rows) (*domain.Gift, error) {
+	var gift domain.Gift
+	err := rows.Scan(
+		&gift.ID,
+		&gift.TransactionID,
+		&gift.PurchaserID,
+		&gift.PlanID,
+		&gift.RecipientEmail,
+		&gift.Code,
+		&gift.IssuedAt,
+		&gift.RedeemedBy,
+		&gift.SubscriptionID,
+		&gift.RedeemedAt,
+		&gift.CreatedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+	return &gift, nil
+}
@@ -0,0 +1,198 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"time"
+
+	"github.com/raflytch/careerly-server/internal/domain"
+
+	"github.com/google/uuid"
+)
+
+const (
+	interviewPackColumns = `id, title, description, job_position, questions, rubric, is_active, created_at, updated_at, deleted_at`
+)
+
+type interviewPackRepository struct {
+	db *sql.DB
+}
+
+func NewInterviewPackRepository(db *sql.DB) domain.InterviewPackRepository {
+	return &interviewPackRepository{db: db}
+}
+
+func (r *interviewPackRepository) Create(ctx context.Context, pack *domain.InterviewPack) error {
+	questionsJSON, err := json.Marshal(pack.Questions)
+	if err != nil {
+		return err
+	}
+	rubricJSON, err := json.Marshal(pack.Rubric)
+	if err != nil {
+		return err
+	}
+
+	query := `
+		INSERT INTO interview_packs (id, title, description, job_position, questions, rubric, is_active, created_at, updated_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9)
+	`
+	_, err = r.db.ExecContext(ctx, query,
+		pack.ID,
+		pack.Title,
+		pack.Description,
+		pack.JobPosition,
+		questionsJSON,
+		rubricJSON,
+		pack.IsActive,
+		pack.CreatedAt,
+		pack.UpdatedAt,
+	)
+	return err
+}
+
+func (r *interviewPackRepository) FindByID(ctx context.Context, id uuid.UUID) (*domain.InterviewPack, error) {
+	query := `
+		SELECT ` + interviewPackColumns + `
+		FROM interview_packs
+		WHERE id = $1 AND deleted_at IS NULL
+	`
+	return r.scanInterviewPack(r.db.QueryRowContext(ctx, query, id))
+}
+
+func (r *interviewPackRepository) FindAll(ctx context.Context, limit, offset int, includeInactive bool) ([]domain.InterviewPack, error) {
+	query := `
+		SELECT ` + interviewPackColumns + `
+		FROM interview_packs
+		WHERE deleted_at IS NULL
+	`
+	if !includeInactive {
+		query += ` AND is_active = true`
+	}
+	query += `
+		ORDER BY title ASC
+		LIMIT $1 OFFSET $2
+	`
+
+	rows, err := r.db.QueryContext(ctx, query, limit, offset)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	packs := make([]domain.InterviewPack, 0)
+	for rows.Next() {
+		pack, err := r.scanInterviewPackFromRows(rows)
+		if err != nil {
+			return nil, err
+		}
+		packs = append(packs, *pack)
+	}
+	return packs, rows.Err()
+}
+
+func (r *interviewPackRepository) Count(ctx context.Context, includeInactive bool) (int64, error) {
+	query := `SELECT COUNT(id) FROM interview_packs WHERE deleted_at IS NULL`
+	if !includeInactive {
+		query += ` AND is_active = true`
+	}
+	var count int64
+	err := r.db.QueryRowContext(ctx, query).Scan(&count)
+	return count, err
+}
+
+func (r *interviewPackRepository) Update(ctx context.Context, pack *domain.InterviewPack) error {
+	questionsJSON, err := json.Marshal(pack.Questions)
+	if err != nil {
+		return err
+	}
+	rubricJSON, err := json.Marshal(pack.Rubric)
+	if err != nil {
+		return err
+	}
+
+	query := `
+		UPDATE interview_packs
+		SET title = $1, description = $2, job_position = $3, questions = $4, rubric = $5, is_active = $6, updated_at = $7
+		WHERE id = $8 AND deleted_at IS NULL
+	`
+	_, err = r.db.ExecContext(ctx, query,
+		pack.Title,
+		pack.Description,
+		pack.JobPosition,
+		questionsJSON,
+		rubricJSON,
+		pack.IsActive,
+		pack.UpdatedAt,
+		pack.ID,
+	)
+	return err
+}
+
+func (r *interviewPackRepository) SoftDelete(ctx context.Context, id uuid.UUID) error {
+	query := `
+		UPDATE interview_packs
+		SET deleted_at = $1
+		WHERE id = $2 AND deleted_at IS NULL
+	`
+	_, err := r.db.ExecContext(ctx, query, time.Now(), id)
+	return err
+}
+
+func (r *interviewPackRepository) scanInterviewPack(row *sql.Row) (*domain.InterviewPack, error) {
+	var pack domain.InterviewPack
+	var questionsJSON, rubricJSON []byte
+	err := row.Scan(
+		&pack.ID,
+		&pack.Title,
+		&pack.Description,
+		&pack.JobPosition,
+		&questionsJSON,
+		&rubricJSON,
+		&pack.IsActive,
+		&pack.CreatedAt,
+		&pack.UpdatedAt,
+		&pack.DeletedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := json.Unmarshal(questionsJSON, &pack.Questions); err != nil {
+		return nil, err
+	}
+	if err := unmarshalRubric(rubricJSON, &pack.Rubric); err != nil {
+		return nil, err
+	}
+
+	return &pack, nil
+}
+
+func (r *interviewPackRepository) scanInterviewPackFromRows(rows *sql.Rows) (*domain.InterviewPack, error) {
+	var pack domain.InterviewPack
+	var questionsJSON, rubricJSON []byte
+	err := rows.Scan(
+		&pack.ID,
+		&pack.Title,
+		&pack.Description,
+		&pack.JobPosition,
+		&questionsJSON,
+		&rubricJSON,
+		&pack.IsActive,
+		&pack.CreatedAt,
+		&pack.UpdatedAt,
+		&pack.DeletedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := json.Unmarshal(questionsJSON, &pack.Questions); err != nil {
+		return nil, err
+	}
+	if err := unmarshalRubric(rubricJSON, &pack.Rubric); err != nil {
+		return nil, err
+	}
+
+	return &pack, nil
+}
@@ -6,20 +6,21 @@ import (
 	"encoding/json"
 	"time"
 
+	"github.com/raflytch/careerly-server/internal/database"
 	"github.com/raflytch/careerly-server/internal/domain"
 
 	"github.com/google/uuid"
 )
 
 const (
-	interviewColumns = `id, user_id, job_position, questions, status, overall_score, created_at, completed_at, deleted_at`
+	interviewColumns = `id, user_id, job_position, language, questions, status, is_adaptive, target_question_count, pack_id, rubric, overall_score, scheduled_at, reminder_sent_at, created_at, completed_at, deleted_at`
 )
 
 type interviewRepository struct {
-	db *sql.DB
+	db *database.Router
 }
 
-func NewInterviewRepository(db *sql.DB) domain.InterviewRepository {
+func NewInterviewRepository(db *database.Router) domain.InterviewRepository {
 	return &interviewRepository{db: db}
 }
 
@@ -28,17 +29,27 @@ func (r *interviewRepository) Create(ctx context.Context, interview *domain.Inte
 	if err != nil {
 		return err
 	}
+	rubricJSON, err := json.Marshal(interview.Rubric)
+	if err != nil {
+		return err
+	}
 
 	query := `
-		INSERT INTO interviews (id, user_id, job_position, questions, status, created_at)
-		VALUES ($1, $2, $3, $4, $5, $6)
+		INSERT INTO interviews (id, user_id, job_position, language, questions, status, is_adaptive, target_question_count, pack_id, rubric, scheduled_at, created_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12)
 	`
 	_, err = r.db.ExecContext(ctx, query,
 		interview.ID,
 		interview.UserID,
 		interview.JobPosition,
+		interview.Language,
 		questionsJSON,
 		interview.Status,
+		interview.Adaptive,
+		interview.TargetQuestionCount,
+		interview.PackID,
+		rubricJSON,
+		interview.ScheduledAt,
 		interview.CreatedAt,
 	)
 	return err
@@ -61,7 +72,42 @@ func (r *interviewRepository) FindByUserID(ctx context.Context, userID uuid.UUID
 		ORDER BY created_at DESC
 		LIMIT $2 OFFSET $3
 	`
-	rows, err := r.db.QueryContext(ctx, query, userID, limit, offset)
+	rows, err := r.db.QueryReadContext(ctx, query, userID, limit, offset)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	interviews := make([]domain.Interview, 0)
+	for rows.Next() {
+		interview, err := r.scanInterviewFromRows(rows)
+		if err != nil {
+			return nil, err
+		}
+		interviews = append(interviews, *interview)
+	}
+	return interviews, rows.Err()
+}
+
+// FindCompletedByPosition returns the user's most recent completed
+// interviews whose position matches, ignoring case and surrounding space,
+// oldest first.
+func (r *interviewRepository) FindCompletedByPosition(ctx context.Context, userID uuid.UUID, position string, limit int) ([]domain.Interview, error) {
+	query := `
+		SELECT ` + interviewColumns + `
+		FROM (
+			SELECT ` + interviewColumns + `
+			FROM interviews
+			WHERE user_id = $1
+			  AND status = 'completed'
+			  AND deleted_at IS NULL
+			  AND LOWER(TRIM(job_position)) = LOWER(TRIM($2))
+			ORDER BY completed_at DESC
+			LIMIT $3
+		) recent
+		ORDER BY completed_at ASC
+	`
+	rows, err := r.db.QueryReadContext(ctx, query, userID, position, limit)
 	if err != nil {
 		return nil, err
 	}
@@ -81,10 +127,58 @@ func (r *interviewRepository) FindByUserID(ctx context.Context, userID uuid.UUID
 func (r *interviewRepository) CountByUserID(ctx context.Context, userID uuid.UUID) (int64, error) {
 	query := `SELECT COUNT(id) FROM interviews WHERE user_id = $1 AND deleted_at IS NULL`
 	var count int64
-	err := r.db.QueryRowContext(ctx, query, userID).Scan(&count)
+	err := r.db.QueryRowReadContext(ctx, query, userID).Scan(&count)
 	return count, err
 }
 
+func (r *interviewRepository) FindScheduledForReminder(ctx context.Context, before time.Time, limit int) ([]domain.Interview, error) {
+	query := `
+		SELECT ` + interviewColumns + `
+		FROM interviews
+		WHERE status = $1 AND reminder_sent_at IS NULL AND scheduled_at <= $2 AND deleted_at IS NULL
+		ORDER BY scheduled_at ASC
+		LIMIT $3
+	`
+	rows, err := r.db.QueryContext(ctx, query, domain.InterviewStatusScheduled, before, limit)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	interviews := make([]domain.Interview, 0)
+	for rows.Next() {
+		interview, err := r.scanInterviewFromRows(rows)
+		if err != nil {
+			return nil, err
+		}
+		interviews = append(interviews, *interview)
+	}
+	return interviews, rows.Err()
+}
+
+func (r *interviewRepository) MarkReminderSent(ctx context.Context, id uuid.UUID, sentAt time.Time) error {
+	query := `
+		UPDATE interviews
+		SET reminder_sent_at = $1
+		WHERE id = $2 AND deleted_at IS NULL
+	`
+	_, err := r.db.ExecContext(ctx, query, sentAt, id)
+	return err
+}
+
+func (r *interviewRepository) MarkScheduledReady(ctx context.Context, now time.Time) (int64, error) {
+	query := `
+		UPDATE interviews
+		SET status = $1
+		WHERE status = $2 AND scheduled_at <= $3 AND deleted_at IS NULL
+	`
+	result, err := r.db.ExecContext(ctx, query, domain.InterviewStatusReady, domain.InterviewStatusScheduled, now)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected()
+}
+
 func (r *interviewRepository) Update(ctx context.Context, interview *domain.Interview) error {
 	questionsJSON, err := json.Marshal(interview.Questions)
 	if err != nil {
@@ -116,17 +210,116 @@ func (r *interviewRepository) SoftDelete(ctx context.Context, id uuid.UUID) erro
 	return err
 }
 
+func (r *interviewRepository) FindDeletedByID(ctx context.Context, id uuid.UUID) (*domain.Interview, error) {
+	query := `
+		SELECT ` + interviewColumns + `
+		FROM interviews
+		WHERE id = $1 AND deleted_at IS NOT NULL
+	`
+	return r.scanInterview(r.db.QueryRowContext(ctx, query, id))
+}
+
+func (r *interviewRepository) FindDeletedByUserID(ctx context.Context, userID uuid.UUID, limit, offset int) ([]domain.Interview, error) {
+	query := `
+		SELECT ` + interviewColumns + `
+		FROM interviews
+		WHERE user_id = $1 AND deleted_at IS NOT NULL
+		ORDER BY deleted_at DESC
+		LIMIT $2 OFFSET $3
+	`
+	rows, err := r.db.QueryReadContext(ctx, query, userID, limit, offset)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	interviews := make([]domain.Interview, 0)
+	for rows.Next() {
+		interview, err := r.scanInterviewFromRows(rows)
+		if err != nil {
+			return nil, err
+		}
+		interviews = append(interviews, *interview)
+	}
+	return interviews, rows.Err()
+}
+
+func (r *interviewRepository) CountDeletedByUserID(ctx context.Context, userID uuid.UUID) (int64, error) {
+	query := `SELECT COUNT(id) FROM interviews WHERE user_id = $1 AND deleted_at IS NOT NULL`
+	var count int64
+	err := r.db.QueryRowReadContext(ctx, query, userID).Scan(&count)
+	return count, err
+}
+
+func (r *interviewRepository) Restore(ctx context.Context, id uuid.UUID) error {
+	query := `
+		UPDATE interviews
+		SET deleted_at = NULL
+		WHERE id = $1 AND deleted_at IS NOT NULL
+	`
+	result, err := r.db.ExecContext(ctx, query, id)
+	if err != nil {
+		return err
+	}
+	affected, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if affected == 0 {
+		return sql.ErrNoRows
+	}
+	return nil
+}
+
+// interviewPurgeDependents remove the mentor shares and comments of purged
+// interviews, which would otherwise block their deletion.
+var interviewPurgeDependents = []string{
+	`DELETE FROM interview_mentor_comments WHERE interview_id = ANY($1::uuid[])`,
+	`DELETE FROM interview_shares WHERE interview_id = ANY($1::uuid[])`,
+}
+
+// PurgeDeletedBefore permanently removes interviews that were soft-deleted
+// before the given time.
+func (r *interviewRepository) PurgeDeletedBefore(ctx context.Context, before time.Time) (int64, error) {
+	query := `
+		SELECT id FROM interviews
+		WHERE deleted_at IS NOT NULL AND deleted_at < $1
+		FOR UPDATE
+	`
+	return purgeRows(ctx, r.db, "interviews", query, []interface{}{before}, interviewPurgeDependents)
+}
+
+// PurgeFreeBefore permanently removes interviews created before the cutoff
+// by users who are not on a paid plan. Interviews still scheduled after the
+// cutoff are kept.
+func (r *interviewRepository) PurgeFreeBefore(ctx context.Context, before time.Time) (int64, error) {
+	query := `
+		SELECT t.id FROM interviews t
+		WHERE t.created_at < $1 AND (t.scheduled_at IS NULL OR t.scheduled_at < $1)
+		  AND NOT ` + onPaidPlan + `
+		FOR UPDATE OF t
+	`
+	return purgeRows(ctx, r.db, "interviews", query, []interface{}{before, time.Now()}, interviewPurgeDependents)
+}
+
 func (r *interviewRepository) scanInterview(row *sql.Row) (*domain.Interview, error) {
 	var interview domain.Interview
-	var questionsJSON []byte
+	var questionsJSON, rubricJSON []byte
 	var status string
 	err := row.Scan(
 		&interview.ID,
 		&interview.UserID,
 		&interview.JobPosition,
+		&interview.Language,
 		&questionsJSON,
 		&status,
+		&interview.Adaptive,
+		&interview.TargetQuestionCount,
+		&interview.PackID,
+		&rubricJSON,
 		&interview.OverallScore,
+		&interview.ScheduledAt,
+		&interview.ReminderSentAt,
 		&interview.CreatedAt,
 		&interview.CompletedAt,
 		&interview.DeletedAt,
@@ -140,21 +333,31 @@ func (r *interviewRepository) scanInterview(row *sql.Row) (*domain.Interview, er
 	if err := json.Unmarshal(questionsJSON, &interview.Questions); err != nil {
 		return nil, err
 	}
+	if err := unmarshalRubric(rubricJSON, &interview.Rubric); err != nil {
+		return nil, err
+	}
 
 	return &interview, nil
 }
 
 func (r *interviewRepository) scanInterviewFromRows(rows *sql.Rows) (*domain.Interview, error) {
 	var interview domain.Interview
-	var questionsJSON []byte
+	var questionsJSON, rubricJSON []byte
 	var status string
 	err := rows.Scan(
 		&interview.ID,
 		&interview.UserID,
 		&interview.JobPosition,
+		&interview.Language,
 		&questionsJSON,
 		&status,
+		&interview.Adaptive,
+		&interview.TargetQuestionCount,
+		&interview.PackID,
+		&rubricJSON,
 		&interview.OverallScore,
+		&interview.ScheduledAt,
+		&interview.ReminderSentAt,
 		&interview.CreatedAt,
 		&interview.CompletedAt,
 		&interview.DeletedAt,
@@ -168,6 +371,18 @@ func (r *interviewRepository) scanInterviewFromRows(rows *sql.Rows) (*domain.Int
 	if err := json.Unmarshal(questionsJSON, &interview.Questions); err != nil {
 		return nil, err
 	}
+	if err := unmarshalRubric(rubricJSON, &interview.Rubric); err != nil {
+		return nil, err
+	}
 
 	return &interview, nil
 }
+
+// unmarshalRubric leaves the rubric empty for rows created before rubrics
+// were stored.
+func unmarshalRubric(data []byte, rubric *[]domain.RubricCriterion) error {
+	if len(data) == 0 {
+		return nil
+	}
+	return json.Unmarshal(data, rubric)
+}
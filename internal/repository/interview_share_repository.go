@@ -0,0 +1,125 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"time"
+
+	"github.com/raflytch/careerly-server/internal/domain"
+
+	"github.com/google/uuid"
+)
+
+const (
+	interviewShareColumns = `id, interview_id, user_id, allow_comments, expires_at, revoked_at, created_at`
+	mentorCommentColumns  = `id, interview_id, share_id, mentor_name, question_id, comment, created_at`
+)
+
+type interviewShareRepository struct {
+	db *sql.DB
+}
+
+func NewInterviewShareRepository(db *sql.DB) domain.InterviewShareRepository {
+	return &interviewShareRepository{db: db}
+}
+
+func (r *interviewShareRepository) Create(ctx context.Context, share *domain.InterviewShare) error {
+	query := `
+		INSERT INTO interview_shares (` + interviewShareColumns + `)
+		VALUES ($1, $2, $3, $4, $5, $6, $7)
+	`
+	_, err := r.db.ExecContext(ctx, query,
+		share.ID,
+		share.InterviewID,
+		share.UserID,
+		share.AllowComments,
+		share.ExpiresAt,
+		share.RevokedAt,
+		share.CreatedAt,
+	)
+	return err
+}
+
+func (r *interviewShareRepository) FindByID(ctx context.Context, id uuid.UUID) (*domain.InterviewShare, error) {
+	query := `
+		SELECT ` + interviewShareColumns + `
+		FROM interview_shares
+		WHERE id = $1
+	`
+	var share domain.InterviewShare
+	err := r.db.QueryRowContext(ctx, query, id).Scan(
+		&share.ID,
+		&share.InterviewID,
+		&share.UserID,
+		&share.AllowComments,
+		&share.ExpiresAt,
+		&share.RevokedAt,
+		&share.CreatedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+	return &share, nil
+}
+
+func (r *interviewShareRepository) Revoke(ctx context.Context, id uuid.UUID, revokedAt time.Time) error {
+	query := `UPDATE interview_shares SET revoked_at = $2 WHERE id = $1 AND revoked_at IS NULL`
+	_, err := r.db.ExecContext(ctx, query, id, revokedAt)
+	return err
+}
+
+func (r *interviewShareRepository) CreateComment(ctx context.Context, comment *domain.MentorComment) error {
+	query := `
+		INSERT INTO interview_mentor_comments (` + mentorCommentColumns + `)
+		VALUES ($1, $2, $3, $4, $5, $6, $7)
+	`
+	_, err := r.db.ExecContext(ctx, query,
+		comment.ID,
+		comment.InterviewID,
+		comment.ShareID,
+		comment.MentorName,
+		comment.QuestionID,
+		comment.Comment,
+		comment.CreatedAt,
+	)
+	return err
+}
+
+func (r *interviewShareRepository) FindCommentsByInterviewID(ctx context.Context, interviewID uuid.UUID) ([]domain.MentorComment, error) {
+	query := `
+		SELECT ` + mentorCommentColumns + `
+		FROM interview_mentor_comments
+		WHERE interview_id = $1
+		ORDER BY created_at ASC
+	`
+	rows, err := r.db.QueryContext(ctx, query, interviewID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	comments := make([]domain.MentorComment, 0)
+	for rows.Next() {
+		var comment domain.MentorComment
+		if err := rows.Scan(
+			&comment.ID,
+			&comment.InterviewID,
+			&comment.ShareID,
+			&comment.MentorName,
+			&comment.QuestionID,
+			&comment.Comment,
+			&comment.CreatedAt,
+		); err != nil {
+			return nil, err
+		}
+		comments = append(comments, comment)
+	}
+	return comments, rows.Err()
+}
+
+func (r *interviewShareRepository) CountCommentsByShareID(ctx context.Context, shareID uuid.UUID) (int64, error) {
+	query := `SELECT COUNT(*) FROM interview_mentor_comments WHERE share_id = $1`
+	var count int64
+	err := r.db.QueryRowContext(ctx, query, shareID).Scan(&count)
+	return count, err
+}
@@ -0,0 +1,116 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"time"
+
+	"github.com/raflytch/careerly-server/internal/domain"
+
+	"github.com/google/uuid"
+)
+
+const studyPlanColumns = `id, interview_id, user_id, summary, weak_areas, topics, tasks, duration_days, created_at, updated_at`
+
+type studyPlanRepository struct {
+	db *sql.DB
+}
+
+func NewStudyPlanRepository(db *sql.DB) domain.StudyPlanRepository {
+	return &studyPlanRepository{db: db}
+}
+
+// Upsert keeps one plan per interview; regenerating replaces its content
+// and progress but keeps the original id and created_at.
+func (r *studyPlanRepository) Upsert(ctx context.Context, plan *domain.StudyPlan) error {
+	weakAreasJSON, err := json.Marshal(plan.WeakAreas)
+	if err != nil {
+		return err
+	}
+	topicsJSON, err := json.Marshal(plan.Topics)
+	if err != nil {
+		return err
+	}
+	tasksJSON, err := json.Marshal(plan.Tasks)
+	if err != nil {
+		return err
+	}
+
+	query := `
+		INSERT INTO interview_study_plans (` + studyPlanColumns + `)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10)
+		ON CONFLICT (interview_id) DO UPDATE SET
+			summary = EXCLUDED.summary,
+			weak_areas = EXCLUDED.weak_areas,
+			topics = EXCLUDED.topics,
+			tasks = EXCLUDED.tasks,
+			duration_days = EXCLUDED.duration_days,
+			updated_at = EXCLUDED.updated_at
+		RETURNING id, created_at
+	`
+	return r.db.QueryRowContext(ctx, query,
+		plan.ID,
+		plan.InterviewID,
+		plan.UserID,
+		plan.Summary,
+		weakAreasJSON,
+		topicsJSON,
+		tasksJSON,
+		plan.DurationDays,
+		plan.CreatedAt,
+		plan.UpdatedAt,
+	).Scan(&plan.ID, &plan.CreatedAt)
+}
+
+func (r *studyPlanRepository) FindByInterviewID(ctx context.Context, interviewID uuid.UUID) (*domain.StudyPlan, error) {
+	query := `
+		SELECT ` + studyPlanColumns + `
+		FROM interview_study_plans
+		WHERE interview_id = $1
+	`
+	return r.scanStudyPlan(r.db.QueryRowContext(ctx, query, interviewID))
+}
+
+func (r *studyPlanRepository) UpdateTasks(ctx context.Context, id uuid.UUID, tasks []domain.StudyPlanTask, updatedAt time.Time) error {
+	tasksJSON, err := json.Marshal(tasks)
+	if err != nil {
+		return err
+	}
+
+	query := `UPDATE interview_study_plans SET tasks = $2, updated_at = $3 WHERE id = $1`
+	_, err = r.db.ExecContext(ctx, query, id, tasksJSON, updatedAt)
+	return err
+}
+
+func (r *studyPlanRepository) scanStudyPlan(row *sql.Row) (*domain.StudyPlan, error) {
+	var plan domain.StudyPlan
+	var weakAreasJSON, topicsJSON, tasksJSON []byte
+	err := row.Scan(
+		&plan.ID,
+		&plan.InterviewID,
+		&plan.UserID,
+		&plan.Summary,
+		&weakAreasJSON,
+		&topicsJSON,
+		&tasksJSON,
+		&plan.DurationDays,
+		&plan.CreatedAt,
+		&plan.UpdatedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := json.Unmarshal(weakAreasJSON, &plan.WeakAreas); err != nil {
+		return nil, err
+	}
+	if err := json.Unmarshal(topicsJSON, &plan.Topics); err != nil {
+		return nil, err
+	}
+	if err := json.Unmarshal(tasksJSON, &plan.Tasks); err != nil {
+		return nil, err
+	}
+
+	return &plan, nil
+}
@@ -0,0 +1,108 @@
+package repository
+
+import (
+	"path"
+	"sync"
+	"time"
+)
+
+type localCacheEntry struct {
+	value     string
+	expiresAt time.Time
+	// sourceExpiresAt is when the key expires in Redis; zero if it does not.
+	sourceExpiresAt time.Time
+}
+
+// localCache is a small in-process copy of hot Redis keys. Entries live for
+// at most ttl, and are dropped early when another instance invalidates them.
+type localCache struct {
+	mu         sync.RWMutex
+	entries    map[string]localCacheEntry
+	ttl        time.Duration
+	maxEntries int
+}
+
+func newLocalCache(ttl time.Duration, maxEntries int) *localCache {
+	return &localCache{
+		entries:    make(map[string]localCacheEntry),
+		ttl:        ttl,
+		maxEntries: maxEntries,
+	}
+}
+
+func (c *localCache) get(key string) (string, bool) {
+	c.mu.RLock()
+	entry, ok := c.entries[key]
+	c.mu.RUnlock()
+
+	if !ok || time.Now().After(entry.expiresAt) {
+		return "", false
+	}
+	return entry.value, true
+}
+
+// getWithTTL is like get but also reports how long the key has left in
+// Redis, or -1 when it has no expiry.
+func (c *localCache) getWithTTL(key string) (string, time.Duration, bool) {
+	c.mu.RLock()
+	entry, ok := c.entries[key]
+	c.mu.RUnlock()
+
+	now := time.Now()
+	if !ok || now.After(entry.expiresAt) {
+		return "", 0, false
+	}
+	if entry.sourceExpiresAt.IsZero() {
+		return entry.value, -1, true
+	}
+	return entry.value, entry.sourceExpiresAt.Sub(now), true
+}
+
+func (c *localCache) set(key, value string, expiration time.Duration) {
+	ttl := c.ttl
+	if expiration > 0 && expiration < ttl {
+		ttl = expiration
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if _, exists := c.entries[key]; !exists && len(c.entries) >= c.maxEntries {
+		c.evictExpired()
+		if len(c.entries) >= c.maxEntries {
+			return
+		}
+	}
+	now := time.Now()
+	entry := localCacheEntry{value: value, expiresAt: now.Add(ttl)}
+	if expiration > 0 {
+		entry.sourceExpiresAt = now.Add(expiration)
+	}
+	c.entries[key] = entry
+}
+
+func (c *localCache) delete(key string) {
+	c.mu.Lock()
+	delete(c.entries, key)
+	c.mu.Unlock()
+}
+
+func (c *localCache) deletePattern(pattern string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	for key := range c.entries {
+		if matched, _ := path.Match(pattern, key); matched {
+			delete(c.entries, key)
+		}
+	}
+}
+
+func (c *localCache) evictExpired() {
+	now := time.Now()
+	for key, entry := range c.entries {
+		if now.After(entry.expiresAt) {
+			delete(c.entries, key)
+		}
+	}
+}
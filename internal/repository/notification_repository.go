@@ -0,0 +1,99 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+
+	"github.com/raflytch/careerly-server/internal/domain"
+
+	"github.com/google/uuid"
+)
+
+const (
+	notificationColumns = `id, user_id, type, title, body, data, created_at`
+)
+
+type notificationRepository struct {
+	db *sql.DB
+}
+
+func NewNotificationRepository(db *sql.DB) domain.NotificationRepository {
+	return &notificationRepository{db: db}
+}
+
+// CreateOnce stores the notification unless the user already has one with
+// the same dedupe key, and reports whether it was stored.
+func (r *notificationRepository) CreateOnce(ctx context.Context, notification *domain.Notification) (bool, error) {
+	query := `
+		INSERT INTO notifications (id, user_id, type, title, body, data, dedupe_key, created_at)
+		VALUES ($1, $2, $3, $4, $5, $6, NULLIF($7, ''), $8)
+		ON CONFLICT (user_id, dedupe_key) DO NOTHING
+	`
+	var data interface{}
+	if len(notification.Data) > 0 {
+		data = []byte(notification.Data)
+	}
+	result, err := r.db.ExecContext(ctx, query,
+		notification.ID,
+		notification.UserID,
+		notification.Type,
+		notification.Title,
+		notification.Body,
+		data,
+		notification.DedupeKey,
+		notification.CreatedAt,
+	)
+	if err != nil {
+		return false, err
+	}
+	rows, err := result.RowsAffected()
+	if err != nil {
+		return false, err
+	}
+	return rows > 0, nil
+}
+
+func (r *notificationRepository) FindByUserID(ctx context.Context, userID uuid.UUID, limit, offset int) ([]domain.Notification, error) {
+	query := `
+		SELECT ` + notificationColumns + `
+		FROM notifications
+		WHERE user_id = $1
+		ORDER BY created_at DESC
+		LIMIT $2 OFFSET $3
+	`
+	rows, err := r.db.QueryContext(ctx, query, userID, limit, offset)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	notifications := make([]domain.Notification, 0)
+	for rows.Next() {
+		var notification domain.Notification
+		var data []byte
+		err := rows.Scan(
+			&notification.ID,
+			&notification.UserID,
+			&notification.Type,
+			&notification.Title,
+			&notification.Body,
+			&data,
+			&notification.CreatedAt,
+		)
+		if err != nil {
+			return nil, err
+		}
+		if len(data) > 0 {
+			notification.Data = data
+		}
+		notifications = append(notifications, notification)
+	}
+	return notifications, rows.Err()
+}
+
+func (r *notificationRepository) CountByUserID(ctx context.Context, userID uuid.UUID) (int64, error) {
+	query := `SELECT COUNT(id) FROM notifications WHERE user_id = $1`
+	var count int64
+	err := r.db.QueryRowContext(ctx, query, userID).Scan(&count)
+	return count, err
+}
@@ -0,0 +1,43 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+
+	"github.com/raflytch/careerly-server/internal/domain"
+
+	"github.com/google/uuid"
+)
+
+type onboardingRepository struct {
+	db *sql.DB
+}
+
+func NewOnboardingRepository(db *sql.DB) domain.OnboardingRepository {
+	return &onboardingRepository{db: db}
+}
+
+// GetMilestones reads when the user first reached each onboarding step.
+// Deleted resumes, checks and interviews still count, so a step stays done
+// once it has been reached.
+func (r *onboardingRepository) GetMilestones(ctx context.Context, userID uuid.UUID) (*domain.OnboardingMilestones, error) {
+	query := `
+		SELECT
+			(SELECT MIN(created_at) FROM resumes WHERE user_id = $1),
+			(SELECT MIN(created_at) FROM ats_checks WHERE user_id = $1),
+			(SELECT MIN(COALESCE(completed_at, created_at)) FROM interviews WHERE user_id = $1 AND status = $2),
+			(SELECT MIN(created_at) FROM subscriptions WHERE user_id = $1)
+	`
+	var milestones domain.OnboardingMilestones
+	err := r.db.QueryRowContext(ctx, query, userID, domain.InterviewStatusCompleted).Scan(
+		&milestones.FirstResumeAt,
+		&milestones.FirstATSCheckAt,
+		&milestones.FirstInterviewAt,
+		&milestones.FirstSubscriptionAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	return &milestones, nil
+}
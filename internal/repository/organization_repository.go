@@ -0,0 +1,328 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"time"
+
+	"github.com/raflytch/careerly-server/internal/domain"
+
+	"github.com/google/uuid"
+)
+
+const (
+	organizationColumns           = `id, name, owner_id, plan_id, seats, period_start, period_end, created_at, updated_at`
+	organizationInvitationColumns = `id, organization_id, email, token, invited_by, expires_at, accepted_at, created_at`
+)
+
+type organizationRepository struct {
+	db *sql.DB
+}
+
+func NewOrganizationRepository(db *sql.DB) domain.OrganizationRepository {
+	return &organizationRepository{db: db}
+}
+
+// Create inserts the organization together with its owner's membership.
+func (r *organizationRepository) Create(ctx context.Context, org *domain.Organization) error {
+	query := `
+		WITH org AS (
+			INSERT INTO organizations (id, name, owner_id, seats, created_at, updated_at)
+			VALUES ($1, $2, $3, $4, $5, $5)
+			RETURNING id
+		)
+		INSERT INTO organization_members (organization_id, user_id, role, joined_at)
+		SELECT id, $3, $6, $5 FROM org
+	`
+	_, err := r.db.ExecContext(ctx, query, org.ID, org.Name, org.OwnerID, org.Seats, org.CreatedAt, domain.OrganizationRoleOwner)
+	return err
+}
+
+func (r *organizationRepository) FindByID(ctx context.Context, id uuid.UUID) (*domain.Organization, error) {
+	query := `
+		SELECT ` + organizationColumns + `
+		FROM organizations
+		WHERE id = $1
+	`
+	var org domain.Organization
+	err := r.db.QueryRowContext(ctx, query, id).Scan(
+		&org.ID,
+		&org.Name,
+		&org.OwnerID,
+		&org.PlanID,
+		&org.Seats,
+		&org.PeriodStart,
+		&org.PeriodEnd,
+		&org.CreatedAt,
+		&org.UpdatedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+	return &org, nil
+}
+
+func (r *organizationRepository) FindMembership(ctx context.Context, userID uuid.UUID) (*domain.OrganizationMember, error) {
+	query := `
+		SELECT organization_id, user_id, role, joined_at
+		FROM organization_members
+		WHERE user_id = $1
+	`
+	var member domain.OrganizationMember
+	var role string
+	err := r.db.QueryRowContext(ctx, query, userID).Scan(&member.OrganizationID, &member.UserID, &role, &member.JoinedAt)
+	if err != nil {
+		return nil, err
+	}
+	member.Role = domain.OrganizationRole(role)
+	return &member, nil
+}
+
+func (r *organizationRepository) FindMembers(ctx context.Context, organizationID uuid.UUID) ([]domain.OrganizationMember, error) {
+	query := `
+		SELECT m.organization_id, m.user_id, m.role, m.joined_at, u.name, u.email
+		FROM organization_members m
+		JOIN users u ON u.id = m.user_id
+		WHERE m.organization_id = $1
+		ORDER BY m.role = 'owner' DESC, m.joined_at ASC
+	`
+	rows, err := r.db.QueryContext(ctx, query, organizationID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	members := make([]domain.OrganizationMember, 0)
+	for rows.Next() {
+		var member domain.OrganizationMember
+		var role string
+		if err := rows.Scan(&member.OrganizationID, &member.UserID, &role, &member.JoinedAt, &member.Name, &member.Email); err != nil {
+			return nil, err
+		}
+		member.Role = domain.OrganizationRole(role)
+		members = append(members, member)
+	}
+	return members, rows.Err()
+}
+
+func (r *organizationRepository) CountMembers(ctx context.Context, organizationID uuid.UUID) (int, error) {
+	query := `SELECT COUNT(*) FROM organization_members WHERE organization_id = $1`
+	var count int
+	err := r.db.QueryRowContext(ctx, query, organizationID).Scan(&count)
+	return count, err
+}
+
+func (r *organizationRepository) AddMember(ctx context.Context, member *domain.OrganizationMember) error {
+	query := `
+		INSERT INTO organization_members (organization_id, user_id, role, joined_at)
+		VALUES ($1, $2, $3, $4)
+	`
+	_, err := r.db.ExecContext(ctx, query, member.OrganizationID, member.UserID, member.Role, member.JoinedAt)
+	return err
+}
+
+// RemoveMember never removes the owner, so an organization always has one.
+func (r *organizationRepository) RemoveMember(ctx context.Context, organizationID, userID uuid.UUID) (bool, error) {
+	query := `
+		DELETE FROM organization_members
+		WHERE organization_id = $1 AND user_id = $2 AND role <> 'owner'
+	`
+	return r.execAffected(ctx, query, organizationID, userID)
+}
+
+func (r *organizationRepository) CreateInvitation(ctx context.Context, invitation *domain.OrganizationInvitation) error {
+	query := `
+		INSERT INTO organization_invitations (id, organization_id, email, token, invited_by, expires_at, created_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7)
+	`
+	_, err := r.db.ExecContext(ctx, query,
+		invitation.ID,
+		invitation.OrganizationID,
+		invitation.Email,
+		invitation.Token,
+		invitation.InvitedBy,
+		invitation.ExpiresAt,
+		invitation.CreatedAt,
+	)
+	return err
+}
+
+func (r *organizationRepository) FindInvitationByToken(ctx context.Context, token string) (*domain.OrganizationInvitation, error) {
+	query := `
+		SELECT ` + organizationInvitationColumns + `
+		FROM organization_invitations
+		WHERE token = $1
+	`
+	var invitation domain.OrganizationInvitation
+	err := r.db.QueryRowContext(ctx, query, token).Scan(
+		&invitation.ID,
+		&invitation.OrganizationID,
+		&invitation.Email,
+		&invitation.Token,
+		&invitation.InvitedBy,
+		&invitation.ExpiresAt,
+		&invitation.AcceptedAt,
+		&invitation.CreatedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+	return &invitation, nil
+}
+
+func (r *organizationRepository) FindPendingInvitations(ctx context.Context, organizationID uuid.UUID, now time.Time) ([]domain.OrganizationInvitation, error) {
+	query := `
+		SELECT ` + organizationInvitationColumns + `
+		FROM organization_invitations
+		WHERE organization_id = $1 AND accepted_at IS NULL AND expires_at > $2
+		ORDER BY created_at ASC
+	`
+	rows, err := r.db.QueryContext(ctx, query, organizationID, now)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	invitations := make([]domain.OrganizationInvitation, 0)
+	for rows.Next() {
+		var invitation domain.OrganizationInvitation
+		if err := rows.Scan(
+			&invitation.ID,
+			&invitation.OrganizationID,
+			&invitation.Email,
+			&invitation.Token,
+			&invitation.InvitedBy,
+			&invitation.ExpiresAt,
+			&invitation.AcceptedAt,
+			&invitation.CreatedAt,
+		); err != nil {
+			return nil, err
+		}
+		invitations = append(invitations, invitation)
+	}
+	return invitations, rows.Err()
+}
+
+// AcceptInvitation reports false when the invitation was already accepted.
+func (r *organizationRepository) AcceptInvitation(ctx context.Context, id uuid.UUID, acceptedAt time.Time) (bool, error) {
+	query := `
+		UPDATE organization_invitations
+		SET accepted_at = $2
+		WHERE id = $1 AND accepted_at IS NULL
+	`
+	return r.execAffected(ctx, query, id, acceptedAt)
+}
+
+func (r *organizationRepository) CreatePurchase(ctx context.Context, purchase *domain.OrganizationPurchase) error {
+	query := `
+		INSERT INTO organization_purchases (transaction_id, organization_id, plan_id, seats, created_at)
+		VALUES ($1, $2, $3, $4, $5)
+	`
+	_, err := r.db.ExecContext(ctx, query, purchase.TransactionID, purchase.OrganizationID, purchase.PlanID, purchase.Seats, purchase.CreatedAt)
+	return err
+}
+
+// ApplyPurchase moves the organization onto the purchased plan and seats
+// for a new period. A purchase is applied once; it reports false when it
+// already was.
+func (r *organizationRepository) ApplyPurchase(ctx context.Context, transactionID uuid.UUID, periodStart, periodEnd time.Time) (bool, error) {
+	query := `
+		WITH applied AS (
+			UPDATE organization_purchases
+			SET applied_at = $2
+			WHERE transaction_id = $1 AND applied_at IS NULL
+			RETURNING organization_id, plan_id, seats
+		)
+		UPDATE organizations o
+		SET plan_id = a.plan_id, seats = a.seats, period_start = $2, period_end = $3, updated_at = $2
+		FROM applied a
+		WHERE o.id = a.organization_id
+	`
+	return r.execAffected(ctx, query, transactionID, periodStart, periodEnd)
+}
+
+// IncrementUsageWithinLimit adds amount to the organization's pooled usage
+// in a single statement, so members using the pool at the same time cannot
+// push it past limit. A limit of zero or less means unlimited. It returns
+// the new count, or sql.ErrNoRows when the increment would exceed the limit.
+func (r *organizationRepository) IncrementUsageWithinLimit(ctx context.Context, organizationID uuid.UUID, feature domain.FeatureType, periodMonth time.Time, amount, limit int) (int, error) {
+	query := `
+		INSERT INTO organization_usage (organization_id, feature, period_month, count)
+		SELECT $1, $2, $3, $4
+		WHERE $5 <= 0 OR $4 <= $5
+		ON CONFLICT (organization_id, feature, period_month) DO UPDATE
+		SET count = organization_usage.count + EXCLUDED.count
+		WHERE $5 <= 0 OR organization_usage.count + EXCLUDED.count <= $5
+		RETURNING count
+	`
+	var count int
+	err := r.db.QueryRowContext(ctx, query, organizationID, feature, periodMonth, amount, limit).Scan(&count)
+	return count, err
+}
+
+func (r *organizationRepository) GetUsage(ctx context.Context, organizationID uuid.UUID, periodMonth time.Time) (map[domain.FeatureType]int, error) {
+	query := `
+		SELECT feature, count
+		FROM organization_usage
+		WHERE organization_id = $1 AND period_month = $2
+	`
+	rows, err := r.db.QueryContext(ctx, query, organizationID, periodMonth)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	usage := make(map[domain.FeatureType]int)
+	for rows.Next() {
+		var feature string
+		var count int
+		if err := rows.Scan(&feature, &count); err != nil {
+			return nil, err
+		}
+		usage[domain.FeatureType(feature)] = count
+	}
+	return usage, rows.Err()
+}
+
+// GetMemberUsage breaks the pooled usage of periodMonth down by member.
+func (r *organizationRepository) GetMemberUsage(ctx context.Context, organizationID uuid.UUID, periodMonth time.Time) ([]domain.OrganizationMemberUsage, error) {
+	query := `
+		SELECT m.user_id, u.name,
+			COALESCE(SUM(g.count) FILTER (WHERE g.feature = 'resume'), 0),
+			COALESCE(SUM(g.count) FILTER (WHERE g.feature = 'ats_check'), 0),
+			COALESCE(SUM(g.count) FILTER (WHERE g.feature = 'interview'), 0)
+		FROM organization_members m
+		JOIN users u ON u.id = m.user_id
+		LEFT JOIN usage g ON g.user_id = m.user_id AND g.period_month = $2 AND g.deleted_at IS NULL
+		WHERE m.organization_id = $1
+		GROUP BY m.user_id, u.name, m.joined_at
+		ORDER BY m.joined_at ASC
+	`
+	rows, err := r.db.QueryContext(ctx, query, organizationID, periodMonth)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	members := make([]domain.OrganizationMemberUsage, 0)
+	for rows.Next() {
+		var member domain.OrganizationMemberUsage
+		if err := rows.Scan(&member.UserID, &member.Name, &member.UsedResumes, &member.UsedATSChecks, &member.UsedInterviews); err != nil {
+			return nil, err
+		}
+		members = append(members, member)
+	}
+	return members, rows.Err()
+}
+
+func (r *organizationRepository) execAffected(ctx context.Context, query string, args ...interface{}) (bool, error) {
+	result, err := r.db.ExecContext(ctx, query, args...)
+	if err != nil {
+		return false, err
+	}
+	rows, err := result.RowsAffected()
+	if err != nil {
+		return false, err
+	}
+	return rows > 0, nil
+}
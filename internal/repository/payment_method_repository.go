@@ -0,0 +1,236 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"time"
+
+	"github.com/raflytch/careerly-server/internal/domain"
+	"github.com/raflytch/careerly-server/pkg/fieldcrypt"
+
+	"github.com/google/uuid"
+)
+
+const (
+	paymentMethodColumns = `id, user_id, token, masked_card, card_type, bank, token_expires_at, last_used_at, created_at, updated_at`
+)
+
+// paymentMethodRepository seals saved card tokens with the PII cipher when
+// one is configured, the same way resume contact details are stored.
+type paymentMethodRepository struct {
+	db     *sql.DB
+	cipher *fieldcrypt.Cipher
+}
+
+func NewPaymentMethodRepository(db *sql.DB, cipher *fieldcrypt.Cipher) domain.PaymentMethodRepository {
+	return &paymentMethodRepository{db: db, cipher: cipher}
+}
+
+// Upsert saves the card, replacing the token of a card the user saved
+// before so each card is listed once.
+func (r *paymentMethodRepository) Upsert(ctx context.Context, method *domain.PaymentMethod) error {
+	token, err := r.seal(method.Token)
+	if err != nil {
+		return err
+	}
+
+	query := `
+		INSERT INTO payment_methods (id, user_id, token, masked_card, card_type, bank, token_expires_at, created_at, updated_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9)
+		ON CONFLICT (user_id, masked_card) DO UPDATE
+		SET token = EXCLUDED.token,
+			card_type = EXCLUDED.card_type,
+			bank = EXCLUDED.bank,
+			token_expires_at = EXCLUDED.token_expires_at,
+			updated_at = EXCLUDED.updated_at
+		RETURNING id, created_at
+	`
+	return r.db.QueryRowContext(ctx, query,
+		method.ID,
+		method.UserID,
+		token,
+		method.MaskedCard,
+		method.CardType,
+		method.Bank,
+		method.TokenExpiresAt,
+		method.CreatedAt,
+		method.UpdatedAt,
+	).Scan(&method.ID, &method.CreatedAt)
+}
+
+func (r *paymentMethodRepository) FindByID(ctx context.Context, id uuid.UUID) (*domain.PaymentMethod, error) {
+	query := `SELECT ` + paymentMethodColumns + ` FROM payment_methods WHERE id = $1`
+	return r.scanPaymentMethod(r.db.QueryRowContext(ctx, query, id))
+}
+
+func (r *paymentMethodRepository) FindByUserID(ctx context.Context, userID uuid.UUID) ([]domain.PaymentMethod, error) {
+	query := `
+		SELECT ` + paymentMethodColumns + `
+		FROM payment_methods
+		WHERE user_id = $1
+		ORDER BY COALESCE(last_used_at, created_at) DESC
+	`
+	rows, err := r.db.QueryContext(ctx, query, userID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	methods := make([]domain.PaymentMethod, 0)
+	for rows.Next() {
+		method, err := r.scanPaymentMethodFromRows(rows)
+		if err != nil {
+			return nil, err
+		}
+		methods = append(methods, *method)
+	}
+	return methods, rows.Err()
+}
+
+func (r *paymentMethodRepository) MarkUsed(ctx context.Context, id uuid.UUID, usedAt time.Time) error {
+	query := `UPDATE payment_methods SET last_used_at = $1 WHERE id = $2`
+	_, err := r.db.ExecContext(ctx, query, usedAt, id)
+	return err
+}
+
+func (r *paymentMethodRepository) Delete(ctx context.Context, id uuid.UUID) error {
+	query := `DELETE FROM payment_methods WHERE id = $1`
+	result, err := r.db.ExecContext(ctx, query, id)
+	if err != nil {
+		return err
+	}
+	rows, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if rows == 0 {
+		return sql.ErrNoRows
+	}
+	return nil
+}
+
+// ReencryptBatch re-seals the tokens of up to limit cards after afterID
+// that are still in plaintext or sealed with a retired key. A token that
+// changed since it was read is skipped; the next run picks it up.
+func (r *paymentMethodRepository) ReencryptBatch(ctx context.Context, afterID uuid.UUID, limit int) (*domain.ResumeBatchResult, error) {
+	query := `
+		SELECT id, token
+		FROM payment_methods
+		WHERE id > $1
+		ORDER BY id
+		LIMIT $2
+	`
+	rows, err := r.db.QueryContext(ctx, query, afterID, limit)
+	if err != nil {
+		return nil, err
+	}
+
+	type stored struct {
+		id    uuid.UUID
+		token string
+	}
+
+	result := &domain.ResumeBatchResult{LastID: afterID}
+	outdated := make([]stored, 0)
+	for rows.Next() {
+		var item stored
+		if err := rows.Scan(&item.id, &item.token); err != nil {
+			rows.Close()
+			return nil, err
+		}
+		result.Scanned++
+		result.LastID = item.id
+		if r.cipher != nil && r.cipher.NeedsRotation(item.token) {
+			outdated = append(outdated, item)
+		}
+	}
+	rows.Close()
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	update := `UPDATE payment_methods SET token = $1 WHERE id = $2 AND token = $3`
+	for _, item := range outdated {
+		token, err := r.open(item.token)
+		if err != nil {
+			return nil, fmt.Errorf("payment method %s: %w", item.id, err)
+		}
+		sealed, err := r.seal(token)
+		if err != nil {
+			return nil, err
+		}
+		res, err := r.db.ExecContext(ctx, update, sealed, item.id, item.token)
+		if err != nil {
+			return nil, fmt.Errorf("payment method %s: %w", item.id, err)
+		}
+		if affected, _ := res.RowsAffected(); affected > 0 {
+			result.Updated++
+		}
+	}
+
+	return result, nil
+}
+
+func (r *paymentMethodRepository) scanPaymentMethod(row *sql.Row) (*domain.PaymentMethod, error) {
+	var method domain.PaymentMethod
+	err := row.Scan(
+		&method.ID,
+		&method.UserID,
+		&method.Token,
+		&method.MaskedCard,
+		&method.CardType,
+		&method.Bank,
+		&method.TokenExpiresAt,
+		&method.LastUsedAt,
+		&method.CreatedAt,
+		&method.UpdatedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+	if method.Token, err = r.open(method.Token); err != nil {
+		return nil, err
+	}
+	return &method, nil
+}
+
+func (r *paymentMethodRepository) scanPaymentMethodFromRows(rows *sql.Rows) (*domain.PaymentMethod, error) {
+	var method domain.PaymentMethod
+	err := rows.Scan(
+		&method.ID,
+		&method.UserID,
+		&method.Token,
+		&method.MaskedCard,
+		&method.CardType,
+		&method.Bank,
+		&method.TokenExpiresAt,
+		&method.LastUsedAt,
+		&method.CreatedAt,
+		&method.UpdatedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+	if method.Token, err = r.open(method.Token); err != nil {
+		return nil, err
+	}
+	return &method, nil
+}
+
+func (r *paymentMethodRepository) seal(token string) (string, error) {
+	if r.cipher == nil {
+		return token, nil
+	}
+	return r.cipher.Encrypt(token)
+}
+
+func (r *paymentMethodRepository) open(token string) (string, error) {
+	if !fieldcrypt.IsEncrypted(token) {
+		return token, nil
+	}
+	if r.cipher == nil {
+		return "", fieldcrypt.ErrUnknownKey
+	}
+	return r.cipher.Decrypt(token)
+}
@@ -0,0 +1,51 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+
+	"github.com/raflytch/careerly-server/internal/domain"
+
+	"github.com/google/uuid"
+)
+
+type paymentNotificationRepository struct {
+	db *sql.DB
+}
+
+func NewPaymentNotificationRepository(db *sql.DB) domain.PaymentNotificationRepository {
+	return &paymentNotificationRepository{db: db}
+}
+
+// Claim records a notification before it is processed. It returns false when
+// the same signature and status were already recorded, i.e. a replay.
+func (r *paymentNotificationRepository) Claim(ctx context.Context, notification *domain.PaymentNotification) (bool, error) {
+	query := `
+		INSERT INTO payment_notifications (id, order_id, signature_key, transaction_status, status_code, event_time, received_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7)
+		ON CONFLICT (signature_key, transaction_status) DO NOTHING
+	`
+	result, err := r.db.ExecContext(ctx, query,
+		notification.ID,
+		notification.OrderID,
+		notification.SignatureKey,
+		notification.TransactionStatus,
+		notification.StatusCode,
+		notification.EventTime,
+		notification.ReceivedAt,
+	)
+	if err != nil {
+		return false, err
+	}
+	rows, err := result.RowsAffected()
+	if err != nil {
+		return false, err
+	}
+	return rows == 1, nil
+}
+
+func (r *paymentNotificationRepository) Release(ctx context.Context, id uuid.UUID) error {
+	query := `DELETE FROM payment_notifications WHERE id = $1`
+	_, err := r.db.ExecContext(ctx, query, id)
+	return err
+}
@@ -12,7 +12,7 @@ import (
 )
 
 const (
-	planColumns = `id, name, display_name, price, duration_days, max_resumes, max_ats_checks, max_interviews, is_active, created_at, deleted_at`
+	planColumns = `id, name, display_name, price, duration_days, max_resumes, max_ats_checks, max_interviews, custom_branding, is_active, archived_at, created_at, deleted_at`
 )
 
 type planRepository struct {
@@ -25,8 +25,8 @@ func NewPlanRepository(db *sql.DB) domain.PlanRepository {
 
 func (r *planRepository) Create(ctx context.Context, plan *domain.Plan) error {
 	query := `
-		INSERT INTO plans (id, name, display_name, price, duration_days, max_resumes, max_ats_checks, max_interviews, is_active, created_at)
-		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10)
+		INSERT INTO plans (id, name, display_name, price, duration_days, max_resumes, max_ats_checks, max_interviews, custom_branding, is_active, created_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11)
 	`
 	_, err := r.db.ExecContext(ctx, query,
 		plan.ID,
@@ -37,6 +37,7 @@ func (r *planRepository) Create(ctx context.Context, plan *domain.Plan) error {
 		plan.MaxResumes,
 		plan.MaxATSChecks,
 		plan.MaxInterviews,
+		plan.CustomBranding,
 		plan.IsActive,
 		plan.CreatedAt,
 	)
@@ -68,7 +69,7 @@ func (r *planRepository) FindAll(ctx context.Context, limit, offset int, include
 		WHERE deleted_at IS NULL
 	`
 	if !includeInactive {
-		query += ` AND is_active = true`
+		query += ` AND is_active = true AND archived_at IS NULL`
 	}
 	query += `
 		ORDER BY created_at DESC
@@ -95,7 +96,7 @@ func (r *planRepository) FindAll(ctx context.Context, limit, offset int, include
 func (r *planRepository) Count(ctx context.Context, includeInactive bool) (int64, error) {
 	query := `SELECT COUNT(id) FROM plans WHERE deleted_at IS NULL`
 	if !includeInactive {
-		query += ` AND is_active = true`
+		query += ` AND is_active = true AND archived_at IS NULL`
 	}
 	var count int64
 	err := r.db.QueryRowContext(ctx, query).Scan(&count)
@@ -106,8 +107,8 @@ func (r *planRepository) Update(ctx context.Context, plan *domain.Plan) error {
 	query := `
 		UPDATE plans
 		SET name = $1, display_name = $2, price = $3, duration_days = $4, 
-			max_resumes = $5, max_ats_checks = $6, max_interviews = $7, is_active = $8
-		WHERE id = $9 AND deleted_at IS NULL
+			max_resumes = $5, max_ats_checks = $6, max_interviews = $7, custom_branding = $8, is_active = $9
+		WHERE id = $10 AND deleted_at IS NULL
 	`
 	_, err := r.db.ExecContext(ctx, query,
 		plan.Name,
@@ -117,6 +118,7 @@ func (r *planRepository) Update(ctx context.Context, plan *domain.Plan) error {
 		plan.MaxResumes,
 		plan.MaxATSChecks,
 		plan.MaxInterviews,
+		plan.CustomBranding,
 		plan.IsActive,
 		plan.ID,
 	)
@@ -133,6 +135,29 @@ func (r *planRepository) SoftDelete(ctx context.Context, id uuid.UUID) error {
 	return err
 }
 
+func (r *planRepository) SetArchived(ctx context.Context, id uuid.UUID, archivedAt *time.Time) error {
+	query := `
+		UPDATE plans
+		SET archived_at = $1
+		WHERE id = $2 AND deleted_at IS NULL
+	`
+	_, err := r.db.ExecContext(ctx, query, archivedAt, id)
+	return err
+}
+
+// CountUsage counts the subscriptions and transactions that reference the
+// plan, whatever their status.
+func (r *planRepository) CountUsage(ctx context.Context, id uuid.UUID) (int64, error) {
+	query := `
+		SELECT
+			(SELECT COUNT(*) FROM subscriptions WHERE plan_id = $1 OR scheduled_plan_id = $1) +
+			(SELECT COUNT(*) FROM transactions WHERE plan_id = $1)
+	`
+	var count int64
+	err := r.db.QueryRowContext(ctx, query, id).Scan(&count)
+	return count, err
+}
+
 func (r *planRepository) scanPlan(row *sql.Row) (*domain.Plan, error) {
 	var plan domain.Plan
 	var price decimal.Decimal
@@ -145,7 +170,9 @@ func (r *planRepository) scanPlan(row *sql.Row) (*domain.Plan, error) {
 		&plan.MaxResumes,
 		&plan.MaxATSChecks,
 		&plan.MaxInterviews,
+		&plan.CustomBranding,
 		&plan.IsActive,
+		&plan.ArchivedAt,
 		&plan.CreatedAt,
 		&plan.DeletedAt,
 	)
@@ -168,7 +195,9 @@ func (r *planRepository) scanPlanFromRows(rows *sql.Rows) (*domain.Plan, error)
 		&plan.MaxResumes,
 		&plan.MaxATSChecks,
 		&plan.MaxInterviews,
+		&plan.CustomBranding,
 		&plan.IsActive,
+		&plan.ArchivedAt,
 		&plan.CreatedAt,
 		&plan.DeletedAt,
 	)
@@ -0,0 +1,240 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"time"
+
+	"github.com/raflytch/careerly-server/internal/domain"
+
+	"github.com/google/uuid"
+)
+
+const promptExperimentColumns = `id, key, name, variants, status, started_at, ended_at`
+
+type promptExperimentRepository struct {
+	db *sql.DB
+}
+
+func NewPromptExperimentRepository(db *sql.DB) domain.PromptExperimentRepository {
+	return &promptExperimentRepository{db: db}
+}
+
+func (r *promptExperimentRepository) Create(ctx context.Context, experiment *domain.PromptExperiment) error {
+	variantsJSON, err := json.Marshal(experiment.Variants)
+	if err != nil {
+		return err
+	}
+
+	query := `
+		INSERT INTO prompt_experiments (` + promptExperimentColumns + `)
+		VALUES ($1, $2, $3, $4, $5, $6, $7)
+	`
+	_, err = r.db.ExecContext(ctx, query,
+		experiment.ID,
+		experiment.Key,
+		experiment.Name,
+		variantsJSON,
+		experiment.Status,
+		experiment.StartedAt,
+		experiment.EndedAt,
+	)
+	return err
+}
+
+func (r *promptExperimentRepository) FindByID(ctx context.Context, id uuid.UUID) (*domain.PromptExperiment, error) {
+	query := `
+		SELECT ` + promptExperimentColumns + `
+		FROM prompt_experiments
+		WHERE id = $1
+	`
+	return r.scanExperiment(r.db.QueryRowContext(ctx, query, id))
+}
+
+func (r *promptExperimentRepository) FindAll(ctx context.Context) ([]domain.PromptExperiment, error) {
+	query := `
+		SELECT ` + promptExperimentColumns + `
+		FROM prompt_experiments
+		ORDER BY started_at DESC
+	`
+	rows, err := r.db.QueryContext(ctx, query)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	experiments := make([]domain.PromptExperiment, 0)
+	for rows.Next() {
+		experiment, err := r.scanExperimentFromRows(rows)
+		if err != nil {
+			return nil, err
+		}
+		experiments = append(experiments, *experiment)
+	}
+
+	return experiments, rows.Err()
+}
+
+func (r *promptExperimentRepository) FindRunning(ctx context.Context, key domain.PromptKey) (*domain.PromptExperiment, error) {
+	query := `
+		SELECT ` + promptExperimentColumns + `
+		FROM prompt_experiments
+		WHERE key = $1 AND status = $2
+	`
+	return r.scanExperiment(r.db.QueryRowContext(ctx, query, key, domain.PromptExperimentRunning))
+}
+
+func (r *promptExperimentRepository) Stop(ctx context.Context, id uuid.UUID, endedAt time.Time) error {
+	query := `UPDATE prompt_experiments SET status = $2, ended_at = $3 WHERE id = $1 AND status = $4`
+	_, err := r.db.ExecContext(ctx, query, id, domain.PromptExperimentStopped, endedAt, domain.PromptExperimentRunning)
+	return err
+}
+
+// Assign stores version for the user unless they already have a variant in
+// the experiment, and returns the variant they end up with, so concurrent
+// first requests agree on one.
+func (r *promptExperimentRepository) Assign(ctx context.Context, experimentID, userID uuid.UUID, version int, assignedAt time.Time) (int, error) {
+	query := `
+		WITH inserted AS (
+			INSERT INTO prompt_experiment_assignments (experiment_id, user_id, version, assigned_at)
+			VALUES ($1, $2, $3, $4)
+			ON CONFLICT (experiment_id, user_id) DO NOTHING
+			RETURNING version
+		)
+		SELECT version FROM inserted
+		UNION ALL
+		SELECT version FROM prompt_experiment_assignments WHERE experiment_id = $1 AND user_id = $2
+		LIMIT 1
+	`
+	var assigned int
+	err := r.db.QueryRowContext(ctx, query, experimentID, userID, version, assignedAt).Scan(&assigned)
+	return assigned, err
+}
+
+func (r *promptExperimentRepository) SaveOutput(ctx context.Context, experimentID, userID, entityID uuid.UUID, version int, createdAt time.Time) error {
+	query := `
+		INSERT INTO prompt_experiment_outputs (experiment_id, entity_id, user_id, version, created_at)
+		VALUES ($1, $2, $3, $4, $5)
+		ON CONFLICT (experiment_id, entity_id) DO UPDATE SET version = EXCLUDED.version, created_at = EXCLUDED.created_at
+	`
+	_, err := r.db.ExecContext(ctx, query, experimentID, entityID, userID, version, createdAt)
+	return err
+}
+
+func (r *promptExperimentRepository) CountAssignments(ctx context.Context, experimentID uuid.UUID) (map[int]int64, error) {
+	query := `
+		SELECT version, COUNT(*)
+		FROM prompt_experiment_assignments
+		WHERE experiment_id = $1
+		GROUP BY version
+	`
+	rows, err := r.db.QueryContext(ctx, query, experimentID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	counts := make(map[int]int64)
+	for rows.Next() {
+		var version int
+		var count int64
+		if err := rows.Scan(&version, &count); err != nil {
+			return nil, err
+		}
+		counts[version] = count
+	}
+
+	return counts, rows.Err()
+}
+
+// SummarizeOutputs groups the experiment's outputs by variant with the
+// feedback users left on them and the score each output reached, an
+// interview's overall score or an ATS check's score.
+func (r *promptExperimentRepository) SummarizeOutputs(ctx context.Context, experimentID uuid.UUID) ([]domain.PromptVariantResult, error) {
+	query := `
+		SELECT
+			o.version,
+			COUNT(*),
+			COUNT(f.id),
+			COUNT(*) FILTER (WHERE f.rating = 'up'),
+			COUNT(*) FILTER (WHERE f.rating = 'down'),
+			AVG(COALESCE(i.overall_score, a.score))
+		FROM prompt_experiment_outputs o
+		LEFT JOIN ai_feedback f ON f.entity_id = o.entity_id AND f.user_id = o.user_id
+		LEFT JOIN interviews i ON i.id = o.entity_id
+		LEFT JOIN ats_checks a ON a.id = o.entity_id
+		WHERE o.experiment_id = $1
+		GROUP BY o.version
+		ORDER BY o.version
+	`
+	rows, err := r.db.QueryContext(ctx, query, experimentID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	results := make([]domain.PromptVariantResult, 0)
+	for rows.Next() {
+		var result domain.PromptVariantResult
+		if err := rows.Scan(
+			&result.Version,
+			&result.Outputs,
+			&result.Ratings,
+			&result.ThumbsUp,
+			&result.ThumbsDown,
+			&result.AverageScore,
+		); err != nil {
+			return nil, err
+		}
+		results = append(results, result)
+	}
+
+	return results, rows.Err()
+}
+
+func (r *promptExperimentRepository) scanExperiment(row *sql.Row) (*domain.PromptExperiment, error) {
+	var experiment domain.PromptExperiment
+	var variantsJSON []byte
+	err := row.Scan(
+		&experiment.ID,
+		&experiment.Key,
+		&experiment.Name,
+		&variantsJSON,
+		&experiment.Status,
+		&experiment.StartedAt,
+		&experiment.EndedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := json.Unmarshal(variantsJSON, &experiment.Variants); err != nil {
+		return nil, err
+	}
+
+	return &experiment, nil
+}
+
+func (r *promptExperimentRepository) scanExperimentFromRows(rows *sql.Rows) (*domain.PromptExperiment, error) {
+	var experiment domain.PromptExperiment
+	var variantsJSON []byte
+	err := rows.Scan(
+		&experiment.ID,
+		&experiment.Key,
+		&experiment.Name,
+		&variantsJSON,
+		&experiment.Status,
+		&experiment.StartedAt,
+		&experiment.EndedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := json.Unmarshal(variantsJSON, &experiment.Variants); err != nil {
+		return nil, err
+	}
+
+	return &experiment, nil
+}
@@ -0,0 +1,149 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"time"
+
+	"github.com/raflytch/careerly-server/internal/domain"
+
+	"github.com/google/uuid"
+)
+
+const (
+	promptColumns = `id, key, version, content, note, is_active, created_at`
+)
+
+type promptRepository struct {
+	db *sql.DB
+}
+
+func NewPromptRepository(db *sql.DB) domain.PromptRepository {
+	return &promptRepository{db: db}
+}
+
+// Create stores prompt as the next version of its key and sets its Version.
+// Two versions created at the same time collide on the (key, version)
+// unique index instead of sharing a number.
+func (r *promptRepository) Create(ctx context.Context, prompt *domain.Prompt) error {
+	query := `
+		INSERT INTO prompts (id, key, version, content, note, is_active, created_at)
+		SELECT $1, $2, COALESCE(MAX(version), 0) + 1, $3, $4, false, $5
+		FROM prompts
+		WHERE key = $2
+		RETURNING version
+	`
+	return r.db.QueryRowContext(ctx, query,
+		prompt.ID,
+		prompt.Key,
+		prompt.Content,
+		prompt.Note,
+		prompt.CreatedAt,
+	).Scan(&prompt.Version)
+}
+
+func (r *promptRepository) FindByKey(ctx context.Context, key domain.PromptKey) ([]domain.Prompt, error) {
+	query := `
+		SELECT ` + promptColumns + `
+		FROM prompts
+		WHERE key = $1
+		ORDER BY version DESC
+	`
+	rows, err := r.db.QueryContext(ctx, query, key)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	prompts := make([]domain.Prompt, 0)
+	for rows.Next() {
+		var prompt domain.Prompt
+		if err := rows.Scan(
+			&prompt.ID,
+			&prompt.Key,
+			&prompt.Version,
+			&prompt.Content,
+			&prompt.Note,
+			&prompt.IsActive,
+			&prompt.CreatedAt,
+		); err != nil {
+			return nil, err
+		}
+		prompts = append(prompts, prompt)
+	}
+	return prompts, rows.Err()
+}
+
+func (r *promptRepository) FindActive(ctx context.Context, key domain.PromptKey) (*domain.Prompt, error) {
+	query := `
+		SELECT ` + promptColumns + `
+		FROM prompts
+		WHERE key = $1 AND is_active = true
+		ORDER BY version DESC
+		LIMIT 1
+	`
+	return r.scanPrompt(r.db.QueryRowContext(ctx, query, key))
+}
+
+func (r *promptRepository) FindVersion(ctx context.Context, key domain.PromptKey, version int) (*domain.Prompt, error) {
+	query := `
+		SELECT ` + promptColumns + `
+		FROM prompts
+		WHERE key = $1 AND version = $2
+	`
+	return r.scanPrompt(r.db.QueryRowContext(ctx, query, key, version))
+}
+
+// Activate makes version the only active version of key in one statement,
+// so readers never see two active versions or none in between.
+func (r *promptRepository) Activate(ctx context.Context, key domain.PromptKey, version int) error {
+	query := `UPDATE prompts SET is_active = (version = $2) WHERE key = $1`
+	_, err := r.db.ExecContext(ctx, query, key, version)
+	return err
+}
+
+func (r *promptRepository) Deactivate(ctx context.Context, key domain.PromptKey) error {
+	query := `UPDATE prompts SET is_active = false WHERE key = $1 AND is_active = true`
+	_, err := r.db.ExecContext(ctx, query, key)
+	return err
+}
+
+func (r *promptRepository) Delete(ctx context.Context, key domain.PromptKey, version int) error {
+	query := `DELETE FROM prompts WHERE key = $1 AND version = $2`
+	_, err := r.db.ExecContext(ctx, query, key, version)
+	return err
+}
+
+func (r *promptRepository) SaveUsage(ctx context.Context, key domain.PromptKey, entityID uuid.UUID, version int, usedAt time.Time) error {
+	query := `
+		INSERT INTO prompt_usages (key, entity_id, version, used_at)
+		VALUES ($1, $2, $3, $4)
+		ON CONFLICT (key, entity_id) DO UPDATE SET version = EXCLUDED.version, used_at = EXCLUDED.used_at
+	`
+	_, err := r.db.ExecContext(ctx, query, key, entityID, version, usedAt)
+	return err
+}
+
+func (r *promptRepository) FindUsage(ctx context.Context, key domain.PromptKey, entityID uuid.UUID) (int, error) {
+	query := `SELECT version FROM prompt_usages WHERE key = $1 AND entity_id = $2`
+	var version int
+	err := r.db.QueryRowContext(ctx, query, key, entityID).Scan(&version)
+	return version, err
+}
+
+func (r *promptRepository) scanPrompt(row *sql.Row) (*domain.Prompt, error) {
+	var prompt domain.Prompt
+	err := row.Scan(
+		&prompt.ID,
+		&prompt.Key,
+		&prompt.Version,
+		&prompt.Content,
+		&prompt.Note,
+		&prompt.IsActive,
+		&prompt.CreatedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+	return &prompt, nil
+}
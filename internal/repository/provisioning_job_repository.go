@@ -0,0 +1,172 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"time"
+
+	"github.com/raflytch/careerly-server/internal/domain"
+
+	"github.com/google/uuid"
+)
+
+const (
+	provisioningJobColumns = `id, transaction_id, order_id, user_id, plan_id, status, attempts, max_attempts, last_error, next_attempt_at, created_at, updated_at`
+)
+
+type provisioningJobRepository struct {
+	db *sql.DB
+}
+
+func NewProvisioningJobRepository(db *sql.DB) domain.ProvisioningJobRepository {
+	return &provisioningJobRepository{db: db}
+}
+
+func (r *provisioningJobRepository) Create(ctx context.Context, job *domain.ProvisioningJob) error {
+	query := `
+		INSERT INTO provisioning_jobs (` + provisioningJobColumns + `)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12)
+	`
+	_, err := r.db.ExecContext(ctx, query,
+		job.ID,
+		job.TransactionID,
+		job.OrderID,
+		job.UserID,
+		job.PlanID,
+		job.Status,
+		job.Attempts,
+		job.MaxAttempts,
+		job.LastError,
+		job.NextAttemptAt,
+		job.CreatedAt,
+		job.UpdatedAt,
+	)
+	return err
+}
+
+func (r *provisioningJobRepository) FindByID(ctx context.Context, id uuid.UUID) (*domain.ProvisioningJob, error) {
+	query := `
+		SELECT ` + provisioningJobColumns + `
+		FROM provisioning_jobs
+		WHERE id = $1
+	`
+	return r.scanProvisioningJob(r.db.QueryRowContext(ctx, query, id))
+}
+
+func (r *provisioningJobRepository) FindAll(ctx context.Context, status domain.ProvisioningJobStatus, limit, offset int) ([]domain.ProvisioningJob, error) {
+	query := `
+		SELECT ` + provisioningJobColumns + `
+		FROM provisioning_jobs
+		WHERE ($1 = '' OR status = $1)
+		ORDER BY created_at DESC
+		LIMIT $2 OFFSET $3
+	`
+	rows, err := r.db.QueryContext(ctx, query, status, limit, offset)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	return r.collectProvisioningJobs(rows)
+}
+
+func (r *provisioningJobRepository) Count(ctx context.Context, status domain.ProvisioningJobStatus) (int64, error) {
+	query := `SELECT COUNT(id) FROM provisioning_jobs WHERE ($1 = '' OR status = $1)`
+	var count int64
+	err := r.db.QueryRowContext(ctx, query, status).Scan(&count)
+	return count, err
+}
+
+func (r *provisioningJobRepository) FindDue(ctx context.Context, now time.Time, limit int) ([]domain.ProvisioningJob, error) {
+	query := `
+		SELECT ` + provisioningJobColumns + `
+		FROM provisioning_jobs
+		WHERE status = $1 AND next_attempt_at <= $2
+		ORDER BY next_attempt_at ASC
+		LIMIT $3
+	`
+	rows, err := r.db.QueryContext(ctx, query, domain.ProvisioningJobStatusPending, now, limit)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	return r.collectProvisioningJobs(rows)
+}
+
+func (r *provisioningJobRepository) Update(ctx context.Context, job *domain.ProvisioningJob) error {
+	query := `
+		UPDATE provisioning_jobs
+		SET status = $1, attempts = $2, last_error = $3, next_attempt_at = $4, updated_at = $5
+		WHERE id = $6
+	`
+	_, err := r.db.ExecContext(ctx, query,
+		job.Status,
+		job.Attempts,
+		job.LastError,
+		job.NextAttemptAt,
+		job.UpdatedAt,
+		job.ID,
+	)
+	return err
+}
+
+func (r *provisioningJobRepository) collectProvisioningJobs(rows *sql.Rows) ([]domain.ProvisioningJob, error) {
+	jobs := make([]domain.ProvisioningJob, 0)
+	for rows.Next() {
+		job, err := r.scanProvisioningJobFromRows(rows)
+		if err != nil {
+			return nil, err
+		}
+		jobs = append(jobs, *job)
+	}
+	return jobs, rows.Err()
+}
+
+func (r *provisioningJobRepository) scanProvisioningJob(row *sql.Row) (*domain.ProvisioningJob, error) {
+	var job domain.ProvisioningJob
+	var status string
+	err := row.Scan(
+		&job.ID,
+		&job.TransactionID,
+		&job.OrderID,
+		&job.UserID,
+		&job.PlanID,
+		&status,
+		&job.Attempts,
+		&job.MaxAttempts,
+		&job.LastError,
+		&job.NextAttemptAt,
+		&job.CreatedAt,
+		&job.UpdatedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+	job.Status = domain.ProvisioningJobStatus(status)
+	return &job, nil
+}
+
+func (r *provisioningJobRepository) scanProvisioningJobFromRows(rows *sql.Rows) (*domain.ProvisioningJob, error) {
+	var job domain.ProvisioningJob
+	var status string
+	err := rows.Scan(
+		&job.ID,
+		&job.TransactionID,
+		&job.OrderID,
+		&job.UserID,
+		&job.PlanID,
+		&status,
+		&job.Attempts,
+		&job.MaxAttempts,
+		&job.LastError,
+		&job.NextAttemptAt,
+		&job.CreatedAt,
+		&job.UpdatedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+	job.Status = domain.ProvisioningJobStatus(status)
+	return &job, nil
+}
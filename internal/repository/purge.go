@@ -0,0 +1,66 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/raflytch/careerly-server/internal/database"
+
+	"github.com/lib/pq"
+)
+
+// purgeRows permanently deletes the rows of table whose ids selectIDs
+// returns, in one transaction. Each dependent statement runs first with the
+// ids as $1 (a uuid[]) to remove or detach rows referencing them. selectIDs
+// should lock what it selects, so nothing can start referencing a row
+// between the dependent statements and its deletion.
+func purgeRows(ctx context.Context, db *database.Router, table, selectIDs string, args []interface{}, dependents []string) (int64, error) {
+	tx, err := db.BeginTx(ctx, nil)
+	if err != nil {
+		return 0, err
+	}
+	defer tx.Rollback()
+
+	rows, err := tx.QueryContext(ctx, selectIDs, args...)
+	if err != nil {
+		return 0, err
+	}
+	var ids []string
+	for rows.Next() {
+		var id string
+		if err := rows.Scan(&id); err != nil {
+			rows.Close()
+			return 0, err
+		}
+		ids = append(ids, id)
+	}
+	rows.Close()
+	if err := rows.Err(); err != nil {
+		return 0, err
+	}
+	if len(ids) == 0 {
+		return 0, nil
+	}
+
+	array := pq.StringArray(ids)
+	for _, statement := range dependents {
+		if _, err := tx.ExecContext(ctx, statement, array); err != nil {
+			return 0, fmt.Errorf("%s: %w", strings.SplitN(statement, "\n", 2)[0], err)
+		}
+	}
+
+	result, err := tx.ExecContext(ctx, `DELETE FROM `+table+` WHERE id = ANY($1::uuid[])`, array)
+	if err != nil {
+		return 0, err
+	}
+	affected, err := result.RowsAffected()
+	if err != nil {
+		return 0, err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return 0, err
+	}
+	return affected, nil
+}
@@ -0,0 +1,128 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/raflytch/careerly-server/internal/domain"
+
+	"github.com/google/uuid"
+)
+
+const (
+	bankQuestionColumns = `id, job_position, type, question, options, difficulty, correct_answer, created_at`
+
+	bankQuestionFilter = `
+		WHERE ($1 = '' OR lower(job_position) = lower($1))
+		AND ($2 = '' OR type = $2)
+	`
+)
+
+type questionBankRepository struct {
+	db *sql.DB
+}
+
+func NewQuestionBankRepository(db *sql.DB) domain.QuestionBankRepository {
+	return &questionBankRepository{db: db}
+}
+
+// CreateBatch inserts the questions whose dedupe key is not in the bank yet
+// and returns how many were inserted.
+func (r *questionBankRepository) CreateBatch(ctx context.Context, questions []domain.BankQuestion) (int, error) {
+	if len(questions) == 0 {
+		return 0, nil
+	}
+
+	values := make([]string, 0, len(questions))
+	args := make([]interface{}, 0, len(questions)*9)
+	for i, q := range questions {
+		optionsJSON, err := json.Marshal(q.Options)
+		if err != nil {
+			return 0, err
+		}
+		n := i * 9
+		values = append(values, fmt.Sprintf("($%d, $%d, $%d, $%d, $%d, $%d, $%d, $%d, $%d)", n+1, n+2, n+3, n+4, n+5, n+6, n+7, n+8, n+9))
+		args = append(args, q.ID, q.JobPosition, q.Type, q.Question, optionsJSON, q.Difficulty, q.CorrectAnswer, q.DedupeKey, q.CreatedAt)
+	}
+
+	query := `
+		INSERT INTO question_bank (id, job_position, type, question, options, difficulty, correct_answer, dedupe_key, created_at)
+		VALUES ` + strings.Join(values, ", ") + `
+		ON CONFLICT (dedupe_key) DO NOTHING
+	`
+	result, err := r.db.ExecContext(ctx, query, args...)
+	if err != nil {
+		return 0, err
+	}
+	inserted, err := result.RowsAffected()
+	return int(inserted), err
+}
+
+func (r *questionBankRepository) FindAll(ctx context.Context, filter domain.QuestionBankFilter, limit, offset int) ([]domain.BankQuestion, error) {
+	query := `
+		SELECT ` + bankQuestionColumns + `
+		FROM question_bank
+	` + bankQuestionFilter + `
+		ORDER BY job_position ASC, created_at DESC
+		LIMIT $3 OFFSET $4
+	`
+	return r.queryQuestions(ctx, query, filter.JobPosition, filter.Type, limit, offset)
+}
+
+func (r *questionBankRepository) Count(ctx context.Context, filter domain.QuestionBankFilter) (int64, error) {
+	query := `SELECT COUNT(id) FROM question_bank ` + bankQuestionFilter
+	var count int64
+	err := r.db.QueryRowContext(ctx, query, filter.JobPosition, filter.Type).Scan(&count)
+	return count, err
+}
+
+// FindRandom picks up to limit questions for the position at random. An
+// empty difficulty matches every difficulty.
+func (r *questionBankRepository) FindRandom(ctx context.Context, jobPosition string, questionType domain.QuestionType, difficulty domain.QuestionDifficulty, limit int) ([]domain.BankQuestion, error) {
+	query := `
+		SELECT ` + bankQuestionColumns + `
+		FROM question_bank
+		WHERE lower(job_position) = lower($1) AND type = $2
+		  AND ($3 = '' OR difficulty = $3)
+		ORDER BY random()
+		LIMIT $4
+	`
+	return r.queryQuestions(ctx, query, strings.TrimSpace(jobPosition), questionType, difficulty, limit)
+}
+
+func (r *questionBankRepository) Delete(ctx context.Context, id uuid.UUID) (bool, error) {
+	result, err := r.db.ExecContext(ctx, `DELETE FROM question_bank WHERE id = $1`, id)
+	if err != nil {
+		return false, err
+	}
+	affected, err := result.RowsAffected()
+	if err != nil {
+		return false, err
+	}
+	return affected > 0, nil
+}
+
+func (r *questionBankRepository) queryQuestions(ctx context.Context, query string, args ...interface{}) ([]domain.BankQuestion, error) {
+	rows, err := r.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	questions := make([]domain.BankQuestion, 0)
+	for rows.Next() {
+		var q domain.BankQuestion
+		var optionsJSON []byte
+		if err := rows.Scan(&q.ID, &q.JobPosition, &q.Type, &q.Question, &optionsJSON, &q.Difficulty, &q.CorrectAnswer, &q.CreatedAt); err != nil {
+			return nil, err
+		}
+		if err := json.Unmarshal(optionsJSON, &q.Options); err != nil {
+			return nil, err
+		}
+		questions = append(questions, q)
+	}
+	return questions, rows.Err()
+}
@@ -0,0 +1,117 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"time"
+
+	"github.com/raflytch/careerly-server/internal/domain"
+
+	"github.com/google/uuid"
+)
+
+const quotaOverrideColumns = `id, user_id, feature, amount, unlimited, reason, expires_at, created_by, created_at, revoked_at`
+
+type quotaOverrideRepository struct {
+	db *sql.DB
+}
+
+func NewQuotaOverrideRepository(db *sql.DB) domain.QuotaOverrideRepository {
+	return &quotaOverrideRepository{db: db}
+}
+
+func (r *quotaOverrideRepository) Create(ctx context.Context, override *domain.QuotaOverride) error {
+	query := `
+		INSERT INTO quota_overrides (` + quotaOverrideColumns + `)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10)
+	`
+	_, err := r.db.ExecContext(ctx, query,
+		override.ID,
+		override.UserID,
+		override.Feature,
+		override.Amount,
+		override.Unlimited,
+		override.Reason,
+		override.ExpiresAt,
+		override.CreatedBy,
+		override.CreatedAt,
+		override.RevokedAt,
+	)
+	return err
+}
+
+func (r *quotaOverrideRepository) FindByID(ctx context.Context, id uuid.UUID) (*domain.QuotaOverride, error) {
+	query := `SELECT ` + quotaOverrideColumns + ` FROM quota_overrides WHERE id = $1`
+	var override domain.QuotaOverride
+	err := r.db.QueryRowContext(ctx, query, id).Scan(
+		&override.ID,
+		&override.UserID,
+		&override.Feature,
+		&override.Amount,
+		&override.Unlimited,
+		&override.Reason,
+		&override.ExpiresAt,
+		&override.CreatedBy,
+		&override.CreatedAt,
+		&override.RevokedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+	return &override, nil
+}
+
+func (r *quotaOverrideRepository) FindByUserID(ctx context.Context, userID uuid.UUID) ([]domain.QuotaOverride, error) {
+	query := `
+		SELECT ` + quotaOverrideColumns + `
+		FROM quota_overrides
+		WHERE user_id = $1
+		ORDER BY created_at DESC
+	`
+	rows, err := r.db.QueryContext(ctx, query, userID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	overrides := make([]domain.QuotaOverride, 0)
+	for rows.Next() {
+		var override domain.QuotaOverride
+		if err := rows.Scan(
+			&override.ID,
+			&override.UserID,
+			&override.Feature,
+			&override.Amount,
+			&override.Unlimited,
+			&override.Reason,
+			&override.ExpiresAt,
+			&override.CreatedBy,
+			&override.CreatedAt,
+			&override.RevokedAt,
+		); err != nil {
+			return nil, err
+		}
+		overrides = append(overrides, override)
+	}
+	return overrides, rows.Err()
+}
+
+func (r *quotaOverrideRepository) SumActive(ctx context.Context, userID uuid.UUID, feature domain.FeatureType, at time.Time) (*domain.QuotaGrant, error) {
+	query := `
+		SELECT COALESCE(SUM(amount), 0), COALESCE(BOOL_OR(unlimited), false)
+		FROM quota_overrides
+		WHERE user_id = $1 AND feature = $2 AND expires_at > $3 AND revoked_at IS NULL
+	`
+	var grant domain.QuotaGrant
+	err := r.db.QueryRowContext(ctx, query, userID, feature, at).Scan(&grant.Amount, &grant.Unlimited)
+	if err != nil {
+		return nil, err
+	}
+	return &grant, nil
+}
+
+func (r *quotaOverrideRepository) Revoke(ctx context.Context, id uuid.UUID, revokedAt time.Time) error {
+	query := `UPDATE quota_overrides SET revoked_at = $2 WHERE id = $1 AND revoked_at IS NULL`
+	_, err := r.db.ExecContext(ctx, query, id, revokedAt)
+	return err
+}
@@ -0,0 +1,193 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+
+	"github.com/raflytch/careerly-server/internal/domain"
+
+	"github.com/google/uuid"
+)
+
+const (
+	referralColumns = `id, referrer_id, referred_id, code, status, reward_days, qualified_at, rewarded_at, created_at`
+)
+
+type referralRepository struct {
+	db *sql.DB
+}
+
+func NewReferralRepository(db *sql.DB) domain.ReferralRepository {
+	return &referralRepository{db: db}
+}
+
+func (r *referralRepository) CreateCode(ctx context.Context, code *domain.ReferralCode) error {
+	query := `
+		INSERT INTO referral_codes (user_id, code, created_at)
+		VALUES ($1, $2, $3)
+	`
+	_, err := r.db.ExecContext(ctx, query, code.UserID, code.Code, code.CreatedAt)
+	return err
+}
+
+func (r *referralRepository) FindCodeByUserID(ctx context.Context, userID uuid.UUID) (*domain.ReferralCode, error) {
+	query := `SELECT user_id, code, created_at FROM referral_codes WHERE user_id = $1`
+	var code domain.ReferralCode
+	err := r.db.QueryRowContext(ctx, query, userID).Scan(&code.UserID, &code.Code, &code.CreatedAt)
+	if err != nil {
+		return nil, err
+	}
+	return &code, nil
+}
+
+func (r *referralRepository) FindCodeByCode(ctx context.Context, code string) (*domain.ReferralCode, error) {
+	query := `SELECT user_id, code, created_at FROM referral_codes WHERE code = $1`
+	var referralCode domain.ReferralCode
+	err := r.db.QueryRowContext(ctx, query, code).Scan(&referralCode.UserID, &referralCode.Code, &referralCode.CreatedAt)
+	if err != nil {
+		return nil, err
+	}
+	return &referralCode, nil
+}
+
+func (r *referralRepository) Create(ctx context.Context, referral *domain.Referral) error {
+	query := `
+		INSERT INTO referrals (` + referralColumns + `)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9)
+	`
+	_, err := r.db.ExecContext(ctx, query,
+		referral.ID,
+		referral.ReferrerID,
+		referral.ReferredID,
+		referral.Code,
+		referral.Status,
+		referral.RewardDays,
+		referral.QualifiedAt,
+		referral.RewardedAt,
+		referral.CreatedAt,
+	)
+	return err
+}
+
+func (r *referralRepository) FindByReferredID(ctx context.Context, referredID uuid.UUID) (*domain.Referral, error) {
+	query := `
+		SELECT ` + referralColumns + `
+		FROM referrals
+		WHERE referred_id = $1
+	`
+	return r.scanReferral(r.db.QueryRowContext(ctx, query, referredID))
+}
+
+func (r *referralRepository) FindByReferrerIDAndStatus(ctx context.Context, referrerID uuid.UUID, status domain.ReferralStatus) ([]domain.Referral, error) {
+	query := `
+		SELECT ` + referralColumns + `
+		FROM referrals
+		WHERE referrer_id = $1 AND status = $2
+		ORDER BY created_at ASC
+	`
+	rows, err := r.db.QueryContext(ctx, query, referrerID, status)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	referrals := make([]domain.Referral, 0)
+	for rows.Next() {
+		referral, err := r.scanReferralFromRows(rows)
+		if err != nil {
+			return nil, err
+		}
+		referrals = append(referrals, *referral)
+	}
+	return referrals, rows.Err()
+}
+
+func (r *referralRepository) CountByStatus(ctx context.Context, referrerID uuid.UUID) (map[domain.ReferralStatus]int64, error) {
+	query := `
+		SELECT status, COUNT(id)
+		FROM referrals
+		WHERE referrer_id = $1
+		GROUP BY status
+	`
+	rows, err := r.db.QueryContext(ctx, query, referrerID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	counts := make(map[domain.ReferralStatus]int64)
+	for rows.Next() {
+		var status string
+		var count int64
+		if err := rows.Scan(&status, &count); err != nil {
+			return nil, err
+		}
+		counts[domain.ReferralStatus(status)] = count
+	}
+	return counts, rows.Err()
+}
+
+func (r *referralRepository) SumRewardedDays(ctx context.Context, referrerID uuid.UUID) (int64, error) {
+	query := `SELECT COALESCE(SUM(reward_days), 0) FROM referrals WHERE referrer_id = $1 AND status = $2`
+	var total int64
+	err := r.db.QueryRowContext(ctx, query, referrerID, domain.ReferralStatusRewarded).Scan(&total)
+	return total, err
+}
+
+func (r *referralRepository) Update(ctx context.Context, referral *domain.Referral) error {
+	query := `
+		UPDATE referrals
+		SET status = $1, reward_days = $2, qualified_at = $3, rewarded_at = $4
+		WHERE id = $5
+	`
+	_, err := r.db.ExecContext(ctx, query,
+		referral.Status,
+		referral.RewardDays,
+		referral.QualifiedAt,
+		referral.RewardedAt,
+		referral.ID,
+	)
+	return err
+}
+
+func (r *referralRepository) scanReferral(row *sql.Row) (*domain.Referral, error) {
+	var referral domain.Referral
+	var status string
+	err := row.Scan(
+		&referral.ID,
+		&referral.ReferrerID,
+		&referral.ReferredID,
+		&referral.Code,
+		&status,
+		&referral.RewardDays,
+		&referral.QualifiedAt,
+		&referral.RewardedAt,
+		&referral.CreatedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+	referral.Status = domain.ReferralStatus(status)
+	return &referral, nil
+}
+
+func (r *referralRepository) scanReferralFromRows(rows *sql.Rows) (*domain.Referral, error) {
+	var referral domain.Referral
+	var status string
+	err := rows.Scan(
+		&referral.ID,
+		&referral.ReferrerID,
+		&referral.ReferredID,
+		&referral.Code,
+		&status,
+		&referral.RewardDays,
+		&referral.QualifiedAt,
+		&referral.RewardedAt,
+		&referral.CreatedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+	referral.Status = domain.ReferralStatus(status)
+	return &referral, nil
+}
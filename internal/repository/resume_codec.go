@@ -0,0 +1,83 @@
+package repository
+
+import (
+	"encoding/json"
+
+	"github.com/raflytch/careerly-server/internal/domain"
+	"github.com/raflytch/careerly-server/pkg/fieldcrypt"
+)
+
+// resumeCodec converts resume content to and from its stored JSON form,
+// encrypting the personal contact fields on the way in and decrypting them
+// on the way out. Content in an older schema is migrated before it is
+// decoded, and encoding always stamps the current schema version. A nil cipher stores new values in plaintext and refuses to
+// read values that were encrypted.
+type resumeCodec struct {
+	cipher *fieldcrypt.Cipher
+}
+
+func (c resumeCodec) encode(original *domain.ResumeContent) ([]byte, error) {
+	original.SchemaVersion = currentResumeSchemaVersion
+	content := *original
+	if c.cipher != nil {
+		for _, field := range sensitiveFields(&content.PersonalInfo) {
+			encrypted, err := c.cipher.Encrypt(*field)
+			if err != nil {
+				return nil, err
+			}
+			*field = encrypted
+		}
+	}
+	return json.Marshal(content)
+}
+
+func (c resumeCodec) decode(data []byte, content *domain.ResumeContent) error {
+	data, err := migrateResumeDocument(data)
+	if err != nil {
+		return err
+	}
+	if err := json.Unmarshal(data, content); err != nil {
+		return err
+	}
+	for _, field := range sensitiveFields(&content.PersonalInfo) {
+		if !fieldcrypt.IsEncrypted(*field) {
+			continue
+		}
+		if c.cipher == nil {
+			return fieldcrypt.ErrUnknownKey
+		}
+		plaintext, err := c.cipher.Decrypt(*field)
+		if err != nil {
+			return err
+		}
+		*field = plaintext
+	}
+	return nil
+}
+
+// searchDocument is the content used to build the search vector. The
+// sensitive fields are left out so ciphertext never ends up in the index.
+func (c resumeCodec) searchDocument(content domain.ResumeContent) ([]byte, error) {
+	for _, field := range sensitiveFields(&content.PersonalInfo) {
+		*field = ""
+	}
+	return json.Marshal(content)
+}
+
+// needsReencryption reports whether any sensitive field is stored in
+// plaintext or under a key that is no longer active.
+func (c resumeCodec) needsReencryption(content *domain.ResumeContent) bool {
+	if c.cipher == nil {
+		return false
+	}
+	for _, field := range sensitiveFields(&content.PersonalInfo) {
+		if c.cipher.NeedsRotation(*field) {
+			return true
+		}
+	}
+	return false
+}
+
+func sensitiveFields(info *domain.PersonalInfo) []*string {
+	return []*string{&info.Email, &info.Phone, &info.DateOfBirth}
+}
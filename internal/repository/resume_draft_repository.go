@@ -0,0 +1,378 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+
+	"github.com/raflytch/careerly-server/internal/config"
+	"github.com/raflytch/careerly-server/internal/domain"
+	"github.com/raflytch/careerly-server/pkg/fieldcrypt"
+
+	"github.com/google/uuid"
+)
+
+const resumeDraftColumns = `id, user_id, title, content, created_at, updated_at, data_region`
+
+type resumeDraftRepository struct {
+	db      *sql.DB
+	codec   resumeCodec
+	regions regionTables
+}
+
+// NewResumeDraftRepository encrypts draft content the same way resumes are
+// encrypted, so a draft holds no more plaintext than the resume it becomes.
+// Drafts of pinned users keep their content in the region, like resumes.
+func NewResumeDraftRepository(db *sql.DB, cipher *fieldcrypt.Cipher, regions config.DataRegionConfig) domain.ResumeDraftRepository {
+	return &resumeDraftRepository{db: db, codec: resumeCodec{cipher: cipher}, regions: newRegionTables(regions, "resume_draft_contents")}
+}
+
+// Create stores the draft in its owner's data region, which it sets on
+// draft.
+func (r *resumeDraftRepository) Create(ctx context.Context, draft *domain.ResumeDraft) error {
+	err := r.db.QueryRowContext(ctx, `SELECT data_region FROM users WHERE id = $1`, draft.UserID).Scan(&draft.DataRegion)
+	if err != nil {
+		return err
+	}
+
+	content, regional, err := r.encode(draft)
+	if err != nil {
+		return err
+	}
+
+	query := `
+		INSERT INTO resume_drafts (` + resumeDraftColumns + `)
+		VALUES ($1, $2, $3, $4, $5, $6, $7)
+	`
+	args := []interface{}{
+		draft.ID,
+		draft.UserID,
+		draft.Title,
+		content,
+		draft.CreatedAt,
+		draft.UpdatedAt,
+		draft.DataRegion,
+	}
+	query, args, err = r.withRegionalContent(query, args, draft.DataRegion, regional)
+	if err != nil {
+		return err
+	}
+
+	_, err = r.db.ExecContext(ctx, query, args...)
+	return err
+}
+
+func (r *resumeDraftRepository) FindByID(ctx context.Context, id uuid.UUID) (*domain.ResumeDraft, error) {
+	query := `
+		SELECT ` + resumeDraftColumns + `
+		FROM resume_drafts
+		WHERE id = $1
+	`
+	draft, err := r.scanDraft(r.db.QueryRowContext(ctx, query, id))
+	if err != nil {
+		return nil, err
+	}
+	if err := r.loadRegionalContent(ctx, []*domain.ResumeDraft{draft}); err != nil {
+		return nil, err
+	}
+	return draft, nil
+}
+
+func (r *resumeDraftRepository) FindByUserID(ctx context.Context, userID uuid.UUID) ([]domain.ResumeDraft, error) {
+	query := `
+		SELECT ` + resumeDraftColumns + `
+		FROM resume_drafts
+		WHERE user_id = $1
+		ORDER BY updated_at DESC
+	`
+	rows, err := r.db.QueryContext(ctx, query, userID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	drafts := make([]domain.ResumeDraft, 0)
+	for rows.Next() {
+		draft, err := r.scanDraftFromRows(rows)
+		if err != nil {
+			return nil, err
+		}
+		drafts = append(drafts, *draft)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	pointers := make([]*domain.ResumeDraft, 0, len(drafts))
+	for i := range drafts {
+		pointers = append(pointers, &drafts[i])
+	}
+	return drafts, r.loadRegionalContent(ctx, pointers)
+}
+
+func (r *resumeDraftRepository) CountByUserID(ctx context.Context, userID uuid.UUID) (int64, error) {
+	query := `SELECT COUNT(id) FROM resume_drafts WHERE user_id = $1`
+	var count int64
+	err := r.db.QueryRowContext(ctx, query, userID).Scan(&count)
+	return count, err
+}
+
+// Update saves the draft in the data region it was created in.
+func (r *resumeDraftRepository) Update(ctx context.Context, draft *domain.ResumeDraft) error {
+	content, regional, err := r.encode(draft)
+	if err != nil {
+		return err
+	}
+
+	query := `
+		UPDATE resume_drafts
+		SET title = $1, content = $2, updated_at = $3
+		WHERE id = $4
+	`
+	args := []interface{}{draft.Title, content, draft.UpdatedAt, draft.ID}
+	query, args, err = r.withRegionalContent(query, args, draft.DataRegion, regional)
+	if err != nil {
+		return err
+	}
+
+	_, err = r.db.ExecContext(ctx, query, args...)
+	return err
+}
+
+func (r *resumeDraftRepository) Delete(ctx context.Context, id uuid.UUID) error {
+	query := `DELETE FROM resume_drafts WHERE id = $1`
+	_, err := r.db.ExecContext(ctx, query, id)
+	return err
+}
+
+// ReencryptBatch re-seals the content of up to limit drafts after afterID,
+// wherever their region keeps it, whose sensitive fields are still in
+// plaintext or sealed with a retired key. updated_at is left untouched, and
+// a draft that changed since it was read is skipped; the next run picks it
+// up.
+func (r *resumeDraftRepository) ReencryptBatch(ctx context.Context, afterID uuid.UUID, limit int) (*domain.ResumeBatchResult, error) {
+	query := `
+		SELECT id, content, data_region
+		FROM resume_drafts
+		WHERE id > $1
+		ORDER BY id
+		LIMIT $2
+	`
+	rows, err := r.db.QueryContext(ctx, query, afterID, limit)
+	if err != nil {
+		return nil, err
+	}
+
+	type stored struct {
+		id      uuid.UUID
+		region  string
+		content []byte
+	}
+
+	result := &domain.ResumeBatchResult{LastID: afterID}
+	items := make([]*stored, 0)
+	pinned := make(map[string][]uuid.UUID)
+	for rows.Next() {
+		item := &stored{}
+		if err := rows.Scan(&item.id, &item.content, &item.region); err != nil {
+			rows.Close()
+			return nil, err
+		}
+		items = append(items, item)
+		if item.region != "" {
+			pinned[item.region] = append(pinned[item.region], item.id)
+		}
+		result.Scanned++
+		result.LastID = item.id
+	}
+	rows.Close()
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	byID := make(map[uuid.UUID]*stored, len(items))
+	for _, item := range items {
+		byID[item.id] = item
+	}
+	for region, ids := range pinned {
+		table, err := r.regions.table(region)
+		if err != nil {
+			return nil, err
+		}
+		placeholders, args := uuidPlaceholders(ids, 1)
+		rows, err := r.db.QueryContext(ctx, `SELECT draft_id, content FROM `+table+` WHERE draft_id IN (`+placeholders+`)`, args...)
+		if err != nil {
+			return nil, err
+		}
+		for rows.Next() {
+			var id uuid.UUID
+			var content []byte
+			if err := rows.Scan(&id, &content); err != nil {
+				rows.Close()
+				return nil, err
+			}
+			byID[id].content = content
+		}
+		rows.Close()
+		if err := rows.Err(); err != nil {
+			return nil, err
+		}
+	}
+
+	for _, item := range items {
+		var content domain.ResumeContent
+		if err := r.codec.decode(item.content, &content); err != nil {
+			return nil, fmt.Errorf("draft %s: %w", item.id, err)
+		}
+		if !r.codec.needsReencryption(&content) {
+			continue
+		}
+		contentJSON, err := r.codec.encode(&content)
+		if err != nil {
+			return nil, err
+		}
+
+		update := `UPDATE resume_drafts SET content = $1 WHERE id = $2 AND content = $3::jsonb`
+		if item.region != "" {
+			table, err := r.regions.table(item.region)
+			if err != nil {
+				return nil, err
+			}
+			update = `UPDATE ` + table + ` SET content = $1 WHERE draft_id = $2 AND content = $3::jsonb`
+		}
+		res, err := r.db.ExecContext(ctx, update, contentJSON, item.id, item.content)
+		if err != nil {
+			return nil, fmt.Errorf("draft %s: %w", item.id, err)
+		}
+		if affected, _ := res.RowsAffected(); affected > 0 {
+			result.Updated++
+		}
+	}
+
+	return result, nil
+}
+
+func (r *resumeDraftRepository) scanDraft(row *sql.Row) (*domain.ResumeDraft, error) {
+	var draft domain.ResumeDraft
+	var contentJSON []byte
+	err := row.Scan(
+		&draft.ID,
+		&draft.UserID,
+		&draft.Title,
+		&contentJSON,
+		&draft.CreatedAt,
+		&draft.UpdatedAt,
+		&draft.DataRegion,
+	)
+	if err != nil {
+		return nil, err
+	}
+	if err := r.codec.decode(contentJSON, &draft.Content); err != nil {
+		return nil, err
+	}
+	return &draft, nil
+}
+
+func (r *resumeDraftRepository) scanDraftFromRows(rows *sql.Rows) (*domain.ResumeDraft, error) {
+	var draft domain.ResumeDraft
+	var contentJSON []byte
+	err := rows.Scan(
+		&draft.ID,
+		&draft.UserID,
+		&draft.Title,
+		&contentJSON,
+		&draft.CreatedAt,
+		&draft.UpdatedAt,
+		&draft.DataRegion,
+	)
+	if err != nil {
+		return nil, err
+	}
+	if err := r.codec.decode(contentJSON, &draft.Content); err != nil {
+		return nil, err
+	}
+	return &draft, nil
+}
+
+// encode returns the content for the resume_drafts row and, for a pinned
+// draft, the content stored in its region. The row of a pinned draft keeps
+// an empty document.
+func (r *resumeDraftRepository) encode(draft *domain.ResumeDraft) ([]byte, []byte, error) {
+	content, err := r.codec.encode(&draft.Content)
+	if err != nil {
+		return nil, nil, err
+	}
+	if draft.DataRegion == "" {
+		return content, nil, nil
+	}
+
+	placeholder, err := r.codec.encode(&domain.ResumeContent{})
+	if err != nil {
+		return nil, nil, err
+	}
+	return placeholder, content, nil
+}
+
+// withRegionalContent makes the statement writing the resume_drafts row
+// also write the regional content, when there is any.
+func (r *resumeDraftRepository) withRegionalContent(query string, args []interface{}, region string, content []byte) (string, []interface{}, error) {
+	if content == nil {
+		return query, args, nil
+	}
+	table, err := r.regions.table(region)
+	if err != nil {
+		return "", nil, err
+	}
+	query, args = withRegionalRow(query, args, table, "draft_id", "jsonb", content)
+	return query, args, nil
+}
+
+// loadRegionalContent replaces the placeholder content of pinned drafts
+// with the content stored in their region.
+func (r *resumeDraftRepository) loadRegionalContent(ctx context.Context, drafts []*domain.ResumeDraft) error {
+	byRegion := make(map[string][]*domain.ResumeDraft)
+	for _, draft := range drafts {
+		if draft.DataRegion != "" {
+			byRegion[draft.DataRegion] = append(byRegion[draft.DataRegion], draft)
+		}
+	}
+
+	for region, pinned := range byRegion {
+		table, err := r.regions.table(region)
+		if err != nil {
+			return err
+		}
+
+		byID := make(map[uuid.UUID]*domain.ResumeDraft, len(pinned))
+		ids := make([]uuid.UUID, 0, len(pinned))
+		for _, draft := range pinned {
+			byID[draft.ID] = draft
+			ids = append(ids, draft.ID)
+		}
+
+		placeholders, args := uuidPlaceholders(ids, 1)
+		rows, err := r.db.QueryContext(ctx, `SELECT draft_id, content FROM `+table+` WHERE draft_id IN (`+placeholders+`)`, args...)
+		if err != nil {
+			return err
+		}
+		for rows.Next() {
+			var id uuid.UUID
+			var contentJSON []byte
+			if err := rows.Scan(&id, &contentJSON); err != nil {
+				rows.Close()
+				return err
+			}
+			draft := byID[id]
+			draft.Content = domain.ResumeContent{}
+			if err := r.codec.decode(contentJSON, &draft.Content); err != nil {
+				rows.Close()
+				return fmt.Errorf("draft %s: %w", id, err)
+			}
+		}
+		rows.Close()
+		if err := rows.Err(); err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,227 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"strings"
+
+	"github.com/raflytch/careerly-server/internal/domain"
+
+	"github.com/google/uuid"
+)
+
+const (
+	resumeTagColumns    = `id, user_id, name, created_at`
+	resumeFolderColumns = `id, user_id, name, created_at`
+)
+
+type resumeLibraryRepository struct {
+	db *sql.DB
+}
+
+func NewResumeLibraryRepository(db *sql.DB) domain.ResumeLibraryRepository {
+	return &resumeLibraryRepository{db: db}
+}
+
+// CreateTag reports false when the user already has a tag with that name,
+// compared case-insensitively.
+func (r *resumeLibraryRepository) CreateTag(ctx context.Context, tag *domain.ResumeTag) (bool, error) {
+	query := `
+		INSERT INTO resume_tags (` + resumeTagColumns + `)
+		VALUES ($1, $2, $3, $4)
+		ON CONFLICT (user_id, lower(name)) DO NOTHING
+	`
+	return r.execAffected(ctx, query, tag.ID, tag.UserID, tag.Name, tag.CreatedAt)
+}
+
+func (r *resumeLibraryRepository) FindTagByID(ctx context.Context, id uuid.UUID) (*domain.ResumeTag, error) {
+	query := `SELECT ` + resumeTagColumns + ` FROM resume_tags WHERE id = $1`
+	var tag domain.ResumeTag
+	err := r.db.QueryRowContext(ctx, query, id).Scan(&tag.ID, &tag.UserID, &tag.Name, &tag.CreatedAt)
+	if err != nil {
+		return nil, err
+	}
+	return &tag, nil
+}
+
+func (r *resumeLibraryRepository) FindTagsByUserID(ctx context.Context, userID uuid.UUID) ([]domain.ResumeTag, error) {
+	query := `
+		SELECT t.id, t.user_id, t.name, t.created_at, COUNT(res.id)
+		FROM resume_tags t
+		LEFT JOIN resume_tag_assignments a ON a.tag_id = t.id
+		LEFT JOIN resumes res ON res.id = a.resume_id AND res.deleted_at IS NULL
+		WHERE t.user_id = $1
+		GROUP BY t.id
+		ORDER BY lower(t.name)
+	`
+	rows, err := r.db.QueryContext(ctx, query, userID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	tags := make([]domain.ResumeTag, 0)
+	for rows.Next() {
+		var tag domain.ResumeTag
+		if err := rows.Scan(&tag.ID, &tag.UserID, &tag.Name, &tag.CreatedAt, &tag.ResumeCount); err != nil {
+			return nil, err
+		}
+		tags = append(tags, tag)
+	}
+	return tags, rows.Err()
+}
+
+func (r *resumeLibraryRepository) CountOwnedTags(ctx context.Context, userID uuid.UUID, ids []uuid.UUID) (int, error) {
+	if len(ids) == 0 {
+		return 0, nil
+	}
+
+	placeholders, args := uuidPlaceholders(ids, 2)
+	query := `SELECT COUNT(id) FROM resume_tags WHERE user_id = $1 AND id IN (` + placeholders + `)`
+	var count int
+	err := r.db.QueryRowContext(ctx, query, append([]interface{}{userID}, args...)...).Scan(&count)
+	return count, err
+}
+
+// RenameTag reports false when another of the user's tags has the name.
+func (r *resumeLibraryRepository) RenameTag(ctx context.Context, tag *domain.ResumeTag) (bool, error) {
+	query := `
+		UPDATE resume_tags SET name = $2
+		WHERE id = $1 AND NOT EXISTS (
+			SELECT 1 FROM resume_tags
+			WHERE user_id = $3 AND lower(name) = lower($2) AND id <> $1
+		)
+	`
+	return r.execAffected(ctx, query, tag.ID, tag.Name, tag.UserID)
+}
+
+func (r *resumeLibraryRepository) DeleteTag(ctx context.Context, id uuid.UUID) error {
+	query := `DELETE FROM resume_tags WHERE id = $1`
+	_, err := r.db.ExecContext(ctx, query, id)
+	return err
+}
+
+// SetResumeTags replaces the tags of a resume with tagIDs in one statement.
+func (r *resumeLibraryRepository) SetResumeTags(ctx context.Context, resumeID uuid.UUID, tagIDs []uuid.UUID) error {
+	if len(tagIDs) == 0 {
+		query := `DELETE FROM resume_tag_assignments WHERE resume_id = $1`
+		_, err := r.db.ExecContext(ctx, query, resumeID)
+		return err
+	}
+
+	placeholders, args := uuidPlaceholders(tagIDs, 2)
+	values := make([]string, 0, len(tagIDs))
+	for i := range tagIDs {
+		values = append(values, fmt.Sprintf("($1, $%d::uuid)", i+2))
+	}
+
+	query := `
+		WITH removed AS (
+			DELETE FROM resume_tag_assignments
+			WHERE resume_id = $1 AND tag_id NOT IN (` + placeholders + `)
+		)
+		INSERT INTO resume_tag_assignments (resume_id, tag_id)
+		VALUES ` + strings.Join(values, ", ") + `
+		ON CONFLICT DO NOTHING
+	`
+	_, err := r.db.ExecContext(ctx, query, append([]interface{}{resumeID}, args...)...)
+	return err
+}
+
+// CreateFolder reports false when the user already has a folder with that
+// name, compared case-insensitively.
+func (r *resumeLibraryRepository) CreateFolder(ctx context.Context, folder *domain.ResumeFolder) (bool, error) {
+	query := `
+		INSERT INTO resume_folders (` + resumeFolderColumns + `)
+		VALUES ($1, $2, $3, $4)
+		ON CONFLICT (user_id, lower(name)) DO NOTHING
+	`
+	return r.execAffected(ctx, query, folder.ID, folder.UserID, folder.Name, folder.CreatedAt)
+}
+
+func (r *resumeLibraryRepository) FindFolderByID(ctx context.Context, id uuid.UUID) (*domain.ResumeFolder, error) {
+	query := `SELECT ` + resumeFolderColumns + ` FROM resume_folders WHERE id = $1`
+	var folder domain.ResumeFolder
+	err := r.db.QueryRowContext(ctx, query, id).Scan(&folder.ID, &folder.UserID, &folder.Name, &folder.CreatedAt)
+	if err != nil {
+		return nil, err
+	}
+	return &folder, nil
+}
+
+func (r *resumeLibraryRepository) FindFoldersByUserID(ctx context.Context, userID uuid.UUID) ([]domain.ResumeFolder, error) {
+	query := `
+		SELECT f.id, f.user_id, f.name, f.created_at, COUNT(res.id)
+		FROM resume_folders f
+		LEFT JOIN resumes res ON res.folder_id = f.id AND res.deleted_at IS NULL
+		WHERE f.user_id = $1
+		GROUP BY f.id
+		ORDER BY lower(f.name)
+	`
+	rows, err := r.db.QueryContext(ctx, query, userID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	folders := make([]domain.ResumeFolder, 0)
+	for rows.Next() {
+		var folder domain.ResumeFolder
+		if err := rows.Scan(&folder.ID, &folder.UserID, &folder.Name, &folder.CreatedAt, &folder.ResumeCount); err != nil {
+			return nil, err
+		}
+		folders = append(folders, folder)
+	}
+	return folders, rows.Err()
+}
+
+// RenameFolder reports false when another of the user's folders has the
+// name.
+func (r *resumeLibraryRepository) RenameFolder(ctx context.Context, folder *domain.ResumeFolder) (bool, error) {
+	query := `
+		UPDATE resume_folders SET name = $2
+		WHERE id = $1 AND NOT EXISTS (
+			SELECT 1 FROM resume_folders
+			WHERE user_id = $3 AND lower(name) = lower($2) AND id <> $1
+		)
+	`
+	return r.execAffected(ctx, query, folder.ID, folder.Name, folder.UserID)
+}
+
+// DeleteFolder leaves the folder's resumes in place; the foreign key clears
+// their folder_id.
+func (r *resumeLibraryRepository) DeleteFolder(ctx context.Context, id uuid.UUID) error {
+	query := `DELETE FROM resume_folders WHERE id = $1`
+	_, err := r.db.ExecContext(ctx, query, id)
+	return err
+}
+
+func (r *resumeLibraryRepository) MoveResume(ctx context.Context, resumeID uuid.UUID, folderID *uuid.UUID) error {
+	query := `UPDATE resumes SET folder_id = $2 WHERE id = $1 AND deleted_at IS NULL`
+	_, err := r.db.ExecContext(ctx, query, resumeID, folderID)
+	return err
+}
+
+func (r *resumeLibraryRepository) execAffected(ctx context.Context, query string, args ...interface{}) (bool, error) {
+	result, err := r.db.ExecContext(ctx, query, args...)
+	if err != nil {
+		return false, err
+	}
+	rows, err := result.RowsAffected()
+	if err != nil {
+		return false, err
+	}
+	return rows > 0, nil
+}
+
+// uuidPlaceholders numbers one placeholder per id starting at $start.
+func uuidPlaceholders(ids []uuid.UUID, start int) (string, []interface{}) {
+	placeholders := make([]string, 0, len(ids))
+	args := make([]interface{}, 0, len(ids))
+	for i, id := range ids {
+		placeholders = append(placeholders, fmt.Sprintf("$%d", start+i))
+		args = append(args, id)
+	}
+	return strings.Join(placeholders, ", "), args
+}
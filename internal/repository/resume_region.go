@@ -0,0 +1,155 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/raflytch/careerly-server/internal/config"
+	"github.com/raflytch/careerly-server/internal/domain"
+
+	"github.com/google/uuid"
+	"github.com/lib/pq"
+)
+
+// regionTables maps a data region to the table in its schema that holds
+// the regional copy of one kind of record. The row in the default schema
+// keeps only what is needed to find the record; for a pinned resume that is
+// an empty content document, so only its title is searchable.
+type regionTables map[string]string
+
+func newRegionTables(cfg config.DataRegionConfig, table string) regionTables {
+	tables := make(regionTables, len(cfg.Regions))
+	for name, region := range cfg.Regions {
+		tables[name] = pq.QuoteIdentifier(region.Schema) + "." + table
+	}
+	return tables
+}
+
+func (r regionTables) table(region string) (string, error) {
+	table, ok := r[region]
+	if !ok {
+		return "", fmt.Errorf("data region %q is not configured", region)
+	}
+	return table, nil
+}
+
+type resumeDocument struct {
+	content  []byte
+	search   []byte
+	regional []byte
+}
+
+func (r *resumeRepository) userRegion(ctx context.Context, userID uuid.UUID) (string, error) {
+	var region string
+	err := r.db.QueryRowContext(ctx, `SELECT data_region FROM users WHERE id = $1`, userID).Scan(&region)
+	return region, err
+}
+
+// encodeForRegion returns what goes in the resumes row and, for a pinned
+// resume, the content stored in its region.
+func (r *resumeRepository) encodeForRegion(resume *domain.Resume) (*resumeDocument, error) {
+	content, err := r.codec.encode(&resume.Content)
+	if err != nil {
+		return nil, err
+	}
+	if resume.DataRegion == "" {
+		search, err := r.codec.searchDocument(resume.Content)
+		if err != nil {
+			return nil, err
+		}
+		return &resumeDocument{content: content, search: search}, nil
+	}
+
+	empty := domain.ResumeContent{}
+	placeholder, err := r.codec.encode(&empty)
+	if err != nil {
+		return nil, err
+	}
+	search, err := r.codec.searchDocument(empty)
+	if err != nil {
+		return nil, err
+	}
+	return &resumeDocument{content: placeholder, search: search, regional: content}, nil
+}
+
+// withRegionalContent wraps a statement writing the resumes row so the same
+// statement also upserts the content into the region's table.
+func (r *resumeRepository) withRegionalContent(query string, args []interface{}, region string, content []byte) (string, []interface{}, error) {
+	table, err := r.regions.table(region)
+	if err != nil {
+		return "", nil, err
+	}
+	query, args = withRegionalRow(query, args, table, "resume_id", "jsonb", content)
+	return query, args, nil
+}
+
+// withRegionalRow wraps a statement writing a row of the default schema so
+// the same statement also upserts content of contentType, keyed by the row's
+// id, into a regional table.
+func withRegionalRow(query string, args []interface{}, table, key, contentType string, content []byte) (string, []interface{}) {
+	wrapped := fmt.Sprintf(`
+		WITH main AS (%s RETURNING id)
+		INSERT INTO %s (%s, content)
+		SELECT id, $%d::%s FROM main
+		ON CONFLICT (%s) DO UPDATE SET content = EXCLUDED.content
+	`, query, table, key, len(args)+1, contentType, key)
+	return wrapped, append(args, content)
+}
+
+// loadRegionalContent replaces the placeholder content of pinned resumes
+// with the content stored in their region.
+func (r *resumeRepository) loadRegionalContent(ctx context.Context, resumes []*domain.Resume) error {
+	byRegion := make(map[string][]*domain.Resume)
+	for _, resume := range resumes {
+		if resume.DataRegion != "" {
+			byRegion[resume.DataRegion] = append(byRegion[resume.DataRegion], resume)
+		}
+	}
+
+	for region, pinned := range byRegion {
+		table, err := r.regions.table(region)
+		if err != nil {
+			return err
+		}
+
+		byID := make(map[uuid.UUID]*domain.Resume, len(pinned))
+		ids := make([]uuid.UUID, 0, len(pinned))
+		for _, resume := range pinned {
+			byID[resume.ID] = resume
+			ids = append(ids, resume.ID)
+		}
+
+		placeholders, args := uuidPlaceholders(ids, 1)
+		rows, err := r.db.QueryReadContext(ctx, `SELECT resume_id, content FROM `+table+` WHERE resume_id IN (`+placeholders+`)`, args...)
+		if err != nil {
+			return err
+		}
+		for rows.Next() {
+			var id uuid.UUID
+			var contentJSON []byte
+			if err := rows.Scan(&id, &contentJSON); err != nil {
+				rows.Close()
+				return err
+			}
+			resume := byID[id]
+			resume.Content = domain.ResumeContent{}
+			if err := r.codec.decode(contentJSON, &resume.Content); err != nil {
+				rows.Close()
+				return fmt.Errorf("resume %s: %w", id, err)
+			}
+		}
+		rows.Close()
+		if err := rows.Err(); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func resumePointers(resumes []domain.Resume) []*domain.Resume {
+	pointers := make([]*domain.Resume, 0, len(resumes))
+	for i := range resumes {
+		pointers = append(pointers, &resumes[i])
+	}
+	return pointers
+}
@@ -4,44 +4,99 @@ import (
 	"context"
 	"database/sql"
 	"encoding/json"
+	"fmt"
 	"time"
 
+	"github.com/raflytch/careerly-server/internal/config"
+	"github.com/raflytch/careerly-server/internal/database"
 	"github.com/raflytch/careerly-server/internal/domain"
+	"github.com/raflytch/careerly-server/pkg/fieldcrypt"
 
 	"github.com/google/uuid"
 )
 
 const (
-	resumeColumns = `id, user_id, title, content, is_active, created_at, updated_at, deleted_at`
+	resumeColumns = `id, user_id, title, content, is_active, created_at, updated_at, deleted_at, folder_id, data_region, ` + resumeTagsColumn
+
+	// resumeTagsColumn aggregates the resume's tags as a JSON array so every
+	// read returns them without a second query.
+	resumeTagsColumn = `COALESCE((
+		SELECT json_agg(json_build_object('id', t.id, 'name', t.name) ORDER BY lower(t.name))
+		FROM resume_tag_assignments a
+		JOIN resume_tags t ON t.id = a.tag_id
+		WHERE a.resume_id = resumes.id
+	), '[]')`
+
+	// resumeFilterClause matches every resume when the filter argument is
+	// NULL. %[1]d and %[2]d are the tag and folder placeholders.
+	resumeFilterClause = `
+		AND ($%[1]d::uuid IS NULL OR EXISTS (SELECT 1 FROM resume_tag_assignments WHERE resume_id = resumes.id AND tag_id = $%[1]d))
+		AND ($%[2]d::uuid IS NULL OR folder_id = $%[2]d)`
+
+	// resumeSearchVector weights the title above string values found
+	// anywhere in the content document. %[1]s and %[2]s are the title and
+	// search document placeholders of the surrounding statement.
+	resumeSearchVector = `setweight(to_tsvector('simple', coalesce(%[1]s, '')), 'A') || setweight(jsonb_to_tsvector('simple', %[2]s::jsonb, '["string"]'), 'B')`
+
+	// resumeSearchText flattens every string value of the content so
+	// ts_headline can build snippets without JSON keys and punctuation.
+	// Encrypted fields are skipped.
+	resumeSearchText = `(SELECT coalesce(string_agg(v #>> '{}', ' '), '') FROM jsonb_path_query(content, 'strict $.**') AS v WHERE jsonb_typeof(v) = 'string' AND left(v #>> '{}', 7) <> 'enc:v1:')`
+
+	resumeHeadlineOptions = `StartSel=<mark>, StopSel=</mark>, MaxFragments=2, MinWords=5, MaxWords=20, FragmentDelimiter=" ... "`
 )
 
 type resumeRepository struct {
-	db *sql.DB
+	db      *database.Router
+	codec   resumeCodec
+	regions regionTables
 }
 
-func NewResumeRepository(db *sql.DB) domain.ResumeRepository {
-	return &resumeRepository{db: db}
+// NewResumeRepository stores the sensitive personal info fields encrypted
+// with cipher. Passing a nil cipher disables encryption. The content of
+// resumes owned by users pinned to a data region is kept in that region's
+// schema.
+func NewResumeRepository(db *database.Router, cipher *fieldcrypt.Cipher, regions config.DataRegionConfig) domain.ResumeRepository {
+	return &resumeRepository{db: db, codec: resumeCodec{cipher: cipher}, regions: newRegionTables(regions, "resume_contents")}
 }
 
+// Create stores the resume in its owner's data region, which it sets on
+// resume.
 func (r *resumeRepository) Create(ctx context.Context, resume *domain.Resume) error {
-	contentJSON, err := json.Marshal(resume.Content)
+	region, err := r.userRegion(ctx, resume.UserID)
+	if err != nil {
+		return err
+	}
+	resume.DataRegion = region
+
+	doc, err := r.encodeForRegion(resume)
 	if err != nil {
 		return err
 	}
 
 	query := `
-		INSERT INTO resumes (id, user_id, title, content, is_active, created_at, updated_at)
-		VALUES ($1, $2, $3, $4, $5, $6, $7)
+		INSERT INTO resumes (id, user_id, title, content, is_active, created_at, updated_at, data_region, search_vector)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, ` + fmt.Sprintf(resumeSearchVector, "$3", "$9") + `)
 	`
-	_, err = r.db.ExecContext(ctx, query,
+	args := []interface{}{
 		resume.ID,
 		resume.UserID,
 		resume.Title,
-		contentJSON,
+		doc.content,
 		resume.IsActive,
 		resume.CreatedAt,
 		resume.UpdatedAt,
-	)
+		resume.DataRegion,
+		doc.search,
+	}
+	if doc.regional != nil {
+		query, args, err = r.withRegionalContent(query, args, resume.DataRegion, doc.regional)
+		if err != nil {
+			return err
+		}
+	}
+
+	_, err = r.db.ExecContext(ctx, query, args...)
 	return err
 }
 
@@ -51,18 +106,29 @@ func (r *resumeRepository) FindByID(ctx context.Context, id uuid.UUID) (*domain.
 		FROM resumes
 		WHERE id = $1 AND deleted_at IS NULL
 	`
-	return r.scanResume(r.db.QueryRowContext(ctx, query, id))
+	return r.scanResume(ctx, r.db.QueryRowContext(ctx, query, id))
 }
 
-func (r *resumeRepository) FindByUserID(ctx context.Context, userID uuid.UUID, limit, offset int) ([]domain.Resume, error) {
+func (r *resumeRepository) FindLatestActiveByUserID(ctx context.Context, userID uuid.UUID) (*domain.Resume, error) {
 	query := `
 		SELECT ` + resumeColumns + `
 		FROM resumes
-		WHERE user_id = $1 AND deleted_at IS NULL
+		WHERE user_id = $1 AND is_active = true AND deleted_at IS NULL
+		ORDER BY updated_at DESC
+		LIMIT 1
+	`
+	return r.scanResume(ctx, r.db.QueryRowContext(ctx, query, userID))
+}
+
+func (r *resumeRepository) FindByUserID(ctx context.Context, userID uuid.UUID, filter domain.ResumeFilter, limit, offset int) ([]domain.Resume, error) {
+	query := `
+		SELECT ` + resumeColumns + `
+		FROM resumes
+		WHERE user_id = $1 AND deleted_at IS NULL` + fmt.Sprintf(resumeFilterClause, 2, 3) + `
 		ORDER BY created_at DESC
-		LIMIT $2 OFFSET $3
+		LIMIT $4 OFFSET $5
 	`
-	rows, err := r.db.QueryContext(ctx, query, userID, limit, offset)
+	rows, err := r.db.QueryReadContext(ctx, query, userID, filter.TagID, filter.FolderID, limit, offset)
 	if err != nil {
 		return nil, err
 	}
@@ -76,34 +142,117 @@ func (r *resumeRepository) FindByUserID(ctx context.Context, userID uuid.UUID, l
 		}
 		resumes = append(resumes, *resume)
 	}
-	return resumes, rows.Err()
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return resumes, r.loadRegionalContent(ctx, resumePointers(resumes))
+}
+
+func (r *resumeRepository) CountByUserID(ctx context.Context, userID uuid.UUID, filter domain.ResumeFilter) (int64, error) {
+	query := `SELECT COUNT(id) FROM resumes WHERE user_id = $1 AND deleted_at IS NULL` + fmt.Sprintf(resumeFilterClause, 2, 3)
+	var count int64
+	err := r.db.QueryRowReadContext(ctx, query, userID, filter.TagID, filter.FolderID).Scan(&count)
+	return count, err
+}
+
+func (r *resumeRepository) Search(ctx context.Context, userID uuid.UUID, query string, limit, offset int) ([]domain.ResumeSearchResult, error) {
+	sqlQuery := `
+		SELECT ` + resumeColumns + `,
+			ts_rank(search_vector, q) AS rank,
+			ts_headline('simple', title, q, '` + resumeHeadlineOptions + `, HighlightAll=true') AS title_highlight,
+			ts_headline('simple', ` + resumeSearchText + `, q, '` + resumeHeadlineOptions + `') AS snippet
+		FROM resumes, websearch_to_tsquery('simple', $2) AS q
+		WHERE user_id = $1 AND deleted_at IS NULL AND search_vector @@ q
+		ORDER BY rank DESC, updated_at DESC
+		LIMIT $3 OFFSET $4
+	`
+	rows, err := r.db.QueryReadContext(ctx, sqlQuery, userID, query, limit, offset)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	results := make([]domain.ResumeSearchResult, 0)
+	for rows.Next() {
+		var result domain.ResumeSearchResult
+		var contentJSON, tagsJSON []byte
+		err := rows.Scan(
+			&result.Resume.ID,
+			&result.Resume.UserID,
+			&result.Resume.Title,
+			&contentJSON,
+			&result.Resume.IsActive,
+			&result.Resume.CreatedAt,
+			&result.Resume.UpdatedAt,
+			&result.Resume.DeletedAt,
+			&result.Resume.FolderID,
+			&result.Resume.DataRegion,
+			&tagsJSON,
+			&result.Rank,
+			&result.TitleHighlight,
+			&result.Snippet,
+		)
+		if err != nil {
+			return nil, err
+		}
+		if err := r.codec.decode(contentJSON, &result.Resume.Content); err != nil {
+			return nil, err
+		}
+		if err := json.Unmarshal(tagsJSON, &result.Resume.Tags); err != nil {
+			return nil, err
+		}
+		results = append(results, result)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	pinned := make([]*domain.Resume, 0, len(results))
+	for i := range results {
+		pinned = append(pinned, &results[i].Resume)
+	}
+	return results, r.loadRegionalContent(ctx, pinned)
 }
 
-func (r *resumeRepository) CountByUserID(ctx context.Context, userID uuid.UUID) (int64, error) {
-	query := `SELECT COUNT(id) FROM resumes WHERE user_id = $1 AND deleted_at IS NULL`
+func (r *resumeRepository) CountSearch(ctx context.Context, userID uuid.UUID, query string) (int64, error) {
+	sqlQuery := `
+		SELECT COUNT(id)
+		FROM resumes
+		WHERE user_id = $1 AND deleted_at IS NULL AND search_vector @@ websearch_to_tsquery('simple', $2)
+	`
 	var count int64
-	err := r.db.QueryRowContext(ctx, query, userID).Scan(&count)
+	err := r.db.QueryRowReadContext(ctx, sqlQuery, userID, query).Scan(&count)
 	return count, err
 }
 
+// Update saves the resume in the data region it was created in.
 func (r *resumeRepository) Update(ctx context.Context, resume *domain.Resume) error {
-	contentJSON, err := json.Marshal(resume.Content)
+	doc, err := r.encodeForRegion(resume)
 	if err != nil {
 		return err
 	}
 
 	query := `
 		UPDATE resumes
-		SET title = $1, content = $2, is_active = $3, updated_at = $4
+		SET title = $1, content = $2, is_active = $3, updated_at = $4, search_vector = ` + fmt.Sprintf(resumeSearchVector, "$1", "$6") + `
 		WHERE id = $5 AND deleted_at IS NULL
 	`
-	_, err = r.db.ExecContext(ctx, query,
+	args := []interface{}{
 		resume.Title,
-		contentJSON,
+		doc.content,
 		resume.IsActive,
 		time.Now(),
 		resume.ID,
-	)
+		doc.search,
+	}
+	if doc.regional != nil {
+		query, args, err = r.withRegionalContent(query, args, resume.DataRegion, doc.regional)
+		if err != nil {
+			return err
+		}
+	}
+
+	_, err = r.db.ExecContext(ctx, query, args...)
 	return err
 }
 
@@ -117,9 +266,194 @@ func (r *resumeRepository) SoftDelete(ctx context.Context, id uuid.UUID) error {
 	return err
 }
 
-func (r *resumeRepository) scanResume(row *sql.Row) (*domain.Resume, error) {
+func (r *resumeRepository) FindDeletedByID(ctx context.Context, id uuid.UUID) (*domain.Resume, error) {
+	query := `
+		SELECT ` + resumeColumns + `
+		FROM resumes
+		WHERE id = $1 AND deleted_at IS NOT NULL
+	`
+	return r.scanResume(ctx, r.db.QueryRowContext(ctx, query, id))
+}
+
+func (r *resumeRepository) FindDeletedByUserID(ctx context.Context, userID uuid.UUID, limit, offset int) ([]domain.Resume, error) {
+	query := `
+		SELECT ` + resumeColumns + `
+		FROM resumes
+		WHERE user_id = $1 AND deleted_at IS NOT NULL
+		ORDER BY deleted_at DESC
+		LIMIT $2 OFFSET $3
+	`
+	rows, err := r.db.QueryReadContext(ctx, query, userID, limit, offset)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	resumes := make([]domain.Resume, 0)
+	for rows.Next() {
+		resume, err := r.scanResumeFromRows(rows)
+		if err != nil {
+			return nil, err
+		}
+		resumes = append(resumes, *resume)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return resumes, r.loadRegionalContent(ctx, resumePointers(resumes))
+}
+
+func (r *resumeRepository) CountDeletedByUserID(ctx context.Context, userID uuid.UUID) (int64, error) {
+	query := `SELECT COUNT(id) FROM resumes WHERE user_id = $1 AND deleted_at IS NOT NULL`
+	var count int64
+	err := r.db.QueryRowReadContext(ctx, query, userID).Scan(&count)
+	return count, err
+}
+
+func (r *resumeRepository) Restore(ctx context.Context, id uuid.UUID) error {
+	query := `
+		UPDATE resumes
+		SET deleted_at = NULL
+		WHERE id = $1 AND deleted_at IS NOT NULL
+	`
+	result, err := r.db.ExecContext(ctx, query, id)
+	if err != nil {
+		return err
+	}
+	affected, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if affected == 0 {
+		return sql.ErrNoRows
+	}
+	return nil
+}
+
+// resumePurgeDependents clear the rows referencing purged resumes that
+// would otherwise block their deletion. ATS checks are history and keep
+// their result without the resume; share analytics go with it.
+var resumePurgeDependents = []string{
+	`UPDATE ats_checks SET resume_id = NULL WHERE resume_id = ANY($1::uuid[])`,
+	`DELETE FROM resume_share_events WHERE resume_id = ANY($1::uuid[])`,
+	`DELETE FROM resume_share_daily_stats WHERE resume_id = ANY($1::uuid[])`,
+}
+
+// PurgeDeletedBefore permanently removes resumes that were soft-deleted
+// before the given time.
+func (r *resumeRepository) PurgeDeletedBefore(ctx context.Context, before time.Time) (int64, error) {
+	query := `
+		SELECT id FROM resumes
+		WHERE deleted_at IS NOT NULL AND deleted_at < $1
+		FOR UPDATE
+	`
+	return purgeRows(ctx, r.db, "resumes", query, []interface{}{before}, resumePurgeDependents)
+}
+
+// ReencryptBatch rewrites the content of up to limit resumes after afterID,
+// including deleted ones, whose sensitive fields are still in plaintext or
+// sealed with a retired key. updated_at is left untouched, and a row that
+// changed since it was read is skipped; the next run picks it up.
+func (r *resumeRepository) ReencryptBatch(ctx context.Context, afterID uuid.UUID, limit int) (*domain.ResumeBatchResult, error) {
+	return r.rewriteBatch(ctx, afterID, limit, func(_ []byte, content *domain.ResumeContent) (bool, error) {
+		return r.codec.needsReencryption(content), nil
+	})
+}
+
+// MigrateSchemaBatch rewrites the content of up to limit resumes after
+// afterID that are stored in an older schema version, with the same guards
+// as ReencryptBatch. Reads already migrate on the fly; this only saves the
+// work from being repeated.
+func (r *resumeRepository) MigrateSchemaBatch(ctx context.Context, afterID uuid.UUID, limit int) (*domain.ResumeBatchResult, error) {
+	return r.rewriteBatch(ctx, afterID, limit, func(stored []byte, _ *domain.ResumeContent) (bool, error) {
+		version, err := storedSchemaVersion(stored)
+		if err != nil {
+			return false, err
+		}
+		return version < currentResumeSchemaVersion, nil
+	})
+}
+
+// rewriteBatch re-encodes the resumes after afterID that stale reports on,
+// comparing the stored content so a concurrent edit is never overwritten.
+// Content kept in a data region is not visited.
+func (r *resumeRepository) rewriteBatch(ctx context.Context, afterID uuid.UUID, limit int, stale func(stored []byte, content *domain.ResumeContent) (bool, error)) (*domain.ResumeBatchResult, error) {
+	query := `
+		SELECT id, title, content
+		FROM resumes
+		WHERE id > $1
+		ORDER BY id
+		LIMIT $2
+	`
+	rows, err := r.db.QueryContext(ctx, query, afterID, limit)
+	if err != nil {
+		return nil, err
+	}
+
+	type pending struct {
+		id      uuid.UUID
+		title   string
+		stored  []byte
+		content domain.ResumeContent
+	}
+
+	result := &domain.ResumeBatchResult{LastID: afterID}
+	outdated := make([]pending, 0)
+	for rows.Next() {
+		var item pending
+		if err := rows.Scan(&item.id, &item.title, &item.stored); err != nil {
+			rows.Close()
+			return nil, err
+		}
+		if err := r.codec.decode(item.stored, &item.content); err != nil {
+			rows.Close()
+			return nil, fmt.Errorf("resume %s: %w", item.id, err)
+		}
+		result.Scanned++
+		result.LastID = item.id
+		rewrite, err := stale(item.stored, &item.content)
+		if err != nil {
+			rows.Close()
+			return nil, fmt.Errorf("resume %s: %w", item.id, err)
+		}
+		if rewrite {
+			outdated = append(outdated, item)
+		}
+	}
+	rows.Close()
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	update := `
+		UPDATE resumes
+		SET content = $1, search_vector = ` + fmt.Sprintf(resumeSearchVector, "$2", "$3") + `
+		WHERE id = $4 AND content = $5::jsonb
+	`
+	for _, item := range outdated {
+		contentJSON, err := r.codec.encode(&item.content)
+		if err != nil {
+			return nil, err
+		}
+		searchJSON, err := r.codec.searchDocument(item.content)
+		if err != nil {
+			return nil, err
+		}
+		res, err := r.db.ExecContext(ctx, update, contentJSON, item.title, searchJSON, item.id, item.stored)
+		if err != nil {
+			return nil, fmt.Errorf("resume %s: %w", item.id, err)
+		}
+		if affected, _ := res.RowsAffected(); affected > 0 {
+			result.Updated++
+		}
+	}
+
+	return result, nil
+}
+
+func (r *resumeRepository) scanResume(ctx context.Context, row *sql.Row) (*domain.Resume, error) {
 	var resume domain.Resume
-	var contentJSON []byte
+	var contentJSON, tagsJSON []byte
 	err := row.Scan(
 		&resume.ID,
 		&resume.UserID,
@@ -129,12 +463,22 @@ func (r *resumeRepository) scanResume(row *sql.Row) (*domain.Resume, error) {
 		&resume.CreatedAt,
 		&resume.UpdatedAt,
 		&resume.DeletedAt,
+		&resume.FolderID,
+		&resume.DataRegion,
+		&tagsJSON,
 	)
 	if err != nil {
 		return nil, err
 	}
 
-	if err := json.Unmarshal(contentJSON, &resume.Content); err != nil {
+	if err := r.codec.decode(contentJSON, &resume.Content); err != nil {
+		return nil, err
+	}
+	if err := json.Unmarshal(tagsJSON, &resume.Tags); err != nil {
+		return nil, err
+	}
+
+	if err := r.loadRegionalContent(ctx, []*domain.Resume{&resume}); err != nil {
 		return nil, err
 	}
 
@@ -143,7 +487,7 @@ func (r *resumeRepository) scanResume(row *sql.Row) (*domain.Resume, error) {
 
 func (r *resumeRepository) scanResumeFromRows(rows *sql.Rows) (*domain.Resume, error) {
 	var resume domain.Resume
-	var contentJSON []byte
+	var contentJSON, tagsJSON []byte
 	err := rows.Scan(
 		&resume.ID,
 		&resume.UserID,
@@ -153,12 +497,18 @@ func (r *resumeRepository) scanResumeFromRows(rows *sql.Rows) (*domain.Resume, e
 		&resume.CreatedAt,
 		&resume.UpdatedAt,
 		&resume.DeletedAt,
+		&resume.FolderID,
+		&resume.DataRegion,
+		&tagsJSON,
 	)
 	if err != nil {
 		return nil, err
 	}
 
-	if err := json.Unmarshal(contentJSON, &resume.Content); err != nil {
+	if err := r.codec.decode(contentJSON, &resume.Content); err != nil {
+		return nil, err
+	}
+	if err := json.Unmarshal(tagsJSON, &resume.Tags); err != nil {
 		return nil, err
 	}
 
@@ -0,0 +1,65 @@
+package repository
+
+import (
+	"encoding/json"
+	"fmt"
+)
+
+// resumeMigrations upgrade stored resume content one version at a time:
+// resumeMigrations[v] takes a document at version v to v+1. Content written
+// before versioning carries no schema_version and is read as version 0.
+// Append new steps here; never edit one that has shipped.
+var resumeMigrations = []func(doc map[string]json.RawMessage) error{
+	migrateResumeV0,
+}
+
+var currentResumeSchemaVersion = len(resumeMigrations)
+
+// migrateResumeV0 replaces the null lists older clients saved with empty
+// ones, so the required sections always decode to a list.
+func migrateResumeV0(doc map[string]json.RawMessage) error {
+	for _, key := range []string{"experience", "education", "skills"} {
+		if value, ok := doc[key]; !ok || string(value) == "null" {
+			doc[key] = json.RawMessage("[]")
+		}
+	}
+	return nil
+}
+
+// storedSchemaVersion reads only the version of a stored document.
+func storedSchemaVersion(data []byte) (int, error) {
+	var header struct {
+		SchemaVersion int `json:"schema_version"`
+	}
+	if err := json.Unmarshal(data, &header); err != nil {
+		return 0, err
+	}
+	return header.SchemaVersion, nil
+}
+
+// migrateResumeDocument upgrades data to the current schema version. Data
+// that is already current is returned as is.
+func migrateResumeDocument(data []byte) ([]byte, error) {
+	version, err := storedSchemaVersion(data)
+	if err != nil {
+		return nil, err
+	}
+	if version == currentResumeSchemaVersion {
+		return data, nil
+	}
+	if version > currentResumeSchemaVersion {
+		return nil, fmt.Errorf("resume schema version %d is newer than %d", version, currentResumeSchemaVersion)
+	}
+
+	doc := make(map[string]json.RawMessage)
+	if err := json.Unmarshal(data, &doc); err != nil {
+		return nil, err
+	}
+	for ; version < currentResumeSchemaVersion; version++ {
+		if err := resumeMigrations[version](doc); err != nil {
+			return nil, fmt.Errorf("migrate resume schema from version %d: %w", version, err)
+		}
+	}
+	doc["schema_version"] = json.RawMessage(fmt.Sprint(version))
+	return json.Marshal(doc)
+}
@@ -0,0 +1,126 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"time"
+
+	"github.com/raflytch/careerly-server/internal/domain"
+
+	"github.com/google/uuid"
+)
+
+// shareCountsSource combines the aggregated daily counts with the raw events
+// the aggregator has not reached yet, so reads are always current.
+const shareCountsSource = `
+	WITH combined AS (
+		SELECT day, type, referrer, country, count
+		FROM resume_share_daily_stats
+		WHERE resume_id = $1
+		UNION ALL
+		SELECT (occurred_at AT TIME ZONE 'UTC')::date, type, referrer, country, 1
+		FROM resume_share_events
+		WHERE resume_id = $1
+	)
+`
+
+type resumeShareAnalyticsRepository struct {
+	db *sql.DB
+}
+
+func NewResumeShareAnalyticsRepository(db *sql.DB) domain.ResumeShareAnalyticsRepository {
+	return &resumeShareAnalyticsRepository{db: db}
+}
+
+func (r *resumeShareAnalyticsRepository) RecordEvent(ctx context.Context, event *domain.ResumeShareEvent) error {
+	query := `
+		INSERT INTO resume_share_events (id, share_id, resume_id, type, referrer, country, occurred_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7)
+	`
+	_, err := r.db.ExecContext(ctx, query,
+		event.ID,
+		event.ShareID,
+		event.ResumeID,
+		event.Type,
+		event.Referrer,
+		event.Country,
+		event.OccurredAt,
+	)
+	return err
+}
+
+// AggregateBefore folds raw events older than before into the daily table
+// and removes them in one statement, so an event is never counted twice.
+func (r *resumeShareAnalyticsRepository) AggregateBefore(ctx context.Context, before time.Time) (int64, error) {
+	query := `
+		WITH moved AS (
+			DELETE FROM resume_share_events
+			WHERE occurred_at < $1
+			RETURNING resume_id, (occurred_at AT TIME ZONE 'UTC')::date AS day, type, referrer, country
+		), grouped AS (
+			INSERT INTO resume_share_daily_stats (resume_id, day, type, referrer, country, count)
+			SELECT resume_id, day, type, referrer, country, COUNT(*)
+			FROM moved
+			GROUP BY resume_id, day, type, referrer, country
+			ON CONFLICT (resume_id, day, type, referrer, country)
+			DO UPDATE SET count = resume_share_daily_stats.count + EXCLUDED.count
+		)
+		SELECT COUNT(*) FROM moved
+	`
+	var moved int64
+	err := r.db.QueryRowContext(ctx, query, before).Scan(&moved)
+	return moved, err
+}
+
+func (r *resumeShareAnalyticsRepository) CountByDay(ctx context.Context, resumeID uuid.UUID, since time.Time) ([]domain.ResumeShareCount, error) {
+	query := shareCountsSource + `
+		SELECT to_char(day, 'YYYY-MM-DD'),
+			COALESCE(SUM(count) FILTER (WHERE type = $2), 0),
+			COALESCE(SUM(count) FILTER (WHERE type = $3), 0)
+		FROM combined
+		WHERE day >= $4::date
+		GROUP BY day
+		ORDER BY day ASC
+	`
+	return r.queryCounts(ctx, query, resumeID, domain.ShareEventView, domain.ShareEventDownload, since)
+}
+
+func (r *resumeShareAnalyticsRepository) CountByReferrer(ctx context.Context, resumeID uuid.UUID) ([]domain.ResumeShareCount, error) {
+	return r.countBy(ctx, "referrer", resumeID)
+}
+
+func (r *resumeShareAnalyticsRepository) CountByCountry(ctx context.Context, resumeID uuid.UUID) ([]domain.ResumeShareCount, error) {
+	return r.countBy(ctx, "country", resumeID)
+}
+
+// countBy groups all-time counts by column, which is always one of the
+// fixed names above and never user input.
+func (r *resumeShareAnalyticsRepository) countBy(ctx context.Context, column string, resumeID uuid.UUID) ([]domain.ResumeShareCount, error) {
+	query := shareCountsSource + `
+		SELECT ` + column + `,
+			COALESCE(SUM(count) FILTER (WHERE type = $2), 0) AS views,
+			COALESCE(SUM(count) FILTER (WHERE type = $3), 0) AS downloads
+		FROM combined
+		GROUP BY ` + column + `
+		ORDER BY views DESC, downloads DESC, ` + column + ` ASC
+	`
+	return r.queryCounts(ctx, query, resumeID, domain.ShareEventView, domain.ShareEventDownload)
+}
+
+func (r *resumeShareAnalyticsRepository) queryCounts(ctx context.Context, query string, args ...interface{}) ([]domain.ResumeShareCount, error) {
+	rows, err := r.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	counts := make([]domain.ResumeShareCount, 0)
+	for rows.Next() {
+		var count domain.ResumeShareCount
+		if err := rows.Scan(&count.Key, &count.Views, &count.Downloads); err != nil {
+			return nil, err
+		}
+		counts = append(counts, count)
+	}
+	return counts, rows.Err()
+}
@@ -0,0 +1,164 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"time"
+
+	"github.com/raflytch/careerly-server/internal/domain"
+
+	"github.com/google/uuid"
+)
+
+const (
+	resumeShareColumns   = `id, resume_id, user_id, redact_personal, expires_at, revoked_at, created_at`
+	resumeCommentColumns = `id, resume_id, share_id, reviewer_name, section, comment, resolved_at, created_at`
+)
+
+type resumeShareRepository struct {
+	db *sql.DB
+}
+
+func NewResumeShareRepository(db *sql.DB) domain.ResumeShareRepository {
+	return &resumeShareRepository{db: db}
+}
+
+func (r *resumeShareRepository) Create(ctx context.Context, share *domain.ResumeShare) error {
+	query := `
+		INSERT INTO resume_shares (` + resumeShareColumns + `)
+		VALUES ($1, $2, $3, $4, $5, $6, $7)
+	`
+	_, err := r.db.ExecContext(ctx, query,
+		share.ID,
+		share.ResumeID,
+		share.UserID,
+		share.RedactPersonal,
+		share.ExpiresAt,
+		share.RevokedAt,
+		share.CreatedAt,
+	)
+	return err
+}
+
+func (r *resumeShareRepository) FindByID(ctx context.Context, id uuid.UUID) (*domain.ResumeShare, error) {
+	query := `
+		SELECT ` + resumeShareColumns + `
+		FROM resume_shares
+		WHERE id = $1
+	`
+	var share domain.ResumeShare
+	err := r.db.QueryRowContext(ctx, query, id).Scan(
+		&share.ID,
+		&share.ResumeID,
+		&share.UserID,
+		&share.RedactPersonal,
+		&share.ExpiresAt,
+		&share.RevokedAt,
+		&share.CreatedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+	return &share, nil
+}
+
+func (r *resumeShareRepository) Revoke(ctx context.Context, id uuid.UUID, revokedAt time.Time) error {
+	query := `UPDATE resume_shares SET revoked_at = $2 WHERE id = $1 AND revoked_at IS NULL`
+	_, err := r.db.ExecContext(ctx, query, id, revokedAt)
+	return err
+}
+
+func (r *resumeShareRepository) CreateComment(ctx context.Context, comment *domain.ResumeComment) error {
+	query := `
+		INSERT INTO resume_comments (` + resumeCommentColumns + `)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8)
+	`
+	_, err := r.db.ExecContext(ctx, query,
+		comment.ID,
+		comment.ResumeID,
+		comment.ShareID,
+		comment.ReviewerName,
+		comment.Section,
+		comment.Comment,
+		comment.ResolvedAt,
+		comment.CreatedAt,
+	)
+	return err
+}
+
+func (r *resumeShareRepository) FindCommentByID(ctx context.Context, id uuid.UUID) (*domain.ResumeComment, error) {
+	query := `
+		SELECT ` + resumeCommentColumns + `
+		FROM resume_comments
+		WHERE id = $1
+	`
+	var comment domain.ResumeComment
+	err := r.db.QueryRowContext(ctx, query, id).Scan(
+		&comment.ID,
+		&comment.ResumeID,
+		&comment.ShareID,
+		&comment.ReviewerName,
+		&comment.Section,
+		&comment.Comment,
+		&comment.ResolvedAt,
+		&comment.CreatedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+	return &comment, nil
+}
+
+func (r *resumeShareRepository) FindCommentsByResumeID(ctx context.Context, resumeID uuid.UUID, resolved *bool) ([]domain.ResumeComment, error) {
+	query := `
+		SELECT ` + resumeCommentColumns + `
+		FROM resume_comments
+		WHERE resume_id = $1
+	`
+	if resolved != nil {
+		if *resolved {
+			query += ` AND resolved_at IS NOT NULL`
+		} else {
+			query += ` AND resolved_at IS NULL`
+		}
+	}
+	query += ` ORDER BY created_at ASC`
+
+	rows, err := r.db.QueryContext(ctx, query, resumeID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	comments := make([]domain.ResumeComment, 0)
+	for rows.Next() {
+		var comment domain.ResumeComment
+		if err := rows.Scan(
+			&comment.ID,
+			&comment.ResumeID,
+			&comment.ShareID,
+			&comment.ReviewerName,
+			&comment.Section,
+			&comment.Comment,
+			&comment.ResolvedAt,
+			&comment.CreatedAt,
+		); err != nil {
+			return nil, err
+		}
+		comments = append(comments, comment)
+	}
+	return comments, rows.Err()
+}
+
+func (r *resumeShareRepository) CountCommentsByShareID(ctx context.Context, shareID uuid.UUID) (int64, error) {
+	query := `SELECT COUNT(*) FROM resume_comments WHERE share_id = $1`
+	var count int64
+	err := r.db.QueryRowContext(ctx, query, shareID).Scan(&count)
+	return count, err
+}
+
+func (r *resumeShareRepository) SetCommentResolved(ctx context.Context, id uuid.UUID, resolvedAt *time.Time) error {
+	query := `UPDATE resume_comments SET resolved_at = $2 WHERE id = $1`
+	_, err := r.db.ExecContext(ctx, query, id, resolvedAt)
+	return err
+}
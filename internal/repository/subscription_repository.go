@@ -3,6 +3,7 @@ package repository
 import (
 	"context"
 	"database/sql"
+	"fmt"
 	"time"
 
 	"github.com/raflytch/careerly-server/internal/domain"
@@ -11,7 +12,7 @@ import (
 )
 
 const (
-	subscriptionColumns = `id, user_id, plan_id, start_date, end_date, status, created_at, deleted_at`
+	subscriptionColumns = `id, user_id, plan_id, scheduled_plan_id, start_date, end_date, status, created_at, deleted_at`
 )
 
 type subscriptionRepository struct {
@@ -50,8 +51,8 @@ func (r *subscriptionRepository) FindByID(ctx context.Context, id uuid.UUID) (*d
 
 func (r *subscriptionRepository) FindActiveByUserID(ctx context.Context, userID uuid.UUID) (*domain.Subscription, error) {
 	query := `
-		SELECT s.id, s.user_id, s.plan_id, s.start_date, s.end_date, s.status, s.created_at, s.deleted_at,
-			   p.id, p.name, p.display_name, p.price, p.duration_days, p.max_resumes, p.max_ats_checks, p.max_interviews, p.is_active, p.created_at, p.deleted_at
+		SELECT s.id, s.user_id, s.plan_id, s.scheduled_plan_id, s.start_date, s.end_date, s.status, s.created_at, s.deleted_at,
+			   p.id, p.name, p.display_name, p.price, p.duration_days, p.max_resumes, p.max_ats_checks, p.max_interviews, p.custom_branding, p.is_active, p.archived_at, p.created_at, p.deleted_at
 		FROM subscriptions s
 		JOIN plans p ON s.plan_id = p.id
 		WHERE s.user_id = $1 
@@ -90,6 +91,31 @@ func (r *subscriptionRepository) FindAllByUserID(ctx context.Context, userID uui
 	return subscriptions, rows.Err()
 }
 
+func (r *subscriptionRepository) FindEndedActive(ctx context.Context, before time.Time, limit int) ([]domain.Subscription, error) {
+	query := `
+		SELECT ` + subscriptionColumns + `
+		FROM subscriptions
+		WHERE status = 'active' AND end_date <= $1 AND deleted_at IS NULL
+		ORDER BY end_date ASC
+		LIMIT $2
+	`
+	rows, err := r.db.QueryContext(ctx, query, before, limit)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	subscriptions := make([]domain.Subscription, 0)
+	for rows.Next() {
+		sub, err := r.scanSubscriptionFromRows(rows)
+		if err != nil {
+			return nil, err
+		}
+		subscriptions = append(subscriptions, *sub)
+	}
+	return subscriptions, rows.Err()
+}
+
 func (r *subscriptionRepository) CountByUserID(ctx context.Context, userID uuid.UUID) (int64, error) {
 	query := `SELECT COUNT(id) FROM subscriptions WHERE user_id = $1 AND deleted_at IS NULL`
 	var count int64
@@ -100,17 +126,106 @@ func (r *subscriptionRepository) CountByUserID(ctx context.Context, userID uuid.
 func (r *subscriptionRepository) Update(ctx context.Context, subscription *domain.Subscription) error {
 	query := `
 		UPDATE subscriptions
-		SET status = $1, end_date = $2
-		WHERE id = $3 AND deleted_at IS NULL
+		SET status = $1, end_date = $2, scheduled_plan_id = $3
+		WHERE id = $4 AND deleted_at IS NULL
 	`
 	_, err := r.db.ExecContext(ctx, query,
 		subscription.Status,
 		subscription.EndDate,
+		subscription.ScheduledPlanID,
 		subscription.ID,
 	)
 	return err
 }
 
+// Grant stores a manually granted subscription together with its manual
+// transaction, canceling the subscription it replaces, in one database
+// transaction so a failure leaves the user's plan untouched. transaction is
+// nil when nothing is recorded as paid, as for a redeemed gift.
+func (r *subscriptionRepository) Grant(ctx context.Context, subscription *domain.Subscription, replaces *uuid.UUID, transaction *domain.Transaction) error {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	if replaces != nil {
+		query := `
+			UPDATE subscriptions
+			SET status = $1
+			WHERE id = $2 AND deleted_at IS NULL
+		`
+		if _, err := tx.ExecContext(ctx, query, domain.SubscriptionStatusCanceled, *replaces); err != nil {
+			return fmt.Errorf("cancel subscription: %w", err)
+		}
+	}
+
+	query := `
+		INSERT INTO subscriptions (id, user_id, plan_id, start_date, end_date, status, created_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7)
+	`
+	_, err = tx.ExecContext(ctx, query,
+		subscription.ID,
+		subscription.UserID,
+		subscription.PlanID,
+		subscription.StartDate,
+		subscription.EndDate,
+		subscription.Status,
+		subscription.CreatedAt,
+	)
+	if err != nil {
+		return fmt.Errorf("create subscription: %w", err)
+	}
+
+	if transaction == nil {
+		return tx.Commit()
+	}
+
+	query = `
+		INSERT INTO transactions (
+			id, user_id, plan_id, subscription_id, order_id, gross_amount,
+			payment_type, status, paid_at, created_at, updated_at
+		) VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11)
+	`
+	_, err = tx.ExecContext(ctx, query,
+		transaction.ID,
+		transaction.UserID,
+		transaction.PlanID,
+		transaction.SubscriptionID,
+		transaction.OrderID,
+		transaction.GrossAmount,
+		transaction.PaymentType,
+		transaction.Status,
+		transaction.PaidAt,
+		transaction.CreatedAt,
+		transaction.UpdatedAt,
+	)
+	if err != nil {
+		return fmt.Errorf("record transaction: %w", err)
+	}
+
+	return tx.Commit()
+}
+
+// Expire marks an active subscription as expired and reports whether this
+// call did so, letting concurrent rollover runs agree on a single winner.
+func (r *subscriptionRepository) Expire(ctx context.Context, id uuid.UUID) (bool, error) {
+	query := `
+		UPDATE subscriptions
+		SET status = 'expired'
+		WHERE id = $1 AND status = 'active' AND deleted_at IS NULL
+	`
+	result, err := r.db.ExecContext(ctx, query, id)
+	if err != nil {
+		return false, err
+	}
+	affected, err := result.RowsAffected()
+	if err != nil {
+		return false, err
+	}
+	return affected > 0, nil
+}
+
 func (r *subscriptionRepository) SoftDelete(ctx context.Context, id uuid.UUID) error {
 	query := `
 		UPDATE subscriptions
@@ -128,6 +243,7 @@ func (r *subscriptionRepository) scanSubscription(row *sql.Row) (*domain.Subscri
 		&sub.ID,
 		&sub.UserID,
 		&sub.PlanID,
+		&sub.ScheduledPlanID,
 		&sub.StartDate,
 		&sub.EndDate,
 		&status,
@@ -148,6 +264,7 @@ func (r *subscriptionRepository) scanSubscriptionFromRows(rows *sql.Rows) (*doma
 		&sub.ID,
 		&sub.UserID,
 		&sub.PlanID,
+		&sub.ScheduledPlanID,
 		&sub.StartDate,
 		&sub.EndDate,
 		&status,
@@ -171,6 +288,7 @@ func (r *subscriptionRepository) scanSubscriptionWithPlan(row *sql.Row) (*domain
 		&sub.ID,
 		&sub.UserID,
 		&sub.PlanID,
+		&sub.ScheduledPlanID,
 		&sub.StartDate,
 		&sub.EndDate,
 		&status,
@@ -184,7 +302,9 @@ func (r *subscriptionRepository) scanSubscriptionWithPlan(row *sql.Row) (*domain
 		&plan.MaxResumes,
 		&plan.MaxATSChecks,
 		&plan.MaxInterviews,
+		&plan.CustomBranding,
 		&plan.IsActive,
+		&plan.ArchivedAt,
 		&plan.CreatedAt,
 		&plan.DeletedAt,
 	)
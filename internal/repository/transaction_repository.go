@@ -6,6 +6,7 @@ import (
 	"encoding/json"
 	"time"
 
+	"github.com/raflytch/careerly-server/internal/database"
 	"github.com/raflytch/careerly-server/internal/domain"
 
 	"github.com/google/uuid"
@@ -17,26 +18,48 @@ const (
 		id, user_id, plan_id, subscription_id, order_id, transaction_id, 
 		gross_amount, payment_type, payment_method, status, transaction_status, 
 		fraud_status, snap_token, redirect_url, midtrans_response, 
-		paid_at, expired_at, created_at, updated_at, deleted_at
+		paid_at, expired_at, created_at, updated_at, deleted_at, addon_id
 	`
 )
 
 type transactionRepository struct {
-	db *sql.DB
+	db *database.Router
 }
 
-func NewTransactionRepository(db *sql.DB) domain.TransactionRepository {
+func NewTransactionRepository(db *database.Router) domain.TransactionRepository {
 	return &transactionRepository{db: db}
 }
 
 func (r *transactionRepository) Create(ctx context.Context, tx *domain.Transaction) error {
+	return insertTransaction(ctx, r.db, tx)
+}
+
+// CreateWithGifts stores a gift order and its recipients in one database
+// transaction, so a paid order never lacks the gifts it pays for.
+func (r *transactionRepository) CreateWithGifts(ctx context.Context, transaction *domain.Transaction, gifts []domain.Gift) error {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	if err := insertTransaction(ctx, tx, transaction); err != nil {
+		return err
+	}
+	if err := insertGifts(ctx, tx, gifts); err != nil {
+		return err
+	}
+	return tx.Commit()
+}
+
+func insertTransaction(ctx context.Context, db sqlExecer, tx *domain.Transaction) error {
 	query := `
 		INSERT INTO transactions (
 			id, user_id, plan_id, subscription_id, order_id, transaction_id,
 			gross_amount, payment_type, payment_method, status, transaction_status,
 			fraud_status, snap_token, redirect_url, midtrans_response,
-			paid_at, expired_at, created_at, updated_at
-		) VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14, $15, $16, $17, $18, $19)
+			paid_at, expired_at, created_at, updated_at, addon_id
+		) VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14, $15, $16, $17, $18, $19, $20)
 	`
 
 	var midtransResp sql.NullString
@@ -44,7 +67,7 @@ func (r *transactionRepository) Create(ctx context.Context, tx *domain.Transacti
 		midtransResp = sql.NullString{String: string(tx.MidtransResponse), Valid: true}
 	}
 
-	_, err := r.db.ExecContext(ctx, query,
+	_, err := db.ExecContext(ctx, query,
 		tx.ID,
 		tx.UserID,
 		tx.PlanID,
@@ -64,6 +87,7 @@ func (r *transactionRepository) Create(ctx context.Context, tx *domain.Transacti
 		tx.ExpiredAt,
 		tx.CreatedAt,
 		tx.UpdatedAt,
+		tx.AddonID,
 	)
 	return err
 }
@@ -95,7 +119,58 @@ func (r *transactionRepository) FindByUserID(ctx context.Context, userID uuid.UU
 		LIMIT $2 OFFSET $3
 	`
 
-	rows, err := r.db.QueryContext(ctx, query, userID, limit, offset)
+	rows, err := r.db.QueryReadContext(ctx, query, userID, limit, offset)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	transactions := make([]domain.Transaction, 0)
+	for rows.Next() {
+		tx, err := r.scanTransactionFromRows(rows)
+		if err != nil {
+			return nil, err
+		}
+		transactions = append(transactions, *tx)
+	}
+	return transactions, rows.Err()
+}
+
+func (r *transactionRepository) FindPendingCreatedBefore(ctx context.Context, before time.Time, limit int) ([]domain.Transaction, error) {
+	query := `
+		SELECT ` + transactionColumns + `
+		FROM transactions
+		WHERE status = $1 AND created_at < $2 AND deleted_at IS NULL
+		ORDER BY created_at ASC
+		LIMIT $3
+	`
+
+	rows, err := r.db.QueryContext(ctx, query, domain.TransactionStatusPending, before, limit)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	transactions := make([]domain.Transaction, 0)
+	for rows.Next() {
+		tx, err := r.scanTransactionFromRows(rows)
+		if err != nil {
+			return nil, err
+		}
+		transactions = append(transactions, *tx)
+	}
+	return transactions, rows.Err()
+}
+
+func (r *transactionRepository) FindCreatedBetween(ctx context.Context, from, to time.Time) ([]domain.Transaction, error) {
+	query := `
+		SELECT ` + transactionColumns + `
+		FROM transactions
+		WHERE created_at >= $1 AND created_at < $2 AND deleted_at IS NULL
+		ORDER BY created_at ASC
+	`
+
+	rows, err := r.db.QueryContext(ctx, query, from, to)
 	if err != nil {
 		return nil, err
 	}
@@ -115,7 +190,7 @@ func (r *transactionRepository) FindByUserID(ctx context.Context, userID uuid.UU
 func (r *transactionRepository) CountByUserID(ctx context.Context, userID uuid.UUID) (int64, error) {
 	query := `SELECT COUNT(id) FROM transactions WHERE user_id = $1 AND deleted_at IS NULL`
 	var count int64
-	err := r.db.QueryRowContext(ctx, query, userID).Scan(&count)
+	err := r.db.QueryRowReadContext(ctx, query, userID).Scan(&count)
 	return count, err
 }
 
@@ -219,6 +294,7 @@ func (r *transactionRepository) scanTransaction(row *sql.Row) (*domain.Transacti
 		&tx.CreatedAt,
 		&tx.UpdatedAt,
 		&tx.DeletedAt,
+		&tx.AddonID,
 	)
 	if err != nil {
 		return nil, err
@@ -261,6 +337,7 @@ func (r *transactionRepository) scanTransactionFromRows(rows *sql.Rows) (*domain
 		&tx.CreatedAt,
 		&tx.UpdatedAt,
 		&tx.DeletedAt,
+		&tx.AddonID,
 	)
 	if err != nil {
 		return nil, err
@@ -23,7 +23,7 @@ func NewUsageRepository(db *sql.DB) domain.UsageRepository {
 }
 
 func (r *usageRepository) FindOrCreate(ctx context.Context, userID uuid.UUID, feature domain.FeatureType, periodMonth time.Time) (*domain.Usage, error) {
-	usage, err := r.GetCurrentMonthUsage(ctx, userID, feature)
+	usage, err := r.GetMonthUsage(ctx, userID, feature, periodMonth)
 	if err == nil {
 		return usage, nil
 	}
@@ -58,23 +58,26 @@ func (r *usageRepository) FindOrCreate(ctx context.Context, userID uuid.UUID, fe
 		return nil, err
 	}
 
-	return r.GetCurrentMonthUsage(ctx, userID, feature)
+	return r.GetMonthUsage(ctx, userID, feature, periodMonth)
 }
 
-func (r *usageRepository) IncrementCount(ctx context.Context, id uuid.UUID) error {
+// IncrementWithinLimit adds amount to the usage count in a single
+// conditional update, so concurrent requests cannot push it past limit. A
+// limit of zero or less means unlimited. It returns the new count, or
+// sql.ErrNoRows when the increment would exceed the limit.
+func (r *usageRepository) IncrementWithinLimit(ctx context.Context, id uuid.UUID, amount, limit int) (int, error) {
 	query := `
 		UPDATE usage
-		SET count = count + 1
-		WHERE id = $1 AND deleted_at IS NULL
+		SET count = count + $2
+		WHERE id = $1 AND deleted_at IS NULL AND ($3 <= 0 OR count + $2 <= $3)
+		RETURNING count
 	`
-	_, err := r.db.ExecContext(ctx, query, id)
-	return err
+	var count int
+	err := r.db.QueryRowContext(ctx, query, id, amount, limit).Scan(&count)
+	return count, err
 }
 
-func (r *usageRepository) GetCurrentMonthUsage(ctx context.Context, userID uuid.UUID, feature domain.FeatureType) (*domain.Usage, error) {
-	now := time.Now()
-	periodMonth := time.Date(now.Year(), now.Month(), 1, 0, 0, 0, 0, time.UTC)
-
+func (r *usageRepository) GetMonthUsage(ctx context.Context, userID uuid.UUID, feature domain.FeatureType, periodMonth time.Time) (*domain.Usage, error) {
 	query := `
 		SELECT ` + usageColumns + `
 		FROM usage
@@ -83,10 +86,7 @@ func (r *usageRepository) GetCurrentMonthUsage(ctx context.Context, userID uuid.
 	return r.scanUsage(r.db.QueryRowContext(ctx, query, userID, feature, periodMonth))
 }
 
-func (r *usageRepository) GetAllCurrentMonthUsage(ctx context.Context, userID uuid.UUID) ([]domain.Usage, error) {
-	now := time.Now()
-	periodMonth := time.Date(now.Year(), now.Month(), 1, 0, 0, 0, 0, time.UTC)
-
+func (r *usageRepository) GetAllMonthUsage(ctx context.Context, userID uuid.UUID, periodMonth time.Time) ([]domain.Usage, error) {
 	query := `
 		SELECT ` + usageColumns + `
 		FROM usage
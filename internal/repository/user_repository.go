@@ -3,29 +3,52 @@ package repository
 import (
 	"context"
 	"database/sql"
+	"strings"
 	"time"
 
+	"github.com/raflytch/careerly-server/internal/database"
 	"github.com/raflytch/careerly-server/internal/domain"
 
 	"github.com/google/uuid"
 )
 
 const (
-	userColumns = `id, google_id, email, name, avatar_url, role, is_active, created_at, last_login_at, deleted_at`
+	userColumns = `id, google_id, email, contact_email, name, avatar_url, role, is_active, two_factor_enabled, timezone, data_region, created_at, last_login_at, deleted_at`
+
+	// userFilter matches $1 as a substring of the name or email, served by
+	// trigram indexes on both. A plan matches users whose current
+	// subscription is on it.
+	userFilter = `
+		WHERE deleted_at IS NULL
+		AND ($1 = '' OR name ILIKE '%' || $1 || '%' OR email ILIKE '%' || $1 || '%')
+		AND ($2 = '' OR role::text = $2)
+		AND ($3::boolean IS NULL OR is_active = $3)
+		AND ($4::uuid IS NULL OR EXISTS (
+			SELECT 1 FROM subscriptions s
+			WHERE s.user_id = users.id AND s.plan_id = $4
+			AND s.status = 'active' AND s.end_date > NOW() AND s.deleted_at IS NULL
+		))
+		AND ($5::timestamptz IS NULL OR created_at >= $5)
+		AND ($6::timestamptz IS NULL OR created_at < $6)
+		AND ($7::timestamptz IS NULL OR last_login_at >= $7)
+		AND ($8::timestamptz IS NULL OR last_login_at < $8)
+	`
 )
 
+var likeEscaper = strings.NewReplacer(`\`, `\\`, `%`, `\%`, `_`, `\_`)
+
 type userRepository struct {
-	db *sql.DB
+	db *database.Router
 }
 
-func NewUserRepository(db *sql.DB) domain.UserRepository {
+func NewUserRepository(db *database.Router) domain.UserRepository {
 	return &userRepository{db: db}
 }
 
 func (r *userRepository) Create(ctx context.Context, user *domain.User) error {
 	query := `
-		INSERT INTO users (id, google_id, email, name, avatar_url, role, is_active, created_at)
-		VALUES ($1, $2, $3, $4, $5, $6, $7, $8)
+		INSERT INTO users (id, google_id, email, name, avatar_url, role, is_active, timezone, created_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9)
 	`
 	_, err := r.db.ExecContext(ctx, query,
 		user.ID,
@@ -35,6 +58,7 @@ func (r *userRepository) Create(ctx context.Context, user *domain.User) error {
 		user.AvatarURL,
 		user.Role,
 		user.IsActive,
+		user.Timezone,
 		user.CreatedAt,
 	)
 	return err
@@ -67,15 +91,41 @@ func (r *userRepository) FindByEmail(ctx context.Context, email string) (*domain
 	return r.scanUser(r.db.QueryRowContext(ctx, query, email))
 }
 
-func (r *userRepository) FindAll(ctx context.Context, limit, offset int) ([]domain.User, error) {
+func (r *userRepository) FindAll(ctx context.Context, filter domain.UserFilter, limit, offset int) ([]domain.User, error) {
 	query := `
 		SELECT ` + userColumns + `
 		FROM users
-		WHERE deleted_at IS NULL
+	` + userFilter + `
 		ORDER BY created_at DESC
-		LIMIT $1 OFFSET $2
+		LIMIT $9 OFFSET $10
+	`
+	args := append(userFilterArgs(filter), limit, offset)
+	rows, err := r.db.QueryReadContext(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	users := make([]domain.User, 0)
+	for rows.Next() {
+		user, err := r.scanUserFromRows(rows)
+		if err != nil {
+			return nil, err
+		}
+		users = append(users, *user)
+	}
+	return users, rows.Err()
+}
+
+func (r *userRepository) FindRecentlyActive(ctx context.Context, limit int) ([]domain.User, error) {
+	query := `
+		SELECT ` + userColumns + `
+		FROM users
+		WHERE deleted_at IS NULL AND is_active = true AND last_login_at IS NOT NULL
+		ORDER BY last_login_at DESC
+		LIMIT $1
 	`
-	rows, err := r.db.QueryContext(ctx, query, limit, offset)
+	rows, err := r.db.QueryContext(ctx, query, limit)
 	if err != nil {
 		return nil, err
 	}
@@ -92,13 +142,26 @@ func (r *userRepository) FindAll(ctx context.Context, limit, offset int) ([]doma
 	return users, rows.Err()
 }
 
-func (r *userRepository) Count(ctx context.Context) (int64, error) {
-	query := `SELECT COUNT(id) FROM users WHERE deleted_at IS NULL`
+func (r *userRepository) Count(ctx context.Context, filter domain.UserFilter) (int64, error) {
+	query := `SELECT COUNT(id) FROM users ` + userFilter
 	var count int64
-	err := r.db.QueryRowContext(ctx, query).Scan(&count)
+	err := r.db.QueryRowReadContext(ctx, query, userFilterArgs(filter)...).Scan(&count)
 	return count, err
 }
 
+func userFilterArgs(filter domain.UserFilter) []interface{} {
+	return []interface{}{
+		likeEscaper.Replace(filter.Query),
+		filter.Role,
+		filter.IsActive,
+		filter.PlanID,
+		filter.CreatedFrom,
+		filter.CreatedTo,
+		filter.LastLoginFrom,
+		filter.LastLoginTo,
+	}
+}
+
 func (r *userRepository) Update(ctx context.Context, user *domain.User) error {
 	query := `
 		UPDATE users
@@ -119,6 +182,67 @@ func (r *userRepository) UpdateAvatar(ctx context.Context, id uuid.UUID, avatarU
 	return err
 }
 
+func (r *userRepository) UpdateTwoFactor(ctx context.Context, id uuid.UUID, enabled bool) error {
+	query := `
+		UPDATE users
+		SET two_factor_enabled = $1
+		WHERE id = $2 AND deleted_at IS NULL
+	`
+	_, err := r.db.ExecContext(ctx, query, enabled, id)
+	return err
+}
+
+func (r *userRepository) UpdateTimezone(ctx context.Context, id uuid.UUID, timezone string) error {
+	query := `
+		UPDATE users
+		SET timezone = $1
+		WHERE id = $2 AND deleted_at IS NULL
+	`
+	_, err := r.db.ExecContext(ctx, query, timezone, id)
+	return err
+}
+
+// UpdateDataRegion moves the user to region unless they have resumes,
+// including in the trash, and reports whether it did. The user row stays
+// locked from the check to the update, and creating a resume has to wait
+// for that lock, so a resume cannot slip in between.
+func (r *userRepository) UpdateDataRegion(ctx context.Context, id uuid.UUID, region string) (bool, error) {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return false, err
+	}
+	defer tx.Rollback()
+
+	lockQuery := `
+		SELECT EXISTS (SELECT 1 FROM resumes WHERE user_id = u.id)
+		FROM users u
+		WHERE u.id = $1 AND u.deleted_at IS NULL
+		FOR UPDATE OF u
+	`
+	var hasResumes bool
+	if err := tx.QueryRowContext(ctx, lockQuery, id).Scan(&hasResumes); err != nil {
+		return false, err
+	}
+	if hasResumes {
+		return false, nil
+	}
+
+	if _, err := tx.ExecContext(ctx, `UPDATE users SET data_region = $1 WHERE id = $2`, region, id); err != nil {
+		return false, err
+	}
+	return true, tx.Commit()
+}
+
+func (r *userRepository) UpdateContactEmail(ctx context.Context, id uuid.UUID, contactEmail *string) error {
+	query := `
+		UPDATE users
+		SET contact_email = $1
+		WHERE id = $2 AND deleted_at IS NULL
+	`
+	_, err := r.db.ExecContext(ctx, query, contactEmail, id)
+	return err
+}
+
 func (r *userRepository) SoftDelete(ctx context.Context, id uuid.UUID) error {
 	query := `
 		UPDATE users
@@ -184,10 +308,14 @@ func (r *userRepository) scanUser(row *sql.Row) (*domain.User, error) {
 		&user.ID,
 		&user.GoogleID,
 		&user.Email,
+		&user.ContactEmail,
 		&user.Name,
 		&user.AvatarURL,
 		&role,
 		&user.IsActive,
+		&user.TwoFactorEnabled,
+		&user.Timezone,
+		&user.DataRegion,
 		&user.CreatedAt,
 		&user.LastLoginAt,
 		&user.DeletedAt,
@@ -206,10 +334,14 @@ func (r *userRepository) scanUserFromRows(rows *sql.Rows) (*domain.User, error)
 		&user.ID,
 		&user.GoogleID,
 		&user.Email,
+		&user.ContactEmail,
 		&user.Name,
 		&user.AvatarURL,
 		&role,
 		&user.IsActive,
+		&user.TwoFactorEnabled,
+		&user.Timezone,
+		&user.DataRegion,
 		&user.CreatedAt,
 		&user.LastLoginAt,
 		&user.DeletedAt,
@@ -0,0 +1,325 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"time"
+
+	"github.com/raflytch/careerly-server/internal/domain"
+
+	"github.com/google/uuid"
+)
+
+const (
+	webhookEndpointColumns = `id, url, description, events, secret, is_active, created_by, created_at, updated_at, deleted_at`
+	webhookDeliveryColumns = `id, endpoint_id, event, payload, status, attempts, max_attempts, response_status, last_error, next_attempt_at, delivered_at, created_at, updated_at`
+)
+
+type webhookRepository struct {
+	db *sql.DB
+}
+
+func NewWebhookRepository(db *sql.DB) domain.WebhookRepository {
+	return &webhookRepository{db: db}
+}
+
+func (r *webhookRepository) CreateEndpoint(ctx context.Context, endpoint *domain.WebhookEndpoint) error {
+	eventsJSON, err := json.Marshal(endpoint.Events)
+	if err != nil {
+		return err
+	}
+
+	query := `
+		INSERT INTO webhook_endpoints (` + webhookEndpointColumns + `)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10)
+	`
+	_, err = r.db.ExecContext(ctx, query,
+		endpoint.ID,
+		endpoint.URL,
+		endpoint.Description,
+		eventsJSON,
+		endpoint.Secret,
+		endpoint.IsActive,
+		endpoint.CreatedBy,
+		endpoint.CreatedAt,
+		endpoint.UpdatedAt,
+		endpoint.DeletedAt,
+	)
+	return err
+}
+
+func (r *webhookRepository) FindEndpointByID(ctx context.Context, id uuid.UUID) (*domain.WebhookEndpoint, error) {
+	query := `
+		SELECT ` + webhookEndpointColumns + `
+		FROM webhook_endpoints
+		WHERE id = $1 AND deleted_at IS NULL
+	`
+	return r.scanEndpoint(r.db.QueryRowContext(ctx, query, id))
+}
+
+func (r *webhookRepository) FindEndpoints(ctx context.Context, limit, offset int) ([]domain.WebhookEndpoint, error) {
+	query := `
+		SELECT ` + webhookEndpointColumns + `
+		FROM webhook_endpoints
+		WHERE deleted_at IS NULL
+		ORDER BY created_at DESC
+		LIMIT $1 OFFSET $2
+	`
+	rows, err := r.db.QueryContext(ctx, query, limit, offset)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	return r.collectEndpoints(rows)
+}
+
+func (r *webhookRepository) CountEndpoints(ctx context.Context) (int64, error) {
+	query := `SELECT COUNT(id) FROM webhook_endpoints WHERE deleted_at IS NULL`
+	var count int64
+	err := r.db.QueryRowContext(ctx, query).Scan(&count)
+	return count, err
+}
+
+func (r *webhookRepository) FindActiveEndpointsByEvent(ctx context.Context, event domain.WebhookEvent) ([]domain.WebhookEndpoint, error) {
+	query := `
+		SELECT ` + webhookEndpointColumns + `
+		FROM webhook_endpoints
+		WHERE is_active = TRUE AND deleted_at IS NULL AND events ? $1
+	`
+	rows, err := r.db.QueryContext(ctx, query, string(event))
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	return r.collectEndpoints(rows)
+}
+
+func (r *webhookRepository) UpdateEndpoint(ctx context.Context, endpoint *domain.WebhookEndpoint) error {
+	eventsJSON, err := json.Marshal(endpoint.Events)
+	if err != nil {
+		return err
+	}
+
+	query := `
+		UPDATE webhook_endpoints
+		SET url = $1, description = $2, events = $3, secret = $4, is_active = $5, updated_at = $6
+		WHERE id = $7 AND deleted_at IS NULL
+	`
+	_, err = r.db.ExecContext(ctx, query,
+		endpoint.URL,
+		endpoint.Description,
+		eventsJSON,
+		endpoint.Secret,
+		endpoint.IsActive,
+		endpoint.UpdatedAt,
+		endpoint.ID,
+	)
+	return err
+}
+
+func (r *webhookRepository) SoftDeleteEndpoint(ctx context.Context, id uuid.UUID) error {
+	query := `UPDATE webhook_endpoints SET deleted_at = $1, is_active = FALSE WHERE id = $2 AND deleted_at IS NULL`
+	_, err := r.db.ExecContext(ctx, query, time.Now(), id)
+	return err
+}
+
+func (r *webhookRepository) CreateDelivery(ctx context.Context, delivery *domain.WebhookDelivery) error {
+	query := `
+		INSERT INTO webhook_deliveries (` + webhookDeliveryColumns + `)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13)
+	`
+	_, err := r.db.ExecContext(ctx, query,
+		delivery.ID,
+		delivery.EndpointID,
+		delivery.Event,
+		[]byte(delivery.Payload),
+		delivery.Status,
+		delivery.Attempts,
+		delivery.MaxAttempts,
+		delivery.ResponseStatus,
+		delivery.LastError,
+		delivery.NextAttemptAt,
+		delivery.DeliveredAt,
+		delivery.CreatedAt,
+		delivery.UpdatedAt,
+	)
+	return err
+}
+
+func (r *webhookRepository) FindDeliveryByID(ctx context.Context, id uuid.UUID) (*domain.WebhookDelivery, error) {
+	query := `
+		SELECT ` + webhookDeliveryColumns + `
+		FROM webhook_deliveries
+		WHERE id = $1
+	`
+	return r.scanDelivery(r.db.QueryRowContext(ctx, query, id))
+}
+
+func (r *webhookRepository) FindDeliveries(ctx context.Context, endpointID uuid.UUID, status domain.WebhookDeliveryStatus, limit, offset int) ([]domain.WebhookDelivery, error) {
+	query := `
+		SELECT ` + webhookDeliveryColumns + `
+		FROM webhook_deliveries
+		WHERE endpoint_id = $1 AND ($2 = '' OR status = $2)
+		ORDER BY created_at DESC
+		LIMIT $3 OFFSET $4
+	`
+	rows, err := r.db.QueryContext(ctx, query, endpointID, status, limit, offset)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	return r.collectDeliveries(rows)
+}
+
+func (r *webhookRepository) CountDeliveries(ctx context.Context, endpointID uuid.UUID, status domain.WebhookDeliveryStatus) (int64, error) {
+	query := `SELECT COUNT(id) FROM webhook_deliveries WHERE endpoint_id = $1 AND ($2 = '' OR status = $2)`
+	var count int64
+	err := r.db.QueryRowContext(ctx, query, endpointID, status).Scan(&count)
+	return count, err
+}
+
+func (r *webhookRepository) FindDueDeliveries(ctx context.Context, now time.Time, limit int) ([]domain.WebhookDelivery, error) {
+	query := `
+		SELECT ` + webhookDeliveryColumns + `
+		FROM webhook_deliveries
+		WHERE status = $1 AND next_attempt_at <= $2
+		ORDER BY next_attempt_at ASC
+		LIMIT $3
+	`
+	rows, err := r.db.QueryContext(ctx, query, domain.WebhookDeliveryStatusPending, now, limit)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	return r.collectDeliveries(rows)
+}
+
+func (r *webhookRepository) UpdateDelivery(ctx context.Context, delivery *domain.WebhookDelivery) error {
+	query := `
+		UPDATE webhook_deliveries
+		SET status = $1, attempts = $2, response_status = $3, last_error = $4, next_attempt_at = $5, delivered_at = $6, updated_at = $7
+		WHERE id = $8
+	`
+	_, err := r.db.ExecContext(ctx, query,
+		delivery.Status,
+		delivery.Attempts,
+		delivery.ResponseStatus,
+		delivery.LastError,
+		delivery.NextAttemptAt,
+		delivery.DeliveredAt,
+		delivery.UpdatedAt,
+		delivery.ID,
+	)
+	return err
+}
+
+func (r *webhookRepository) collectEndpoints(rows *sql.Rows) ([]domain.WebhookEndpoint, error) {
+	endpoints := make([]domain.WebhookEndpoint, 0)
+	for rows.Next() {
+		var endpoint domain.WebhookEndpoint
+		var eventsJSON []byte
+		err := rows.Scan(
+			&endpoint.ID,
+			&endpoint.URL,
+			&endpoint.Description,
+			&eventsJSON,
+			&endpoint.Secret,
+			&endpoint.IsActive,
+			&endpoint.CreatedBy,
+			&endpoint.CreatedAt,
+			&endpoint.UpdatedAt,
+			&endpoint.DeletedAt,
+		)
+		if err != nil {
+			return nil, err
+		}
+		if err := json.Unmarshal(eventsJSON, &endpoint.Events); err != nil {
+			return nil, err
+		}
+		endpoints = append(endpoints, endpoint)
+	}
+	return endpoints, rows.Err()
+}
+
+func (r *webhookRepository) scanEndpoint(row *sql.Row) (*domain.WebhookEndpoint, error) {
+	var endpoint domain.WebhookEndpoint
+	var eventsJSON []byte
+	err := row.Scan(
+		&endpoint.ID,
+		&endpoint.URL,
+		&endpoint.Description,
+		&eventsJSON,
+		&endpoint.Secret,
+		&endpoint.IsActive,
+		&endpoint.CreatedBy,
+		&endpoint.CreatedAt,
+		&endpoint.UpdatedAt,
+		&endpoint.DeletedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+	if err := json.Unmarshal(eventsJSON, &endpoint.Events); err != nil {
+		return nil, err
+	}
+	return &endpoint, nil
+}
+
+func (r *webhookRepository) collectDeliveries(rows *sql.Rows) ([]domain.WebhookDelivery, error) {
+	deliveries := make([]domain.WebhookDelivery, 0)
+	for rows.Next() {
+		var delivery domain.WebhookDelivery
+		var payload []byte
+		err := rows.Scan(
+			&delivery.ID,
+			&delivery.EndpointID,
+			&delivery.Event,
+			&payload,
+			&delivery.Status,
+			&delivery.Attempts,
+			&delivery.MaxAttempts,
+			&delivery.ResponseStatus,
+			&delivery.LastError,
+			&delivery.NextAttemptAt,
+			&delivery.DeliveredAt,
+			&delivery.CreatedAt,
+			&delivery.UpdatedAt,
+		)
+		if err != nil {
+			return nil, err
+		}
+		delivery.Payload = payload
+		deliveries = append(deliveries, delivery)
+	}
+	return deliveries, rows.Err()
+}
+
+func (r *webhookRepository) scanDelivery(row *sql.Row) (*domain.WebhookDelivery, error) {
+	var delivery domain.WebhookDelivery
+	var payload []byte
+	err := row.Scan(
+		&delivery.ID,
+		&delivery.EndpointID,
+		&delivery.Event,
+		&payload,
+		&delivery.Status,
+		&delivery.Attempts,
+		&delivery.MaxAttempts,
+		&delivery.ResponseStatus,
+		&delivery.LastError,
+		&delivery.NextAttemptAt,
+		&delivery.DeliveredAt,
+		&delivery.CreatedAt,
+		&delivery.UpdatedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+	delivery.Payload = payload
+	return &delivery, nil
+}
@@ -0,0 +1,25 @@
+package routes
+
+import (
+	"github.com/raflytch/careerly-server/internal/handler"
+	"github.com/raflytch/careerly-server/internal/middleware"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+func setupAddonRoutes(router fiber.Router, h *handler.AddonHandler, authMiddleware *middleware.AuthMiddleware) {
+	addons := router.Group("/addons")
+	addons.Use(authMiddleware.Authenticate())
+
+	addons.Get("/", h.ListAvailable)
+}
+
+func setupAddonAdminRoutes(router fiber.Router, h *handler.AddonHandler) {
+	addons := router.Group("/addons")
+
+	addons.Post("/", h.Create)
+	addons.Get("/", h.GetAll)
+	addons.Get("/:id", h.GetByID)
+	addons.Put("/:id", h.Update)
+	addons.Delete("/:id", h.Delete)
+}
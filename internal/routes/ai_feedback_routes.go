@@ -0,0 +1,18 @@
+package routes
+
+import (
+	"github.com/raflytch/careerly-server/internal/handler"
+	"github.com/raflytch/careerly-server/internal/middleware"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+func setupAIFeedbackRoutes(router fiber.Router, h *handler.AIFeedbackHandler, auth *middleware.AuthMiddleware) {
+	router.Post("/resumes/:id/feedback", auth.Authenticate(), h.RateResume)
+	router.Post("/interviews/:id/feedback", auth.Authenticate(), h.RateInterview)
+	router.Post("/ats-checks/:id/feedback", auth.Authenticate(), h.RateATSCheck)
+}
+
+func setupAIFeedbackAdminRoutes(admin fiber.Router, h *handler.AIFeedbackHandler) {
+	admin.Get("/ai-quality", h.GetQualityReport)
+}
@@ -0,0 +1,14 @@
+package routes
+
+import (
+	"github.com/raflytch/careerly-server/internal/handler"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+func setupAIUsageRoutes(admin fiber.Router, h *handler.AIUsageHandler) {
+	aiUsage := admin.Group("/ai-usage")
+
+	aiUsage.Get("/", h.GetReport)
+	aiUsage.Get("/logs", h.GetLogs)
+}
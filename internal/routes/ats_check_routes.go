@@ -7,13 +7,17 @@ import (
 	"github.com/gofiber/fiber/v2"
 )
 
-func setupATSCheckRoutes(router fiber.Router, h *handler.ATSCheckHandler, auth *middleware.AuthMiddleware) {
+func setupATSCheckRoutes(router fiber.Router, h *handler.ATSCheckHandler, auth *middleware.AuthMiddleware, aiTimeout, aiLimit fiber.Handler) {
 	ats := router.Group("/ats-checks")
 
 	ats.Use(auth.Authenticate())
 
 	ats.Post("/analyze", h.Analyze)
+	ats.Post("/batch", aiTimeout, aiLimit, h.AnalyzeBatch)
 	ats.Get("/", h.GetMyATSChecks)
+	ats.Get("/compare", h.Compare)
 	ats.Get("/:id", h.GetByID)
+	ats.Get("/:id/events", h.StreamEvents)
+	ats.Get("/:id/annotated-pdf", h.GetAnnotatedPDF)
 	ats.Delete("/:id", h.Delete)
 }
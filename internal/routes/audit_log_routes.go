@@ -0,0 +1,13 @@
+package routes
+
+import (
+	"github.com/raflytch/careerly-server/internal/handler"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+func setupAuditLogRoutes(router fiber.Router, h *handler.AuditLogHandler) {
+	auditLogs := router.Group("/audit-logs")
+
+	auditLogs.Get("/", h.GetAll)
+}
@@ -13,6 +13,10 @@ func setupAuthRoutes(router fiber.Router, h *handler.AuthHandler) {
 	google.Get("/login", h.GoogleLogin)
 	google.Get("/callback", h.GoogleCallback)
 
+	twoFactor := auth.Group("/2fa")
+	twoFactor.Post("/verify", h.VerifyTwoFactor)
+	twoFactor.Post("/resend", h.ResendTwoFactorOTP)
+
 	restore := auth.Group("/restore")
 	restore.Post("/request-otp", h.RequestRestoreOTP)
 	restore.Post("/verify-otp", h.VerifyRestoreOTP)
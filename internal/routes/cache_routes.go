@@ -0,0 +1,13 @@
+package routes
+
+import (
+	"github.com/raflytch/careerly-server/internal/handler"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+func setupCacheRoutes(admin fiber.Router, h *handler.CacheHandler) {
+	cache := admin.Group("/cache")
+
+	cache.Post("/warm", h.Warm)
+}
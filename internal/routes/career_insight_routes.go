@@ -0,0 +1,16 @@
+package routes
+
+import (
+	"github.com/raflytch/careerly-server/internal/handler"
+	"github.com/raflytch/careerly-server/internal/middleware"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+func setupCareerInsightRoutes(router fiber.Router, h *handler.CareerInsightHandler, auth *middleware.AuthMiddleware, aiTimeout, aiLimit fiber.Handler) {
+	insights := router.Group("/insights")
+
+	insights.Use(auth.Authenticate())
+
+	insights.Get("/skill-gap", aiTimeout, aiLimit, h.GetSkillGap)
+}
@@ -0,0 +1,12 @@
+package routes
+
+import (
+	"github.com/raflytch/careerly-server/internal/handler"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+func setupDataRegionRoutes(router fiber.Router, h *handler.DataRegionHandler) {
+	router.Get("/data-regions", h.GetRegions)
+	router.Put("/users/:id/data-region", h.SetUserRegion)
+}
@@ -0,0 +1,14 @@
+package routes
+
+import (
+	"github.com/raflytch/careerly-server/internal/handler"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+func setupDataTransferRoutes(admin fiber.Router, h *handler.DataTransferHandler) {
+	users := admin.Group("/users")
+
+	users.Get("/:id/export", h.Export)
+	users.Post("/:id/import", h.Import)
+}
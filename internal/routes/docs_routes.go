@@ -0,0 +1,14 @@
+package routes
+
+import (
+	"github.com/raflytch/careerly-server/internal/handler"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+func setupDocsRoutes(app *fiber.App, h *handler.DocsHandler) {
+	docs := app.Group("/docs")
+
+	docs.Get("/", h.UI)
+	docs.Get("/openapi.json", h.Spec)
+}
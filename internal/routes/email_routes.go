@@ -0,0 +1,20 @@
+package routes
+
+import (
+	"github.com/raflytch/careerly-server/internal/handler"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+func setupEmailRoutes(router fiber.Router, h *handler.EmailHandler) {
+	email := router.Group("/email")
+
+	email.Post("/events/:provider", h.ProviderEvents)
+}
+
+func setupEmailAdminRoutes(router fiber.Router, h *handler.EmailHandler) {
+	suppressions := router.Group("/email-suppressions")
+
+	suppressions.Get("/", h.GetSuppressions)
+	suppressions.Delete("/:email", h.RemoveSuppression)
+}
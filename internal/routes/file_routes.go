@@ -0,0 +1,11 @@
+package routes
+
+import (
+	"github.com/raflytch/careerly-server/internal/handler"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+func setupFileRoutes(router fiber.Router, h *handler.FileHandler) {
+	router.Get("/files", h.Get)
+}
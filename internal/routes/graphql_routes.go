@@ -0,0 +1,15 @@
+package routes
+
+import (
+	"github.com/raflytch/careerly-server/internal/handler"
+	"github.com/raflytch/careerly-server/internal/middleware"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+func setupGraphQLRoutes(router fiber.Router, h *handler.GraphQLHandler, auth *middleware.AuthMiddleware) {
+	graphql := router.Group("/graphql", auth.Authenticate())
+
+	graphql.Get("/", h.Serve)
+	graphql.Post("/", h.Serve)
+}
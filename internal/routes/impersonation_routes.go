@@ -0,0 +1,11 @@
+package routes
+
+import (
+	"github.com/raflytch/careerly-server/internal/handler"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+func setupImpersonationRoutes(router fiber.Router, h *handler.AuthHandler) {
+	router.Post("/users/:id/impersonate", h.Impersonate)
+}
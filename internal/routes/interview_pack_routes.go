@@ -0,0 +1,25 @@
+package routes
+
+import (
+	"github.com/raflytch/careerly-server/internal/handler"
+	"github.com/raflytch/careerly-server/internal/middleware"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+func setupInterviewPackRoutes(router fiber.Router, h *handler.InterviewPackHandler, authMiddleware *middleware.AuthMiddleware) {
+	packs := router.Group("/interview-packs")
+	packs.Use(authMiddleware.Authenticate())
+
+	packs.Get("/", h.ListAvailable)
+}
+
+func setupInterviewPackAdminRoutes(router fiber.Router, h *handler.InterviewPackHandler) {
+	packs := router.Group("/interview-packs")
+
+	packs.Post("/", h.Create)
+	packs.Get("/", h.GetAll)
+	packs.Get("/:id", h.GetByID)
+	packs.Put("/:id", h.Update)
+	packs.Delete("/:id", h.Delete)
+}
@@ -7,14 +7,23 @@ import (
 	"github.com/gofiber/fiber/v2"
 )
 
-func setupInterviewRoutes(router fiber.Router, h *handler.InterviewHandler, auth *middleware.AuthMiddleware) {
+func setupInterviewRoutes(router fiber.Router, h *handler.InterviewHandler, auth *middleware.AuthMiddleware, aiTimeout, aiLimit fiber.Handler) {
 	interviews := router.Group("/interviews")
 
 	interviews.Use(auth.Authenticate())
 
-	interviews.Post("/", h.Create)
+	interviews.Post("/", aiTimeout, aiLimit, h.Create)
+	interviews.Post("/schedule", h.Schedule)
+	interviews.Post("/from-pack/:id", h.StartFromPack)
 	interviews.Get("/", h.GetMyInterviews)
+	interviews.Get("/trash", h.GetTrash)
+	interviews.Get("/progress", h.GetPositionProgress)
 	interviews.Get("/:id", h.GetByID)
 	interviews.Post("/:id/submit", h.SubmitAnswers)
+	interviews.Post("/:id/rounds", aiTimeout, aiLimit, h.SubmitRound)
+	interviews.Post("/:id/questions/:questionId/video", aiTimeout, aiLimit, h.UploadVideoAnswer)
+	interviews.Get("/:id/evaluation", h.GetEvaluation)
+	interviews.Get("/:id/export", h.Export)
 	interviews.Delete("/:id", h.Delete)
+	interviews.Post("/:id/restore", h.Restore)
 }
@@ -0,0 +1,19 @@
+package routes
+
+import (
+	"github.com/raflytch/careerly-server/internal/handler"
+	"github.com/raflytch/careerly-server/internal/middleware"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+func setupInterviewShareRoutes(router fiber.Router, h *handler.InterviewShareHandler, auth *middleware.AuthMiddleware) {
+	owner := router.Group("/interviews", auth.Authenticate())
+	owner.Post("/:id/share", h.Create)
+	owner.Delete("/:id/share/:shareId", h.Revoke)
+	owner.Get("/:id/comments", h.GetComments)
+
+	shared := router.Group("/shared/interviews")
+	shared.Get("/:token", h.GetSharedReport)
+	shared.Post("/:token/comments", h.AddComment)
+}
@@ -0,0 +1,15 @@
+package routes
+
+import (
+	"github.com/raflytch/careerly-server/internal/handler"
+	"github.com/raflytch/careerly-server/internal/middleware"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+func setupStudyPlanRoutes(router fiber.Router, h *handler.StudyPlanHandler, auth *middleware.AuthMiddleware, aiTimeout, aiLimit fiber.Handler) {
+	plans := router.Group("/interviews/:id/study-plan", auth.Authenticate())
+	plans.Post("/", aiTimeout, aiLimit, h.Generate)
+	plans.Get("/", h.Get)
+	plans.Patch("/tasks/:taskId", h.UpdateTask)
+}
@@ -0,0 +1,17 @@
+package routes
+
+import (
+	"github.com/raflytch/careerly-server/internal/handler"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+func setupJobRoutes(admin fiber.Router, h *handler.JobHandler) {
+	jobs := admin.Group("/jobs")
+
+	jobs.Get("/", h.GetStats)
+	jobs.Get("/dead", h.GetDeadJobs)
+	jobs.Get("/dead/:id", h.GetDeadJob)
+	jobs.Post("/dead/:id/retry", h.RetryDeadJob)
+	jobs.Delete("/dead/:id", h.DeleteDeadJob)
+}
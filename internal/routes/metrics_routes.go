@@ -0,0 +1,11 @@
+package routes
+
+import (
+	"github.com/raflytch/careerly-server/internal/handler"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+func setupMetricsRoutes(app *fiber.App, h *handler.MetricsHandler) {
+	app.Get("/metrics", h.Get)
+}
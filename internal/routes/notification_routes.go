@@ -0,0 +1,16 @@
+package routes
+
+import (
+	"github.com/raflytch/careerly-server/internal/handler"
+	"github.com/raflytch/careerly-server/internal/middleware"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+func setupNotificationRoutes(router fiber.Router, h *handler.NotificationHandler, auth *middleware.AuthMiddleware) {
+	notifications := router.Group("/notifications")
+
+	notifications.Use(auth.Authenticate())
+
+	notifications.Get("/", h.GetAll)
+}
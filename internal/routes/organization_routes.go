@@ -0,0 +1,24 @@
+package routes
+
+import (
+	"github.com/raflytch/careerly-server/internal/handler"
+	"github.com/raflytch/careerly-server/internal/middleware"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+func setupOrganizationRoutes(router fiber.Router, h *handler.OrganizationHandler, auth *middleware.AuthMiddleware) {
+	organizations := router.Group("/organizations")
+
+	organizations.Use(auth.Authenticate())
+
+	organizations.Post("/", middleware.DenyImpersonation(), h.Create)
+	organizations.Post("/invitations/accept", middleware.DenyImpersonation(), h.AcceptInvitation)
+
+	organizations.Get("/current", h.Get)
+	organizations.Get("/current/quota", h.GetQuota)
+	organizations.Post("/current/seats", middleware.DenyImpersonation(), h.PurchaseSeats)
+	organizations.Post("/current/invitations", middleware.DenyImpersonation(), h.Invite)
+	organizations.Delete("/current/members/:userId", middleware.DenyImpersonation(), h.RemoveMember)
+	organizations.Post("/current/leave", middleware.DenyImpersonation(), h.Leave)
+}
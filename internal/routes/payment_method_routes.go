@@ -0,0 +1,18 @@
+package routes
+
+import (
+	"github.com/raflytch/careerly-server/internal/handler"
+	"github.com/raflytch/careerly-server/internal/middleware"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+func setupPaymentMethodRoutes(router fiber.Router, h *handler.PaymentMethodHandler, auth *middleware.AuthMiddleware) {
+	methods := router.Group("/payment-methods")
+
+	methods.Use(auth.Authenticate())
+
+	methods.Get("/", h.GetAll)
+
+	methods.Delete("/:id", middleware.DenyImpersonation(), h.Delete)
+}
@@ -0,0 +1,14 @@
+package routes
+
+import (
+	"github.com/raflytch/careerly-server/internal/handler"
+	"github.com/raflytch/careerly-server/internal/middleware"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+func setupPaymentSimulationRoutes(router fiber.Router, h *handler.PaymentSimulationHandler, auth *middleware.AuthMiddleware) {
+	dev := router.Group("/dev", auth.Authenticate())
+
+	dev.Post("/simulate-payment/:orderID", h.SimulatePayment)
+}
@@ -5,18 +5,21 @@ import (
 	"github.com/raflytch/careerly-server/internal/middleware"
 
 	"github.com/gofiber/fiber/v2"
+	"github.com/gofiber/fiber/v2/middleware/etag"
 )
 
 func setupPlanRoutes(router fiber.Router, h *handler.PlanHandler, authMiddleware *middleware.AuthMiddleware) {
 	plans := router.Group("/plans")
 	plans.Use(authMiddleware.Authenticate())
 
-	plans.Post("/", h.Create)
-	plans.Get("/", h.GetAll)
+	plans.Post("/", middleware.AuditContext(), h.Create)
+	plans.Get("/", etag.New(), h.GetAll)
 
 	adminPlans := plans.Group("/")
-	adminPlans.Use(middleware.RequireAdmin())
+	adminPlans.Use(middleware.RequireAdmin(), middleware.AuditContext())
 	adminPlans.Get("/:id", h.GetByID)
 	adminPlans.Put("/:id", h.Update)
 	adminPlans.Delete("/:id", h.Delete)
+	adminPlans.Post("/:id/archive", h.Archive)
+	adminPlans.Post("/:id/unarchive", h.Unarchive)
 }
@@ -0,0 +1,16 @@
+package routes
+
+import (
+	"github.com/raflytch/careerly-server/internal/handler"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+func setupPromptExperimentRoutes(admin fiber.Router, h *handler.PromptExperimentHandler) {
+	experiments := admin.Group("/experiments")
+
+	experiments.Get("/", h.List)
+	experiments.Post("/", h.Create)
+	experiments.Get("/:id", h.GetResults)
+	experiments.Post("/:id/stop", h.Stop)
+}
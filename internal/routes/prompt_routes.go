@@ -0,0 +1,18 @@
+package routes
+
+import (
+	"github.com/raflytch/careerly-server/internal/handler"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+func setupPromptRoutes(admin fiber.Router, h *handler.PromptHandler) {
+	prompts := admin.Group("/prompts")
+
+	prompts.Get("/", h.List)
+	prompts.Get("/:key", h.Get)
+	prompts.Post("/:key/versions", h.CreateVersion)
+	prompts.Post("/:key/versions/:version/activate", h.Activate)
+	prompts.Delete("/:key/versions/:version", h.DeleteVersion)
+	prompts.Post("/:key/reset", h.Reset)
+}
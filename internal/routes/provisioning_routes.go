@@ -0,0 +1,15 @@
+package routes
+
+import (
+	"github.com/raflytch/careerly-server/internal/handler"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+func setupProvisioningRoutes(admin fiber.Router, h *handler.ProvisioningHandler) {
+	jobs := admin.Group("/provisioning-jobs")
+
+	jobs.Get("/", h.GetAll)
+	jobs.Get("/:id", h.GetByID)
+	jobs.Post("/:id/replay", h.Replay)
+}
@@ -0,0 +1,15 @@
+package routes
+
+import (
+	"github.com/raflytch/careerly-server/internal/handler"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+func setupQuestionBankAdminRoutes(router fiber.Router, h *handler.QuestionBankHandler) {
+	bank := router.Group("/question-bank")
+
+	bank.Post("/import", h.Import)
+	bank.Get("/", h.GetAll)
+	bank.Delete("/:id", h.Delete)
+}
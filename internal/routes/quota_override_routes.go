@@ -0,0 +1,15 @@
+package routes
+
+import (
+	"github.com/raflytch/careerly-server/internal/handler"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+func setupQuotaOverrideRoutes(router fiber.Router, h *handler.QuotaOverrideHandler) {
+	overrides := router.Group("/users/:id/quota-override")
+
+	overrides.Post("/", h.Create)
+	overrides.Get("/", h.GetByUser)
+	overrides.Delete("/:overrideId", h.Revoke)
+}
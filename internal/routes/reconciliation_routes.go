@@ -0,0 +1,11 @@
+package routes
+
+import (
+	"github.com/raflytch/careerly-server/internal/handler"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+func setupReconciliationRoutes(admin fiber.Router, h *handler.ReconciliationHandler) {
+	admin.Get("/reconciliation", h.GetReport)
+}
@@ -0,0 +1,16 @@
+package routes
+
+import (
+	"github.com/raflytch/careerly-server/internal/handler"
+	"github.com/raflytch/careerly-server/internal/middleware"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+func setupReferralRoutes(router fiber.Router, h *handler.ReferralHandler, auth *middleware.AuthMiddleware) {
+	referrals := router.Group("/referrals")
+
+	referrals.Use(auth.Authenticate())
+
+	referrals.Get("/stats", h.GetStats)
+}
@@ -0,0 +1,19 @@
+package routes
+
+import (
+	"github.com/raflytch/careerly-server/internal/handler"
+	"github.com/raflytch/careerly-server/internal/middleware"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+func setupResumeDraftRoutes(router fiber.Router, h *handler.ResumeDraftHandler, authMiddleware *middleware.AuthMiddleware, aiTimeout, aiLimit fiber.Handler) {
+	drafts := router.Group("/resumes/drafts", authMiddleware.Authenticate())
+
+	drafts.Post("/", h.Create)
+	drafts.Get("/", h.GetMyDrafts)
+	drafts.Get("/:id", h.GetByID)
+	drafts.Patch("/:id", h.Update)
+	drafts.Delete("/:id", h.Delete)
+	drafts.Post("/:id/publish", aiTimeout, aiLimit, h.Publish)
+}
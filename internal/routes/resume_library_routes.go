@@ -0,0 +1,26 @@
+package routes
+
+import (
+	"github.com/raflytch/careerly-server/internal/handler"
+	"github.com/raflytch/careerly-server/internal/middleware"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+func setupResumeLibraryRoutes(router fiber.Router, h *handler.ResumeLibraryHandler, authMiddleware *middleware.AuthMiddleware) {
+	tags := router.Group("/resume-tags", authMiddleware.Authenticate())
+	tags.Post("/", h.CreateTag)
+	tags.Get("/", h.GetTags)
+	tags.Patch("/:id", h.RenameTag)
+	tags.Delete("/:id", h.DeleteTag)
+
+	folders := router.Group("/resume-folders", authMiddleware.Authenticate())
+	folders.Post("/", h.CreateFolder)
+	folders.Get("/", h.GetFolders)
+	folders.Patch("/:id", h.RenameFolder)
+	folders.Delete("/:id", h.DeleteFolder)
+
+	resumes := router.Group("/resumes", authMiddleware.Authenticate())
+	resumes.Put("/:id/tags", h.SetResumeTags)
+	resumes.Put("/:id/folder", h.MoveResume)
+}
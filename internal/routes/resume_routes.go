@@ -7,15 +7,28 @@ import (
 	"github.com/gofiber/fiber/v2"
 )
 
-func setupResumeRoutes(router fiber.Router, h *handler.ResumeHandler, authMiddleware *middleware.AuthMiddleware) {
+func setupResumeRoutes(router fiber.Router, h *handler.ResumeHandler, ats *handler.ATSCheckHandler, authMiddleware *middleware.AuthMiddleware, aiTimeout, aiLimit fiber.Handler) {
 	resumes := router.Group("/resumes")
 	resumes.Use(authMiddleware.Authenticate())
 
-	resumes.Post("/", h.Create)
+	resumes.Post("/", aiTimeout, aiLimit, h.Create)
 	resumes.Get("/", h.GetMyResumes)
 	resumes.Get("/quota", h.GetQuota)
+	resumes.Get("/search", h.Search)
+	resumes.Get("/trash", h.GetTrash)
+	resumes.Post("/bullets/generate", aiTimeout, aiLimit, h.GenerateBullets)
 	resumes.Get("/:id", h.GetByID)
-	resumes.Put("/:id", h.Update)
+	resumes.Put("/:id", aiTimeout, aiLimit, h.Update)
 	resumes.Delete("/:id", h.Delete)
+	resumes.Post("/:id/restore", h.Restore)
 	resumes.Get("/:id/pdf", h.DownloadPDF)
+	resumes.Get("/:id/pdf/link", h.GetPDFLink)
+	resumes.Get("/:id/lint", h.Lint)
+	resumes.Put("/:id/photo", h.UploadPhoto)
+	resumes.Patch("/:id/photo", h.UpdatePhotoVisibility)
+	resumes.Delete("/:id/photo", h.DeletePhoto)
+	resumes.Patch("/:id/redaction", h.UpdateRedaction)
+	resumes.Post("/:id/optimize", aiTimeout, aiLimit, h.Optimize)
+	resumes.Post("/:id/apply-suggestions", h.ApplySuggestions)
+	resumes.Post("/:id/ats-check", aiTimeout, aiLimit, ats.AnalyzeResume)
 }
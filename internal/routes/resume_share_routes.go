@@ -0,0 +1,22 @@
+package routes
+
+import (
+	"github.com/raflytch/careerly-server/internal/handler"
+	"github.com/raflytch/careerly-server/internal/middleware"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+func setupResumeShareRoutes(router fiber.Router, h *handler.ResumeShareHandler, auth *middleware.AuthMiddleware) {
+	owner := router.Group("/resumes", auth.Authenticate())
+	owner.Post("/:id/share", h.Create)
+	owner.Delete("/:id/share/:shareId", h.Revoke)
+	owner.Get("/:id/comments", h.GetComments)
+	owner.Patch("/:id/comments/:commentId", h.ResolveComment)
+	owner.Get("/:id/analytics", h.GetAnalytics)
+
+	shared := router.Group("/shared/resumes")
+	shared.Get("/:token", h.GetSharedResume)
+	shared.Get("/:token/pdf", h.DownloadSharedPDF)
+	shared.Post("/:token/comments", h.AddComment)
+}
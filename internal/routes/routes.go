@@ -8,31 +8,116 @@ import (
 )
 
 type Handlers struct {
-	Auth        *handler.AuthHandler
-	User        *handler.UserHandler
-	Plan        *handler.PlanHandler
-	Resume      *handler.ResumeHandler
-	Interview   *handler.InterviewHandler
-	ATSCheck    *handler.ATSCheckHandler
-	Transaction *handler.TransactionHandler
+	Auth           *handler.AuthHandler
+	User           *handler.UserHandler
+	Plan           *handler.PlanHandler
+	Resume         *handler.ResumeHandler
+	Interview      *handler.InterviewHandler
+	ATSCheck       *handler.ATSCheckHandler
+	Transaction    *handler.TransactionHandler
+	DataTransfer   *handler.DataTransferHandler
+	Schema         *handler.SchemaHandler
+	Cache          *handler.CacheHandler
+	AIUsage        *handler.AIUsageHandler
+	Provisioning   *handler.ProvisioningHandler
+	Referral       *handler.ReferralHandler
+	AuditLog       *handler.AuditLogHandler
+	CareerInsight  *handler.CareerInsightHandler
+	Metrics        *handler.MetricsHandler
+	InterviewShare *handler.InterviewShareHandler
+	GraphQL        *handler.GraphQLHandler
+	Docs           *handler.DocsHandler
+	Webhook        *handler.WebhookHandler
+	InterviewPack  *handler.InterviewPackHandler
+	QuestionBank   *handler.QuestionBankHandler
+	Email          *handler.EmailHandler
+	Reconciliation *handler.ReconciliationHandler
+	Addon          *handler.AddonHandler
+	Subscription   *handler.SubscriptionHandler
+	File           *handler.FileHandler
+	ResumeShare    *handler.ResumeShareHandler
+	Job            *handler.JobHandler
+	Prompt         *handler.PromptHandler
+	AIFeedback     *handler.AIFeedbackHandler
+	ResumeDraft    *handler.ResumeDraftHandler
+	ResumeLibrary  *handler.ResumeLibraryHandler
+	QuotaOverride  *handler.QuotaOverrideHandler
+	DataRegion     *handler.DataRegionHandler
+	StudyPlan      *handler.StudyPlanHandler
+	Experiment     *handler.PromptExperimentHandler
+	Notification   *handler.NotificationHandler
+	PaymentMethod  *handler.PaymentMethodHandler
+	Organization   *handler.OrganizationHandler
+	// PaymentSimulation is nil outside development.
+	PaymentSimulation *handler.PaymentSimulationHandler
 }
 
 type Middlewares struct {
-	Auth *middleware.AuthMiddleware
+	Auth           *middleware.AuthMiddleware
+	RequestTimeout fiber.Handler
+	AITimeout      fiber.Handler
+	AILimit        fiber.Handler
 }
 
 func Setup(app *fiber.App, handlers Handlers, middlewares Middlewares) {
 	app.Get("/health", healthCheck)
+	setupMetricsRoutes(app, handlers.Metrics)
+	setupDocsRoutes(app, handlers.Docs)
 
-	api := app.Group("/api/v1")
+	setupWebSocketRoutes(app, handlers.Interview, middlewares.Auth)
+
+	api := app.Group("/api/v1", middlewares.RequestTimeout)
 
 	setupAuthRoutes(api, handlers.Auth)
-	setupUserRoutes(api, handlers.User, middlewares.Auth)
+	setupUserRoutes(api, handlers.User, handlers.Auth, handlers.AIUsage, middlewares.Auth)
 	setupPlanRoutes(api, handlers.Plan, middlewares.Auth)
-	setupResumeRoutes(api, handlers.Resume, middlewares.Auth)
-	setupInterviewRoutes(api, handlers.Interview, middlewares.Auth)
-	setupATSCheckRoutes(api, handlers.ATSCheck, middlewares.Auth)
+	// Registered before the resume routes so "drafts" is not taken for a resume id.
+	setupResumeDraftRoutes(api, handlers.ResumeDraft, middlewares.Auth, middlewares.AITimeout, middlewares.AILimit)
+	setupResumeRoutes(api, handlers.Resume, handlers.ATSCheck, middlewares.Auth, middlewares.AITimeout, middlewares.AILimit)
+	setupResumeLibraryRoutes(api, handlers.ResumeLibrary, middlewares.Auth)
+	setupInterviewRoutes(api, handlers.Interview, middlewares.Auth, middlewares.AITimeout, middlewares.AILimit)
+	setupATSCheckRoutes(api, handlers.ATSCheck, middlewares.Auth, middlewares.AITimeout, middlewares.AILimit)
 	setupTransactionRoutes(api, handlers.Transaction, middlewares.Auth)
+	setupSchemaRoutes(api, handlers.Schema)
+	setupReferralRoutes(api, handlers.Referral, middlewares.Auth)
+	setupCareerInsightRoutes(api, handlers.CareerInsight, middlewares.Auth, middlewares.AITimeout, middlewares.AILimit)
+	setupInterviewShareRoutes(api, handlers.InterviewShare, middlewares.Auth)
+	setupStudyPlanRoutes(api, handlers.StudyPlan, middlewares.Auth, middlewares.AITimeout, middlewares.AILimit)
+	setupGraphQLRoutes(api, handlers.GraphQL, middlewares.Auth)
+	setupInterviewPackRoutes(api, handlers.InterviewPack, middlewares.Auth)
+	setupEmailRoutes(api, handlers.Email)
+	setupAddonRoutes(api, handlers.Addon, middlewares.Auth)
+	setupSubscriptionRoutes(api, handlers.Subscription, middlewares.Auth)
+	setupFileRoutes(api, handlers.File)
+	setupResumeShareRoutes(api, handlers.ResumeShare, middlewares.Auth)
+	setupAIFeedbackRoutes(api, handlers.AIFeedback, middlewares.Auth)
+	setupNotificationRoutes(api, handlers.Notification, middlewares.Auth)
+	setupPaymentMethodRoutes(api, handlers.PaymentMethod, middlewares.Auth)
+	setupOrganizationRoutes(api, handlers.Organization, middlewares.Auth)
+	if handlers.PaymentSimulation != nil {
+		setupPaymentSimulationRoutes(api, handlers.PaymentSimulation, middlewares.Auth)
+	}
+
+	admin := api.Group("/admin", middlewares.Auth.Authenticate(), middleware.RequireAdmin(), middleware.AuditContext())
+	setupDataTransferRoutes(admin, handlers.DataTransfer)
+	setupCacheRoutes(admin, handlers.Cache)
+	setupAIUsageRoutes(admin, handlers.AIUsage)
+	setupProvisioningRoutes(admin, handlers.Provisioning)
+	setupAuditLogRoutes(admin, handlers.AuditLog)
+	setupImpersonationRoutes(admin, handlers.Auth)
+	setupWebhookRoutes(admin, handlers.Webhook)
+	setupInterviewPackAdminRoutes(admin, handlers.InterviewPack)
+	setupQuestionBankAdminRoutes(admin, handlers.QuestionBank)
+	setupAddonAdminRoutes(admin, handlers.Addon)
+	setupEmailAdminRoutes(admin, handlers.Email)
+	setupReconciliationRoutes(admin, handlers.Reconciliation)
+	setupJobRoutes(admin, handlers.Job)
+	setupPromptRoutes(admin, handlers.Prompt)
+	setupPromptExperimentRoutes(admin, handlers.Experiment)
+	setupAIFeedbackAdminRoutes(admin, handlers.AIFeedback)
+	setupQuotaOverrideRoutes(admin, handlers.QuotaOverride)
+	setupDataRegionRoutes(admin, handlers.DataRegion)
+	setupSubscriptionAdminRoutes(admin, handlers.Subscription)
 }
 
 func healthCheck(c *fiber.Ctx) error {
@@ -0,0 +1,14 @@
+package routes
+
+import (
+	"github.com/raflytch/careerly-server/internal/handler"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+func setupSchemaRoutes(router fiber.Router, h *handler.SchemaHandler) {
+	schema := router.Group("/schema")
+
+	schema.Get("/", h.ListResources)
+	schema.Get("/:resource", h.GetSchema)
+}
@@ -0,0 +1,21 @@
+package routes
+
+import (
+	"github.com/raflytch/careerly-server/internal/handler"
+	"github.com/raflytch/careerly-server/internal/middleware"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+func setupSubscriptionRoutes(api fiber.Router, h *handler.SubscriptionHandler, auth *middleware.AuthMiddleware) {
+	subscriptions := api.Group("/subscriptions", auth.Authenticate())
+
+	subscriptions.Post("/schedule-change", middleware.DenyImpersonation(), h.ScheduleChange)
+	subscriptions.Delete("/schedule-change", middleware.DenyImpersonation(), h.CancelScheduledChange)
+	subscriptions.Post("/redeem", middleware.DenyImpersonation(), h.RedeemGift)
+	subscriptions.Get("/gifts", h.GetGifts)
+}
+
+func setupSubscriptionAdminRoutes(router fiber.Router, h *handler.SubscriptionHandler) {
+	router.Post("/users/:id/subscriptions", h.Grant)
+}
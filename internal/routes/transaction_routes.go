@@ -10,11 +10,17 @@ import (
 func setupTransactionRoutes(api fiber.Router, h *handler.TransactionHandler, auth *middleware.AuthMiddleware) {
 	transactions := api.Group("/transactions")
 
-	transactions.Post("/webhook", h.MidtransWebhook)
+	transactions.Post("/webhook", middleware.PrimaryDB(), h.MidtransWebhook)
 
 	protected := transactions.Group("", auth.Authenticate())
 
-	protected.Post("", h.CreateTransaction)
+	protected.Post("", middleware.DenyImpersonation(), h.CreateTransaction)
+
+	protected.Post("/addons", middleware.DenyImpersonation(), h.CreateAddonTransaction)
+
+	protected.Post("/gifts", middleware.DenyImpersonation(), h.CreateGiftTransaction)
+
+	protected.Post("/saved-card", middleware.DenyImpersonation(), h.ChargeSavedCard)
 
 	protected.Get("", h.GetUserTransactions)
 
@@ -7,19 +7,33 @@ import (
 	"github.com/gofiber/fiber/v2"
 )
 
-func setupUserRoutes(router fiber.Router, h *handler.UserHandler, authMiddleware *middleware.AuthMiddleware) {
+func setupUserRoutes(router fiber.Router, h *handler.UserHandler, auth *handler.AuthHandler, aiUsage *handler.AIUsageHandler, authMiddleware *middleware.AuthMiddleware) {
 	users := router.Group("/users")
 	users.Use(authMiddleware.Authenticate())
 
 	users.Get("/profile", h.GetProfile)
 	users.Put("/profile", h.Update)
+	users.Get("/me/completeness", h.GetCompleteness)
+	users.Get("/me/onboarding", h.GetOnboarding)
+	users.Get("/me/activity", h.GetActivity)
+	users.Put("/me/2fa", middleware.DenyImpersonation(), h.UpdateTwoFactor)
+	users.Put("/me/timezone", h.UpdateTimezone)
+	users.Post("/me/email", middleware.DenyImpersonation(), h.RequestContactEmailChange)
+	users.Post("/me/email/verify", middleware.DenyImpersonation(), h.VerifyContactEmailChange)
+	users.Delete("/me/email", middleware.DenyImpersonation(), h.ClearContactEmail)
+	users.Get("/me/ai-history", aiUsage.GetMyHistory)
+	users.Get("/me/sessions", h.GetSessions)
+	users.Delete("/me/sessions/:id", middleware.DenyImpersonation(), h.RevokeSession)
+	users.Get("/me/identities", auth.GetIdentities)
+	users.Post("/me/identities/:provider", middleware.DenyImpersonation(), auth.LinkProvider)
+	users.Delete("/me/identities/:id", middleware.DenyImpersonation(), auth.UnlinkIdentity)
 
-	deleteAccount := users.Group("/delete")
+	deleteAccount := users.Group("/delete", middleware.DenyImpersonation())
 	deleteAccount.Post("/request-otp", h.RequestDeleteOTP)
 	deleteAccount.Post("/verify-otp", h.VerifyDeleteOTP)
 	deleteAccount.Post("/resend-otp", h.ResendDeleteOTP)
 
 	users.Get("/", middleware.RequireAdmin(), h.GetAll)
 	users.Get("/:id", middleware.RequireAdmin(), h.GetByID)
-	users.Delete("/:id", middleware.RequireAdmin(), h.Delete)
+	users.Delete("/:id", middleware.RequireAdmin(), middleware.AuditContext(), h.Delete)
 }
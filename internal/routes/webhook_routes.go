@@ -0,0 +1,20 @@
+package routes
+
+import (
+	"github.com/raflytch/careerly-server/internal/handler"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+func setupWebhookRoutes(admin fiber.Router, h *handler.WebhookHandler) {
+	webhooks := admin.Group("/webhooks")
+
+	webhooks.Post("/", h.Create)
+	webhooks.Get("/", h.GetAll)
+	webhooks.Get("/:id", h.GetByID)
+	webhooks.Put("/:id", h.Update)
+	webhooks.Delete("/:id", h.Delete)
+	webhooks.Post("/:id/rotate-secret", h.RotateSecret)
+	webhooks.Get("/:id/deliveries", h.GetDeliveries)
+	webhooks.Post("/:id/deliveries/:deliveryId/redeliver", h.Redeliver)
+}
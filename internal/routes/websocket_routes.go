@@ -0,0 +1,14 @@
+package routes
+
+import (
+	"github.com/raflytch/careerly-server/internal/handler"
+	"github.com/raflytch/careerly-server/internal/middleware"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+func setupWebSocketRoutes(app *fiber.App, interviewHandler *handler.InterviewHandler, auth *middleware.AuthMiddleware) {
+	ws := app.Group("/ws")
+
+	ws.Get("/interviews/:id", auth.AuthenticateWebSocket(), interviewHandler.UpgradeProgress, interviewHandler.StreamProgress())
+}
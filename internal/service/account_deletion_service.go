@@ -0,0 +1,187 @@
+package service
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"log"
+	"time"
+
+	"github.com/raflytch/careerly-server/internal/domain"
+	"github.com/raflytch/careerly-server/pkg/jobqueue"
+	"github.com/raflytch/careerly-server/pkg/storage"
+
+	"github.com/google/uuid"
+)
+
+type accountDeletionService struct {
+	deletionRepo domain.AccountDeletionRepository
+	emailService domain.EmailService
+	jobs         domain.JobScheduler
+	artifacts    *artifactStore
+	videoStorage storage.Storage
+	images       domain.ImageRemover
+	grace        time.Duration
+	noticeLead   time.Duration
+}
+
+func NewAccountDeletionService(
+	deletionRepo domain.AccountDeletionRepository,
+	emailService domain.EmailService,
+	jobs domain.JobScheduler,
+	artifactStorage storage.Storage,
+	regionalStorage map[string]storage.Storage,
+	videoStorage storage.Storage,
+	images domain.ImageRemover,
+	grace time.Duration,
+	noticeLead time.Duration,
+) domain.AccountDeletionService {
+	return &accountDeletionService{
+		deletionRepo: deletionRepo,
+		emailService: emailService,
+		jobs:         jobs,
+		artifacts:    newArtifactStore(nil, artifactStorage, regionalStorage, 0),
+		videoStorage: videoStorage,
+		images:       images,
+		grace:        grace,
+		noticeLead:   noticeLead,
+	}
+}
+
+// Schedule queues the permanent removal of an account the user is deleting
+// and the notice sent ahead of it. No notice is sent when the grace period
+// is shorter than the notice lead.
+func (s *accountDeletionService) Schedule(ctx context.Context, user *domain.User) (*domain.AccountDeletion, error) {
+	now := time.Now().UTC()
+	deletion := &domain.AccountDeletion{
+		UserID:      user.ID,
+		NoticeEmail: contactEmail(user),
+		PurgeAt:     now.Add(s.grace),
+		CreatedAt:   now,
+	}
+	job := domain.AccountPurgeJob{UserID: user.ID}
+
+	purgeJobID, err := s.jobs.EnqueueAt(ctx, domain.JobAccountPurge, job, deletion.PurgeAt)
+	if err != nil {
+		return nil, err
+	}
+	deletion.PurgeJobID = purgeJobID
+
+	if noticeAt := deletion.PurgeAt.Add(-s.noticeLead); s.noticeLead > 0 && noticeAt.After(now) {
+		noticeJobID, err := s.jobs.EnqueueAt(ctx, domain.JobAccountPurgeNotice, job, noticeAt)
+		if err != nil {
+			s.cancelJobs(ctx, deletion)
+			return nil, err
+		}
+		deletion.NoticeJobID = noticeJobID
+	}
+
+	if err := s.deletionRepo.Upsert(ctx, deletion); err != nil {
+		s.cancelJobs(ctx, deletion)
+		return nil, err
+	}
+	return deletion, nil
+}
+
+// Cancel stops the pending removal of a restored account. Dropping the row
+// is what counts: the jobs check for it, so one that could not be recalled
+// does nothing when it runs.
+func (s *accountDeletionService) Cancel(ctx context.Context, userID uuid.UUID) error {
+	deletion, err := s.deletionRepo.FindByUserID(ctx, userID)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil
+		}
+		return err
+	}
+
+	if _, err := s.deletionRepo.Delete(ctx, userID); err != nil {
+		return err
+	}
+	s.cancelJobs(ctx, deletion)
+	return nil
+}
+
+// SendNotice warns the user that their account is about to be removed for
+// good. Accounts restored in the meantime are skipped.
+func (s *accountDeletionService) SendNotice(ctx context.Context, userID uuid.UUID) error {
+	deletion, err := s.deletionRepo.FindByUserID(ctx, userID)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil
+		}
+		return fmt.Errorf("failed to load account deletion: %w", err)
+	}
+	if !deletion.PurgeAt.After(time.Now()) {
+		return nil
+	}
+
+	err = s.emailService.SendAccountPurgeNotice(ctx, deletion.NoticeEmail, deletion.PurgeAt)
+	if errors.Is(err, ErrEmailSuppressed) {
+		return nil
+	}
+	return err
+}
+
+// Purge permanently removes an account whose grace period has ended. Its
+// files go first: once the rows are gone nothing points at them, while a
+// failure before that leaves the account for the job to retry.
+func (s *accountDeletionService) Purge(ctx context.Context, userID uuid.UUID) error {
+	now := time.Now()
+	files, err := s.deletionRepo.FindPurgeFiles(ctx, userID, now)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil
+		}
+		return fmt.Errorf("failed to list account files: %w", err)
+	}
+	if err := s.deleteFiles(ctx, userID, files); err != nil {
+		return err
+	}
+
+	purged, err := s.deletionRepo.Purge(ctx, userID, now)
+	if err != nil {
+		return fmt.Errorf("failed to purge account: %w", err)
+	}
+	if purged {
+		log.Printf("Account deletion: permanently removed user %s", userID)
+	}
+	return nil
+}
+
+func (s *accountDeletionService) deleteFiles(ctx context.Context, userID uuid.UUID, files *domain.AccountFiles) error {
+	for i := range files.Artifacts {
+		if err := s.artifacts.remove(ctx, &files.Artifacts[i]); err != nil {
+			return fmt.Errorf("failed to delete artifact %s: %w", files.Artifacts[i].StorageKey, err)
+		}
+	}
+
+	if s.videoStorage == nil && len(files.VideoIDs) > 0 {
+		log.Printf("Account deletion: video storage not configured, leaving %d video answers of user %s", len(files.VideoIDs), userID)
+	} else {
+		for _, id := range files.VideoIDs {
+			if err := s.videoStorage.Delete(ctx, id); err != nil {
+				return fmt.Errorf("failed to delete video answer %s: %w", id, err)
+			}
+		}
+	}
+
+	for _, url := range files.ImageURLs {
+		if err := s.images.DeleteFileByURL(ctx, url); err != nil {
+			return fmt.Errorf("failed to delete image %s: %w", url, err)
+		}
+	}
+	return nil
+}
+
+func (s *accountDeletionService) cancelJobs(ctx context.Context, deletion *domain.AccountDeletion) {
+	for _, id := range []string{deletion.PurgeJobID, deletion.NoticeJobID} {
+		if id == "" {
+			continue
+		}
+		if err := s.jobs.Cancel(ctx, id); err != nil && !errors.Is(err, jobqueue.ErrJobNotFound) {
+			log.Printf("Account deletion: failed to cancel job %s for user %s: %v", id, deletion.UserID, err)
+		}
+	}
+}
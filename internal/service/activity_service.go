@@ -0,0 +1,56 @@
+package service
+
+import (
+	"context"
+
+	"github.com/raflytch/careerly-server/internal/domain"
+
+	"github.com/google/uuid"
+)
+
+type activityService struct {
+	activityRepo domain.ActivityRepository
+}
+
+func NewActivityService(activityRepo domain.ActivityRepository) domain.ActivityService {
+	return &activityService{activityRepo: activityRepo}
+}
+
+func (s *activityService) GetFeed(ctx context.Context, userID uuid.UUID, page, limit int) (*domain.PaginatedActivities, error) {
+	if page < 1 {
+		page = 1
+	}
+	if limit < 1 {
+		limit = 10
+	}
+	if limit > 100 {
+		limit = 100
+	}
+
+	offset := (page - 1) * limit
+
+	total, err := s.activityRepo.CountByUserID(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	activities, err := s.activityRepo.FindByUserID(ctx, userID, limit, offset)
+	if err != nil {
+		return nil, err
+	}
+
+	totalPages := int(total) / limit
+	if int(total)%limit > 0 {
+		totalPages++
+	}
+
+	return &domain.PaginatedActivities{
+		Activities: activities,
+		Pagination: domain.Pagination{
+			Page:       page,
+			Limit:      limit,
+			Total:      total,
+			TotalPages: totalPages,
+		},
+	}, nil
+}
@@ -0,0 +1,171 @@
+package service
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"time"
+
+	"github.com/raflytch/careerly-server/internal/domain"
+
+	"github.com/google/uuid"
+)
+
+var (
+	ErrAddonNotFound     = errors.New("add-on not found")
+	ErrAddonNameExists   = errors.New("add-on name already exists")
+	ErrAddonNotAvailable = errors.New("add-on is not available for purchase")
+	ErrAddonNotNeeded    = errors.New("current plan already has unlimited usage for this feature")
+)
+
+type addonService struct {
+	addonRepo    domain.AddonRepository
+	auditService domain.AuditService
+}
+
+func NewAddonService(addonRepo domain.AddonRepository, auditService domain.AuditService) domain.AddonService {
+	return &addonService{
+		addonRepo:    addonRepo,
+		auditService: auditService,
+	}
+}
+
+func (s *addonService) Create(ctx context.Context, req *domain.CreateAddonRequest) (*domain.Addon, error) {
+	existing, _ := s.addonRepo.FindByName(ctx, req.Name)
+	if existing != nil {
+		return nil, ErrAddonNameExists
+	}
+
+	isActive := true
+	if req.IsActive != nil {
+		isActive = *req.IsActive
+	}
+
+	addon := &domain.Addon{
+		ID:          uuid.New(),
+		Name:        req.Name,
+		DisplayName: req.DisplayName,
+		Feature:     req.Feature,
+		Quantity:    req.Quantity,
+		Price:       req.Price,
+		IsActive:    isActive,
+		CreatedAt:   time.Now(),
+	}
+
+	if err := s.addonRepo.Create(ctx, addon); err != nil {
+		return nil, err
+	}
+
+	s.auditService.Record(ctx, domain.AuditActionAddonCreate, domain.AuditTargetAddon, addon.ID, nil, addon)
+
+	return addon, nil
+}
+
+func (s *addonService) GetByID(ctx context.Context, id uuid.UUID) (*domain.Addon, error) {
+	addon, err := s.addonRepo.FindByID(ctx, id)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, ErrAddonNotFound
+		}
+		return nil, err
+	}
+	return addon, nil
+}
+
+func (s *addonService) GetAll(ctx context.Context, page, limit int, includeInactive bool) (*domain.PaginatedAddons, error) {
+	if page < 1 {
+		page = 1
+	}
+	if limit < 1 {
+		limit = 10
+	}
+	if limit > 100 {
+		limit = 100
+	}
+
+	offset := (page - 1) * limit
+
+	total, err := s.addonRepo.Count(ctx, includeInactive)
+	if err != nil {
+		return nil, err
+	}
+
+	addons, err := s.addonRepo.FindAll(ctx, limit, offset, includeInactive)
+	if err != nil {
+		return nil, err
+	}
+
+	totalPages := int(total) / limit
+	if int(total)%limit > 0 {
+		totalPages++
+	}
+
+	return &domain.PaginatedAddons{
+		Addons: addons,
+		Pagination: domain.Pagination{
+			Page:       page,
+			Limit:      limit,
+			Total:      total,
+			TotalPages: totalPages,
+		},
+	}, nil
+}
+
+func (s *addonService) Update(ctx context.Context, id uuid.UUID, req *domain.UpdateAddonRequest) (*domain.Addon, error) {
+	addon, err := s.addonRepo.FindByID(ctx, id)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, ErrAddonNotFound
+		}
+		return nil, err
+	}
+
+	before := *addon
+
+	if req.Name != nil && *req.Name != addon.Name {
+		existing, _ := s.addonRepo.FindByName(ctx, *req.Name)
+		if existing != nil {
+			return nil, ErrAddonNameExists
+		}
+		addon.Name = *req.Name
+	}
+
+	if req.DisplayName != nil {
+		addon.DisplayName = *req.DisplayName
+	}
+	if req.Quantity != nil {
+		addon.Quantity = *req.Quantity
+	}
+	if req.Price != nil {
+		addon.Price = *req.Price
+	}
+	if req.IsActive != nil {
+		addon.IsActive = *req.IsActive
+	}
+
+	if err := s.addonRepo.Update(ctx, addon); err != nil {
+		return nil, err
+	}
+
+	s.auditService.Record(ctx, domain.AuditActionAddonUpdate, domain.AuditTargetAddon, id, before, addon)
+
+	return addon, nil
+}
+
+func (s *addonService) Delete(ctx context.Context, id uuid.UUID) error {
+	addon, err := s.addonRepo.FindByID(ctx, id)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return ErrAddonNotFound
+		}
+		return err
+	}
+
+	if err := s.addonRepo.SoftDelete(ctx, id); err != nil {
+		return err
+	}
+
+	s.auditService.Record(ctx, domain.AuditActionAddonDelete, domain.AuditTargetAddon, id, addon, nil)
+
+	return nil
+}
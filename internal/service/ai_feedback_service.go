@@ -0,0 +1,168 @@
+package service
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"math"
+	"time"
+
+	"github.com/raflytch/careerly-server/internal/domain"
+
+	"github.com/google/uuid"
+)
+
+var (
+	ErrUnknownAIFeedbackFeature = errors.New("unknown ai feedback feature")
+	ErrAIFeedbackNotReady       = errors.New("there is no ai output to rate yet")
+)
+
+var aiFeedbackPrompts = map[domain.AIFeedbackFeature]domain.PromptKey{
+	domain.AIFeedbackResumeEnhancement: domain.PromptResumeRewrite,
+	domain.AIFeedbackInterviewFeedback: domain.PromptInterviewEvaluation,
+	domain.AIFeedbackATSAnalysis:       domain.PromptATSAnalysis,
+}
+
+type aiFeedbackService struct {
+	feedbackRepo  domain.AIFeedbackRepository
+	promptRepo    domain.PromptRepository
+	resumeRepo    domain.ResumeRepository
+	interviewRepo domain.InterviewRepository
+	atsCheckRepo  domain.ATSCheckRepository
+}
+
+func NewAIFeedbackService(
+	feedbackRepo domain.AIFeedbackRepository,
+	promptRepo domain.PromptRepository,
+	resumeRepo domain.ResumeRepository,
+	interviewRepo domain.InterviewRepository,
+	atsCheckRepo domain.ATSCheckRepository,
+) domain.AIFeedbackService {
+	return &aiFeedbackService{
+		feedbackRepo:  feedbackRepo,
+		promptRepo:    promptRepo,
+		resumeRepo:    resumeRepo,
+		interviewRepo: interviewRepo,
+		atsCheckRepo:  atsCheckRepo,
+	}
+}
+
+// Submit stores the user's rating of an AI output they own, attributed to
+// the prompt version that produced it. Outputs generated before prompt
+// versions were tracked are stored without one.
+func (s *aiFeedbackService) Submit(ctx context.Context, userID uuid.UUID, feature domain.AIFeedbackFeature, entityID uuid.UUID, req *domain.AIFeedbackRequest) (*domain.AIFeedback, error) {
+	promptKey, ok := aiFeedbackPrompts[feature]
+	if !ok {
+		return nil, ErrUnknownAIFeedbackFeature
+	}
+
+	if err := s.checkOwnership(ctx, userID, feature, entityID); err != nil {
+		return nil, err
+	}
+
+	var promptVersion *int
+	version, err := s.promptRepo.FindUsage(ctx, promptKey, entityID)
+	if err == nil {
+		promptVersion = &version
+	} else if !errors.Is(err, sql.ErrNoRows) {
+		return nil, fmt.Errorf("failed to fetch prompt version: %w", err)
+	}
+
+	now := time.Now()
+	feedback := &domain.AIFeedback{
+		ID:            uuid.New(),
+		UserID:        userID,
+		Feature:       feature,
+		EntityID:      entityID,
+		Rating:        req.Rating,
+		Comment:       req.Comment,
+		PromptVersion: promptVersion,
+		CreatedAt:     now,
+		UpdatedAt:     now,
+	}
+	if err := s.feedbackRepo.Upsert(ctx, feedback); err != nil {
+		return nil, fmt.Errorf("failed to save feedback: %w", err)
+	}
+
+	return feedback, nil
+}
+
+func (s *aiFeedbackService) GetQualityReport(ctx context.Context, from, to time.Time) (*domain.AIQualityReport, error) {
+	byFeature, err := s.feedbackRepo.Summarize(ctx, from, to, false)
+	if err != nil {
+		return nil, err
+	}
+
+	byPromptVersion, err := s.feedbackRepo.Summarize(ctx, from, to, true)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, summaries := range [][]domain.AIFeedbackSummary{byFeature, byPromptVersion} {
+		for i := range summaries {
+			if summaries[i].Ratings > 0 {
+				satisfaction := float64(summaries[i].ThumbsUp) / float64(summaries[i].Ratings) * 100
+				summaries[i].Satisfaction = math.Round(satisfaction*10) / 10
+			}
+		}
+	}
+
+	return &domain.AIQualityReport{
+		From:            from,
+		To:              to,
+		ByFeature:       byFeature,
+		ByPromptVersion: byPromptVersion,
+	}, nil
+}
+
+func (s *aiFeedbackService) checkOwnership(ctx context.Context, userID uuid.UUID, feature domain.AIFeedbackFeature, entityID uuid.UUID) error {
+	switch feature {
+	case domain.AIFeedbackResumeEnhancement:
+		resume, err := s.resumeRepo.FindByID(ctx, entityID)
+		if err != nil {
+			if errors.Is(err, sql.ErrNoRows) {
+				return ErrResumeNotFound
+			}
+			return err
+		}
+		if resume.UserID != userID {
+			return ErrUnauthorized
+		}
+	case domain.AIFeedbackInterviewFeedback:
+		interview, err := s.interviewRepo.FindByID(ctx, entityID)
+		if err != nil {
+			if errors.Is(err, sql.ErrNoRows) {
+				return ErrInterviewNotFound
+			}
+			return err
+		}
+		if interview.UserID != userID {
+			return ErrInterviewUnauthorized
+		}
+		if !hasEvaluatedAnswer(interview) {
+			return ErrAIFeedbackNotReady
+		}
+	case domain.AIFeedbackATSAnalysis:
+		check, err := s.atsCheckRepo.FindByID(ctx, entityID)
+		if err != nil {
+			if errors.Is(err, sql.ErrNoRows) {
+				return ErrATSCheckNotFound
+			}
+			return err
+		}
+		if check.UserID != userID {
+			return ErrATSCheckUnauthorized
+		}
+	}
+	return nil
+}
+
+func hasEvaluatedAnswer(interview *domain.Interview) bool {
+	for _, q := range interview.Questions {
+		if q.Feedback != "" || q.Score != nil || q.IsCorrect != nil {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,15 @@
+package service
+
+import (
+	"github.com/raflytch/careerly-server/internal/domain"
+	"github.com/raflytch/careerly-server/pkg/genai"
+)
+
+// Response schemas for the AI calls whose JSON is unmarshalled straight
+// into these types, so the model cannot answer in a shape they reject.
+var (
+	interviewQuestionsSchema  = genai.RegisterSchema("interview_questions", []generatedQuestion{})
+	interviewEvaluationSchema = genai.RegisterSchema("interview_evaluation", []evaluationResult{})
+	atsAnalysisSchema         = genai.RegisterSchema("ats_analysis", domain.ATSAnalysis{})
+	resumeContentSchema       = genai.RegisterSchema("resume_content", domain.ResumeContent{})
+)
@@ -0,0 +1,51 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"log"
+
+	"github.com/raflytch/careerly-server/internal/config"
+	"github.com/raflytch/careerly-server/internal/domain"
+
+	"github.com/google/uuid"
+)
+
+var ErrAIConcurrencyLimit = errors.New("too many ai requests in progress, please wait for one to finish")
+
+// aiSlots caps the AI work a user has in flight when that work runs in the
+// background. The AIConcurrency middleware gives its slot back once the
+// response is sent, so it cannot cover a job that only starts then.
+type aiSlots struct {
+	limiter domain.ConcurrencyLimiter
+	plans   domain.QuotaService
+	cfg     config.AILimitConfig
+}
+
+// acquire takes one of the user's slots, the higher limit going to paid
+// plans as in the middleware. release must be called when the job ends.
+// When Redis cannot be reached the job is let through.
+func (a aiSlots) acquire(ctx context.Context, userID uuid.UUID) (release func(), err error) {
+	noop := func() {}
+	if a.limiter == nil {
+		return noop, nil
+	}
+
+	limit := a.cfg.FreeConcurrent
+	if plan, err := a.plans.GetActivePlan(ctx, userID); err == nil && plan != nil && plan.Price.IsPositive() {
+		limit = a.cfg.PaidConcurrent
+	}
+	if limit <= 0 {
+		return noop, nil
+	}
+
+	release, acquired, err := a.limiter.Acquire(ctx, userID.String(), limit)
+	if err != nil {
+		log.Printf("AI concurrency check failed for user %s: %v", userID, err)
+		return noop, nil
+	}
+	if !acquired {
+		return nil, ErrAIConcurrencyLimit
+	}
+	return release, nil
+}
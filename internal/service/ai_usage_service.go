@@ -0,0 +1,291 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"time"
+
+	"github.com/raflytch/careerly-server/internal/config"
+	"github.com/raflytch/careerly-server/internal/domain"
+	"github.com/raflytch/careerly-server/pkg/genai"
+
+	"github.com/google/uuid"
+	"github.com/shopspring/decimal"
+)
+
+const (
+	aiMonthCostCachePrefix   = "ai_usage:month_cost:"
+	aiMonthCostCacheDuration = 1 * time.Minute
+	aiUsageRecordTimeout     = 5 * time.Second
+)
+
+var (
+	ErrAIBudgetExceeded = errors.New("monthly AI budget exceeded")
+)
+
+var tokensPerMillion = decimal.NewFromInt(1_000_000)
+
+type aiUsageService struct {
+	aiUsageRepo          domain.AIUsageRepository
+	cacheRepo            domain.CacheRepository
+	inputCostPerMillion  decimal.Decimal
+	outputCostPerMillion decimal.Decimal
+	monthlyBudget        decimal.Decimal
+}
+
+func NewAIUsageService(aiUsageRepo domain.AIUsageRepository, cacheRepo domain.CacheRepository, cfg config.AIBudgetConfig) domain.AIUsageService {
+	return &aiUsageService{
+		aiUsageRepo:          aiUsageRepo,
+		cacheRepo:            cacheRepo,
+		inputCostPerMillion:  decimal.NewFromFloat(cfg.InputCostPerMillion),
+		outputCostPerMillion: decimal.NewFromFloat(cfg.OutputCostPerMillion),
+		monthlyBudget:        decimal.NewFromFloat(cfg.MonthlyBudget),
+	}
+}
+
+func (s *aiUsageService) Record(ctx context.Context, usage *domain.AIUsage) error {
+	if usage.ID == uuid.Nil {
+		usage.ID = uuid.New()
+	}
+	if usage.CreatedAt.IsZero() {
+		usage.CreatedAt = time.Now()
+	}
+	usage.EstimatedCost = s.estimateCost(usage.PromptTokens, usage.CompletionTokens)
+
+	if err := s.aiUsageRepo.Create(ctx, usage); err != nil {
+		return err
+	}
+
+	_ = s.cacheRepo.Delete(ctx, s.monthCostCacheKey(usage.CreatedAt))
+
+	return nil
+}
+
+func (s *aiUsageService) CheckBudget(ctx context.Context) error {
+	if !s.monthlyBudget.IsPositive() {
+		return nil
+	}
+
+	spent, err := s.monthToDateCost(ctx)
+	if err != nil {
+		// Failing open keeps AI features available when reporting is degraded.
+		return nil
+	}
+
+	if spent.GreaterThanOrEqual(s.monthlyBudget) {
+		return ErrAIBudgetExceeded
+	}
+	return nil
+}
+
+func (s *aiUsageService) GetReport(ctx context.Context, from, to time.Time) (*domain.AIUsageReport, error) {
+	summaries, err := s.aiUsageRepo.SummarizeByFeature(ctx, from, to)
+	if err != nil {
+		return nil, err
+	}
+
+	totals := domain.AIUsageSummary{}
+	var latencyWeighted float64
+	for _, summary := range summaries {
+		totals.Calls += summary.Calls
+		totals.FailedCalls += summary.FailedCalls
+		totals.PromptTokens += summary.PromptTokens
+		totals.CompletionTokens += summary.CompletionTokens
+		totals.TotalTokens += summary.TotalTokens
+		totals.EstimatedCost = totals.EstimatedCost.Add(summary.EstimatedCost)
+		latencyWeighted += summary.AvgLatencyMs * float64(summary.Calls)
+	}
+	if totals.Calls > 0 {
+		totals.AvgLatencyMs = latencyWeighted / float64(totals.Calls)
+	}
+
+	monthCost, err := s.monthToDateCost(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	return &domain.AIUsageReport{
+		From:            from,
+		To:              to,
+		Totals:          totals,
+		ByFeature:       summaries,
+		MonthToDateCost: monthCost,
+		MonthlyBudget:   s.monthlyBudget,
+		BudgetExceeded:  s.monthlyBudget.IsPositive() && monthCost.GreaterThanOrEqual(s.monthlyBudget),
+	}, nil
+}
+
+func (s *aiUsageService) GetLogs(ctx context.Context, feature string, page, limit int) (*domain.PaginatedAIUsage, error) {
+	if page < 1 {
+		page = 1
+	}
+	if limit < 1 {
+		limit = 10
+	}
+	if limit > 100 {
+		limit = 100
+	}
+
+	offset := (page - 1) * limit
+
+	total, err := s.aiUsageRepo.Count(ctx, feature)
+	if err != nil {
+		return nil, err
+	}
+
+	logs, err := s.aiUsageRepo.FindAll(ctx, feature, limit, offset)
+	if err != nil {
+		return nil, err
+	}
+
+	totalPages := int(total) / limit
+	if int(total)%limit > 0 {
+		totalPages++
+	}
+
+	return &domain.PaginatedAIUsage{
+		Logs: logs,
+		Pagination: domain.Pagination{
+			Page:       page,
+			Limit:      limit,
+			Total:      total,
+			TotalPages: totalPages,
+		},
+	}, nil
+}
+
+func (s *aiUsageService) GetUserHistory(ctx context.Context, userID uuid.UUID, feature string, page, limit int) (*domain.PaginatedAIHistory, error) {
+	if page < 1 {
+		page = 1
+	}
+	if limit < 1 {
+		limit = 10
+	}
+	if limit > 100 {
+		limit = 100
+	}
+
+	offset := (page - 1) * limit
+
+	total, err := s.aiUsageRepo.CountByUserID(ctx, userID, feature)
+	if err != nil {
+		return nil, err
+	}
+
+	logs, err := s.aiUsageRepo.FindByUserID(ctx, userID, feature, limit, offset)
+	if err != nil {
+		return nil, err
+	}
+
+	entries := make([]domain.AIHistoryEntry, 0, len(logs))
+	for _, usage := range logs {
+		status := domain.AIHistoryStatusSuccess
+		if !usage.Success {
+			status = domain.AIHistoryStatusFailed
+		}
+		entries = append(entries, domain.AIHistoryEntry{
+			ID:        usage.ID,
+			Feature:   usage.Feature,
+			Status:    status,
+			QuotaCost: usage.QuotaCost,
+			LatencyMs: usage.LatencyMs,
+			CreatedAt: usage.CreatedAt,
+		})
+	}
+
+	totalPages := int(total) / limit
+	if int(total)%limit > 0 {
+		totalPages++
+	}
+
+	return &domain.PaginatedAIHistory{
+		Entries: entries,
+		Pagination: domain.Pagination{
+			Page:       page,
+			Limit:      limit,
+			Total:      total,
+			TotalPages: totalPages,
+		},
+	}, nil
+}
+
+func (s *aiUsageService) estimateCost(promptTokens, completionTokens int) decimal.Decimal {
+	input := decimal.NewFromInt(int64(promptTokens)).Mul(s.inputCostPerMillion)
+	output := decimal.NewFromInt(int64(completionTokens)).Mul(s.outputCostPerMillion)
+	return input.Add(output).Div(tokensPerMillion)
+}
+
+func (s *aiUsageService) monthToDateCost(ctx context.Context) (decimal.Decimal, error) {
+	now := time.Now().UTC()
+	cacheKey := s.monthCostCacheKey(now)
+
+	cached, err := s.cacheRepo.Get(ctx, cacheKey)
+	if err == nil && cached != "" {
+		var cost decimal.Decimal
+		if err := cost.UnmarshalJSON([]byte(cached)); err == nil {
+			return cost, nil
+		}
+	}
+
+	monthStart := time.Date(now.Year(), now.Month(), 1, 0, 0, 0, 0, time.UTC)
+	cost, err := s.aiUsageRepo.SumCostSince(ctx, monthStart)
+	if err != nil {
+		return decimal.Zero, err
+	}
+
+	_ = s.cacheRepo.Set(ctx, cacheKey, cost, aiMonthCostCacheDuration)
+
+	return cost, nil
+}
+
+func (s *aiUsageService) monthCostCacheKey(t time.Time) string {
+	return fmt.Sprintf("%s%s", aiMonthCostCachePrefix, t.UTC().Format("2006-01"))
+}
+
+type genaiUsageHook struct {
+	aiUsageService domain.AIUsageService
+}
+
+// NewGenAIUsageHook adapts the usage service to the genai client hook so
+// every model call is budget-checked before it runs and logged afterwards.
+func NewGenAIUsageHook(aiUsageService domain.AIUsageService) genai.UsageHook {
+	return &genaiUsageHook{aiUsageService: aiUsageService}
+}
+
+func (h *genaiUsageHook) BeforeCall(ctx context.Context, meta genai.CallMetadata) error {
+	if err := h.aiUsageService.CheckBudget(ctx); err != nil {
+		return fmt.Errorf("%w: %v", genai.ErrBudgetExceeded, err)
+	}
+	return nil
+}
+
+func (h *genaiUsageHook) AfterCall(ctx context.Context, record genai.UsageRecord) {
+	usage := &domain.AIUsage{
+		Feature:          record.Feature,
+		Model:            record.Model,
+		PromptTokens:     int(record.PromptTokens),
+		CompletionTokens: int(record.CompletionTokens),
+		TotalTokens:      int(record.TotalTokens),
+		LatencyMs:        record.Latency.Milliseconds(),
+		QuotaCost:        record.QuotaCost,
+		Success:          record.Success,
+	}
+	if userID, err := uuid.Parse(record.UserID); err == nil {
+		usage.UserID = &userID
+	}
+	if record.Error != "" {
+		errMsg := record.Error
+		usage.ErrorMessage = &errMsg
+	}
+
+	go func() {
+		recordCtx, cancel := context.WithTimeout(context.WithoutCancel(ctx), aiUsageRecordTimeout)
+		defer cancel()
+
+		if err := h.aiUsageService.Record(recordCtx, usage); err != nil {
+			log.Printf("Failed to record AI usage: %v", err)
+		}
+	}()
+}
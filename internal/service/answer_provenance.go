@@ -0,0 +1,164 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log"
+	"unicode/utf8"
+
+	"github.com/raflytch/careerly-server/internal/domain"
+)
+
+const (
+	provenanceSourceAI        = "ai"
+	provenanceSourceTelemetry = "telemetry"
+
+	// An answer mostly made of pasted text, or entered faster than anyone
+	// types, is flagged even when the AI check is unavailable.
+	pastedShareThreshold    = 0.8
+	maxTypingCharsPerSecond = 15.0
+)
+
+const detectProvenancePrompt = `You are reviewing essay answers from a practice interview for a %s position and judging whether each answer was written by the candidate.
+
+Here are the questions, the candidate's answers and client telemetry (typing time in milliseconds, number of paste events and pasted characters; telemetry may be missing):
+%s
+
+For each answer decide one verdict:
+- "original": reads like the candidate's own words
+- "likely_ai_generated": generic, overly polished or structured like AI assistant output
+- "likely_plagiarized": appears copied from documentation, articles or other published text
+
+Respond ONLY with valid JSON array in this exact format:
+[
+  {
+    "question_id": 1,
+    "verdict": "original",
+    "confidence": 0.8,
+    "reason": "One sentence explaining the verdict"
+  }
+]
+
+Review now:`
+
+type provenanceResult struct {
+	QuestionID int                            `json:"question_id"`
+	Verdict    domain.AnswerProvenanceVerdict `json:"verdict"`
+	Confidence float64                        `json:"confidence"`
+	Reason     string                         `json:"reason"`
+}
+
+// assessProvenance records a provenance verdict on each answered essay
+// question, mentioning anything other than an original verdict in the
+// question's feedback. Telemetry alone is used when the AI check fails.
+func (s *interviewService) assessProvenance(ctx context.Context, jobPosition string, questions []*domain.Question) {
+	candidates := make([]*domain.Question, 0, len(questions))
+	for _, q := range questions {
+		if q.Type == domain.QuestionTypeEssay && q.UserAnswer != "" {
+			candidates = append(candidates, q)
+		}
+	}
+	if len(candidates) == 0 {
+		return
+	}
+
+	verdicts, err := s.detectProvenance(ctx, jobPosition, candidates)
+	if err != nil {
+		log.Printf("Answer provenance check failed: %v", err)
+	}
+
+	for _, q := range candidates {
+		provenance := telemetryProvenance(q)
+		if v, ok := verdicts[q.ID]; ok && (provenance == nil || v.Verdict != domain.AnswerProvenanceOriginal) {
+			provenance = &domain.AnswerProvenance{
+				Verdict:    v.Verdict,
+				Confidence: v.Confidence,
+				Reason:     v.Reason,
+				Source:     provenanceSourceAI,
+			}
+		}
+		if provenance == nil {
+			continue
+		}
+
+		q.Provenance = provenance
+		if provenance.Verdict != domain.AnswerProvenanceOriginal && provenance.Reason != "" {
+			q.Feedback = fmt.Sprintf("%s\n\nIntegrity check: %s", q.Feedback, provenance.Reason)
+		}
+	}
+}
+
+func (s *interviewService) detectProvenance(ctx context.Context, jobPosition string, questions []*domain.Question) (map[int]provenanceResult, error) {
+	if s.aiClient == nil {
+		return nil, nil
+	}
+
+	answers := make([]map[string]interface{}, 0, len(questions))
+	for _, q := range questions {
+		answer := map[string]interface{}{
+			"id":       q.ID,
+			"question": q.Question,
+			"answer":   q.UserAnswer,
+		}
+		if q.Telemetry != nil {
+			answer["telemetry"] = q.Telemetry
+		}
+		answers = append(answers, answer)
+	}
+
+	answersJSON, err := json.Marshal(answers)
+	if err != nil {
+		return nil, err
+	}
+
+	result, err := s.aiClient.GenerateJSON(ctx, fmt.Sprintf(detectProvenancePrompt, jobPosition, string(answersJSON)))
+	if err != nil {
+		return nil, err
+	}
+
+	var results []provenanceResult
+	if err := json.Unmarshal([]byte(result), &results); err != nil {
+		return nil, err
+	}
+
+	verdicts := make(map[int]provenanceResult, len(results))
+	for _, r := range results {
+		switch r.Verdict {
+		case domain.AnswerProvenanceOriginal, domain.AnswerProvenanceLikelyAIGenerated, domain.AnswerProvenanceLikelyPlagiarized:
+			verdicts[r.QuestionID] = r
+		}
+	}
+	return verdicts, nil
+}
+
+// telemetryProvenance flags answers whose client telemetry shows they were
+// mostly pasted or entered implausibly fast. It returns nil when there is
+// no telemetry or nothing stands out.
+func telemetryProvenance(q *domain.Question) *domain.AnswerProvenance {
+	t := q.Telemetry
+	length := utf8.RuneCountInString(q.UserAnswer)
+	if t == nil || length == 0 {
+		return nil
+	}
+
+	if float64(t.PastedChars) >= pastedShareThreshold*float64(length) {
+		return &domain.AnswerProvenance{
+			Verdict:    domain.AnswerProvenanceSuspicious,
+			Confidence: 0.7,
+			Reason:     "most of this answer was pasted in rather than typed",
+			Source:     provenanceSourceTelemetry,
+		}
+	}
+
+	if t.TypingDurationMs > 0 && float64(length)/(float64(t.TypingDurationMs)/1000) > maxTypingCharsPerSecond {
+		return &domain.AnswerProvenance{
+			Verdict:    domain.AnswerProvenanceSuspicious,
+			Confidence: 0.6,
+			Reason:     "this answer was entered faster than a person can type",
+			Source:     provenanceSourceTelemetry,
+		}
+	}
+
+	return nil
+}
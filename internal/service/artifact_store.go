@@ -0,0 +1,158 @@
+package service
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"log"
+	"time"
+
+	"github.com/raflytch/careerly-server/internal/domain"
+	"github.com/raflytch/careerly-server/pkg/storage"
+
+	"github.com/google/uuid"
+	"golang.org/x/sync/singleflight"
+)
+
+var ErrArtifactStorageDisabled = errors.New("artifact storage is not configured")
+
+// artifactStore keeps generated files in object storage, one per entity,
+// along with the version of the source they were rendered from. A file is
+// only rendered again once the caller reports a different version, and is
+// always handed out through a short-lived signed URL. Files of an entity
+// pinned to a data region are kept in that region's storage.
+type artifactStore struct {
+	repo     domain.ArtifactRepository
+	storage  storage.Storage
+	regional map[string]storage.Storage
+	ttl      time.Duration
+	group    singleflight.Group
+}
+
+// newArtifactStore returns nil when store is missing or cannot sign URLs,
+// which callers treat as artifact storage being disabled.
+func newArtifactStore(repo domain.ArtifactRepository, store storage.Storage, regional map[string]storage.Storage, ttl time.Duration) *artifactStore {
+	if store == nil {
+		return nil
+	}
+	if _, ok := store.(storage.URLSigner); !ok {
+		return nil
+	}
+	return &artifactStore{repo: repo, storage: store, regional: regional, ttl: ttl}
+}
+
+// storageFor returns the storage of region, the default one for an empty
+// region.
+func (a *artifactStore) storageFor(region string) (storage.Storage, storage.URLSigner, error) {
+	store := a.storage
+	if region != "" {
+		store = a.regional[region]
+	}
+	if store == nil {
+		return nil, nil, ErrArtifactStorageDisabled
+	}
+	signer, ok := store.(storage.URLSigner)
+	if !ok {
+		return nil, nil, ErrArtifactStorageDisabled
+	}
+	return store, signer, nil
+}
+
+func (a *artifactStore) link(
+	ctx context.Context,
+	entityType string,
+	entityID uuid.UUID,
+	region, version, ext, contentType string,
+	render func(ctx context.Context) ([]byte, error),
+) (*domain.ArtifactLink, error) {
+	store, signer, err := a.storageFor(region)
+	if err != nil {
+		return nil, err
+	}
+
+	artifact, err := a.repo.Find(ctx, entityType, entityID)
+	if err != nil && !errors.Is(err, sql.ErrNoRows) {
+		return nil, fmt.Errorf("failed to fetch artifact: %w", err)
+	}
+
+	if artifact == nil || artifact.Version != version || artifact.Driver != store.Driver() || artifact.Region != region {
+		key := fmt.Sprintf("artifacts/%s/%s/%s%s", entityType, entityID, version, ext)
+		v, err, _ := a.group.Do(region+"/"+key, func() (interface{}, error) {
+			return a.store(ctx, store, artifact, key, entityType, entityID, region, version, contentType, render)
+		})
+		if err != nil {
+			return nil, err
+		}
+		artifact = v.(*domain.Artifact)
+	}
+
+	expiresAt := time.Now().Add(a.ttl)
+	url, err := signer.SignedURL(ctx, artifact.StorageKey, a.ttl)
+	if err != nil {
+		return nil, fmt.Errorf("failed to sign artifact url: %w", err)
+	}
+
+	return &domain.ArtifactLink{URL: url, ExpiresAt: expiresAt}, nil
+}
+
+func (a *artifactStore) store(
+	ctx context.Context,
+	store storage.Storage,
+	previous *domain.Artifact,
+	key, entityType string,
+	entityID uuid.UUID,
+	region, version, contentType string,
+	render func(ctx context.Context) ([]byte, error),
+) (*domain.Artifact, error) {
+	data, err := render(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	object, err := store.Put(ctx, key, data, contentType)
+	if err != nil {
+		return nil, fmt.Errorf("failed to store artifact: %w", err)
+	}
+
+	artifact := &domain.Artifact{
+		EntityType:  entityType,
+		EntityID:    entityID,
+		Version:     version,
+		Driver:      store.Driver(),
+		Region:      region,
+		StorageKey:  object.ID,
+		ContentType: contentType,
+		Size:        object.Size,
+		CreatedAt:   time.Now(),
+	}
+	if err := a.repo.Save(ctx, artifact); err != nil {
+		return nil, fmt.Errorf("failed to save artifact: %w", err)
+	}
+
+	if previous != nil && (previous.Region != artifact.Region || previous.StorageKey != artifact.StorageKey) {
+		a.deleteOutdated(ctx, previous)
+	}
+
+	return artifact, nil
+}
+
+// deleteOutdated removes a replaced file.
+func (a *artifactStore) deleteOutdated(ctx context.Context, previous *domain.Artifact) {
+	if err := a.remove(ctx, previous); err != nil {
+		log.Printf("Failed to delete outdated artifact %s: %v", previous.StorageKey, err)
+	}
+}
+
+// remove deletes the file of artifact, as long as the storage it was put
+// in is still the one configured for its region.
+func (a *artifactStore) remove(ctx context.Context, artifact *domain.Artifact) error {
+	if a == nil {
+		return nil
+	}
+	store, _, err := a.storageFor(artifact.Region)
+	if err != nil || store.Driver() != artifact.Driver {
+		return nil
+	}
+	return store.Delete(ctx, artifact.StorageKey)
+}
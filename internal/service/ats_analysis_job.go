@@ -0,0 +1,106 @@
+package service
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log"
+	"time"
+
+	"github.com/raflytch/careerly-server/internal/domain"
+	"github.com/raflytch/careerly-server/pkg/genai"
+
+	"github.com/google/uuid"
+)
+
+var ErrATSAnalysisNotFound = errors.New("ats analysis not found")
+
+const (
+	atsAnalysisJobPrefix   = "ats:analysis:"
+	atsAnalysisJobDuration = 24 * time.Hour
+	atsAnalysisTimeout     = 3 * time.Minute
+)
+
+// GetAnalysisJob returns where an upload stands. Once the job has expired
+// from the cache, a saved check is reported as done.
+func (s *atsCheckService) GetAnalysisJob(ctx context.Context, userID uuid.UUID, id uuid.UUID) (*domain.ATSAnalysisJob, error) {
+	cached, err := s.cacheRepo.Get(ctx, atsAnalysisJobKey(id))
+	if err == nil && cached != "" {
+		var job domain.ATSAnalysisJob
+		if err := json.Unmarshal([]byte(cached), &job); err == nil {
+			if job.UserID != userID {
+				return nil, ErrATSCheckUnauthorized
+			}
+			return &job, nil
+		}
+	}
+
+	check, err := s.atsCheckRepo.FindByID(ctx, id)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, ErrATSAnalysisNotFound
+		}
+		return nil, err
+	}
+
+	if check.UserID != userID {
+		return nil, ErrATSCheckUnauthorized
+	}
+
+	return &domain.ATSAnalysisJob{
+		ID:        check.ID,
+		UserID:    check.UserID,
+		Status:    domain.ATSAnalysisDone,
+		Result:    &domain.ATSCheckResponse{ATSCheck: check},
+		UpdatedAt: check.CreatedAt,
+	}, nil
+}
+
+// runFileAnalysis scores an upload in the background. A failed AI call still
+// saves the fallback analysis, as the synchronous endpoints do; the job only
+// fails when the check cannot be saved.
+func (s *atsCheckService) runFileAnalysis(job *domain.ATSAnalysisJob, file genai.File, data []byte) {
+	ctx, cancel := context.WithTimeout(context.Background(), atsAnalysisTimeout)
+	defer cancel()
+
+	s.advanceAnalysisJob(ctx, job, domain.ATSAnalysisAnalyzing)
+
+	aiCtx := genai.WithQuotaCost(genai.WithCallMetadata(ctx, domain.AIFeatureATSAnalysis, job.UserID.String()), 1)
+	analysis, promptVersion, err := s.analyzeFile(aiCtx, job.UserID, file)
+
+	s.advanceAnalysisJob(ctx, job, domain.ATSAnalysisScoring)
+
+	result, err := s.recordCheck(ctx, job.ID, job.UserID, nil, analysis, promptVersion, err)
+	if err != nil {
+		log.Printf("Failed to save ats analysis %s: %v", job.ID, err)
+		job.Error = "failed to save analysis, please upload again"
+		s.advanceAnalysisJob(ctx, job, domain.ATSAnalysisFailed)
+		return
+	}
+
+	if result.AIAnalysisStatus == "success" {
+		s.saveSource(ctx, job.ID, data)
+	}
+
+	job.Result = result
+	s.advanceAnalysisJob(ctx, job, domain.ATSAnalysisDone)
+}
+
+// advanceAnalysisJob mirrors the job in the cache before publishing, so a
+// client that subscribes late or on another instance reads the same state.
+func (s *atsCheckService) advanceAnalysisJob(ctx context.Context, job *domain.ATSAnalysisJob, status domain.ATSAnalysisStatus) {
+	job.Status = status
+	job.UpdatedAt = time.Now()
+	s.saveAnalysisJob(ctx, job)
+	s.progressBroker.Publish(*job)
+}
+
+func (s *atsCheckService) saveAnalysisJob(ctx context.Context, job *domain.ATSAnalysisJob) {
+	_ = s.cacheRepo.Set(ctx, atsAnalysisJobKey(job.ID), job, atsAnalysisJobDuration)
+}
+
+func atsAnalysisJobKey(id uuid.UUID) string {
+	return fmt.Sprintf("%s%s", atsAnalysisJobPrefix, id.String())
+}
@@ -0,0 +1,111 @@
+package service
+
+import (
+	"bytes"
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"log"
+	"strings"
+
+	"github.com/raflytch/careerly-server/internal/domain"
+	"github.com/raflytch/careerly-server/pkg/pdfannot"
+
+	"github.com/google/uuid"
+)
+
+var annotationColors = map[string]pdfannot.Color{
+	"critical": {R: 0.9, G: 0.1, B: 0.1},
+	"high":     {R: 1, G: 0.55, B: 0},
+	"medium":   {R: 1, G: 0.85, B: 0},
+	"low":      {R: 0.2, G: 0.5, B: 1},
+}
+
+// saveSource keeps the uploaded PDF for GetAnnotatedPDF. A failure only
+// costs the user the annotated download, so it does not fail the check.
+func (s *atsCheckService) saveSource(ctx context.Context, checkID uuid.UUID, data []byte) {
+	if !bytes.HasPrefix(data, []byte("%PDF-")) {
+		return
+	}
+
+	if err := s.atsCheckRepo.SaveSource(ctx, checkID, data); err != nil {
+		log.Printf("Failed to store ats check %s upload: %v", checkID, err)
+	}
+}
+
+// GetAnnotatedPDF returns the uploaded resume with each located improvement
+// highlighted and its suggestion attached as a comment. Improvements without
+// a location, and the verdict, go into a comment on the first page.
+func (s *atsCheckService) GetAnnotatedPDF(ctx context.Context, userID uuid.UUID, id uuid.UUID) ([]byte, error) {
+	check, err := s.GetByID(ctx, userID, id)
+	if err != nil {
+		return nil, err
+	}
+
+	source, err := s.atsCheckRepo.FindSource(ctx, check.ID)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, ErrATSCheckNoSource
+		}
+		return nil, err
+	}
+
+	annotated, err := pdfannot.Annotate(source, annotationNotes(analysisOrEmpty(check)))
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrPDFNotAnnotatable, err)
+	}
+	return annotated, nil
+}
+
+func annotationNotes(analysis *domain.ATSAnalysis) []pdfannot.Note {
+	var notes []pdfannot.Note
+	var general []string
+
+	for _, improvement := range analysis.Improvements {
+		title := fmt.Sprintf("[%s] %s: %s", strings.ToUpper(improvement.Priority), improvement.Category, improvement.Issue)
+		if improvement.Location == nil {
+			general = append(general, title+"\n"+improvement.Suggestion)
+			continue
+		}
+
+		color, ok := annotationColors[strings.ToLower(improvement.Priority)]
+		if !ok {
+			color = annotationColors["medium"]
+		}
+		notes = append(notes, pdfannot.Note{
+			Page:     improvement.Location.Page,
+			Band:     &pdfannot.Band{Top: improvement.Location.Top, Bottom: improvement.Location.Bottom},
+			Title:    title,
+			Contents: improvement.Suggestion,
+			Color:    color,
+		})
+	}
+
+	summary := fmt.Sprintf("ATS score %.1f/100\n%s", analysis.OverallScore, analysis.Verdict)
+	if len(general) > 0 {
+		summary += "\n\n" + strings.Join(general, "\n\n")
+	}
+	notes = append(notes, pdfannot.Note{
+		Page:     1,
+		Title:    "Careerly ATS check",
+		Contents: summary,
+		Color:    annotationColors["low"],
+	})
+
+	return notes
+}
+
+// sanitizeLocations drops locations that fall outside the page, which the
+// model sometimes returns for issues it could not place.
+func sanitizeLocations(analysis *domain.ATSAnalysis) {
+	for i := range analysis.Improvements {
+		location := analysis.Improvements[i].Location
+		if location == nil {
+			continue
+		}
+		if location.Page < 1 || location.Top < 0 || location.Bottom > 1 || location.Bottom < location.Top {
+			analysis.Improvements[i].Location = nil
+		}
+	}
+}
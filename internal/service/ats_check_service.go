@@ -1,16 +1,22 @@
 package service
 
 import (
+	"bytes"
 	"context"
 	"database/sql"
 	"encoding/json"
 	"errors"
+	"fmt"
 	"mime/multipart"
+	"sort"
 	"strings"
+	"sync"
 	"time"
 
+	"github.com/raflytch/careerly-server/internal/config"
 	"github.com/raflytch/careerly-server/internal/domain"
 	"github.com/raflytch/careerly-server/pkg/genai"
+	"github.com/raflytch/careerly-server/pkg/pdfannot"
 
 	"github.com/google/uuid"
 )
@@ -19,6 +25,11 @@ var (
 	ErrATSCheckNotFound     = errors.New("ats check not found")
 	ErrATSCheckUnauthorized = errors.New("unauthorized access to ats check")
 	ErrAIClientUnavailable  = errors.New("ai client is not available, cannot analyze pdf")
+	ErrAIServiceUnavailable = errors.New("ai service is temporarily unavailable, please try again later")
+	ErrTooManyJobs          = errors.New("too many job descriptions in batch")
+	ErrATSCheckNoSource     = errors.New("no uploaded pdf is stored for this ats check")
+	ErrPDFNotAnnotatable    = errors.New("this pdf cannot be annotated")
+	ErrPDFNoText            = errors.New("this pdf has no extractable text, please upload a text-based pdf instead of a scan")
 )
 
 const atsFileAnalysisSystemPrompt = `You are an extremely strict and brutally honest ATS (Applicant Tracking System) resume analyzer. Your job is to evaluate resumes the way real ATS software does — with zero sympathy. Do NOT inflate scores. If the resume is bad, say it clearly. If it's mediocre, don't sugarcoat.
@@ -108,47 +119,174 @@ You MUST respond ONLY with valid JSON (no markdown, no backticks, no explanation
 Priority levels: "critical", "high", "medium", "low"
 Be ruthless. Be specific. No generic advice. Every feedback must reference actual content from this resume PDF.`
 
-const atsFileAnalysisUserPrompt = `Analyze the uploaded resume PDF file as a strict ATS system. Extract all text content from the PDF and evaluate it thoroughly. Be brutally honest — do NOT inflate scores. Respond with the JSON format specified in your instructions.`
+const atsFileAnalysisUserPrompt = `Analyze the uploaded resume PDF file as a strict ATS system. Extract all text content from the PDF and evaluate it thoroughly. Be brutally honest — do NOT inflate scores. Respond with the JSON format specified in your instructions.
+
+Read the PDF page by page. For every improvement that points at existing content, add a "location" field showing where it is:
+"location": {"page": 1, "line_start": 12, "line_end": 14, "top": 0.32, "bottom": 0.38, "excerpt": "short quote of the text"}
+- "page" is 1-based. "line_start" and "line_end" count text lines from the top of that page.
+- "top" and "bottom" are the approximate vertical position of those lines as a fraction of the page height, 0 being the top edge and 1 the bottom edge.
+- Leave out "location" for issues about something the resume is missing.`
+
+const atsResumeTextUserPrompt = `Analyze the following resume as a strict ATS system. It was exported as plain text from structured resume data and will be rendered into a clean single-column layout, so score "Formatting & ATS Compatibility" on structure and completeness (consistent dates, filled fields, clear headings) rather than on visual layout. Be brutally honest — do NOT inflate scores. Respond with the JSON format specified in your instructions.
+
+Resume:
+%s`
+
+const atsJobMatchSystemPrompt = `You are a strict ATS (Applicant Tracking System) that screens a resume PDF against one specific job description. Score how well the resume matches THIS job, not how good the resume is in general. Do NOT inflate scores.
+
+Scoring Rules:
+- Required skills or qualifications from the job description that are missing from the resume weigh heavily.
+- Keywords only count when they appear in the resume with supporting evidence.
+- 80+ means a strong, well-evidenced match; 40-60 is a partial match; below 40 is a poor fit.
+
+You MUST respond ONLY with valid JSON (no markdown, no backticks, no explanation) in this exact format:
+{
+  "match_score": 62.5,
+  "verdict": "One sentence honest verdict about the fit for this job",
+  "matched_keywords": ["keyword found in both"],
+  "missing_keywords": ["keyword the job asks for that the resume lacks"],
+  "recommendation": "The single most important change to improve the match for this job"
+}`
+
+const atsJobMatchUserPrompt = `Compare the uploaded resume PDF against the following job description and respond with the JSON format specified in your instructions.
+
+Job description:
+%s`
 
 type atsCheckService struct {
-	atsCheckRepo domain.ATSCheckRepository
-	quotaService domain.QuotaService
-	genaiClient  *genai.Client
+	atsCheckRepo   domain.ATSCheckRepository
+	quotaService   domain.QuotaService
+	resumeService  domain.ResumeService
+	cacheRepo      domain.CacheRepository
+	progressBroker domain.ATSProgressBroker
+	aiClient       domain.AIClient
+	prompts        domain.PromptProvider
+	cfg            config.ATSCheckConfig
+	aiSlots        aiSlots
 }
 
 func NewATSCheckService(
 	atsCheckRepo domain.ATSCheckRepository,
 	quotaService domain.QuotaService,
-	genaiClient *genai.Client,
+	resumeService domain.ResumeService,
+	cacheRepo domain.CacheRepository,
+	progressBroker domain.ATSProgressBroker,
+	aiClient domain.AIClient,
+	prompts domain.PromptProvider,
+	cfg config.ATSCheckConfig,
+	aiLimiter domain.ConcurrencyLimiter,
+	aiLimit config.AILimitConfig,
 ) domain.ATSCheckService {
 	return &atsCheckService{
-		atsCheckRepo: atsCheckRepo,
-		quotaService: quotaService,
-		genaiClient:  genaiClient,
+		atsCheckRepo:   atsCheckRepo,
+		quotaService:   quotaService,
+		resumeService:  resumeService,
+		cacheRepo:      cacheRepo,
+		progressBroker: progressBroker,
+		aiClient:       aiClient,
+		prompts:        prompts,
+		cfg:            cfg,
+		aiSlots:        aiSlots{limiter: aiLimiter, plans: quotaService, cfg: aiLimit},
+	}
+}
+
+// AnalyzeFromFile charges the check and queues the upload for analysis,
+// returning straight away. Progress is published as the job moves along and
+// the job's ID becomes the ID of the saved check. The job holds one of the
+// user's AI slots until it ends.
+func (s *atsCheckService) AnalyzeFromFile(ctx context.Context, userID uuid.UUID, file *multipart.FileHeader) (*domain.ATSAnalysisJob, error) {
+	if s.aiClient == nil {
+		return nil, ErrAIClientUnavailable
+	}
+
+	if !s.aiClient.Available() {
+		return nil, ErrAIServiceUnavailable
+	}
+
+	data, err := readUpload(file)
+	if err != nil {
+		return nil, err
+	}
+	if err := requireExtractableText(data); err != nil {
+		return nil, err
+	}
+
+	release, err := s.aiSlots.acquire(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	if _, err := s.quotaService.CheckAndIncrementUsage(ctx, userID, domain.FeatureATSCheck); err != nil {
+		release()
+		return nil, err
 	}
+
+	job := &domain.ATSAnalysisJob{
+		ID:        uuid.New(),
+		UserID:    userID,
+		Status:    domain.ATSAnalysisUploaded,
+		UpdatedAt: time.Now(),
+	}
+	s.saveAnalysisJob(ctx, job)
+
+	go func() {
+		defer release()
+		s.runFileAnalysis(job, genai.File{
+			Reader:   bytes.NewReader(data),
+			MIMEType: file.Header.Get("Content-Type"),
+			Name:     file.Filename,
+		}, data)
+	}()
+
+	return job, nil
 }
 
-func (s *atsCheckService) AnalyzeFromFile(ctx context.Context, userID uuid.UUID, file *multipart.FileHeader) (*domain.ATSCheckResponse, error) {
-	if s.genaiClient == nil {
+// AnalyzeResume scores a resume stored in Careerly by serializing its
+// structured content to text, so users don't have to export and re-upload
+// the PDF.
+func (s *atsCheckService) AnalyzeResume(ctx context.Context, userID uuid.UUID, resumeID uuid.UUID) (*domain.ATSCheckResponse, error) {
+	if s.aiClient == nil {
 		return nil, ErrAIClientUnavailable
 	}
 
-	if err := s.quotaService.CheckAndIncrementUsage(ctx, userID, domain.FeatureATSCheck); err != nil {
+	if !s.aiClient.Available() {
+		return nil, ErrAIServiceUnavailable
+	}
+
+	resume, err := s.resumeService.GetByID(ctx, userID, resumeID)
+	if err != nil {
+		return nil, err
+	}
+
+	if _, err := s.quotaService.CheckAndIncrementUsage(ctx, userID, domain.FeatureATSCheck); err != nil {
 		return nil, err
 	}
 
-	analysis, err := s.analyzeFile(ctx, file)
+	aiCtx := genai.WithQuotaCost(genai.WithCallMetadata(ctx, domain.AIFeatureATSAnalysis, userID.String()), 1)
+	analysis, promptVersion, err := s.analyzeText(aiCtx, userID, renderResumeText(resume))
+	return s.recordCheck(ctx, uuid.New(), userID, &resume.ID, analysis, promptVersion, err)
+}
+
+func (s *atsCheckService) recordCheck(ctx context.Context, id uuid.UUID, userID uuid.UUID, resumeID *uuid.UUID, analysis *domain.ATSAnalysis, promptVersion int, err error) (*domain.ATSCheckResponse, error) {
 	aiStatus := "success"
 	if err != nil {
 		aiStatus = "failed"
+		if errors.Is(err, genai.ErrBudgetExceeded) {
+			aiStatus = "skipped_budget_exceeded"
+		} else if errors.Is(err, genai.ErrTimeout) {
+			aiStatus = "timed_out"
+		} else if errors.Is(err, genai.ErrUnavailable) {
+			aiStatus = "skipped_ai_unavailable"
+		}
 		analysis = s.buildFallbackAnalysis()
 	}
 
 	score := analysis.OverallScore
 
 	check := &domain.ATSCheck{
-		ID:        uuid.New(),
+		ID:        id,
 		UserID:    userID,
+		ResumeID:  resumeID,
 		Score:     &score,
 		Analysis:  analysis,
 		CreatedAt: time.Now(),
@@ -158,12 +296,125 @@ func (s *atsCheckService) AnalyzeFromFile(ctx context.Context, userID uuid.UUID,
 		return nil, err
 	}
 
+	if aiStatus == "success" {
+		s.prompts.RecordUse(ctx, domain.PromptATSAnalysis, userID, check.ID, promptVersion)
+	}
+
 	return &domain.ATSCheckResponse{
 		ATSCheck:         check,
 		AIAnalysisStatus: aiStatus,
 	}, nil
 }
 
+// AnalyzeBatch scores one resume against several job descriptions. Each job
+// description consumes one ATS check from the monthly quota, and the AI calls
+// run through a worker pool bounded by cfg.BatchConcurrency.
+func (s *atsCheckService) AnalyzeBatch(ctx context.Context, userID uuid.UUID, file *multipart.FileHeader, req *domain.ATSBatchRequest) (*domain.ATSBatchResponse, error) {
+	if s.aiClient == nil {
+		return nil, ErrAIClientUnavailable
+	}
+
+	if !s.aiClient.Available() {
+		return nil, ErrAIServiceUnavailable
+	}
+
+	if len(req.JobDescriptions) > s.cfg.BatchMaxJobs {
+		return nil, ErrTooManyJobs
+	}
+
+	data, err := readUpload(file)
+	if err != nil {
+		return nil, err
+	}
+	if err := requireExtractableText(data); err != nil {
+		return nil, err
+	}
+
+	if _, err := s.quotaService.ConsumeUsage(ctx, userID, domain.FeatureATSCheck, len(req.JobDescriptions)); err != nil {
+		return nil, err
+	}
+
+	concurrency := s.cfg.BatchConcurrency
+	if concurrency < 1 {
+		concurrency = 1
+	}
+
+	aiCtx := genai.WithQuotaCost(genai.WithCallMetadata(ctx, domain.AIFeatureATSAnalysis, userID.String()), 1)
+	results := make([]domain.ATSJobMatch, len(req.JobDescriptions))
+	sem := make(chan struct{}, concurrency)
+	var wg sync.WaitGroup
+
+	for i, jd := range req.JobDescriptions {
+		wg.Add(1)
+		go func(i int, jd domain.ATSJobDescription) {
+			defer wg.Done()
+			sem <- struct{}{}
+			defer func() { <-sem }()
+
+			results[i] = s.matchJob(aiCtx, file, i, jd)
+		}(i, jd)
+	}
+	wg.Wait()
+
+	failed := 0
+	for _, r := range results {
+		if r.AIStatus != "success" {
+			failed++
+		}
+	}
+
+	sort.SliceStable(results, func(a, b int) bool {
+		okA, okB := results[a].AIStatus == "success", results[b].AIStatus == "success"
+		if okA != okB {
+			return okA
+		}
+		return results[a].MatchScore > results[b].MatchScore
+	})
+	for i := range results {
+		results[i].Rank = i + 1
+	}
+
+	return &domain.ATSBatchResponse{
+		Results:   results,
+		Processed: len(results),
+		Failed:    failed,
+	}, nil
+}
+
+func (s *atsCheckService) matchJob(ctx context.Context, file *multipart.FileHeader, index int, jd domain.ATSJobDescription) domain.ATSJobMatch {
+	match := domain.ATSJobMatch{
+		Index:           index,
+		Title:           jd.Title,
+		MatchedKeywords: []string{},
+		MissingKeywords: []string{},
+		AIStatus:        "success",
+	}
+
+	result, err := s.generateFromUpload(
+		ctx,
+		file,
+		atsJobMatchSystemPrompt,
+		fmt.Sprintf(atsJobMatchUserPrompt, jd.Description),
+	)
+	if err == nil {
+		err = json.Unmarshal([]byte(cleanJSONResponse(result)), &match)
+	}
+	if err != nil {
+		match.AIStatus = "failed"
+		if errors.Is(err, genai.ErrBudgetExceeded) {
+			match.AIStatus = "skipped_budget_exceeded"
+		} else if errors.Is(err, genai.ErrTimeout) {
+			match.AIStatus = "timed_out"
+		} else if errors.Is(err, genai.ErrUnavailable) {
+			match.AIStatus = "skipped_ai_unavailable"
+		}
+		match.MatchScore = 0
+		match.Verdict = "AI analysis failed for this job description. Please try again later."
+	}
+
+	return match
+}
+
 func (s *atsCheckService) GetByID(ctx context.Context, userID uuid.UUID, id uuid.UUID) (*domain.ATSCheck, error) {
 	check, err := s.atsCheckRepo.FindByID(ctx, id)
 	if err != nil {
@@ -235,25 +486,71 @@ func (s *atsCheckService) Delete(ctx context.Context, userID uuid.UUID, id uuid.
 	return s.atsCheckRepo.SoftDelete(ctx, id)
 }
 
-func (s *atsCheckService) analyzeFile(ctx context.Context, file *multipart.FileHeader) (*domain.ATSAnalysis, error) {
-	result, err := s.genaiClient.GenerateFromFileWithSystemPrompt(
-		ctx,
-		file,
-		atsFileAnalysisSystemPrompt,
-		atsFileAnalysisUserPrompt,
+func (s *atsCheckService) analyzeFile(ctx context.Context, userID uuid.UUID, file genai.File) (*domain.ATSAnalysis, int, error) {
+	systemPrompt, promptVersion := s.prompts.PromptForUser(ctx, domain.PromptATSAnalysis, userID)
+	result, err := s.aiClient.GenerateFromFileWithSystemPrompt(genai.WithResponseSchema(ctx, atsAnalysisSchema), file, systemPrompt, atsFileAnalysisUserPrompt)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	cleaned := cleanJSONResponse(result)
+
+	var analysis domain.ATSAnalysis
+	if err := json.Unmarshal([]byte(cleaned), &analysis); err != nil {
+		return nil, 0, err
+	}
+	sanitizeLocations(&analysis)
+
+	return &analysis, promptVersion, nil
+}
+
+// generateFromUpload opens its own handle on the upload so concurrent batch
+// calls each read it from the start. Large uploads sit in a temp file and
+// are streamed to the model rather than loaded whole.
+func (s *atsCheckService) generateFromUpload(ctx context.Context, file *multipart.FileHeader, systemPrompt, userPrompt string) (string, error) {
+	f, err := file.Open()
+	if err != nil {
+		return "", fmt.Errorf("failed to open file: %w", err)
+	}
+	defer f.Close()
+
+	return s.aiClient.GenerateFromFileWithSystemPrompt(ctx, genai.File{
+		Reader:   f,
+		MIMEType: file.Header.Get("Content-Type"),
+		Name:     file.Filename,
+	}, systemPrompt, userPrompt)
+}
+
+// requireExtractableText rejects a PDF with no text before any quota or AI
+// call is spent on it. A PDF the local parser cannot read is let through,
+// since the model may still manage it.
+func requireExtractableText(data []byte) error {
+	hasText, err := pdfannot.HasText(data)
+	if err == nil && !hasText {
+		return ErrPDFNoText
+	}
+	return nil
+}
+
+func (s *atsCheckService) analyzeText(ctx context.Context, userID uuid.UUID, resumeText string) (*domain.ATSAnalysis, int, error) {
+	systemPrompt, promptVersion := s.prompts.PromptForUser(ctx, domain.PromptATSAnalysis, userID)
+	result, err := s.aiClient.GenerateTextWithSystemPrompt(
+		genai.WithResponseSchema(ctx, atsAnalysisSchema),
+		systemPrompt,
+		fmt.Sprintf(atsResumeTextUserPrompt, resumeText),
 	)
 	if err != nil {
-		return nil, err
+		return nil, 0, err
 	}
 
 	cleaned := cleanJSONResponse(result)
 
 	var analysis domain.ATSAnalysis
 	if err := json.Unmarshal([]byte(cleaned), &analysis); err != nil {
-		return nil, err
+		return nil, 0, err
 	}
 
-	return &analysis, nil
+	return &analysis, promptVersion, nil
 }
 
 func (s *atsCheckService) buildFallbackAnalysis() *domain.ATSAnalysis {
@@ -0,0 +1,172 @@
+package service
+
+import (
+	"context"
+	"math"
+	"strings"
+
+	"github.com/raflytch/careerly-server/internal/domain"
+
+	"github.com/google/uuid"
+)
+
+// Compare diffs two of the user's analyses, reading from as the earlier
+// one. Sections and keywords are matched case-insensitively by name.
+// Improvements are matched on category and issue text, so an issue the
+// model rewords shows up as resolved in one report and new in the other.
+func (s *atsCheckService) Compare(ctx context.Context, userID uuid.UUID, fromID, toID uuid.UUID) (*domain.ATSComparison, error) {
+	from, err := s.GetByID(ctx, userID, fromID)
+	if err != nil {
+		return nil, err
+	}
+	to, err := s.GetByID(ctx, userID, toID)
+	if err != nil {
+		return nil, err
+	}
+
+	fromAnalysis := analysisOrEmpty(from)
+	toAnalysis := analysisOrEmpty(to)
+
+	return &domain.ATSComparison{
+		From:         atsCheckRef(from, fromAnalysis),
+		To:           atsCheckRef(to, toAnalysis),
+		ScoreDelta:   roundScore(toAnalysis.OverallScore - fromAnalysis.OverallScore),
+		Sections:     diffSections(fromAnalysis.Sections, toAnalysis.Sections),
+		Keywords:     diffKeywords(fromAnalysis.KeywordAnalysis, toAnalysis.KeywordAnalysis),
+		Improvements: diffImprovements(fromAnalysis.Improvements, toAnalysis.Improvements),
+	}, nil
+}
+
+func analysisOrEmpty(check *domain.ATSCheck) *domain.ATSAnalysis {
+	if check.Analysis == nil {
+		return &domain.ATSAnalysis{}
+	}
+	return check.Analysis
+}
+
+func atsCheckRef(check *domain.ATSCheck, analysis *domain.ATSAnalysis) domain.ATSCheckRef {
+	return domain.ATSCheckRef{
+		ID:        check.ID,
+		Score:     analysis.OverallScore,
+		CreatedAt: check.CreatedAt,
+	}
+}
+
+// diffSections lists the sections of to in order, followed by any section
+// only the earlier report had.
+func diffSections(from, to []domain.ATSSection) []domain.ATSSectionDelta {
+	fromByName := make(map[string]domain.ATSSection, len(from))
+	for _, section := range from {
+		fromByName[normalizeKey(section.Name)] = section
+	}
+
+	deltas := make([]domain.ATSSectionDelta, 0, len(to))
+	seen := make(map[string]bool, len(to))
+	for _, section := range to {
+		key := normalizeKey(section.Name)
+		seen[key] = true
+
+		toScore := section.Score
+		delta := domain.ATSSectionDelta{
+			Name:     section.Name,
+			ToScore:  &toScore,
+			MaxScore: section.MaxScore,
+			Delta:    section.Score,
+		}
+		if previous, ok := fromByName[key]; ok {
+			fromScore := previous.Score
+			delta.FromScore = &fromScore
+			delta.Delta = roundScore(section.Score - previous.Score)
+		}
+		deltas = append(deltas, delta)
+	}
+
+	for _, section := range from {
+		if seen[normalizeKey(section.Name)] {
+			continue
+		}
+		fromScore := section.Score
+		deltas = append(deltas, domain.ATSSectionDelta{
+			Name:      section.Name,
+			FromScore: &fromScore,
+			MaxScore:  section.MaxScore,
+			Delta:     -section.Score,
+		})
+	}
+
+	return deltas
+}
+
+func diffKeywords(from, to domain.ATSKeywords) domain.ATSKeywordDiff {
+	foundBefore := keySet(from.Found)
+	missingBefore := keySet(from.Missing)
+
+	diff := domain.ATSKeywordDiff{
+		NewlyFound:   []string{},
+		StillMissing: []string{},
+		NewlyMissing: []string{},
+	}
+	for _, keyword := range to.Found {
+		if !foundBefore[normalizeKey(keyword)] {
+			diff.NewlyFound = append(diff.NewlyFound, keyword)
+		}
+	}
+	for _, keyword := range to.Missing {
+		if missingBefore[normalizeKey(keyword)] {
+			diff.StillMissing = append(diff.StillMissing, keyword)
+		} else {
+			diff.NewlyMissing = append(diff.NewlyMissing, keyword)
+		}
+	}
+	return diff
+}
+
+func diffImprovements(from, to []domain.ATSImprovement) domain.ATSImprovementDiff {
+	improvementKey := func(improvement domain.ATSImprovement) string {
+		return normalizeKey(improvement.Category) + "\x00" + normalizeKey(improvement.Issue)
+	}
+
+	before := make(map[string]bool, len(from))
+	for _, improvement := range from {
+		before[improvementKey(improvement)] = true
+	}
+	after := make(map[string]bool, len(to))
+	for _, improvement := range to {
+		after[improvementKey(improvement)] = true
+	}
+
+	diff := domain.ATSImprovementDiff{
+		Resolved:  []domain.ATSImprovement{},
+		New:       []domain.ATSImprovement{},
+		Remaining: []domain.ATSImprovement{},
+	}
+	for _, improvement := range from {
+		if !after[improvementKey(improvement)] {
+			diff.Resolved = append(diff.Resolved, improvement)
+		}
+	}
+	for _, improvement := range to {
+		if before[improvementKey(improvement)] {
+			diff.Remaining = append(diff.Remaining, improvement)
+		} else {
+			diff.New = append(diff.New, improvement)
+		}
+	}
+	return diff
+}
+
+func keySet(values []string) map[string]bool {
+	set := make(map[string]bool, len(values))
+	for _, value := range values {
+		set[normalizeKey(value)] = true
+	}
+	return set
+}
+
+func normalizeKey(value string) string {
+	return strings.ToLower(strings.Join(strings.Fields(value), " "))
+}
+
+func roundScore(value float64) float64 {
+	return math.Round(value*10) / 10
+}
@@ -0,0 +1,96 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"log"
+	"time"
+
+	"github.com/raflytch/careerly-server/internal/domain"
+
+	"github.com/google/uuid"
+)
+
+type auditService struct {
+	auditLogRepo domain.AuditLogRepository
+}
+
+func NewAuditService(auditLogRepo domain.AuditLogRepository) domain.AuditService {
+	return &auditService{
+		auditLogRepo: auditLogRepo,
+	}
+}
+
+// Record is best effort: a failure to write the audit trail is logged but
+// never rolls back or fails the action being audited.
+func (s *auditService) Record(ctx context.Context, action domain.AuditAction, targetType string, targetID uuid.UUID, before, after interface{}) {
+	entry := &domain.AuditLog{
+		ID:         uuid.New(),
+		Action:     action,
+		TargetType: targetType,
+		TargetID:   targetID,
+		Before:     auditSnapshot(before),
+		After:      auditSnapshot(after),
+		CreatedAt:  time.Now(),
+	}
+
+	if actor, ok := domain.AuditActorFromContext(ctx); ok {
+		entry.ActorID = &actor.UserID
+		entry.IPAddress = actor.IPAddress
+		entry.UserAgent = actor.UserAgent
+	}
+
+	if err := s.auditLogRepo.Create(ctx, entry); err != nil {
+		log.Printf("Failed to record audit log %s for %s %s: %v", action, targetType, targetID, err)
+	}
+}
+
+func (s *auditService) GetAll(ctx context.Context, filter domain.AuditLogFilter, page, limit int) (*domain.PaginatedAuditLogs, error) {
+	if page < 1 {
+		page = 1
+	}
+	if limit < 1 {
+		limit = 10
+	}
+	if limit > 100 {
+		limit = 100
+	}
+
+	offset := (page - 1) * limit
+
+	total, err := s.auditLogRepo.Count(ctx, filter)
+	if err != nil {
+		return nil, err
+	}
+
+	logs, err := s.auditLogRepo.FindAll(ctx, filter, limit, offset)
+	if err != nil {
+		return nil, err
+	}
+
+	totalPages := int(total) / limit
+	if int(total)%limit > 0 {
+		totalPages++
+	}
+
+	return &domain.PaginatedAuditLogs{
+		Logs: logs,
+		Pagination: domain.Pagination{
+			Page:       page,
+			Limit:      limit,
+			Total:      total,
+			TotalPages: totalPages,
+		},
+	}, nil
+}
+
+func auditSnapshot(v interface{}) json.RawMessage {
+	if v == nil {
+		return nil
+	}
+	data, err := json.Marshal(v)
+	if err != nil {
+		return nil
+	}
+	return data
+}
@@ -0,0 +1,258 @@
+package service
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"log"
+	"strings"
+	"time"
+
+	"github.com/raflytch/careerly-server/internal/domain"
+
+	"github.com/google/uuid"
+	"golang.org/x/oauth2"
+)
+
+func (s *authService) fetchGoogleUser(ctx context.Context, code string) (*domain.GoogleUserInfo, error) {
+	token, err := s.oauthConfig.Exchange(ctx, code)
+	if err != nil {
+		return nil, ErrFailedToExchangeToken
+	}
+
+	httpClient := s.oauthConfig.Client(ctx, token)
+	resp, err := httpClient.Get("https://www.googleapis.com/oauth2/v2/userinfo")
+	if err != nil {
+		return nil, ErrFailedToGetUserInfo
+	}
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, ErrFailedToGetUserInfo
+	}
+
+	var googleUser domain.GoogleUserInfo
+	if err := json.Unmarshal(body, &googleUser); err != nil {
+		return nil, ErrFailedToGetUserInfo
+	}
+
+	return &googleUser, nil
+}
+
+// resolveGoogleUser finds the account behind a Google login. A Google account
+// that is not linked yet is attached to the existing user with the same
+// email when Google has verified that email; otherwise a new user is created.
+func (s *authService) resolveGoogleUser(ctx context.Context, googleUser *domain.GoogleUserInfo, referralCode string) (*domain.User, error) {
+	identity, err := s.identityRepo.FindByProvider(ctx, domain.AuthProviderGoogle, googleUser.ID)
+	if err == nil {
+		user, err := s.userRepo.FindByID(ctx, identity.UserID)
+		if err != nil {
+			if errors.Is(err, sql.ErrNoRows) {
+				return nil, domain.ErrUserDeleted
+			}
+			return nil, err
+		}
+		_ = s.identityRepo.UpdateLastUsed(ctx, identity.ID)
+		return user, nil
+	}
+	if !errors.Is(err, sql.ErrNoRows) {
+		return nil, err
+	}
+
+	deletedUser, delErr := s.userRepo.FindDeletedByGoogleID(ctx, googleUser.ID)
+	if delErr == nil && deletedUser != nil {
+		return nil, domain.ErrUserDeleted
+	}
+
+	user, err := s.userRepo.FindByEmail(ctx, googleUser.Email)
+	if err == nil {
+		if !googleUser.VerifiedEmail {
+			return nil, domain.ErrEmailBelongsToAnother
+		}
+		if err := s.linkIdentity(ctx, user.ID, domain.AuthProviderGoogle, googleUser.ID, googleUser.Email); err != nil {
+			if errors.Is(err, domain.ErrProviderAlreadyLinked) {
+				return nil, domain.ErrEmailBelongsToAnother
+			}
+			return nil, err
+		}
+		return user, nil
+	}
+	if !errors.Is(err, sql.ErrNoRows) {
+		return nil, err
+	}
+
+	deletedUser, delErr = s.userRepo.FindDeletedByEmail(ctx, googleUser.Email)
+	if delErr == nil && deletedUser != nil {
+		return nil, domain.ErrUserDeleted
+	}
+
+	now := time.Now()
+	user = &domain.User{
+		ID:        uuid.New(),
+		GoogleID:  googleUser.ID,
+		Email:     googleUser.Email,
+		Name:      googleUser.Name,
+		AvatarURL: &googleUser.Picture,
+		Role:      domain.RoleUser,
+		IsActive:  true,
+		Timezone:  domain.DefaultTimezone,
+		CreatedAt: now,
+	}
+	if err := s.userRepo.Create(ctx, user); err != nil {
+		if s.isDuplicateKeyError(err) {
+			deletedUser, delErr := s.userRepo.FindDeletedByGoogleID(ctx, googleUser.ID)
+			if delErr == nil && deletedUser != nil {
+				return nil, domain.ErrUserDeleted
+			}
+		}
+		return nil, err
+	}
+
+	identity = &domain.AuthIdentity{
+		ID:             uuid.New(),
+		UserID:         user.ID,
+		Provider:       domain.AuthProviderGoogle,
+		ProviderUserID: googleUser.ID,
+		Email:          googleUser.Email,
+		CreatedAt:      now,
+		LastUsedAt:     &now,
+	}
+	if err := s.identityRepo.Create(ctx, identity); err != nil {
+		return nil, err
+	}
+
+	if referralCode != "" {
+		if err := s.referralService.AttributeSignup(ctx, user.ID, referralCode); err != nil {
+			log.Printf("Referral attribution failed for user %s: %v", user.ID, err)
+		}
+	}
+
+	return user, nil
+}
+
+// StartLink returns the provider's consent URL for attaching another login
+// to userID. The OAuth state is remembered so the shared callback links the
+// account instead of signing in; the caller must also hand state to the
+// browser, whose copy the callback checks.
+func (s *authService) StartLink(ctx context.Context, userID uuid.UUID, provider domain.AuthProvider, state string) (*domain.LinkProviderResponse, error) {
+	if provider != domain.AuthProviderGoogle {
+		return nil, domain.ErrUnsupportedProvider
+	}
+
+	identities, err := s.identityRepo.FindByUserID(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	for _, identity := range identities {
+		if identity.Provider == provider {
+			return nil, domain.ErrProviderAlreadyLinked
+		}
+	}
+
+	if err := s.cacheRepo.Set(ctx, linkStatePrefix+state, userID.String(), linkStateDuration); err != nil {
+		return nil, fmt.Errorf("failed to store link state: %w", err)
+	}
+
+	return &domain.LinkProviderResponse{
+		Provider: provider,
+		AuthURL:  s.oauthConfig.AuthCodeURL(state, oauth2.AccessTypeOffline, oauth2.SetAuthURLParam("prompt", "select_account")),
+	}, nil
+}
+
+func (s *authService) GetIdentities(ctx context.Context, userID uuid.UUID) ([]domain.AuthIdentity, error) {
+	return s.identityRepo.FindByUserID(ctx, userID)
+}
+
+func (s *authService) Unlink(ctx context.Context, userID, identityID uuid.UUID) error {
+	identities, err := s.identityRepo.FindByUserID(ctx, userID)
+	if err != nil {
+		return err
+	}
+
+	var target *domain.AuthIdentity
+	for i := range identities {
+		if identities[i].ID == identityID {
+			target = &identities[i]
+			break
+		}
+	}
+	if target == nil {
+		return domain.ErrIdentityNotFound
+	}
+	if len(identities) <= 1 {
+		return domain.ErrLastIdentity
+	}
+
+	if err := s.identityRepo.Delete(ctx, target.ID); err != nil {
+		return err
+	}
+
+	s.auditService.Record(ctx, domain.AuditActionIdentityUnlink, domain.AuditTargetAuthIdentity, target.ID, target, nil)
+	return nil
+}
+
+func (s *authService) consumeLinkState(ctx context.Context, state string) (uuid.UUID, bool) {
+	if state == "" {
+		return uuid.Nil, false
+	}
+
+	key := linkStatePrefix + state
+	cached, err := s.cacheRepo.Get(ctx, key)
+	if err != nil || cached == "" {
+		return uuid.Nil, false
+	}
+	_ = s.cacheRepo.Delete(ctx, key)
+
+	userID, err := uuid.Parse(strings.Trim(cached, "\""))
+	if err != nil {
+		return uuid.Nil, false
+	}
+	return userID, true
+}
+
+func (s *authService) linkIdentity(ctx context.Context, userID uuid.UUID, provider domain.AuthProvider, providerUserID, email string) error {
+	existing, err := s.identityRepo.FindByProvider(ctx, provider, providerUserID)
+	if err == nil {
+		if existing.UserID == userID {
+			return nil
+		}
+		return domain.ErrIdentityInUse
+	}
+	if !errors.Is(err, sql.ErrNoRows) {
+		return err
+	}
+
+	identities, err := s.identityRepo.FindByUserID(ctx, userID)
+	if err != nil {
+		return err
+	}
+	for _, identity := range identities {
+		if identity.Provider == provider {
+			return domain.ErrProviderAlreadyLinked
+		}
+	}
+
+	now := time.Now()
+	identity := &domain.AuthIdentity{
+		ID:             uuid.New(),
+		UserID:         userID,
+		Provider:       provider,
+		ProviderUserID: providerUserID,
+		Email:          email,
+		CreatedAt:      now,
+		LastUsedAt:     &now,
+	}
+	if err := s.identityRepo.Create(ctx, identity); err != nil {
+		if s.isDuplicateKeyError(err) {
+			return domain.ErrIdentityInUse
+		}
+		return err
+	}
+
+	s.auditService.Record(ctx, domain.AuditActionIdentityLink, domain.AuditTargetAuthIdentity, identity.ID, nil, identity)
+	return nil
+}
@@ -6,7 +6,7 @@ import (
 	"encoding/json"
 	"errors"
 	"fmt"
-	"io"
+	"log"
 	"strings"
 	"time"
 
@@ -25,6 +25,16 @@ const (
 	otpCachePrefix    = "otp:restore:"
 	otpCacheDuration  = 15 * time.Minute
 	otpLength         = 6
+
+	twoFactorSessionPrefix   = "2fa:session:"
+	twoFactorOTPPrefix       = "otp:2fa:"
+	twoFactorMaxAttempts     = 5
+	twoFactorMaxResends      = 3
+	twoFactorResendCooldown  = time.Minute
+	twoFactorTokenByteLength = 32
+
+	linkStatePrefix   = "oauth:link:"
+	linkStateDuration = 10 * time.Minute
 )
 
 var (
@@ -34,19 +44,33 @@ var (
 )
 
 type authService struct {
-	userRepo     domain.UserRepository
-	cacheRepo    domain.CacheRepository
-	emailService domain.EmailService
-	oauthConfig  *oauth2.Config
-	jwtManager   *jwt.JWTManager
-	frontendURL  string
+	userRepo        domain.UserRepository
+	identityRepo    domain.AuthIdentityRepository
+	cacheRepo       domain.CacheRepository
+	userLoader      *cachedLoader
+	emailService    domain.EmailService
+	referralService domain.ReferralService
+	sessionService  domain.SessionService
+	auditService    domain.AuditService
+	deletions       domain.AccountDeletionService
+	oauthConfig     *oauth2.Config
+	jwtManager      *jwt.JWTManager
+	frontendURL     string
+
+	impersonationTTL time.Duration
 }
 
 func NewAuthService(
 	userRepo domain.UserRepository,
+	identityRepo domain.AuthIdentityRepository,
 	cacheRepo domain.CacheRepository,
 	emailService domain.EmailService,
+	referralService domain.ReferralService,
+	sessionService domain.SessionService,
+	auditService domain.AuditService,
+	deletions domain.AccountDeletionService,
 	cfg config.GoogleConfig,
+	jwtCfg config.JWTConfig,
 	jwtManager *jwt.JWTManager,
 ) domain.AuthService {
 	oauthConfig := &oauth2.Config{
@@ -61,12 +85,20 @@ func NewAuthService(
 	}
 
 	return &authService{
-		userRepo:     userRepo,
-		cacheRepo:    cacheRepo,
-		emailService: emailService,
-		oauthConfig:  oauthConfig,
-		jwtManager:   jwtManager,
-		frontendURL:  cfg.FrontendURL,
+		userRepo:        userRepo,
+		identityRepo:    identityRepo,
+		cacheRepo:       cacheRepo,
+		userLoader:      newCachedLoader(cacheRepo, userCacheDuration),
+		emailService:    emailService,
+		referralService: referralService,
+		sessionService:  sessionService,
+		auditService:    auditService,
+		deletions:       deletions,
+		oauthConfig:     oauthConfig,
+		jwtManager:      jwtManager,
+		frontendURL:     cfg.FrontendURL,
+
+		impersonationTTL: time.Duration(jwtCfg.ImpersonationMinutes) * time.Minute,
 	}
 }
 
@@ -78,70 +110,214 @@ func (s *authService) GetFrontendURL() string {
 	return s.frontendURL
 }
 
-func (s *authService) HandleGoogleCallback(ctx context.Context, code string) (string, error) {
-	token, err := s.oauthConfig.Exchange(ctx, code)
+func (s *authService) HandleGoogleCallback(ctx context.Context, code, state, referralCode string, client domain.ClientInfo) (*domain.LoginResult, error) {
+	googleUser, err := s.fetchGoogleUser(ctx, code)
+	if err != nil {
+		return nil, err
+	}
+
+	if linkUserID, ok := s.consumeLinkState(ctx, state); ok {
+		if err := s.linkIdentity(ctx, linkUserID, domain.AuthProviderGoogle, googleUser.ID, googleUser.Email); err != nil {
+			return nil, err
+		}
+		return &domain.LoginResult{LinkedProvider: domain.AuthProviderGoogle}, nil
+	}
+
+	user, err := s.resolveGoogleUser(ctx, googleUser, referralCode)
+	if err != nil {
+		return nil, err
+	}
+
+	if !user.IsActive {
+		return nil, ErrUserNotActive
+	}
+
+	if user.TwoFactorEnabled {
+		pendingToken, err := s.startTwoFactorSession(ctx, user)
+		if err != nil {
+			return nil, err
+		}
+		return &domain.LoginResult{
+			TwoFactorRequired: true,
+			PendingToken:      pendingToken,
+		}, nil
+	}
+
+	jwtToken, err := s.issueToken(ctx, user, client)
 	if err != nil {
-		return "", ErrFailedToExchangeToken
+		return nil, err
 	}
 
-	client := s.oauthConfig.Client(ctx, token)
-	resp, err := client.Get("https://www.googleapis.com/oauth2/v2/userinfo")
+	return &domain.LoginResult{Token: jwtToken}, nil
+}
+
+func (s *authService) VerifyTwoFactor(ctx context.Context, pendingToken, otp string, client domain.ClientInfo) (*domain.AuthResponse, error) {
+	sessionKey := fmt.Sprintf("%s%s", twoFactorSessionPrefix, pendingToken)
+	session, err := s.getTwoFactorSession(ctx, sessionKey)
 	if err != nil {
-		return "", ErrFailedToGetUserInfo
+		return nil, err
 	}
-	defer resp.Body.Close()
 
-	body, err := io.ReadAll(resp.Body)
+	otpKey := fmt.Sprintf("%s%s", twoFactorOTPPrefix, session.UserID.String())
+	storedOTP, err := s.cacheRepo.Get(ctx, otpKey)
 	if err != nil {
-		return "", ErrFailedToGetUserInfo
+		return nil, domain.ErrInvalidOTP
 	}
 
-	var googleUser domain.GoogleUserInfo
-	if err := json.Unmarshal(body, &googleUser); err != nil {
-		return "", ErrFailedToGetUserInfo
+	storedOTP = strings.Trim(storedOTP, "\"")
+	if storedOTP != otp {
+		session.Attempts++
+		if session.Attempts >= twoFactorMaxAttempts {
+			_ = s.cacheRepo.Delete(ctx, sessionKey)
+			_ = s.cacheRepo.Delete(ctx, otpKey)
+			return nil, domain.ErrTwoFactorSession
+		}
+		_ = s.cacheRepo.Set(ctx, sessionKey, session, otpCacheDuration)
+		return nil, domain.ErrInvalidOTP
 	}
 
-	user, err := s.userRepo.FindByGoogleID(ctx, googleUser.ID)
+	_ = s.cacheRepo.Delete(ctx, sessionKey)
+	_ = s.cacheRepo.Delete(ctx, otpKey)
+
+	user, err := s.userRepo.FindByID(ctx, session.UserID)
 	if err != nil {
 		if errors.Is(err, sql.ErrNoRows) {
-			deletedUser, delErr := s.userRepo.FindDeletedByGoogleID(ctx, googleUser.ID)
-			if delErr == nil && deletedUser != nil {
-				return "", domain.ErrUserDeleted
-			}
-
-			user = &domain.User{
-				ID:        uuid.New(),
-				GoogleID:  googleUser.ID,
-				Email:     googleUser.Email,
-				Name:      googleUser.Name,
-				AvatarURL: &googleUser.Picture,
-				Role:      domain.RoleUser,
-				IsActive:  true,
-				CreatedAt: time.Now(),
-			}
-			if err := s.userRepo.Create(ctx, user); err != nil {
-				if s.isDuplicateKeyError(err) {
-					deletedUser, delErr := s.userRepo.FindDeletedByGoogleID(ctx, googleUser.ID)
-					if delErr == nil && deletedUser != nil {
-						return "", domain.ErrUserDeleted
-					}
-				}
-				return "", err
-			}
-		} else {
-			return "", err
+			return nil, domain.ErrTwoFactorSession
 		}
+		return nil, err
 	}
 
 	if !user.IsActive {
-		return "", ErrUserNotActive
+		return nil, ErrUserNotActive
+	}
+
+	jwtToken, err := s.issueToken(ctx, user, client)
+	if err != nil {
+		return nil, err
+	}
+
+	return &domain.AuthResponse{
+		Token: jwtToken,
+		User:  *user,
+	}, nil
+}
+
+// ResendTwoFactorOTP emails a new login OTP for a pending session, at most
+// twoFactorMaxResends times and no sooner than twoFactorResendCooldown after
+// the last one. Asking past the limit ends the session, as too many wrong
+// codes do, so the user has to sign in again.
+func (s *authService) ResendTwoFactorOTP(ctx context.Context, pendingToken string) (*domain.OTPResponse, error) {
+	sessionKey := fmt.Sprintf("%s%s", twoFactorSessionPrefix, pendingToken)
+	session, err := s.getTwoFactorSession(ctx, sessionKey)
+	if err != nil {
+		return nil, err
+	}
+
+	if time.Since(session.LastSentAt) < twoFactorResendCooldown {
+		return nil, domain.ErrOTPAlreadySent
+	}
+	if session.Resends >= twoFactorMaxResends {
+		_ = s.cacheRepo.Delete(ctx, sessionKey)
+		_ = s.cacheRepo.Delete(ctx, fmt.Sprintf("%s%s", twoFactorOTPPrefix, session.UserID.String()))
+		return nil, domain.ErrTwoFactorSession
+	}
+
+	user, err := s.userRepo.FindByID(ctx, session.UserID)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, domain.ErrTwoFactorSession
+		}
+		return nil, err
+	}
+
+	session.Resends++
+	session.LastSentAt = time.Now()
+	if err := s.cacheRepo.Set(ctx, sessionKey, session, otpCacheDuration); err != nil {
+		return nil, fmt.Errorf("failed to store two-factor session: %w", err)
+	}
+
+	if err := s.sendTwoFactorOTP(ctx, user); err != nil {
+		return nil, err
+	}
+
+	return &domain.OTPResponse{
+		Message:   "A new OTP has been sent to your email address",
+		ExpiresIn: int(otpCacheDuration.Seconds()),
+	}, nil
+}
+
+type twoFactorSession struct {
+	UserID     uuid.UUID `json:"user_id"`
+	Attempts   int       `json:"attempts"`
+	Resends    int       `json:"resends"`
+	LastSentAt time.Time `json:"last_sent_at"`
+}
+
+// startTwoFactorSession emails a login OTP and returns an opaque pending
+// token that stands in for the JWT until the OTP is verified.
+func (s *authService) startTwoFactorSession(ctx context.Context, user *domain.User) (string, error) {
+	pendingToken, err := GenerateToken(twoFactorTokenByteLength)
+	if err != nil {
+		return "", fmt.Errorf("failed to generate session token: %w", err)
+	}
+
+	sessionKey := fmt.Sprintf("%s%s", twoFactorSessionPrefix, pendingToken)
+	if err := s.cacheRepo.Set(ctx, sessionKey, twoFactorSession{UserID: user.ID, LastSentAt: time.Now()}, otpCacheDuration); err != nil {
+		return "", fmt.Errorf("failed to store two-factor session: %w", err)
+	}
+
+	if err := s.sendTwoFactorOTP(ctx, user); err != nil {
+		_ = s.cacheRepo.Delete(ctx, sessionKey)
+		return "", err
+	}
+
+	return pendingToken, nil
+}
+
+func (s *authService) getTwoFactorSession(ctx context.Context, sessionKey string) (*twoFactorSession, error) {
+	cached, err := s.cacheRepo.Get(ctx, sessionKey)
+	if err != nil || cached == "" {
+		return nil, domain.ErrTwoFactorSession
+	}
+
+	var session twoFactorSession
+	if err := json.Unmarshal([]byte(cached), &session); err != nil {
+		return nil, domain.ErrTwoFactorSession
+	}
+
+	return &session, nil
+}
+
+func (s *authService) sendTwoFactorOTP(ctx context.Context, user *domain.User) error {
+	otp, err := GenerateOTP(otpLength)
+	if err != nil {
+		return fmt.Errorf("failed to generate OTP: %w", err)
+	}
+
+	otpKey := fmt.Sprintf("%s%s", twoFactorOTPPrefix, user.ID.String())
+	if err := s.cacheRepo.Set(ctx, otpKey, otp, otpCacheDuration); err != nil {
+		return fmt.Errorf("failed to store OTP: %w", err)
+	}
+
+	if err := s.emailService.SendLoginOTP(ctx, user.Email, otp); err != nil {
+		_ = s.cacheRepo.Delete(ctx, otpKey)
+		return fmt.Errorf("failed to send OTP email: %w", err)
 	}
 
+	return nil
+}
+
+func (s *authService) issueToken(ctx context.Context, user *domain.User, client domain.ClientInfo) (string, error) {
 	if err := s.userRepo.UpdateLastLogin(ctx, user.ID); err != nil {
 		return "", err
 	}
 
-	jwtToken, err := s.jwtManager.Generate(user.ID, user.Email, string(user.Role))
+	session, err := s.sessionService.Create(ctx, user.ID, client)
+	if err != nil {
+		return "", err
+	}
+
+	jwtToken, err := s.jwtManager.Generate(user.ID, session.ID, user.Email, string(user.Role))
 	if err != nil {
 		return "", err
 	}
@@ -152,25 +328,33 @@ func (s *authService) HandleGoogleCallback(ctx context.Context, code string) (st
 	return jwtToken, nil
 }
 
-func (s *authService) ValidateToken(ctx context.Context, tokenString string) (*domain.User, error) {
+func (s *authService) ValidateToken(ctx context.Context, tokenString string) (*domain.TokenIdentity, error) {
 	claims, err := s.jwtManager.Validate(tokenString)
 	if err != nil {
 		return nil, err
 	}
 
-	cacheKey := fmt.Sprintf("%s%s", userCachePrefix, claims.UserID.String())
-	cached, err := s.cacheRepo.Get(ctx, cacheKey)
-	if err == nil && cached != "" {
-		var user domain.User
-		if err := json.Unmarshal([]byte(cached), &user); err == nil {
-			if user.IsActive {
-				return &user, nil
-			}
-			return nil, ErrUserNotActive
-		}
+	if err := s.sessionService.Validate(ctx, claims.UserID, claims.SessionID); err != nil {
+		return nil, err
 	}
 
-	user, err := s.userRepo.FindByID(ctx, claims.UserID)
+	user, err := s.loadActiveUser(ctx, claims.UserID)
+	if err != nil {
+		return nil, err
+	}
+
+	return &domain.TokenIdentity{
+		User:           user,
+		SessionID:      claims.SessionID,
+		ImpersonatorID: claims.ImpersonatorID,
+	}, nil
+}
+
+func (s *authService) loadActiveUser(ctx context.Context, userID uuid.UUID) (*domain.User, error) {
+	cacheKey := fmt.Sprintf("%s%s", userCachePrefix, userID.String())
+	user, err := loadCached(ctx, s.userLoader, cacheKey, func(ctx context.Context) (*domain.User, error) {
+		return s.userRepo.FindByID(ctx, userID)
+	})
 	if err != nil {
 		return nil, err
 	}
@@ -179,11 +363,54 @@ func (s *authService) ValidateToken(ctx context.Context, tokenString string) (*d
 		return nil, ErrUserNotActive
 	}
 
-	_ = s.cacheRepo.Set(ctx, cacheKey, user, userCacheDuration)
-
 	return user, nil
 }
 
+// Impersonate issues a short-lived token that acts as targetID. The token
+// carries the admin's ID so every request made with it can be attributed.
+func (s *authService) Impersonate(ctx context.Context, adminID, targetID uuid.UUID, client domain.ClientInfo) (*domain.ImpersonationResponse, error) {
+	if adminID == targetID {
+		return nil, domain.ErrCannotImpersonateSelf
+	}
+
+	target, err := s.userRepo.FindByID(ctx, targetID)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, domain.ErrUserNotFound
+		}
+		return nil, err
+	}
+
+	if !target.IsActive {
+		return nil, ErrUserNotActive
+	}
+	if target.Role == domain.RoleAdmin {
+		return nil, domain.ErrCannotImpersonateAdmin
+	}
+
+	session, err := s.sessionService.CreateImpersonation(ctx, target.ID, adminID, client, s.impersonationTTL)
+	if err != nil {
+		return nil, err
+	}
+
+	token, err := s.jwtManager.GenerateImpersonation(target.ID, session.ID, adminID, target.Email, string(target.Role), s.impersonationTTL)
+	if err != nil {
+		return nil, err
+	}
+
+	s.auditService.Record(ctx, domain.AuditActionUserImpersonate, domain.AuditTargetUser, target.ID, nil, map[string]interface{}{
+		"session_id": session.ID,
+		"expires_at": session.ExpiresAt,
+	})
+
+	return &domain.ImpersonationResponse{
+		Token:          token,
+		ExpiresAt:      session.ExpiresAt,
+		ImpersonatorID: adminID,
+		User:           *target,
+	}, nil
+}
+
 func (s *authService) RequestRestoreOTP(ctx context.Context, email string) (*domain.OTPResponse, error) {
 	deletedUser, err := s.userRepo.FindDeletedByEmail(ctx, email)
 	if err != nil {
@@ -243,6 +470,9 @@ func (s *authService) VerifyRestoreOTP(ctx context.Context, email, otp string) (
 	if err := s.userRepo.Restore(ctx, deletedUser.ID); err != nil {
 		return nil, fmt.Errorf("failed to restore user: %w", err)
 	}
+	if err := s.deletions.Cancel(ctx, deletedUser.ID); err != nil {
+		log.Printf("Failed to cancel removal of restored user %s: %v", deletedUser.ID, err)
+	}
 
 	_ = s.cacheRepo.Delete(ctx, otpKey)
 
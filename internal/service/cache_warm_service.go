@@ -0,0 +1,92 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/raflytch/careerly-server/internal/domain"
+)
+
+const (
+	warmPlanPageLimit = 10
+	warmPlanMaxPages  = 5
+)
+
+type cacheWarmService struct {
+	planService domain.PlanService
+	userRepo    domain.UserRepository
+	cacheRepo   domain.CacheRepository
+	recentUsers int
+}
+
+func NewCacheWarmService(planService domain.PlanService, userRepo domain.UserRepository, cacheRepo domain.CacheRepository, recentUsers int) domain.CacheWarmService {
+	return &cacheWarmService{
+		planService: planService,
+		userRepo:    userRepo,
+		cacheRepo:   cacheRepo,
+		recentUsers: recentUsers,
+	}
+}
+
+func (s *cacheWarmService) Warm(ctx context.Context) (*domain.CacheWarmResult, error) {
+	result := &domain.CacheWarmResult{
+		StartedAt: time.Now(),
+	}
+
+	pages, err := s.warmPlans(ctx)
+	result.PlanPages = pages
+	if err != nil {
+		result.Errors = append(result.Errors, fmt.Sprintf("plans: %v", err))
+	}
+
+	if s.recentUsers > 0 {
+		users, err := s.warmUsers(ctx)
+		result.Users = users
+		if err != nil {
+			result.Errors = append(result.Errors, fmt.Sprintf("users: %v", err))
+		}
+	}
+
+	result.CompletedAt = time.Now()
+	result.DurationMs = result.CompletedAt.Sub(result.StartedAt).Milliseconds()
+
+	return result, nil
+}
+
+// warmPlans populates the public active plan listing that the pricing page
+// hits on every visit, following pagination until the last page.
+func (s *cacheWarmService) warmPlans(ctx context.Context) (int, error) {
+	warmed := 0
+	for page := 1; page <= warmPlanMaxPages; page++ {
+		result, err := s.planService.GetAll(ctx, page, warmPlanPageLimit, false)
+		if err != nil {
+			return warmed, err
+		}
+		warmed++
+
+		if page >= result.Pagination.TotalPages {
+			break
+		}
+	}
+	return warmed, nil
+}
+
+// warmUsers loads the most recently active users into the same cache entries
+// the auth middleware reads when validating tokens.
+func (s *cacheWarmService) warmUsers(ctx context.Context) (int, error) {
+	users, err := s.userRepo.FindRecentlyActive(ctx, s.recentUsers)
+	if err != nil {
+		return 0, err
+	}
+
+	warmed := 0
+	for i := range users {
+		cacheKey := fmt.Sprintf("%s%s", userCachePrefix, users[i].ID.String())
+		if err := s.cacheRepo.Set(ctx, cacheKey, users[i], userCacheDuration); err != nil {
+			return warmed, err
+		}
+		warmed++
+	}
+	return warmed, nil
+}
@@ -0,0 +1,79 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"math"
+	"math/rand/v2"
+	"sync/atomic"
+	"time"
+
+	"github.com/raflytch/careerly-server/internal/domain"
+
+	"golang.org/x/sync/singleflight"
+)
+
+// earlyRefreshBeta scales how eagerly entries are refreshed ahead of expiry.
+// Values above 1 favour refreshing earlier.
+const earlyRefreshBeta = 1.0
+
+// cachedLoader guards one family of hot cache keys against stampedes.
+// Concurrent misses for the same key share a single load, and each hit may
+// refresh the entry slightly before it expires, with a probability that
+// grows as expiry approaches and with how long a load takes, so a hot key is
+// usually rebuilt by one request before everyone else sees it missing.
+type cachedLoader struct {
+	cache domain.CacheRepository
+	ttl   time.Duration
+	group singleflight.Group
+	// loadTime is the duration of the most recent load, in nanoseconds.
+	loadTime atomic.Int64
+}
+
+func newCachedLoader(cache domain.CacheRepository, ttl time.Duration) *cachedLoader {
+	return &cachedLoader{cache: cache, ttl: ttl}
+}
+
+func loadCached[T any](ctx context.Context, l *cachedLoader, key string, load func(ctx context.Context) (*T, error)) (*T, error) {
+	var stale *T
+	raw, remaining, err := l.cache.GetWithTTL(ctx, key)
+	if err == nil && raw != "" {
+		var cached T
+		if err := json.Unmarshal([]byte(raw), &cached); err == nil {
+			if !l.refreshEarly(remaining) {
+				return &cached, nil
+			}
+			stale = &cached
+		}
+	}
+
+	v, err, _ := l.group.Do(key, func() (interface{}, error) {
+		start := time.Now()
+		value, err := load(ctx)
+		if err != nil {
+			return nil, err
+		}
+		l.loadTime.Store(int64(time.Since(start)))
+
+		_ = l.cache.Set(ctx, key, value, l.ttl)
+		return value, nil
+	})
+	if err != nil {
+		if stale != nil {
+			return stale, nil
+		}
+		return nil, err
+	}
+
+	// Callers sharing a load each get their own copy.
+	value := *v.(*T)
+	return &value, nil
+}
+
+func (l *cachedLoader) refreshEarly(remaining time.Duration) bool {
+	loadTime := float64(l.loadTime.Load())
+	if remaining <= 0 || loadTime == 0 {
+		return false
+	}
+	return -loadTime*earlyRefreshBeta*math.Log(rand.Float64()) >= float64(remaining)
+}
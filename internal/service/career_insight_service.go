@@ -0,0 +1,269 @@
+package service
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/raflytch/careerly-server/internal/domain"
+	"github.com/raflytch/careerly-server/pkg/genai"
+
+	"github.com/google/uuid"
+)
+
+const (
+	skillGapCachePrefix   = "insights:skill-gap:"
+	skillGapCacheDuration = 24 * time.Hour
+	insightInterviewLimit = 10
+	insightATSCheckLimit  = 5
+	weakInterviewScore    = 60.0
+)
+
+var ErrInsufficientInsightData = errors.New("create a resume, interview or ats check before requesting insights")
+
+const skillGapPrompt = `You are a senior career coach. Based on the candidate data below, identify the candidate's strengths, the most important skill gaps holding them back, and concrete learning topics to close those gaps.
+
+Candidate data (JSON):
+%s
+
+Rules:
+- Ground every gap in the data: missing ATS keywords, low interview scores, or skills absent from the resume.
+- Order skill gaps from most to least important. Use priority "high", "medium" or "low".
+- Recommend at most 6 learning topics, each tied to one or more gaps.
+
+Respond ONLY with valid JSON in this exact format:
+{
+  "summary": "Two sentence overview of where the candidate stands",
+  "strengths": ["strength"],
+  "skill_gaps": [
+    {"skill": "Skill name", "priority": "high", "evidence": "Why this is a gap, citing the data"}
+  ],
+  "learning_topics": [
+    {"topic": "Topic name", "reason": "Which gap it closes", "resources": ["Suggested course, book or practice idea"]}
+  ]
+}`
+
+type insightInterview struct {
+	JobPosition   string   `json:"job_position"`
+	OverallScore  *float64 `json:"overall_score"`
+	WeakQuestions []string `json:"weak_questions,omitempty"`
+	CompletedAt   string   `json:"completed_at"`
+}
+
+type insightInput struct {
+	Skills          []string           `json:"resume_skills"`
+	RecentPositions []string           `json:"recent_positions"`
+	Interviews      []insightInterview `json:"interviews"`
+	MissingKeywords []string           `json:"ats_missing_keywords"`
+}
+
+type careerInsightService struct {
+	resumeRepo    domain.ResumeRepository
+	interviewRepo domain.InterviewRepository
+	atsCheckRepo  domain.ATSCheckRepository
+	cacheRepo     domain.CacheRepository
+	aiClient      domain.AIClient
+}
+
+func NewCareerInsightService(
+	resumeRepo domain.ResumeRepository,
+	interviewRepo domain.InterviewRepository,
+	atsCheckRepo domain.ATSCheckRepository,
+	cacheRepo domain.CacheRepository,
+	aiClient domain.AIClient,
+) domain.CareerInsightService {
+	return &careerInsightService{
+		resumeRepo:    resumeRepo,
+		interviewRepo: interviewRepo,
+		atsCheckRepo:  atsCheckRepo,
+		cacheRepo:     cacheRepo,
+		aiClient:      aiClient,
+	}
+}
+
+// GetSkillGapReport serves the cached report when one exists. Only AI
+// generated reports are cached, so a fallback report is retried on the next
+// request instead of being pinned for a day.
+func (s *careerInsightService) GetSkillGapReport(ctx context.Context, userID uuid.UUID) (*domain.SkillGapReport, error) {
+	cacheKey := fmt.Sprintf("%s%s", skillGapCachePrefix, userID.String())
+	cached, err := s.cacheRepo.Get(ctx, cacheKey)
+	if err == nil && cached != "" {
+		var report domain.SkillGapReport
+		if err := json.Unmarshal([]byte(cached), &report); err == nil {
+			return &report, nil
+		}
+	}
+
+	input, err := s.collectInput(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	aiCtx := genai.WithCallMetadata(ctx, domain.AIFeatureCareerInsights, userID.String())
+	report, err := s.generateReport(aiCtx, input)
+	if err != nil {
+		aiStatus := "failed"
+		if s.aiClient == nil {
+			aiStatus = "skipped_no_ai_client"
+		} else if errors.Is(err, genai.ErrBudgetExceeded) {
+			aiStatus = "skipped_budget_exceeded"
+		} else if errors.Is(err, genai.ErrTimeout) {
+			aiStatus = "timed_out"
+		} else if errors.Is(err, genai.ErrUnavailable) {
+			aiStatus = "skipped_ai_unavailable"
+		}
+		report = s.buildFallbackReport(input)
+		report.AIStatus = aiStatus
+	} else {
+		report.AIStatus = "success"
+	}
+
+	now := time.Now()
+	report.GeneratedAt = now
+	report.ExpiresAt = now.Add(skillGapCacheDuration)
+
+	if report.AIStatus == "success" {
+		_ = s.cacheRepo.Set(ctx, cacheKey, report, skillGapCacheDuration)
+	}
+
+	return report, nil
+}
+
+func (s *careerInsightService) collectInput(ctx context.Context, userID uuid.UUID) (*insightInput, error) {
+	input := &insightInput{
+		Skills:          []string{},
+		RecentPositions: []string{},
+		Interviews:      []insightInterview{},
+		MissingKeywords: []string{},
+	}
+
+	resume, err := s.resumeRepo.FindLatestActiveByUserID(ctx, userID)
+	if err != nil && !errors.Is(err, sql.ErrNoRows) {
+		return nil, err
+	}
+	if resume != nil {
+		input.Skills = append(input.Skills, resume.Content.Skills...)
+		for _, exp := range resume.Content.Experience {
+			if exp.Position != "" {
+				input.RecentPositions = append(input.RecentPositions, exp.Position)
+			}
+		}
+	}
+
+	interviews, err := s.interviewRepo.FindByUserID(ctx, userID, insightInterviewLimit, 0)
+	if err != nil {
+		return nil, err
+	}
+	for _, interview := range interviews {
+		if interview.Status != domain.InterviewStatusCompleted || interview.CompletedAt == nil {
+			continue
+		}
+		entry := insightInterview{
+			JobPosition:  interview.JobPosition,
+			OverallScore: interview.OverallScore,
+			CompletedAt:  interview.CompletedAt.UTC().Format(time.RFC3339),
+		}
+		for _, q := range interview.Questions {
+			if q.Score != nil && *q.Score < weakInterviewScore {
+				entry.WeakQuestions = append(entry.WeakQuestions, q.Question)
+			}
+		}
+		input.Interviews = append(input.Interviews, entry)
+	}
+
+	checks, err := s.atsCheckRepo.FindByUserID(ctx, userID, insightATSCheckLimit, 0)
+	if err != nil {
+		return nil, err
+	}
+	seen := make(map[string]bool)
+	for _, check := range checks {
+		if check.Analysis == nil {
+			continue
+		}
+		for _, keyword := range check.Analysis.KeywordAnalysis.Missing {
+			key := strings.ToLower(strings.TrimSpace(keyword))
+			if key == "" || seen[key] {
+				continue
+			}
+			seen[key] = true
+			input.MissingKeywords = append(input.MissingKeywords, keyword)
+		}
+	}
+
+	if resume == nil && len(input.Interviews) == 0 && len(input.MissingKeywords) == 0 {
+		return nil, ErrInsufficientInsightData
+	}
+
+	return input, nil
+}
+
+func (s *careerInsightService) generateReport(ctx context.Context, input *insightInput) (*domain.SkillGapReport, error) {
+	if s.aiClient == nil {
+		return nil, errors.New("genai client not available")
+	}
+
+	inputJSON, err := json.Marshal(input)
+	if err != nil {
+		return nil, err
+	}
+
+	result, err := s.aiClient.GenerateJSON(ctx, fmt.Sprintf(skillGapPrompt, string(inputJSON)))
+	if err != nil {
+		return nil, err
+	}
+
+	var report domain.SkillGapReport
+	if err := json.Unmarshal([]byte(cleanJSONResponse(result)), &report); err != nil {
+		return nil, err
+	}
+
+	return &report, nil
+}
+
+// buildFallbackReport derives a report without AI: ATS keywords missing from
+// the resume skills become gaps, and low-scoring interviews are listed as
+// areas to practise.
+func (s *careerInsightService) buildFallbackReport(input *insightInput) *domain.SkillGapReport {
+	report := &domain.SkillGapReport{
+		Summary:        "AI insights are unavailable right now. This report is based on your ATS keyword gaps and interview scores.",
+		Strengths:      []string{},
+		SkillGaps:      []domain.SkillGap{},
+		LearningTopics: []domain.LearningTopic{},
+	}
+
+	skills := make(map[string]bool, len(input.Skills))
+	for _, skill := range input.Skills {
+		skills[strings.ToLower(strings.TrimSpace(skill))] = true
+	}
+
+	for _, keyword := range input.MissingKeywords {
+		if skills[strings.ToLower(strings.TrimSpace(keyword))] {
+			continue
+		}
+		report.SkillGaps = append(report.SkillGaps, domain.SkillGap{
+			Skill:    keyword,
+			Priority: "medium",
+			Evidence: "Flagged as a missing keyword in a recent ATS check",
+		})
+	}
+
+	for _, interview := range input.Interviews {
+		if interview.OverallScore == nil {
+			continue
+		}
+		if *interview.OverallScore >= weakInterviewScore {
+			report.Strengths = append(report.Strengths, fmt.Sprintf("%s interview (score %.0f)", interview.JobPosition, *interview.OverallScore))
+			continue
+		}
+		report.LearningTopics = append(report.LearningTopics, domain.LearningTopic{
+			Topic:  fmt.Sprintf("%s interview preparation", interview.JobPosition),
+			Reason: fmt.Sprintf("Scored %.0f in a recent mock interview", *interview.OverallScore),
+		})
+	}
+
+	return report
+}
@@ -0,0 +1,171 @@
+package service
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"regexp"
+	"strings"
+
+	"github.com/raflytch/careerly-server/internal/domain"
+)
+
+const minSummaryLength = 50
+
+var quantifiedPattern = regexp.MustCompile(`\d+(\.\d+)?\s*(%|x|k|m|\+)?`)
+
+type completenessService struct {
+	resumeRepo domain.ResumeRepository
+}
+
+func NewCompletenessService(resumeRepo domain.ResumeRepository) domain.CompletenessService {
+	return &completenessService{
+		resumeRepo: resumeRepo,
+	}
+}
+
+func (s *completenessService) GetCompleteness(ctx context.Context, user *domain.User) (*domain.ProfileCompleteness, error) {
+	resume, err := s.resumeRepo.FindLatestActiveByUserID(ctx, user.ID)
+	if err != nil && !errors.Is(err, sql.ErrNoRows) {
+		return nil, err
+	}
+
+	var content domain.ResumeContent
+	if resume != nil {
+		content = resume.Content
+	}
+	info := content.PersonalInfo
+
+	checklist := []domain.CompletenessItem{
+		{
+			Key:       "profile_avatar",
+			Label:     "Profile photo uploaded",
+			Weight:    5,
+			Completed: user.AvatarURL != nil && *user.AvatarURL != "",
+			Hint:      "Upload a profile photo",
+		},
+		{
+			Key:       "has_resume",
+			Label:     "Active resume created",
+			Weight:    10,
+			Completed: resume != nil,
+			Hint:      "Create a resume and keep it active",
+		},
+		{
+			Key:       "contact_info",
+			Label:     "Full name, email, phone and location",
+			Weight:    10,
+			Completed: info.FullName != "" && info.Email != "" && info.Phone != "" && info.Location != "",
+			Hint:      "Fill in all contact details in your resume",
+		},
+		{
+			Key:       "linkedin",
+			Label:     "LinkedIn profile present",
+			Weight:    5,
+			Completed: strings.TrimSpace(info.LinkedIn) != "",
+			Hint:      "Add your LinkedIn URL",
+		},
+		{
+			Key:       "portfolio",
+			Label:     "Portfolio or website present",
+			Weight:    5,
+			Completed: strings.TrimSpace(info.Portfolio) != "",
+			Hint:      "Link to a portfolio, GitHub or personal site",
+		},
+		{
+			Key:       "summary",
+			Label:     "Professional summary",
+			Weight:    10,
+			Completed: len(strings.TrimSpace(content.Summary)) >= minSummaryLength,
+			Hint:      "Write a summary of at least 50 characters",
+		},
+		{
+			Key:       "experience",
+			Label:     "At least one work experience",
+			Weight:    10,
+			Completed: len(content.Experience) > 0,
+			Hint:      "Add your work history",
+		},
+		{
+			Key:       "experience_descriptions",
+			Label:     "Every experience has a description",
+			Weight:    10,
+			Completed: hasDescribedExperience(content.Experience),
+			Hint:      "Describe what you did in each role",
+		},
+		{
+			Key:       "quantified_achievements",
+			Label:     "Quantified achievements",
+			Weight:    10,
+			Completed: hasQuantifiedAchievement(content),
+			Hint:      "Add numbers such as percentages, revenue or team size",
+		},
+		{
+			Key:       "education",
+			Label:     "At least one education entry",
+			Weight:    10,
+			Completed: len(content.Education) > 0,
+			Hint:      "Add your education background",
+		},
+		{
+			Key:       "skills",
+			Label:     "At least 3 skills",
+			Weight:    10,
+			Completed: len(content.Skills) >= 3,
+			Hint:      "List at least three relevant skills",
+		},
+		{
+			Key:       "languages",
+			Label:     "Languages listed",
+			Weight:    5,
+			Completed: len(content.Languages) > 0,
+			Hint:      "List the languages you speak",
+		},
+	}
+
+	result := &domain.ProfileCompleteness{
+		Checklist: checklist,
+	}
+	if resume != nil {
+		result.ResumeID = &resume.ID
+		result.ResumeTitle = resume.Title
+	}
+
+	for _, item := range checklist {
+		result.MaxScore += item.Weight
+		if item.Completed {
+			result.Score += item.Weight
+		}
+	}
+	if result.MaxScore > 0 {
+		result.Percentage = result.Score * 100 / result.MaxScore
+	}
+
+	return result, nil
+}
+
+func hasDescribedExperience(experience []domain.Experience) bool {
+	if len(experience) == 0 {
+		return false
+	}
+	for _, exp := range experience {
+		if strings.TrimSpace(exp.Description) == "" {
+			return false
+		}
+	}
+	return true
+}
+
+func hasQuantifiedAchievement(content domain.ResumeContent) bool {
+	for _, achievement := range content.Achievements {
+		if quantifiedPattern.MatchString(achievement) {
+			return true
+		}
+	}
+	for _, exp := range content.Experience {
+		if quantifiedPattern.MatchString(exp.Description) {
+			return true
+		}
+	}
+	return false
+}
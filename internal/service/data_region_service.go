@@ -0,0 +1,86 @@
+package service
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"sort"
+
+	"github.com/raflytch/careerly-server/internal/config"
+	"github.com/raflytch/careerly-server/internal/domain"
+
+	"github.com/google/uuid"
+)
+
+var (
+	ErrUnknownDataRegion = errors.New("data region is not configured")
+	ErrDataRegionLocked  = errors.New("data region cannot change once the user has resumes")
+)
+
+type dataRegionService struct {
+	regions      config.DataRegionConfig
+	userRepo     domain.UserRepository
+	cacheRepo    domain.CacheRepository
+	auditService domain.AuditService
+}
+
+func NewDataRegionService(regions config.DataRegionConfig, userRepo domain.UserRepository, cacheRepo domain.CacheRepository, auditService domain.AuditService) domain.DataRegionService {
+	return &dataRegionService{
+		regions:      regions,
+		userRepo:     userRepo,
+		cacheRepo:    cacheRepo,
+		auditService: auditService,
+	}
+}
+
+func (s *dataRegionService) Regions() []string {
+	names := make([]string, 0, len(s.regions.Regions))
+	for name := range s.regions.Regions {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// SetUserRegion decides where the user's resume content and PDFs are
+// stored from now on. Existing resumes are not moved, so the region can
+// only change while the user has none, including in the trash.
+func (s *dataRegionService) SetUserRegion(ctx context.Context, userID uuid.UUID, region string) (*domain.User, error) {
+	if _, ok := s.regions.Regions[region]; region != "" && !ok {
+		return nil, ErrUnknownDataRegion
+	}
+
+	user, err := s.userRepo.FindByID(ctx, userID)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, domain.ErrUserNotFound
+		}
+		return nil, err
+	}
+	if user.DataRegion == region {
+		return user, nil
+	}
+
+	updated, err := s.userRepo.UpdateDataRegion(ctx, userID, region)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, domain.ErrUserNotFound
+		}
+		return nil, err
+	}
+	if !updated {
+		return nil, ErrDataRegionLocked
+	}
+
+	before := *user
+	user.DataRegion = region
+
+	cacheKey := fmt.Sprintf("%s%s", userCachePrefix, userID.String())
+	_ = s.cacheRepo.Delete(ctx, cacheKey)
+	_ = s.cacheRepo.DeleteByPattern(ctx, userListCacheKey+"*")
+
+	s.auditService.Record(ctx, domain.AuditActionUserDataRegion, domain.AuditTargetUser, userID, before, user)
+
+	return user, nil
+}
@@ -0,0 +1,301 @@
+package service
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/raflytch/careerly-server/internal/domain"
+
+	"github.com/google/uuid"
+)
+
+const (
+	dataExportBatchSize = 100
+	redactedValue       = "[redacted]"
+)
+
+type dataTransferService struct {
+	transactor    domain.Transactor
+	userRepo      domain.UserRepository
+	resumeRepo    domain.ResumeRepository
+	interviewRepo domain.InterviewRepository
+	atsCheckRepo  domain.ATSCheckRepository
+}
+
+func NewDataTransferService(
+	transactor domain.Transactor,
+	userRepo domain.UserRepository,
+	resumeRepo domain.ResumeRepository,
+	interviewRepo domain.InterviewRepository,
+	atsCheckRepo domain.ATSCheckRepository,
+) domain.DataTransferService {
+	return &dataTransferService{
+		transactor:    transactor,
+		userRepo:      userRepo,
+		resumeRepo:    resumeRepo,
+		interviewRepo: interviewRepo,
+		atsCheckRepo:  atsCheckRepo,
+	}
+}
+
+func (s *dataTransferService) ExportUserData(ctx context.Context, userID uuid.UUID, opts domain.ExportOptions) (*domain.DataBundle, error) {
+	user, err := s.userRepo.FindByID(ctx, userID)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, domain.ErrUserNotFound
+		}
+		return nil, err
+	}
+
+	bundle := &domain.DataBundle{
+		Version:      domain.DataBundleVersion,
+		ExportedAt:   time.Now(),
+		SourceUserID: user.ID,
+		PIIRedacted:  opts.RedactPII,
+		User: domain.BundleUser{
+			Email: user.Email,
+			Name:  user.Name,
+			Role:  user.Role,
+		},
+		Resumes:    make([]domain.BundleResume, 0),
+		Interviews: make([]domain.BundleInterview, 0),
+		ATSChecks:  make([]domain.BundleATSCheck, 0),
+	}
+
+	for offset := 0; ; offset += dataExportBatchSize {
+		resumes, err := s.resumeRepo.FindByUserID(ctx, userID, domain.ResumeFilter{}, dataExportBatchSize, offset)
+		if err != nil {
+			return nil, fmt.Errorf("failed to export resumes: %w", err)
+		}
+		for _, r := range resumes {
+			bundle.Resumes = append(bundle.Resumes, domain.BundleResume{
+				ID:        r.ID,
+				Title:     r.Title,
+				Content:   r.Content,
+				IsActive:  r.IsActive,
+				CreatedAt: r.CreatedAt,
+				UpdatedAt: r.UpdatedAt,
+			})
+		}
+		if len(resumes) < dataExportBatchSize {
+			break
+		}
+	}
+
+	for offset := 0; ; offset += dataExportBatchSize {
+		interviews, err := s.interviewRepo.FindByUserID(ctx, userID, dataExportBatchSize, offset)
+		if err != nil {
+			return nil, fmt.Errorf("failed to export interviews: %w", err)
+		}
+		for _, i := range interviews {
+			bundle.Interviews = append(bundle.Interviews, toBundleInterview(&i))
+		}
+		if len(interviews) < dataExportBatchSize {
+			break
+		}
+	}
+
+	for offset := 0; ; offset += dataExportBatchSize {
+		checks, err := s.atsCheckRepo.FindByUserID(ctx, userID, dataExportBatchSize, offset)
+		if err != nil {
+			return nil, fmt.Errorf("failed to export ats checks: %w", err)
+		}
+		for _, c := range checks {
+			bundle.ATSChecks = append(bundle.ATSChecks, domain.BundleATSCheck{
+				ID:        c.ID,
+				Score:     c.Score,
+				Analysis:  c.Analysis,
+				CreatedAt: c.CreatedAt,
+			})
+		}
+		if len(checks) < dataExportBatchSize {
+			break
+		}
+	}
+
+	if opts.RedactPII {
+		redactBundle(bundle)
+	}
+
+	return bundle, nil
+}
+
+// ImportUserData copies a bundle into the target account in one
+// transaction, so a failure part way leaves nothing behind. Imported resumes
+// start inactive; the target keeps whichever resume it already has active.
+func (s *dataTransferService) ImportUserData(ctx context.Context, targetUserID uuid.UUID, bundle *domain.DataBundle, opts domain.ImportOptions) (*domain.ImportResult, error) {
+	if bundle.Version != domain.DataBundleVersion {
+		return nil, domain.ErrUnsupportedBundleVersion
+	}
+
+	if len(bundle.Resumes) == 0 && len(bundle.Interviews) == 0 && len(bundle.ATSChecks) == 0 {
+		return nil, domain.ErrEmptyDataBundle
+	}
+
+	if _, err := s.userRepo.FindByID(ctx, targetUserID); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, domain.ErrUserNotFound
+		}
+		return nil, err
+	}
+
+	if opts.RedactPII && !bundle.PIIRedacted {
+		redactBundle(bundle)
+	}
+
+	result := &domain.ImportResult{
+		TargetUserID: targetUserID,
+		IDMapping:    make(map[string]uuid.UUID),
+	}
+
+	err := s.transactor.InTx(ctx, func(ctx context.Context) error {
+		for _, r := range bundle.Resumes {
+			resume := &domain.Resume{
+				ID:        uuid.New(),
+				UserID:    targetUserID,
+				Title:     r.Title,
+				Content:   r.Content,
+				IsActive:  false,
+				CreatedAt: r.CreatedAt,
+				UpdatedAt: r.UpdatedAt,
+			}
+			if err := s.resumeRepo.Create(ctx, resume); err != nil {
+				return fmt.Errorf("failed to import resume %s: %w", r.ID, err)
+			}
+			result.IDMapping[r.ID.String()] = resume.ID
+			result.Resumes++
+		}
+
+		for _, i := range bundle.Interviews {
+			interview := fromBundleInterview(&i, targetUserID)
+			if err := s.interviewRepo.Create(ctx, interview); err != nil {
+				return fmt.Errorf("failed to import interview %s: %w", i.ID, err)
+			}
+			if interview.Status != domain.InterviewStatusInProgress {
+				if err := s.interviewRepo.Update(ctx, interview); err != nil {
+					return fmt.Errorf("failed to import interview %s: %w", i.ID, err)
+				}
+			}
+			result.IDMapping[i.ID.String()] = interview.ID
+			result.Interviews++
+		}
+
+		for _, c := range bundle.ATSChecks {
+			check := &domain.ATSCheck{
+				ID:        uuid.New(),
+				UserID:    targetUserID,
+				Score:     c.Score,
+				Analysis:  c.Analysis,
+				CreatedAt: c.CreatedAt,
+			}
+			if err := s.atsCheckRepo.Create(ctx, check); err != nil {
+				return fmt.Errorf("failed to import ats check %s: %w", c.ID, err)
+			}
+			result.IDMapping[c.ID.String()] = check.ID
+			result.ATSChecks++
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return result, nil
+}
+
+func toBundleInterview(interview *domain.Interview) domain.BundleInterview {
+	questions := make([]domain.BundleQuestion, len(interview.Questions))
+	for i, q := range interview.Questions {
+		questions[i] = domain.BundleQuestion{
+			ID:            q.ID,
+			Type:          q.Type,
+			Question:      q.Question,
+			Options:       q.Options,
+			CorrectAnswer: q.CorrectAnswer,
+			UserAnswer:    q.UserAnswer,
+			IsCorrect:     q.IsCorrect,
+			Score:         q.Score,
+			Feedback:      q.Feedback,
+		}
+	}
+
+	return domain.BundleInterview{
+		ID:           interview.ID,
+		JobPosition:  interview.JobPosition,
+		Language:     interview.Language,
+		Questions:    questions,
+		Status:       interview.Status,
+		OverallScore: interview.OverallScore,
+		CreatedAt:    interview.CreatedAt,
+		CompletedAt:  interview.CompletedAt,
+	}
+}
+
+func fromBundleInterview(b *domain.BundleInterview, userID uuid.UUID) *domain.Interview {
+	questions := make([]domain.Question, len(b.Questions))
+	for i, q := range b.Questions {
+		questions[i] = domain.Question{
+			ID:            q.ID,
+			Type:          q.Type,
+			Question:      q.Question,
+			Options:       q.Options,
+			CorrectAnswer: q.CorrectAnswer,
+			UserAnswer:    q.UserAnswer,
+			IsCorrect:     q.IsCorrect,
+			Score:         q.Score,
+			Feedback:      q.Feedback,
+		}
+	}
+
+	status := b.Status
+	if status == "" {
+		status = domain.InterviewStatusInProgress
+	}
+
+	language := b.Language
+	if language == "" {
+		language = domain.InterviewLanguageEnglish
+	}
+
+	return &domain.Interview{
+		ID:           uuid.New(),
+		UserID:       userID,
+		JobPosition:  b.JobPosition,
+		Language:     language,
+		Questions:    questions,
+		Status:       status,
+		OverallScore: b.OverallScore,
+		CreatedAt:    b.CreatedAt,
+		CompletedAt:  b.CompletedAt,
+	}
+}
+
+func redactBundle(bundle *domain.DataBundle) {
+	bundle.User.Email = fmt.Sprintf("user-%s@redacted.local", bundle.SourceUserID.String()[:8])
+	bundle.User.Name = redactedValue
+
+	for i := range bundle.Resumes {
+		redactPersonalInfo(&bundle.Resumes[i].Content.PersonalInfo)
+	}
+
+	bundle.PIIRedacted = true
+}
+
+func redactPersonalInfo(info *domain.PersonalInfo) {
+	info.FullName = redactedValue
+	info.Email = "redacted@example.com"
+	info.Phone = redactedValue
+	if info.Location != "" {
+		info.Location = redactedValue
+	}
+	if info.LinkedIn != "" {
+		info.LinkedIn = redactedValue
+	}
+	if info.Portfolio != "" {
+		info.Portfolio = redactedValue
+	}
+	info.DateOfBirth = ""
+}
@@ -2,32 +2,258 @@ package service
 
 import (
 	"context"
+	"crypto/subtle"
+	"errors"
 	"fmt"
-	"net/smtp"
+	"log"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
 
-	"github.com/raflytch/careerly-server/internal/config"
 	"github.com/raflytch/careerly-server/internal/domain"
+	"github.com/raflytch/careerly-server/pkg/mailer"
+
+	"github.com/google/uuid"
+)
+
+var (
+	ErrEmailSuppressed            = errors.New("email address is suppressed after a bounce or complaint")
+	ErrEmailSuppressionNotFound   = errors.New("email suppression not found")
+	ErrInvalidEmailCallbackToken  = errors.New("invalid email callback token")
+	ErrUnsupportedEmailProvider   = errors.New("unsupported email provider")
+	ErrInvalidEmailCallbackFormat = errors.New("invalid email callback payload")
 )
 
 type emailService struct {
-	cfg config.SMTPConfig
+	sender        mailer.Sender
+	emailRepo     domain.EmailRepository
+	userRepo      domain.UserRepository
+	callbackToken string
+	httpClient    *http.Client
+}
+
+func NewEmailService(sender mailer.Sender, emailRepo domain.EmailRepository, userRepo domain.UserRepository, callbackToken string) domain.EmailService {
+	return &emailService{
+		sender:        sender,
+		emailRepo:     emailRepo,
+		userRepo:      userRepo,
+		callbackToken: callbackToken,
+		httpClient:    &http.Client{Timeout: 10 * time.Second},
+	}
+}
+
+// sendEmail refuses addresses that hard bounced or complained before, and
+// records every attempt so provider callbacks can update its status.
+func (s *emailService) sendEmail(ctx context.Context, to, subject, body string) error {
+	suppressed, err := s.emailRepo.IsSuppressed(ctx, to)
+	if err != nil {
+		return err
+	}
+
+	message := &domain.EmailMessage{
+		ID:        uuid.New(),
+		UserID:    s.userIDFor(ctx, to),
+		Recipient: to,
+		Subject:   subject,
+		Provider:  s.sender.Driver(),
+		Status:    domain.EmailStatusSent,
+		CreatedAt: time.Now(),
+	}
+
+	var sendErr error
+	if suppressed {
+		message.Status = domain.EmailStatusSuppressed
+		sendErr = ErrEmailSuppressed
+	} else {
+		message.ProviderMessageID, sendErr = s.sender.Send(ctx, mailer.Message{To: to, Subject: subject, Text: body})
+		if sendErr != nil {
+			message.Status = domain.EmailStatusFailed
+			message.Detail = sendErr.Error()
+			if mailer.IsPermanent(sendErr) {
+				message.Status = domain.EmailStatusBounced
+				s.suppress(ctx, to, message.UserID, domain.EmailStatusBounced, sendErr.Error())
+			}
+		}
+	}
+
+	message.UpdatedAt = message.CreatedAt
+	if err := s.emailRepo.CreateMessage(ctx, message); err != nil {
+		log.Printf("Failed to record email to %s: %v", to, err)
+	}
+
+	return sendErr
+}
+
+func (s *emailService) userIDFor(ctx context.Context, email string) *uuid.UUID {
+	user, err := s.userRepo.FindByEmail(ctx, email)
+	if err != nil || user == nil {
+		return nil
+	}
+	return &user.ID
+}
+
+func (s *emailService) suppress(ctx context.Context, email string, userID *uuid.UUID, reason domain.EmailStatus, detail string) {
+	suppression := &domain.EmailSuppression{
+		Email:     email,
+		UserID:    userID,
+		Reason:    reason,
+		Provider:  s.sender.Driver(),
+		Detail:    detail,
+		CreatedAt: time.Now(),
+	}
+	if err := s.emailRepo.Suppress(ctx, suppression); err != nil {
+		log.Printf("Failed to suppress email %s: %v", email, err)
+	}
+}
+
+// HandleProviderEvents applies a delivery status callback from SendGrid's
+// Event Webhook or SES (through SNS). Hard bounces and complaints suppress
+// the address so nothing is sent to it again.
+func (s *emailService) HandleProviderEvents(ctx context.Context, provider, token string, body []byte) error {
+	if s.callbackToken == "" || subtle.ConstantTimeCompare([]byte(token), []byte(s.callbackToken)) != 1 {
+		return ErrInvalidEmailCallbackToken
+	}
+
+	var events []mailer.Event
+	switch provider {
+	case mailer.DriverSendGrid:
+		parsed, err := mailer.ParseSendGridEvents(body)
+		if err != nil {
+			return ErrInvalidEmailCallbackFormat
+		}
+		events = parsed
+	case mailer.DriverSES:
+		msg, err := mailer.ParseSNSMessage(body)
+		if err != nil {
+			return ErrInvalidEmailCallbackFormat
+		}
+		if msg.Type == mailer.SNSSubscriptionConfirmation {
+			return s.confirmSNSSubscription(ctx, msg.SubscribeURL)
+		}
+		if msg.Type != mailer.SNSNotification {
+			return nil
+		}
+		parsed, err := mailer.ParseSESEvents(msg.Message)
+		if err != nil {
+			return ErrInvalidEmailCallbackFormat
+		}
+		events = parsed
+	default:
+		return ErrUnsupportedEmailProvider
+	}
+
+	for _, event := range events {
+		status := emailStatusFromEvent(event.Type)
+		if event.MessageID != "" {
+			if err := s.emailRepo.UpdateMessageStatus(ctx, provider, event.MessageID, status, event.Detail); err != nil {
+				return err
+			}
+		}
+		if event.Permanent && event.Recipient != "" {
+			suppression := &domain.EmailSuppression{
+				Email:     event.Recipient,
+				UserID:    s.userIDFor(ctx, event.Recipient),
+				Reason:    status,
+				Provider:  provider,
+				Detail:    event.Detail,
+				CreatedAt: time.Now(),
+			}
+			if err := s.emailRepo.Suppress(ctx, suppression); err != nil {
+				return err
+			}
+		}
+	}
+
+	return nil
+}
+
+func (s *emailService) confirmSNSSubscription(ctx context.Context, subscribeURL string) error {
+	u, err := url.Parse(subscribeURL)
+	if err != nil || u.Scheme != "https" || !strings.HasSuffix(u.Hostname(), ".amazonaws.com") {
+		return ErrInvalidEmailCallbackFormat
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, u.String(), nil)
+	if err != nil {
+		return err
+	}
+	resp, err := s.httpClient.Do(req)
+	if err != nil {
+		return fmt.Errorf("failed to confirm SNS subscription: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode >= 300 {
+		return fmt.Errorf("SNS subscription confirmation returned %d", resp.StatusCode)
+	}
+	return nil
 }
 
-func NewEmailService(cfg config.SMTPConfig) domain.EmailService {
-	return &emailService{cfg: cfg}
+func emailStatusFromEvent(eventType mailer.EventType) domain.EmailStatus {
+	switch eventType {
+	case mailer.EventDelivered:
+		return domain.EmailStatusDelivered
+	case mailer.EventBounced:
+		return domain.EmailStatusBounced
+	case mailer.EventComplained:
+		return domain.EmailStatusComplained
+	default:
+		return domain.EmailStatusFailed
+	}
 }
 
-func (s *emailService) sendEmail(to, subject, body string) error {
-	auth := smtp.PlainAuth("", s.cfg.Username, s.cfg.Password, s.cfg.Host)
+func (s *emailService) GetSuppressions(ctx context.Context, page, limit int) (*domain.PaginatedEmailSuppressions, error) {
+	if page < 1 {
+		page = 1
+	}
+	if limit < 1 {
+		limit = 10
+	}
+	if limit > 100 {
+		limit = 100
+	}
 
-	msg := fmt.Sprintf("From: %s\r\nTo: %s\r\nSubject: %s\r\nMIME-Version: 1.0\r\nContent-Type: text/plain; charset=UTF-8\r\n\r\n%s",
-		s.cfg.From, to, subject, body)
+	offset := (page - 1) * limit
 
-	addr := fmt.Sprintf("%s:%d", s.cfg.Host, s.cfg.Port)
-	return smtp.SendMail(addr, auth, s.cfg.From, []string{to}, []byte(msg))
+	total, err := s.emailRepo.CountSuppressions(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	suppressions, err := s.emailRepo.FindSuppressions(ctx, limit, offset)
+	if err != nil {
+		return nil, err
+	}
+
+	totalPages := int(total) / limit
+	if int(total)%limit > 0 {
+		totalPages++
+	}
+
+	return &domain.PaginatedEmailSuppressions{
+		Suppressions: suppressions,
+		Pagination: domain.Pagination{
+			Page:       page,
+			Limit:      limit,
+			Total:      total,
+			TotalPages: totalPages,
+		},
+	}, nil
+}
+
+func (s *emailService) RemoveSuppression(ctx context.Context, email string) error {
+	removed, err := s.emailRepo.DeleteSuppression(ctx, email)
+	if err != nil {
+		return err
+	}
+	if !removed {
+		return ErrEmailSuppressionNotFound
+	}
+	return nil
 }
 
-func (s *emailService) SendOTP(_ context.Context, email, otp string) error {
+func (s *emailService) SendOTP(ctx context.Context, email, otp string) error {
 	subject := "Your Account Restoration OTP - Careerly"
 	body := fmt.Sprintf(
 		"Careerly - Account Restoration\n\n"+
@@ -37,10 +263,10 @@ func (s *emailService) SendOTP(_ context.Context, email, otp string) error {
 			"If you did not request this restoration, please ignore this email.\n\n"+
 			"Careerly Team", otp)
 
-	return s.sendEmail(email, subject, body)
+	return s.sendEmail(ctx, email, subject, body)
 }
 
-func (s *emailService) SendDeleteOTP(_ context.Context, email, otp string) error {
+func (s *emailService) SendDeleteOTP(ctx context.Context, email, otp string) error {
 	subject := "Account Deletion Confirmation OTP - Careerly"
 	body := fmt.Sprintf(
 		"Careerly - Account Deletion\n\n"+
@@ -51,5 +277,89 @@ func (s *emailService) SendDeleteOTP(_ context.Context, email, otp string) error
 			"If you did not request this deletion, please ignore this email and secure your account immediately.\n\n"+
 			"Careerly Team", otp)
 
-	return s.sendEmail(email, subject, body)
+	return s.sendEmail(ctx, email, subject, body)
+}
+
+func (s *emailService) SendAccountPurgeNotice(ctx context.Context, email string, purgeAt time.Time) error {
+	subject := "Your Account Will Be Permanently Deleted Soon - Careerly"
+	body := fmt.Sprintf(
+		"Careerly - Account Deletion\n\n"+
+			"Your Careerly account was deleted and will be permanently removed on %s, together with your resumes, interviews and ATS checks.\n\n"+
+			"If you want to keep your account, restore it before then from the sign-in page.\n"+
+			"After that date your data cannot be recovered.\n\n"+
+			"Careerly Team", purgeAt.UTC().Format("Monday, 02 Jan 2006 15:04 MST"))
+
+	return s.sendEmail(ctx, email, subject, body)
+}
+
+func (s *emailService) SendContactEmailOTP(ctx context.Context, email, otp string) error {
+	subject := "Confirm Your Contact Email - Careerly"
+	body := fmt.Sprintf(
+		"Careerly - Contact Email Change\n\n"+
+			"Someone asked to use this address as the contact email of a Careerly account.\n\n"+
+			"Your OTP Code: %s\n\n"+
+			"This OTP will expire in 15 minutes. Do not share this code with anyone.\n\n"+
+			"If you did not request this, you can ignore this email and nothing will change.\n\n"+
+			"Careerly Team", otp)
+
+	return s.sendEmail(ctx, email, subject, body)
+}
+
+func (s *emailService) SendLoginOTP(ctx context.Context, email, otp string) error {
+	subject := "Your Login Verification Code - Careerly"
+	body := fmt.Sprintf(
+		"Careerly - Login Verification\n\n"+
+			"Someone just signed in to your Careerly account with Google.\n\n"+
+			"Your OTP Code: %s\n\n"+
+			"This OTP will expire in 15 minutes. Do not share this code with anyone.\n\n"+
+			"If this wasn't you, your Google account may be compromised. Please secure it immediately.\n\n"+
+			"Careerly Team", otp)
+
+	return s.sendEmail(ctx, email, subject, body)
+}
+
+func (s *emailService) SendInterviewReminder(ctx context.Context, email, jobPosition string, scheduledAt time.Time) error {
+	subject := "Your Mock Interview Starts Soon - Careerly"
+	body := fmt.Sprintf(
+		"Careerly - Interview Reminder\n\n"+
+			"Your mock interview for the %s position is scheduled for %s.\n\n"+
+			"Your questions will be ready at the scheduled time. Find a quiet place and good luck!\n\n"+
+			"Careerly Team", jobPosition, scheduledAt.UTC().Format("Monday, 02 Jan 2006 15:04 MST"))
+
+	return s.sendEmail(ctx, email, subject, body)
+}
+
+func (s *emailService) SendGiftCode(ctx context.Context, email, senderName, planName, code string) error {
+	subject := "You've Been Gifted Careerly " + planName
+	body := fmt.Sprintf(
+		"Careerly - Gift Subscription\n\n"+
+			"%s bought you the Careerly %s plan.\n\n"+
+			"Your Redemption Code: %s\n\n"+
+			"Sign in to Careerly with any account and enter this code to activate the plan. The code can be used once.\n\n"+
+			"Careerly Team", senderName, planName, code)
+
+	return s.sendEmail(ctx, email, subject, body)
+}
+
+func (s *emailService) SendQuotaWarning(ctx context.Context, email, feature string, threshold, used, limit int) error {
+	subject := fmt.Sprintf("You've Used %d%% of Your %s - Careerly", threshold, feature)
+	body := fmt.Sprintf(
+		"Careerly - Usage Update\n\n"+
+			"You have used %d of the %d %s in your plan this month.\n\n"+
+			"Your quota resets at the start of next month. If you need more before then, you can upgrade your plan or buy an add-on pack.\n\n"+
+			"Careerly Team", used, limit, feature)
+
+	return s.sendEmail(ctx, email, subject, body)
+}
+
+func (s *emailService) SendOrganizationInvite(ctx context.Context, email, inviterName, organizationName, inviteURL string) error {
+	subject := fmt.Sprintf("Join %s on Careerly", organizationName)
+	body := fmt.Sprintf(
+		"Careerly - Team Invitation\n\n"+
+			"%s invited you to join %s on Careerly. Members share the team's plan and quota.\n\n"+
+			"Accept the invitation: %s\n\n"+
+			"Sign in with this email address to accept. The link expires in 7 days.\n\n"+
+			"Careerly Team", inviterName, organizationName, inviteURL)
+
+	return s.sendEmail(ctx, email, subject, body)
 }
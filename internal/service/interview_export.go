@@ -0,0 +1,128 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/raflytch/careerly-server/internal/domain"
+
+	"github.com/google/uuid"
+)
+
+var ErrUnsupportedExportFormat = errors.New("unsupported export format, use json or markdown")
+
+func (s *interviewService) Export(ctx context.Context, userID uuid.UUID, id uuid.UUID, format domain.InterviewExportFormat) (*domain.InterviewExport, error) {
+	if format != domain.InterviewExportJSON && format != domain.InterviewExportMarkdown {
+		return nil, ErrUnsupportedExportFormat
+	}
+
+	interview, err := s.GetByID(ctx, userID, id)
+	if err != nil {
+		return nil, err
+	}
+
+	transcript := &domain.InterviewTranscript{
+		ExportedAt: time.Now().UTC(),
+		Interview:  interview,
+	}
+	filename := fmt.Sprintf("interview_%s", interview.ID.String())
+
+	if format == domain.InterviewExportMarkdown {
+		return &domain.InterviewExport{
+			Filename:    filename + ".md",
+			ContentType: "text/markdown; charset=utf-8",
+			Data:        []byte(renderInterviewMarkdown(transcript)),
+		}, nil
+	}
+
+	data, err := json.MarshalIndent(transcript, "", "  ")
+	if err != nil {
+		return nil, err
+	}
+
+	return &domain.InterviewExport{
+		Filename:    filename + ".json",
+		ContentType: "application/json",
+		Data:        data,
+	}, nil
+}
+
+func renderInterviewMarkdown(transcript *domain.InterviewTranscript) string {
+	interview := transcript.Interview
+	var b strings.Builder
+
+	fmt.Fprintf(&b, "# Interview: %s\n\n", interview.JobPosition)
+	fmt.Fprintf(&b, "- **Status:** %s\n", interview.Status)
+	fmt.Fprintf(&b, "- **Started:** %s\n", interview.CreatedAt.UTC().Format(time.RFC1123))
+	if interview.CompletedAt != nil {
+		fmt.Fprintf(&b, "- **Completed:** %s\n", interview.CompletedAt.UTC().Format(time.RFC1123))
+	}
+	if interview.OverallScore != nil {
+		fmt.Fprintf(&b, "- **Overall score:** %.1f\n", *interview.OverallScore)
+	}
+	fmt.Fprintf(&b, "- **Exported:** %s\n", transcript.ExportedAt.Format(time.RFC1123))
+
+	if len(interview.Questions) == 0 {
+		b.WriteString("\n_No questions available yet._\n")
+		return b.String()
+	}
+
+	for i, q := range interview.Questions {
+		fmt.Fprintf(&b, "\n## Question %d", i+1)
+		details := []string{string(q.Type)}
+		if q.Difficulty != "" {
+			details = append(details, string(q.Difficulty))
+		}
+		if q.Round > 0 {
+			details = append(details, fmt.Sprintf("round %d", q.Round))
+		}
+		fmt.Fprintf(&b, " (%s)\n\n%s\n", strings.Join(details, ", "), q.Question)
+
+		if len(q.Options) > 0 {
+			b.WriteString("\n")
+			for _, option := range q.Options {
+				fmt.Fprintf(&b, "- **%s.** %s\n", option.Label, option.Text)
+			}
+		}
+
+		answer := q.UserAnswer
+		if answer == "" {
+			answer = "_Not answered_"
+		}
+		fmt.Fprintf(&b, "\n**Your answer:**\n\n%s\n", quoteMarkdown(answer))
+		if q.VideoAnswer != nil {
+			fmt.Fprintf(&b, "\n**Video answer:** %s\n", q.VideoAnswer.URL)
+		}
+
+		if q.IsCorrect != nil {
+			result := "Incorrect"
+			if *q.IsCorrect {
+				result = "Correct"
+			}
+			fmt.Fprintf(&b, "\n**Result:** %s\n", result)
+		}
+		if q.Score != nil {
+			fmt.Fprintf(&b, "\n**Score:** %.1f\n", *q.Score)
+		}
+		for _, criterion := range q.CriterionScores {
+			fmt.Fprintf(&b, "- %s: %.1f\n", criterion.Name, criterion.Score)
+		}
+		if q.Feedback != "" {
+			fmt.Fprintf(&b, "\n**Feedback:**\n\n%s\n", q.Feedback)
+		}
+	}
+
+	return b.String()
+}
+
+func quoteMarkdown(text string) string {
+	lines := strings.Split(strings.TrimSpace(text), "\n")
+	for i, line := range lines {
+		lines[i] = "> " + line
+	}
+	return strings.Join(lines, "\n")
+}
@@ -0,0 +1,247 @@
+package service
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"strings"
+	"time"
+
+	"github.com/raflytch/careerly-server/internal/domain"
+
+	"github.com/google/uuid"
+)
+
+var (
+	ErrInterviewPackNotFound = errors.New("interview pack not found")
+	ErrInvalidPackQuestion   = errors.New("multiple choice questions need options and a correct answer matching one of their labels; essay questions take no options")
+)
+
+type interviewPackService struct {
+	packRepo     domain.InterviewPackRepository
+	auditService domain.AuditService
+}
+
+func NewInterviewPackService(packRepo domain.InterviewPackRepository, auditService domain.AuditService) domain.InterviewPackService {
+	return &interviewPackService{
+		packRepo:     packRepo,
+		auditService: auditService,
+	}
+}
+
+func (s *interviewPackService) Create(ctx context.Context, req *domain.CreateInterviewPackRequest) (*domain.InterviewPack, error) {
+	if err := validatePackQuestions(req.Questions); err != nil {
+		return nil, err
+	}
+	if err := validateRubric(req.Rubric); err != nil {
+		return nil, err
+	}
+
+	isActive := true
+	if req.IsActive != nil {
+		isActive = *req.IsActive
+	}
+
+	now := time.Now()
+	pack := &domain.InterviewPack{
+		ID:          uuid.New(),
+		Title:       req.Title,
+		Description: req.Description,
+		JobPosition: req.JobPosition,
+		Questions:   req.Questions,
+		Rubric:      req.Rubric,
+		IsActive:    isActive,
+		CreatedAt:   now,
+		UpdatedAt:   now,
+	}
+
+	if err := s.packRepo.Create(ctx, pack); err != nil {
+		return nil, err
+	}
+
+	s.auditService.Record(ctx, domain.AuditActionInterviewPackCreate, domain.AuditTargetInterviewPack, pack.ID, nil, pack)
+
+	return pack, nil
+}
+
+func (s *interviewPackService) GetByID(ctx context.Context, id uuid.UUID) (*domain.InterviewPack, error) {
+	pack, err := s.packRepo.FindByID(ctx, id)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, ErrInterviewPackNotFound
+		}
+		return nil, err
+	}
+	return pack, nil
+}
+
+func (s *interviewPackService) GetAll(ctx context.Context, page, limit int, includeInactive bool) (*domain.PaginatedInterviewPacks, error) {
+	if page < 1 {
+		page = 1
+	}
+	if limit < 1 {
+		limit = 10
+	}
+	if limit > 100 {
+		limit = 100
+	}
+
+	offset := (page - 1) * limit
+
+	total, err := s.packRepo.Count(ctx, includeInactive)
+	if err != nil {
+		return nil, err
+	}
+
+	packs, err := s.packRepo.FindAll(ctx, limit, offset, includeInactive)
+	if err != nil {
+		return nil, err
+	}
+
+	totalPages := int(total) / limit
+	if int(total)%limit > 0 {
+		totalPages++
+	}
+
+	return &domain.PaginatedInterviewPacks{
+		Packs: packs,
+		Pagination: domain.Pagination{
+			Page:       page,
+			Limit:      limit,
+			Total:      total,
+			TotalPages: totalPages,
+		},
+	}, nil
+}
+
+// ListAvailable lists active packs without their questions, so answers are
+// never exposed before an interview is started from a pack.
+func (s *interviewPackService) ListAvailable(ctx context.Context, page, limit int) (*domain.PaginatedInterviewPackSummaries, error) {
+	result, err := s.GetAll(ctx, page, limit, false)
+	if err != nil {
+		return nil, err
+	}
+
+	summaries := make([]domain.InterviewPackSummary, len(result.Packs))
+	for i, pack := range result.Packs {
+		summaries[i] = toInterviewPackSummary(&pack)
+	}
+
+	return &domain.PaginatedInterviewPackSummaries{
+		Packs:      summaries,
+		Pagination: result.Pagination,
+	}, nil
+}
+
+func (s *interviewPackService) Update(ctx context.Context, id uuid.UUID, req *domain.UpdateInterviewPackRequest) (*domain.InterviewPack, error) {
+	pack, err := s.packRepo.FindByID(ctx, id)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, ErrInterviewPackNotFound
+		}
+		return nil, err
+	}
+
+	before := *pack
+
+	if req.Title != nil {
+		pack.Title = *req.Title
+	}
+	if req.Description != nil {
+		pack.Description = *req.Description
+	}
+	if req.JobPosition != nil {
+		pack.JobPosition = *req.JobPosition
+	}
+	if req.Questions != nil {
+		if err := validatePackQuestions(req.Questions); err != nil {
+			return nil, err
+		}
+		pack.Questions = req.Questions
+	}
+	if req.Rubric != nil {
+		if err := validateRubric(req.Rubric); err != nil {
+			return nil, err
+		}
+		pack.Rubric = req.Rubric
+	}
+	if req.IsActive != nil {
+		pack.IsActive = *req.IsActive
+	}
+	pack.UpdatedAt = time.Now()
+
+	if err := s.packRepo.Update(ctx, pack); err != nil {
+		return nil, err
+	}
+
+	s.auditService.Record(ctx, domain.AuditActionInterviewPackUpdate, domain.AuditTargetInterviewPack, id, before, pack)
+
+	return pack, nil
+}
+
+func (s *interviewPackService) Delete(ctx context.Context, id uuid.UUID) error {
+	pack, err := s.packRepo.FindByID(ctx, id)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return ErrInterviewPackNotFound
+		}
+		return err
+	}
+
+	if err := s.packRepo.SoftDelete(ctx, id); err != nil {
+		return err
+	}
+
+	s.auditService.Record(ctx, domain.AuditActionInterviewPackDelete, domain.AuditTargetInterviewPack, id, pack, nil)
+
+	return nil
+}
+
+func validatePackQuestions(questions []domain.InterviewPackQuestion) error {
+	for _, q := range questions {
+		switch q.Type {
+		case domain.QuestionTypeMultipleChoice:
+			if len(q.Options) < 2 {
+				return ErrInvalidPackQuestion
+			}
+			matched := false
+			for _, option := range q.Options {
+				if strings.TrimSpace(option.Label) == "" || strings.TrimSpace(option.Text) == "" {
+					return ErrInvalidPackQuestion
+				}
+				if strings.EqualFold(option.Label, q.CorrectAnswer) {
+					matched = true
+				}
+			}
+			if !matched {
+				return ErrInvalidPackQuestion
+			}
+		case domain.QuestionTypeEssay:
+			if len(q.Options) > 0 {
+				return ErrInvalidPackQuestion
+			}
+		}
+	}
+	return nil
+}
+
+func toInterviewPackSummary(pack *domain.InterviewPack) domain.InterviewPackSummary {
+	types := make([]domain.QuestionType, 0, 2)
+	seen := make(map[domain.QuestionType]bool)
+	for _, q := range pack.Questions {
+		if !seen[q.Type] {
+			seen[q.Type] = true
+			types = append(types, q.Type)
+		}
+	}
+
+	return domain.InterviewPackSummary{
+		ID:            pack.ID,
+		Title:         pack.Title,
+		Description:   pack.Description,
+		JobPosition:   pack.JobPosition,
+		QuestionCount: len(pack.Questions),
+		QuestionTypes: types,
+		Rubric:        pack.Rubric,
+	}
+}
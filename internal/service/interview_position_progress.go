@@ -0,0 +1,132 @@
+package service
+
+import (
+	"context"
+	"strings"
+
+	"github.com/raflytch/careerly-server/internal/domain"
+
+	"github.com/google/uuid"
+)
+
+const (
+	positionProgressLimit = 100
+	// trendSlopeThreshold is how many points per interview the fitted score
+	// has to move before the trend counts as improving or declining.
+	trendSlopeThreshold = 1.0
+)
+
+var progressQuestionTypes = []domain.QuestionType{domain.QuestionTypeEssay, domain.QuestionTypeMultipleChoice}
+
+// GetPositionProgress charts the user's completed interviews for a job
+// position: the overall score of each, the average score per question type
+// and plain statistics over both. It makes no AI calls.
+func (s *interviewService) GetPositionProgress(ctx context.Context, userID uuid.UUID, position string) (*domain.InterviewPositionProgress, error) {
+	position = strings.TrimSpace(position)
+
+	interviews, err := s.interviewRepo.FindCompletedByPosition(ctx, userID, position, positionProgressLimit)
+	if err != nil {
+		return nil, err
+	}
+
+	progress := &domain.InterviewPositionProgress{
+		JobPosition:    position,
+		Points:         make([]domain.InterviewScorePoint, 0, len(interviews)),
+		ByQuestionType: make([]domain.QuestionTypeProgress, 0, len(progressQuestionTypes)),
+	}
+	byType := make(map[domain.QuestionType][]domain.InterviewScorePoint, len(progressQuestionTypes))
+
+	for _, interview := range interviews {
+		if interview.OverallScore == nil || interview.CompletedAt == nil {
+			continue
+		}
+		progress.Points = append(progress.Points, domain.InterviewScorePoint{
+			InterviewID: interview.ID,
+			CompletedAt: *interview.CompletedAt,
+			Score:       roundScore(*interview.OverallScore),
+		})
+
+		totals := make(map[domain.QuestionType]float64, len(progressQuestionTypes))
+		counts := make(map[domain.QuestionType]int, len(progressQuestionTypes))
+		for _, q := range interview.Questions {
+			if q.Score != nil {
+				totals[q.Type] += *q.Score
+				counts[q.Type]++
+			}
+		}
+		for _, questionType := range progressQuestionTypes {
+			if counts[questionType] == 0 {
+				continue
+			}
+			byType[questionType] = append(byType[questionType], domain.InterviewScorePoint{
+				InterviewID: interview.ID,
+				CompletedAt: *interview.CompletedAt,
+				Score:       roundScore(totals[questionType] / float64(counts[questionType])),
+			})
+		}
+	}
+
+	progress.Stats = scoreStats(progress.Points)
+	for _, questionType := range progressQuestionTypes {
+		points := byType[questionType]
+		if len(points) == 0 {
+			continue
+		}
+		progress.ByQuestionType = append(progress.ByQuestionType, domain.QuestionTypeProgress{
+			QuestionType: questionType,
+			Stats:        scoreStats(points),
+			Points:       points,
+		})
+	}
+
+	return progress, nil
+}
+
+func scoreStats(points []domain.InterviewScorePoint) domain.InterviewScoreStats {
+	stats := domain.InterviewScoreStats{
+		Count: len(points),
+		Trend: domain.InterviewTrendSteady,
+	}
+	if len(points) == 0 {
+		return stats
+	}
+
+	var total float64
+	best := points[0].Score
+	for _, point := range points {
+		total += point.Score
+		best = max(best, point.Score)
+	}
+
+	slope := scoreSlope(points)
+	stats.Average = roundScore(total / float64(len(points)))
+	stats.Best = best
+	stats.Latest = points[len(points)-1].Score
+	stats.Slope = roundScore(slope)
+	switch {
+	case slope >= trendSlopeThreshold:
+		stats.Trend = domain.InterviewTrendImproving
+	case slope <= -trendSlopeThreshold:
+		stats.Trend = domain.InterviewTrendDeclining
+	}
+	return stats
+}
+
+// scoreSlope fits a least-squares line through the scores in order and
+// returns its change per interview.
+func scoreSlope(points []domain.InterviewScorePoint) float64 {
+	if len(points) < 2 {
+		return 0
+	}
+
+	n := float64(len(points))
+	var sumX, sumY, sumXY, sumXX float64
+	for i, point := range points {
+		x := float64(i)
+		sumX += x
+		sumY += point.Score
+		sumXY += x * point.Score
+		sumXX += x * x
+	}
+	return (n*sumXY - sumX*sumY) / (n*sumXX - sumX*sumX)
+}
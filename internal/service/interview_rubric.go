@@ -0,0 +1,88 @@
+package service
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"strings"
+
+	"github.com/raflytch/careerly-server/internal/domain"
+)
+
+var ErrInvalidRubric = errors.New("rubric criteria must have unique names")
+
+// rubricPromptSection is appended to the evaluation prompt rather than
+// being a placeholder of it, so prompt versions stored before rubrics
+// existed keep working.
+const rubricPromptSection = `
+
+Also score every essay answer against this rubric. Score each criterion from 0 to 100 and add a "criterion_scores" array to that answer's object, naming the criteria exactly as written below:
+%s
+
+For example: "criterion_scores": [{"name": %q, "score": 80}]`
+
+type criterionResult struct {
+	Name  string  `json:"name"`
+	Score float64 `json:"score"`
+}
+
+func validateRubric(rubric []domain.RubricCriterion) error {
+	seen := make(map[string]bool, len(rubric))
+	for _, criterion := range rubric {
+		name := strings.ToLower(strings.TrimSpace(criterion.Name))
+		if name == "" || seen[name] {
+			return ErrInvalidRubric
+		}
+		seen[name] = true
+	}
+	return nil
+}
+
+func rubricPrompt(rubric []domain.RubricCriterion) string {
+	if len(rubric) == 0 {
+		return ""
+	}
+
+	var total float64
+	for _, criterion := range rubric {
+		total += criterion.Weight
+	}
+
+	lines := make([]string, len(rubric))
+	for i, criterion := range rubric {
+		lines[i] = fmt.Sprintf("- %s (%.0f%% of the score)", criterion.Name, criterion.Weight/total*100)
+	}
+	return fmt.Sprintf(rubricPromptSection, strings.Join(lines, "\n"), rubric[0].Name)
+}
+
+// applyRubric stores the per-criterion scores of an essay answer and
+// replaces its score with their weighted average. Criteria the model left
+// out are not counted; when it scored none of them the score it gave
+// overall is kept.
+func applyRubric(rubric []domain.RubricCriterion, q *domain.Question, results []criterionResult) {
+	if len(rubric) == 0 || q.Type != domain.QuestionTypeEssay {
+		return
+	}
+
+	scores := make([]domain.CriterionScore, 0, len(rubric))
+	var weighted, weights float64
+	for _, criterion := range rubric {
+		for _, result := range results {
+			if !strings.EqualFold(strings.TrimSpace(result.Name), strings.TrimSpace(criterion.Name)) {
+				continue
+			}
+			score := math.Max(0, math.Min(100, result.Score))
+			scores = append(scores, domain.CriterionScore{Name: criterion.Name, Weight: criterion.Weight, Score: score})
+			weighted += score * criterion.Weight
+			weights += criterion.Weight
+			break
+		}
+	}
+	if len(scores) == 0 {
+		return
+	}
+
+	overall := weighted / weights
+	q.CriterionScores = scores
+	q.Score = &overall
+}
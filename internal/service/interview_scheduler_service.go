@@ -0,0 +1,96 @@
+package service
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/raflytch/careerly-server/internal/domain"
+
+	"github.com/google/uuid"
+)
+
+const reminderBatchSize = 100
+
+type interviewSchedulerService struct {
+	interviewRepo domain.InterviewRepository
+	userRepo      domain.UserRepository
+	emailService  domain.EmailService
+	jobs          domain.JobEnqueuer
+	reminderLead  time.Duration
+}
+
+func NewInterviewSchedulerService(
+	interviewRepo domain.InterviewRepository,
+	userRepo domain.UserRepository,
+	emailService domain.EmailService,
+	jobs domain.JobEnqueuer,
+	reminderLead time.Duration,
+) domain.InterviewSchedulerService {
+	return &interviewSchedulerService{
+		interviewRepo: interviewRepo,
+		userRepo:      userRepo,
+		emailService:  emailService,
+		jobs:          jobs,
+		reminderLead:  reminderLead,
+	}
+}
+
+func (s *interviewSchedulerService) ProcessDue(ctx context.Context) (*domain.InterviewScheduleResult, error) {
+	now := time.Now().UTC()
+	result := &domain.InterviewScheduleResult{}
+
+	interviews, err := s.interviewRepo.FindScheduledForReminder(ctx, now.Add(s.reminderLead), reminderBatchSize)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, interview := range interviews {
+		// Reminders that would arrive after the start time are skipped but
+		// still marked so they are not retried forever. Sending happens on
+		// the job queue, which retries failed deliveries.
+		if interview.ScheduledAt != nil && interview.ScheduledAt.After(now) {
+			if _, err := s.jobs.Enqueue(ctx, domain.JobInterviewReminder, domain.InterviewReminderJob{InterviewID: interview.ID}); err != nil {
+				return result, err
+			}
+			result.RemindersQueued++
+		}
+
+		if err := s.interviewRepo.MarkReminderSent(ctx, interview.ID, now); err != nil {
+			return result, err
+		}
+	}
+
+	ready, err := s.interviewRepo.MarkScheduledReady(ctx, now)
+	if err != nil {
+		return result, err
+	}
+	result.MarkedReady = ready
+
+	return result, nil
+}
+
+// SendReminder emails the reminder for one interview. Interviews that were
+// deleted or have already started by the time the job runs are skipped.
+func (s *interviewSchedulerService) SendReminder(ctx context.Context, interviewID uuid.UUID) error {
+	interview, err := s.interviewRepo.FindByID(ctx, interviewID)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil
+		}
+		return fmt.Errorf("failed to load interview: %w", err)
+	}
+
+	if interview.ScheduledAt == nil || !interview.ScheduledAt.After(time.Now()) {
+		return nil
+	}
+
+	user, err := s.userRepo.FindByID(ctx, interview.UserID)
+	if err != nil {
+		return fmt.Errorf("failed to load user: %w", err)
+	}
+
+	return s.emailService.SendInterviewReminder(ctx, contactEmail(user), interview.JobPosition, *interview.ScheduledAt)
+}
@@ -6,10 +6,13 @@ import (
 	"encoding/json"
 	"errors"
 	"fmt"
+	"log"
 	"time"
 
+	"github.com/raflytch/careerly-server/internal/config"
 	"github.com/raflytch/careerly-server/internal/domain"
 	"github.com/raflytch/careerly-server/pkg/genai"
+	"github.com/raflytch/careerly-server/pkg/storage"
 
 	"github.com/google/uuid"
 )
@@ -19,13 +22,40 @@ var (
 	ErrInterviewUnauthorized = errors.New("unauthorized access to interview")
 	ErrInterviewCompleted    = errors.New("interview already completed")
 	ErrInvalidQuestionID     = errors.New("invalid question id")
+	ErrInterviewNotReady     = errors.New("interview is scheduled and not ready yet")
+	ErrInvalidScheduleTime   = errors.New("scheduled_at must be in the future and within 90 days")
+	ErrInterviewEvaluating   = errors.New("interview answers are being evaluated")
+	ErrEvaluationJobNotFound = errors.New("evaluation job not found")
+	ErrInterviewAdaptive     = errors.New("adaptive interviews are answered round by round")
+	ErrInterviewNotAdaptive  = errors.New("interview is not adaptive")
+	ErrIncompleteRound       = errors.New("all questions in the current round must be answered")
 )
 
+const (
+	maxScheduleAhead          = 90 * 24 * time.Hour
+	evaluationJobPrefix       = "interview:evaluation:"
+	evaluationJobDuration     = 24 * time.Hour
+	evaluationTimeout         = 2 * time.Minute
+	aiEvaluationStatusPending = "pending"
+
+	adaptiveRoundSize     = 3
+	adaptivePromoteScore  = 75.0
+	adaptiveDemoteScore   = 45.0
+	mixedDifficultyPrompt = "mixed, a balance of easy, medium and hard"
+)
+
+var interviewLanguageNames = map[domain.InterviewLanguage]string{
+	domain.InterviewLanguageEnglish:    "English",
+	domain.InterviewLanguageIndonesian: "Indonesian (Bahasa Indonesia)",
+}
+
 const generateQuestionsPrompt = `You are an expert technical interviewer. Generate interview questions for a %s position.
 
 Requirements:
 - Generate exactly %d questions
 - Question type: %s
+- Difficulty: %s
+- Language: write every question, option and expected answer in %s
 - Questions should be relevant, professional, and assess real-world skills
 - For multiple choice, provide exactly 5 options (A, B, C, D, E)
 - Each question should have a clear correct answer
@@ -60,6 +90,10 @@ Evaluate each answer and provide:
 1. For multiple choice: Check if the answer matches the correct answer (true/false)
 2. For essay: Evaluate the quality on a scale of 0-100 and provide brief feedback
 
+The interview is conducted in %s. Write all feedback in %s, and do not penalise an answer for being written in that language.
+
+Answers with "answer_source": "video_transcript" are transcripts of spoken answers. Judge only their content; do not penalise filler words, transcription errors, or anything about the candidate's voice, accent or appearance.
+
 Respond ONLY with valid JSON array in this exact format:
 [
   {
@@ -73,46 +107,176 @@ Respond ONLY with valid JSON array in this exact format:
 Evaluate now:`
 
 type interviewService struct {
-	interviewRepo domain.InterviewRepository
-	quotaService  domain.QuotaService
-	genaiClient   *genai.Client
+	interviewRepo  domain.InterviewRepository
+	packRepo       domain.InterviewPackRepository
+	bankRepo       domain.QuestionBankRepository
+	quotaService   domain.QuotaService
+	cacheRepo      domain.CacheRepository
+	progressBroker domain.InterviewProgressBroker
+	aiClient       domain.AIClient
+	prompts        domain.PromptProvider
+	webhooks       domain.WebhookPublisher
+	videoStorage   storage.Storage
+	ffmpegPath     string
+	aiSlots        aiSlots
 }
 
 func NewInterviewService(
 	interviewRepo domain.InterviewRepository,
+	packRepo domain.InterviewPackRepository,
+	bankRepo domain.QuestionBankRepository,
 	quotaService domain.QuotaService,
-	genaiClient *genai.Client,
+	cacheRepo domain.CacheRepository,
+	progressBroker domain.InterviewProgressBroker,
+	aiClient domain.AIClient,
+	prompts domain.PromptProvider,
+	webhooks domain.WebhookPublisher,
+	videoStorage storage.Storage,
+	ffmpegPath string,
+	aiLimiter domain.ConcurrencyLimiter,
+	aiLimit config.AILimitConfig,
 ) domain.InterviewService {
 	return &interviewService{
-		interviewRepo: interviewRepo,
-		quotaService:  quotaService,
-		genaiClient:   genaiClient,
+		interviewRepo:  interviewRepo,
+		packRepo:       packRepo,
+		bankRepo:       bankRepo,
+		quotaService:   quotaService,
+		cacheRepo:      cacheRepo,
+		progressBroker: progressBroker,
+		aiClient:       aiClient,
+		prompts:        prompts,
+		webhooks:       webhooks,
+		videoStorage:   videoStorage,
+		ffmpegPath:     ffmpegPath,
+		aiSlots:        aiSlots{limiter: aiLimiter, plans: quotaService, cfg: aiLimit},
 	}
 }
 
 func (s *interviewService) Create(ctx context.Context, userID uuid.UUID, req *domain.CreateInterviewRequest) (*domain.InterviewResponse, error) {
-	if err := s.quotaService.CheckAndIncrementUsage(ctx, userID, domain.FeatureInterview); err != nil {
+	return s.createInterview(ctx, userID, req.JobPosition, req.Language, req.QuestionType, req.QuestionCount, req.Adaptive, req.Rubric, nil)
+}
+
+func (s *interviewService) Schedule(ctx context.Context, userID uuid.UUID, req *domain.ScheduleInterviewRequest) (*domain.InterviewResponse, error) {
+	now := time.Now()
+	if !req.ScheduledAt.After(now) || req.ScheduledAt.After(now.Add(maxScheduleAhead)) {
+		return nil, ErrInvalidScheduleTime
+	}
+
+	scheduledAt := req.ScheduledAt.UTC()
+	return s.createInterview(ctx, userID, req.JobPosition, req.Language, req.QuestionType, req.QuestionCount, req.Adaptive, req.Rubric, &scheduledAt)
+}
+
+// StartFromPack starts an interview with the fixed question set of an
+// admin-curated pack. It counts towards the interview quota but makes no AI
+// generation call.
+func (s *interviewService) StartFromPack(ctx context.Context, userID uuid.UUID, packID uuid.UUID) (*domain.InterviewResponse, error) {
+	pack, err := s.packRepo.FindByID(ctx, packID)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, ErrInterviewPackNotFound
+		}
 		return nil, err
 	}
+	if !pack.IsActive || len(pack.Questions) == 0 {
+		return nil, ErrInterviewPackNotFound
+	}
+
+	if _, err := s.quotaService.CheckAndIncrementUsage(ctx, userID, domain.FeatureInterview); err != nil {
+		return nil, err
+	}
+
+	questions := make([]domain.Question, len(pack.Questions))
+	for i, q := range pack.Questions {
+		questions[i] = domain.Question{
+			ID:            i + 1,
+			Type:          q.Type,
+			Question:      q.Question,
+			Options:       q.Options,
+			Difficulty:    q.Difficulty,
+			CorrectAnswer: q.CorrectAnswer,
+		}
+	}
+
+	interview := &domain.Interview{
+		ID:                  uuid.New(),
+		UserID:              userID,
+		JobPosition:         pack.JobPosition,
+		Language:            domain.InterviewLanguageEnglish,
+		Questions:           questions,
+		Status:              domain.InterviewStatusInProgress,
+		TargetQuestionCount: len(questions),
+		PackID:              &pack.ID,
+		Rubric:              pack.Rubric,
+		CreatedAt:           time.Now(),
+	}
+
+	if err := s.interviewRepo.Create(ctx, interview); err != nil {
+		return nil, err
+	}
+
+	return &domain.InterviewResponse{
+		Interview: toInterviewForUser(interview),
+	}, nil
+}
+
+// createInterview generates the whole question set up front, or for adaptive
+// interviews only the first round at medium difficulty; later rounds are
+// generated by SubmitRound based on how the previous round went.
+func (s *interviewService) createInterview(ctx context.Context, userID uuid.UUID, jobPosition string, language domain.InterviewLanguage, questionType domain.QuestionType, questionCount int, adaptive bool, rubric []domain.RubricCriterion, scheduledAt *time.Time) (*domain.InterviewResponse, error) {
+	if err := validateRubric(rubric); err != nil {
+		return nil, err
+	}
+
+	if _, err := s.quotaService.CheckAndIncrementUsage(ctx, userID, domain.FeatureInterview); err != nil {
+		return nil, err
+	}
+
+	if language == "" {
+		language = domain.InterviewLanguageEnglish
+	}
+
+	generateCount := questionCount
+	var difficulty domain.QuestionDifficulty
+	if adaptive {
+		generateCount = min(adaptiveRoundSize, questionCount)
+		difficulty = domain.QuestionDifficultyMedium
+	}
 
 	aiStatus := "success"
-	questions, err := s.generateQuestions(ctx, req.JobPosition, req.QuestionType, req.QuestionCount)
-	if err != nil {
-		if s.genaiClient == nil {
-			aiStatus = "skipped_no_ai_client"
-		} else {
-			aiStatus = "failed"
+	questions := s.bankQuestions(ctx, jobPosition, language, questionType, generateCount, difficulty)
+	if questions != nil {
+		aiStatus = "skipped_question_bank"
+	} else {
+		aiCtx := genai.WithQuotaCost(genai.WithCallMetadata(ctx, domain.AIFeatureInterviewQuestions, userID.String()), 1)
+		var err error
+		questions, err = s.generateQuestions(aiCtx, jobPosition, language, questionType, generateCount, difficulty)
+		if err != nil {
+			aiStatus = aiFailureStatus(s.aiClient == nil, err)
+			questions = s.generateFallbackQuestions(questionType, generateCount)
 		}
-		questions = s.generateFallbackQuestions(req.QuestionType, req.QuestionCount)
+	}
+
+	if adaptive {
+		prepareRound(questions, 1, 1, difficulty)
+	}
+
+	status := domain.InterviewStatusInProgress
+	if scheduledAt != nil {
+		status = domain.InterviewStatusScheduled
 	}
 
 	interview := &domain.Interview{
-		ID:          uuid.New(),
-		UserID:      userID,
-		JobPosition: req.JobPosition,
-		Questions:   questions,
-		Status:      domain.InterviewStatusInProgress,
-		CreatedAt:   time.Now(),
+		ID:                  uuid.New(),
+		UserID:              userID,
+		JobPosition:         jobPosition,
+		Language:            language,
+		Questions:           questions,
+		Status:              status,
+		Adaptive:            adaptive,
+		TargetQuestionCount: questionCount,
+		Rubric:              rubric,
+		ScheduledAt:         scheduledAt,
+		CreatedAt:           time.Now(),
 	}
 
 	if err := s.interviewRepo.Create(ctx, interview); err != nil {
@@ -120,7 +284,7 @@ func (s *interviewService) Create(ctx context.Context, userID uuid.UUID, req *do
 	}
 
 	return &domain.InterviewResponse{
-		Interview:          s.toInterviewForUser(interview),
+		Interview:          toInterviewForUser(interview),
 		AIGenerationStatus: aiStatus,
 	}, nil
 }
@@ -138,7 +302,7 @@ func (s *interviewService) GetByID(ctx context.Context, userID uuid.UUID, id uui
 		return nil, ErrInterviewUnauthorized
 	}
 
-	return s.toInterviewForUser(interview), nil
+	return toInterviewForUser(interview), nil
 }
 
 func (s *interviewService) GetByUserID(ctx context.Context, userID uuid.UUID, page, limit int) (*domain.PaginatedInterviews, error) {
@@ -166,7 +330,7 @@ func (s *interviewService) GetByUserID(ctx context.Context, userID uuid.UUID, pa
 
 	interviewsForUser := make([]domain.InterviewForUser, len(interviews))
 	for i, interview := range interviews {
-		interviewsForUser[i] = *s.toInterviewForUser(&interview)
+		interviewsForUser[i] = *toInterviewForUser(&interview)
 	}
 
 	totalPages := int(total) / limit
@@ -202,26 +366,313 @@ func (s *interviewService) SubmitAnswers(ctx context.Context, userID uuid.UUID,
 		return nil, ErrInterviewCompleted
 	}
 
-	answerMap := make(map[int]string)
+	if interview.Status == domain.InterviewStatusScheduled {
+		return nil, ErrInterviewNotReady
+	}
+
+	if interview.Status == domain.InterviewStatusEvaluating {
+		return nil, ErrInterviewEvaluating
+	}
+
+	if interview.Adaptive {
+		return nil, ErrInterviewAdaptive
+	}
+
+	answerMap := make(map[int]domain.AnswerSubmission)
 	for _, ans := range req.Answers {
-		answerMap[ans.QuestionID] = ans.Answer
+		answerMap[ans.QuestionID] = ans
 	}
+	withVideoAnswers(answerMap, interview.Questions)
 
+	answered := 0
 	for i := range interview.Questions {
-		if answer, ok := answerMap[interview.Questions[i].ID]; ok {
-			interview.Questions[i].UserAnswer = answer
+		if ans, ok := answerMap[interview.Questions[i].ID]; ok {
+			interview.Questions[i].UserAnswer = ans.Answer
+			interview.Questions[i].Telemetry = ans.Telemetry
+			answered++
 		}
 	}
 
-	aiStatus := "success"
-	evaluations, err := s.evaluateAnswers(ctx, interview)
+	release, err := s.aiSlots.acquire(ctx, userID)
 	if err != nil {
-		if s.genaiClient == nil {
-			aiStatus = "skipped_no_ai_client"
-		} else {
-			aiStatus = "failed"
+		return nil, err
+	}
+
+	interview.Status = domain.InterviewStatusEvaluating
+	if err := s.interviewRepo.Update(ctx, interview); err != nil {
+		release()
+		return nil, err
+	}
+
+	job := &domain.EvaluationJob{
+		InterviewID: interview.ID,
+		Status:      domain.EvaluationJobStatusQueued,
+		Total:       answered,
+		UpdatedAt:   time.Now(),
+	}
+	s.saveEvaluationJob(ctx, job)
+
+	go func() {
+		defer release()
+		s.runEvaluation(interview, job)
+	}()
+
+	return &domain.InterviewResponse{
+		Interview:          toInterviewForUser(interview),
+		AIEvaluationStatus: aiEvaluationStatusPending,
+	}, nil
+}
+
+// SubmitRound grades the current round of an adaptive interview, then either
+// generates the next round one difficulty step up or down depending on the
+// round's average score, or completes the interview once the target question
+// count has been reached.
+func (s *interviewService) SubmitRound(ctx context.Context, userID uuid.UUID, id uuid.UUID, req *domain.SubmitAnswerRequest) (*domain.InterviewResponse, error) {
+	interview, err := s.interviewRepo.FindByID(ctx, id)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, ErrInterviewNotFound
 		}
+		return nil, err
+	}
+
+	if interview.UserID != userID {
+		return nil, ErrInterviewUnauthorized
+	}
+
+	if !interview.Adaptive {
+		return nil, ErrInterviewNotAdaptive
+	}
+
+	switch interview.Status {
+	case domain.InterviewStatusCompleted:
+		return nil, ErrInterviewCompleted
+	case domain.InterviewStatusScheduled:
+		return nil, ErrInterviewNotReady
+	case domain.InterviewStatusEvaluating:
+		return nil, ErrInterviewEvaluating
+	}
+
+	if len(interview.Questions) == 0 {
+		return nil, ErrInvalidQuestionID
+	}
+
+	answerMap := make(map[int]domain.AnswerSubmission)
+	for _, ans := range req.Answers {
+		answerMap[ans.QuestionID] = ans
+	}
+	withVideoAnswers(answerMap, interview.Questions)
+
+	currentRound := interview.Questions[len(interview.Questions)-1].Round
+	currentDifficulty := interview.Questions[len(interview.Questions)-1].Difficulty
+	roundQuestions := make([]domain.Question, 0, adaptiveRoundSize)
+	for i := range interview.Questions {
+		if interview.Questions[i].Round != currentRound {
+			continue
+		}
+		ans, ok := answerMap[interview.Questions[i].ID]
+		if !ok || ans.Answer == "" {
+			return nil, ErrIncompleteRound
+		}
+		interview.Questions[i].UserAnswer = ans.Answer
+		interview.Questions[i].Telemetry = ans.Telemetry
+		roundQuestions = append(roundQuestions, interview.Questions[i])
+	}
+
+	aiEvaluationStatus := "success"
+	roundInterview := &domain.Interview{UserID: interview.UserID, JobPosition: interview.JobPosition, Language: interview.Language, Rubric: interview.Rubric, Questions: roundQuestions}
+	evalCtx := genai.WithCallMetadata(ctx, domain.AIFeatureInterviewEvaluation, userID.String())
+	evaluations, promptVersion, err := s.evaluateAnswers(evalCtx, roundInterview)
+	if err != nil {
+		aiEvaluationStatus = aiFailureStatus(s.aiClient == nil, err)
+		evaluations = s.evaluateFallback(roundInterview)
+	} else {
+		s.prompts.RecordUse(ctx, domain.PromptInterviewEvaluation, interview.UserID, interview.ID, promptVersion)
+	}
+
+	var roundScore float64
+	var roundScored int
+	for i := range interview.Questions {
+		for _, eval := range evaluations {
+			if eval.QuestionID == interview.Questions[i].ID && interview.Questions[i].Round == currentRound {
+				interview.Questions[i].IsCorrect = eval.IsCorrect
+				interview.Questions[i].Score = eval.Score
+				interview.Questions[i].Feedback = eval.Feedback
+				applyRubric(interview.Rubric, &interview.Questions[i], eval.CriterionScores)
+				if score := interview.Questions[i].Score; score != nil {
+					roundScore += *score
+					roundScored++
+				}
+				break
+			}
+		}
+	}
+
+	assessed := make([]*domain.Question, 0, len(roundQuestions))
+	for i := range interview.Questions {
+		if interview.Questions[i].Round == currentRound {
+			assessed = append(assessed, &interview.Questions[i])
+		}
+	}
+	addVideoFeedback(assessed)
+	provenanceCtx := genai.WithCallMetadata(ctx, domain.AIFeatureAnswerProvenance, userID.String())
+	s.assessProvenance(provenanceCtx, interview.JobPosition, assessed)
+
+	aiGenerationStatus := ""
+	remaining := interview.TargetQuestionCount - len(interview.Questions)
+	if remaining > 0 {
+		nextDifficulty := currentDifficulty
+		if roundScored > 0 {
+			nextDifficulty = adjustDifficulty(currentDifficulty, roundScore/float64(roundScored))
+		}
+
+		count := min(adaptiveRoundSize, remaining)
+		questionType := interview.Questions[0].Type
+		aiGenerationStatus = "success"
+		genCtx := genai.WithCallMetadata(ctx, domain.AIFeatureInterviewQuestions, userID.String())
+		questions, err := s.generateQuestions(genCtx, interview.JobPosition, interview.Language, questionType, count, nextDifficulty)
+		if err != nil {
+			aiGenerationStatus = aiFailureStatus(s.aiClient == nil, err)
+			questions = s.generateFallbackQuestions(questionType, count)
+		}
+		prepareRound(questions, len(interview.Questions)+1, currentRound+1, nextDifficulty)
+		interview.Questions = append(interview.Questions, questions...)
+		interview.Status = domain.InterviewStatusInProgress
+	} else {
+		var totalScore float64
+		var scored int
+		for _, q := range interview.Questions {
+			if q.Score != nil {
+				totalScore += *q.Score
+				scored++
+			}
+		}
+		if scored > 0 {
+			avgScore := totalScore / float64(scored)
+			interview.OverallScore = &avgScore
+		}
+		now := time.Now()
+		interview.Status = domain.InterviewStatusCompleted
+		interview.CompletedAt = &now
+	}
+
+	if err := s.interviewRepo.Update(ctx, interview); err != nil {
+		return nil, err
+	}
+
+	if interview.Status == domain.InterviewStatusCompleted {
+		s.publishCompleted(ctx, interview)
+	}
+
+	return &domain.InterviewResponse{
+		Interview:          toInterviewForUser(interview),
+		AIGenerationStatus: aiGenerationStatus,
+		AIEvaluationStatus: aiEvaluationStatus,
+	}, nil
+}
+
+func adjustDifficulty(current domain.QuestionDifficulty, roundScore float64) domain.QuestionDifficulty {
+	levels := []domain.QuestionDifficulty{
+		domain.QuestionDifficultyEasy,
+		domain.QuestionDifficultyMedium,
+		domain.QuestionDifficultyHard,
+	}
+
+	index := 1
+	for i, level := range levels {
+		if level == current {
+			index = i
+		}
+	}
+
+	switch {
+	case roundScore >= adaptivePromoteScore && index < len(levels)-1:
+		index++
+	case roundScore < adaptiveDemoteScore && index > 0:
+		index--
+	}
+
+	return levels[index]
+}
+
+// prepareRound numbers generated questions after the ones already asked and
+// tags them with their round and difficulty.
+func prepareRound(questions []domain.Question, firstID, round int, difficulty domain.QuestionDifficulty) {
+	for i := range questions {
+		questions[i].ID = firstID + i
+		questions[i].Round = round
+		questions[i].Difficulty = difficulty
+	}
+}
+
+func aiFailureStatus(noClient bool, err error) string {
+	switch {
+	case noClient:
+		return "skipped_no_ai_client"
+	case errors.Is(err, genai.ErrBudgetExceeded):
+		return "skipped_budget_exceeded"
+	case errors.Is(err, genai.ErrTimeout):
+		return "timed_out"
+	case errors.Is(err, genai.ErrUnavailable):
+		return "skipped_ai_unavailable"
+	default:
+		return "failed"
+	}
+}
+
+func (s *interviewService) GetEvaluationJob(ctx context.Context, userID uuid.UUID, id uuid.UUID) (*domain.EvaluationJob, error) {
+	interview, err := s.interviewRepo.FindByID(ctx, id)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, ErrInterviewNotFound
+		}
+		return nil, err
+	}
+
+	if interview.UserID != userID {
+		return nil, ErrInterviewUnauthorized
+	}
+
+	cached, err := s.cacheRepo.Get(ctx, evaluationJobKey(id))
+	if err == nil && cached != "" {
+		var job domain.EvaluationJob
+		if err := json.Unmarshal([]byte(cached), &job); err == nil {
+			return &job, nil
+		}
+	}
+
+	if interview.Status == domain.InterviewStatusCompleted && interview.CompletedAt != nil {
+		return &domain.EvaluationJob{
+			InterviewID: interview.ID,
+			Status:      domain.EvaluationJobStatusCompleted,
+			UpdatedAt:   *interview.CompletedAt,
+		}, nil
+	}
+
+	return nil, ErrEvaluationJobNotFound
+}
+
+// runEvaluation grades submitted answers in the background, publishing
+// progress to the broker and mirroring the job state in the cache so
+// clients that connect late or poll still see where it stands. SubmitAnswers
+// holds one of the user's AI slots until it returns.
+func (s *interviewService) runEvaluation(interview *domain.Interview, job *domain.EvaluationJob) {
+	ctx, cancel := context.WithTimeout(context.Background(), evaluationTimeout)
+	defer cancel()
+
+	job.Status = domain.EvaluationJobStatusRunning
+	job.UpdatedAt = time.Now()
+	s.saveEvaluationJob(ctx, job)
+	s.publishProgress(domain.InterviewProgressStarted, job, 0, nil)
+
+	aiStatus := "success"
+	aiCtx := genai.WithCallMetadata(ctx, domain.AIFeatureInterviewEvaluation, interview.UserID.String())
+	evaluations, promptVersion, err := s.evaluateAnswers(aiCtx, interview)
+	if err != nil {
+		aiStatus = aiFailureStatus(s.aiClient == nil, err)
 		evaluations = s.evaluateFallback(interview)
+	} else {
+		s.prompts.RecordUse(ctx, domain.PromptInterviewEvaluation, interview.UserID, interview.ID, promptVersion)
 	}
 
 	var totalScore float64
@@ -232,32 +683,95 @@ func (s *interviewService) SubmitAnswers(ctx context.Context, userID uuid.UUID,
 				interview.Questions[i].IsCorrect = eval.IsCorrect
 				interview.Questions[i].Score = eval.Score
 				interview.Questions[i].Feedback = eval.Feedback
-				if eval.Score != nil {
-					totalScore += *eval.Score
+				applyRubric(interview.Rubric, &interview.Questions[i], eval.CriterionScores)
+				if score := interview.Questions[i].Score; score != nil {
+					totalScore += *score
 					answeredCount++
 				}
+				job.Evaluated++
+				s.publishProgress(domain.InterviewProgressQuestionEvaluated, job, eval.QuestionID, nil)
 				break
 			}
 		}
 	}
 
+	assessed := make([]*domain.Question, len(interview.Questions))
+	for i := range interview.Questions {
+		assessed[i] = &interview.Questions[i]
+	}
+	addVideoFeedback(assessed)
+	provenanceCtx := genai.WithCallMetadata(ctx, domain.AIFeatureAnswerProvenance, interview.UserID.String())
+	s.assessProvenance(provenanceCtx, interview.JobPosition, assessed)
+
 	if answeredCount > 0 {
 		avgScore := totalScore / float64(answeredCount)
 		interview.OverallScore = &avgScore
 	}
+	s.publishProgress(domain.InterviewProgressScoreComputed, job, 0, interview.OverallScore)
 
 	now := time.Now()
 	interview.Status = domain.InterviewStatusCompleted
 	interview.CompletedAt = &now
 
+	job.AIEvaluationStatus = aiStatus
+	job.UpdatedAt = time.Now()
+
 	if err := s.interviewRepo.Update(ctx, interview); err != nil {
-		return nil, err
+		log.Printf("Failed to save evaluation for interview %s: %v", interview.ID, err)
+
+		interview.Status = domain.InterviewStatusInProgress
+		interview.CompletedAt = nil
+		_ = s.interviewRepo.Update(ctx, interview)
+
+		job.Status = domain.EvaluationJobStatusFailed
+		job.Error = "failed to save evaluation, please submit again"
+		s.saveEvaluationJob(ctx, job)
+		s.publishProgress(domain.InterviewProgressFailed, job, 0, nil)
+		return
 	}
 
-	return &domain.InterviewResponse{
-		Interview:          s.toInterviewForUser(interview),
-		AIEvaluationStatus: aiStatus,
-	}, nil
+	job.Status = domain.EvaluationJobStatusCompleted
+	s.saveEvaluationJob(ctx, job)
+	s.publishProgress(domain.InterviewProgressCompleted, job, 0, interview.OverallScore)
+	s.publishCompleted(ctx, interview)
+}
+
+func (s *interviewService) publishCompleted(ctx context.Context, interview *domain.Interview) {
+	event := domain.InterviewCompletedEvent{
+		InterviewID:  interview.ID,
+		UserID:       interview.UserID,
+		JobPosition:  interview.JobPosition,
+		OverallScore: interview.OverallScore,
+	}
+	if interview.CompletedAt != nil {
+		event.CompletedAt = *interview.CompletedAt
+	}
+	s.webhooks.Publish(ctx, domain.WebhookEventInterviewCompleted, event)
+}
+
+func (s *interviewService) saveEvaluationJob(ctx context.Context, job *domain.EvaluationJob) {
+	_ = s.cacheRepo.Set(ctx, evaluationJobKey(job.InterviewID), job, evaluationJobDuration)
+}
+
+func (s *interviewService) publishProgress(eventType domain.InterviewProgressEventType, job *domain.EvaluationJob, questionID int, overallScore *float64) {
+	event := domain.InterviewProgressEvent{
+		Type:         eventType,
+		InterviewID:  job.InterviewID,
+		QuestionID:   questionID,
+		Evaluated:    job.Evaluated,
+		Total:        job.Total,
+		OverallScore: overallScore,
+		Timestamp:    time.Now(),
+	}
+	if eventType == domain.InterviewProgressCompleted || eventType == domain.InterviewProgressFailed {
+		snapshot := *job
+		event.Job = &snapshot
+	}
+	s.progressBroker.Publish(event)
+}
+
+func evaluationJobKey(interviewID uuid.UUID) string {
+	return fmt.Sprintf("%s%s", evaluationJobPrefix, interviewID.String())
 }
 
 func (s *interviewService) Delete(ctx context.Context, userID uuid.UUID, id uuid.UUID) error {
@@ -276,30 +790,154 @@ func (s *interviewService) Delete(ctx context.Context, userID uuid.UUID, id uuid
 	return s.interviewRepo.SoftDelete(ctx, id)
 }
 
-func (s *interviewService) generateQuestions(ctx context.Context, jobPosition string, questionType domain.QuestionType, count int) ([]domain.Question, error) {
-	if s.genaiClient == nil {
+func (s *interviewService) GetTrash(ctx context.Context, userID uuid.UUID, page, limit int) (*domain.PaginatedInterviews, error) {
+	if page < 1 {
+		page = 1
+	}
+	if limit < 1 {
+		limit = 10
+	}
+	if limit > 100 {
+		limit = 100
+	}
+
+	offset := (page - 1) * limit
+
+	total, err := s.interviewRepo.CountDeletedByUserID(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	interviews, err := s.interviewRepo.FindDeletedByUserID(ctx, userID, limit, offset)
+	if err != nil {
+		return nil, err
+	}
+
+	interviewsForUser := make([]domain.InterviewForUser, len(interviews))
+	for i, interview := range interviews {
+		interviewsForUser[i] = *toInterviewForUser(&interview)
+	}
+
+	totalPages := int(total) / limit
+	if int(total)%limit > 0 {
+		totalPages++
+	}
+
+	return &domain.PaginatedInterviews{
+		Interviews: interviewsForUser,
+		Pagination: domain.Pagination{
+			Page:       page,
+			Limit:      limit,
+			Total:      total,
+			TotalPages: totalPages,
+		},
+	}, nil
+}
+
+func (s *interviewService) Restore(ctx context.Context, userID uuid.UUID, id uuid.UUID) (*domain.InterviewForUser, error) {
+	interview, err := s.interviewRepo.FindDeletedByID(ctx, id)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, ErrInterviewNotFound
+		}
+		return nil, err
+	}
+
+	if interview.UserID != userID {
+		return nil, ErrInterviewUnauthorized
+	}
+
+	if err := s.interviewRepo.Restore(ctx, id); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, ErrInterviewNotFound
+		}
+		return nil, err
+	}
+
+	interview.DeletedAt = nil
+	return toInterviewForUser(interview), nil
+}
+
+func (s *interviewService) generateQuestions(ctx context.Context, jobPosition string, language domain.InterviewLanguage, questionType domain.QuestionType, count int, difficulty domain.QuestionDifficulty) ([]domain.Question, error) {
+	if s.aiClient == nil {
 		return nil, errors.New("genai client not available")
 	}
 
+	difficultyStr := mixedDifficultyPrompt
+	if difficulty != "" {
+		difficultyStr = string(difficulty)
+	}
+
 	typeStr := string(questionType)
-	prompt := fmt.Sprintf(generateQuestionsPrompt, jobPosition, count, typeStr, typeStr)
+	template, _ := s.prompts.Prompt(ctx, domain.PromptInterviewQuestions)
+	prompt := fmt.Sprintf(template, jobPosition, count, typeStr, difficultyStr, interviewLanguageName(language), typeStr)
 
-	result, err := s.genaiClient.GenerateJSON(ctx, prompt)
+	result, err := s.aiClient.GenerateJSON(genai.WithResponseSchema(ctx, interviewQuestionsSchema), prompt)
 	if err != nil {
 		return nil, err
 	}
 
-	var questions []domain.Question
-	if err := json.Unmarshal([]byte(result), &questions); err != nil {
+	var generated []generatedQuestion
+	if err := json.Unmarshal([]byte(result), &generated); err != nil {
 		return nil, err
 	}
 
+	questions := make([]domain.Question, len(generated))
+	for i, q := range generated {
+		questions[i] = domain.Question{
+			ID:            q.ID,
+			Type:          q.Type,
+			Question:      q.Question,
+			Options:       q.Options,
+			Difficulty:    q.Difficulty,
+			CorrectAnswer: q.CorrectAnswer,
+		}
+	}
 	return questions, nil
 }
 
-func (s *interviewService) evaluateAnswers(ctx context.Context, interview *domain.Interview) ([]evaluationResult, error) {
-	if s.genaiClient == nil {
-		return nil, errors.New("genai client not available")
+// bankQuestions draws the questions from the question bank when it holds
+// enough for the position, so common positions need no generation. The
+// bank is curated in English only. It returns nil when the model should be
+// asked instead.
+func (s *interviewService) bankQuestions(ctx context.Context, jobPosition string, language domain.InterviewLanguage, questionType domain.QuestionType, count int, difficulty domain.QuestionDifficulty) []domain.Question {
+	if language != domain.InterviewLanguageEnglish {
+		return nil
+	}
+
+	banked, err := s.bankRepo.FindRandom(ctx, jobPosition, questionType, difficulty, count)
+	if err != nil || len(banked) < count {
+		return nil
+	}
+
+	questions := make([]domain.Question, len(banked))
+	for i, q := range banked {
+		questions[i] = domain.Question{
+			ID:            i + 1,
+			Type:          q.Type,
+			Question:      q.Question,
+			Options:       q.Options,
+			Difficulty:    q.Difficulty,
+			CorrectAnswer: q.CorrectAnswer,
+		}
+	}
+	return questions
+}
+
+// generatedQuestion is a question as the model writes it, before it is
+// answered.
+type generatedQuestion struct {
+	ID            int                       `json:"id"`
+	Type          domain.QuestionType       `json:"type"`
+	Question      string                    `json:"question"`
+	Options       []domain.Option           `json:"options,omitempty"`
+	Difficulty    domain.QuestionDifficulty `json:"difficulty,omitempty"`
+	CorrectAnswer string                    `json:"correct_answer"`
+}
+
+func (s *interviewService) evaluateAnswers(ctx context.Context, interview *domain.Interview) ([]evaluationResult, int, error) {
+	if s.aiClient == nil {
+		return nil, 0, errors.New("genai client not available")
 	}
 
 	questionsWithAnswers := make([]map[string]interface{}, 0)
@@ -317,34 +955,40 @@ func (s *interviewService) evaluateAnswers(ctx context.Context, interview *domai
 		if len(q.Options) > 0 {
 			qMap["options"] = q.Options
 		}
+		if answeredByVideo(&q) {
+			qMap["answer_source"] = "video_transcript"
+		}
 		questionsWithAnswers = append(questionsWithAnswers, qMap)
 	}
 
 	questionsJSON, err := json.Marshal(questionsWithAnswers)
 	if err != nil {
-		return nil, err
+		return nil, 0, err
 	}
 
-	prompt := fmt.Sprintf(evaluateAnswersPrompt, interview.JobPosition, string(questionsJSON))
+	languageName := interviewLanguageName(interview.Language)
+	template, promptVersion := s.prompts.PromptForUser(ctx, domain.PromptInterviewEvaluation, interview.UserID)
+	prompt := fmt.Sprintf(template, interview.JobPosition, string(questionsJSON), languageName, languageName) + rubricPrompt(interview.Rubric)
 
-	result, err := s.genaiClient.GenerateJSON(ctx, prompt)
+	result, err := s.aiClient.GenerateJSON(genai.WithResponseSchema(ctx, interviewEvaluationSchema), prompt)
 	if err != nil {
-		return nil, err
+		return nil, 0, err
 	}
 
 	var evaluations []evaluationResult
 	if err := json.Unmarshal([]byte(result), &evaluations); err != nil {
-		return nil, err
+		return nil, 0, err
 	}
 
-	return evaluations, nil
+	return evaluations, promptVersion, nil
 }
 
 type evaluationResult struct {
-	QuestionID int      `json:"question_id"`
-	IsCorrect  *bool    `json:"is_correct"`
-	Score      *float64 `json:"score"`
-	Feedback   string   `json:"feedback"`
+	QuestionID      int               `json:"question_id"`
+	IsCorrect       *bool             `json:"is_correct"`
+	Score           *float64          `json:"score"`
+	Feedback        string            `json:"feedback"`
+	CriterionScores []criterionResult `json:"criterion_scores,omitempty"`
 }
 
 func (s *interviewService) generateFallbackQuestions(questionType domain.QuestionType, count int) []domain.Question {
@@ -398,29 +1042,65 @@ func (s *interviewService) evaluateFallback(interview *domain.Interview) []evalu
 	return results
 }
 
-func (s *interviewService) toInterviewForUser(interview *domain.Interview) *domain.InterviewForUser {
-	questionsForUser := make([]domain.QuestionForUser, len(interview.Questions))
-	for i, q := range interview.Questions {
-		questionsForUser[i] = domain.QuestionForUser{
-			ID:         q.ID,
-			Type:       q.Type,
-			Question:   q.Question,
-			Options:    q.Options,
-			UserAnswer: q.UserAnswer,
-			IsCorrect:  q.IsCorrect,
-			Score:      q.Score,
-			Feedback:   q.Feedback,
-		}
+// interviewLanguageName falls back to English for interviews created before
+// a language could be chosen.
+func interviewLanguageName(language domain.InterviewLanguage) string {
+	if name, ok := interviewLanguageNames[language]; ok {
+		return name
+	}
+	return interviewLanguageNames[domain.InterviewLanguageEnglish]
+}
+
+func toInterviewForUser(interview *domain.Interview) *domain.InterviewForUser {
+	// Questions stay hidden until a scheduled interview becomes ready.
+	questions := interview.Questions
+	if interview.Status == domain.InterviewStatusScheduled {
+		questions = nil
+	}
+
+	questionsForUser := make([]domain.QuestionForUser, len(questions))
+	for i := range questions {
+		questionsForUser[i] = toQuestionForUser(&questions[i])
 	}
 
 	return &domain.InterviewForUser{
-		ID:           interview.ID,
-		UserID:       interview.UserID,
-		JobPosition:  interview.JobPosition,
-		Questions:    questionsForUser,
-		Status:       interview.Status,
-		OverallScore: interview.OverallScore,
-		CreatedAt:    interview.CreatedAt,
-		CompletedAt:  interview.CompletedAt,
+		ID:                  interview.ID,
+		UserID:              interview.UserID,
+		JobPosition:         interview.JobPosition,
+		Language:            interview.Language,
+		Questions:           questionsForUser,
+		Status:              interview.Status,
+		Adaptive:            interview.Adaptive,
+		TargetQuestionCount: interview.TargetQuestionCount,
+		PackID:              interview.PackID,
+		Rubric:              interview.Rubric,
+		OverallScore:        interview.OverallScore,
+		ScheduledAt:         interview.ScheduledAt,
+		CreatedAt:           interview.CreatedAt,
+		CompletedAt:         interview.CompletedAt,
+		DeletedAt:           interview.DeletedAt,
+	}
+}
+
+func toQuestionForUser(q *domain.Question) domain.QuestionForUser {
+	questionForUser := domain.QuestionForUser{
+		ID:              q.ID,
+		Type:            q.Type,
+		Question:        q.Question,
+		Options:         q.Options,
+		Difficulty:      q.Difficulty,
+		Round:           q.Round,
+		UserAnswer:      q.UserAnswer,
+		IsCorrect:       q.IsCorrect,
+		Score:           q.Score,
+		CriterionScores: q.CriterionScores,
+		Feedback:        q.Feedback,
+		Provenance:      q.Provenance,
+	}
+	if q.VideoAnswer != nil {
+		video := *q.VideoAnswer
+		video.StorageID = ""
+		questionForUser.VideoAnswer = &video
 	}
+	return questionForUser
 }
@@ -0,0 +1,226 @@
+package service
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/raflytch/careerly-server/internal/domain"
+	"github.com/raflytch/careerly-server/pkg/signedtoken"
+
+	"github.com/google/uuid"
+)
+
+const (
+	defaultShareExpiry     = 72 * time.Hour
+	maxCommentsPerShare    = 50
+	sharedInterviewURLPath = "/shared/interviews/"
+)
+
+type interviewShareService struct {
+	shareRepo     domain.InterviewShareRepository
+	interviewRepo domain.InterviewRepository
+	signer        *signedtoken.Signer
+	frontendURL   string
+}
+
+func NewInterviewShareService(
+	shareRepo domain.InterviewShareRepository,
+	interviewRepo domain.InterviewRepository,
+	signer *signedtoken.Signer,
+	frontendURL string,
+) domain.InterviewShareService {
+	return &interviewShareService{
+		shareRepo:     shareRepo,
+		interviewRepo: interviewRepo,
+		signer:        signer,
+		frontendURL:   frontendURL,
+	}
+}
+
+func (s *interviewShareService) Create(ctx context.Context, userID, interviewID uuid.UUID, req *domain.CreateInterviewShareRequest) (*domain.InterviewShareResponse, error) {
+	interview, err := s.findOwnedInterview(ctx, userID, interviewID)
+	if err != nil {
+		return nil, err
+	}
+
+	if interview.Status != domain.InterviewStatusCompleted {
+		return nil, domain.ErrInterviewNotShareable
+	}
+
+	expiry := defaultShareExpiry
+	if req.ExpiresInHours > 0 {
+		expiry = time.Duration(req.ExpiresInHours) * time.Hour
+	}
+
+	now := time.Now()
+	share := &domain.InterviewShare{
+		ID:            uuid.New(),
+		InterviewID:   interview.ID,
+		UserID:        userID,
+		AllowComments: req.AllowComments,
+		ExpiresAt:     now.Add(expiry),
+		CreatedAt:     now,
+	}
+
+	if err := s.shareRepo.Create(ctx, share); err != nil {
+		return nil, err
+	}
+
+	token := s.signer.Sign(share.ID.String(), share.ExpiresAt)
+
+	return &domain.InterviewShareResponse{
+		Share: share,
+		Token: token,
+		URL:   strings.TrimRight(s.frontendURL, "/") + sharedInterviewURLPath + token,
+	}, nil
+}
+
+func (s *interviewShareService) Revoke(ctx context.Context, userID, interviewID, shareID uuid.UUID) error {
+	share, err := s.shareRepo.FindByID(ctx, shareID)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return domain.ErrInterviewShareNotFound
+		}
+		return err
+	}
+
+	if share.UserID != userID || share.InterviewID != interviewID {
+		return domain.ErrInterviewShareNotFound
+	}
+
+	return s.shareRepo.Revoke(ctx, share.ID, time.Now())
+}
+
+// GetSharedReport is served without authentication, so it only ever exposes
+// what the owner sees themselves; correct answers stay hidden.
+func (s *interviewShareService) GetSharedReport(ctx context.Context, token string) (*domain.SharedInterviewReport, error) {
+	share, err := s.resolveShare(ctx, token)
+	if err != nil {
+		return nil, err
+	}
+
+	interview, err := s.interviewRepo.FindByID(ctx, share.InterviewID)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, domain.ErrInterviewShareNotFound
+		}
+		return nil, err
+	}
+
+	comments, err := s.shareRepo.FindCommentsByInterviewID(ctx, interview.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	report := toInterviewForUser(interview)
+	report.UserID = uuid.Nil
+
+	return &domain.SharedInterviewReport{
+		Interview:     report,
+		AllowComments: share.AllowComments,
+		ExpiresAt:     share.ExpiresAt,
+		Comments:      comments,
+	}, nil
+}
+
+func (s *interviewShareService) AddComment(ctx context.Context, token string, req *domain.MentorCommentRequest) (*domain.MentorComment, error) {
+	share, err := s.resolveShare(ctx, token)
+	if err != nil {
+		return nil, err
+	}
+
+	if !share.AllowComments {
+		return nil, domain.ErrShareCommentsDisabled
+	}
+
+	count, err := s.shareRepo.CountCommentsByShareID(ctx, share.ID)
+	if err != nil {
+		return nil, err
+	}
+	if count >= maxCommentsPerShare {
+		return nil, domain.ErrShareCommentLimit
+	}
+
+	if req.QuestionID != nil {
+		interview, err := s.interviewRepo.FindByID(ctx, share.InterviewID)
+		if err != nil {
+			return nil, err
+		}
+		if *req.QuestionID > len(interview.Questions) {
+			return nil, ErrInvalidQuestionID
+		}
+	}
+
+	comment := &domain.MentorComment{
+		ID:          uuid.New(),
+		InterviewID: share.InterviewID,
+		ShareID:     share.ID,
+		MentorName:  strings.TrimSpace(req.MentorName),
+		QuestionID:  req.QuestionID,
+		Comment:     strings.TrimSpace(req.Comment),
+		CreatedAt:   time.Now(),
+	}
+
+	if err := s.shareRepo.CreateComment(ctx, comment); err != nil {
+		return nil, err
+	}
+
+	return comment, nil
+}
+
+func (s *interviewShareService) GetComments(ctx context.Context, userID, interviewID uuid.UUID) ([]domain.MentorComment, error) {
+	if _, err := s.findOwnedInterview(ctx, userID, interviewID); err != nil {
+		return nil, err
+	}
+
+	return s.shareRepo.FindCommentsByInterviewID(ctx, interviewID)
+}
+
+func (s *interviewShareService) resolveShare(ctx context.Context, token string) (*domain.InterviewShare, error) {
+	subject, err := s.signer.Verify(token)
+	if err != nil {
+		if errors.Is(err, signedtoken.ErrExpiredToken) {
+			return nil, domain.ErrInterviewShareExpired
+		}
+		return nil, domain.ErrInterviewShareNotFound
+	}
+
+	shareID, err := uuid.Parse(subject)
+	if err != nil {
+		return nil, domain.ErrInterviewShareNotFound
+	}
+
+	share, err := s.shareRepo.FindByID(ctx, shareID)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, domain.ErrInterviewShareNotFound
+		}
+		return nil, fmt.Errorf("failed to load share: %w", err)
+	}
+
+	if share.RevokedAt != nil || time.Now().After(share.ExpiresAt) {
+		return nil, domain.ErrInterviewShareExpired
+	}
+
+	return share, nil
+}
+
+func (s *interviewShareService) findOwnedInterview(ctx context.Context, userID, interviewID uuid.UUID) (*domain.Interview, error) {
+	interview, err := s.interviewRepo.FindByID(ctx, interviewID)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, ErrInterviewNotFound
+		}
+		return nil, err
+	}
+
+	if interview.UserID != userID {
+		return nil, ErrInterviewUnauthorized
+	}
+
+	return interview, nil
+}
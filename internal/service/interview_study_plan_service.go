@@ -0,0 +1,293 @@
+package service
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/raflytch/careerly-server/internal/domain"
+	"github.com/raflytch/careerly-server/pkg/genai"
+
+	"github.com/google/uuid"
+)
+
+const (
+	studyPlanDays      = 14
+	studyPlanWeakScore = 70.0
+	maxStudyPlanTasks  = 42
+)
+
+const studyPlanSystemPrompt = `You are an experienced career coach. Build a 14-day improvement plan for a candidate from the weak areas of a mock interview.
+
+Return ONLY valid JSON in this structure:
+{
+  "summary": "<two or three sentences on what the plan focuses on and why>",
+  "weak_areas": ["<short name of a skill or knowledge gap>"],
+  "topics": [
+    {
+      "name": "<topic to study>",
+      "reason": "<which answers showed this gap>",
+      "resources": [
+        {"title": "<book, documentation, course or exercise type>", "type": "<book|documentation|course|article|practice>", "notes": "<what to focus on>"}
+      ]
+    }
+  ],
+  "tasks": [
+    {"day": 1, "topic": "<topic name>", "title": "<short task title>", "description": "<concrete practice task that can be checked off>"}
+  ]
+}
+
+Rules:
+1. Derive weak areas only from the questions, answers, scores and feedback provided
+2. Give 3 to 6 topics, each with 1 to 3 well-known resources; never invent URLs
+3. Give 1 to 3 tasks for every day from 1 to 14, building from fundamentals to mock practice
+4. Every task must name a topic from the topics list
+5. Write everything in %s
+6. Do not add any explanation or markdown formatting`
+
+type studyPlanService struct {
+	studyPlanRepo domain.StudyPlanRepository
+	interviewRepo domain.InterviewRepository
+	aiClient      domain.AIClient
+}
+
+func NewStudyPlanService(
+	studyPlanRepo domain.StudyPlanRepository,
+	interviewRepo domain.InterviewRepository,
+	aiClient domain.AIClient,
+) domain.StudyPlanService {
+	return &studyPlanService{
+		studyPlanRepo: studyPlanRepo,
+		interviewRepo: interviewRepo,
+		aiClient:      aiClient,
+	}
+}
+
+// Generate builds a plan from the interview's weak answers. An existing plan
+// is returned as is unless regenerate is set, so progress is not lost to a
+// repeated request.
+func (s *studyPlanService) Generate(ctx context.Context, userID, interviewID uuid.UUID, regenerate bool) (*domain.StudyPlan, error) {
+	interview, err := s.findOwnedInterview(ctx, userID, interviewID)
+	if err != nil {
+		return nil, err
+	}
+
+	if interview.Status != domain.InterviewStatusCompleted {
+		return nil, domain.ErrStudyPlanNotAvailable
+	}
+
+	if !regenerate {
+		existing, err := s.studyPlanRepo.FindByInterviewID(ctx, interview.ID)
+		if err == nil {
+			return existing, nil
+		}
+		if !errors.Is(err, sql.ErrNoRows) {
+			return nil, err
+		}
+	}
+
+	if s.aiClient == nil {
+		return nil, ErrAIClientUnavailable
+	}
+	if !s.aiClient.Available() {
+		return nil, ErrAIServiceUnavailable
+	}
+
+	systemPrompt := fmt.Sprintf(studyPlanSystemPrompt, interviewLanguageName(interview.Language))
+	aiCtx := genai.WithCallMetadata(ctx, domain.AIFeatureStudyPlan, userID.String())
+	result, err := s.aiClient.GenerateJSONWithSystemPrompt(aiCtx, systemPrompt, studyPlanPrompt(interview))
+	if err != nil {
+		return nil, err
+	}
+
+	var parsed domain.StudyPlan
+	if err := json.Unmarshal([]byte(result), &parsed); err != nil {
+		return nil, fmt.Errorf("failed to parse study plan response: %w", err)
+	}
+
+	now := time.Now()
+	plan := &domain.StudyPlan{
+		ID:           uuid.New(),
+		InterviewID:  interview.ID,
+		UserID:       userID,
+		Summary:      strings.TrimSpace(parsed.Summary),
+		WeakAreas:    make([]string, 0, len(parsed.WeakAreas)),
+		Topics:       make([]domain.StudyPlanTopic, 0, len(parsed.Topics)),
+		Tasks:        make([]domain.StudyPlanTask, 0, len(parsed.Tasks)),
+		DurationDays: studyPlanDays,
+		CreatedAt:    now,
+		UpdatedAt:    now,
+	}
+
+	for _, area := range parsed.WeakAreas {
+		if area = strings.TrimSpace(area); area != "" {
+			plan.WeakAreas = append(plan.WeakAreas, area)
+		}
+	}
+
+	for _, topic := range parsed.Topics {
+		topic.Name = strings.TrimSpace(topic.Name)
+		if topic.Name == "" {
+			continue
+		}
+		if topic.Resources == nil {
+			topic.Resources = make([]domain.StudyPlanResource, 0)
+		}
+		plan.Topics = append(plan.Topics, topic)
+	}
+
+	for _, task := range parsed.Tasks {
+		title := strings.TrimSpace(task.Title)
+		if title == "" || task.Day < 1 || task.Day > studyPlanDays {
+			continue
+		}
+		plan.Tasks = append(plan.Tasks, domain.StudyPlanTask{
+			ID:          len(plan.Tasks) + 1,
+			Day:         task.Day,
+			Topic:       strings.TrimSpace(task.Topic),
+			Title:       title,
+			Description: strings.TrimSpace(task.Description),
+		})
+		if len(plan.Tasks) == maxStudyPlanTasks {
+			break
+		}
+	}
+
+	if len(plan.Topics) == 0 || len(plan.Tasks) == 0 {
+		return nil, domain.ErrStudyPlanGenerateFailed
+	}
+
+	if err := s.studyPlanRepo.Upsert(ctx, plan); err != nil {
+		return nil, err
+	}
+
+	return plan, nil
+}
+
+func (s *studyPlanService) Get(ctx context.Context, userID, interviewID uuid.UUID) (*domain.StudyPlan, error) {
+	if _, err := s.findOwnedInterview(ctx, userID, interviewID); err != nil {
+		return nil, err
+	}
+
+	return s.findPlan(ctx, interviewID)
+}
+
+func (s *studyPlanService) SetTaskCompleted(ctx context.Context, userID, interviewID uuid.UUID, taskID int, completed bool) (*domain.StudyPlan, error) {
+	if _, err := s.findOwnedInterview(ctx, userID, interviewID); err != nil {
+		return nil, err
+	}
+
+	plan, err := s.findPlan(ctx, interviewID)
+	if err != nil {
+		return nil, err
+	}
+
+	index := -1
+	for i := range plan.Tasks {
+		if plan.Tasks[i].ID == taskID {
+			index = i
+			break
+		}
+	}
+	if index < 0 {
+		return nil, domain.ErrStudyPlanTaskNotFound
+	}
+
+	task := &plan.Tasks[index]
+	if task.Completed == completed {
+		return plan, nil
+	}
+
+	now := time.Now()
+	task.Completed = completed
+	task.CompletedAt = nil
+	if completed {
+		task.CompletedAt = &now
+	}
+
+	if err := s.studyPlanRepo.UpdateTasks(ctx, plan.ID, plan.Tasks, now); err != nil {
+		return nil, err
+	}
+	plan.UpdatedAt = now
+
+	return plan, nil
+}
+
+func (s *studyPlanService) findPlan(ctx context.Context, interviewID uuid.UUID) (*domain.StudyPlan, error) {
+	plan, err := s.studyPlanRepo.FindByInterviewID(ctx, interviewID)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, domain.ErrStudyPlanNotFound
+		}
+		return nil, err
+	}
+	return plan, nil
+}
+
+func (s *studyPlanService) findOwnedInterview(ctx context.Context, userID, interviewID uuid.UUID) (*domain.Interview, error) {
+	interview, err := s.interviewRepo.FindByID(ctx, interviewID)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, ErrInterviewNotFound
+		}
+		return nil, err
+	}
+
+	if interview.UserID != userID {
+		return nil, ErrInterviewUnauthorized
+	}
+
+	return interview, nil
+}
+
+// studyPlanPrompt lists the answers that scored below the weak threshold,
+// falling back to every answer when the candidate did well throughout so
+// the plan can still push them further.
+func studyPlanPrompt(interview *domain.Interview) string {
+	weak := make([]domain.Question, 0, len(interview.Questions))
+	for _, q := range interview.Questions {
+		if isWeakAnswer(q) {
+			weak = append(weak, q)
+		}
+	}
+	if len(weak) == 0 {
+		weak = interview.Questions
+	}
+
+	var prompt strings.Builder
+	fmt.Fprintf(&prompt, "Position: %s\n", interview.JobPosition)
+	if interview.OverallScore != nil {
+		fmt.Fprintf(&prompt, "Overall score: %.0f/100\n", *interview.OverallScore)
+	}
+	prompt.WriteString("\nAnswers to improve on:\n")
+	for _, q := range weak {
+		fmt.Fprintf(&prompt, "\nQuestion: %s\n", q.Question)
+		answer := q.UserAnswer
+		if answer == "" {
+			answer = "(not answered)"
+		}
+		fmt.Fprintf(&prompt, "Answer: %s\n", answer)
+		if q.Score != nil {
+			fmt.Fprintf(&prompt, "Score: %.0f/100\n", *q.Score)
+		}
+		if q.Feedback != "" {
+			fmt.Fprintf(&prompt, "Feedback: %s\n", q.Feedback)
+		}
+	}
+
+	return prompt.String()
+}
+
+func isWeakAnswer(q domain.Question) bool {
+	if strings.TrimSpace(q.UserAnswer) == "" {
+		return true
+	}
+	if q.IsCorrect != nil && !*q.IsCorrect {
+		return true
+	}
+	return q.Score != nil && *q.Score < studyPlanWeakScore
+}
@@ -0,0 +1,221 @@
+package service
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"log"
+	"mime/multipart"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/raflytch/careerly-server/internal/domain"
+	"github.com/raflytch/careerly-server/pkg/genai"
+	"github.com/raflytch/careerly-server/pkg/media"
+
+	"github.com/google/uuid"
+)
+
+var (
+	ErrVideoAnswersDisabled  = errors.New("video answers are not available")
+	ErrVideoAnswerNotAllowed = errors.New("video answers are only accepted for unanswered essay questions")
+	ErrVideoNoSpeech         = errors.New("no speech could be recognised in the video")
+)
+
+var videoContentTypes = map[string]string{
+	".mp4":  "video/mp4",
+	".webm": "video/webm",
+	".mov":  "video/quicktime",
+}
+
+const transcribeVideoAnswerPrompt = `You are transcribing a candidate's spoken answer in a practice interview for a %s position.
+
+Question: %s
+
+Transcribe what the candidate says. Then review only the content of the answer and how clearly it is communicated: structure, relevance to the question, use of concrete examples and conciseness. Do not comment on appearance, body language, facial expressions, background, voice or accent.
+
+Respond ONLY with valid JSON in this exact format:
+{
+  "transcript": "Verbatim transcript of the spoken answer",
+  "summary": "Two or three sentence summary of the answer",
+  "content_feedback": "Two or three sentences of feedback on the content and clarity of the answer"
+}
+
+If there is no intelligible speech, return an empty transcript.`
+
+type videoTranscription struct {
+	Transcript      string `json:"transcript"`
+	Summary         string `json:"summary"`
+	ContentFeedback string `json:"content_feedback"`
+}
+
+// UploadVideoAnswer stores a recorded answer to an essay question and
+// transcribes it straight away, so evaluation can grade the transcript like
+// a typed answer. Uploading again replaces the previous recording.
+func (s *interviewService) UploadVideoAnswer(ctx context.Context, userID uuid.UUID, id uuid.UUID, questionID int, file *multipart.FileHeader) (*domain.QuestionForUser, error) {
+	if s.videoStorage == nil || s.aiClient == nil {
+		return nil, ErrVideoAnswersDisabled
+	}
+
+	interview, err := s.interviewRepo.FindByID(ctx, id)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, ErrInterviewNotFound
+		}
+		return nil, err
+	}
+
+	if interview.UserID != userID {
+		return nil, ErrInterviewUnauthorized
+	}
+
+	switch interview.Status {
+	case domain.InterviewStatusCompleted:
+		return nil, ErrInterviewCompleted
+	case domain.InterviewStatusScheduled:
+		return nil, ErrInterviewNotReady
+	case domain.InterviewStatusEvaluating:
+		return nil, ErrInterviewEvaluating
+	}
+
+	var question *domain.Question
+	for i := range interview.Questions {
+		if interview.Questions[i].ID == questionID {
+			question = &interview.Questions[i]
+			break
+		}
+	}
+	if question == nil {
+		return nil, ErrInvalidQuestionID
+	}
+	if question.Type != domain.QuestionTypeEssay || question.Score != nil {
+		return nil, ErrVideoAnswerNotAllowed
+	}
+
+	if !s.aiClient.Available() {
+		return nil, ErrAIServiceUnavailable
+	}
+
+	data, err := readUpload(file)
+	if err != nil {
+		return nil, err
+	}
+	contentType := videoContentType(file)
+
+	aiCtx := genai.WithCallMetadata(ctx, domain.AIFeatureVideoTranscription, userID.String())
+	transcription, err := s.transcribeVideo(aiCtx, interview.JobPosition, question.Question, data, contentType)
+	if err != nil {
+		return nil, err
+	}
+
+	key := fmt.Sprintf("interviews/%s/question-%d-%s%s", interview.ID, question.ID, uuid.New().String(), strings.ToLower(filepath.Ext(file.Filename)))
+	object, err := s.videoStorage.Put(ctx, key, data, contentType)
+	if err != nil {
+		return nil, fmt.Errorf("failed to store video answer: %w", err)
+	}
+
+	previous := question.VideoAnswer
+	question.VideoAnswer = &domain.VideoAnswer{
+		URL:             object.URL,
+		StorageID:       object.ID,
+		ContentType:     contentType,
+		Size:            object.Size,
+		Transcript:      transcription.Transcript,
+		Summary:         transcription.Summary,
+		ContentFeedback: transcription.ContentFeedback,
+		UploadedAt:      time.Now(),
+	}
+
+	if err := s.interviewRepo.Update(ctx, interview); err != nil {
+		_ = s.videoStorage.Delete(ctx, object.ID)
+		return nil, err
+	}
+
+	if previous != nil {
+		if err := s.videoStorage.Delete(ctx, previous.StorageID); err != nil {
+			log.Printf("Failed to delete replaced video answer %s: %v", previous.StorageID, err)
+		}
+	}
+
+	result := toQuestionForUser(question)
+	return &result, nil
+}
+
+// transcribeVideo sends only the audio track when ffmpeg is available, and
+// the whole video otherwise.
+func (s *interviewService) transcribeVideo(ctx context.Context, jobPosition, question string, video []byte, contentType string) (*videoTranscription, error) {
+	data, mimeType := video, contentType
+	if audio, err := media.ExtractAudio(ctx, s.ffmpegPath, video); err == nil {
+		data, mimeType = audio, media.AudioMIMEType
+	} else if !errors.Is(err, media.ErrFFmpegUnavailable) {
+		log.Printf("Audio extraction failed, transcribing the video instead: %v", err)
+	}
+
+	result, err := s.aiClient.GenerateJSONFromData(ctx, data, mimeType, fmt.Sprintf(transcribeVideoAnswerPrompt, jobPosition, question))
+	if err != nil {
+		return nil, err
+	}
+
+	var transcription videoTranscription
+	if err := json.Unmarshal([]byte(result), &transcription); err != nil {
+		return nil, err
+	}
+	transcription.Transcript = strings.TrimSpace(transcription.Transcript)
+	if transcription.Transcript == "" {
+		return nil, ErrVideoNoSpeech
+	}
+
+	return &transcription, nil
+}
+
+// withVideoAnswers answers each question that has a transcribed video with
+// the transcript, unless the candidate also typed an answer for it.
+func withVideoAnswers(answers map[int]domain.AnswerSubmission, questions []domain.Question) {
+	for _, q := range questions {
+		if q.VideoAnswer == nil || q.VideoAnswer.Transcript == "" {
+			continue
+		}
+		if ans, ok := answers[q.ID]; ok && ans.Answer != "" {
+			continue
+		}
+		answers[q.ID] = domain.AnswerSubmission{QuestionID: q.ID, Answer: q.VideoAnswer.Transcript}
+	}
+}
+
+func answeredByVideo(q *domain.Question) bool {
+	return q.VideoAnswer != nil && q.UserAnswer != "" && q.UserAnswer == q.VideoAnswer.Transcript
+}
+
+func addVideoFeedback(questions []*domain.Question) {
+	for _, q := range questions {
+		if !answeredByVideo(q) || q.VideoAnswer.ContentFeedback == "" {
+			continue
+		}
+		q.Feedback = fmt.Sprintf("%s\n\nSpoken answer: %s", q.Feedback, q.VideoAnswer.ContentFeedback)
+	}
+}
+
+func readUpload(file *multipart.FileHeader) ([]byte, error) {
+	f, err := file.Open()
+	if err != nil {
+		return nil, fmt.Errorf("failed to open file: %w", err)
+	}
+	defer f.Close()
+
+	data, err := io.ReadAll(f)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read file: %w", err)
+	}
+	return data, nil
+}
+
+func videoContentType(file *multipart.FileHeader) string {
+	if contentType, ok := videoContentTypes[strings.ToLower(filepath.Ext(file.Filename))]; ok {
+		return contentType
+	}
+	return file.Header.Get("Content-Type")
+}
@@ -0,0 +1,133 @@
+package service
+
+import (
+	"context"
+	"errors"
+
+	"github.com/raflytch/careerly-server/internal/domain"
+	"github.com/raflytch/careerly-server/pkg/jobqueue"
+
+	"github.com/google/uuid"
+)
+
+var ErrJobNotFound = errors.New("job not found")
+
+type jobService struct {
+	queue        *jobqueue.Queue
+	auditService domain.AuditService
+}
+
+func NewJobService(queue *jobqueue.Queue, auditService domain.AuditService) domain.JobService {
+	return &jobService{
+		queue:        queue,
+		auditService: auditService,
+	}
+}
+
+func (s *jobService) GetStats(ctx context.Context) (*domain.JobQueueStats, error) {
+	stats, err := s.queue.Stats(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	return &domain.JobQueueStats{
+		Queued:    stats.Queued,
+		Running:   stats.Running,
+		Scheduled: stats.Scheduled,
+		Dead:      stats.Dead,
+	}, nil
+}
+
+func (s *jobService) GetDeadJobs(ctx context.Context, page, limit int) (*domain.PaginatedQueuedJobs, error) {
+	if page < 1 {
+		page = 1
+	}
+	if limit < 1 {
+		limit = 10
+	}
+	if limit > 100 {
+		limit = 100
+	}
+
+	offset := (page - 1) * limit
+
+	jobs, total, err := s.queue.DeadJobs(ctx, offset, limit)
+	if err != nil {
+		return nil, err
+	}
+
+	result := make([]domain.QueuedJob, 0, len(jobs))
+	for i := range jobs {
+		result = append(result, *toQueuedJob(&jobs[i]))
+	}
+
+	totalPages := int(total) / limit
+	if int(total)%limit > 0 {
+		totalPages++
+	}
+
+	return &domain.PaginatedQueuedJobs{
+		Jobs: result,
+		Pagination: domain.Pagination{
+			Page:       page,
+			Limit:      limit,
+			Total:      total,
+			TotalPages: totalPages,
+		},
+	}, nil
+}
+
+func (s *jobService) GetDeadJob(ctx context.Context, id uuid.UUID) (*domain.QueuedJob, error) {
+	job, err := s.queue.DeadJob(ctx, id.String())
+	if err != nil {
+		if errors.Is(err, jobqueue.ErrJobNotFound) {
+			return nil, ErrJobNotFound
+		}
+		return nil, err
+	}
+	return toQueuedJob(job), nil
+}
+
+func (s *jobService) RetryDeadJob(ctx context.Context, id uuid.UUID) (*domain.QueuedJob, error) {
+	job, err := s.queue.RetryDead(ctx, id.String())
+	if err != nil {
+		if errors.Is(err, jobqueue.ErrJobNotFound) {
+			return nil, ErrJobNotFound
+		}
+		return nil, err
+	}
+
+	s.auditService.Record(ctx, domain.AuditActionJobRetry, domain.AuditTargetJob, id, nil, toQueuedJob(job))
+
+	return toQueuedJob(job), nil
+}
+
+func (s *jobService) DeleteDeadJob(ctx context.Context, id uuid.UUID) error {
+	job, err := s.GetDeadJob(ctx, id)
+	if err != nil {
+		return err
+	}
+
+	if err := s.queue.DeleteDead(ctx, id.String()); err != nil {
+		if errors.Is(err, jobqueue.ErrJobNotFound) {
+			return ErrJobNotFound
+		}
+		return err
+	}
+
+	s.auditService.Record(ctx, domain.AuditActionJobDelete, domain.AuditTargetJob, id, job, nil)
+
+	return nil
+}
+
+func toQueuedJob(job *jobqueue.Job) *domain.QueuedJob {
+	return &domain.QueuedJob{
+		ID:         job.ID,
+		Type:       job.Type,
+		Payload:    job.Payload,
+		Attempts:   job.Attempts,
+		LastError:  job.LastError,
+		EnqueuedAt: job.EnqueuedAt,
+		FailedAt:   job.FailedAt,
+	}
+}
@@ -0,0 +1,117 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/raflytch/careerly-server/internal/domain"
+
+	"github.com/google/uuid"
+)
+
+type notificationService struct {
+	notificationRepo domain.NotificationRepository
+	userRepo         domain.UserRepository
+	emailService     domain.EmailService
+}
+
+func NewNotificationService(notificationRepo domain.NotificationRepository, userRepo domain.UserRepository, emailService domain.EmailService) domain.NotificationService {
+	return &notificationService{
+		notificationRepo: notificationRepo,
+		userRepo:         userRepo,
+		emailService:     emailService,
+	}
+}
+
+// NotifyQuotaThreshold records an in-app notification for a crossed quota
+// threshold and emails the user. The dedupe key makes it a no-op when the
+// threshold was already reported for the period, so callers racing on the
+// same usage row send at most one.
+func (s *notificationService) NotifyQuotaThreshold(ctx context.Context, event domain.QuotaThresholdEvent) error {
+	label := featureLabel(event.Feature)
+
+	data, err := json.Marshal(event)
+	if err != nil {
+		return err
+	}
+
+	notification := &domain.Notification{
+		ID:        uuid.New(),
+		UserID:    event.UserID,
+		Type:      domain.NotificationQuotaWarning,
+		Title:     fmt.Sprintf("You have used %d%% of your %s quota", event.Threshold, label),
+		Body:      fmt.Sprintf("You have used %d of %d %s this month.", event.Used, event.Limit, label),
+		Data:      data,
+		DedupeKey: fmt.Sprintf("quota:%s:%s:%d", event.Feature, event.PeriodMonth.Format("2006-01"), event.Threshold),
+		CreatedAt: time.Now(),
+	}
+	if event.Threshold >= 100 {
+		notification.Type = domain.NotificationQuotaExhausted
+		notification.Title = fmt.Sprintf("You have reached your %s quota", label)
+		notification.Body = fmt.Sprintf("You have used all %d %s this month. Upgrade your plan or buy an add-on pack to continue.", event.Limit, label)
+	}
+
+	created, err := s.notificationRepo.CreateOnce(ctx, notification)
+	if err != nil || !created {
+		return err
+	}
+
+	user, err := s.userRepo.FindByID(ctx, event.UserID)
+	if err != nil {
+		return err
+	}
+	return s.emailService.SendQuotaWarning(ctx, contactEmail(user), label, event.Threshold, event.Used, event.Limit)
+}
+
+func (s *notificationService) GetByUserID(ctx context.Context, userID uuid.UUID, page, limit int) (*domain.PaginatedNotifications, error) {
+	if page < 1 {
+		page = 1
+	}
+	if limit < 1 {
+		limit = 10
+	}
+	if limit > 100 {
+		limit = 100
+	}
+
+	offset := (page - 1) * limit
+
+	total, err := s.notificationRepo.CountByUserID(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	notifications, err := s.notificationRepo.FindByUserID(ctx, userID, limit, offset)
+	if err != nil {
+		return nil, err
+	}
+
+	totalPages := int(total) / limit
+	if int(total)%limit > 0 {
+		totalPages++
+	}
+
+	return &domain.PaginatedNotifications{
+		Notifications: notifications,
+		Pagination: domain.Pagination{
+			Page:       page,
+			Limit:      limit,
+			Total:      total,
+			TotalPages: totalPages,
+		},
+	}, nil
+}
+
+func featureLabel(feature domain.FeatureType) string {
+	switch feature {
+	case domain.FeatureResume:
+		return "resumes"
+	case domain.FeatureATSCheck:
+		return "ATS checks"
+	case domain.FeatureInterview:
+		return "mock interviews"
+	}
+	return string(feature)
+}
@@ -0,0 +1,82 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"time"
+
+	"github.com/raflytch/careerly-server/internal/domain"
+
+	"github.com/google/uuid"
+)
+
+const (
+	onboardingCachePrefix = "onboarding:"
+	// Steps never become undone, so a finished checklist can be cached far
+	// longer than one the user is still working through.
+	onboardingCacheDuration         = time.Minute
+	onboardingCompleteCacheDuration = 7 * 24 * time.Hour
+)
+
+type onboardingService struct {
+	onboardingRepo domain.OnboardingRepository
+	cacheRepo      domain.CacheRepository
+}
+
+func NewOnboardingService(onboardingRepo domain.OnboardingRepository, cacheRepo domain.CacheRepository) domain.OnboardingService {
+	return &onboardingService{
+		onboardingRepo: onboardingRepo,
+		cacheRepo:      cacheRepo,
+	}
+}
+
+func (s *onboardingService) GetStatus(ctx context.Context, userID uuid.UUID) (*domain.OnboardingStatus, error) {
+	cacheKey := onboardingCachePrefix + userID.String()
+	cached, err := s.cacheRepo.Get(ctx, cacheKey)
+	if err == nil && cached != "" {
+		var status domain.OnboardingStatus
+		if err := json.Unmarshal([]byte(cached), &status); err == nil {
+			return &status, nil
+		}
+	}
+
+	milestones, err := s.onboardingRepo.GetMilestones(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	steps := []domain.OnboardingStep{
+		onboardingStep(domain.OnboardingStepFirstResume, "Create your first resume", milestones.FirstResumeAt),
+		onboardingStep(domain.OnboardingStepFirstATSCheck, "Run your first ATS check", milestones.FirstATSCheckAt),
+		onboardingStep(domain.OnboardingStepFirstInterview, "Complete your first mock interview", milestones.FirstInterviewAt),
+		onboardingStep(domain.OnboardingStepSubscribed, "Subscribe to a plan", milestones.FirstSubscriptionAt),
+	}
+
+	status := &domain.OnboardingStatus{
+		TotalSteps: len(steps),
+		Steps:      steps,
+	}
+	for _, step := range steps {
+		if step.Completed {
+			status.CompletedSteps++
+		}
+	}
+	status.Completed = status.CompletedSteps == status.TotalSteps
+
+	ttl := onboardingCacheDuration
+	if status.Completed {
+		ttl = onboardingCompleteCacheDuration
+	}
+	_ = s.cacheRepo.Set(ctx, cacheKey, status, ttl)
+
+	return status, nil
+}
+
+func onboardingStep(key, label string, completedAt *time.Time) domain.OnboardingStep {
+	return domain.OnboardingStep{
+		Key:         key,
+		Label:       label,
+		Completed:   completedAt != nil,
+		CompletedAt: completedAt,
+	}
+}
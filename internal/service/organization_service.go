@@ -0,0 +1,283 @@
+package service
+
+import (
+	"context"
+	"crypto/rand"
+	"database/sql"
+	"encoding/hex"
+	"errors"
+	"log"
+	"strings"
+	"time"
+
+	"github.com/raflytch/careerly-server/internal/domain"
+
+	"github.com/google/uuid"
+)
+
+const (
+	organizationInviteTTL     = 7 * 24 * time.Hour
+	organizationInviteURLPath = "/organizations/join?token="
+)
+
+var (
+	ErrOrganizationNotFound      = errors.New("you are not a member of an organization")
+	ErrAlreadyInOrganization     = errors.New("user is already a member of an organization")
+	ErrOrganizationOwnerOnly     = errors.New("only the organization owner can do this")
+	ErrOrganizationNoSeats       = errors.New("all seats of the organization are taken")
+	ErrOrganizationSeatsTooFew   = errors.New("seats cannot be fewer than the current members")
+	ErrOrganizationMemberMissing = errors.New("organization member not found")
+	ErrOrganizationOwnerLeave    = errors.New("the owner cannot leave or be removed from the organization")
+	ErrInvitationInvalid         = errors.New("invitation is invalid or has expired")
+	ErrInvitationEmailMismatch   = errors.New("invitation was sent to a different email address")
+)
+
+type organizationService struct {
+	orgRepo      domain.OrganizationRepository
+	userRepo     domain.UserRepository
+	emailService domain.EmailService
+	frontendURL  string
+}
+
+func NewOrganizationService(orgRepo domain.OrganizationRepository, userRepo domain.UserRepository, emailService domain.EmailService, frontendURL string) domain.OrganizationService {
+	return &organizationService{
+		orgRepo:      orgRepo,
+		userRepo:     userRepo,
+		emailService: emailService,
+		frontendURL:  frontendURL,
+	}
+}
+
+// Create starts an organization owned by userID. It has no seats until the
+// owner buys a plan for it.
+func (s *organizationService) Create(ctx context.Context, userID uuid.UUID, req *domain.CreateOrganizationRequest) (*domain.Organization, error) {
+	if _, err := s.orgRepo.FindMembership(ctx, userID); err == nil {
+		return nil, ErrAlreadyInOrganization
+	} else if !errors.Is(err, sql.ErrNoRows) {
+		return nil, err
+	}
+
+	now := time.Now()
+	org := &domain.Organization{
+		ID:        uuid.New(),
+		Name:      strings.TrimSpace(req.Name),
+		OwnerID:   userID,
+		CreatedAt: now,
+		UpdatedAt: now,
+	}
+	if err := s.orgRepo.Create(ctx, org); err != nil {
+		return nil, err
+	}
+	return org, nil
+}
+
+// GetForUser returns the caller's organization with its members. Pending
+// invitations are only shown to the owner.
+func (s *organizationService) GetForUser(ctx context.Context, userID uuid.UUID) (*domain.OrganizationDetail, error) {
+	membership, org, err := s.membership(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	members, err := s.orgRepo.FindMembers(ctx, org.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	detail := &domain.OrganizationDetail{
+		Organization: *org,
+		Role:         membership.Role,
+		Members:      members,
+	}
+	if membership.Role == domain.OrganizationRoleOwner {
+		detail.Invitations, err = s.orgRepo.FindPendingInvitations(ctx, org.ID, time.Now())
+		if err != nil {
+			return nil, err
+		}
+	}
+	return detail, nil
+}
+
+// Invite emails a join link. Members and open invitations together may not
+// exceed the seats bought, so every accepted invitation has a seat.
+func (s *organizationService) Invite(ctx context.Context, userID uuid.UUID, req *domain.InviteOrganizationMemberRequest) (*domain.OrganizationInvitation, error) {
+	_, org, err := s.ownedOrganization(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	members, err := s.orgRepo.CountMembers(ctx, org.ID)
+	if err != nil {
+		return nil, err
+	}
+	pending, err := s.orgRepo.FindPendingInvitations(ctx, org.ID, now)
+	if err != nil {
+		return nil, err
+	}
+	if members+len(pending) >= org.Seats {
+		return nil, ErrOrganizationNoSeats
+	}
+
+	token, err := generateInvitationToken()
+	if err != nil {
+		return nil, err
+	}
+
+	invitation := &domain.OrganizationInvitation{
+		ID:             uuid.New(),
+		OrganizationID: org.ID,
+		Email:          strings.ToLower(strings.TrimSpace(req.Email)),
+		Token:          token,
+		InvitedBy:      userID,
+		ExpiresAt:      now.Add(organizationInviteTTL),
+		CreatedAt:      now,
+	}
+	if err := s.orgRepo.CreateInvitation(ctx, invitation); err != nil {
+		return nil, err
+	}
+
+	inviterName := "A teammate"
+	if inviter, err := s.userRepo.FindByID(ctx, userID); err == nil {
+		inviterName = inviter.Name
+	}
+	inviteURL := strings.TrimRight(s.frontendURL, "/") + organizationInviteURLPath + token
+	if err := s.emailService.SendOrganizationInvite(ctx, invitation.Email, inviterName, org.Name, inviteURL); err != nil {
+		log.Printf("Failed to email organization invitation %s to %s: %v", invitation.ID, invitation.Email, err)
+	}
+
+	return invitation, nil
+}
+
+// AcceptInvitation joins the caller to the inviting organization. The
+// invitation must have been sent to one of the caller's email addresses.
+func (s *organizationService) AcceptInvitation(ctx context.Context, userID uuid.UUID, req *domain.AcceptOrganizationInvitationRequest) (*domain.Organization, error) {
+	invitation, err := s.orgRepo.FindInvitationByToken(ctx, req.Token)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, ErrInvitationInvalid
+		}
+		return nil, err
+	}
+	if invitation.AcceptedAt != nil || time.Now().After(invitation.ExpiresAt) {
+		return nil, ErrInvitationInvalid
+	}
+
+	user, err := s.userRepo.FindByID(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	if !strings.EqualFold(invitation.Email, user.Email) && !strings.EqualFold(invitation.Email, contactEmail(user)) {
+		return nil, ErrInvitationEmailMismatch
+	}
+
+	if _, err := s.orgRepo.FindMembership(ctx, userID); err == nil {
+		return nil, ErrAlreadyInOrganization
+	} else if !errors.Is(err, sql.ErrNoRows) {
+		return nil, err
+	}
+
+	org, err := s.orgRepo.FindByID(ctx, invitation.OrganizationID)
+	if err != nil {
+		return nil, err
+	}
+	members, err := s.orgRepo.CountMembers(ctx, org.ID)
+	if err != nil {
+		return nil, err
+	}
+	if members >= org.Seats {
+		return nil, ErrOrganizationNoSeats
+	}
+
+	accepted, err := s.orgRepo.AcceptInvitation(ctx, invitation.ID, time.Now())
+	if err != nil {
+		return nil, err
+	}
+	if !accepted {
+		return nil, ErrInvitationInvalid
+	}
+
+	member := &domain.OrganizationMember{
+		OrganizationID: org.ID,
+		UserID:         userID,
+		Role:           domain.OrganizationRoleMember,
+		JoinedAt:       time.Now(),
+	}
+	if err := s.orgRepo.AddMember(ctx, member); err != nil {
+		return nil, err
+	}
+	return org, nil
+}
+
+func (s *organizationService) RemoveMember(ctx context.Context, userID, memberID uuid.UUID) error {
+	_, org, err := s.ownedOrganization(ctx, userID)
+	if err != nil {
+		return err
+	}
+	if memberID == org.OwnerID {
+		return ErrOrganizationOwnerLeave
+	}
+
+	removed, err := s.orgRepo.RemoveMember(ctx, org.ID, memberID)
+	if err != nil {
+		return err
+	}
+	if !removed {
+		return ErrOrganizationMemberMissing
+	}
+	return nil
+}
+
+func (s *organizationService) Leave(ctx context.Context, userID uuid.UUID) error {
+	membership, _, err := s.membership(ctx, userID)
+	if err != nil {
+		return err
+	}
+	if membership.Role == domain.OrganizationRoleOwner {
+		return ErrOrganizationOwnerLeave
+	}
+
+	_, err = s.orgRepo.RemoveMember(ctx, membership.OrganizationID, userID)
+	return err
+}
+
+func (s *organizationService) membership(ctx context.Context, userID uuid.UUID) (*domain.OrganizationMember, *domain.Organization, error) {
+	return findOrganizationMembership(ctx, s.orgRepo, userID)
+}
+
+func (s *organizationService) ownedOrganization(ctx context.Context, userID uuid.UUID) (*domain.OrganizationMember, *domain.Organization, error) {
+	membership, org, err := s.membership(ctx, userID)
+	if err != nil {
+		return nil, nil, err
+	}
+	if membership.Role != domain.OrganizationRoleOwner {
+		return nil, nil, ErrOrganizationOwnerOnly
+	}
+	return membership, org, nil
+}
+
+// findOrganizationMembership returns the user's membership and its
+// organization, or ErrOrganizationNotFound when the user has none.
+func findOrganizationMembership(ctx context.Context, orgRepo domain.OrganizationRepository, userID uuid.UUID) (*domain.OrganizationMember, *domain.Organization, error) {
+	membership, err := orgRepo.FindMembership(ctx, userID)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, nil, ErrOrganizationNotFound
+		}
+		return nil, nil, err
+	}
+
+	org, err := orgRepo.FindByID(ctx, membership.OrganizationID)
+	if err != nil {
+		return nil, nil, err
+	}
+	return membership, org, nil
+}
+
+func generateInvitationToken() (string, error) {
+	b := make([]byte, 32)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(b), nil
+}
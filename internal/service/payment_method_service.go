@@ -0,0 +1,46 @@
+package service
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+
+	"github.com/raflytch/careerly-server/internal/domain"
+
+	"github.com/google/uuid"
+)
+
+type paymentMethodService struct {
+	paymentMethodRepo domain.PaymentMethodRepository
+}
+
+func NewPaymentMethodService(paymentMethodRepo domain.PaymentMethodRepository) domain.PaymentMethodService {
+	return &paymentMethodService{paymentMethodRepo: paymentMethodRepo}
+}
+
+func (s *paymentMethodService) GetByUserID(ctx context.Context, userID uuid.UUID) ([]domain.PaymentMethod, error) {
+	return s.paymentMethodRepo.FindByUserID(ctx, userID)
+}
+
+// Delete forgets the card. Midtrans has no call to revoke a saved token, so
+// dropping our copy is what stops it from being charged again.
+func (s *paymentMethodService) Delete(ctx context.Context, userID uuid.UUID, id uuid.UUID) error {
+	method, err := s.paymentMethodRepo.FindByID(ctx, id)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return ErrPaymentMethodNotFound
+		}
+		return err
+	}
+	if method.UserID != userID {
+		return ErrPaymentMethodNotFound
+	}
+
+	if err := s.paymentMethodRepo.Delete(ctx, id); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return ErrPaymentMethodNotFound
+		}
+		return err
+	}
+	return nil
+}
@@ -0,0 +1,61 @@
+package service
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+
+	"github.com/raflytch/careerly-server/internal/domain"
+
+	"github.com/google/uuid"
+)
+
+type paymentSimulationService struct {
+	transactionRepo    domain.TransactionRepository
+	transactionService domain.TransactionService
+	simulator          domain.PaymentSimulator
+}
+
+func NewPaymentSimulationService(transactionRepo domain.TransactionRepository, transactionService domain.TransactionService, simulator domain.PaymentSimulator) domain.PaymentSimulationService {
+	return &paymentSimulationService{
+		transactionRepo:    transactionRepo,
+		transactionService: transactionService,
+		simulator:          simulator,
+	}
+}
+
+// SimulatePayment settles the caller's order and feeds a signed settlement
+// notification through HandleWebhook, so the order goes through the same
+// claim, queue and provisioning steps as a real payment.
+func (s *paymentSimulationService) SimulatePayment(ctx context.Context, userID uuid.UUID, orderID string) error {
+	transaction, err := s.transactionRepo.FindByOrderID(ctx, orderID)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return ErrTransactionNotFound
+		}
+		return err
+	}
+	if transaction.UserID != userID {
+		return ErrTransactionNotFound
+	}
+	if transaction.Status == domain.TransactionStatusSuccess {
+		return ErrTransactionAlreadyPaid
+	}
+
+	status := s.simulator.Settle(orderID, transaction.GrossAmount.StringFixed(2))
+	payload := map[string]interface{}{
+		"order_id":           status.OrderID,
+		"transaction_id":     status.TransactionID,
+		"transaction_status": status.TransactionStatus,
+		"fraud_status":       status.FraudStatus,
+		"payment_type":       status.PaymentType,
+		"gross_amount":       status.GrossAmount,
+		"status_code":        status.StatusCode,
+		"status_message":     status.StatusMessage,
+		"transaction_time":   status.TransactionTime,
+		"settlement_time":    status.SettlementTime,
+		"signature_key":      s.simulator.Sign(status.OrderID, status.StatusCode, status.GrossAmount),
+	}
+
+	return s.transactionService.HandleWebhook(ctx, payload)
+}
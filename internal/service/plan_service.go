@@ -13,25 +13,33 @@ import (
 )
 
 const (
-	planCachePrefix  = "plan:"
-	planListCacheKey = "plans:list"
+	planCachePrefix   = "plan:"
+	planListCacheKey  = "plans:list"
+	planCacheDuration = 1 * time.Hour
 )
 
 var (
 	ErrPlanNotFound    = errors.New("plan not found")
 	ErrPlanNameExists  = errors.New("plan name already exists")
 	ErrInvalidPlanData = errors.New("invalid plan data")
+	ErrPlanInUse       = errors.New("plan has subscriptions or transactions, archive it instead")
 )
 
 type planService struct {
-	planRepo  domain.PlanRepository
-	cacheRepo domain.CacheRepository
+	planRepo     domain.PlanRepository
+	cacheRepo    domain.CacheRepository
+	listLoader   *cachedLoader
+	planLoader   *cachedLoader
+	auditService domain.AuditService
 }
 
-func NewPlanService(planRepo domain.PlanRepository, cacheRepo domain.CacheRepository) domain.PlanService {
+func NewPlanService(planRepo domain.PlanRepository, cacheRepo domain.CacheRepository, auditService domain.AuditService) domain.PlanService {
 	return &planService{
-		planRepo:  planRepo,
-		cacheRepo: cacheRepo,
+		planRepo:     planRepo,
+		cacheRepo:    cacheRepo,
+		listLoader:   newCachedLoader(cacheRepo, planCacheDuration),
+		planLoader:   newCachedLoader(cacheRepo, planCacheDuration),
+		auditService: auditService,
 	}
 }
 
@@ -62,25 +70,31 @@ func (s *planService) Create(ctx context.Context, req *domain.CreatePlanRequest)
 		IsActive:      isActive,
 		CreatedAt:     time.Now(),
 	}
+	if req.CustomBranding != nil {
+		plan.CustomBranding = *req.CustomBranding
+	}
 
 	if err := s.planRepo.Create(ctx, plan); err != nil {
 		return nil, err
 	}
 
 	s.invalidateListCache(ctx)
+	s.auditService.Record(ctx, domain.AuditActionPlanCreate, domain.AuditTargetPlan, plan.ID, nil, plan)
 
 	return plan, nil
 }
 
 func (s *planService) GetByID(ctx context.Context, id uuid.UUID) (*domain.Plan, error) {
-	plan, err := s.planRepo.FindByID(ctx, id)
-	if err != nil {
-		if errors.Is(err, sql.ErrNoRows) {
-			return nil, ErrPlanNotFound
+	return loadCached(ctx, s.planLoader, planCachePrefix+id.String(), func(ctx context.Context) (*domain.Plan, error) {
+		plan, err := s.planRepo.FindByID(ctx, id)
+		if err != nil {
+			if errors.Is(err, sql.ErrNoRows) {
+				return nil, ErrPlanNotFound
+			}
+			return nil, err
 		}
-		return nil, err
-	}
-	return plan, nil
+		return plan, nil
+	})
 }
 
 func (s *planService) GetAll(ctx context.Context, page, limit int, includeInactive bool) (*domain.PaginatedPlans, error) {
@@ -94,32 +108,35 @@ func (s *planService) GetAll(ctx context.Context, page, limit int, includeInacti
 		limit = 100
 	}
 
-	offset := (page - 1) * limit
+	cacheKey := fmt.Sprintf("%s:%d:%d:%t", planListCacheKey, page, limit, includeInactive)
+	return loadCached(ctx, s.listLoader, cacheKey, func(ctx context.Context) (*domain.PaginatedPlans, error) {
+		offset := (page - 1) * limit
 
-	total, err := s.planRepo.Count(ctx, includeInactive)
-	if err != nil {
-		return nil, err
-	}
+		total, err := s.planRepo.Count(ctx, includeInactive)
+		if err != nil {
+			return nil, err
+		}
 
-	plans, err := s.planRepo.FindAll(ctx, limit, offset, includeInactive)
-	if err != nil {
-		return nil, err
-	}
+		plans, err := s.planRepo.FindAll(ctx, limit, offset, includeInactive)
+		if err != nil {
+			return nil, err
+		}
 
-	totalPages := int(total) / limit
-	if int(total)%limit > 0 {
-		totalPages++
-	}
+		totalPages := int(total) / limit
+		if int(total)%limit > 0 {
+			totalPages++
+		}
 
-	return &domain.PaginatedPlans{
-		Plans: plans,
-		Pagination: domain.Pagination{
-			Page:       page,
-			Limit:      limit,
-			Total:      total,
-			TotalPages: totalPages,
-		},
-	}, nil
+		return &domain.PaginatedPlans{
+			Plans: plans,
+			Pagination: domain.Pagination{
+				Page:       page,
+				Limit:      limit,
+				Total:      total,
+				TotalPages: totalPages,
+			},
+		}, nil
+	})
 }
 
 func (s *planService) Update(ctx context.Context, id uuid.UUID, req *domain.UpdatePlanRequest) (*domain.Plan, error) {
@@ -131,6 +148,8 @@ func (s *planService) Update(ctx context.Context, id uuid.UUID, req *domain.Upda
 		return nil, err
 	}
 
+	before := *plan
+
 	if req.Name != nil && *req.Name != plan.Name {
 		existing, _ := s.planRepo.FindByName(ctx, *req.Name)
 		if existing != nil {
@@ -157,6 +176,9 @@ func (s *planService) Update(ctx context.Context, id uuid.UUID, req *domain.Upda
 	if req.MaxInterviews != nil {
 		plan.MaxInterviews = req.MaxInterviews
 	}
+	if req.CustomBranding != nil {
+		plan.CustomBranding = *req.CustomBranding
+	}
 	if req.IsActive != nil {
 		plan.IsActive = *req.IsActive
 	}
@@ -166,12 +188,15 @@ func (s *planService) Update(ctx context.Context, id uuid.UUID, req *domain.Upda
 	}
 
 	s.invalidateCache(ctx, id)
+	s.auditService.Record(ctx, domain.AuditActionPlanUpdate, domain.AuditTargetPlan, id, before, plan)
 
 	return plan, nil
 }
 
+// Delete removes a plan nobody ever bought. Plans referenced by a
+// subscription or transaction have to be archived so those keep resolving.
 func (s *planService) Delete(ctx context.Context, id uuid.UUID) error {
-	_, err := s.planRepo.FindByID(ctx, id)
+	plan, err := s.planRepo.FindByID(ctx, id)
 	if err != nil {
 		if errors.Is(err, sql.ErrNoRows) {
 			return ErrPlanNotFound
@@ -179,15 +204,66 @@ func (s *planService) Delete(ctx context.Context, id uuid.UUID) error {
 		return err
 	}
 
+	usage, err := s.planRepo.CountUsage(ctx, id)
+	if err != nil {
+		return err
+	}
+	if usage > 0 {
+		return ErrPlanInUse
+	}
+
 	if err := s.planRepo.SoftDelete(ctx, id); err != nil {
 		return err
 	}
 
 	s.invalidateCache(ctx, id)
+	s.auditService.Record(ctx, domain.AuditActionPlanDelete, domain.AuditTargetPlan, id, plan, nil)
 
 	return nil
 }
 
+// Archive takes a plan off sale. Current subscribers keep it until their
+// subscription ends, and pending payments for it still complete.
+func (s *planService) Archive(ctx context.Context, id uuid.UUID) (*domain.Plan, error) {
+	return s.setArchived(ctx, id, true)
+}
+
+func (s *planService) Unarchive(ctx context.Context, id uuid.UUID) (*domain.Plan, error) {
+	return s.setArchived(ctx, id, false)
+}
+
+func (s *planService) setArchived(ctx context.Context, id uuid.UUID, archived bool) (*domain.Plan, error) {
+	plan, err := s.planRepo.FindByID(ctx, id)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, ErrPlanNotFound
+		}
+		return nil, err
+	}
+
+	if archived == (plan.ArchivedAt != nil) {
+		return plan, nil
+	}
+
+	before := *plan
+	action := domain.AuditActionPlanUnarchive
+	plan.ArchivedAt = nil
+	if archived {
+		now := time.Now()
+		action = domain.AuditActionPlanArchive
+		plan.ArchivedAt = &now
+	}
+
+	if err := s.planRepo.SetArchived(ctx, id, plan.ArchivedAt); err != nil {
+		return nil, err
+	}
+
+	s.invalidateCache(ctx, id)
+	s.auditService.Record(ctx, action, domain.AuditTargetPlan, id, before, plan)
+
+	return plan, nil
+}
+
 func (s *planService) validateCreateRequest(req *domain.CreatePlanRequest) error {
 	if req.Name == "" {
 		return ErrInvalidPlanData
@@ -199,8 +275,7 @@ func (s *planService) validateCreateRequest(req *domain.CreatePlanRequest) error
 }
 
 func (s *planService) invalidateCache(ctx context.Context, id uuid.UUID) {
-	cacheKey := fmt.Sprintf("%s%s", planCachePrefix, id.String())
-	_ = s.cacheRepo.Delete(ctx, cacheKey)
+	_ = s.cacheRepo.Delete(ctx, planCachePrefix+id.String())
 	s.invalidateListCache(ctx)
 }
 
@@ -0,0 +1,130 @@
+package service
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/raflytch/careerly-server/internal/domain"
+
+	"github.com/shopspring/decimal"
+)
+
+// chargeCurrency is what Midtrans bills in. Local prices are only a guide.
+const chargeCurrency = "IDR"
+
+type countryPricing struct {
+	currency string
+	// tax is the local consumption tax, which prices do not include.
+	tax string
+}
+
+var countryPricingTable = map[string]countryPricing{
+	"ID": {currency: "IDR"},
+	"SG": {currency: "SGD", tax: "GST"},
+	"MY": {currency: "MYR", tax: "SST"},
+	"PH": {currency: "PHP", tax: "VAT"},
+	"TH": {currency: "THB", tax: "VAT"},
+	"VN": {currency: "VND", tax: "VAT"},
+	"IN": {currency: "INR", tax: "GST"},
+	"JP": {currency: "JPY", tax: "consumption tax"},
+	"AU": {currency: "AUD", tax: "GST"},
+	"GB": {currency: "GBP", tax: "VAT"},
+	"US": {currency: "USD", tax: "sales tax"},
+	"AT": {currency: "EUR", tax: "VAT"},
+	"BE": {currency: "EUR", tax: "VAT"},
+	"DE": {currency: "EUR", tax: "VAT"},
+	"ES": {currency: "EUR", tax: "VAT"},
+	"FI": {currency: "EUR", tax: "VAT"},
+	"FR": {currency: "EUR", tax: "VAT"},
+	"IE": {currency: "EUR", tax: "VAT"},
+	"IT": {currency: "EUR", tax: "VAT"},
+	"NL": {currency: "EUR", tax: "VAT"},
+	"PT": {currency: "EUR", tax: "VAT"},
+}
+
+// currencyDecimals lists currencies that are not quoted in cents.
+var currencyDecimals = map[string]int32{
+	"IDR": 0,
+	"JPY": 0,
+	"VND": 0,
+}
+
+type pricingService struct {
+	// exchangeRates holds how much of each currency one rupiah buys.
+	exchangeRates map[string]decimal.Decimal
+}
+
+func NewPricingService(exchangeRates map[string]decimal.Decimal) domain.PricingService {
+	return &pricingService{
+		exchangeRates: exchangeRates,
+	}
+}
+
+// ParseExchangeRates reads a spec such as "USD=0.000061,SGD=0.000082",
+// giving the amount of each currency worth one rupiah.
+func ParseExchangeRates(spec string) (map[string]decimal.Decimal, error) {
+	rates := make(map[string]decimal.Decimal)
+	for _, entry := range strings.Split(spec, ",") {
+		entry = strings.TrimSpace(entry)
+		if entry == "" {
+			continue
+		}
+		currency, value, ok := strings.Cut(entry, "=")
+		if !ok {
+			return nil, fmt.Errorf("invalid exchange rate %q, expected CURRENCY=rate", entry)
+		}
+		rate, err := decimal.NewFromString(strings.TrimSpace(value))
+		if err != nil || !rate.IsPositive() {
+			return nil, fmt.Errorf("invalid exchange rate for %s: %q", currency, value)
+		}
+		rates[strings.ToUpper(strings.TrimSpace(currency))] = rate
+	}
+	return rates, nil
+}
+
+// Localize converts plan prices into the currency of country. Countries
+// that are unknown, or whose currency has no configured rate, are shown
+// rupiah prices.
+func (s *pricingService) Localize(plans *domain.PaginatedPlans, country string) *domain.LocalizedPlans {
+	country = strings.ToUpper(country)
+
+	pricing := domain.PlanPricing{
+		Country:        country,
+		Currency:       chargeCurrency,
+		ChargeCurrency: chargeCurrency,
+		ExchangeRate:   decimal.NewFromInt(1),
+	}
+	if local, ok := countryPricingTable[country]; ok {
+		pricing.TaxHint = taxHint(local)
+		if rate, ok := s.exchangeRates[local.currency]; ok {
+			pricing.Currency = local.currency
+			pricing.ExchangeRate = rate
+		}
+	}
+
+	decimals, ok := currencyDecimals[pricing.Currency]
+	if !ok {
+		decimals = 2
+	}
+
+	localized := make([]domain.LocalizedPlan, len(plans.Plans))
+	for i, plan := range plans.Plans {
+		localized[i] = domain.LocalizedPlan{
+			Plan:       plan,
+			LocalPrice: plan.Price.Mul(pricing.ExchangeRate).Round(decimals),
+		}
+	}
+
+	return &domain.LocalizedPlans{
+		Plans:      localized,
+		Pagination: plans.Pagination,
+		Pricing:    pricing,
+	}
+}
+
+func taxHint(local countryPricing) string {
+	if local.currency == chargeCurrency {
+		return "Prices include 11% VAT (PPN)."
+	}
+	return fmt.Sprintf("Prices exclude %s. You are charged in %s, so your card issuer may add foreign transaction fees.", local.tax, chargeCurrency)
+}
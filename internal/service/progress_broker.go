@@ -0,0 +1,77 @@
+package service
+
+import (
+	"sync"
+
+	"github.com/raflytch/careerly-server/internal/domain"
+
+	"github.com/google/uuid"
+)
+
+const progressSubscriberBuffer = 32
+
+// progressBroker fans progress events of background work out to the
+// WebSocket and SSE subscribers connected to this instance, keyed by the
+// entity being worked on.
+type progressBroker[T any] struct {
+	key         func(T) uuid.UUID
+	mu          sync.RWMutex
+	subscribers map[uuid.UUID]map[chan T]struct{}
+}
+
+func newProgressBroker[T any](key func(T) uuid.UUID) *progressBroker[T] {
+	return &progressBroker[T]{
+		key:         key,
+		subscribers: make(map[uuid.UUID]map[chan T]struct{}),
+	}
+}
+
+func NewInterviewProgressBroker() domain.InterviewProgressBroker {
+	return newProgressBroker(func(event domain.InterviewProgressEvent) uuid.UUID {
+		return event.InterviewID
+	})
+}
+
+func NewATSProgressBroker() domain.ATSProgressBroker {
+	return newProgressBroker(func(job domain.ATSAnalysisJob) uuid.UUID {
+		return job.ID
+	})
+}
+
+func (b *progressBroker[T]) Publish(event T) {
+	b.mu.RLock()
+	defer b.mu.RUnlock()
+
+	for ch := range b.subscribers[b.key(event)] {
+		select {
+		case ch <- event:
+		default:
+		}
+	}
+}
+
+func (b *progressBroker[T]) Subscribe(id uuid.UUID) (<-chan T, func()) {
+	ch := make(chan T, progressSubscriberBuffer)
+
+	b.mu.Lock()
+	if b.subscribers[id] == nil {
+		b.subscribers[id] = make(map[chan T]struct{})
+	}
+	b.subscribers[id][ch] = struct{}{}
+	b.mu.Unlock()
+
+	var once sync.Once
+	unsubscribe := func() {
+		once.Do(func() {
+			b.mu.Lock()
+			delete(b.subscribers[id], ch)
+			if len(b.subscribers[id]) == 0 {
+				delete(b.subscribers, id)
+			}
+			b.mu.Unlock()
+			close(ch)
+		})
+	}
+
+	return ch, unsubscribe
+}
@@ -0,0 +1,193 @@
+package service
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"math"
+	"time"
+
+	"github.com/raflytch/careerly-server/internal/domain"
+
+	"github.com/google/uuid"
+)
+
+const promptExperimentCachePrefix = "prompt_experiment:"
+
+var (
+	ErrExperimentNotFound        = errors.New("experiment not found")
+	ErrExperimentRunning         = errors.New("an experiment is already running for this prompt, stop it first")
+	ErrExperimentStopped         = errors.New("experiment has already been stopped")
+	ErrExperimentVariants        = errors.New("experiment variants must be distinct prompt versions")
+	ErrPromptVersionInExperiment = errors.New("this prompt version is a variant of a running experiment, stop the experiment first")
+)
+
+// promptExperimentCacheEntry is a running experiment with its variants'
+// prompt text, so assigning a user costs one query instead of one per
+// variant. An empty ID means no experiment is running for the key.
+type promptExperimentCacheEntry struct {
+	ID       uuid.UUID                 `json:"id"`
+	Variants []promptVariantCacheEntry `json:"variants"`
+}
+
+type promptVariantCacheEntry struct {
+	Version int    `json:"version"`
+	Weight  int    `json:"weight"`
+	Content string `json:"content"`
+}
+
+type promptExperimentService struct {
+	experimentRepo domain.PromptExperimentRepository
+	promptRepo     domain.PromptRepository
+	cacheRepo      domain.CacheRepository
+	auditService   domain.AuditService
+}
+
+func NewPromptExperimentService(
+	experimentRepo domain.PromptExperimentRepository,
+	promptRepo domain.PromptRepository,
+	cacheRepo domain.CacheRepository,
+	auditService domain.AuditService,
+) domain.PromptExperimentService {
+	return &promptExperimentService{
+		experimentRepo: experimentRepo,
+		promptRepo:     promptRepo,
+		cacheRepo:      cacheRepo,
+		auditService:   auditService,
+	}
+}
+
+func (s *promptExperimentService) Create(ctx context.Context, req *domain.CreatePromptExperimentRequest) (*domain.PromptExperiment, error) {
+	if _, ok := defaultPrompts[req.Key]; !ok {
+		return nil, ErrUnknownPrompt
+	}
+
+	seen := make(map[int]bool, len(req.Variants))
+	for _, variant := range req.Variants {
+		if seen[variant.Version] {
+			return nil, ErrExperimentVariants
+		}
+		seen[variant.Version] = true
+
+		if variant.Version == 0 {
+			continue
+		}
+		if _, err := s.promptRepo.FindVersion(ctx, req.Key, variant.Version); err != nil {
+			if errors.Is(err, sql.ErrNoRows) {
+				return nil, ErrPromptVersionNotFound
+			}
+			return nil, err
+		}
+	}
+
+	if _, err := s.experimentRepo.FindRunning(ctx, req.Key); err == nil {
+		return nil, ErrExperimentRunning
+	} else if !errors.Is(err, sql.ErrNoRows) {
+		return nil, err
+	}
+
+	experiment := &domain.PromptExperiment{
+		ID:        uuid.New(),
+		Key:       req.Key,
+		Name:      req.Name,
+		Variants:  req.Variants,
+		Status:    domain.PromptExperimentRunning,
+		StartedAt: time.Now(),
+	}
+	if err := s.experimentRepo.Create(ctx, experiment); err != nil {
+		return nil, fmt.Errorf("failed to create experiment: %w", err)
+	}
+
+	s.invalidateCache(ctx, experiment.Key)
+	s.auditService.Record(ctx, domain.AuditActionExperimentCreate, domain.AuditTargetExperiment, experiment.ID, nil, experiment)
+
+	return experiment, nil
+}
+
+func (s *promptExperimentService) List(ctx context.Context) ([]domain.PromptExperiment, error) {
+	return s.experimentRepo.FindAll(ctx)
+}
+
+// GetResults reports every variant, including ones no user has reached yet,
+// with the users assigned to it and the outputs and feedback it produced.
+func (s *promptExperimentService) GetResults(ctx context.Context, id uuid.UUID) (*domain.PromptExperimentResults, error) {
+	experiment, err := s.findExperiment(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	assignments, err := s.experimentRepo.CountAssignments(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	outputs, err := s.experimentRepo.SummarizeOutputs(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	byVersion := make(map[int]domain.PromptVariantResult, len(outputs))
+	for _, output := range outputs {
+		byVersion[output.Version] = output
+	}
+
+	results := &domain.PromptExperimentResults{
+		Experiment: experiment,
+		Variants:   make([]domain.PromptVariantResult, 0, len(experiment.Variants)),
+	}
+	for _, variant := range experiment.Variants {
+		result := byVersion[variant.Version]
+		result.Version = variant.Version
+		result.Weight = variant.Weight
+		result.Users = assignments[variant.Version]
+		if result.Ratings > 0 {
+			satisfaction := float64(result.ThumbsUp) / float64(result.Ratings) * 100
+			result.Satisfaction = math.Round(satisfaction*10) / 10
+		}
+		if result.AverageScore != nil {
+			score := math.Round(*result.AverageScore*10) / 10
+			result.AverageScore = &score
+		}
+		results.Variants = append(results.Variants, result)
+	}
+
+	return results, nil
+}
+
+func (s *promptExperimentService) Stop(ctx context.Context, id uuid.UUID) (*domain.PromptExperiment, error) {
+	experiment, err := s.findExperiment(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if experiment.Status != domain.PromptExperimentRunning {
+		return nil, ErrExperimentStopped
+	}
+
+	before := *experiment
+	now := time.Now()
+	if err := s.experimentRepo.Stop(ctx, id, now); err != nil {
+		return nil, fmt.Errorf("failed to stop experiment: %w", err)
+	}
+
+	experiment.Status = domain.PromptExperimentStopped
+	experiment.EndedAt = &now
+	s.invalidateCache(ctx, experiment.Key)
+	s.auditService.Record(ctx, domain.AuditActionExperimentStop, domain.AuditTargetExperiment, experiment.ID, before, experiment)
+
+	return experiment, nil
+}
+
+func (s *promptExperimentService) findExperiment(ctx context.Context, id uuid.UUID) (*domain.PromptExperiment, error) {
+	experiment, err := s.experimentRepo.FindByID(ctx, id)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, ErrExperimentNotFound
+		}
+		return nil, err
+	}
+	return experiment, nil
+}
+
+func (s *promptExperimentService) invalidateCache(ctx context.Context, key domain.PromptKey) {
+	_ = s.cacheRepo.Delete(ctx, promptExperimentCachePrefix+string(key))
+}
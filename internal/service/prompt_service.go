@@ -0,0 +1,365 @@
+package service
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"log"
+	"math/rand/v2"
+	"regexp"
+	"slices"
+	"time"
+
+	"github.com/raflytch/careerly-server/internal/domain"
+
+	"github.com/google/uuid"
+)
+
+const (
+	promptCachePrefix   = "prompt:"
+	promptCacheDuration = 5 * time.Minute
+)
+
+var (
+	ErrUnknownPrompt         = errors.New("unknown prompt key")
+	ErrPromptVersionNotFound = errors.New("prompt version not found")
+	ErrPromptPlaceholders    = errors.New("prompt must keep the same placeholders, in the same order, as the default")
+	ErrPromptVersionActive   = errors.New("the active prompt version cannot be deleted, activate another version or reset to the default first")
+)
+
+// defaultPrompts are the prompts built into the binary. They are used for
+// every key until an admin activates a stored version, and whenever the
+// stored version cannot be loaded.
+var defaultPrompts = map[domain.PromptKey]string{
+	domain.PromptResumeRewrite:       resumeSystemPrompt,
+	domain.PromptATSAnalysis:         atsFileAnalysisSystemPrompt,
+	domain.PromptInterviewQuestions:  generateQuestionsPrompt,
+	domain.PromptInterviewEvaluation: evaluateAnswersPrompt,
+}
+
+var promptKeys = []domain.PromptKey{
+	domain.PromptResumeRewrite,
+	domain.PromptATSAnalysis,
+	domain.PromptInterviewQuestions,
+	domain.PromptInterviewEvaluation,
+}
+
+var promptPlaceholderPattern = regexp.MustCompile(`%[-+# 0]*[0-9]*(?:\.[0-9]+)?[a-zA-Z]`)
+
+type promptCacheEntry struct {
+	Content string `json:"content"`
+	Version int    `json:"version"`
+}
+
+type promptService struct {
+	promptRepo     domain.PromptRepository
+	experimentRepo domain.PromptExperimentRepository
+	cacheRepo      domain.CacheRepository
+	loader         *cachedLoader
+	auditService   domain.AuditService
+}
+
+func NewPromptService(promptRepo domain.PromptRepository, experimentRepo domain.PromptExperimentRepository, cacheRepo domain.CacheRepository, auditService domain.AuditService) domain.PromptService {
+	return &promptService{
+		promptRepo:     promptRepo,
+		experimentRepo: experimentRepo,
+		cacheRepo:      cacheRepo,
+		loader:         newCachedLoader(cacheRepo, promptCacheDuration),
+		auditService:   auditService,
+	}
+}
+
+// Prompt returns the active version of key and its number, falling back to
+// the built-in default, reported as version 0, when none is active or the
+// stored prompts cannot be read. It never fails, so a database outage
+// degrades to the defaults instead of breaking AI features.
+func (s *promptService) Prompt(ctx context.Context, key domain.PromptKey) (string, int) {
+	fallback := defaultPrompts[key]
+
+	entry, err := loadCached(ctx, s.loader, promptCachePrefix+string(key), func(ctx context.Context) (*promptCacheEntry, error) {
+		prompt, err := s.promptRepo.FindActive(ctx, key)
+		if err != nil {
+			if errors.Is(err, sql.ErrNoRows) {
+				return &promptCacheEntry{}, nil
+			}
+			return nil, err
+		}
+		return &promptCacheEntry{Content: prompt.Content, Version: prompt.Version}, nil
+	})
+	if err != nil {
+		log.Printf("Failed to load prompt %s, using default: %v", key, err)
+		return fallback, 0
+	}
+	if entry.Content == "" {
+		return fallback, 0
+	}
+	return entry.Content, entry.Version
+}
+
+// PromptForUser is Prompt for keys that can run experiments. While one is
+// running for key, the user is assigned one of its variants at random on
+// first use and keeps it for the rest of the experiment.
+func (s *promptService) PromptForUser(ctx context.Context, key domain.PromptKey, userID uuid.UUID) (string, int) {
+	experiment := s.runningExperiment(ctx, key)
+	if experiment == nil {
+		return s.Prompt(ctx, key)
+	}
+
+	version, err := s.experimentRepo.Assign(ctx, experiment.ID, userID, pickVariant(experiment.Variants), time.Now())
+	if err != nil {
+		log.Printf("Failed to assign experiment %s variant to %s, using active prompt: %v", experiment.ID, userID, err)
+		return s.Prompt(ctx, key)
+	}
+
+	for _, variant := range experiment.Variants {
+		if variant.Version == version {
+			return variant.Content, variant.Version
+		}
+	}
+	return s.Prompt(ctx, key)
+}
+
+// RecordUse remembers which version of key produced the AI output stored
+// under entityID, so feedback on that output can be attributed to it, and
+// logs the output against the running experiment when it came from one of
+// its variants.
+func (s *promptService) RecordUse(ctx context.Context, key domain.PromptKey, userID, entityID uuid.UUID, version int) {
+	now := time.Now()
+	if err := s.promptRepo.SaveUsage(ctx, key, entityID, version, now); err != nil {
+		log.Printf("Failed to record prompt %s version %d for %s: %v", key, version, entityID, err)
+	}
+
+	experiment := s.runningExperiment(ctx, key)
+	if experiment == nil {
+		return
+	}
+	for _, variant := range experiment.Variants {
+		if variant.Version != version {
+			continue
+		}
+		if err := s.experimentRepo.SaveOutput(ctx, experiment.ID, userID, entityID, version, now); err != nil {
+			log.Printf("Failed to record experiment %s output %s: %v", experiment.ID, entityID, err)
+		}
+		return
+	}
+}
+
+// runningExperiment returns the experiment running for key, or nil when
+// there is none or it cannot be loaded, in which case the active prompt is
+// used as if no experiment existed.
+func (s *promptService) runningExperiment(ctx context.Context, key domain.PromptKey) *promptExperimentCacheEntry {
+	entry, err := loadCached(ctx, s.loader, promptExperimentCachePrefix+string(key), func(ctx context.Context) (*promptExperimentCacheEntry, error) {
+		experiment, err := s.experimentRepo.FindRunning(ctx, key)
+		if err != nil {
+			if errors.Is(err, sql.ErrNoRows) {
+				return &promptExperimentCacheEntry{}, nil
+			}
+			return nil, err
+		}
+
+		entry := &promptExperimentCacheEntry{ID: experiment.ID}
+		for _, variant := range experiment.Variants {
+			content := defaultPrompts[key]
+			if variant.Version > 0 {
+				prompt, err := s.promptRepo.FindVersion(ctx, key, variant.Version)
+				if err != nil {
+					return nil, err
+				}
+				content = prompt.Content
+			}
+			entry.Variants = append(entry.Variants, promptVariantCacheEntry{Version: variant.Version, Weight: variant.Weight, Content: content})
+		}
+		return entry, nil
+	})
+	if err != nil {
+		log.Printf("Failed to load experiment for prompt %s: %v", key, err)
+		return nil
+	}
+	if entry.ID == uuid.Nil || len(entry.Variants) == 0 {
+		return nil
+	}
+	return entry
+}
+
+func pickVariant(variants []promptVariantCacheEntry) int {
+	total := 0
+	for _, variant := range variants {
+		total += variant.Weight
+	}
+
+	n := rand.IntN(total)
+	for _, variant := range variants {
+		if n < variant.Weight {
+			return variant.Version
+		}
+		n -= variant.Weight
+	}
+	return variants[len(variants)-1].Version
+}
+
+func (s *promptService) List(ctx context.Context) ([]domain.PromptSummary, error) {
+	summaries := make([]domain.PromptSummary, 0, len(promptKeys))
+	for _, key := range promptKeys {
+		versions, err := s.promptRepo.FindByKey(ctx, key)
+		if err != nil {
+			return nil, err
+		}
+
+		summary := domain.PromptSummary{Key: key, UsesDefault: true}
+		for i := range versions {
+			summary.LatestVersion = max(summary.LatestVersion, versions[i].Version)
+			if versions[i].IsActive {
+				summary.ActiveVersion = &versions[i].Version
+				summary.UsesDefault = false
+			}
+		}
+		summaries = append(summaries, summary)
+	}
+	return summaries, nil
+}
+
+func (s *promptService) Get(ctx context.Context, key domain.PromptKey) (*domain.PromptDetail, error) {
+	fallback, ok := defaultPrompts[key]
+	if !ok {
+		return nil, ErrUnknownPrompt
+	}
+
+	versions, err := s.promptRepo.FindByKey(ctx, key)
+	if err != nil {
+		return nil, err
+	}
+
+	detail := &domain.PromptDetail{Key: key, Default: fallback, Versions: versions}
+	for i := range versions {
+		if versions[i].IsActive {
+			detail.Active = &versions[i]
+		}
+	}
+	return detail, nil
+}
+
+// CreateVersion stores a new version of key. Prompts that are filled in
+// with fmt, which are the ones whose default has placeholders, must keep
+// those placeholders, or the values the code passes in would land in the
+// wrong place.
+func (s *promptService) CreateVersion(ctx context.Context, key domain.PromptKey, req *domain.CreatePromptVersionRequest) (*domain.Prompt, error) {
+	fallback, ok := defaultPrompts[key]
+	if !ok {
+		return nil, ErrUnknownPrompt
+	}
+	if placeholders := promptPlaceholders(fallback); len(placeholders) > 0 && !slices.Equal(promptPlaceholders(req.Content), placeholders) {
+		return nil, ErrPromptPlaceholders
+	}
+
+	prompt := &domain.Prompt{
+		ID:        uuid.New(),
+		Key:       key,
+		Content:   req.Content,
+		Note:      req.Note,
+		CreatedAt: time.Now(),
+	}
+	if err := s.promptRepo.Create(ctx, prompt); err != nil {
+		return nil, fmt.Errorf("failed to create prompt version: %w", err)
+	}
+	s.auditService.Record(ctx, domain.AuditActionPromptCreate, domain.AuditTargetPrompt, prompt.ID, nil, prompt)
+
+	if req.Activate {
+		return s.Activate(ctx, key, prompt.Version)
+	}
+	return prompt, nil
+}
+
+func (s *promptService) Activate(ctx context.Context, key domain.PromptKey, version int) (*domain.Prompt, error) {
+	prompt, err := s.findVersion(ctx, key, version)
+	if err != nil {
+		return nil, err
+	}
+
+	before, err := s.promptRepo.FindActive(ctx, key)
+	if err != nil && !errors.Is(err, sql.ErrNoRows) {
+		return nil, err
+	}
+
+	if err := s.promptRepo.Activate(ctx, key, version); err != nil {
+		return nil, fmt.Errorf("failed to activate prompt version: %w", err)
+	}
+
+	prompt.IsActive = true
+	s.invalidateCache(ctx, key)
+	s.auditService.Record(ctx, domain.AuditActionPromptActivate, domain.AuditTargetPrompt, prompt.ID, before, prompt)
+
+	return prompt, nil
+}
+
+// Reset deactivates every stored version of key so the built-in default is
+// used again.
+func (s *promptService) Reset(ctx context.Context, key domain.PromptKey) error {
+	if _, ok := defaultPrompts[key]; !ok {
+		return ErrUnknownPrompt
+	}
+
+	active, err := s.promptRepo.FindActive(ctx, key)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil
+		}
+		return err
+	}
+
+	if err := s.promptRepo.Deactivate(ctx, key); err != nil {
+		return fmt.Errorf("failed to reset prompt: %w", err)
+	}
+
+	s.invalidateCache(ctx, key)
+	s.auditService.Record(ctx, domain.AuditActionPromptReset, domain.AuditTargetPrompt, active.ID, active, nil)
+
+	return nil
+}
+
+func (s *promptService) DeleteVersion(ctx context.Context, key domain.PromptKey, version int) error {
+	prompt, err := s.findVersion(ctx, key, version)
+	if err != nil {
+		return err
+	}
+	if prompt.IsActive {
+		return ErrPromptVersionActive
+	}
+	if experiment := s.runningExperiment(ctx, key); experiment != nil {
+		for _, variant := range experiment.Variants {
+			if variant.Version == version {
+				return ErrPromptVersionInExperiment
+			}
+		}
+	}
+
+	if err := s.promptRepo.Delete(ctx, key, version); err != nil {
+		return fmt.Errorf("failed to delete prompt version: %w", err)
+	}
+	s.auditService.Record(ctx, domain.AuditActionPromptDelete, domain.AuditTargetPrompt, prompt.ID, prompt, nil)
+
+	return nil
+}
+
+func (s *promptService) findVersion(ctx context.Context, key domain.PromptKey, version int) (*domain.Prompt, error) {
+	if _, ok := defaultPrompts[key]; !ok {
+		return nil, ErrUnknownPrompt
+	}
+
+	prompt, err := s.promptRepo.FindVersion(ctx, key, version)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, ErrPromptVersionNotFound
+		}
+		return nil, err
+	}
+	return prompt, nil
+}
+
+func (s *promptService) invalidateCache(ctx context.Context, key domain.PromptKey) {
+	_ = s.cacheRepo.Delete(ctx, promptCachePrefix+string(key))
+}
+
+func promptPlaceholders(prompt string) []string {
+	return promptPlaceholderPattern.FindAllString(prompt, -1)
+}
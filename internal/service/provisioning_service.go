@@ -0,0 +1,182 @@
+package service
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"time"
+
+	"github.com/raflytch/careerly-server/internal/domain"
+
+	"github.com/google/uuid"
+)
+
+const (
+	provisioningMaxAttempts = 8
+	provisioningBaseDelay   = 30 * time.Second
+	provisioningMaxDelay    = 1 * time.Hour
+	provisioningBatchSize   = 50
+)
+
+var (
+	ErrProvisioningJobNotFound  = errors.New("provisioning job not found")
+	ErrProvisioningJobSucceeded = errors.New("provisioning job already succeeded")
+)
+
+// provisioningBackoff returns the delay before the next attempt, doubling
+// from provisioningBaseDelay and capped at provisioningMaxDelay.
+func provisioningBackoff(attempts int) time.Duration {
+	return exponentialBackoff(attempts, provisioningBaseDelay, provisioningMaxDelay)
+}
+
+// exponentialBackoff doubles base for every attempt after the first and caps
+// the result at max.
+func exponentialBackoff(attempts int, base, max time.Duration) time.Duration {
+	delay := base
+	for i := 1; i < attempts; i++ {
+		delay *= 2
+		if delay >= max {
+			return max
+		}
+	}
+	return delay
+}
+
+type provisioningService struct {
+	provisioningJobRepo domain.ProvisioningJobRepository
+	transactionService  domain.TransactionService
+	auditService        domain.AuditService
+}
+
+func NewProvisioningService(provisioningJobRepo domain.ProvisioningJobRepository, transactionService domain.TransactionService, auditService domain.AuditService) domain.ProvisioningService {
+	return &provisioningService{
+		provisioningJobRepo: provisioningJobRepo,
+		transactionService:  transactionService,
+		auditService:        auditService,
+	}
+}
+
+func (s *provisioningService) ProcessDue(ctx context.Context) (*domain.ProvisioningRunResult, error) {
+	jobs, err := s.provisioningJobRepo.FindDue(ctx, time.Now(), provisioningBatchSize)
+	if err != nil {
+		return nil, err
+	}
+
+	result := &domain.ProvisioningRunResult{}
+	for i := range jobs {
+		job := &jobs[i]
+		if err := s.attempt(ctx, job, false); err != nil {
+			return result, err
+		}
+
+		result.Processed++
+		switch job.Status {
+		case domain.ProvisioningJobStatusSucceeded:
+			result.Succeeded++
+		case domain.ProvisioningJobStatusFailed:
+			result.Failed++
+		}
+	}
+
+	return result, nil
+}
+
+func (s *provisioningService) GetAll(ctx context.Context, status domain.ProvisioningJobStatus, page, limit int) (*domain.PaginatedProvisioningJobs, error) {
+	if page < 1 {
+		page = 1
+	}
+	if limit < 1 {
+		limit = 10
+	}
+	if limit > 100 {
+		limit = 100
+	}
+
+	offset := (page - 1) * limit
+
+	total, err := s.provisioningJobRepo.Count(ctx, status)
+	if err != nil {
+		return nil, err
+	}
+
+	jobs, err := s.provisioningJobRepo.FindAll(ctx, status, limit, offset)
+	if err != nil {
+		return nil, err
+	}
+
+	totalPages := int(total) / limit
+	if int(total)%limit > 0 {
+		totalPages++
+	}
+
+	return &domain.PaginatedProvisioningJobs{
+		Jobs: jobs,
+		Pagination: domain.Pagination{
+			Page:       page,
+			Limit:      limit,
+			Total:      total,
+			TotalPages: totalPages,
+		},
+	}, nil
+}
+
+func (s *provisioningService) GetByID(ctx context.Context, id uuid.UUID) (*domain.ProvisioningJob, error) {
+	job, err := s.provisioningJobRepo.FindByID(ctx, id)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, ErrProvisioningJobNotFound
+		}
+		return nil, err
+	}
+	return job, nil
+}
+
+func (s *provisioningService) Replay(ctx context.Context, id uuid.UUID) (*domain.ProvisioningJob, error) {
+	job, err := s.GetByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	if job.Status == domain.ProvisioningJobStatusSucceeded {
+		return nil, ErrProvisioningJobSucceeded
+	}
+
+	before := *job
+	if err := s.attempt(ctx, job, true); err != nil {
+		return nil, err
+	}
+
+	s.auditService.Record(ctx, domain.AuditActionProvisioningReplay, domain.AuditTargetProvisioningJob, job.ID, before, job)
+
+	return job, nil
+}
+
+// attempt runs one provisioning try and persists the outcome. Manual replays
+// do not count towards the automatic retry budget and leave exhausted jobs
+// in the failed state when they fail again.
+func (s *provisioningService) attempt(ctx context.Context, job *domain.ProvisioningJob, manual bool) error {
+	now := time.Now()
+	job.Attempts++
+	job.UpdatedAt = now
+
+	err := s.transactionService.ProvisionSubscription(ctx, job.TransactionID)
+	if err == nil {
+		job.Status = domain.ProvisioningJobStatusSucceeded
+		job.LastError = nil
+		return s.provisioningJobRepo.Update(ctx, job)
+	}
+
+	lastError := err.Error()
+	job.LastError = &lastError
+
+	switch {
+	case manual && job.Status == domain.ProvisioningJobStatusFailed:
+	case job.Attempts >= job.MaxAttempts:
+		job.Status = domain.ProvisioningJobStatusFailed
+	default:
+		job.Status = domain.ProvisioningJobStatusPending
+		job.NextAttemptAt = now.Add(provisioningBackoff(job.Attempts))
+	}
+
+	return s.provisioningJobRepo.Update(ctx, job)
+}
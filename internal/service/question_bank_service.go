@@ -0,0 +1,292 @@
+package service
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/csv"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"mime/multipart"
+	"strings"
+	"time"
+
+	"github.com/raflytch/careerly-server/internal/domain"
+	"github.com/raflytch/careerly-server/pkg/validator"
+
+	"github.com/google/uuid"
+)
+
+const (
+	maxQuestionImportRows = 1000
+	optionSeparator       = "|"
+	optionLabels          = "ABCDEF"
+)
+
+var (
+	ErrBankQuestionNotFound    = errors.New("question not found in the question bank")
+	ErrQuestionImportHeader    = errors.New("csv header must have question, type, correct_answer and position columns; options and difficulty are optional")
+	ErrQuestionImportEmpty     = errors.New("csv has no question rows")
+	ErrQuestionImportTooLarge  = fmt.Errorf("csv can have at most %d question rows", maxQuestionImportRows)
+	ErrQuestionImportMalformed = errors.New("csv file could not be parsed")
+)
+
+var questionImportColumns = []string{"question", "type", "options", "correct_answer", "position", "difficulty"}
+
+type questionBankService struct {
+	bankRepo domain.QuestionBankRepository
+}
+
+func NewQuestionBankService(bankRepo domain.QuestionBankRepository) domain.QuestionBankService {
+	return &questionBankService{
+		bankRepo: bankRepo,
+	}
+}
+
+// Import adds the questions of a CSV file to the bank. Each row is checked
+// like an interview pack question; rejected rows are reported and the rest
+// are still imported. Questions already in the bank, or repeated in the
+// file, for the same position and type are skipped as duplicates.
+//
+// Options are separated by "|" and labelled A, B, C... in order. The
+// correct answer of a multiple choice question is either that label or
+// the text of the option.
+func (s *questionBankService) Import(ctx context.Context, file *multipart.FileHeader) (*domain.QuestionImportResult, error) {
+	src, err := file.Open()
+	if err != nil {
+		return nil, err
+	}
+	defer src.Close()
+
+	reader := csv.NewReader(src)
+	reader.TrimLeadingSpace = true
+
+	header, err := reader.Read()
+	if err != nil {
+		if errors.Is(err, io.EOF) {
+			return nil, ErrQuestionImportEmpty
+		}
+		return nil, fmt.Errorf("%w: %v", ErrQuestionImportMalformed, err)
+	}
+	columns, err := questionImportHeader(header)
+	if err != nil {
+		return nil, err
+	}
+
+	result := &domain.QuestionImportResult{Errors: make([]domain.QuestionImportError, 0)}
+	seen := make(map[string]bool)
+	valid := make([]domain.BankQuestion, 0)
+	now := time.Now()
+
+	for {
+		record, err := reader.Read()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		var parseErr *csv.ParseError
+		if errors.As(err, &parseErr) && errors.Is(err, csv.ErrFieldCount) {
+			result.Rows++
+			result.Invalid++
+			result.Errors = append(result.Errors, domain.QuestionImportError{Row: parseErr.StartLine, Message: "row has a different number of columns than the header"})
+			continue
+		}
+		if err != nil {
+			return nil, fmt.Errorf("%w: %v", ErrQuestionImportMalformed, err)
+		}
+		line, _ := reader.FieldPos(0)
+		if isBlankRecord(record) {
+			continue
+		}
+
+		result.Rows++
+		if result.Rows > maxQuestionImportRows {
+			return nil, ErrQuestionImportTooLarge
+		}
+
+		question, rowErrors := parseQuestionRow(record, columns, line)
+		if len(rowErrors) > 0 {
+			result.Invalid++
+			result.Errors = append(result.Errors, rowErrors...)
+			continue
+		}
+
+		question.DedupeKey = questionDedupeKey(question)
+		if seen[question.DedupeKey] {
+			result.Duplicates++
+			continue
+		}
+		seen[question.DedupeKey] = true
+
+		question.ID = uuid.New()
+		question.CreatedAt = now
+		valid = append(valid, *question)
+	}
+
+	if result.Rows == 0 {
+		return nil, ErrQuestionImportEmpty
+	}
+
+	inserted, err := s.bankRepo.CreateBatch(ctx, valid)
+	if err != nil {
+		return nil, fmt.Errorf("failed to save questions: %w", err)
+	}
+	result.Imported = inserted
+	result.Duplicates += len(valid) - inserted
+
+	return result, nil
+}
+
+func (s *questionBankService) GetAll(ctx context.Context, filter domain.QuestionBankFilter, page, limit int) (*domain.PaginatedBankQuestions, error) {
+	if page < 1 {
+		page = 1
+	}
+	if limit < 1 {
+		limit = 10
+	}
+	if limit > 100 {
+		limit = 100
+	}
+
+	offset := (page - 1) * limit
+
+	total, err := s.bankRepo.Count(ctx, filter)
+	if err != nil {
+		return nil, err
+	}
+
+	questions, err := s.bankRepo.FindAll(ctx, filter, limit, offset)
+	if err != nil {
+		return nil, err
+	}
+
+	totalPages := int(total) / limit
+	if int(total)%limit > 0 {
+		totalPages++
+	}
+
+	return &domain.PaginatedBankQuestions{
+		Questions: questions,
+		Pagination: domain.Pagination{
+			Page:       page,
+			Limit:      limit,
+			Total:      total,
+			TotalPages: totalPages,
+		},
+	}, nil
+}
+
+func (s *questionBankService) Delete(ctx context.Context, id uuid.UUID) error {
+	deleted, err := s.bankRepo.Delete(ctx, id)
+	if err != nil {
+		return err
+	}
+	if !deleted {
+		return ErrBankQuestionNotFound
+	}
+	return nil
+}
+
+// questionImportHeader maps each known column to its index in the file.
+func questionImportHeader(header []string) (map[string]int, error) {
+	columns := make(map[string]int)
+	for i, name := range header {
+		name = strings.ToLower(strings.TrimSpace(strings.TrimPrefix(name, "\ufeff")))
+		for _, column := range questionImportColumns {
+			if name == column {
+				columns[column] = i
+			}
+		}
+	}
+	for _, required := range []string{"question", "type", "correct_answer", "position"} {
+		if _, ok := columns[required]; !ok {
+			return nil, ErrQuestionImportHeader
+		}
+	}
+	return columns, nil
+}
+
+func parseQuestionRow(record []string, columns map[string]int, line int) (*domain.BankQuestion, []domain.QuestionImportError) {
+	field := func(name string) string {
+		i, ok := columns[name]
+		if !ok {
+			return ""
+		}
+		return strings.TrimSpace(record[i])
+	}
+
+	questionType := strings.ToLower(field("type"))
+	questionType = strings.NewReplacer(" ", "_", "-", "_").Replace(questionType)
+
+	packQuestion := domain.InterviewPackQuestion{
+		Type:          domain.QuestionType(questionType),
+		Question:      field("question"),
+		Difficulty:    domain.QuestionDifficulty(strings.ToLower(field("difficulty"))),
+		CorrectAnswer: field("correct_answer"),
+	}
+	if options := field("options"); options != "" {
+		for i, text := range strings.Split(options, optionSeparator) {
+			label := fmt.Sprintf("%d", i+1)
+			if i < len(optionLabels) {
+				label = optionLabels[i : i+1]
+			}
+			text = strings.TrimSpace(text)
+			packQuestion.Options = append(packQuestion.Options, domain.Option{Label: label, Text: text})
+			if strings.EqualFold(text, packQuestion.CorrectAnswer) {
+				packQuestion.CorrectAnswer = label
+			}
+		}
+	}
+
+	var rowErrors []domain.QuestionImportError
+	var fieldErrors validator.ValidationErrors
+	if err := validator.ValidateStruct(&packQuestion); errors.As(err, &fieldErrors) {
+		for _, fieldError := range fieldErrors {
+			rowErrors = append(rowErrors, domain.QuestionImportError{Row: line, Field: fieldError.Field, Message: fieldError.Message})
+		}
+	} else if err != nil {
+		rowErrors = append(rowErrors, domain.QuestionImportError{Row: line, Message: err.Error()})
+	}
+
+	jobPosition := field("position")
+	if len(jobPosition) < 3 || len(jobPosition) > 255 {
+		rowErrors = append(rowErrors, domain.QuestionImportError{Row: line, Field: "position", Message: "position must be between 3 and 255 characters"})
+	}
+
+	if len(rowErrors) == 0 {
+		if err := validatePackQuestions([]domain.InterviewPackQuestion{packQuestion}); err != nil {
+			rowErrors = append(rowErrors, domain.QuestionImportError{Row: line, Message: err.Error()})
+		}
+	}
+	if len(rowErrors) > 0 {
+		return nil, rowErrors
+	}
+
+	return &domain.BankQuestion{
+		JobPosition:   jobPosition,
+		Type:          packQuestion.Type,
+		Question:      packQuestion.Question,
+		Options:       packQuestion.Options,
+		Difficulty:    packQuestion.Difficulty,
+		CorrectAnswer: packQuestion.CorrectAnswer,
+	}, nil
+}
+
+// questionDedupeKey treats questions for the same position and type as
+// duplicates when their text differs only in case and whitespace.
+func questionDedupeKey(q *domain.BankQuestion) string {
+	normalize := func(s string) string {
+		return strings.ToLower(strings.Join(strings.Fields(s), " "))
+	}
+	sum := sha256.Sum256([]byte(normalize(q.JobPosition) + "\x00" + string(q.Type) + "\x00" + normalize(q.Question)))
+	return hex.EncodeToString(sum[:])
+}
+
+func isBlankRecord(record []string) bool {
+	for _, value := range record {
+		if strings.TrimSpace(value) != "" {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,105 @@
+package service
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"strings"
+	"time"
+
+	"github.com/raflytch/careerly-server/internal/domain"
+
+	"github.com/google/uuid"
+)
+
+var (
+	ErrQuotaOverrideNotFound = errors.New("quota override not found")
+	ErrQuotaOverrideAmount   = errors.New("set either an amount or unlimited, not both")
+	ErrQuotaOverrideExpiry   = errors.New("expiry must be in the future")
+)
+
+type quotaOverrideService struct {
+	overrideRepo domain.QuotaOverrideRepository
+	userRepo     domain.UserRepository
+	auditService domain.AuditService
+}
+
+func NewQuotaOverrideService(overrideRepo domain.QuotaOverrideRepository, userRepo domain.UserRepository, auditService domain.AuditService) domain.QuotaOverrideService {
+	return &quotaOverrideService{
+		overrideRepo: overrideRepo,
+		userRepo:     userRepo,
+		auditService: auditService,
+	}
+}
+
+// Create grants the user extra uses of a feature, or unlimited use, until
+// the override expires. Overrides stack with the plan and add-ons and work
+// even when the user has no subscription.
+func (s *quotaOverrideService) Create(ctx context.Context, adminID, userID uuid.UUID, req *domain.CreateQuotaOverrideRequest) (*domain.QuotaOverride, error) {
+	if req.Unlimited == (req.Amount > 0) {
+		return nil, ErrQuotaOverrideAmount
+	}
+
+	now := time.Now()
+	if !req.ExpiresAt.After(now) {
+		return nil, ErrQuotaOverrideExpiry
+	}
+
+	if _, err := s.userRepo.FindByID(ctx, userID); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, domain.ErrUserNotFound
+		}
+		return nil, err
+	}
+
+	override := &domain.QuotaOverride{
+		ID:        uuid.New(),
+		UserID:    userID,
+		Feature:   req.Feature,
+		Amount:    req.Amount,
+		Unlimited: req.Unlimited,
+		Reason:    strings.TrimSpace(req.Reason),
+		ExpiresAt: req.ExpiresAt,
+		CreatedBy: adminID,
+		CreatedAt: now,
+	}
+
+	if err := s.overrideRepo.Create(ctx, override); err != nil {
+		return nil, err
+	}
+
+	s.auditService.Record(ctx, domain.AuditActionQuotaOverrideCreate, domain.AuditTargetQuotaOverride, override.ID, nil, override)
+
+	return override, nil
+}
+
+func (s *quotaOverrideService) GetByUserID(ctx context.Context, userID uuid.UUID) ([]domain.QuotaOverride, error) {
+	return s.overrideRepo.FindByUserID(ctx, userID)
+}
+
+func (s *quotaOverrideService) Revoke(ctx context.Context, userID, overrideID uuid.UUID) (*domain.QuotaOverride, error) {
+	override, err := s.overrideRepo.FindByID(ctx, overrideID)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, ErrQuotaOverrideNotFound
+		}
+		return nil, err
+	}
+	if override.UserID != userID {
+		return nil, ErrQuotaOverrideNotFound
+	}
+	if override.RevokedAt != nil {
+		return override, nil
+	}
+
+	before := *override
+	now := time.Now()
+	if err := s.overrideRepo.Revoke(ctx, override.ID, now); err != nil {
+		return nil, err
+	}
+	override.RevokedAt = &now
+
+	s.auditService.Record(ctx, domain.AuditActionQuotaOverrideRevoke, domain.AuditTargetQuotaOverride, override.ID, before, override)
+
+	return override, nil
+}
@@ -4,6 +4,7 @@ import (
 	"context"
 	"database/sql"
 	"errors"
+	"log"
 	"time"
 
 	"github.com/raflytch/careerly-server/internal/domain"
@@ -16,63 +17,136 @@ var (
 	ErrQuotaExceeded        = errors.New("quota exceeded for this feature")
 )
 
+const quotaNotificationTimeout = 30 * time.Second
+
 type quotaService struct {
 	subscriptionRepo domain.SubscriptionRepository
 	usageRepo        domain.UsageRepository
+	userRepo         domain.UserRepository
+	addonRepo        domain.AddonRepository
+	overrideRepo     domain.QuotaOverrideRepository
+	orgRepo          domain.OrganizationRepository
+	planRepo         domain.PlanRepository
+	notifications    domain.NotificationService
+	freePlanDays     int
 }
 
-func NewQuotaService(subscriptionRepo domain.SubscriptionRepository, usageRepo domain.UsageRepository) domain.QuotaService {
+func NewQuotaService(subscriptionRepo domain.SubscriptionRepository, usageRepo domain.UsageRepository, userRepo domain.UserRepository, addonRepo domain.AddonRepository, overrideRepo domain.QuotaOverrideRepository, orgRepo domain.OrganizationRepository, planRepo domain.PlanRepository, notifications domain.NotificationService, freePlanDays int) domain.QuotaService {
 	return &quotaService{
 		subscriptionRepo: subscriptionRepo,
 		usageRepo:        usageRepo,
+		userRepo:         userRepo,
+		addonRepo:        addonRepo,
+		overrideRepo:     overrideRepo,
+		orgRepo:          orgRepo,
+		planRepo:         planRepo,
+		notifications:    notifications,
+		freePlanDays:     freePlanDays,
 	}
 }
 
-func (s *quotaService) CheckAndIncrementUsage(ctx context.Context, userID uuid.UUID, feature domain.FeatureType) error {
-	subscription, err := s.subscriptionRepo.FindActiveByUserID(ctx, userID)
+func (s *quotaService) CheckAndIncrementUsage(ctx context.Context, userID uuid.UUID, feature domain.FeatureType) (int, error) {
+	return s.ConsumeUsage(ctx, userID, feature, 1)
+}
+
+// ConsumeUsage atomically records amount uses of feature and returns the
+// quota left in the current period, or domain.UnlimitedQuota when the plan
+// has no limit. The limit is the plan's allowance plus any add-on packs
+// bought for the period and any admin override still in effect. Nothing is
+// recorded when the full amount does not fit. Members of an organization
+// with a current plan draw on the organization's pool instead.
+func (s *quotaService) ConsumeUsage(ctx context.Context, userID uuid.UUID, feature domain.FeatureType, amount int) (int, error) {
+	org, err := s.organizationPool(ctx, userID)
 	if err != nil {
-		if errors.Is(err, sql.ErrNoRows) {
-			return ErrNoActiveSubscription
-		}
-		return err
+		return 0, err
+	}
+	if org != nil {
+		return s.consumePooled(ctx, org, userID, feature, amount)
 	}
 
-	if subscription.Plan == nil {
-		return ErrNoActiveSubscription
+	plan, err := s.activePlan(ctx, userID)
+	if err != nil {
+		return 0, err
 	}
 
-	now := time.Now()
-	periodMonth := time.Date(now.Year(), now.Month(), 1, 0, 0, 0, 0, time.UTC)
+	grant, err := s.overrideRepo.SumActive(ctx, userID, feature, time.Now())
+	if err != nil {
+		return 0, err
+	}
+
+	if !featureAvailable(plan, grant) {
+		return 0, ErrNoActiveSubscription
+	}
+
+	periodMonth := usagePeriodMonth(time.Now(), s.location(ctx, userID))
 
 	usage, err := s.usageRepo.FindOrCreate(ctx, userID, feature, periodMonth)
 	if err != nil {
-		return err
+		return 0, err
 	}
 
-	var maxAllowed int
-	switch feature {
-	case domain.FeatureResume:
-		if subscription.Plan.MaxResumes != nil {
-			maxAllowed = *subscription.Plan.MaxResumes
-		}
-	case domain.FeatureATSCheck:
-		if subscription.Plan.MaxATSChecks != nil {
-			maxAllowed = *subscription.Plan.MaxATSChecks
-		}
-	case domain.FeatureInterview:
-		if subscription.Plan.MaxInterviews != nil {
-			maxAllowed = *subscription.Plan.MaxInterviews
+	maxAllowed, err := s.periodLimit(ctx, userID, plan, grant, feature, periodMonth)
+	if err != nil {
+		return 0, err
+	}
+
+	count, err := s.usageRepo.IncrementWithinLimit(ctx, usage.ID, amount, maxAllowed)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return 0, ErrQuotaExceeded
 		}
+		return 0, err
 	}
 
-	if maxAllowed > 0 && usage.Count >= maxAllowed {
-		return ErrQuotaExceeded
+	if maxAllowed <= 0 {
+		return domain.UnlimitedQuota, nil
 	}
 
-	return s.usageRepo.IncrementCount(ctx, usage.ID)
+	if threshold := crossedQuotaThreshold(count-amount, count, maxAllowed); threshold > 0 {
+		go s.notifyThreshold(domain.QuotaThresholdEvent{
+			UserID:      userID,
+			Feature:     feature,
+			PeriodMonth: periodMonth,
+			Threshold:   threshold,
+			Used:        count,
+			Limit:       maxAllowed,
+		})
+	}
+
+	return maxAllowed - count, nil
 }
 
-func (s *quotaService) GetUserQuota(ctx context.Context, userID uuid.UUID) (*domain.UserQuota, error) {
+// crossedQuotaThreshold returns the highest warning threshold that usage
+// passed going from before to after, or 0 when it passed none. A single
+// large consumption reports only the highest so the user gets one message.
+func crossedQuotaThreshold(before, after, limit int) int {
+	crossed := 0
+	for _, threshold := range domain.QuotaWarningThresholds {
+		if before*100 < threshold*limit && after*100 >= threshold*limit {
+			crossed = threshold
+		}
+	}
+	return crossed
+}
+
+func (s *quotaService) notifyThreshold(event domain.QuotaThresholdEvent) {
+	ctx, cancel := context.WithTimeout(context.Background(), quotaNotificationTimeout)
+	defer cancel()
+
+	if err := s.notifications.NotifyQuotaThreshold(ctx, event); err != nil {
+		log.Printf("Failed to send %d%% %s quota notification to user %s: %v", event.Threshold, event.Feature, event.UserID, err)
+	}
+}
+
+func (s *quotaService) GetActivePlan(ctx context.Context, userID uuid.UUID) (*domain.Plan, error) {
+	org, err := s.organizationPool(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	if org != nil {
+		return org.Plan, nil
+	}
+
 	subscription, err := s.subscriptionRepo.FindActiveByUserID(ctx, userID)
 	if err != nil {
 		if errors.Is(err, sql.ErrNoRows) {
@@ -85,25 +159,71 @@ func (s *quotaService) GetUserQuota(ctx context.Context, userID uuid.UUID) (*dom
 		return nil, ErrNoActiveSubscription
 	}
 
+	return subscription.Plan, nil
+}
+
+func (s *quotaService) GetUserQuota(ctx context.Context, userID uuid.UUID) (*domain.UserQuota, error) {
+	org, err := s.organizationPool(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	if org != nil {
+		return s.pooledQuota(ctx, org)
+	}
+
+	plan, err := s.activePlan(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
 	now := time.Now()
-	periodMonth := time.Date(now.Year(), now.Month(), 1, 0, 0, 0, 0, time.UTC)
+	grants := make(map[domain.FeatureType]*domain.QuotaGrant, 3)
+	overridden := false
+	for _, feature := range []domain.FeatureType{domain.FeatureResume, domain.FeatureATSCheck, domain.FeatureInterview} {
+		grant, err := s.overrideRepo.SumActive(ctx, userID, feature, now)
+		if err != nil {
+			return nil, err
+		}
+		grants[feature] = grant
+		overridden = overridden || grant.Unlimited || grant.Amount > 0
+	}
+
+	if plan == nil && !overridden {
+		return nil, ErrNoActiveSubscription
+	}
+
+	loc := s.location(ctx, userID)
+	periodMonth := usagePeriodMonth(now, loc)
+	periodStart, resetsAt := usagePeriodBounds(now, loc)
 
 	resumeUsage, _ := s.usageRepo.FindOrCreate(ctx, userID, domain.FeatureResume, periodMonth)
 	atsUsage, _ := s.usageRepo.FindOrCreate(ctx, userID, domain.FeatureATSCheck, periodMonth)
 	interviewUsage, _ := s.usageRepo.FindOrCreate(ctx, userID, domain.FeatureInterview, periodMonth)
 
 	quota := &domain.UserQuota{
-		PlanName: subscription.Plan.DisplayName,
+		HistoryRetentionDays: historyRetentionDays(plan, s.freePlanDays),
+		Timezone:             loc.String(),
+		PeriodStart:          periodStart,
+		ResetsAt:             resetsAt,
+	}
+
+	if plan != nil {
+		quota.PlanName = plan.DisplayName
 	}
 
-	if subscription.Plan.MaxResumes != nil {
-		quota.MaxResumes = *subscription.Plan.MaxResumes
+	if quota.MaxResumes, err = s.periodLimit(ctx, userID, plan, grants[domain.FeatureResume], domain.FeatureResume, periodMonth); err != nil {
+		return nil, err
+	}
+	if quota.MaxATSChecks, err = s.periodLimit(ctx, userID, plan, grants[domain.FeatureATSCheck], domain.FeatureATSCheck, periodMonth); err != nil {
+		return nil, err
 	}
-	if subscription.Plan.MaxATSChecks != nil {
-		quota.MaxATSChecks = *subscription.Plan.MaxATSChecks
+	if quota.MaxInterviews, err = s.periodLimit(ctx, userID, plan, grants[domain.FeatureInterview], domain.FeatureInterview, periodMonth); err != nil {
+		return nil, err
 	}
-	if subscription.Plan.MaxInterviews != nil {
-		quota.MaxInterviews = *subscription.Plan.MaxInterviews
+	for _, feature := range []domain.FeatureType{domain.FeatureResume, domain.FeatureATSCheck, domain.FeatureInterview} {
+		if !featureAvailable(plan, grants[feature]) {
+			quota.Unavailable = append(quota.Unavailable, feature)
+		}
 	}
 
 	if resumeUsage != nil {
@@ -118,3 +238,201 @@ func (s *quotaService) GetUserQuota(ctx context.Context, userID uuid.UUID) (*dom
 
 	return quota, nil
 }
+
+// featureAvailable reports whether the user may use a feature at all: with
+// a plan every feature is, without one only those with an override grant.
+func featureAvailable(plan *domain.Plan, grant *domain.QuotaGrant) bool {
+	return plan != nil || grant.Unlimited || grant.Amount > 0
+}
+
+// periodLimit returns the plan's limit for feature raised by the add-on
+// credits bought for periodMonth and by the override grant. Zero means
+// unlimited, so add-ons and extra amounts never apply to features the plan
+// does not cap. Without a plan only the grant counts, and a feature without
+// a grant has a limit of 0 that featureAvailable must rule out first.
+func (s *quotaService) periodLimit(ctx context.Context, userID uuid.UUID, plan *domain.Plan, grant *domain.QuotaGrant, feature domain.FeatureType, periodMonth time.Time) (int, error) {
+	if grant.Unlimited {
+		return 0, nil
+	}
+	if plan == nil {
+		return grant.Amount, nil
+	}
+
+	limit := planFeatureLimit(plan, feature)
+	if limit <= 0 {
+		return 0, nil
+	}
+
+	credits, err := s.addonRepo.SumCredits(ctx, userID, feature, periodMonth)
+	if err != nil {
+		return limit + grant.Amount, err
+	}
+	return limit + credits + grant.Amount, nil
+}
+
+// activePlan returns the plan of the user's active subscription, or nil
+// when there is none.
+func (s *quotaService) activePlan(ctx context.Context, userID uuid.UUID) (*domain.Plan, error) {
+	subscription, err := s.subscriptionRepo.FindActiveByUserID(ctx, userID)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return subscription.Plan, nil
+}
+
+func planFeatureLimit(plan *domain.Plan, feature domain.FeatureType) int {
+	var limit *int
+	switch feature {
+	case domain.FeatureResume:
+		limit = plan.MaxResumes
+	case domain.FeatureATSCheck:
+		limit = plan.MaxATSChecks
+	case domain.FeatureInterview:
+		limit = plan.MaxInterviews
+	}
+	if limit == nil {
+		return 0
+	}
+	return *limit
+}
+
+// GetOrganizationQuota shows the owner the organization's pool and how
+// much of it each member used this period.
+func (s *quotaService) GetOrganizationQuota(ctx context.Context, userID uuid.UUID) (*domain.OrganizationQuota, error) {
+	membership, org, err := findOrganizationMembership(ctx, s.orgRepo, userID)
+	if err != nil {
+		return nil, err
+	}
+	if membership.Role != domain.OrganizationRoleOwner {
+		return nil, ErrOrganizationOwnerOnly
+	}
+
+	if err := s.loadOrganizationPlan(ctx, org); err != nil {
+		return nil, err
+	}
+	if org.Plan == nil {
+		return nil, ErrNoActiveSubscription
+	}
+
+	quota, err := s.pooledQuota(ctx, org)
+	if err != nil {
+		return nil, err
+	}
+
+	members, err := s.orgRepo.GetMemberUsage(ctx, org.ID, usagePeriodMonth(time.Now(), time.UTC))
+	if err != nil {
+		return nil, err
+	}
+
+	return &domain.OrganizationQuota{UserQuota: *quota, Members: members}, nil
+}
+
+// organizationPool returns the user's organization, with its plan, when
+// the organization has a plan for the current period, and nil otherwise.
+func (s *quotaService) organizationPool(ctx context.Context, userID uuid.UUID) (*domain.Organization, error) {
+	_, org, err := findOrganizationMembership(ctx, s.orgRepo, userID)
+	if err != nil {
+		if errors.Is(err, ErrOrganizationNotFound) {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	if err := s.loadOrganizationPlan(ctx, org); err != nil {
+		return nil, err
+	}
+	if org.Plan == nil {
+		return nil, nil
+	}
+	return org, nil
+}
+
+// loadOrganizationPlan sets org.Plan when its paid period is running.
+func (s *quotaService) loadOrganizationPlan(ctx context.Context, org *domain.Organization) error {
+	if org.PlanID == nil || org.PeriodEnd == nil || !org.PeriodEnd.After(time.Now()) {
+		return nil
+	}
+	plan, err := s.planRepo.FindByID(ctx, *org.PlanID)
+	if err != nil {
+		return err
+	}
+	org.Plan = plan
+	return nil
+}
+
+// consumePooled counts usage against the organization's pool, which resets
+// on UTC months since its members may live in different timezones. The
+// member's own usage row records the use too, so the owner can see who
+// used what.
+func (s *quotaService) consumePooled(ctx context.Context, org *domain.Organization, userID uuid.UUID, feature domain.FeatureType, amount int) (int, error) {
+	periodMonth := usagePeriodMonth(time.Now(), time.UTC)
+	limit := pooledLimit(org, feature)
+
+	count, err := s.orgRepo.IncrementUsageWithinLimit(ctx, org.ID, feature, periodMonth, amount, limit)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return 0, ErrQuotaExceeded
+		}
+		return 0, err
+	}
+
+	usage, err := s.usageRepo.FindOrCreate(ctx, userID, feature, periodMonth)
+	if err == nil {
+		_, err = s.usageRepo.IncrementWithinLimit(ctx, usage.ID, amount, 0)
+	}
+	if err != nil {
+		log.Printf("Failed to record pooled %s usage for user %s in organization %s: %v", feature, userID, org.ID, err)
+	}
+
+	if limit <= 0 {
+		return domain.UnlimitedQuota, nil
+	}
+	return limit - count, nil
+}
+
+func (s *quotaService) pooledQuota(ctx context.Context, org *domain.Organization) (*domain.UserQuota, error) {
+	now := time.Now()
+	periodStart, resetsAt := usagePeriodBounds(now, time.UTC)
+
+	usage, err := s.orgRepo.GetUsage(ctx, org.ID, usagePeriodMonth(now, time.UTC))
+	if err != nil {
+		return nil, err
+	}
+
+	return &domain.UserQuota{
+		PlanName:             org.Plan.DisplayName,
+		OrganizationID:       &org.ID,
+		Seats:                org.Seats,
+		MaxResumes:           pooledLimit(org, domain.FeatureResume),
+		MaxATSChecks:         pooledLimit(org, domain.FeatureATSCheck),
+		MaxInterviews:        pooledLimit(org, domain.FeatureInterview),
+		UsedResumes:          usage[domain.FeatureResume],
+		UsedATSChecks:        usage[domain.FeatureATSCheck],
+		UsedInterviews:       usage[domain.FeatureInterview],
+		HistoryRetentionDays: historyRetentionDays(org.Plan, s.freePlanDays),
+		Timezone:             time.UTC.String(),
+		PeriodStart:          periodStart,
+		ResetsAt:             resetsAt,
+	}, nil
+}
+
+// pooledLimit is the plan's limit for feature times the seats bought, or
+// zero when the plan does not cap it.
+func pooledLimit(org *domain.Organization, feature domain.FeatureType) int {
+	limit := planFeatureLimit(org.Plan, feature)
+	if limit <= 0 {
+		return 0
+	}
+	return limit * org.Seats
+}
+
+func (s *quotaService) location(ctx context.Context, userID uuid.UUID) *time.Location {
+	user, err := s.userRepo.FindByID(ctx, userID)
+	if err != nil {
+		return time.UTC
+	}
+	return userLocation(user)
+}
@@ -0,0 +1,211 @@
+package service
+
+import (
+	"bytes"
+	"context"
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/raflytch/careerly-server/internal/domain"
+	"github.com/raflytch/careerly-server/pkg/midtrans"
+
+	"github.com/shopspring/decimal"
+)
+
+const reconciliationMonthLayout = "2006-01"
+
+var ErrPaymentGatewayNotConfigured = errors.New("payment gateway is not configured")
+
+type reconciliationService struct {
+	transactionRepo domain.TransactionRepository
+	paymentGateway  domain.PaymentGateway
+}
+
+func NewReconciliationService(transactionRepo domain.TransactionRepository, paymentGateway domain.PaymentGateway) domain.ReconciliationService {
+	return &reconciliationService{
+		transactionRepo: transactionRepo,
+		paymentGateway:  paymentGateway,
+	}
+}
+
+// GenerateReport compares every transaction created in the given month
+// against the settlement data Midtrans holds for its order. Midtrans has no
+// bulk settlement API, so each order is looked up through the status API.
+// Successful transactions are always listed; other transactions only when
+// Midtrans reports a settlement we never recorded. Manual grants never went
+// through Midtrans and are left out.
+func (s *reconciliationService) GenerateReport(ctx context.Context, month time.Time) (*domain.ReconciliationReport, error) {
+	if s.paymentGateway == nil {
+		return nil, ErrPaymentGatewayNotConfigured
+	}
+
+	from := time.Date(month.Year(), month.Month(), 1, 0, 0, 0, 0, time.UTC)
+	to := from.AddDate(0, 1, 0)
+
+	transactions, err := s.transactionRepo.FindCreatedBetween(ctx, from, to)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch transactions: %w", err)
+	}
+
+	report := &domain.ReconciliationReport{
+		Month:       from.Format(reconciliationMonthLayout),
+		GeneratedAt: time.Now(),
+		Entries:     make([]domain.ReconciliationEntry, 0),
+	}
+
+	for i := range transactions {
+		if err := ctx.Err(); err != nil {
+			return nil, err
+		}
+
+		transaction := &transactions[i]
+		if isManualTransaction(transaction) {
+			continue
+		}
+		entry, err := s.reconcile(transaction)
+		if err != nil {
+			return nil, err
+		}
+		report.Checked++
+
+		if entry == nil {
+			continue
+		}
+
+		if transaction.Status == domain.TransactionStatusSuccess {
+			report.LocalTotal = report.LocalTotal.Add(transaction.GrossAmount)
+		}
+		if entry.GatewayAmount != nil && entry.SettlementTime != nil {
+			report.SettledTotal = report.SettledTotal.Add(*entry.GatewayAmount)
+		}
+		if entry.Result == domain.ReconciliationMatched {
+			report.Matched++
+		} else {
+			report.Mismatched++
+		}
+		report.Entries = append(report.Entries, *entry)
+	}
+
+	return report, nil
+}
+
+// reconcile returns nil for unpaid transactions that Midtrans agrees were
+// never settled, since there is nothing to report for them.
+func (s *reconciliationService) reconcile(transaction *domain.Transaction) (*domain.ReconciliationEntry, error) {
+	entry := &domain.ReconciliationEntry{
+		OrderID:     transaction.OrderID,
+		LocalStatus: transaction.Status,
+		LocalAmount: transaction.GrossAmount,
+		PaidAt:      transaction.PaidAt,
+	}
+	paid := transaction.Status == domain.TransactionStatusSuccess
+
+	statusResp, err := s.paymentGateway.CheckTransaction(transaction.OrderID)
+	switch {
+	case errors.Is(err, midtrans.ErrUnavailable):
+		return nil, ErrPaymentGatewayDown
+	case errors.Is(err, midtrans.ErrOrderNotFound):
+		if !paid {
+			return nil, nil
+		}
+		entry.Result = domain.ReconciliationMissingSettlement
+		entry.Detail = "order not found at midtrans"
+		return entry, nil
+	case err != nil:
+		entry.Result = domain.ReconciliationLookupFailed
+		entry.Detail = err.Error()
+		return entry, nil
+	}
+
+	entry.GatewayStatus = statusResp.TransactionStatus
+	entry.PaymentType = statusResp.PaymentType
+	if amount, err := decimal.NewFromString(statusResp.GrossAmount); err == nil {
+		entry.GatewayAmount = &amount
+	}
+
+	settled := isSettled(statusResp.TransactionStatus, statusResp.FraudStatus)
+	if settled && statusResp.SettlementTime != "" {
+		if settledAt, err := midtrans.ParseTime(statusResp.SettlementTime); err == nil {
+			entry.SettlementTime = &settledAt
+		}
+	}
+
+	switch {
+	case paid && !settled:
+		entry.Result = domain.ReconciliationMissingSettlement
+		entry.Detail = fmt.Sprintf("midtrans status is %s", statusResp.TransactionStatus)
+	case !paid && settled:
+		entry.Result = domain.ReconciliationUnrecordedSettlement
+		entry.Detail = fmt.Sprintf("settled at midtrans but %s locally", transaction.Status)
+	case !paid:
+		return nil, nil
+	case entry.GatewayAmount == nil || !entry.GatewayAmount.Equal(transaction.GrossAmount):
+		entry.Result = domain.ReconciliationAmountMismatch
+		entry.Detail = fmt.Sprintf("local %s, midtrans %s", transaction.GrossAmount.String(), statusResp.GrossAmount)
+	default:
+		entry.Result = domain.ReconciliationMatched
+	}
+
+	return entry, nil
+}
+
+func isSettled(transactionStatus, fraudStatus string) bool {
+	switch transactionStatus {
+	case "settlement":
+		return true
+	case "capture":
+		return fraudStatus == "accept"
+	}
+	return false
+}
+
+func (s *reconciliationService) ExportCSV(ctx context.Context, month time.Time) (*domain.ReconciliationExport, error) {
+	report, err := s.GenerateReport(ctx, month)
+	if err != nil {
+		return nil, err
+	}
+
+	var buf bytes.Buffer
+	w := csv.NewWriter(&buf)
+	_ = w.Write([]string{
+		"order_id", "result", "local_status", "local_amount", "paid_at",
+		"gateway_status", "gateway_amount", "settlement_time", "payment_type", "detail",
+	})
+	for _, entry := range report.Entries {
+		gatewayAmount := ""
+		if entry.GatewayAmount != nil {
+			gatewayAmount = entry.GatewayAmount.String()
+		}
+		_ = w.Write([]string{
+			entry.OrderID,
+			string(entry.Result),
+			string(entry.LocalStatus),
+			entry.LocalAmount.String(),
+			formatCSVTime(entry.PaidAt),
+			entry.GatewayStatus,
+			gatewayAmount,
+			formatCSVTime(entry.SettlementTime),
+			entry.PaymentType,
+			entry.Detail,
+		})
+	}
+	w.Flush()
+	if err := w.Error(); err != nil {
+		return nil, err
+	}
+
+	return &domain.ReconciliationExport{
+		Filename:    fmt.Sprintf("reconciliation_%s.csv", report.Month),
+		ContentType: "text/csv; charset=utf-8",
+		Data:        buf.Bytes(),
+	}, nil
+}
+
+func formatCSVTime(t *time.Time) string {
+	if t == nil {
+		return ""
+	}
+	return t.UTC().Format(time.RFC3339)
+}
@@ -0,0 +1,234 @@
+package service
+
+import (
+	"context"
+	"crypto/rand"
+	"database/sql"
+	"errors"
+	"fmt"
+	"math/big"
+	"strings"
+	"time"
+
+	"github.com/raflytch/careerly-server/internal/config"
+	"github.com/raflytch/careerly-server/internal/domain"
+
+	"github.com/google/uuid"
+)
+
+const (
+	referralCodeAlphabet    = "ABCDEFGHJKLMNPQRSTUVWXYZ23456789"
+	referralCodeLength      = 8
+	referralCodeMaxAttempts = 5
+)
+
+type referralService struct {
+	referralRepo     domain.ReferralRepository
+	subscriptionRepo domain.SubscriptionRepository
+	rewardDays       int
+	frontendURL      string
+}
+
+func NewReferralService(
+	referralRepo domain.ReferralRepository,
+	subscriptionRepo domain.SubscriptionRepository,
+	cfg config.ReferralConfig,
+	frontendURL string,
+) domain.ReferralService {
+	return &referralService{
+		referralRepo:     referralRepo,
+		subscriptionRepo: subscriptionRepo,
+		rewardDays:       cfg.RewardDays,
+		frontendURL:      frontendURL,
+	}
+}
+
+func (s *referralService) GetStats(ctx context.Context, userID uuid.UUID) (*domain.ReferralStats, error) {
+	code, err := s.getOrCreateCode(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	counts, err := s.referralRepo.CountByStatus(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	rewardedDays, err := s.referralRepo.SumRewardedDays(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	stats := &domain.ReferralStats{
+		Code:            code.Code,
+		ShareURL:        fmt.Sprintf("%s?ref=%s", s.frontendURL, code.Code),
+		Pending:         counts[domain.ReferralStatusPending],
+		Qualified:       counts[domain.ReferralStatusQualified],
+		Rewarded:        counts[domain.ReferralStatusRewarded],
+		TotalRewardDays: rewardedDays,
+		RewardDays:      s.rewardDays,
+	}
+	stats.TotalReferred = stats.Pending + stats.Qualified + stats.Rewarded
+
+	return stats, nil
+}
+
+func (s *referralService) AttributeSignup(ctx context.Context, referredID uuid.UUID, code string) error {
+	code = strings.ToUpper(strings.TrimSpace(code))
+	if code == "" {
+		return nil
+	}
+
+	referralCode, err := s.referralRepo.FindCodeByCode(ctx, code)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return domain.ErrReferralCodeNotFound
+		}
+		return err
+	}
+
+	if referralCode.UserID == referredID {
+		return domain.ErrSelfReferral
+	}
+
+	existing, err := s.referralRepo.FindByReferredID(ctx, referredID)
+	if err != nil && !errors.Is(err, sql.ErrNoRows) {
+		return err
+	}
+	if existing != nil {
+		return domain.ErrAlreadyReferred
+	}
+
+	return s.referralRepo.Create(ctx, &domain.Referral{
+		ID:         uuid.New(),
+		ReferrerID: referralCode.UserID,
+		ReferredID: referredID,
+		Code:       referralCode.Code,
+		Status:     domain.ReferralStatusPending,
+		CreatedAt:  time.Now(),
+	})
+}
+
+// HandleSubscriptionCreated runs after every new subscription. It qualifies
+// the user's own pending referral on their first purchase and applies any
+// rewards the user earned as a referrer but could not receive because they
+// had no active subscription at the time.
+func (s *referralService) HandleSubscriptionCreated(ctx context.Context, userID uuid.UUID, subscription *domain.Subscription) error {
+	if err := s.qualifyReferral(ctx, userID); err != nil {
+		return err
+	}
+
+	qualified, err := s.referralRepo.FindByReferrerIDAndStatus(ctx, userID, domain.ReferralStatusQualified)
+	if err != nil {
+		return err
+	}
+	if len(qualified) == 0 {
+		return nil
+	}
+
+	return s.applyRewards(ctx, subscription, qualified)
+}
+
+func (s *referralService) qualifyReferral(ctx context.Context, referredID uuid.UUID) error {
+	referral, err := s.referralRepo.FindByReferredID(ctx, referredID)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil
+		}
+		return err
+	}
+
+	if referral.Status != domain.ReferralStatusPending {
+		return nil
+	}
+
+	now := time.Now()
+	referral.Status = domain.ReferralStatusQualified
+	referral.RewardDays = s.rewardDays
+	referral.QualifiedAt = &now
+	if err := s.referralRepo.Update(ctx, referral); err != nil {
+		return err
+	}
+
+	referrerSub, err := s.subscriptionRepo.FindActiveByUserID(ctx, referral.ReferrerID)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil
+		}
+		return err
+	}
+
+	return s.applyRewards(ctx, referrerSub, []domain.Referral{*referral})
+}
+
+func (s *referralService) applyRewards(ctx context.Context, subscription *domain.Subscription, referrals []domain.Referral) error {
+	totalDays := 0
+	for _, referral := range referrals {
+		totalDays += referral.RewardDays
+	}
+	if totalDays <= 0 {
+		return nil
+	}
+
+	subscription.EndDate = subscription.EndDate.AddDate(0, 0, totalDays)
+	if err := s.subscriptionRepo.Update(ctx, subscription); err != nil {
+		return err
+	}
+
+	now := time.Now()
+	for i := range referrals {
+		referrals[i].Status = domain.ReferralStatusRewarded
+		referrals[i].RewardedAt = &now
+		if err := s.referralRepo.Update(ctx, &referrals[i]); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (s *referralService) getOrCreateCode(ctx context.Context, userID uuid.UUID) (*domain.ReferralCode, error) {
+	existing, err := s.referralRepo.FindCodeByUserID(ctx, userID)
+	if err == nil {
+		return existing, nil
+	}
+	if !errors.Is(err, sql.ErrNoRows) {
+		return nil, err
+	}
+
+	var lastErr error
+	for attempt := 0; attempt < referralCodeMaxAttempts; attempt++ {
+		code, err := generateReferralCode()
+		if err != nil {
+			return nil, err
+		}
+
+		referralCode := &domain.ReferralCode{
+			UserID:    userID,
+			Code:      code,
+			CreatedAt: time.Now(),
+		}
+		if lastErr = s.referralRepo.CreateCode(ctx, referralCode); lastErr == nil {
+			return referralCode, nil
+		}
+
+		// A concurrent request may have created the user's code already.
+		if existing, err := s.referralRepo.FindCodeByUserID(ctx, userID); err == nil {
+			return existing, nil
+		}
+	}
+
+	return nil, fmt.Errorf("failed to generate referral code: %w", lastErr)
+}
+
+func generateReferralCode() (string, error) {
+	code := make([]byte, referralCodeLength)
+	for i := range code {
+		n, err := rand.Int(rand.Reader, big.NewInt(int64(len(referralCodeAlphabet))))
+		if err != nil {
+			return "", err
+		}
+		code[i] = referralCodeAlphabet[n.Int64()]
+	}
+	return string(code), nil
+}
@@ -0,0 +1,105 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/raflytch/careerly-server/internal/domain"
+	"github.com/raflytch/careerly-server/pkg/genai"
+
+	"github.com/google/uuid"
+)
+
+const (
+	minGeneratedBullets = 3
+	maxGeneratedBullets = 5
+)
+
+var ErrBulletGenerationFailed = errors.New("could not generate bullet points, try describing the work in more detail")
+
+const bulletGeneratorSystemPrompt = `You are an expert resume writer. Turn a casual description of what someone did at work into polished resume bullet points.
+
+Return ONLY valid JSON in this structure:
+{
+  "bullets": [
+    {
+      "text": "<one resume bullet>",
+      "quantification_prompts": ["<question that helps the user add a number to this bullet>"]
+    }
+  ]
+}
+
+Rules:
+1. Return between 3 and 5 alternative bullets, each phrasing the same work differently
+2. Start every bullet with a strong past-tense action verb and keep it to one sentence
+3. Never invent numbers, tools, employers or outcomes the description does not support
+4. Where a metric would strengthen a bullet, use a placeholder such as [X%] or [N users] and add a quantification prompt asking for that value
+5. Give each bullet 1 to 3 quantification prompts; use an empty list when the bullet needs none
+6. Match the language of the description
+7. Do not add any explanation or markdown formatting`
+
+// GenerateBullets drafts alternative bullet points for a single piece of
+// work. Nothing is stored, so the editor can call it before a resume exists.
+func (s *resumeService) GenerateBullets(ctx context.Context, userID uuid.UUID, req *domain.GenerateBulletsRequest) (*domain.GeneratedBullets, error) {
+	if s.aiClient == nil {
+		return nil, ErrAIClientUnavailable
+	}
+	if !s.aiClient.Available() {
+		return nil, ErrAIServiceUnavailable
+	}
+
+	var prompt strings.Builder
+	if req.Position != "" {
+		fmt.Fprintf(&prompt, "Position: %s\n", req.Position)
+	}
+	if req.Company != "" {
+		fmt.Fprintf(&prompt, "Company: %s\n", req.Company)
+	}
+	if req.Industry != "" {
+		fmt.Fprintf(&prompt, "Industry: %s\n", req.Industry)
+	}
+	if req.JobDescription != "" {
+		fmt.Fprintf(&prompt, "Target job description:\n%s\n", req.JobDescription)
+	}
+	fmt.Fprintf(&prompt, "\nWhat I did:\n%s", req.Description)
+
+	aiCtx := genai.WithCallMetadata(ctx, domain.AIFeatureBulletGeneration, userID.String())
+	result, err := s.aiClient.GenerateJSONWithSystemPrompt(aiCtx, bulletGeneratorSystemPrompt, prompt.String())
+	if err != nil {
+		return nil, err
+	}
+
+	var parsed domain.GeneratedBullets
+	if err := json.Unmarshal([]byte(result), &parsed); err != nil {
+		return nil, fmt.Errorf("failed to parse bullet response: %w", err)
+	}
+
+	generated := &domain.GeneratedBullets{Bullets: make([]domain.ResumeBullet, 0, maxGeneratedBullets)}
+	for _, bullet := range parsed.Bullets {
+		text := strings.TrimSpace(bullet.Text)
+		if text == "" {
+			continue
+		}
+
+		prompts := make([]string, 0, len(bullet.QuantificationPrompts))
+		for _, p := range bullet.QuantificationPrompts {
+			if p = strings.TrimSpace(p); p != "" {
+				prompts = append(prompts, p)
+			}
+		}
+
+		generated.Bullets = append(generated.Bullets, domain.ResumeBullet{Text: text, QuantificationPrompts: prompts})
+		if len(generated.Bullets) == maxGeneratedBullets {
+			break
+		}
+	}
+
+	if len(generated.Bullets) < minGeneratedBullets {
+		return nil, ErrBulletGenerationFailed
+	}
+
+	return generated, nil
+}
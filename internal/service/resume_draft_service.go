@@ -0,0 +1,192 @@
+package service
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"log"
+	"strings"
+	"time"
+
+	"github.com/raflytch/careerly-server/internal/domain"
+
+	"github.com/google/uuid"
+)
+
+const maxDraftsPerUser = 20
+
+var (
+	ErrResumeDraftNotFound   = errors.New("resume draft not found")
+	ErrResumeDraftLimit      = fmt.Errorf("a user can keep at most %d resume drafts", maxDraftsPerUser)
+	ErrResumeDraftIncomplete = errors.New("draft needs a title of at least 3 characters before it can be published")
+)
+
+type resumeDraftService struct {
+	draftRepo     domain.ResumeDraftRepository
+	resumeService domain.ResumeService
+}
+
+func NewResumeDraftService(draftRepo domain.ResumeDraftRepository, resumeService domain.ResumeService) domain.ResumeDraftService {
+	return &resumeDraftService{
+		draftRepo:     draftRepo,
+		resumeService: resumeService,
+	}
+}
+
+// Create starts an empty draft. Drafts are saved as they are filled in and
+// cost nothing; quota and AI enhancement only apply when one is published.
+func (s *resumeDraftService) Create(ctx context.Context, userID uuid.UUID, req *domain.CreateResumeDraftRequest) (*domain.ResumeDraft, error) {
+	count, err := s.draftRepo.CountByUserID(ctx, userID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to count drafts: %w", err)
+	}
+	if count >= maxDraftsPerUser {
+		return nil, ErrResumeDraftLimit
+	}
+
+	now := time.Now()
+	draft := &domain.ResumeDraft{
+		ID:        uuid.New(),
+		UserID:    userID,
+		Title:     strings.TrimSpace(req.Title),
+		CreatedAt: now,
+		UpdatedAt: now,
+	}
+
+	if err := s.draftRepo.Create(ctx, draft); err != nil {
+		return nil, err
+	}
+
+	return draft, nil
+}
+
+func (s *resumeDraftService) GetByID(ctx context.Context, userID, id uuid.UUID) (*domain.ResumeDraft, error) {
+	return s.findOwnedDraft(ctx, userID, id)
+}
+
+func (s *resumeDraftService) GetByUserID(ctx context.Context, userID uuid.UUID) ([]domain.ResumeDraft, error) {
+	return s.draftRepo.FindByUserID(ctx, userID)
+}
+
+func (s *resumeDraftService) Update(ctx context.Context, userID, id uuid.UUID, req *domain.UpdateResumeDraftRequest) (*domain.ResumeDraft, error) {
+	draft, err := s.findOwnedDraft(ctx, userID, id)
+	if err != nil {
+		return nil, err
+	}
+
+	if req.Title != nil {
+		draft.Title = strings.TrimSpace(*req.Title)
+	}
+	if req.PersonalInfo != nil {
+		draft.Content.PersonalInfo = *req.PersonalInfo
+		draft.Content.PersonalInfo.PhotoURL = ""
+		draft.Content.PersonalInfo.ShowPhoto = false
+	}
+	if req.Summary != nil {
+		draft.Content.Summary = *req.Summary
+	}
+	if req.Experience != nil {
+		draft.Content.Experience = req.Experience
+	}
+	if req.Education != nil {
+		draft.Content.Education = req.Education
+	}
+	if req.Skills != nil {
+		draft.Content.Skills = req.Skills
+	}
+	if req.Achievements != nil {
+		draft.Content.Achievements = req.Achievements
+	}
+	if req.Volunteer != nil {
+		draft.Content.Volunteer = req.Volunteer
+	}
+	if req.Languages != nil {
+		draft.Content.Languages = req.Languages
+	}
+	if req.Hobbies != nil {
+		draft.Content.Hobbies = req.Hobbies
+	}
+	if req.SectionOrder != nil {
+		draft.Content.SectionOrder = req.SectionOrder
+	}
+	if req.CustomSections != nil {
+		draft.Content.CustomSections = req.CustomSections
+	}
+
+	if err := validateResumeLayout(&draft.Content); err != nil {
+		return nil, err
+	}
+
+	draft.UpdatedAt = time.Now()
+
+	if err := s.draftRepo.Update(ctx, draft); err != nil {
+		return nil, err
+	}
+
+	return draft, nil
+}
+
+func (s *resumeDraftService) Delete(ctx context.Context, userID, id uuid.UUID) error {
+	if _, err := s.findOwnedDraft(ctx, userID, id); err != nil {
+		return err
+	}
+
+	return s.draftRepo.Delete(ctx, id)
+}
+
+// Publish turns the draft into a resume through the regular create path,
+// which checks for duplicates, charges the resume quota and runs the AI
+// enhancement. The draft is removed only once the resume exists.
+func (s *resumeDraftService) Publish(ctx context.Context, userID, id uuid.UUID, force bool) (*domain.ResumeResponse, error) {
+	draft, err := s.findOwnedDraft(ctx, userID, id)
+	if err != nil {
+		return nil, err
+	}
+
+	if len([]rune(draft.Title)) < 3 {
+		return nil, ErrResumeDraftIncomplete
+	}
+
+	content := draft.Content
+	result, err := s.resumeService.Create(ctx, userID, &domain.CreateResumeRequest{
+		Title:          draft.Title,
+		PersonalInfo:   content.PersonalInfo,
+		Summary:        content.Summary,
+		Experience:     content.Experience,
+		Education:      content.Education,
+		Skills:         content.Skills,
+		Achievements:   content.Achievements,
+		Volunteer:      content.Volunteer,
+		Languages:      content.Languages,
+		Hobbies:        content.Hobbies,
+		SectionOrder:   content.SectionOrder,
+		CustomSections: content.CustomSections,
+		Force:          force,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	if err := s.draftRepo.Delete(ctx, draft.ID); err != nil {
+		log.Printf("Failed to delete published draft %s: %v", draft.ID, err)
+	}
+
+	return result, nil
+}
+
+func (s *resumeDraftService) findOwnedDraft(ctx context.Context, userID, id uuid.UUID) (*domain.ResumeDraft, error) {
+	draft, err := s.draftRepo.FindByID(ctx, id)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, ErrResumeDraftNotFound
+		}
+		return nil, err
+	}
+
+	if draft.UserID != userID {
+		return nil, ErrResumeDraftNotFound
+	}
+
+	return draft, nil
+}
@@ -0,0 +1,119 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"math"
+	"strings"
+
+	"github.com/raflytch/careerly-server/internal/domain"
+
+	"github.com/google/uuid"
+)
+
+const (
+	duplicateResumeCandidates = 50
+	duplicateResumeSimilarity = 0.85
+)
+
+var ErrDuplicateResume = errors.New("this resume is nearly identical to one you already have, set force to create it anyway")
+
+// DuplicateResumeError carries the resume a new one matched, so the client
+// can link to it instead of spending quota on a copy.
+type DuplicateResumeError struct {
+	Match domain.DuplicateResume
+}
+
+func (e *DuplicateResumeError) Error() string {
+	return ErrDuplicateResume.Error()
+}
+
+func (e *DuplicateResumeError) Unwrap() error {
+	return ErrDuplicateResume
+}
+
+func (e *DuplicateResumeError) Details() interface{} {
+	return e.Match
+}
+
+// findDuplicateResume compares content with the user's most recent resumes.
+// Stored resumes have been through the AI rewrite, so free text is left out
+// and only the facts a rewrite keeps are compared: who, where and what.
+func (s *resumeService) findDuplicateResume(ctx context.Context, userID uuid.UUID, content domain.ResumeContent) (*domain.DuplicateResume, error) {
+	candidates, err := s.resumeRepo.FindByUserID(ctx, userID, domain.ResumeFilter{}, duplicateResumeCandidates, 0)
+	if err != nil {
+		return nil, err
+	}
+
+	fingerprint := resumeFingerprint(content)
+	if len(fingerprint) == 0 {
+		return nil, nil
+	}
+
+	var best *domain.DuplicateResume
+	for _, candidate := range candidates {
+		similarity := jaccard(fingerprint, resumeFingerprint(candidate.Content))
+		if similarity < duplicateResumeSimilarity {
+			continue
+		}
+		if best == nil || similarity > best.Similarity {
+			best = &domain.DuplicateResume{
+				ResumeID:   candidate.ID,
+				Title:      candidate.Title,
+				Similarity: math.Round(similarity*100) / 100,
+			}
+		}
+	}
+
+	return best, nil
+}
+
+func resumeFingerprint(content domain.ResumeContent) map[string]struct{} {
+	keys := make(map[string]struct{})
+	add := func(prefix string, parts ...string) {
+		normalized := make([]string, 0, len(parts))
+		for _, part := range parts {
+			if part = normalizeKey(part); part != "" {
+				normalized = append(normalized, part)
+			}
+		}
+		if len(normalized) > 0 {
+			keys[prefix+":"+strings.Join(normalized, "|")] = struct{}{}
+		}
+	}
+
+	add("name", content.PersonalInfo.FullName)
+	add("email", content.PersonalInfo.Email)
+	for _, exp := range content.Experience {
+		add("experience", exp.Company, exp.Position, exp.StartDate)
+	}
+	for _, edu := range content.Education {
+		add("education", edu.Institution, edu.Degree, edu.Field)
+	}
+	for _, skill := range content.Skills {
+		add("skill", skill)
+	}
+	for _, language := range content.Languages {
+		add("language", language.Name)
+	}
+	for _, volunteer := range content.Volunteer {
+		add("volunteer", volunteer.Organization, volunteer.Role)
+	}
+
+	return keys
+}
+
+func jaccard(a, b map[string]struct{}) float64 {
+	if len(a) == 0 && len(b) == 0 {
+		return 0
+	}
+
+	shared := 0
+	for key := range a {
+		if _, ok := b[key]; ok {
+			shared++
+		}
+	}
+
+	return float64(shared) / float64(len(a)+len(b)-shared)
+}
@@ -0,0 +1,186 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"sync"
+
+	"github.com/raflytch/careerly-server/internal/domain"
+	"github.com/raflytch/careerly-server/pkg/genai"
+)
+
+// resumeEnhanceConcurrency bounds the AI calls one resume rewrite makes at
+// a time.
+const resumeEnhanceConcurrency = 3
+
+var errResumeChunkMismatch = errors.New("ai response does not match the resume section")
+
+// resumeChunk is one independently rewritten part of a resume. The summary
+// chunk carries every section except experience; each experience entry is
+// its own chunk so long histories stay within the model's limits.
+type resumeChunk struct {
+	section string
+	index   int
+	content domain.ResumeContent
+	result  domain.ResumeContent
+	err     error
+}
+
+func splitResumeChunks(content domain.ResumeContent) []*resumeChunk {
+	base := content
+	base.Experience = nil
+	base.SectionOrder = nil
+
+	chunks := make([]*resumeChunk, 0, len(content.Experience)+1)
+	chunks = append(chunks, &resumeChunk{section: domain.SectionSummary, index: -1, content: base})
+	for i, exp := range content.Experience {
+		chunks = append(chunks, &resumeChunk{
+			section: domain.SectionExperience,
+			index:   i,
+			content: domain.ResumeContent{Experience: []domain.Experience{exp}},
+		})
+	}
+	return chunks
+}
+
+// convertToProfessional rewrites the resume section by section. Sections the
+// AI fails on keep their original text; an error is returned only when every
+// section failed.
+func (s *resumeService) convertToProfessional(ctx context.Context, content domain.ResumeContent) (domain.ResumeContent, []domain.ResumeSectionAIStatus, int, error) {
+	if s.aiClient == nil {
+		return content, nil, 0, nil
+	}
+
+	systemPrompt, promptVersion := s.prompts.Prompt(ctx, domain.PromptResumeRewrite)
+	chunks := splitResumeChunks(content)
+
+	sem := make(chan struct{}, resumeEnhanceConcurrency)
+	var wg sync.WaitGroup
+
+	for i, chunk := range chunks {
+		chunkCtx := ctx
+		if i > 0 {
+			chunkCtx = genai.WithQuotaCost(ctx, 0)
+		}
+
+		wg.Add(1)
+		go func(ctx context.Context, chunk *resumeChunk) {
+			defer wg.Done()
+			sem <- struct{}{}
+			defer func() { <-sem }()
+
+			chunk.result, chunk.err = s.enhanceChunk(ctx, systemPrompt, chunk)
+		}(chunkCtx, chunk)
+	}
+	wg.Wait()
+
+	merged := content
+	merged.Experience = append([]domain.Experience(nil), content.Experience...)
+	statuses := make([]domain.ResumeSectionAIStatus, 0, len(chunks))
+	var firstErr error
+	succeeded := 0
+
+	for _, chunk := range chunks {
+		status := domain.ResumeSectionAIStatus{Section: chunk.section, Status: "success"}
+		if chunk.index >= 0 {
+			index := chunk.index
+			status.Index = &index
+		}
+
+		if chunk.err != nil {
+			status.Status = resumeAIFailureStatus(false, chunk.err)
+			if firstErr == nil {
+				firstErr = chunk.err
+			}
+		} else {
+			succeeded++
+			if chunk.index < 0 {
+				mergeResumeBase(&merged, content, chunk.result)
+			} else {
+				merged.Experience[chunk.index] = chunk.result.Experience[0]
+			}
+		}
+		statuses = append(statuses, status)
+	}
+
+	if succeeded == 0 {
+		return content, statuses, 0, firstErr
+	}
+	return merged, statuses, promptVersion, nil
+}
+
+func (s *resumeService) enhanceChunk(ctx context.Context, systemPrompt string, chunk *resumeChunk) (domain.ResumeContent, error) {
+	chunkJSON, err := json.Marshal(chunk.content)
+	if err != nil {
+		return domain.ResumeContent{}, err
+	}
+
+	result, err := s.aiClient.GenerateJSONWithSystemPrompt(genai.WithResponseSchema(ctx, resumeContentSchema), systemPrompt, string(chunkJSON))
+	if err != nil {
+		return domain.ResumeContent{}, err
+	}
+
+	var enhanced domain.ResumeContent
+	if err := json.Unmarshal([]byte(result), &enhanced); err != nil {
+		return domain.ResumeContent{}, err
+	}
+	if chunk.index >= 0 && len(enhanced.Experience) != 1 {
+		return domain.ResumeContent{}, errResumeChunkMismatch
+	}
+	return enhanced, nil
+}
+
+// mergeResumeBase copies the rewritten non-experience sections into merged,
+// keeping the fields the AI must not change from the original.
+func mergeResumeBase(merged *domain.ResumeContent, original, enhanced domain.ResumeContent) {
+	merged.PersonalInfo = enhanced.PersonalInfo
+	merged.PersonalInfo.PhotoURL = original.PersonalInfo.PhotoURL
+	merged.PersonalInfo.ShowPhoto = original.PersonalInfo.ShowPhoto
+	merged.PersonalInfo.RedactPersonal = original.PersonalInfo.RedactPersonal
+	merged.Summary = enhanced.Summary
+	merged.Education = enhanced.Education
+	merged.Skills = enhanced.Skills
+	merged.Achievements = enhanced.Achievements
+	merged.Volunteer = enhanced.Volunteer
+	merged.Languages = enhanced.Languages
+	merged.Hobbies = enhanced.Hobbies
+
+	if len(enhanced.CustomSections) != len(original.CustomSections) {
+		merged.CustomSections = original.CustomSections
+	} else {
+		merged.CustomSections = enhanced.CustomSections
+		for i := range merged.CustomSections {
+			merged.CustomSections[i].Key = original.CustomSections[i].Key
+		}
+	}
+}
+
+// resumeConversionStatus summarizes the per-section results: "partial" when
+// some sections kept their original text.
+func resumeConversionStatus(noClient bool, sections []domain.ResumeSectionAIStatus, err error) string {
+	if err != nil {
+		return resumeAIFailureStatus(noClient, err)
+	}
+	for _, section := range sections {
+		if section.Status != "success" {
+			return "partial_using_original"
+		}
+	}
+	return "success"
+}
+
+func resumeAIFailureStatus(noClient bool, err error) string {
+	switch {
+	case noClient:
+		return "skipped_no_ai_client"
+	case errors.Is(err, genai.ErrBudgetExceeded):
+		return "skipped_budget_exceeded"
+	case errors.Is(err, genai.ErrTimeout):
+		return "timed_out_using_original"
+	case errors.Is(err, genai.ErrUnavailable):
+		return "skipped_ai_unavailable"
+	default:
+		return "failed_using_original"
+	}
+}
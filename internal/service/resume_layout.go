@@ -0,0 +1,100 @@
+package service
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/raflytch/careerly-server/internal/domain"
+)
+
+var (
+	ErrDuplicateSection       = errors.New("duplicate section in section_order")
+	ErrUnknownSection         = errors.New("unknown section in section_order")
+	ErrDuplicateCustomSection = errors.New("duplicate custom section")
+)
+
+func isBuiltinSection(key string) bool {
+	for _, section := range domain.DefaultSectionOrder {
+		if section == key {
+			return true
+		}
+	}
+	return false
+}
+
+func validateResumeLayout(content *domain.ResumeContent) error {
+	customKeys := make(map[string]bool, len(content.CustomSections))
+	customTitles := make(map[string]bool, len(content.CustomSections))
+	for _, section := range content.CustomSections {
+		key := strings.ToLower(strings.TrimSpace(section.Key))
+		if isBuiltinSection(key) {
+			return fmt.Errorf("%w: key %q is reserved", ErrDuplicateCustomSection, section.Key)
+		}
+		if customKeys[key] {
+			return fmt.Errorf("%w: key %q", ErrDuplicateCustomSection, section.Key)
+		}
+		customKeys[key] = true
+
+		title := strings.ToLower(strings.TrimSpace(section.Title))
+		if customTitles[title] {
+			return fmt.Errorf("%w: title %q", ErrDuplicateCustomSection, section.Title)
+		}
+		customTitles[title] = true
+	}
+
+	seen := make(map[string]bool, len(content.SectionOrder))
+	for _, entry := range content.SectionOrder {
+		key := strings.ToLower(strings.TrimSpace(entry))
+		if seen[key] {
+			return fmt.Errorf("%w: %q", ErrDuplicateSection, entry)
+		}
+		seen[key] = true
+
+		if !isBuiltinSection(key) && !customKeys[key] {
+			return fmt.Errorf("%w: %q", ErrUnknownSection, entry)
+		}
+	}
+
+	return nil
+}
+
+// resolveSectionOrder returns the render order for a resume. Sections missing
+// from section_order keep their default position after the explicit ones, and
+// custom sections follow in the order they were defined.
+func resolveSectionOrder(content *domain.ResumeContent) []string {
+	order := make([]string, 0, len(domain.DefaultSectionOrder)+len(content.CustomSections))
+	seen := make(map[string]bool)
+
+	for _, entry := range content.SectionOrder {
+		key := strings.ToLower(strings.TrimSpace(entry))
+		if !seen[key] {
+			order = append(order, key)
+			seen[key] = true
+		}
+	}
+	for _, section := range domain.DefaultSectionOrder {
+		if !seen[section] {
+			order = append(order, section)
+			seen[section] = true
+		}
+	}
+	for _, section := range content.CustomSections {
+		key := strings.ToLower(strings.TrimSpace(section.Key))
+		if !seen[key] {
+			order = append(order, key)
+			seen[key] = true
+		}
+	}
+
+	return order
+}
+
+func findCustomSection(content *domain.ResumeContent, key string) *domain.CustomSection {
+	for i := range content.CustomSections {
+		if strings.EqualFold(strings.TrimSpace(content.CustomSections[i].Key), key) {
+			return &content.CustomSections[i]
+		}
+	}
+	return nil
+}
@@ -0,0 +1,200 @@
+package service
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"strings"
+	"time"
+
+	"github.com/raflytch/careerly-server/internal/domain"
+
+	"github.com/google/uuid"
+)
+
+var (
+	ErrResumeTagNotFound    = errors.New("resume tag not found")
+	ErrResumeTagExists      = errors.New("a tag with this name already exists")
+	ErrResumeFolderNotFound = errors.New("resume folder not found")
+	ErrResumeFolderExists   = errors.New("a folder with this name already exists")
+)
+
+type resumeLibraryService struct {
+	libraryRepo   domain.ResumeLibraryRepository
+	resumeService domain.ResumeService
+}
+
+func NewResumeLibraryService(libraryRepo domain.ResumeLibraryRepository, resumeService domain.ResumeService) domain.ResumeLibraryService {
+	return &resumeLibraryService{
+		libraryRepo:   libraryRepo,
+		resumeService: resumeService,
+	}
+}
+
+func (s *resumeLibraryService) CreateTag(ctx context.Context, userID uuid.UUID, req *domain.ResumeTagRequest) (*domain.ResumeTag, error) {
+	tag := &domain.ResumeTag{
+		ID:        uuid.New(),
+		UserID:    userID,
+		Name:      strings.TrimSpace(req.Name),
+		CreatedAt: time.Now(),
+	}
+
+	created, err := s.libraryRepo.CreateTag(ctx, tag)
+	if err != nil {
+		return nil, err
+	}
+	if !created {
+		return nil, ErrResumeTagExists
+	}
+	return tag, nil
+}
+
+func (s *resumeLibraryService) GetTags(ctx context.Context, userID uuid.UUID) ([]domain.ResumeTag, error) {
+	return s.libraryRepo.FindTagsByUserID(ctx, userID)
+}
+
+func (s *resumeLibraryService) RenameTag(ctx context.Context, userID, id uuid.UUID, req *domain.ResumeTagRequest) (*domain.ResumeTag, error) {
+	tag, err := s.findOwnedTag(ctx, userID, id)
+	if err != nil {
+		return nil, err
+	}
+
+	tag.Name = strings.TrimSpace(req.Name)
+	renamed, err := s.libraryRepo.RenameTag(ctx, tag)
+	if err != nil {
+		return nil, err
+	}
+	if !renamed {
+		return nil, ErrResumeTagExists
+	}
+	return tag, nil
+}
+
+// DeleteTag removes the tag from every resume that carries it.
+func (s *resumeLibraryService) DeleteTag(ctx context.Context, userID, id uuid.UUID) error {
+	if _, err := s.findOwnedTag(ctx, userID, id); err != nil {
+		return err
+	}
+	return s.libraryRepo.DeleteTag(ctx, id)
+}
+
+// SetResumeTags replaces the resume's tags. Every tag must belong to the
+// caller; an empty list clears them.
+func (s *resumeLibraryService) SetResumeTags(ctx context.Context, userID, resumeID uuid.UUID, req *domain.SetResumeTagsRequest) (*domain.Resume, error) {
+	if _, err := s.resumeService.GetByID(ctx, userID, resumeID); err != nil {
+		return nil, err
+	}
+
+	tagIDs := make([]uuid.UUID, 0, len(req.TagIDs))
+	seen := make(map[uuid.UUID]bool, len(req.TagIDs))
+	for _, id := range req.TagIDs {
+		if !seen[id] {
+			seen[id] = true
+			tagIDs = append(tagIDs, id)
+		}
+	}
+
+	owned, err := s.libraryRepo.CountOwnedTags(ctx, userID, tagIDs)
+	if err != nil {
+		return nil, err
+	}
+	if owned != len(tagIDs) {
+		return nil, ErrResumeTagNotFound
+	}
+
+	if err := s.libraryRepo.SetResumeTags(ctx, resumeID, tagIDs); err != nil {
+		return nil, err
+	}
+	return s.resumeService.GetByID(ctx, userID, resumeID)
+}
+
+func (s *resumeLibraryService) CreateFolder(ctx context.Context, userID uuid.UUID, req *domain.ResumeFolderRequest) (*domain.ResumeFolder, error) {
+	folder := &domain.ResumeFolder{
+		ID:        uuid.New(),
+		UserID:    userID,
+		Name:      strings.TrimSpace(req.Name),
+		CreatedAt: time.Now(),
+	}
+
+	created, err := s.libraryRepo.CreateFolder(ctx, folder)
+	if err != nil {
+		return nil, err
+	}
+	if !created {
+		return nil, ErrResumeFolderExists
+	}
+	return folder, nil
+}
+
+func (s *resumeLibraryService) GetFolders(ctx context.Context, userID uuid.UUID) ([]domain.ResumeFolder, error) {
+	return s.libraryRepo.FindFoldersByUserID(ctx, userID)
+}
+
+func (s *resumeLibraryService) RenameFolder(ctx context.Context, userID, id uuid.UUID, req *domain.ResumeFolderRequest) (*domain.ResumeFolder, error) {
+	folder, err := s.findOwnedFolder(ctx, userID, id)
+	if err != nil {
+		return nil, err
+	}
+
+	folder.Name = strings.TrimSpace(req.Name)
+	renamed, err := s.libraryRepo.RenameFolder(ctx, folder)
+	if err != nil {
+		return nil, err
+	}
+	if !renamed {
+		return nil, ErrResumeFolderExists
+	}
+	return folder, nil
+}
+
+// DeleteFolder keeps the folder's resumes; they are left without a folder.
+func (s *resumeLibraryService) DeleteFolder(ctx context.Context, userID, id uuid.UUID) error {
+	if _, err := s.findOwnedFolder(ctx, userID, id); err != nil {
+		return err
+	}
+	return s.libraryRepo.DeleteFolder(ctx, id)
+}
+
+func (s *resumeLibraryService) MoveResume(ctx context.Context, userID, resumeID uuid.UUID, req *domain.MoveResumeRequest) (*domain.Resume, error) {
+	if _, err := s.resumeService.GetByID(ctx, userID, resumeID); err != nil {
+		return nil, err
+	}
+	if req.FolderID != nil {
+		if _, err := s.findOwnedFolder(ctx, userID, *req.FolderID); err != nil {
+			return nil, err
+		}
+	}
+
+	if err := s.libraryRepo.MoveResume(ctx, resumeID, req.FolderID); err != nil {
+		return nil, err
+	}
+	return s.resumeService.GetByID(ctx, userID, resumeID)
+}
+
+func (s *resumeLibraryService) findOwnedTag(ctx context.Context, userID, id uuid.UUID) (*domain.ResumeTag, error) {
+	tag, err := s.libraryRepo.FindTagByID(ctx, id)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, ErrResumeTagNotFound
+		}
+		return nil, err
+	}
+	if tag.UserID != userID {
+		return nil, ErrResumeTagNotFound
+	}
+	return tag, nil
+}
+
+func (s *resumeLibraryService) findOwnedFolder(ctx context.Context, userID, id uuid.UUID) (*domain.ResumeFolder, error) {
+	folder, err := s.libraryRepo.FindFolderByID(ctx, id)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, ErrResumeFolderNotFound
+		}
+		return nil, err
+	}
+	if folder.UserID != userID {
+		return nil, ErrResumeFolderNotFound
+	}
+	return folder, nil
+}
@@ -0,0 +1,367 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+	"time"
+	"unicode"
+
+	"github.com/raflytch/careerly-server/internal/domain"
+
+	"github.com/google/uuid"
+)
+
+const (
+	maxBulletWords   = 30
+	maxBulletChars   = 220
+	maxExcerptLength = 80
+	overlapTolerance = 31 * 24 * time.Hour
+)
+
+var (
+	firstPersonPattern  = regexp.MustCompile(`(?:^|\s)(I|[Mm]e|[Mm]y|[Mm]ine|[Mm]yself|[Ww]e|[Oo]ur)(?:\s|[,.;:!?]|$)`)
+	passiveVoicePattern = regexp.MustCompile(`(?i)\b(was|were|is|are|been|being)\s+(\w+ed|built|made|done|given|led|run|written|taken|chosen|sent|set|held|kept)\b|\bresponsible for\b`)
+	bulletPrefixes      = "-*•·▪◦"
+)
+
+var resumeDateLayouts = []string{
+	"2006-01-02",
+	"2006-01",
+	"01/2006",
+	"1/2006",
+	"Jan 2006",
+	"January 2006",
+	"Jan, 2006",
+	"January, 2006",
+	"2006",
+}
+
+var presentDateWords = map[string]bool{
+	"present":   true,
+	"current":   true,
+	"now":       true,
+	"ongoing":   true,
+	"sekarang":  true,
+	"saat ini":  true,
+	"currently": true,
+}
+
+type resumeLintService struct {
+	resumeService domain.ResumeService
+}
+
+func NewResumeLintService(resumeService domain.ResumeService) domain.ResumeLintService {
+	return &resumeLintService{
+		resumeService: resumeService,
+	}
+}
+
+func (s *resumeLintService) Lint(ctx context.Context, userID uuid.UUID, id uuid.UUID) (*domain.ResumeLintReport, error) {
+	resume, err := s.resumeService.GetByID(ctx, userID, id)
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	l := &resumeLinter{now: now, warnings: make([]domain.LintWarning, 0)}
+	l.lint(&resume.Content)
+
+	report := &domain.ResumeLintReport{
+		ResumeID:  resume.ID,
+		Warnings:  l.warnings,
+		CheckedAt: now,
+	}
+	for _, warning := range l.warnings {
+		if warning.Severity == domain.LintSeverityWarning {
+			report.WarningCount++
+		} else {
+			report.InfoCount++
+		}
+	}
+
+	return report, nil
+}
+
+type resumeLinter struct {
+	now      time.Time
+	warnings []domain.LintWarning
+}
+
+type employmentPeriod struct {
+	index      int
+	company    string
+	start, end time.Time
+}
+
+func (l *resumeLinter) lint(content *domain.ResumeContent) {
+	l.checkText(domain.SectionSummary, nil, "", content.Summary)
+
+	periods := make([]employmentPeriod, 0, len(content.Experience))
+	for i, exp := range content.Experience {
+		index := i
+		start, end, ok := l.checkDates(domain.SectionExperience, &index, exp.StartDate, exp.EndDate, true)
+		if ok {
+			periods = append(periods, employmentPeriod{index: i, company: exp.Company, start: start, end: end})
+		}
+
+		bullets := splitBullets(exp.Description)
+		for _, bullet := range bullets {
+			l.checkText(domain.SectionExperience, &index, "description", bullet)
+			l.checkBulletLength(domain.SectionExperience, &index, bullet)
+		}
+		if len(bullets) > 0 && !quantifiedPattern.MatchString(exp.Description) {
+			l.add(domain.LintWarning{
+				Rule:     domain.LintRuleMissingMetrics,
+				Severity: domain.LintSeverityInfo,
+				Section:  domain.SectionExperience,
+				Index:    &index,
+				Field:    "description",
+				Message:  fmt.Sprintf("No measurable results for %s; add numbers such as percentages, amounts or team size", describeEntry(exp.Position, exp.Company)),
+			})
+		}
+	}
+	l.checkOverlaps(periods)
+
+	for i, edu := range content.Education {
+		index := i
+		l.checkDates(domain.SectionEducation, &index, edu.StartDate, edu.EndDate, false)
+	}
+
+	for i, vol := range content.Volunteer {
+		index := i
+		l.checkDates(domain.SectionVolunteer, &index, vol.StartDate, vol.EndDate, false)
+		for _, bullet := range splitBullets(vol.Description) {
+			l.checkText(domain.SectionVolunteer, &index, "description", bullet)
+			l.checkBulletLength(domain.SectionVolunteer, &index, bullet)
+		}
+	}
+
+	for i, achievement := range content.Achievements {
+		index := i
+		l.checkText(domain.SectionAchievements, &index, "", achievement)
+		l.checkBulletLength(domain.SectionAchievements, &index, achievement)
+	}
+}
+
+// checkDates reports missing, unparseable and reversed dates and returns the
+// parsed period. An empty or "present" end date is treated as ongoing.
+func (l *resumeLinter) checkDates(section string, index *int, rawStart, rawEnd string, endRequired bool) (time.Time, time.Time, bool) {
+	if strings.TrimSpace(rawStart) == "" {
+		l.add(domain.LintWarning{
+			Rule:     domain.LintRuleMissingDate,
+			Severity: domain.LintSeverityWarning,
+			Section:  section,
+			Index:    index,
+			Field:    "start_date",
+			Message:  "Start date is missing",
+		})
+		return time.Time{}, time.Time{}, false
+	}
+
+	start, ok := parseResumeDate(rawStart, l.now)
+	if !ok {
+		l.add(domain.LintWarning{
+			Rule:     domain.LintRuleInvalidDate,
+			Severity: domain.LintSeverityWarning,
+			Section:  section,
+			Index:    index,
+			Field:    "start_date",
+			Message:  "Start date is not in a recognizable format such as \"Jan 2022\" or \"2022-01\"",
+			Excerpt:  rawStart,
+		})
+		return time.Time{}, time.Time{}, false
+	}
+
+	if strings.TrimSpace(rawEnd) == "" {
+		if endRequired {
+			l.add(domain.LintWarning{
+				Rule:     domain.LintRuleMissingDate,
+				Severity: domain.LintSeverityInfo,
+				Section:  section,
+				Index:    index,
+				Field:    "end_date",
+				Message:  "End date is missing; use \"Present\" if this is ongoing",
+			})
+		}
+		return start, l.now, true
+	}
+
+	end, ok := parseResumeDate(rawEnd, l.now)
+	if !ok {
+		l.add(domain.LintWarning{
+			Rule:     domain.LintRuleInvalidDate,
+			Severity: domain.LintSeverityWarning,
+			Section:  section,
+			Index:    index,
+			Field:    "end_date",
+			Message:  "End date is not in a recognizable format such as \"Jan 2022\", \"2022-01\" or \"Present\"",
+			Excerpt:  rawEnd,
+		})
+		return time.Time{}, time.Time{}, false
+	}
+
+	if end.Before(start) {
+		l.add(domain.LintWarning{
+			Rule:     domain.LintRuleDateOrder,
+			Severity: domain.LintSeverityWarning,
+			Section:  section,
+			Index:    index,
+			Field:    "end_date",
+			Message:  "End date is before the start date",
+		})
+		return time.Time{}, time.Time{}, false
+	}
+
+	return start, end, true
+}
+
+// checkOverlaps flags jobs at different companies that overlap by more than a
+// month. Overlaps within one company are usually promotions.
+func (l *resumeLinter) checkOverlaps(periods []employmentPeriod) {
+	sort.Slice(periods, func(i, j int) bool { return periods[i].start.Before(periods[j].start) })
+
+	for i := 0; i < len(periods); i++ {
+		for j := i + 1; j < len(periods); j++ {
+			a, b := periods[i], periods[j]
+			if !b.start.Before(a.end) {
+				break
+			}
+			if strings.EqualFold(strings.TrimSpace(a.company), strings.TrimSpace(b.company)) {
+				continue
+			}
+
+			overlapEnd := a.end
+			if b.end.Before(overlapEnd) {
+				overlapEnd = b.end
+			}
+			if overlapEnd.Sub(b.start) <= overlapTolerance {
+				continue
+			}
+
+			index := b.index
+			l.add(domain.LintWarning{
+				Rule:     domain.LintRuleOverlappingJobs,
+				Severity: domain.LintSeverityWarning,
+				Section:  domain.SectionExperience,
+				Index:    &index,
+				Message:  fmt.Sprintf("Employment at %s overlaps with %s; clarify if these were concurrent roles", orUnnamed(b.company), orUnnamed(a.company)),
+			})
+		}
+	}
+}
+
+func (l *resumeLinter) checkText(section string, index *int, field, text string) {
+	text = strings.TrimSpace(text)
+	if text == "" {
+		return
+	}
+
+	if match := firstPersonPattern.FindStringSubmatch(text); match != nil {
+		l.add(domain.LintWarning{
+			Rule:     domain.LintRuleFirstPerson,
+			Severity: domain.LintSeverityWarning,
+			Section:  section,
+			Index:    index,
+			Field:    field,
+			Message:  fmt.Sprintf("Avoid first-person pronouns such as %q; start with an action verb instead", match[1]),
+			Excerpt:  excerpt(text),
+		})
+	}
+
+	if passiveVoicePattern.MatchString(text) {
+		l.add(domain.LintWarning{
+			Rule:     domain.LintRulePassiveVoice,
+			Severity: domain.LintSeverityInfo,
+			Section:  section,
+			Index:    index,
+			Field:    field,
+			Message:  "Possible passive voice; rephrase with an active verb describing what you did",
+			Excerpt:  excerpt(text),
+		})
+	}
+}
+
+func (l *resumeLinter) checkBulletLength(section string, index *int, bullet string) {
+	words := len(strings.Fields(bullet))
+	if words <= maxBulletWords && len([]rune(bullet)) <= maxBulletChars {
+		return
+	}
+
+	l.add(domain.LintWarning{
+		Rule:     domain.LintRuleLongBullet,
+		Severity: domain.LintSeverityInfo,
+		Section:  section,
+		Index:    index,
+		Message:  fmt.Sprintf("Bullet has %d words; keep bullets under %d words so they can be scanned quickly", words, maxBulletWords),
+		Excerpt:  excerpt(bullet),
+	})
+}
+
+func (l *resumeLinter) add(warning domain.LintWarning) {
+	l.warnings = append(l.warnings, warning)
+}
+
+func splitBullets(description string) []string {
+	bullets := make([]string, 0)
+	for _, line := range strings.Split(description, "\n") {
+		line = strings.TrimSpace(strings.TrimLeft(strings.TrimSpace(line), bulletPrefixes))
+		if line != "" {
+			bullets = append(bullets, line)
+		}
+	}
+	return bullets
+}
+
+func parseResumeDate(raw string, now time.Time) (time.Time, bool) {
+	value := strings.TrimSpace(raw)
+	if presentDateWords[strings.ToLower(value)] {
+		return now, true
+	}
+
+	value = capitalizeWords(strings.ToLower(strings.ReplaceAll(value, ".", "")))
+	for _, layout := range resumeDateLayouts {
+		if t, err := time.Parse(layout, value); err == nil {
+			return t, true
+		}
+	}
+	return time.Time{}, false
+}
+
+func capitalizeWords(s string) string {
+	runes := []rune(s)
+	for i, r := range runes {
+		if i == 0 || unicode.IsSpace(runes[i-1]) {
+			runes[i] = unicode.ToUpper(r)
+		}
+	}
+	return string(runes)
+}
+
+func excerpt(text string) string {
+	runes := []rune(text)
+	if len(runes) <= maxExcerptLength {
+		return text
+	}
+	return string(runes[:maxExcerptLength]) + "..."
+}
+
+func describeEntry(position, company string) string {
+	switch {
+	case position != "" && company != "":
+		return position + " at " + company
+	case position != "":
+		return position
+	default:
+		return orUnnamed(company)
+	}
+}
+
+func orUnnamed(company string) string {
+	if strings.TrimSpace(company) == "" {
+		return "an unnamed employer"
+	}
+	return company
+}
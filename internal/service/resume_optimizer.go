@@ -0,0 +1,265 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/raflytch/careerly-server/internal/domain"
+	"github.com/raflytch/careerly-server/pkg/genai"
+
+	"github.com/google/uuid"
+)
+
+const (
+	resumeOptimizationCachePrefix = "resume:optimization:"
+	resumeOptimizationTTL         = 24 * time.Hour
+)
+
+var ErrOptimizationNotFound = errors.New("optimization not found or expired")
+
+var atsVendorGuidance = map[domain.ATSVendor]string{
+	domain.ATSVendorWorkday: `Workday parses resumes into rigid profile fields.
+- Job titles should be conventional and searchable; avoid internal or creative titles
+- Spell out acronyms at least once alongside the abbreviation
+- Descriptions should be plain sentences or bullets without symbols, tables or columns
+- Skills are matched literally, so prefer the exact names used in the industry`,
+	domain.ATSVendorGreenhouse: `Greenhouse keeps the original document and recruiters search it by keyword.
+- Mirror the exact phrasing of skills and tools from the job description where truthful
+- Put hard skills inside experience bullets, not only in the skills list
+- Keep the summary short and keyword-dense
+- Skills should be a flat, comma-separated list of individual terms`,
+	domain.ATSVendorLever: `Lever parses resumes into a candidate profile and indexes the full text for search.
+- Lead each bullet with a keyword-bearing action verb followed by a measurable outcome
+- Keep each bullet to one line of meaning; split compound achievements
+- Use standard names for technologies and certifications
+- Avoid abbreviations a recruiter would not search for`,
+}
+
+const resumeOptimizerSystemPrompt = `You are an expert in applicant tracking systems and resume optimization. Review the resume JSON and propose targeted rewrites that improve how it is parsed and ranked by the given ATS vendor.
+
+Return ONLY valid JSON in this structure:
+{
+  "suggestions": [
+    {
+      "section": "summary | experience | volunteer | achievements | skills",
+      "index": 0,
+      "field": "description | position | role",
+      "suggested": "<rewritten text>",
+      "reason": "<one sentence explaining the benefit for this ATS>"
+    }
+  ]
+}
+
+Rules:
+1. "index" is the zero-based position within the section; omit it for summary and skills
+2. "field" is required for experience (description or position) and volunteer (description or role); omit it otherwise
+3. For skills, "suggested" is the complete comma-separated skills list
+4. Never invent employers, dates, degrees or achievements; only rephrase what exists
+5. Only suggest changes that meaningfully help; at most 15 suggestions
+6. Do not add any explanation or markdown formatting`
+
+type optimizerSuggestion struct {
+	Section   string `json:"section"`
+	Index     *int   `json:"index"`
+	Field     string `json:"field"`
+	Suggested string `json:"suggested"`
+	Reason    string `json:"reason"`
+}
+
+func (s *resumeService) Optimize(ctx context.Context, userID uuid.UUID, id uuid.UUID, req *domain.OptimizeResumeRequest) (*domain.ResumeOptimization, error) {
+	if s.aiClient == nil {
+		return nil, ErrAIClientUnavailable
+	}
+	if !s.aiClient.Available() {
+		return nil, ErrAIServiceUnavailable
+	}
+
+	resume, err := s.GetByID(ctx, userID, id)
+	if err != nil {
+		return nil, err
+	}
+
+	contentJSON, err := json.Marshal(resume.Content)
+	if err != nil {
+		return nil, err
+	}
+
+	var prompt strings.Builder
+	fmt.Fprintf(&prompt, "Target ATS: %s\n%s\n\n", req.Vendor, atsVendorGuidance[req.Vendor])
+	if req.JobDescription != "" {
+		fmt.Fprintf(&prompt, "Job description:\n%s\n\n", req.JobDescription)
+	}
+	fmt.Fprintf(&prompt, "Resume:\n%s", contentJSON)
+
+	aiCtx := genai.WithCallMetadata(ctx, domain.AIFeatureResumeOptimization, userID.String())
+	result, err := s.aiClient.GenerateJSONWithSystemPrompt(aiCtx, resumeOptimizerSystemPrompt, prompt.String())
+	if err != nil {
+		return nil, err
+	}
+
+	var parsed struct {
+		Suggestions []optimizerSuggestion `json:"suggestions"`
+	}
+	if err := json.Unmarshal([]byte(result), &parsed); err != nil {
+		return nil, fmt.Errorf("failed to parse optimization response: %w", err)
+	}
+
+	now := time.Now()
+	optimization := &domain.ResumeOptimization{
+		ID:          uuid.New(),
+		ResumeID:    resume.ID,
+		UserID:      userID,
+		Vendor:      req.Vendor,
+		Suggestions: make([]domain.ResumeSuggestion, 0, len(parsed.Suggestions)),
+		CreatedAt:   now,
+		ExpiresAt:   now.Add(resumeOptimizationTTL),
+	}
+
+	for _, raw := range parsed.Suggestions {
+		suggestion := domain.ResumeSuggestion{
+			Section:   raw.Section,
+			Index:     raw.Index,
+			Field:     raw.Field,
+			Suggested: strings.TrimSpace(raw.Suggested),
+			Reason:    raw.Reason,
+		}
+
+		// Original is taken from the stored resume rather than the model so
+		// that apply can detect edits made after the optimization ran.
+		original, _, ok := resolveSuggestionTarget(&resume.Content, suggestion)
+		if !ok || suggestion.Suggested == "" || suggestion.Suggested == original {
+			continue
+		}
+		suggestion.ID = fmt.Sprintf("s%d", len(optimization.Suggestions)+1)
+		suggestion.Original = original
+		optimization.Suggestions = append(optimization.Suggestions, suggestion)
+	}
+
+	cacheKey := resumeOptimizationCachePrefix + optimization.ID.String()
+	if err := s.cacheRepo.Set(ctx, cacheKey, optimization, resumeOptimizationTTL); err != nil {
+		return nil, err
+	}
+
+	return optimization, nil
+}
+
+func (s *resumeService) ApplySuggestions(ctx context.Context, userID uuid.UUID, id uuid.UUID, req *domain.ApplySuggestionsRequest) (*domain.ApplySuggestionsResult, error) {
+	resume, err := s.GetByID(ctx, userID, id)
+	if err != nil {
+		return nil, err
+	}
+
+	cached, err := s.cacheRepo.Get(ctx, resumeOptimizationCachePrefix+req.OptimizationID.String())
+	if err != nil || cached == "" {
+		return nil, ErrOptimizationNotFound
+	}
+
+	var optimization domain.ResumeOptimization
+	if err := json.Unmarshal([]byte(cached), &optimization); err != nil {
+		return nil, ErrOptimizationNotFound
+	}
+	if optimization.UserID != userID || optimization.ResumeID != resume.ID {
+		return nil, ErrOptimizationNotFound
+	}
+
+	suggestions := make(map[string]domain.ResumeSuggestion, len(optimization.Suggestions))
+	for _, suggestion := range optimization.Suggestions {
+		suggestions[suggestion.ID] = suggestion
+	}
+
+	result := &domain.ApplySuggestionsResult{
+		Resume:  resume,
+		Applied: make([]string, 0, len(req.SuggestionIDs)),
+		Skipped: make([]domain.SkippedSuggestion, 0),
+	}
+
+	for _, suggestionID := range req.SuggestionIDs {
+		suggestion, ok := suggestions[suggestionID]
+		if !ok {
+			result.Skipped = append(result.Skipped, domain.SkippedSuggestion{ID: suggestionID, Reason: "unknown suggestion"})
+			continue
+		}
+
+		current, set, ok := resolveSuggestionTarget(&resume.Content, suggestion)
+		if !ok || current != suggestion.Original {
+			result.Skipped = append(result.Skipped, domain.SkippedSuggestion{ID: suggestionID, Reason: "resume changed since the optimization ran"})
+			continue
+		}
+
+		set(suggestion.Suggested)
+		result.Applied = append(result.Applied, suggestionID)
+	}
+
+	if len(result.Applied) == 0 {
+		return result, nil
+	}
+
+	resume.UpdatedAt = time.Now()
+	if err := s.resumeRepo.Update(ctx, resume); err != nil {
+		return nil, err
+	}
+
+	return result, nil
+}
+
+// resolveSuggestionTarget returns the current text a suggestion points at
+// and a setter that replaces it. ok is false if the location does not exist.
+func resolveSuggestionTarget(content *domain.ResumeContent, suggestion domain.ResumeSuggestion) (current string, set func(string), ok bool) {
+	index := -1
+	if suggestion.Index != nil {
+		index = *suggestion.Index
+	}
+
+	switch suggestion.Section {
+	case domain.SectionSummary:
+		return content.Summary, func(v string) { content.Summary = v }, true
+
+	case domain.SectionSkills:
+		set = func(v string) {
+			skills := make([]string, 0)
+			for _, skill := range strings.Split(v, ",") {
+				if skill = strings.TrimSpace(skill); skill != "" {
+					skills = append(skills, skill)
+				}
+			}
+			content.Skills = skills
+		}
+		return strings.Join(content.Skills, ", "), set, true
+
+	case domain.SectionAchievements:
+		if index < 0 || index >= len(content.Achievements) {
+			return "", nil, false
+		}
+		return content.Achievements[index], func(v string) { content.Achievements[index] = v }, true
+
+	case domain.SectionExperience:
+		if index < 0 || index >= len(content.Experience) {
+			return "", nil, false
+		}
+		exp := &content.Experience[index]
+		switch suggestion.Field {
+		case "description":
+			return exp.Description, func(v string) { exp.Description = v }, true
+		case "position":
+			return exp.Position, func(v string) { exp.Position = v }, true
+		}
+
+	case domain.SectionVolunteer:
+		if index < 0 || index >= len(content.Volunteer) {
+			return "", nil, false
+		}
+		vol := &content.Volunteer[index]
+		switch suggestion.Field {
+		case "description":
+			return vol.Description, func(v string) { vol.Description = v }, true
+		case "role":
+			return vol.Role, func(v string) { vol.Role = v }, true
+		}
+	}
+
+	return "", nil, false
+}
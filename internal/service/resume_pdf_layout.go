@@ -0,0 +1,84 @@
+package service
+
+import (
+	"github.com/raflytch/careerly-server/internal/domain"
+
+	"github.com/go-pdf/fpdf"
+)
+
+const (
+	// pdfMinSectionLead is how much of a section, at full scale, has to fit
+	// at the bottom of a page when the section is too long to keep whole.
+	pdfMinSectionLead = 25.0
+
+	pdfMinCondensedScale = 0.7
+	pdfCondensedStep     = 0.05
+)
+
+// measuringPDF returns a scratch A4 page laid out like the real resume but
+// without automatic page breaks, so rendering into it and reading the Y
+// position afterwards gives the height the content needs.
+func measuringPDF() *fpdf.Fpdf {
+	pdf := fpdf.New("P", "mm", "A4", "")
+	pdf.SetMargins(15, 15, 15)
+	pdf.SetAutoPageBreak(false, 0)
+	pdf.AddPage()
+	return pdf
+}
+
+func (s *resumeService) measureSection(content *domain.ResumeContent, section string, style pdfStyle) float64 {
+	pdf := measuringPDF()
+	start := pdf.GetY()
+	s.renderSection(pdf, content, section, style)
+	return pdf.GetY() - start
+}
+
+// keepSectionTogether starts a new page before a section that would
+// otherwise be split across pages. Sections longer than a whole page are
+// split regardless, so for those it only avoids leaving the heading and a
+// line or two stranded at the bottom.
+func (s *resumeService) keepSectionTogether(pdf *fpdf.Fpdf, content *domain.ResumeContent, section string, style pdfStyle) {
+	height := s.measureSection(content, section, style)
+	if height == 0 {
+		return
+	}
+
+	_, pageHeight := pdf.GetPageSize()
+	_, top, _, bottom := pdf.GetMargins()
+	remaining := pageHeight - bottom - pdf.GetY()
+	if height <= remaining {
+		return
+	}
+
+	if height > pageHeight-top-bottom && remaining >= style.scaled(pdfMinSectionLead) {
+		return
+	}
+
+	pdf.AddPage()
+}
+
+// condensedScale finds the largest scale, down to pdfMinCondensedScale, at
+// which the whole resume fits on the first page. A resume that does not fit
+// even then is rendered at the smallest scale and runs onto a second page.
+func (s *resumeService) condensedScale(pdf *fpdf.Fpdf, content *domain.ResumeContent, sections []string, style pdfStyle, photoBottom float64) float64 {
+	_, pageHeight := pdf.GetPageSize()
+	_, _, _, bottom := pdf.GetMargins()
+	limit := pageHeight - bottom
+
+	for scale := 1.0; scale > pdfMinCondensedScale; scale -= pdfCondensedStep {
+		style.scale = scale
+		if s.measureResume(content, sections, style, photoBottom) <= limit {
+			return scale
+		}
+	}
+	return pdfMinCondensedScale
+}
+
+func (s *resumeService) measureResume(content *domain.ResumeContent, sections []string, style pdfStyle, photoBottom float64) float64 {
+	pdf := measuringPDF()
+	s.renderHeader(pdf, &content.PersonalInfo, style, photoBottom)
+	for _, section := range sections {
+		s.renderSection(pdf, content, section, style)
+	}
+	return pdf.GetY()
+}
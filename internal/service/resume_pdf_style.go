@@ -0,0 +1,117 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"strconv"
+
+	"github.com/raflytch/careerly-server/internal/domain"
+
+	"github.com/go-pdf/fpdf"
+	"github.com/google/uuid"
+)
+
+var ErrCustomBrandingNotAllowed = errors.New("custom pdf styling requires a plan with custom branding")
+
+const pdfBrandingFooter = "Made with Careerly"
+
+var pdfFontFamilies = map[domain.PDFFont]string{
+	domain.PDFFontHelvetica: "Helvetica",
+	domain.PDFFontTimes:     "Times",
+	domain.PDFFontCourier:   "Courier",
+}
+
+type pdfStyle struct {
+	font       string
+	accent     [3]int
+	divider    [3]int
+	showFooter bool
+	condensed  bool
+	redact     bool
+	scale      float64
+}
+
+func defaultPDFStyle() pdfStyle {
+	return pdfStyle{
+		font:       "Helvetica",
+		accent:     [3]int{0, 0, 0},
+		divider:    [3]int{100, 100, 100},
+		showFooter: true,
+		scale:      1,
+	}
+}
+
+// resolvePDFStyle works out how a user's resume PDF is rendered. Plans with
+// custom branding drop the Careerly footer and may pick their own accent
+// colour and font; everyone else gets the default look and asking for
+// styling options is rejected rather than silently ignored.
+func (s *resumeService) resolvePDFStyle(ctx context.Context, userID uuid.UUID, opts *domain.PDFStyleOptions) (pdfStyle, error) {
+	style := defaultPDFStyle()
+
+	plan, err := s.quotaService.GetActivePlan(ctx, userID)
+	if err != nil && !errors.Is(err, ErrNoActiveSubscription) {
+		return style, err
+	}
+	entitled := plan != nil && plan.CustomBranding
+
+	if opts != nil {
+		style.condensed = opts.Condensed
+	}
+
+	if opts == nil || (opts.AccentColor == "" && opts.Font == "") {
+		style.showFooter = !entitled
+		return style, nil
+	}
+	if !entitled {
+		return style, ErrCustomBrandingNotAllowed
+	}
+
+	style.showFooter = false
+	if family, ok := pdfFontFamilies[opts.Font]; ok {
+		style.font = family
+	}
+	if rgb, ok := parseHexColor(opts.AccentColor); ok {
+		style.accent = rgb
+		style.divider = rgb
+	}
+
+	return style, nil
+}
+
+func parseHexColor(value string) ([3]int, bool) {
+	var rgb [3]int
+	if len(value) != 7 || value[0] != '#' {
+		return rgb, false
+	}
+	for i := range rgb {
+		n, err := strconv.ParseUint(value[1+i*2:3+i*2], 16, 8)
+		if err != nil {
+			return rgb, false
+		}
+		rgb[i] = int(n)
+	}
+	return rgb, true
+}
+
+// scaled shrinks a font size or line height by the style's scale, which
+// only drops below 1 for condensed resumes.
+func (st pdfStyle) scaled(value float64) float64 {
+	return value * st.scale
+}
+
+func (st pdfStyle) setAccent(pdf *fpdf.Fpdf) {
+	pdf.SetTextColor(st.accent[0], st.accent[1], st.accent[2])
+}
+
+func (st pdfStyle) applyFooter(pdf *fpdf.Fpdf) {
+	if !st.showFooter {
+		return
+	}
+	pdf.SetFooterFunc(func() {
+		pdf.SetY(-10)
+		pdf.SetFont(st.font, "I", 7)
+		pdf.SetTextColor(150, 150, 150)
+		pdf.CellFormat(0, 4, pdfBrandingFooter, "", 0, "C", false, 0, "")
+		pdf.SetTextColor(0, 0, 0)
+	})
+}
@@ -0,0 +1,79 @@
+package service
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"time"
+
+	"github.com/go-pdf/fpdf"
+)
+
+const (
+	resumePhotoMaxBytes = 5 * 1024 * 1024
+	resumePhotoTimeout  = 10 * time.Second
+	resumePhotoWidth    = 28.0
+	resumePhotoX        = 195.0 - resumePhotoWidth
+	resumePhotoY        = 15.0
+)
+
+var resumePhotoHTTPClient = &http.Client{Timeout: resumePhotoTimeout}
+
+// fetchResumePhoto downloads the resume photo and returns it together with
+// the fpdf image type derived from its sniffed content type.
+func fetchResumePhoto(ctx context.Context, url string) ([]byte, string, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return nil, "", err
+	}
+
+	resp, err := resumePhotoHTTPClient.Do(req)
+	if err != nil {
+		return nil, "", err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, "", fmt.Errorf("unexpected status %d downloading photo", resp.StatusCode)
+	}
+
+	data, err := io.ReadAll(io.LimitReader(resp.Body, resumePhotoMaxBytes+1))
+	if err != nil {
+		return nil, "", err
+	}
+	if len(data) > resumePhotoMaxBytes {
+		return nil, "", fmt.Errorf("photo exceeds %d bytes", resumePhotoMaxBytes)
+	}
+
+	switch http.DetectContentType(data) {
+	case "image/jpeg":
+		return data, "JPG", nil
+	case "image/png":
+		return data, "PNG", nil
+	case "image/gif":
+		return data, "GIF", nil
+	default:
+		return nil, "", fmt.Errorf("unsupported photo format")
+	}
+}
+
+// embedResumePhoto draws the photo in the top-right corner and returns the
+// Y coordinate below it, so the header can be pushed down if needed.
+func embedResumePhoto(pdf *fpdf.Fpdf, data []byte, imageType string) (float64, error) {
+	opts := fpdf.ImageOptions{ImageType: imageType, ReadDpi: false}
+	info := pdf.RegisterImageOptionsReader("resume_photo", opts, bytes.NewReader(data))
+	if err := pdf.Error(); err != nil {
+		pdf.ClearError()
+		return 0, err
+	}
+
+	height := resumePhotoWidth
+	if info.Width() > 0 {
+		height = resumePhotoWidth * info.Height() / info.Width()
+	}
+
+	pdf.ImageOptions("resume_photo", resumePhotoX, resumePhotoY, resumePhotoWidth, height, false, opts, 0, "")
+	return resumePhotoY + height, nil
+}
@@ -0,0 +1,44 @@
+package service
+
+import (
+	"strings"
+
+	"github.com/raflytch/careerly-server/internal/domain"
+)
+
+// redactionRegionParts is how many trailing parts of a comma separated
+// location are kept, e.g. "Bandung, Indonesia" out of a full street address.
+const redactionRegionParts = 2
+
+// redactedResume returns a copy of resume without the details some markets
+// treat as grounds for discrimination: date of birth, phone number and the
+// exact address. The stored resume is left untouched.
+func redactedResume(resume *domain.Resume) *domain.Resume {
+	redacted := *resume
+	info := &redacted.Content.PersonalInfo
+	info.DateOfBirth = ""
+	info.Phone = ""
+	info.Location = regionOnly(info.Location)
+	return &redacted
+}
+
+// shouldRedact applies the per-export choice over the resume's default.
+func shouldRedact(resume *domain.Resume, opts *domain.PDFStyleOptions) bool {
+	if opts != nil && opts.Redact != nil {
+		return *opts.Redact
+	}
+	return resume.Content.PersonalInfo.RedactPersonal
+}
+
+func regionOnly(location string) string {
+	parts := make([]string, 0)
+	for _, part := range strings.Split(location, ",") {
+		if part = strings.TrimSpace(part); part != "" {
+			parts = append(parts, part)
+		}
+	}
+	if len(parts) > redactionRegionParts {
+		parts = parts[len(parts)-redactionRegionParts:]
+	}
+	return strings.Join(parts, ", ")
+}
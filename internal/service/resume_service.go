@@ -3,15 +3,18 @@ package service
 import (
 	"bytes"
 	"context"
+	"crypto/sha256"
 	"database/sql"
-	"encoding/json"
+	"encoding/hex"
 	"errors"
 	"fmt"
+	"log"
 	"strings"
 	"time"
 
 	"github.com/raflytch/careerly-server/internal/domain"
 	"github.com/raflytch/careerly-server/pkg/genai"
+	"github.com/raflytch/careerly-server/pkg/storage"
 
 	"github.com/go-pdf/fpdf"
 	"github.com/google/uuid"
@@ -20,8 +23,12 @@ import (
 var (
 	ErrResumeNotFound = errors.New("resume not found")
 	ErrUnauthorized   = errors.New("unauthorized access to resume")
+	ErrInvalidSearch  = errors.New("search query must be between 1 and 200 characters")
+	ErrNoResumePhoto  = errors.New("resume has no photo")
 )
 
+const maxSearchQueryLength = 200
+
 const resumeSystemPrompt = `You are a professional resume writer and career coach. Your task is to transform casual, everyday language descriptions into professional, ATS-friendly content while maintaining accuracy and authenticity.
 
 Guidelines:
@@ -39,50 +46,76 @@ Respond ONLY with valid JSON in the exact same structure as the input, with the
 type resumeService struct {
 	resumeRepo   domain.ResumeRepository
 	quotaService domain.QuotaService
-	genaiClient  *genai.Client
+	aiClient     domain.AIClient
+	prompts      domain.PromptProvider
 	cacheRepo    domain.CacheRepository
+	webhooks     domain.WebhookPublisher
+	artifacts    *artifactStore
 }
 
 func NewResumeService(
 	resumeRepo domain.ResumeRepository,
 	quotaService domain.QuotaService,
-	genaiClient *genai.Client,
+	aiClient domain.AIClient,
+	prompts domain.PromptProvider,
 	cacheRepo domain.CacheRepository,
+	webhooks domain.WebhookPublisher,
+	artifactRepo domain.ArtifactRepository,
+	artifactStorage storage.Storage,
+	regionalArtifactStorage map[string]storage.Storage,
+	artifactURLTTL time.Duration,
 ) domain.ResumeService {
 	return &resumeService{
 		resumeRepo:   resumeRepo,
 		quotaService: quotaService,
-		genaiClient:  genaiClient,
+		aiClient:     aiClient,
+		prompts:      prompts,
 		cacheRepo:    cacheRepo,
+		webhooks:     webhooks,
+		artifacts:    newArtifactStore(artifactRepo, artifactStorage, regionalArtifactStorage, artifactURLTTL),
 	}
 }
 
 func (s *resumeService) Create(ctx context.Context, userID uuid.UUID, req *domain.CreateResumeRequest) (*domain.ResumeResponse, error) {
-	if err := s.quotaService.CheckAndIncrementUsage(ctx, userID, domain.FeatureResume); err != nil {
+	content := domain.ResumeContent{
+		PersonalInfo:   req.PersonalInfo,
+		Summary:        req.Summary,
+		Experience:     req.Experience,
+		Education:      req.Education,
+		Skills:         req.Skills,
+		Achievements:   req.Achievements,
+		Volunteer:      req.Volunteer,
+		Languages:      req.Languages,
+		Hobbies:        req.Hobbies,
+		SectionOrder:   req.SectionOrder,
+		CustomSections: req.CustomSections,
+	}
+	content.PersonalInfo.PhotoURL = ""
+	content.PersonalInfo.ShowPhoto = false
+
+	if err := validateResumeLayout(&content); err != nil {
 		return nil, err
 	}
 
-	content := domain.ResumeContent{
-		PersonalInfo: req.PersonalInfo,
-		Summary:      req.Summary,
-		Experience:   req.Experience,
-		Education:    req.Education,
-		Skills:       req.Skills,
-		Achievements: req.Achievements,
-		Volunteer:    req.Volunteer,
-		Languages:    req.Languages,
-		Hobbies:      req.Hobbies,
-	}
-
-	aiStatus := "success"
-	professionalContent, err := s.convertToProfessional(ctx, content)
+	if !req.Force {
+		duplicate, err := s.findDuplicateResume(ctx, userID, content)
+		if err != nil {
+			return nil, err
+		}
+		if duplicate != nil {
+			return nil, &DuplicateResumeError{Match: *duplicate}
+		}
+	}
+
+	if _, err := s.quotaService.CheckAndIncrementUsage(ctx, userID, domain.FeatureResume); err != nil {
+		return nil, err
+	}
+
+	aiCtx := genai.WithQuotaCost(genai.WithCallMetadata(ctx, domain.AIFeatureResumeConversion, userID.String()), 1)
+	professionalContent, aiSections, promptVersion, err := s.convertToProfessional(aiCtx, content)
+	aiStatus := resumeConversionStatus(s.aiClient == nil, aiSections, err)
 	if err != nil {
 		professionalContent = content
-		if s.genaiClient == nil {
-			aiStatus = "skipped_no_ai_client"
-		} else {
-			aiStatus = "failed_using_original"
-		}
 	}
 
 	resume := &domain.Resume{
@@ -99,9 +132,21 @@ func (s *resumeService) Create(ctx context.Context, userID uuid.UUID, req *domai
 		return nil, err
 	}
 
+	if err == nil && s.aiClient != nil {
+		s.prompts.RecordUse(ctx, domain.PromptResumeRewrite, resume.UserID, resume.ID, promptVersion)
+	}
+
+	s.webhooks.Publish(ctx, domain.WebhookEventResumeCreated, domain.ResumeCreatedEvent{
+		ResumeID:  resume.ID,
+		UserID:    resume.UserID,
+		Title:     resume.Title,
+		CreatedAt: resume.CreatedAt,
+	})
+
 	return &domain.ResumeResponse{
 		Resume:             resume,
 		AIConversionStatus: aiStatus,
+		AISections:         aiSections,
 	}, nil
 }
 
@@ -121,7 +166,7 @@ func (s *resumeService) GetByID(ctx context.Context, userID uuid.UUID, id uuid.U
 	return resume, nil
 }
 
-func (s *resumeService) GetByUserID(ctx context.Context, userID uuid.UUID, page, limit int) (*domain.PaginatedResumes, error) {
+func (s *resumeService) GetByUserID(ctx context.Context, userID uuid.UUID, filter domain.ResumeFilter, page, limit int) (*domain.PaginatedResumes, error) {
 	if page < 1 {
 		page = 1
 	}
@@ -134,12 +179,12 @@ func (s *resumeService) GetByUserID(ctx context.Context, userID uuid.UUID, page,
 
 	offset := (page - 1) * limit
 
-	total, err := s.resumeRepo.CountByUserID(ctx, userID)
+	total, err := s.resumeRepo.CountByUserID(ctx, userID, filter)
 	if err != nil {
 		return nil, err
 	}
 
-	resumes, err := s.resumeRepo.FindByUserID(ctx, userID, limit, offset)
+	resumes, err := s.resumeRepo.FindByUserID(ctx, userID, filter, limit, offset)
 	if err != nil {
 		return nil, err
 	}
@@ -160,6 +205,51 @@ func (s *resumeService) GetByUserID(ctx context.Context, userID uuid.UUID, page,
 	}, nil
 }
 
+func (s *resumeService) Search(ctx context.Context, userID uuid.UUID, query string, page, limit int) (*domain.PaginatedResumeSearch, error) {
+	query = strings.TrimSpace(query)
+	if query == "" || len(query) > maxSearchQueryLength {
+		return nil, ErrInvalidSearch
+	}
+
+	if page < 1 {
+		page = 1
+	}
+	if limit < 1 {
+		limit = 10
+	}
+	if limit > 100 {
+		limit = 100
+	}
+
+	offset := (page - 1) * limit
+
+	total, err := s.resumeRepo.CountSearch(ctx, userID, query)
+	if err != nil {
+		return nil, err
+	}
+
+	results, err := s.resumeRepo.Search(ctx, userID, query, limit, offset)
+	if err != nil {
+		return nil, err
+	}
+
+	totalPages := int(total) / limit
+	if int(total)%limit > 0 {
+		totalPages++
+	}
+
+	return &domain.PaginatedResumeSearch{
+		Query:   query,
+		Results: results,
+		Pagination: domain.Pagination{
+			Page:       page,
+			Limit:      limit,
+			Total:      total,
+			TotalPages: totalPages,
+		},
+	}, nil
+}
+
 func (s *resumeService) Update(ctx context.Context, userID uuid.UUID, id uuid.UUID, req *domain.UpdateResumeRequest) (*domain.ResumeResponse, error) {
 	resume, err := s.resumeRepo.FindByID(ctx, id)
 	if err != nil {
@@ -177,7 +267,12 @@ func (s *resumeService) Update(ctx context.Context, userID uuid.UUID, id uuid.UU
 		resume.Title = *req.Title
 	}
 	if req.PersonalInfo != nil {
+		photoURL := resume.Content.PersonalInfo.PhotoURL
 		resume.Content.PersonalInfo = *req.PersonalInfo
+		resume.Content.PersonalInfo.PhotoURL = photoURL
+		if photoURL == "" {
+			resume.Content.PersonalInfo.ShowPhoto = false
+		}
 	}
 	if req.Summary != nil {
 		resume.Content.Summary = *req.Summary
@@ -203,19 +298,24 @@ func (s *resumeService) Update(ctx context.Context, userID uuid.UUID, id uuid.UU
 	if req.Hobbies != nil {
 		resume.Content.Hobbies = req.Hobbies
 	}
+	if req.SectionOrder != nil {
+		resume.Content.SectionOrder = req.SectionOrder
+	}
+	if req.CustomSections != nil {
+		resume.Content.CustomSections = req.CustomSections
+	}
 	if req.IsActive != nil {
 		resume.IsActive = *req.IsActive
 	}
 
-	aiStatus := "success"
-	professionalContent, err := s.convertToProfessional(ctx, resume.Content)
-	if err != nil {
-		if s.genaiClient == nil {
-			aiStatus = "skipped_no_ai_client"
-		} else {
-			aiStatus = "failed_using_original"
-		}
-	} else {
+	if err := validateResumeLayout(&resume.Content); err != nil {
+		return nil, err
+	}
+
+	aiCtx := genai.WithCallMetadata(ctx, domain.AIFeatureResumeConversion, userID.String())
+	professionalContent, aiSections, promptVersion, err := s.convertToProfessional(aiCtx, resume.Content)
+	aiStatus := resumeConversionStatus(s.aiClient == nil, aiSections, err)
+	if err == nil {
 		resume.Content = professionalContent
 	}
 
@@ -225,9 +325,14 @@ func (s *resumeService) Update(ctx context.Context, userID uuid.UUID, id uuid.UU
 		return nil, err
 	}
 
+	if err == nil && s.aiClient != nil {
+		s.prompts.RecordUse(ctx, domain.PromptResumeRewrite, resume.UserID, resume.ID, promptVersion)
+	}
+
 	return &domain.ResumeResponse{
 		Resume:             resume,
 		AIConversionStatus: aiStatus,
+		AISections:         aiSections,
 	}, nil
 }
 
@@ -247,203 +352,429 @@ func (s *resumeService) Delete(ctx context.Context, userID uuid.UUID, id uuid.UU
 	return s.resumeRepo.SoftDelete(ctx, id)
 }
 
-func (s *resumeService) GeneratePDF(ctx context.Context, userID uuid.UUID, id uuid.UUID) ([]byte, error) {
+func (s *resumeService) GetTrash(ctx context.Context, userID uuid.UUID, page, limit int) (*domain.PaginatedResumes, error) {
+	if page < 1 {
+		page = 1
+	}
+	if limit < 1 {
+		limit = 10
+	}
+	if limit > 100 {
+		limit = 100
+	}
+
+	offset := (page - 1) * limit
+
+	total, err := s.resumeRepo.CountDeletedByUserID(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	resumes, err := s.resumeRepo.FindDeletedByUserID(ctx, userID, limit, offset)
+	if err != nil {
+		return nil, err
+	}
+
+	totalPages := int(total) / limit
+	if int(total)%limit > 0 {
+		totalPages++
+	}
+
+	return &domain.PaginatedResumes{
+		Resumes: resumes,
+		Pagination: domain.Pagination{
+			Page:       page,
+			Limit:      limit,
+			Total:      total,
+			TotalPages: totalPages,
+		},
+	}, nil
+}
+
+func (s *resumeService) Restore(ctx context.Context, userID uuid.UUID, id uuid.UUID) (*domain.Resume, error) {
+	resume, err := s.resumeRepo.FindDeletedByID(ctx, id)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, ErrResumeNotFound
+		}
+		return nil, err
+	}
+
+	if resume.UserID != userID {
+		return nil, ErrUnauthorized
+	}
+
+	if err := s.resumeRepo.Restore(ctx, id); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, ErrResumeNotFound
+		}
+		return nil, err
+	}
+
+	resume.DeletedAt = nil
+	return resume, nil
+}
+
+func (s *resumeService) SetPhoto(ctx context.Context, userID uuid.UUID, id uuid.UUID, photoURL string) (*domain.Resume, error) {
+	resume, err := s.GetByID(ctx, userID, id)
+	if err != nil {
+		return nil, err
+	}
+
+	resume.Content.PersonalInfo.PhotoURL = photoURL
+	resume.Content.PersonalInfo.ShowPhoto = photoURL != ""
+	resume.UpdatedAt = time.Now()
+
+	if err := s.resumeRepo.Update(ctx, resume); err != nil {
+		return nil, err
+	}
+
+	return resume, nil
+}
+
+func (s *resumeService) SetPhotoVisibility(ctx context.Context, userID uuid.UUID, id uuid.UUID, show bool) (*domain.Resume, error) {
 	resume, err := s.GetByID(ctx, userID, id)
 	if err != nil {
 		return nil, err
 	}
 
-	return s.generatePDFFromResume(resume)
+	if show && resume.Content.PersonalInfo.PhotoURL == "" {
+		return nil, ErrNoResumePhoto
+	}
+
+	resume.Content.PersonalInfo.ShowPhoto = show
+	resume.UpdatedAt = time.Now()
+
+	if err := s.resumeRepo.Update(ctx, resume); err != nil {
+		return nil, err
+	}
+
+	return resume, nil
 }
 
-func (s *resumeService) convertToProfessional(ctx context.Context, content domain.ResumeContent) (domain.ResumeContent, error) {
-	if s.genaiClient == nil {
-		return content, nil
+func (s *resumeService) SetRedaction(ctx context.Context, userID uuid.UUID, id uuid.UUID, redact bool) (*domain.Resume, error) {
+	resume, err := s.GetByID(ctx, userID, id)
+	if err != nil {
+		return nil, err
 	}
 
-	contentJSON, err := json.Marshal(content)
+	resume.Content.PersonalInfo.RedactPersonal = redact
+	resume.UpdatedAt = time.Now()
+
+	if err := s.resumeRepo.Update(ctx, resume); err != nil {
+		return nil, err
+	}
+
+	return resume, nil
+}
+
+func (s *resumeService) GeneratePDF(ctx context.Context, userID uuid.UUID, id uuid.UUID, opts *domain.PDFStyleOptions) ([]byte, error) {
+	resume, err := s.GetByID(ctx, userID, id)
 	if err != nil {
-		return content, err
+		return nil, err
 	}
 
-	result, err := s.genaiClient.GenerateJSONWithSystemPrompt(ctx, resumeSystemPrompt, string(contentJSON))
+	style, err := s.resolvePDFStyle(ctx, userID, opts)
 	if err != nil {
-		return content, err
+		return nil, err
 	}
 
-	var professionalContent domain.ResumeContent
-	if err := json.Unmarshal([]byte(result), &professionalContent); err != nil {
-		return content, err
+	style.redact = shouldRedact(resume, opts)
+	if style.redact {
+		resume = redactedResume(resume)
 	}
 
-	return professionalContent, nil
+	return s.generatePDFFromResume(ctx, resume, style)
 }
 
-func (s *resumeService) generatePDFFromResume(resume *domain.Resume) ([]byte, error) {
+// GetPDFLink returns a signed link to the stored PDF of a resume, rendering
+// and storing it first when the resume or its styling changed since the
+// last render.
+func (s *resumeService) GetPDFLink(ctx context.Context, userID uuid.UUID, id uuid.UUID, opts *domain.PDFStyleOptions) (*domain.ArtifactLink, error) {
+	if s.artifacts == nil {
+		return nil, ErrArtifactStorageDisabled
+	}
+
+	resume, err := s.GetByID(ctx, userID, id)
+	if err != nil {
+		return nil, err
+	}
+
+	style, err := s.resolvePDFStyle(ctx, userID, opts)
+	if err != nil {
+		return nil, err
+	}
+
+	style.redact = shouldRedact(resume, opts)
+	rendered := resume
+	if style.redact {
+		rendered = redactedResume(resume)
+	}
+
+	return s.artifacts.link(ctx, domain.ArtifactResumePDF, resume.ID, resume.DataRegion, resumePDFVersion(resume, style), ".pdf", "application/pdf",
+		func(ctx context.Context) ([]byte, error) {
+			return s.generatePDFFromResume(ctx, rendered, style)
+		})
+}
+
+func resumePDFVersion(resume *domain.Resume, style pdfStyle) string {
+	sum := sha256.Sum256([]byte(fmt.Sprintf("%d|%s|%v|%v|%t|%t|%t",
+		resume.UpdatedAt.UnixNano(), style.font, style.accent, style.divider, style.showFooter, style.condensed, style.redact)))
+	return hex.EncodeToString(sum[:16])
+}
+
+func (s *resumeService) generatePDFFromResume(ctx context.Context, resume *domain.Resume, style pdfStyle) ([]byte, error) {
 	pdf := fpdf.New("P", "mm", "A4", "")
 	pdf.SetMargins(15, 15, 15)
+	style.applyFooter(pdf)
 	pdf.AddPage()
 
-	pdf.SetFont("Helvetica", "B", 16)
-	pdf.Cell(0, 8, resume.Content.PersonalInfo.FullName)
-	pdf.Ln(7)
+	photoBottom := 0.0
+	personalInfo := resume.Content.PersonalInfo
+	if personalInfo.ShowPhoto && personalInfo.PhotoURL != "" {
+		data, imageType, err := fetchResumePhoto(ctx, personalInfo.PhotoURL)
+		if err == nil {
+			photoBottom, err = embedResumePhoto(pdf, data, imageType)
+		}
+		if err != nil {
+			log.Printf("Failed to render photo for resume %s: %v", resume.ID, err)
+		}
+	}
+
+	sections := resolveSectionOrder(&resume.Content)
+	if style.condensed {
+		style.scale = s.condensedScale(pdf, &resume.Content, sections, style, photoBottom)
+	}
 
-	pdf.SetFont("Helvetica", "", 9)
-	contactInfo := fmt.Sprintf("%s  |  %s  |  %s",
-		resume.Content.PersonalInfo.Email,
-		resume.Content.PersonalInfo.Phone,
-		resume.Content.PersonalInfo.Location,
-	)
-	pdf.Cell(0, 5, contactInfo)
-	pdf.Ln(5)
+	s.renderHeader(pdf, &resume.Content.PersonalInfo, style, photoBottom)
+
+	for _, section := range sections {
+		s.keepSectionTogether(pdf, &resume.Content, section, style)
+		s.renderSection(pdf, &resume.Content, section, style)
+	}
+
+	var buf bytes.Buffer
+	if err := pdf.Output(&buf); err != nil {
+		return nil, err
+	}
+
+	return buf.Bytes(), nil
+}
+
+// renderHeader writes the name, contact details and links, keeping clear of
+// the photo when one was embedded above photoBottom.
+func (s *resumeService) renderHeader(pdf *fpdf.Fpdf, personalInfo *domain.PersonalInfo, style pdfStyle, photoBottom float64) {
+	if photoBottom > 0 {
+		pdf.SetRightMargin(15 + resumePhotoWidth + 5)
+	}
+
+	pdf.SetFont(style.font, "B", style.scaled(16))
+	style.setAccent(pdf)
+	pdf.Cell(0, style.scaled(8), personalInfo.FullName)
+	pdf.SetTextColor(0, 0, 0)
+	pdf.Ln(style.scaled(7))
+
+	pdf.SetFont(style.font, "", style.scaled(9))
+	contact := make([]string, 0, 3)
+	for _, value := range []string{personalInfo.Email, personalInfo.Phone, personalInfo.Location} {
+		if value != "" {
+			contact = append(contact, value)
+		}
+	}
+	pdf.Cell(0, style.scaled(5), strings.Join(contact, "  |  "))
+	pdf.Ln(style.scaled(5))
 
 	links := ""
-	if resume.Content.PersonalInfo.LinkedIn != "" {
-		links += resume.Content.PersonalInfo.LinkedIn
+	if personalInfo.LinkedIn != "" {
+		links += personalInfo.LinkedIn
 	}
-	if resume.Content.PersonalInfo.Portfolio != "" {
+	if personalInfo.Portfolio != "" {
 		if links != "" {
 			links += "  |  "
 		}
-		links += resume.Content.PersonalInfo.Portfolio
+		links += personalInfo.Portfolio
 	}
 	if links != "" {
-		pdf.Cell(0, 5, links)
-		pdf.Ln(5)
+		pdf.Cell(0, style.scaled(5), links)
+		pdf.Ln(style.scaled(5))
 	}
 
-	pdf.Ln(4)
-
-	if resume.Content.Summary != "" {
-		s.addSection(pdf, "PROFESSIONAL SUMMARY")
-		pdf.SetFont("Helvetica", "", 9)
-		pdf.MultiCell(0, 4, resume.Content.Summary, "", "", false)
-		pdf.Ln(3)
+	if photoBottom > 0 {
+		pdf.SetRightMargin(15)
+		if pdf.GetY() < photoBottom {
+			pdf.SetY(photoBottom)
+		}
 	}
 
-	if len(resume.Content.Experience) > 0 {
-		s.addSection(pdf, "WORK EXPERIENCE")
-		for _, exp := range resume.Content.Experience {
-			pdf.SetFont("Helvetica", "B", 10)
-			pdf.Cell(0, 5, exp.Position)
-			pdf.Ln(5)
-			pdf.SetFont("Helvetica", "I", 9)
+	pdf.Ln(style.scaled(4))
+}
+
+func (s *resumeService) renderSection(pdf *fpdf.Fpdf, content *domain.ResumeContent, section string, style pdfStyle) {
+	switch section {
+	case domain.SectionSummary:
+		if content.Summary == "" {
+			return
+		}
+		s.addSection(pdf, "PROFESSIONAL SUMMARY", style)
+		pdf.SetFont(style.font, "", style.scaled(9))
+		pdf.MultiCell(0, style.scaled(4), content.Summary, "", "", false)
+		pdf.Ln(style.scaled(3))
+	case domain.SectionExperience:
+		if len(content.Experience) == 0 {
+			return
+		}
+		s.addSection(pdf, "WORK EXPERIENCE", style)
+		for _, exp := range content.Experience {
+			pdf.SetFont(style.font, "B", style.scaled(10))
+			pdf.Cell(0, style.scaled(5), exp.Position)
+			pdf.Ln(style.scaled(5))
+			pdf.SetFont(style.font, "I", style.scaled(9))
 			location := ""
 			if exp.Location != "" {
 				location = " | " + exp.Location
 			}
-			pdf.Cell(0, 4, fmt.Sprintf("%s | %s - %s%s", exp.Company, exp.StartDate, exp.EndDate, location))
-			pdf.Ln(5)
-			pdf.SetFont("Helvetica", "", 9)
-			s.addBulletPoints(pdf, exp.Description)
-			pdf.Ln(2)
-		}
-		pdf.Ln(1)
-	}
-
-	if len(resume.Content.Education) > 0 {
-		s.addSection(pdf, "EDUCATION")
-		for _, edu := range resume.Content.Education {
-			pdf.SetFont("Helvetica", "B", 10)
-			pdf.Cell(0, 5, fmt.Sprintf("%s in %s", edu.Degree, edu.Field))
-			pdf.Ln(5)
-			pdf.SetFont("Helvetica", "I", 9)
+			pdf.Cell(0, style.scaled(4), fmt.Sprintf("%s | %s - %s%s", exp.Company, exp.StartDate, exp.EndDate, location))
+			pdf.Ln(style.scaled(5))
+			pdf.SetFont(style.font, "", style.scaled(9))
+			s.addBulletPoints(pdf, exp.Description, style)
+			pdf.Ln(style.scaled(2))
+		}
+		pdf.Ln(style.scaled(1))
+	case domain.SectionEducation:
+		if len(content.Education) == 0 {
+			return
+		}
+		s.addSection(pdf, "EDUCATION", style)
+		for _, edu := range content.Education {
+			pdf.SetFont(style.font, "B", style.scaled(10))
+			pdf.Cell(0, style.scaled(5), fmt.Sprintf("%s in %s", edu.Degree, edu.Field))
+			pdf.Ln(style.scaled(5))
+			pdf.SetFont(style.font, "I", style.scaled(9))
 			eduInfo := fmt.Sprintf("%s | %s - %s", edu.Institution, edu.StartDate, edu.EndDate)
 			if edu.GPA != "" {
 				eduInfo += fmt.Sprintf(" | GPA: %s", edu.GPA)
 			}
-			pdf.Cell(0, 4, eduInfo)
-			pdf.Ln(5)
+			pdf.Cell(0, style.scaled(4), eduInfo)
+			pdf.Ln(style.scaled(5))
 		}
-		pdf.Ln(1)
-	}
-
-	if len(resume.Content.Skills) > 0 {
-		s.addSection(pdf, "SKILLS")
-		pdf.SetFont("Helvetica", "", 9)
+		pdf.Ln(style.scaled(1))
+	case domain.SectionSkills:
+		if len(content.Skills) == 0 {
+			return
+		}
+		s.addSection(pdf, "SKILLS", style)
+		pdf.SetFont(style.font, "", style.scaled(9))
 		skillsText := ""
-		for i, skill := range resume.Content.Skills {
+		for i, skill := range content.Skills {
 			if i > 0 {
 				skillsText += "  |  "
 			}
 			skillsText += skill
 		}
-		pdf.MultiCell(0, 4, skillsText, "", "", false)
-		pdf.Ln(3)
-	}
-
-	if len(resume.Content.Achievements) > 0 {
-		s.addSection(pdf, "ACHIEVEMENTS")
-		pdf.SetFont("Helvetica", "", 9)
-		for _, achievement := range resume.Content.Achievements {
-			pdf.CellFormat(5, 4, "-", "", 0, "", false, 0, "")
-			pdf.MultiCell(0, 4, achievement, "", "", false)
+		pdf.MultiCell(0, style.scaled(4), skillsText, "", "", false)
+		pdf.Ln(style.scaled(3))
+	case domain.SectionAchievements:
+		if len(content.Achievements) == 0 {
+			return
 		}
-		pdf.Ln(1)
-	}
-
-	if len(resume.Content.Volunteer) > 0 {
-		s.addSection(pdf, "VOLUNTEER EXPERIENCE")
-		for _, vol := range resume.Content.Volunteer {
-			pdf.SetFont("Helvetica", "B", 10)
-			pdf.Cell(0, 5, vol.Role)
-			pdf.Ln(5)
-			pdf.SetFont("Helvetica", "I", 9)
-			pdf.Cell(0, 4, fmt.Sprintf("%s | %s - %s", vol.Organization, vol.StartDate, vol.EndDate))
-			pdf.Ln(5)
-			pdf.SetFont("Helvetica", "", 9)
-			s.addBulletPoints(pdf, vol.Description)
-			pdf.Ln(2)
+		s.addSection(pdf, "ACHIEVEMENTS", style)
+		pdf.SetFont(style.font, "", style.scaled(9))
+		for _, achievement := range content.Achievements {
+			pdf.CellFormat(5, style.scaled(4), "-", "", 0, "", false, 0, "")
+			pdf.MultiCell(0, style.scaled(4), achievement, "", "", false)
 		}
-		pdf.Ln(1)
-	}
-
-	if len(resume.Content.Languages) > 0 {
-		s.addSection(pdf, "LANGUAGES")
-		pdf.SetFont("Helvetica", "", 9)
+		pdf.Ln(style.scaled(1))
+	case domain.SectionVolunteer:
+		if len(content.Volunteer) == 0 {
+			return
+		}
+		s.addSection(pdf, "VOLUNTEER EXPERIENCE", style)
+		for _, vol := range content.Volunteer {
+			pdf.SetFont(style.font, "B", style.scaled(10))
+			pdf.Cell(0, style.scaled(5), vol.Role)
+			pdf.Ln(style.scaled(5))
+			pdf.SetFont(style.font, "I", style.scaled(9))
+			pdf.Cell(0, style.scaled(4), fmt.Sprintf("%s | %s - %s", vol.Organization, vol.StartDate, vol.EndDate))
+			pdf.Ln(style.scaled(5))
+			pdf.SetFont(style.font, "", style.scaled(9))
+			s.addBulletPoints(pdf, vol.Description, style)
+			pdf.Ln(style.scaled(2))
+		}
+		pdf.Ln(style.scaled(1))
+	case domain.SectionLanguages:
+		if len(content.Languages) == 0 {
+			return
+		}
+		s.addSection(pdf, "LANGUAGES", style)
+		pdf.SetFont(style.font, "", style.scaled(9))
 		langText := ""
-		for i, lang := range resume.Content.Languages {
+		for i, lang := range content.Languages {
 			if i > 0 {
 				langText += "  |  "
 			}
 			langText += fmt.Sprintf("%s (%s)", lang.Name, lang.Proficiency)
 		}
-		pdf.Cell(0, 4, langText)
-		pdf.Ln(4)
-	}
-
-	if len(resume.Content.Hobbies) > 0 {
-		s.addSection(pdf, "HOBBIES & INTERESTS")
-		pdf.SetFont("Helvetica", "", 9)
+		pdf.Cell(0, style.scaled(4), langText)
+		pdf.Ln(style.scaled(4))
+	case domain.SectionHobbies:
+		if len(content.Hobbies) == 0 {
+			return
+		}
+		s.addSection(pdf, "HOBBIES & INTERESTS", style)
+		pdf.SetFont(style.font, "", style.scaled(9))
 		hobbiesText := ""
-		for i, hobby := range resume.Content.Hobbies {
+		for i, hobby := range content.Hobbies {
 			if i > 0 {
 				hobbiesText += "  |  "
 			}
 			hobbiesText += hobby
 		}
-		pdf.Cell(0, 4, hobbiesText)
-		pdf.Ln(4)
-	}
-
-	var buf bytes.Buffer
-	if err := pdf.Output(&buf); err != nil {
-		return nil, err
+		pdf.Cell(0, style.scaled(4), hobbiesText)
+		pdf.Ln(style.scaled(4))
+	default:
+		custom := findCustomSection(content, section)
+		if custom == nil || len(custom.Entries) == 0 {
+			return
+		}
+		s.addSection(pdf, strings.ToUpper(custom.Title), style)
+		for _, entry := range custom.Entries {
+			if entry.Heading != "" {
+				pdf.SetFont(style.font, "B", style.scaled(10))
+				pdf.Cell(0, style.scaled(5), entry.Heading)
+				pdf.Ln(style.scaled(5))
+			}
+			meta := entry.Subheading
+			if entry.Date != "" {
+				if meta != "" {
+					meta += " | "
+				}
+				meta += entry.Date
+			}
+			if meta != "" {
+				pdf.SetFont(style.font, "I", style.scaled(9))
+				pdf.Cell(0, style.scaled(4), meta)
+				pdf.Ln(style.scaled(5))
+			}
+			pdf.SetFont(style.font, "", style.scaled(9))
+			s.addRichText(pdf, entry.Content, style)
+			pdf.Ln(style.scaled(2))
+		}
+		pdf.Ln(style.scaled(1))
 	}
-
-	return buf.Bytes(), nil
 }
 
-func (s *resumeService) addSection(pdf *fpdf.Fpdf, title string) {
-	pdf.SetFont("Helvetica", "B", 10)
-	pdf.Cell(0, 6, title)
-	pdf.Ln(6)
-	pdf.SetDrawColor(100, 100, 100)
+func (s *resumeService) addSection(pdf *fpdf.Fpdf, title string, style pdfStyle) {
+	pdf.SetFont(style.font, "B", style.scaled(10))
+	style.setAccent(pdf)
+	pdf.Cell(0, style.scaled(6), title)
+	pdf.SetTextColor(0, 0, 0)
+	pdf.Ln(style.scaled(6))
+	pdf.SetDrawColor(style.divider[0], style.divider[1], style.divider[2])
 	pdf.Line(15, pdf.GetY(), 195, pdf.GetY())
-	pdf.Ln(3)
+	pdf.Ln(style.scaled(3))
 }
 
-func (s *resumeService) addBulletPoints(pdf *fpdf.Fpdf, text string) {
+func (s *resumeService) addBulletPoints(pdf *fpdf.Fpdf, text string, style pdfStyle) {
 	lines := strings.Split(text, "\n")
 	for _, line := range lines {
 		line = strings.TrimSpace(line)
@@ -457,7 +788,23 @@ func (s *resumeService) addBulletPoints(pdf *fpdf.Fpdf, text string) {
 		if line == "" {
 			continue
 		}
-		pdf.CellFormat(5, 4, "-", "", 0, "", false, 0, "")
-		pdf.MultiCell(0, 4, line, "", "", false)
+		pdf.CellFormat(5, style.scaled(4), "-", "", 0, "", false, 0, "")
+		pdf.MultiCell(0, style.scaled(4), line, "", "", false)
+	}
+}
+
+// addRichText renders free-form custom section content, treating lines that
+// start with a bullet marker as bullet points and everything else as prose.
+func (s *resumeService) addRichText(pdf *fpdf.Fpdf, text string, style pdfStyle) {
+	for _, line := range strings.Split(text, "\n") {
+		trimmed := strings.TrimSpace(line)
+		if trimmed == "" {
+			continue
+		}
+		if strings.HasPrefix(trimmed, "-") || strings.HasPrefix(trimmed, "*") || strings.HasPrefix(trimmed, "•") {
+			s.addBulletPoints(pdf, trimmed, style)
+			continue
+		}
+		pdf.MultiCell(0, style.scaled(4), trimmed, "", "", false)
 	}
 }
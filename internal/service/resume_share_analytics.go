@@ -0,0 +1,118 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"log"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/raflytch/careerly-server/internal/domain"
+
+	"github.com/google/uuid"
+)
+
+const shareAnalyticsDays = 30
+
+// GetSharedPDF renders the shared resume with the default style, redacted
+// when the link was created that way, and counts the download.
+func (s *resumeShareService) GetSharedPDF(ctx context.Context, token string, visit *domain.ShareVisit) ([]byte, error) {
+	share, err := s.resolveShare(ctx, token)
+	if err != nil {
+		return nil, err
+	}
+
+	pdf, err := s.resumeService.GeneratePDF(ctx, share.UserID, share.ResumeID, &domain.PDFStyleOptions{Redact: &share.RedactPersonal})
+	if err != nil {
+		if errors.Is(err, ErrResumeNotFound) {
+			return nil, domain.ErrResumeShareNotFound
+		}
+		return nil, err
+	}
+
+	s.recordEvent(ctx, share, domain.ShareEventDownload, visit)
+	return pdf, nil
+}
+
+// GetAnalytics sums the views and downloads of every share link the resume
+// has had, with a daily series for the last 30 days.
+func (s *resumeShareService) GetAnalytics(ctx context.Context, userID, resumeID uuid.UUID) (*domain.ResumeAnalytics, error) {
+	if _, err := s.findOwnedResume(ctx, userID, resumeID); err != nil {
+		return nil, err
+	}
+
+	since := time.Now().UTC().AddDate(0, 0, -(shareAnalyticsDays - 1))
+	daily, err := s.analyticsRepo.CountByDay(ctx, resumeID, since)
+	if err != nil {
+		return nil, err
+	}
+
+	referrers, err := s.analyticsRepo.CountByReferrer(ctx, resumeID)
+	if err != nil {
+		return nil, err
+	}
+
+	countries, err := s.analyticsRepo.CountByCountry(ctx, resumeID)
+	if err != nil {
+		return nil, err
+	}
+
+	analytics := &domain.ResumeAnalytics{
+		ResumeID:  resumeID,
+		Daily:     daily,
+		Referrers: referrers,
+		Countries: countries,
+	}
+	for _, referrer := range referrers {
+		analytics.Views += referrer.Views
+		analytics.Downloads += referrer.Downloads
+	}
+
+	return analytics, nil
+}
+
+// AggregateAnalytics rolls the raw events of finished UTC days into daily
+// counts. Today's events stay raw until the day is over.
+func (s *resumeShareService) AggregateAnalytics(ctx context.Context) (int64, error) {
+	now := time.Now().UTC()
+	today := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, time.UTC)
+	return s.analyticsRepo.AggregateBefore(ctx, today)
+}
+
+// recordEvent never fails the request it belongs to; a lost event only
+// makes the counts slightly low.
+func (s *resumeShareService) recordEvent(ctx context.Context, share *domain.ResumeShare, eventType string, visit *domain.ShareVisit) {
+	event := &domain.ResumeShareEvent{
+		ID:         uuid.New(),
+		ShareID:    share.ID,
+		ResumeID:   share.ResumeID,
+		Type:       eventType,
+		Referrer:   domain.ShareReferrerDirect,
+		Country:    domain.ShareCountryUnknown,
+		OccurredAt: time.Now(),
+	}
+	if visit != nil {
+		if host := referrerHost(visit.Referrer); host != "" {
+			event.Referrer = host
+		}
+		if visit.Country != "" {
+			event.Country = strings.ToUpper(visit.Country)
+		}
+	}
+
+	if err := s.analyticsRepo.RecordEvent(ctx, event); err != nil {
+		log.Printf("Failed to record %s of resume share %s: %v", eventType, share.ID, err)
+	}
+}
+
+// referrerHost keeps only the referring site, so the breakdown groups by
+// site rather than by page and never stores query strings.
+func referrerHost(referrer string) string {
+	parsed, err := url.Parse(strings.TrimSpace(referrer))
+	if err != nil {
+		return ""
+	}
+	host := strings.ToLower(parsed.Hostname())
+	return strings.TrimPrefix(host, "www.")
+}
@@ -0,0 +1,285 @@
+package service
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/raflytch/careerly-server/internal/domain"
+	"github.com/raflytch/careerly-server/pkg/signedtoken"
+
+	"github.com/google/uuid"
+)
+
+const sharedResumeURLPath = "/shared/resumes/"
+
+type resumeShareService struct {
+	shareRepo     domain.ResumeShareRepository
+	analyticsRepo domain.ResumeShareAnalyticsRepository
+	resumeRepo    domain.ResumeRepository
+	resumeService domain.ResumeService
+	signer        *signedtoken.Signer
+	frontendURL   string
+}
+
+func NewResumeShareService(
+	shareRepo domain.ResumeShareRepository,
+	analyticsRepo domain.ResumeShareAnalyticsRepository,
+	resumeRepo domain.ResumeRepository,
+	resumeService domain.ResumeService,
+	signer *signedtoken.Signer,
+	frontendURL string,
+) domain.ResumeShareService {
+	return &resumeShareService{
+		shareRepo:     shareRepo,
+		analyticsRepo: analyticsRepo,
+		resumeRepo:    resumeRepo,
+		resumeService: resumeService,
+		signer:        signer,
+		frontendURL:   frontendURL,
+	}
+}
+
+// Create issues a read-only review link. Reviewers holding it can read the
+// resume and comment on it, but never change it. Whether personal details
+// are redacted is fixed when the link is created, from the request or else
+// the resume's default.
+func (s *resumeShareService) Create(ctx context.Context, userID, resumeID uuid.UUID, req *domain.CreateResumeShareRequest) (*domain.ResumeShareResponse, error) {
+	resume, err := s.findOwnedResume(ctx, userID, resumeID)
+	if err != nil {
+		return nil, err
+	}
+
+	expiry := defaultShareExpiry
+	if req.ExpiresInHours > 0 {
+		expiry = time.Duration(req.ExpiresInHours) * time.Hour
+	}
+
+	redact := resume.Content.PersonalInfo.RedactPersonal
+	if req.RedactPersonal != nil {
+		redact = *req.RedactPersonal
+	}
+
+	now := time.Now()
+	share := &domain.ResumeShare{
+		ID:             uuid.New(),
+		ResumeID:       resume.ID,
+		UserID:         userID,
+		RedactPersonal: redact,
+		ExpiresAt:      now.Add(expiry),
+		CreatedAt:      now,
+	}
+
+	if err := s.shareRepo.Create(ctx, share); err != nil {
+		return nil, err
+	}
+
+	token := s.signer.Sign(share.ID.String(), share.ExpiresAt)
+
+	return &domain.ResumeShareResponse{
+		Share: share,
+		Token: token,
+		URL:   strings.TrimRight(s.frontendURL, "/") + sharedResumeURLPath + token,
+	}, nil
+}
+
+func (s *resumeShareService) Revoke(ctx context.Context, userID, resumeID, shareID uuid.UUID) error {
+	share, err := s.shareRepo.FindByID(ctx, shareID)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return domain.ErrResumeShareNotFound
+		}
+		return err
+	}
+
+	if share.UserID != userID || share.ResumeID != resumeID {
+		return domain.ErrResumeShareNotFound
+	}
+
+	return s.shareRepo.Revoke(ctx, share.ID, time.Now())
+}
+
+func (s *resumeShareService) GetSharedResume(ctx context.Context, token string, visit *domain.ShareVisit) (*domain.SharedResume, error) {
+	share, err := s.resolveShare(ctx, token)
+	if err != nil {
+		return nil, err
+	}
+
+	resume, err := s.sharedResume(ctx, share)
+	if err != nil {
+		return nil, err
+	}
+
+	comments, err := s.shareRepo.FindCommentsByResumeID(ctx, resume.ID, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	if share.RedactPersonal {
+		resume = redactedResume(resume)
+	}
+	resume.UserID = uuid.Nil
+	s.recordEvent(ctx, share, domain.ShareEventView, visit)
+
+	return &domain.SharedResume{
+		Resume:    resume,
+		ExpiresAt: share.ExpiresAt,
+		Comments:  comments,
+	}, nil
+}
+
+// AddComment attaches a reviewer's comment to the resume as a whole or, when
+// a section is given, to one of the sections the resume renders.
+func (s *resumeShareService) AddComment(ctx context.Context, token string, req *domain.ResumeCommentRequest) (*domain.ResumeComment, error) {
+	share, err := s.resolveShare(ctx, token)
+	if err != nil {
+		return nil, err
+	}
+
+	count, err := s.shareRepo.CountCommentsByShareID(ctx, share.ID)
+	if err != nil {
+		return nil, err
+	}
+	if count >= maxCommentsPerShare {
+		return nil, domain.ErrShareCommentLimit
+	}
+
+	var section *string
+	if req.Section != nil {
+		resume, err := s.sharedResume(ctx, share)
+		if err != nil {
+			return nil, err
+		}
+
+		key := strings.ToLower(strings.TrimSpace(*req.Section))
+		if !hasSection(&resume.Content, key) {
+			return nil, fmt.Errorf("%w: %q", ErrUnknownSection, *req.Section)
+		}
+		section = &key
+	}
+
+	comment := &domain.ResumeComment{
+		ID:           uuid.New(),
+		ResumeID:     share.ResumeID,
+		ShareID:      share.ID,
+		ReviewerName: strings.TrimSpace(req.ReviewerName),
+		Section:      section,
+		Comment:      strings.TrimSpace(req.Comment),
+		CreatedAt:    time.Now(),
+	}
+
+	if err := s.shareRepo.CreateComment(ctx, comment); err != nil {
+		return nil, err
+	}
+
+	return comment, nil
+}
+
+func (s *resumeShareService) GetComments(ctx context.Context, userID, resumeID uuid.UUID, filter *domain.ResumeCommentFilter) ([]domain.ResumeComment, error) {
+	if _, err := s.findOwnedResume(ctx, userID, resumeID); err != nil {
+		return nil, err
+	}
+
+	return s.shareRepo.FindCommentsByResumeID(ctx, resumeID, filter.Resolved)
+}
+
+func (s *resumeShareService) ResolveComment(ctx context.Context, userID, resumeID, commentID uuid.UUID, resolved bool) (*domain.ResumeComment, error) {
+	if _, err := s.findOwnedResume(ctx, userID, resumeID); err != nil {
+		return nil, err
+	}
+
+	comment, err := s.shareRepo.FindCommentByID(ctx, commentID)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, domain.ErrResumeCommentNotFound
+		}
+		return nil, err
+	}
+	if comment.ResumeID != resumeID {
+		return nil, domain.ErrResumeCommentNotFound
+	}
+
+	if resolved == (comment.ResolvedAt != nil) {
+		return comment, nil
+	}
+
+	comment.ResolvedAt = nil
+	if resolved {
+		now := time.Now()
+		comment.ResolvedAt = &now
+	}
+
+	if err := s.shareRepo.SetCommentResolved(ctx, comment.ID, comment.ResolvedAt); err != nil {
+		return nil, err
+	}
+
+	return comment, nil
+}
+
+func (s *resumeShareService) resolveShare(ctx context.Context, token string) (*domain.ResumeShare, error) {
+	subject, err := s.signer.Verify(token)
+	if err != nil {
+		if errors.Is(err, signedtoken.ErrExpiredToken) {
+			return nil, domain.ErrResumeShareExpired
+		}
+		return nil, domain.ErrResumeShareNotFound
+	}
+
+	shareID, err := uuid.Parse(subject)
+	if err != nil {
+		return nil, domain.ErrResumeShareNotFound
+	}
+
+	share, err := s.shareRepo.FindByID(ctx, shareID)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, domain.ErrResumeShareNotFound
+		}
+		return nil, fmt.Errorf("failed to load share: %w", err)
+	}
+
+	if share.RevokedAt != nil || time.Now().After(share.ExpiresAt) {
+		return nil, domain.ErrResumeShareExpired
+	}
+
+	return share, nil
+}
+
+func (s *resumeShareService) sharedResume(ctx context.Context, share *domain.ResumeShare) (*domain.Resume, error) {
+	resume, err := s.resumeRepo.FindByID(ctx, share.ResumeID)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, domain.ErrResumeShareNotFound
+		}
+		return nil, err
+	}
+	return resume, nil
+}
+
+func (s *resumeShareService) findOwnedResume(ctx context.Context, userID, resumeID uuid.UUID) (*domain.Resume, error) {
+	resume, err := s.resumeRepo.FindByID(ctx, resumeID)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, ErrResumeNotFound
+		}
+		return nil, err
+	}
+
+	if resume.UserID != userID {
+		return nil, ErrUnauthorized
+	}
+
+	return resume, nil
+}
+
+func hasSection(content *domain.ResumeContent, key string) bool {
+	for _, section := range resolveSectionOrder(content) {
+		if section == key {
+			return true
+		}
+	}
+	return false
+}
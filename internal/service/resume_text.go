@@ -0,0 +1,134 @@
+package service
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/raflytch/careerly-server/internal/domain"
+)
+
+// renderResumeText serializes a resume into plain text in its render order,
+// mirroring what the PDF export shows, for AI features that need the
+// resume as a document rather than as JSON.
+func renderResumeText(resume *domain.Resume) string {
+	content := &resume.Content
+	var b strings.Builder
+
+	info := content.PersonalInfo
+	b.WriteString(info.FullName + "\n")
+	contact := make([]string, 0, 5)
+	for _, value := range []string{info.Email, info.Phone, info.Location, info.LinkedIn, info.Portfolio} {
+		if strings.TrimSpace(value) != "" {
+			contact = append(contact, value)
+		}
+	}
+	if len(contact) > 0 {
+		b.WriteString(strings.Join(contact, " | ") + "\n")
+	}
+
+	for _, section := range resolveSectionOrder(content) {
+		switch section {
+		case domain.SectionSummary:
+			if content.Summary != "" {
+				writeTextSection(&b, "Summary")
+				b.WriteString(content.Summary + "\n")
+			}
+		case domain.SectionExperience:
+			if len(content.Experience) > 0 {
+				writeTextSection(&b, "Experience")
+				for i, exp := range content.Experience {
+					if i > 0 {
+						b.WriteString("\n")
+					}
+					fmt.Fprintf(&b, "%s, %s (%s)\n", exp.Position, exp.Company, textDateRange(exp.StartDate, exp.EndDate))
+					if exp.Location != "" {
+						b.WriteString(exp.Location + "\n")
+					}
+					if exp.Description != "" {
+						b.WriteString(exp.Description + "\n")
+					}
+				}
+			}
+		case domain.SectionEducation:
+			if len(content.Education) > 0 {
+				writeTextSection(&b, "Education")
+				for _, edu := range content.Education {
+					degree := strings.TrimSpace(edu.Degree + " " + edu.Field)
+					fmt.Fprintf(&b, "%s, %s (%s)\n", degree, edu.Institution, textDateRange(edu.StartDate, edu.EndDate))
+					if edu.GPA != "" {
+						fmt.Fprintf(&b, "GPA: %s\n", edu.GPA)
+					}
+				}
+			}
+		case domain.SectionSkills:
+			if len(content.Skills) > 0 {
+				writeTextSection(&b, "Skills")
+				b.WriteString(strings.Join(content.Skills, ", ") + "\n")
+			}
+		case domain.SectionAchievements:
+			if len(content.Achievements) > 0 {
+				writeTextSection(&b, "Achievements")
+				for _, achievement := range content.Achievements {
+					b.WriteString("- " + achievement + "\n")
+				}
+			}
+		case domain.SectionVolunteer:
+			if len(content.Volunteer) > 0 {
+				writeTextSection(&b, "Volunteer")
+				for _, vol := range content.Volunteer {
+					fmt.Fprintf(&b, "%s, %s (%s)\n", vol.Role, vol.Organization, textDateRange(vol.StartDate, vol.EndDate))
+					if vol.Description != "" {
+						b.WriteString(vol.Description + "\n")
+					}
+				}
+			}
+		case domain.SectionLanguages:
+			if len(content.Languages) > 0 {
+				writeTextSection(&b, "Languages")
+				for _, lang := range content.Languages {
+					fmt.Fprintf(&b, "- %s (%s)\n", lang.Name, lang.Proficiency)
+				}
+			}
+		case domain.SectionHobbies:
+			if len(content.Hobbies) > 0 {
+				writeTextSection(&b, "Hobbies")
+				b.WriteString(strings.Join(content.Hobbies, ", ") + "\n")
+			}
+		default:
+			custom := findCustomSection(content, section)
+			if custom == nil || len(custom.Entries) == 0 {
+				continue
+			}
+			writeTextSection(&b, custom.Title)
+			for _, entry := range custom.Entries {
+				heading := strings.TrimSpace(strings.Join([]string{entry.Heading, entry.Subheading}, " - "))
+				heading = strings.Trim(heading, " -")
+				if entry.Date != "" {
+					heading = strings.TrimSpace(fmt.Sprintf("%s (%s)", heading, entry.Date))
+				}
+				if heading != "" {
+					b.WriteString(heading + "\n")
+				}
+				if entry.Content != "" {
+					b.WriteString(entry.Content + "\n")
+				}
+			}
+		}
+	}
+
+	return strings.TrimSpace(b.String())
+}
+
+func writeTextSection(b *strings.Builder, title string) {
+	fmt.Fprintf(b, "\n%s\n", strings.ToUpper(title))
+}
+
+func textDateRange(start, end string) string {
+	if end == "" {
+		end = "Present"
+	}
+	if start == "" {
+		return end
+	}
+	return start + " - " + end
+}
@@ -0,0 +1,56 @@
+package service
+
+import (
+	"context"
+	"time"
+
+	"github.com/raflytch/careerly-server/internal/domain"
+)
+
+type retentionService struct {
+	atsCheckRepo  domain.ATSCheckRepository
+	interviewRepo domain.InterviewRepository
+	freePlanDays  int
+}
+
+func NewRetentionService(atsCheckRepo domain.ATSCheckRepository, interviewRepo domain.InterviewRepository, freePlanDays int) domain.RetentionService {
+	return &retentionService{
+		atsCheckRepo:  atsCheckRepo,
+		interviewRepo: interviewRepo,
+		freePlanDays:  freePlanDays,
+	}
+}
+
+// Purge permanently deletes the ATS checks and interviews of users without
+// a paid plan once they are older than the free plan's retention window.
+// Paid plans keep their whole history.
+func (s *retentionService) Purge(ctx context.Context) (*domain.RetentionPurgeResult, error) {
+	result := &domain.RetentionPurgeResult{}
+	if s.freePlanDays <= 0 {
+		return result, nil
+	}
+	cutoff := time.Now().AddDate(0, 0, -s.freePlanDays)
+
+	checks, err := s.atsCheckRepo.PurgeFreeBefore(ctx, cutoff)
+	if err != nil {
+		return result, err
+	}
+	result.ATSChecks = checks
+
+	interviews, err := s.interviewRepo.PurgeFreeBefore(ctx, cutoff)
+	if err != nil {
+		return result, err
+	}
+	result.Interviews = interviews
+
+	return result, nil
+}
+
+// historyRetentionDays is how long plan keeps ATS and interview history;
+// zero means forever. Users without a plan get the free window.
+func historyRetentionDays(plan *domain.Plan, freePlanDays int) int {
+	if plan != nil && plan.Price.IsPositive() {
+		return 0
+	}
+	return max(freePlanDays, 0)
+}
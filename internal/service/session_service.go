@@ -0,0 +1,210 @@
+package service
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/raflytch/careerly-server/internal/config"
+	"github.com/raflytch/careerly-server/internal/domain"
+
+	"github.com/google/uuid"
+)
+
+const (
+	sessionCachePrefix     = "session:"
+	sessionTouchInterval   = 5 * time.Minute
+	maxSessionUserAgentLen = 512
+)
+
+type sessionService struct {
+	sessionRepo domain.SessionRepository
+	cacheRepo   domain.CacheRepository
+	ttl         time.Duration
+}
+
+func NewSessionService(sessionRepo domain.SessionRepository, cacheRepo domain.CacheRepository, cfg config.JWTConfig) domain.SessionService {
+	return &sessionService{
+		sessionRepo: sessionRepo,
+		cacheRepo:   cacheRepo,
+		ttl:         time.Duration(cfg.ExpiryHours) * time.Hour,
+	}
+}
+
+func (s *sessionService) Create(ctx context.Context, userID uuid.UUID, client domain.ClientInfo) (*domain.Session, error) {
+	return s.create(ctx, userID, nil, client, s.ttl)
+}
+
+// CreateImpersonation opens a short-lived session on the user's account for
+// an admin. It shows up in the user's session list and can be revoked there.
+func (s *sessionService) CreateImpersonation(ctx context.Context, userID, impersonatorID uuid.UUID, client domain.ClientInfo, ttl time.Duration) (*domain.Session, error) {
+	return s.create(ctx, userID, &impersonatorID, client, ttl)
+}
+
+func (s *sessionService) create(ctx context.Context, userID uuid.UUID, impersonatorID *uuid.UUID, client domain.ClientInfo, ttl time.Duration) (*domain.Session, error) {
+	userAgent := client.UserAgent
+	if len(userAgent) > maxSessionUserAgentLen {
+		userAgent = userAgent[:maxSessionUserAgentLen]
+	}
+
+	now := time.Now()
+	device := describeDevice(userAgent)
+	if impersonatorID != nil {
+		device = "Support impersonation (" + device + ")"
+	}
+
+	session := &domain.Session{
+		ID:             uuid.New(),
+		UserID:         userID,
+		Device:         device,
+		IPAddress:      client.IPAddress,
+		UserAgent:      userAgent,
+		CreatedAt:      now,
+		LastSeenAt:     now,
+		ExpiresAt:      now.Add(ttl),
+		ImpersonatorID: impersonatorID,
+	}
+
+	if err := s.sessionRepo.Create(ctx, session); err != nil {
+		return nil, fmt.Errorf("failed to create session: %w", err)
+	}
+
+	s.cacheSession(ctx, session)
+
+	return session, nil
+}
+
+func (s *sessionService) Validate(ctx context.Context, userID, sessionID uuid.UUID) error {
+	if sessionID == uuid.Nil {
+		return domain.ErrSessionRevoked
+	}
+
+	cacheKey := fmt.Sprintf("%s%s", sessionCachePrefix, sessionID.String())
+
+	var session *domain.Session
+	cached, err := s.cacheRepo.Get(ctx, cacheKey)
+	if err == nil && cached != "" {
+		var cachedSession domain.Session
+		if err := json.Unmarshal([]byte(cached), &cachedSession); err == nil {
+			session = &cachedSession
+		}
+	}
+
+	if session == nil {
+		session, err = s.sessionRepo.FindByID(ctx, sessionID)
+		if err != nil {
+			if errors.Is(err, sql.ErrNoRows) {
+				return domain.ErrSessionRevoked
+			}
+			return err
+		}
+	}
+
+	now := time.Now()
+	if session.UserID != userID || session.RevokedAt != nil || now.After(session.ExpiresAt) {
+		_ = s.cacheRepo.Delete(ctx, cacheKey)
+		return domain.ErrSessionRevoked
+	}
+
+	if now.Sub(session.LastSeenAt) >= sessionTouchInterval {
+		if err := s.sessionRepo.UpdateLastSeen(ctx, session.ID, now); err == nil {
+			session.LastSeenAt = now
+		}
+	}
+
+	s.cacheSession(ctx, session)
+
+	return nil
+}
+
+func (s *sessionService) GetActiveByUserID(ctx context.Context, userID, currentSessionID uuid.UUID) ([]domain.Session, error) {
+	sessions, err := s.sessionRepo.FindActiveByUserID(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	for i := range sessions {
+		sessions[i].Current = sessions[i].ID == currentSessionID
+	}
+
+	return sessions, nil
+}
+
+func (s *sessionService) Revoke(ctx context.Context, userID, sessionID uuid.UUID) error {
+	session, err := s.sessionRepo.FindByID(ctx, sessionID)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return domain.ErrSessionNotFound
+		}
+		return err
+	}
+
+	if session.UserID != userID {
+		return domain.ErrSessionNotFound
+	}
+
+	if err := s.sessionRepo.Revoke(ctx, sessionID); err != nil {
+		return err
+	}
+
+	cacheKey := fmt.Sprintf("%s%s", sessionCachePrefix, sessionID.String())
+	_ = s.cacheRepo.Delete(ctx, cacheKey)
+
+	return nil
+}
+
+func (s *sessionService) cacheSession(ctx context.Context, session *domain.Session) {
+	ttl := time.Until(session.ExpiresAt)
+	if ttl <= 0 {
+		return
+	}
+	cacheKey := fmt.Sprintf("%s%s", sessionCachePrefix, session.ID.String())
+	_ = s.cacheRepo.Set(ctx, cacheKey, session, ttl)
+}
+
+// describeDevice turns a user agent into a short label such as
+// "Chrome on Windows" for the session list.
+func describeDevice(userAgent string) string {
+	ua := strings.ToLower(userAgent)
+	if ua == "" {
+		return "Unknown device"
+	}
+
+	browser := "Unknown browser"
+	switch {
+	case strings.Contains(ua, "edg/"):
+		browser = "Edge"
+	case strings.Contains(ua, "opr/") || strings.Contains(ua, "opera"):
+		browser = "Opera"
+	case strings.Contains(ua, "firefox/"):
+		browser = "Firefox"
+	case strings.Contains(ua, "chrome/"):
+		browser = "Chrome"
+	case strings.Contains(ua, "safari/"):
+		browser = "Safari"
+	case strings.Contains(ua, "curl/"):
+		browser = "curl"
+	case strings.Contains(ua, "postman"):
+		browser = "Postman"
+	}
+
+	platform := "Unknown OS"
+	switch {
+	case strings.Contains(ua, "android"):
+		platform = "Android"
+	case strings.Contains(ua, "iphone") || strings.Contains(ua, "ipad"):
+		platform = "iOS"
+	case strings.Contains(ua, "windows"):
+		platform = "Windows"
+	case strings.Contains(ua, "mac os"):
+		platform = "macOS"
+	case strings.Contains(ua, "linux"):
+		platform = "Linux"
+	}
+
+	return fmt.Sprintf("%s on %s", browser, platform)
+}
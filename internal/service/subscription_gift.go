@@ -0,0 +1,116 @@
+package service
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/raflytch/careerly-server/internal/domain"
+
+	"github.com/google/uuid"
+)
+
+var (
+	ErrGiftCodeInvalid            = errors.New("invalid gift code")
+	ErrGiftAlreadyRedeemed        = errors.New("gift code has already been redeemed")
+	ErrGiftPaidSubscriptionActive = errors.New("your current paid subscription must end before a gift can be redeemed")
+)
+
+// RedeemGift activates a gifted plan on the caller's account, which need not
+// be the buyer's or the one the code was emailed to. A free subscription is
+// replaced; a paid one is refused so its remaining time is not lost.
+func (s *subscriptionService) RedeemGift(ctx context.Context, userID uuid.UUID, req *domain.RedeemGiftRequest) (*domain.Subscription, error) {
+	gift, err := s.giftRepo.FindByCode(ctx, strings.ToUpper(strings.TrimSpace(req.Code)))
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, ErrGiftCodeInvalid
+		}
+		return nil, fmt.Errorf("failed to fetch gift: %w", err)
+	}
+	if gift.RedeemedAt != nil {
+		return nil, ErrGiftAlreadyRedeemed
+	}
+
+	plan, err := s.planRepo.FindByID(ctx, gift.PlanID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch plan: %w", err)
+	}
+
+	existing, err := s.subscriptionRepo.FindActiveByUserID(ctx, userID)
+	if err != nil && !errors.Is(err, sql.ErrNoRows) {
+		return nil, fmt.Errorf("failed to fetch subscription: %w", err)
+	}
+	if existing != nil && existing.Plan != nil && !existing.Plan.Price.IsZero() {
+		return nil, ErrGiftPaidSubscriptionActive
+	}
+
+	now := time.Now()
+	subscriptionID := uuid.New()
+	claimed, err := s.giftRepo.Redeem(ctx, gift.ID, userID, subscriptionID, now)
+	if err != nil {
+		return nil, fmt.Errorf("failed to redeem gift: %w", err)
+	}
+	if !claimed {
+		return nil, ErrGiftAlreadyRedeemed
+	}
+
+	durationDays := 30
+	if plan.DurationDays != nil {
+		durationDays = *plan.DurationDays
+	}
+
+	loc := time.UTC
+	if user, err := s.userRepo.FindByID(ctx, userID); err == nil {
+		loc = userLocation(user)
+	}
+
+	subscription := &domain.Subscription{
+		ID:        subscriptionID,
+		UserID:    userID,
+		PlanID:    plan.ID,
+		StartDate: now,
+		EndDate:   subscriptionEndDate(now, durationDays, loc),
+		Status:    domain.SubscriptionStatusActive,
+		CreatedAt: now,
+	}
+	var replaces *uuid.UUID
+	if existing != nil {
+		replaces = &existing.ID
+	}
+	if err := s.subscriptionRepo.Grant(ctx, subscription, replaces, nil); err != nil {
+		if releaseErr := s.giftRepo.Unredeem(ctx, gift.ID); releaseErr != nil {
+			return nil, errors.Join(err, releaseErr)
+		}
+		return nil, fmt.Errorf("failed to create subscription: %w", err)
+	}
+
+	s.webhooks.Publish(ctx, domain.WebhookEventSubscriptionActivated, subscription)
+
+	subscription.Plan = plan
+	localizeSubscription(subscription, loc)
+	return subscription, nil
+}
+
+// GetPurchasedGifts lists the codes issued for the caller's paid gift
+// orders, so a buyer can pass on a code whose email went astray.
+func (s *subscriptionService) GetPurchasedGifts(ctx context.Context, userID uuid.UUID) ([]domain.Gift, error) {
+	gifts, err := s.giftRepo.FindByPurchaserID(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	plans := make(map[uuid.UUID]*domain.Plan)
+	for i := range gifts {
+		plan, ok := plans[gifts[i].PlanID]
+		if !ok {
+			plan, _ = s.planRepo.FindByID(ctx, gifts[i].PlanID)
+			plans[gifts[i].PlanID] = plan
+		}
+		gifts[i].Plan = plan
+	}
+
+	return gifts, nil
+}
@@ -0,0 +1,116 @@
+package service
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/raflytch/careerly-server/internal/domain"
+
+	"github.com/google/uuid"
+	"github.com/shopspring/decimal"
+)
+
+const manualOrderPrefix = "CAREERLY-MANUAL-"
+
+func isManualTransaction(transaction *domain.Transaction) bool {
+	return transaction.PaymentType != nil && *transaction.PaymentType == domain.PaymentTypeManual
+}
+
+// Grant activates a plan for a user without payment, for sales and support.
+// It replaces the active subscription like a purchase does and is recorded
+// as a zero-amount manual transaction, so it shows up in the user's billing
+// history. Referral rewards are not triggered.
+func (s *subscriptionService) Grant(ctx context.Context, adminID, userID uuid.UUID, req *domain.GrantSubscriptionRequest) (*domain.SubscriptionGrant, error) {
+	user, err := s.userRepo.FindByID(ctx, userID)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, domain.ErrUserNotFound
+		}
+		return nil, fmt.Errorf("failed to fetch user: %w", err)
+	}
+
+	plan, err := s.planRepo.FindByID(ctx, req.PlanID)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, ErrPlanNotAvailable
+		}
+		return nil, fmt.Errorf("failed to fetch plan: %w", err)
+	}
+
+	durationDays := req.DurationDays
+	if durationDays == 0 {
+		durationDays = 30
+		if plan.DurationDays != nil {
+			durationDays = *plan.DurationDays
+		}
+	}
+
+	existing, err := s.subscriptionRepo.FindActiveByUserID(ctx, userID)
+	if err != nil && !errors.Is(err, sql.ErrNoRows) {
+		return nil, fmt.Errorf("failed to fetch subscription: %w", err)
+	}
+
+	now := time.Now()
+	loc := userLocation(user)
+	subscription := &domain.Subscription{
+		ID:        uuid.New(),
+		UserID:    userID,
+		PlanID:    plan.ID,
+		StartDate: now,
+		EndDate:   subscriptionEndDate(now, durationDays, loc),
+		Status:    domain.SubscriptionStatusActive,
+		CreatedAt: now,
+	}
+
+	var before interface{}
+	var replaces *uuid.UUID
+	if existing != nil {
+		before = *existing
+		replaces = &existing.ID
+	}
+
+	orderID := fmt.Sprintf("%s%s-%s-%d",
+		manualOrderPrefix,
+		plan.ID.String()[:8],
+		userID.String()[:8],
+		now.UnixMilli(),
+	)
+
+	paymentType := domain.PaymentTypeManual
+	transaction := &domain.Transaction{
+		ID:             uuid.New(),
+		UserID:         userID,
+		PlanID:         plan.ID,
+		SubscriptionID: &subscription.ID,
+		OrderID:        orderID,
+		GrossAmount:    decimal.Zero,
+		PaymentType:    &paymentType,
+		Status:         domain.TransactionStatusSuccess,
+		PaidAt:         &now,
+		CreatedAt:      now,
+		UpdatedAt:      now,
+	}
+	if err := s.subscriptionRepo.Grant(ctx, subscription, replaces, transaction); err != nil {
+		return nil, fmt.Errorf("failed to grant subscription: %w", err)
+	}
+
+	s.webhooks.Publish(ctx, domain.WebhookEventSubscriptionActivated, subscription)
+
+	subscription.Plan = plan
+	transaction.Plan = plan
+	localizeSubscription(subscription, loc)
+
+	grant := &domain.SubscriptionGrant{
+		Subscription: subscription,
+		Transaction:  transaction,
+		Reason:       strings.TrimSpace(req.Reason),
+		GrantedBy:    adminID,
+	}
+	s.auditService.Record(ctx, domain.AuditActionSubscriptionGrant, domain.AuditTargetSubscription, subscription.ID, before, grant)
+
+	return grant, nil
+}
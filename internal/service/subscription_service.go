@@ -0,0 +1,199 @@
+package service
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"log"
+	"time"
+
+	"github.com/raflytch/careerly-server/internal/domain"
+
+	"github.com/google/uuid"
+)
+
+const rolloverBatchSize = 100
+
+var (
+	ErrPlanChangeNotDowngrade = errors.New("only free plans can be scheduled, paid plans must be purchased")
+	ErrPlanChangeSamePlan     = errors.New("subscription is already on this plan")
+	ErrNoScheduledPlanChange  = errors.New("no plan change is scheduled")
+)
+
+type subscriptionService struct {
+	subscriptionRepo domain.SubscriptionRepository
+	planRepo         domain.PlanRepository
+	giftRepo         domain.GiftRepository
+	userRepo         domain.UserRepository
+	webhooks         domain.WebhookPublisher
+	auditService     domain.AuditService
+}
+
+func NewSubscriptionService(
+	subscriptionRepo domain.SubscriptionRepository,
+	planRepo domain.PlanRepository,
+	giftRepo domain.GiftRepository,
+	userRepo domain.UserRepository,
+	webhooks domain.WebhookPublisher,
+	auditService domain.AuditService,
+) domain.SubscriptionService {
+	return &subscriptionService{
+		subscriptionRepo: subscriptionRepo,
+		planRepo:         planRepo,
+		giftRepo:         giftRepo,
+		userRepo:         userRepo,
+		webhooks:         webhooks,
+		auditService:     auditService,
+	}
+}
+
+// ScheduleChange switches the user's active subscription to another plan
+// once the current period ends. There is no recurring billing, so only free
+// plans can be scheduled; moving to a paid plan goes through a purchase.
+func (s *subscriptionService) ScheduleChange(ctx context.Context, userID uuid.UUID, req *domain.ScheduleChangeRequest) (*domain.Subscription, error) {
+	subscription, err := s.activeSubscription(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	if subscription.PlanID == req.PlanID {
+		return nil, ErrPlanChangeSamePlan
+	}
+
+	plan, err := s.planRepo.FindByID(ctx, req.PlanID)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, ErrPlanNotAvailable
+		}
+		return nil, fmt.Errorf("failed to fetch plan: %w", err)
+	}
+	if !plan.IsActive || plan.ArchivedAt != nil {
+		return nil, ErrPlanNotAvailable
+	}
+	if !plan.Price.IsZero() {
+		return nil, ErrPlanChangeNotDowngrade
+	}
+
+	subscription.ScheduledPlanID = &plan.ID
+	if err := s.subscriptionRepo.Update(ctx, subscription); err != nil {
+		return nil, fmt.Errorf("failed to schedule plan change: %w", err)
+	}
+
+	subscription.ScheduledPlan = plan
+	return s.localize(ctx, userID, subscription), nil
+}
+
+func (s *subscriptionService) CancelScheduledChange(ctx context.Context, userID uuid.UUID) (*domain.Subscription, error) {
+	subscription, err := s.activeSubscription(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	if subscription.ScheduledPlanID == nil {
+		return nil, ErrNoScheduledPlanChange
+	}
+
+	subscription.ScheduledPlanID = nil
+	if err := s.subscriptionRepo.Update(ctx, subscription); err != nil {
+		return nil, fmt.Errorf("failed to cancel plan change: %w", err)
+	}
+
+	return s.localize(ctx, userID, subscription), nil
+}
+
+// Rollover expires active subscriptions whose period has ended and starts
+// the scheduled plan, if any, from the moment the old period ended.
+func (s *subscriptionService) Rollover(ctx context.Context) (*domain.SubscriptionRolloverResult, error) {
+	result := &domain.SubscriptionRolloverResult{}
+
+	subscriptions, err := s.subscriptionRepo.FindEndedActive(ctx, time.Now(), rolloverBatchSize)
+	if err != nil {
+		return result, fmt.Errorf("failed to fetch ended subscriptions: %w", err)
+	}
+
+	for i := range subscriptions {
+		if err := ctx.Err(); err != nil {
+			return result, err
+		}
+
+		subscription := &subscriptions[i]
+		expired, err := s.subscriptionRepo.Expire(ctx, subscription.ID)
+		if err != nil {
+			log.Printf("Failed to expire subscription %s: %v", subscription.ID, err)
+			result.Failed++
+			continue
+		}
+		if !expired {
+			continue
+		}
+		result.Expired++
+
+		if subscription.ScheduledPlanID == nil {
+			continue
+		}
+		if err := s.startScheduledPlan(ctx, subscription); err != nil {
+			log.Printf("Failed to start scheduled plan for subscription %s: %v", subscription.ID, err)
+			result.Failed++
+			continue
+		}
+		result.Switched++
+	}
+
+	return result, nil
+}
+
+func (s *subscriptionService) startScheduledPlan(ctx context.Context, previous *domain.Subscription) error {
+	plan, err := s.planRepo.FindByID(ctx, *previous.ScheduledPlanID)
+	if err != nil {
+		return err
+	}
+	if !plan.IsActive {
+		return ErrPlanNotAvailable
+	}
+
+	durationDays := 30
+	if plan.DurationDays != nil {
+		durationDays = *plan.DurationDays
+	}
+
+	loc := time.UTC
+	if user, err := s.userRepo.FindByID(ctx, previous.UserID); err == nil {
+		loc = userLocation(user)
+	}
+
+	start := previous.EndDate
+	subscription := &domain.Subscription{
+		ID:        uuid.New(),
+		UserID:    previous.UserID,
+		PlanID:    plan.ID,
+		StartDate: start,
+		EndDate:   subscriptionEndDate(start, durationDays, loc),
+		Status:    domain.SubscriptionStatusActive,
+		CreatedAt: time.Now(),
+	}
+	if err := s.subscriptionRepo.Create(ctx, subscription); err != nil {
+		return err
+	}
+
+	s.webhooks.Publish(ctx, domain.WebhookEventSubscriptionActivated, subscription)
+	return nil
+}
+
+func (s *subscriptionService) activeSubscription(ctx context.Context, userID uuid.UUID) (*domain.Subscription, error) {
+	subscription, err := s.subscriptionRepo.FindActiveByUserID(ctx, userID)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, ErrNoActiveSubscription
+		}
+		return nil, fmt.Errorf("failed to fetch subscription: %w", err)
+	}
+	return subscription, nil
+}
+
+func (s *subscriptionService) localize(ctx context.Context, userID uuid.UUID, subscription *domain.Subscription) *domain.Subscription {
+	if user, err := s.userRepo.FindByID(ctx, userID); err == nil {
+		localizeSubscription(subscription, userLocation(user))
+	}
+	return subscription
+}
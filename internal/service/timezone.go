@@ -0,0 +1,56 @@
+package service
+
+import (
+	"time"
+
+	"github.com/raflytch/careerly-server/internal/domain"
+)
+
+func userLocation(user *domain.User) *time.Location {
+	if user == nil || user.Timezone == "" {
+		return time.UTC
+	}
+	loc, err := time.LoadLocation(user.Timezone)
+	if err != nil {
+		return time.UTC
+	}
+	return loc
+}
+
+// usagePeriodMonth returns the usage period label for the month that is
+// current in loc. The label is stored as a UTC date so existing rows keep
+// matching; only the boundary moves with the user's timezone.
+func usagePeriodMonth(now time.Time, loc *time.Location) time.Time {
+	local := now.In(loc)
+	return time.Date(local.Year(), local.Month(), 1, 0, 0, 0, 0, time.UTC)
+}
+
+func usagePeriodBounds(now time.Time, loc *time.Location) (time.Time, time.Time) {
+	local := now.In(loc)
+	start := time.Date(local.Year(), local.Month(), 1, 0, 0, 0, 0, loc)
+	return start, start.AddDate(0, 1, 0)
+}
+
+// subscriptionEndDate runs a subscription until midnight in the user's
+// timezone after durationDays, so it never lapses mid-day for the user.
+func subscriptionEndDate(start time.Time, durationDays int, loc *time.Location) time.Time {
+	local := start.In(loc).AddDate(0, 0, durationDays)
+	return time.Date(local.Year(), local.Month(), local.Day()+1, 0, 0, 0, 0, loc)
+}
+
+func localizeSubscription(subscription *domain.Subscription, loc *time.Location) {
+	if subscription == nil {
+		return
+	}
+	subscription.StartDate = subscription.StartDate.In(loc)
+	subscription.EndDate = subscription.EndDate.In(loc)
+	subscription.CreatedAt = subscription.CreatedAt.In(loc)
+}
+
+func localizeUser(user *domain.User, loc *time.Location) {
+	user.CreatedAt = user.CreatedAt.In(loc)
+	if user.LastLoginAt != nil {
+		lastLogin := user.LastLoginAt.In(loc)
+		user.LastLoginAt = &lastLogin
+	}
+}
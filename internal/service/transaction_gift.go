@@ -0,0 +1,161 @@
+package service
+
+import (
+	"context"
+	"crypto/rand"
+	"database/sql"
+	"errors"
+	"fmt"
+	"log"
+	"math/big"
+	"strings"
+	"time"
+
+	"github.com/raflytch/careerly-server/internal/domain"
+
+	"github.com/google/uuid"
+	"github.com/shopspring/decimal"
+)
+
+const (
+	giftOrderPrefix  = "CAREERLY-GIFT-"
+	giftCodeAlphabet = "ABCDEFGHJKLMNPQRSTUVWXYZ23456789"
+	giftCodeLength   = 12
+)
+
+func isGiftOrder(orderID string) bool {
+	return strings.HasPrefix(orderID, giftOrderPrefix)
+}
+
+// CreateGiftTransaction starts the purchase of a plan for other people, one
+// seat per recipient email. The buyer's own subscription is untouched; each
+// recipient gets a redemption code by email once the payment succeeds.
+func (s *transactionService) CreateGiftTransaction(ctx context.Context, userID uuid.UUID, req *domain.CreateGiftTransactionRequest) (*domain.TransactionResponse, error) {
+	plan, err := s.planRepo.FindByID(ctx, req.PlanID)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, ErrPlanNotAvailable
+		}
+		return nil, fmt.Errorf("failed to fetch plan: %w", err)
+	}
+
+	if !plan.IsActive || plan.ArchivedAt != nil || plan.Price.IsZero() {
+		return nil, ErrPlanNotAvailable
+	}
+
+	user, err := s.userRepo.FindByID(ctx, userID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch user: %w", err)
+	}
+
+	orderID := fmt.Sprintf("%s%s-%s-%d",
+		giftOrderPrefix,
+		plan.ID.String()[:8],
+		userID.String()[:8],
+		time.Now().UnixMilli(),
+	)
+
+	seats := int32(len(req.RecipientEmails))
+	snapResp, err := s.createSnap(orderID, plan.ID, plan.DisplayName, plan.Price, seats, user, false)
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	expiryTime := now.Add(defaultTransactionExpiry)
+
+	transaction := &domain.Transaction{
+		ID:          uuid.New(),
+		UserID:      userID,
+		PlanID:      plan.ID,
+		OrderID:     orderID,
+		GrossAmount: plan.Price.Mul(decimal.NewFromInt(int64(seats))),
+		Status:      domain.TransactionStatusPending,
+		SnapToken:   &snapResp.Token,
+		RedirectURL: &snapResp.RedirectURL,
+		ExpiredAt:   &expiryTime,
+		CreatedAt:   now,
+		UpdatedAt:   now,
+	}
+
+	gifts := make([]domain.Gift, 0, len(req.RecipientEmails))
+	for _, email := range req.RecipientEmails {
+		gifts = append(gifts, domain.Gift{
+			ID:             uuid.New(),
+			TransactionID:  transaction.ID,
+			PurchaserID:    userID,
+			PlanID:         plan.ID,
+			RecipientEmail: strings.ToLower(strings.TrimSpace(email)),
+			CreatedAt:      now,
+		})
+	}
+	if err := s.transactionRepo.CreateWithGifts(ctx, transaction, gifts); err != nil {
+		return nil, fmt.Errorf("failed to create transaction record: %w", err)
+	}
+
+	transaction.Plan = plan
+	transaction.Gifts = gifts
+
+	return &domain.TransactionResponse{
+		Transaction: transaction,
+		SnapToken:   snapResp.Token,
+		RedirectURL: snapResp.RedirectURL,
+	}, nil
+}
+
+// issueGifts gives every recipient of a paid gift order a redemption code
+// and emails it. Gifts that already have a code are skipped, so a retried
+// provisioning job only finishes what is left. A failed email is logged;
+// the buyer can still see the code in their gift list.
+func (s *transactionService) issueGifts(ctx context.Context, transaction *domain.Transaction) error {
+	gifts, err := s.giftRepo.FindByTransactionID(ctx, transaction.ID)
+	if err != nil {
+		return err
+	}
+
+	plan, err := s.planRepo.FindByID(ctx, transaction.PlanID)
+	if err != nil {
+		return err
+	}
+
+	senderName := "Someone"
+	if user, err := s.userRepo.FindByID(ctx, transaction.UserID); err == nil {
+		senderName = user.Name
+	}
+
+	for _, gift := range gifts {
+		if gift.Code != nil {
+			continue
+		}
+
+		code, err := generateGiftCode()
+		if err != nil {
+			return err
+		}
+		issued, err := s.giftRepo.Issue(ctx, gift.ID, code, time.Now())
+		if err != nil {
+			return err
+		}
+		if !issued {
+			continue
+		}
+
+		if err := s.emailService.SendGiftCode(ctx, gift.RecipientEmail, senderName, plan.DisplayName, code); err != nil {
+			log.Printf("Failed to email gift code for order %s to %s: %v", transaction.OrderID, gift.RecipientEmail, err)
+		}
+	}
+
+	return nil
+}
+
+func generateGiftCode() (string, error) {
+	code := make([]byte, giftCodeLength)
+	for i := range code {
+		n, err := rand.Int(rand.Reader, big.NewInt(int64(len(giftCodeAlphabet))))
+		if err != nil {
+			return "", err
+		}
+		code[i] = giftCodeAlphabet[n.Int64()]
+	}
+	return string(code), nil
+}
@@ -0,0 +1,129 @@
+package service
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/raflytch/careerly-server/internal/domain"
+
+	"github.com/google/uuid"
+	"github.com/shopspring/decimal"
+)
+
+const organizationOrderPrefix = "CAREERLY-ORG-"
+
+func isOrganizationOrder(orderID string) bool {
+	return strings.HasPrefix(orderID, organizationOrderPrefix)
+}
+
+// CreateSeatTransaction starts the owner's purchase of a plan for the whole
+// organization, charged per seat. Once paid, the organization moves onto
+// the plan with the seats bought for a new period.
+func (s *transactionService) CreateSeatTransaction(ctx context.Context, userID uuid.UUID, req *domain.CreateSeatTransactionRequest) (*domain.TransactionResponse, error) {
+	membership, org, err := findOrganizationMembership(ctx, s.orgRepo, userID)
+	if err != nil {
+		return nil, err
+	}
+	if membership.Role != domain.OrganizationRoleOwner {
+		return nil, ErrOrganizationOwnerOnly
+	}
+
+	members, err := s.orgRepo.CountMembers(ctx, org.ID)
+	if err != nil {
+		return nil, err
+	}
+	if req.Seats < members {
+		return nil, ErrOrganizationSeatsTooFew
+	}
+
+	plan, err := s.planRepo.FindByID(ctx, req.PlanID)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, ErrPlanNotAvailable
+		}
+		return nil, fmt.Errorf("failed to fetch plan: %w", err)
+	}
+
+	if !plan.IsActive || plan.ArchivedAt != nil || plan.Price.IsZero() {
+		return nil, ErrPlanNotAvailable
+	}
+
+	user, err := s.userRepo.FindByID(ctx, userID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch user: %w", err)
+	}
+
+	orderID := fmt.Sprintf("%s%s-%s-%d",
+		organizationOrderPrefix,
+		plan.ID.String()[:8],
+		org.ID.String()[:8],
+		time.Now().UnixMilli(),
+	)
+
+	snapResp, err := s.createSnap(orderID, plan.ID, plan.DisplayName, plan.Price, int32(req.Seats), user, false)
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	expiryTime := now.Add(defaultTransactionExpiry)
+
+	transaction := &domain.Transaction{
+		ID:          uuid.New(),
+		UserID:      userID,
+		PlanID:      plan.ID,
+		OrderID:     orderID,
+		GrossAmount: plan.Price.Mul(decimal.NewFromInt(int64(req.Seats))),
+		Status:      domain.TransactionStatusPending,
+		SnapToken:   &snapResp.Token,
+		RedirectURL: &snapResp.RedirectURL,
+		ExpiredAt:   &expiryTime,
+		CreatedAt:   now,
+		UpdatedAt:   now,
+	}
+
+	if err := s.transactionRepo.Create(ctx, transaction); err != nil {
+		return nil, fmt.Errorf("failed to create transaction record: %w", err)
+	}
+
+	purchase := &domain.OrganizationPurchase{
+		TransactionID:  transaction.ID,
+		OrganizationID: org.ID,
+		PlanID:         plan.ID,
+		Seats:          req.Seats,
+		CreatedAt:      now,
+	}
+	if err := s.orgRepo.CreatePurchase(ctx, purchase); err != nil {
+		return nil, fmt.Errorf("failed to record seat purchase: %w", err)
+	}
+
+	transaction.Plan = plan
+
+	return &domain.TransactionResponse{
+		Transaction: transaction,
+		SnapToken:   snapResp.Token,
+		RedirectURL: snapResp.RedirectURL,
+	}, nil
+}
+
+// applySeatPurchase starts the organization's new period on the purchased
+// plan. Applying is keyed by transaction, so repeating it is harmless.
+func (s *transactionService) applySeatPurchase(ctx context.Context, transaction *domain.Transaction) error {
+	plan, err := s.planRepo.FindByID(ctx, transaction.PlanID)
+	if err != nil {
+		return err
+	}
+
+	durationDays := 30
+	if plan.DurationDays != nil {
+		durationDays = *plan.DurationDays
+	}
+
+	now := time.Now()
+	_, err = s.orgRepo.ApplyPurchase(ctx, transaction.ID, now, subscriptionEndDate(now, durationDays, time.UTC))
+	return err
+}
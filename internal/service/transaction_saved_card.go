@@ -0,0 +1,150 @@
+package service
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"log"
+	"time"
+
+	"github.com/raflytch/careerly-server/internal/domain"
+	"github.com/raflytch/careerly-server/pkg/midtrans"
+
+	"github.com/google/uuid"
+)
+
+var (
+	ErrPaymentMethodNotFound = errors.New("payment method not found")
+	ErrPaymentMethodExpired  = errors.New("saved card has expired, please pay with the card again to save it")
+	ErrSavedCardDeclined     = errors.New("the saved card was declined")
+)
+
+// ChargeSavedCard buys a plan with a card saved on an earlier payment,
+// charging it directly through the Core API instead of opening Snap. The
+// charge usually settles at once and the subscription starts right away;
+// when the bank asks for 3D Secure the transaction stays pending and the
+// response carries the page to send the user to.
+func (s *transactionService) ChargeSavedCard(ctx context.Context, userID uuid.UUID, req *domain.ChargeSavedCardRequest) (*domain.TransactionResponse, error) {
+	method, err := s.paymentMethodRepo.FindByID(ctx, req.PaymentMethodID)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, ErrPaymentMethodNotFound
+		}
+		return nil, fmt.Errorf("failed to fetch payment method: %w", err)
+	}
+	if method.UserID != userID {
+		return nil, ErrPaymentMethodNotFound
+	}
+	if method.TokenExpiresAt != nil && time.Now().After(*method.TokenExpiresAt) {
+		return nil, ErrPaymentMethodExpired
+	}
+
+	plan, err := s.purchasablePlan(ctx, userID, req.PlanID)
+	if err != nil {
+		return nil, err
+	}
+
+	user, err := s.userRepo.FindByID(ctx, userID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch user: %w", err)
+	}
+
+	orderID := planOrderID(plan.ID, userID)
+	unitPrice := plan.Price.IntPart()
+
+	charge, err := s.paymentGateway.ChargeCard(midtrans.ChargeCardRequest{
+		OrderID:         orderID,
+		GrossAmount:     unitPrice,
+		ItemDetails:     gatewayItems(plan.ID, plan.DisplayName, unitPrice, 1),
+		CustomerDetails: gatewayCustomer(user),
+		SavedTokenID:    method.Token,
+	})
+	if err != nil {
+		if errors.Is(err, midtrans.ErrUnavailable) {
+			return nil, ErrPaymentGatewayDown
+		}
+		return nil, fmt.Errorf("failed to charge saved card: %w", err)
+	}
+
+	now := time.Now()
+	expiryTime := now.Add(defaultTransactionExpiry)
+
+	transaction := &domain.Transaction{
+		ID:                uuid.New(),
+		UserID:            userID,
+		PlanID:            plan.ID,
+		OrderID:           orderID,
+		TransactionID:     &charge.TransactionID,
+		GrossAmount:       plan.Price,
+		PaymentType:       &charge.PaymentType,
+		Status:            s.mapMidtransStatus(charge.TransactionStatus, charge.FraudStatus),
+		TransactionStatus: &charge.TransactionStatus,
+		FraudStatus:       &charge.FraudStatus,
+		ExpiredAt:         &expiryTime,
+		CreatedAt:         now,
+		UpdatedAt:         now,
+	}
+	if charge.RedirectURL != "" {
+		transaction.RedirectURL = &charge.RedirectURL
+	}
+
+	if err := s.transactionRepo.Create(ctx, transaction); err != nil {
+		return nil, fmt.Errorf("failed to create transaction record: %w", err)
+	}
+
+	if err := s.paymentMethodRepo.MarkUsed(ctx, method.ID, now); err != nil {
+		log.Printf("Failed to mark payment method %s used: %v", method.ID, err)
+	}
+
+	if transaction.Status == domain.TransactionStatusFailed {
+		return nil, ErrSavedCardDeclined
+	}
+
+	if transaction.Status == domain.TransactionStatusSuccess {
+		transaction.PaidAt = &now
+		s.provisionOrEnqueue(ctx, transaction)
+
+		if err := s.transactionRepo.Update(ctx, transaction); err != nil {
+			return nil, fmt.Errorf("failed to update transaction: %w", err)
+		}
+	}
+
+	transaction.Plan = plan
+
+	return &domain.TransactionResponse{
+		Transaction: transaction,
+		RedirectURL: charge.RedirectURL,
+	}, nil
+}
+
+// savePaymentMethod keeps the card of a successful payment when the status
+// Midtrans reports carries a saved token, which Midtrans only issues when
+// the user agreed to save the card on the payment page. The notification
+// body is not trusted for this since its signature does not cover the card.
+func (s *transactionService) savePaymentMethod(ctx context.Context, transaction *domain.Transaction, status *midtrans.TransactionStatusResponse) {
+	if status.PaymentType != "credit_card" || status.SavedTokenID == "" || status.MaskedCard == "" {
+		return
+	}
+
+	now := time.Now()
+	method := &domain.PaymentMethod{
+		ID:         uuid.New(),
+		UserID:     transaction.UserID,
+		Token:      status.SavedTokenID,
+		MaskedCard: status.MaskedCard,
+		CardType:   status.CardType,
+		Bank:       status.Bank,
+		CreatedAt:  now,
+		UpdatedAt:  now,
+	}
+	if status.SavedTokenIDExpiredAt != "" {
+		if parsed, err := midtrans.ParseTime(status.SavedTokenIDExpiredAt); err == nil {
+			method.TokenExpiresAt = &parsed
+		}
+	}
+
+	if err := s.paymentMethodRepo.Upsert(ctx, method); err != nil {
+		log.Printf("Failed to save card for order %s: %v", transaction.OrderID, err)
+	}
+}
@@ -6,18 +6,22 @@ import (
 	"encoding/json"
 	"errors"
 	"fmt"
+	"log"
 	"time"
 
 	"github.com/raflytch/careerly-server/internal/domain"
 	"github.com/raflytch/careerly-server/pkg/midtrans"
 
 	"github.com/google/uuid"
+	"github.com/shopspring/decimal"
 )
 
 const (
 	transactionCachePrefix   = "transaction:"
 	transactionListCacheKey  = "transactions:list"
 	defaultTransactionExpiry = 24 * time.Hour
+	reconcileBatchSize       = 100
+	notificationClockSkew    = 5 * time.Minute
 )
 
 var (
@@ -27,37 +31,128 @@ var (
 	ErrPlanNotAvailable         = errors.New("plan is not available for purchase")
 	ErrActiveSubscriptionExists = errors.New("user already has an active subscription for this plan")
 	ErrInvalidSignature         = errors.New("invalid webhook signature")
+	ErrMissingSignature         = errors.New("webhook signature is required")
+	ErrTransactionNotPaid       = errors.New("transaction has not been paid")
+	ErrPaymentGatewayDown       = errors.New("payment gateway is temporarily unavailable, please try again later")
+	ErrStaleNotification        = errors.New("notification is outside the accepted time window")
+	ErrDuplicateNotification    = errors.New("notification has already been processed")
+	ErrNotificationNotQueued    = errors.New("notification could not be queued, please retry")
 )
 
 type transactionService struct {
-	transactionRepo  domain.TransactionRepository
-	planRepo         domain.PlanRepository
-	subscriptionRepo domain.SubscriptionRepository
-	userRepo         domain.UserRepository
-	cacheRepo        domain.CacheRepository
-	midtransClient   *midtrans.Client
+	transactionRepo     domain.TransactionRepository
+	planRepo            domain.PlanRepository
+	addonRepo           domain.AddonRepository
+	giftRepo            domain.GiftRepository
+	subscriptionRepo    domain.SubscriptionRepository
+	userRepo            domain.UserRepository
+	provisioningJobRepo domain.ProvisioningJobRepository
+	notificationRepo    domain.PaymentNotificationRepository
+	paymentMethodRepo   domain.PaymentMethodRepository
+	orgRepo             domain.OrganizationRepository
+	cacheRepo           domain.CacheRepository
+	referralService     domain.ReferralService
+	emailService        domain.EmailService
+	paymentGateway      domain.PaymentGateway
+	webhooks            domain.WebhookPublisher
+	jobs                domain.JobEnqueuer
+	notificationWindow  time.Duration
+	requireSignature    bool
 }
 
 func NewTransactionService(
 	transactionRepo domain.TransactionRepository,
 	planRepo domain.PlanRepository,
+	addonRepo domain.AddonRepository,
+	giftRepo domain.GiftRepository,
 	subscriptionRepo domain.SubscriptionRepository,
 	userRepo domain.UserRepository,
+	provisioningJobRepo domain.ProvisioningJobRepository,
+	notificationRepo domain.PaymentNotificationRepository,
+	paymentMethodRepo domain.PaymentMethodRepository,
+	orgRepo domain.OrganizationRepository,
 	cacheRepo domain.CacheRepository,
-	midtransClient *midtrans.Client,
+	referralService domain.ReferralService,
+	emailService domain.EmailService,
+	paymentGateway domain.PaymentGateway,
+	webhooks domain.WebhookPublisher,
+	jobs domain.JobEnqueuer,
+	notificationWindow time.Duration,
+	requireSignature bool,
 ) domain.TransactionService {
 	return &transactionService{
-		transactionRepo:  transactionRepo,
-		planRepo:         planRepo,
-		subscriptionRepo: subscriptionRepo,
-		userRepo:         userRepo,
-		cacheRepo:        cacheRepo,
-		midtransClient:   midtransClient,
+		transactionRepo:     transactionRepo,
+		planRepo:            planRepo,
+		addonRepo:           addonRepo,
+		giftRepo:            giftRepo,
+		subscriptionRepo:    subscriptionRepo,
+		userRepo:            userRepo,
+		provisioningJobRepo: provisioningJobRepo,
+		notificationRepo:    notificationRepo,
+		paymentMethodRepo:   paymentMethodRepo,
+		orgRepo:             orgRepo,
+		cacheRepo:           cacheRepo,
+		referralService:     referralService,
+		emailService:        emailService,
+		paymentGateway:      paymentGateway,
+		webhooks:            webhooks,
+		jobs:                jobs,
+		notificationWindow:  notificationWindow,
+		requireSignature:    requireSignature,
 	}
 }
 
 func (s *transactionService) CreateTransaction(ctx context.Context, userID uuid.UUID, req *domain.CreateTransactionRequest) (*domain.TransactionResponse, error) {
-	plan, err := s.planRepo.FindByID(ctx, req.PlanID)
+	plan, err := s.purchasablePlan(ctx, userID, req.PlanID)
+	if err != nil {
+		return nil, err
+	}
+
+	user, err := s.userRepo.FindByID(ctx, userID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch user: %w", err)
+	}
+
+	orderID := planOrderID(plan.ID, userID)
+
+	snapResp, err := s.createSnap(orderID, plan.ID, plan.DisplayName, plan.Price, 1, user, req.SaveCard)
+	if err != nil {
+		return nil, err
+	}
+
+	expiryTime := time.Now().Add(defaultTransactionExpiry)
+
+	transaction := &domain.Transaction{
+		ID:          uuid.New(),
+		UserID:      userID,
+		PlanID:      plan.ID,
+		OrderID:     orderID,
+		GrossAmount: plan.Price,
+		Status:      domain.TransactionStatusPending,
+		SnapToken:   &snapResp.Token,
+		RedirectURL: &snapResp.RedirectURL,
+		ExpiredAt:   &expiryTime,
+		CreatedAt:   time.Now(),
+		UpdatedAt:   time.Now(),
+	}
+
+	if err := s.transactionRepo.Create(ctx, transaction); err != nil {
+		return nil, fmt.Errorf("failed to create transaction record: %w", err)
+	}
+
+	transaction.Plan = plan
+
+	return &domain.TransactionResponse{
+		Transaction: transaction,
+		SnapToken:   snapResp.Token,
+		RedirectURL: snapResp.RedirectURL,
+	}, nil
+}
+
+// purchasablePlan returns the plan when the user can buy it: it is on sale,
+// not free and not the plan the user is already subscribed to.
+func (s *transactionService) purchasablePlan(ctx context.Context, userID uuid.UUID, planID uuid.UUID) (*domain.Plan, error) {
+	plan, err := s.planRepo.FindByID(ctx, planID)
 	if err != nil {
 		if errors.Is(err, sql.ErrNoRows) {
 			return nil, ErrPlanNotAvailable
@@ -65,7 +160,7 @@ func (s *transactionService) CreateTransaction(ctx context.Context, userID uuid.
 		return nil, fmt.Errorf("failed to fetch plan: %w", err)
 	}
 
-	if !plan.IsActive {
+	if !plan.IsActive || plan.ArchivedAt != nil {
 		return nil, ErrPlanNotAvailable
 	}
 
@@ -74,43 +169,67 @@ func (s *transactionService) CreateTransaction(ctx context.Context, userID uuid.
 	}
 
 	existingSub, _ := s.subscriptionRepo.FindActiveByUserID(ctx, userID)
-	if existingSub != nil && existingSub.PlanID == req.PlanID {
+	if existingSub != nil && existingSub.PlanID == planID {
 		return nil, ErrActiveSubscriptionExists
 	}
 
+	return plan, nil
+}
+
+func planOrderID(planID uuid.UUID, userID uuid.UUID) string {
+	return fmt.Sprintf("CAREERLY-%s-%s-%d",
+		planID.String()[:8],
+		userID.String()[:8],
+		time.Now().UnixMilli(),
+	)
+}
+
+// CreateAddonTransaction starts a one-time purchase of an add-on pack. Packs
+// top up a capped feature of the buyer's current plan for the running usage
+// period, so they need an active subscription and are refused when the plan
+// already allows unlimited use. The transaction keeps the current plan's id
+// for reporting.
+func (s *transactionService) CreateAddonTransaction(ctx context.Context, userID uuid.UUID, req *domain.CreateAddonTransactionRequest) (*domain.TransactionResponse, error) {
+	addon, err := s.addonRepo.FindByID(ctx, req.AddonID)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, ErrAddonNotAvailable
+		}
+		return nil, fmt.Errorf("failed to fetch add-on: %w", err)
+	}
+
+	if !addon.IsActive || addon.Price.IsZero() {
+		return nil, ErrAddonNotAvailable
+	}
+
+	subscription, err := s.subscriptionRepo.FindActiveByUserID(ctx, userID)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, ErrNoActiveSubscription
+		}
+		return nil, fmt.Errorf("failed to fetch subscription: %w", err)
+	}
+	if subscription.Plan == nil {
+		return nil, ErrNoActiveSubscription
+	}
+	if planFeatureLimit(subscription.Plan, addon.Feature) <= 0 {
+		return nil, ErrAddonNotNeeded
+	}
+
 	user, err := s.userRepo.FindByID(ctx, userID)
 	if err != nil {
 		return nil, fmt.Errorf("failed to fetch user: %w", err)
 	}
 
-	orderID := fmt.Sprintf("CAREERLY-%s-%s-%d",
-		plan.ID.String()[:8],
+	orderID := fmt.Sprintf("CAREERLY-ADDON-%s-%s-%d",
+		addon.ID.String()[:8],
 		userID.String()[:8],
 		time.Now().UnixMilli(),
 	)
 
-	grossAmount := plan.Price.IntPart()
-
-	midtransReq := midtrans.CreateTransactionRequest{
-		OrderID:     orderID,
-		GrossAmount: grossAmount,
-		ItemDetails: []midtrans.ItemDetail{
-			{
-				ID:       plan.ID.String(),
-				Name:     plan.DisplayName,
-				Price:    grossAmount,
-				Quantity: 1,
-			},
-		},
-		CustomerDetails: midtrans.CustomerDetail{
-			FirstName: user.Name,
-			Email:     user.Email,
-		},
-	}
-
-	snapResp, err := s.midtransClient.CreateSnapTransaction(midtransReq)
+	snapResp, err := s.createSnap(orderID, addon.ID, addon.DisplayName, addon.Price, 1, user, false)
 	if err != nil {
-		return nil, fmt.Errorf("failed to create midtrans transaction: %w", err)
+		return nil, err
 	}
 
 	expiryTime := time.Now().Add(defaultTransactionExpiry)
@@ -118,9 +237,10 @@ func (s *transactionService) CreateTransaction(ctx context.Context, userID uuid.
 	transaction := &domain.Transaction{
 		ID:          uuid.New(),
 		UserID:      userID,
-		PlanID:      plan.ID,
+		PlanID:      subscription.PlanID,
+		AddonID:     &addon.ID,
 		OrderID:     orderID,
-		GrossAmount: plan.Price,
+		GrossAmount: addon.Price,
 		Status:      domain.TransactionStatusPending,
 		SnapToken:   &snapResp.Token,
 		RedirectURL: &snapResp.RedirectURL,
@@ -133,7 +253,7 @@ func (s *transactionService) CreateTransaction(ctx context.Context, userID uuid.
 		return nil, fmt.Errorf("failed to create transaction record: %w", err)
 	}
 
-	transaction.Plan = plan
+	transaction.Addon = addon
 
 	return &domain.TransactionResponse{
 		Transaction: transaction,
@@ -142,6 +262,49 @@ func (s *transactionService) CreateTransaction(ctx context.Context, userID uuid.
 	}, nil
 }
 
+// createSnap opens a Snap payment page. saveCard is the user's consent to
+// keep the card for later charges; Midtrans only returns a saved token when
+// it is set.
+func (s *transactionService) createSnap(orderID string, itemID uuid.UUID, itemName string, price decimal.Decimal, quantity int32, user *domain.User, saveCard bool) (*midtrans.CreateTransactionResponse, error) {
+	unitPrice := price.IntPart()
+
+	midtransReq := midtrans.CreateTransactionRequest{
+		OrderID:         orderID,
+		GrossAmount:     unitPrice * int64(quantity),
+		ItemDetails:     gatewayItems(itemID, itemName, unitPrice, quantity),
+		CustomerDetails: gatewayCustomer(user),
+		SaveCard:        saveCard,
+		UserID:          user.ID.String(),
+	}
+
+	snapResp, err := s.paymentGateway.CreateSnapTransaction(midtransReq)
+	if err != nil {
+		if errors.Is(err, midtrans.ErrUnavailable) {
+			return nil, ErrPaymentGatewayDown
+		}
+		return nil, fmt.Errorf("failed to create midtrans transaction: %w", err)
+	}
+	return snapResp, nil
+}
+
+func gatewayItems(itemID uuid.UUID, itemName string, unitPrice int64, quantity int32) []midtrans.ItemDetail {
+	return []midtrans.ItemDetail{
+		{
+			ID:       itemID.String(),
+			Name:     itemName,
+			Price:    unitPrice,
+			Quantity: quantity,
+		},
+	}
+}
+
+func gatewayCustomer(user *domain.User) midtrans.CustomerDetail {
+	return midtrans.CustomerDetail{
+		FirstName: user.Name,
+		Email:     contactEmail(user),
+	}
+}
+
 func (s *transactionService) GetByID(ctx context.Context, userID uuid.UUID, id uuid.UUID) (*domain.Transaction, error) {
 	transaction, err := s.transactionRepo.FindByID(ctx, id)
 	if err != nil {
@@ -218,12 +381,111 @@ func (s *transactionService) HandleWebhook(ctx context.Context, payload map[stri
 	grossAmount, _ := payload["gross_amount"].(string)
 	signatureKey, _ := payload["signature_key"].(string)
 
+	if signatureKey == "" && s.requireSignature {
+		return ErrMissingSignature
+	}
 	if signatureKey != "" {
-		if !s.midtransClient.VerifySignatureKey(orderID, statusCode, grossAmount, signatureKey) {
+		if !s.paymentGateway.VerifySignatureKey(orderID, statusCode, grossAmount, signatureKey) {
 			return ErrInvalidSignature
 		}
 	}
 
+	eventTime, err := notificationEventTime(payload)
+	if err != nil {
+		return ErrStaleNotification
+	}
+	now := time.Now()
+	if s.notificationWindow > 0 && (now.Sub(eventTime) > s.notificationWindow || eventTime.Sub(now) > notificationClockSkew) {
+		return ErrStaleNotification
+	}
+
+	if signatureKey == "" {
+		return s.enqueueNotification(ctx, orderID, payload, nil)
+	}
+
+	transactionStatus, _ := payload["transaction_status"].(string)
+	notification := &domain.PaymentNotification{
+		ID:                uuid.New(),
+		OrderID:           orderID,
+		SignatureKey:      signatureKey,
+		TransactionStatus: transactionStatus,
+		StatusCode:        statusCode,
+		EventTime:         eventTime,
+		ReceivedAt:        now,
+	}
+	claimed, err := s.notificationRepo.Claim(ctx, notification)
+	if err != nil {
+		return fmt.Errorf("failed to record notification: %w", err)
+	}
+	if !claimed {
+		return ErrDuplicateNotification
+	}
+
+	return s.enqueueNotification(ctx, orderID, payload, &notification.ID)
+}
+
+// enqueueNotification hands the notification to the job queue so the
+// webhook can be acknowledged without waiting on Midtrans or the database.
+// When it cannot be queued the claim is released, so Midtrans' own retry of
+// this notification is accepted.
+func (s *transactionService) enqueueNotification(ctx context.Context, orderID string, payload map[string]interface{}, notificationID *uuid.UUID) error {
+	job := domain.PaymentNotificationJob{OrderID: orderID, Payload: payload}
+	if _, err := s.jobs.Enqueue(ctx, domain.JobPaymentNotification, job); err != nil {
+		log.Printf("Failed to queue payment notification for order %s: %v", orderID, err)
+		if notificationID != nil {
+			if releaseErr := s.notificationRepo.Release(ctx, *notificationID); releaseErr != nil {
+				log.Printf("Failed to release payment notification %s: %v", *notificationID, releaseErr)
+			}
+		}
+		return ErrNotificationNotQueued
+	}
+	return nil
+}
+
+// ProcessNotification verifies a queued notification with Midtrans and
+// applies it. Errors are returned so the queue retries the job; a
+// notification for an unknown order is dropped instead.
+func (s *transactionService) ProcessNotification(ctx context.Context, job *domain.PaymentNotificationJob) error {
+	if err := s.processWebhook(ctx, job.OrderID, job.Payload); err != nil {
+		if errors.Is(err, ErrTransactionNotFound) {
+			log.Printf("Dropping payment notification for unknown order %s", job.OrderID)
+			return nil
+		}
+		return err
+	}
+	return nil
+}
+
+// notificationEventTime returns when the notified status change happened:
+// the latest of transaction_time, settlement_time and, for expirations,
+// expiry_time.
+func notificationEventTime(payload map[string]interface{}) (time.Time, error) {
+	fields := []string{"transaction_time", "settlement_time"}
+	if status, _ := payload["transaction_status"].(string); status == "expire" {
+		fields = append(fields, "expiry_time")
+	}
+
+	var latest time.Time
+	for _, field := range fields {
+		value, _ := payload[field].(string)
+		if value == "" {
+			continue
+		}
+		parsed, err := midtrans.ParseTime(value)
+		if err != nil {
+			return time.Time{}, err
+		}
+		if parsed.After(latest) {
+			latest = parsed
+		}
+	}
+	if latest.IsZero() {
+		return time.Time{}, errors.New("notification has no timestamp")
+	}
+	return latest, nil
+}
+
+func (s *transactionService) processWebhook(ctx context.Context, orderID string, payload map[string]interface{}) error {
 	transaction, err := s.transactionRepo.FindByOrderID(ctx, orderID)
 	if err != nil {
 		if errors.Is(err, sql.ErrNoRows) {
@@ -237,8 +499,11 @@ func (s *transactionService) HandleWebhook(ctx context.Context, payload map[stri
 		return nil
 	}
 
-	statusResp, err := s.midtransClient.CheckTransaction(orderID)
+	statusResp, err := s.paymentGateway.CheckTransaction(orderID)
 	if err != nil {
+		if errors.Is(err, midtrans.ErrUnavailable) {
+			return ErrPaymentGatewayDown
+		}
 		return fmt.Errorf("failed to verify transaction with midtrans: %w", err)
 	}
 
@@ -258,12 +523,10 @@ func (s *transactionService) HandleWebhook(ctx context.Context, payload map[stri
 		transaction.PaidAt = &now
 
 		if transaction.SubscriptionID == nil {
-			subscriptionID, err := s.createSubscription(ctx, transaction)
-			if err != nil {
-				return fmt.Errorf("failed to create subscription: %w", err)
-			}
-			transaction.SubscriptionID = &subscriptionID
+			s.provisionOrEnqueue(ctx, transaction)
 		}
+
+		s.savePaymentMethod(ctx, transaction, statusResp)
 	}
 
 	if err := s.transactionRepo.Update(ctx, transaction); err != nil {
@@ -289,11 +552,74 @@ func (s *transactionService) CheckTransactionStatus(ctx context.Context, orderID
 		return transaction, nil
 	}
 
-	statusResp, err := s.midtransClient.CheckTransaction(orderID)
+	statusResp, err := s.paymentGateway.CheckTransaction(orderID)
 	if err != nil {
+		if errors.Is(err, midtrans.ErrUnavailable) {
+			return nil, ErrPaymentGatewayDown
+		}
 		return nil, fmt.Errorf("failed to check transaction status: %w", err)
 	}
 
+	s.applyGatewayStatus(ctx, transaction, statusResp)
+
+	if err := s.transactionRepo.Update(ctx, transaction); err != nil {
+		return nil, fmt.Errorf("failed to update transaction: %w", err)
+	}
+
+	return transaction, nil
+}
+
+// ReconcilePending re-checks transactions that have been pending for longer
+// than olderThan, for when the Midtrans webhook never arrived. Transactions
+// still unpaid past their ExpiredAt are expired locally.
+func (s *transactionService) ReconcilePending(ctx context.Context, olderThan time.Duration) (*domain.TransactionReconcileResult, error) {
+	transactions, err := s.transactionRepo.FindPendingCreatedBefore(ctx, time.Now().Add(-olderThan), reconcileBatchSize)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch pending transactions: %w", err)
+	}
+
+	result := &domain.TransactionReconcileResult{}
+	for i := range transactions {
+		transaction := &transactions[i]
+		result.Checked++
+
+		statusResp, err := s.paymentGateway.CheckTransaction(transaction.OrderID)
+		switch {
+		case err == nil:
+			s.applyGatewayStatus(ctx, transaction, statusResp)
+		case errors.Is(err, midtrans.ErrUnavailable):
+			return result, ErrPaymentGatewayDown
+		case errors.Is(err, midtrans.ErrOrderNotFound):
+			// The user never picked a payment method, so Midtrans has no
+			// record of the order; only our own expiry applies.
+		default:
+			log.Printf("Failed to reconcile transaction %s: %v", transaction.OrderID, err)
+			result.Failed++
+			continue
+		}
+
+		if transaction.Status == domain.TransactionStatusPending {
+			if transaction.ExpiredAt == nil || time.Now().Before(*transaction.ExpiredAt) {
+				continue
+			}
+			transaction.Status = domain.TransactionStatusExpired
+			result.Expired++
+		}
+
+		if err := s.transactionRepo.Update(ctx, transaction); err != nil {
+			log.Printf("Failed to update reconciled transaction %s: %v", transaction.OrderID, err)
+			result.Failed++
+			continue
+		}
+		result.Updated++
+
+		s.invalidateCache(ctx, transaction.ID)
+	}
+
+	return result, nil
+}
+
+func (s *transactionService) applyGatewayStatus(ctx context.Context, transaction *domain.Transaction, statusResp *midtrans.TransactionStatusResponse) {
 	transaction.TransactionID = &statusResp.TransactionID
 	transaction.PaymentType = &statusResp.PaymentType
 	transaction.TransactionStatus = &statusResp.TransactionStatus
@@ -306,18 +632,108 @@ func (s *transactionService) CheckTransactionStatus(ctx context.Context, orderID
 		now := time.Now()
 		transaction.PaidAt = &now
 
-		subscriptionID, err := s.createSubscription(ctx, transaction)
-		if err != nil {
-			return nil, fmt.Errorf("failed to create subscription: %w", err)
+		s.provisionOrEnqueue(ctx, transaction)
+	}
+}
+
+func (s *transactionService) ProvisionSubscription(ctx context.Context, transactionID uuid.UUID) error {
+	transaction, err := s.transactionRepo.FindByID(ctx, transactionID)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return ErrTransactionNotFound
+		}
+		return err
+	}
+
+	if transaction.SubscriptionID != nil {
+		return nil
+	}
+
+	if transaction.Status != domain.TransactionStatusSuccess {
+		return ErrTransactionNotPaid
+	}
+
+	if transaction.AddonID != nil {
+		if err := s.creditAddon(ctx, transaction); err != nil {
+			return fmt.Errorf("failed to credit add-on: %w", err)
 		}
-		transaction.SubscriptionID = &subscriptionID
+		return nil
 	}
 
+	if isGiftOrder(transaction.OrderID) {
+		if err := s.issueGifts(ctx, transaction); err != nil {
+			return fmt.Errorf("failed to issue gift codes: %w", err)
+		}
+		return nil
+	}
+
+	if isOrganizationOrder(transaction.OrderID) {
+		if err := s.applySeatPurchase(ctx, transaction); err != nil {
+			return fmt.Errorf("failed to apply seat purchase: %w", err)
+		}
+		return nil
+	}
+
+	subscriptionID, err := s.createSubscription(ctx, transaction)
+	if err != nil {
+		return fmt.Errorf("failed to create subscription: %w", err)
+	}
+	transaction.SubscriptionID = &subscriptionID
+
 	if err := s.transactionRepo.Update(ctx, transaction); err != nil {
-		return nil, fmt.Errorf("failed to update transaction: %w", err)
+		return fmt.Errorf("failed to update transaction: %w", err)
 	}
 
-	return transaction, nil
+	s.invalidateCache(ctx, transaction.ID)
+
+	return nil
+}
+
+// provisionOrEnqueue creates the subscription, credits the add-on pack,
+// issues the gift codes or applies the organization's seats for a paid
+// transaction. When that fails the payment is still recorded and a
+// provisioning job is queued so the retry worker can finish the job without
+// losing the webhook.
+func (s *transactionService) provisionOrEnqueue(ctx context.Context, transaction *domain.Transaction) {
+	var err error
+	if transaction.AddonID != nil {
+		err = s.creditAddon(ctx, transaction)
+	} else if isGiftOrder(transaction.OrderID) {
+		err = s.issueGifts(ctx, transaction)
+	} else if isOrganizationOrder(transaction.OrderID) {
+		err = s.applySeatPurchase(ctx, transaction)
+	} else {
+		var subscriptionID uuid.UUID
+		subscriptionID, err = s.createSubscription(ctx, transaction)
+		if err == nil {
+			transaction.SubscriptionID = &subscriptionID
+		}
+	}
+	if err == nil {
+		return
+	}
+
+	log.Printf("Subscription provisioning failed for order %s, queueing retry: %v", transaction.OrderID, err)
+
+	now := time.Now()
+	lastError := err.Error()
+	job := &domain.ProvisioningJob{
+		ID:            uuid.New(),
+		TransactionID: transaction.ID,
+		OrderID:       transaction.OrderID,
+		UserID:        transaction.UserID,
+		PlanID:        transaction.PlanID,
+		Status:        domain.ProvisioningJobStatusPending,
+		Attempts:      1,
+		MaxAttempts:   provisioningMaxAttempts,
+		LastError:     &lastError,
+		NextAttemptAt: now.Add(provisioningBackoff(1)),
+		CreatedAt:     now,
+		UpdatedAt:     now,
+	}
+	if err := s.provisioningJobRepo.Create(ctx, job); err != nil {
+		log.Printf("Failed to queue provisioning job for order %s: %v", transaction.OrderID, err)
+	}
 }
 
 func (s *transactionService) createSubscription(ctx context.Context, transaction *domain.Transaction) (uuid.UUID, error) {
@@ -331,8 +747,13 @@ func (s *transactionService) createSubscription(ctx context.Context, transaction
 		durationDays = *plan.DurationDays
 	}
 
+	loc := time.UTC
+	if user, err := s.userRepo.FindByID(ctx, transaction.UserID); err == nil {
+		loc = userLocation(user)
+	}
+
 	now := time.Now()
-	endDate := now.AddDate(0, 0, durationDays)
+	endDate := subscriptionEndDate(now, durationDays, loc)
 
 	existingSub, _ := s.subscriptionRepo.FindActiveByUserID(ctx, transaction.UserID)
 	if existingSub != nil {
@@ -354,9 +775,48 @@ func (s *transactionService) createSubscription(ctx context.Context, transaction
 		return uuid.Nil, err
 	}
 
+	if err := s.referralService.HandleSubscriptionCreated(ctx, transaction.UserID, subscription); err != nil {
+		log.Printf("Referral reward processing failed for user %s: %v", transaction.UserID, err)
+	}
+
+	s.webhooks.Publish(ctx, domain.WebhookEventSubscriptionActivated, subscription)
+
 	return subscription.ID, nil
 }
 
+// creditAddon grants the pack's allowance for the buyer's usage period at
+// the time of payment. Crediting is keyed by transaction, so repeating it
+// is harmless.
+func (s *transactionService) creditAddon(ctx context.Context, transaction *domain.Transaction) error {
+	addon, err := s.addonRepo.FindByID(ctx, *transaction.AddonID)
+	if err != nil {
+		return err
+	}
+
+	loc := time.UTC
+	if user, err := s.userRepo.FindByID(ctx, transaction.UserID); err == nil {
+		loc = userLocation(user)
+	}
+
+	paidAt := time.Now()
+	if transaction.PaidAt != nil {
+		paidAt = *transaction.PaidAt
+	}
+
+	credit := &domain.AddonCredit{
+		ID:            uuid.New(),
+		UserID:        transaction.UserID,
+		AddonID:       addon.ID,
+		TransactionID: transaction.ID,
+		Feature:       addon.Feature,
+		Quantity:      addon.Quantity,
+		PeriodMonth:   usagePeriodMonth(paidAt, loc),
+		CreatedAt:     time.Now(),
+	}
+	_, err = s.addonRepo.CreateCredit(ctx, credit)
+	return err
+}
+
 func (s *transactionService) mapMidtransStatus(transactionStatus, fraudStatus string) domain.TransactionStatus {
 	switch transactionStatus {
 	case "capture":
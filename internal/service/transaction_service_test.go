@@ -0,0 +1,638 @@
+package service
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/raflytch/careerly-server/internal/domain"
+	"github.com/raflytch/careerly-server/pkg/midtrans"
+
+	"github.com/google/uuid"
+	"github.com/shopspring/decimal"
+)
+
+const testServerKey = "test-server-key"
+
+// The fakes embed the interface they stand in for, so a call the test did
+// not expect panics instead of passing silently.
+
+type fakeTransactionRepo struct {
+	domain.TransactionRepository
+	mu           sync.Mutex
+	transactions map[uuid.UUID]domain.Transaction
+}
+
+func (r *fakeTransactionRepo) Create(ctx context.Context, transaction *domain.Transaction) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.transactions[transaction.ID] = *transaction
+	return nil
+}
+
+func (r *fakeTransactionRepo) Update(ctx context.Context, transaction *domain.Transaction) error {
+	return r.Create(ctx, transaction)
+}
+
+func (r *fakeTransactionRepo) FindByID(ctx context.Context, id uuid.UUID) (*domain.Transaction, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	transaction, ok := r.transactions[id]
+	if !ok {
+		return nil, sql.ErrNoRows
+	}
+	return &transaction, nil
+}
+
+func (r *fakeTransactionRepo) FindByOrderID(ctx context.Context, orderID string) (*domain.Transaction, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	for _, transaction := range r.transactions {
+		if transaction.OrderID == orderID {
+			return &transaction, nil
+		}
+	}
+	return nil, sql.ErrNoRows
+}
+
+type fakePlanRepo struct {
+	domain.PlanRepository
+	plans map[uuid.UUID]*domain.Plan
+}
+
+func (r *fakePlanRepo) FindByID(ctx context.Context, id uuid.UUID) (*domain.Plan, error) {
+	plan, ok := r.plans[id]
+	if !ok {
+		return nil, sql.ErrNoRows
+	}
+	return plan, nil
+}
+
+type fakeSubscriptionRepo struct {
+	domain.SubscriptionRepository
+	mu            sync.Mutex
+	subscriptions []domain.Subscription
+}
+
+func (r *fakeSubscriptionRepo) Create(ctx context.Context, subscription *domain.Subscription) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.subscriptions = append(r.subscriptions, *subscription)
+	return nil
+}
+
+func (r *fakeSubscriptionRepo) Update(ctx context.Context, subscription *domain.Subscription) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	for i := range r.subscriptions {
+		if r.subscriptions[i].ID == subscription.ID {
+			r.subscriptions[i] = *subscription
+			return nil
+		}
+	}
+	return sql.ErrNoRows
+}
+
+func (r *fakeSubscriptionRepo) FindActiveByUserID(ctx context.Context, userID uuid.UUID) (*domain.Subscription, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	for _, subscription := range r.subscriptions {
+		if subscription.UserID == userID && subscription.Status == domain.SubscriptionStatusActive {
+			return &subscription, nil
+		}
+	}
+	return nil, sql.ErrNoRows
+}
+
+func (r *fakeSubscriptionRepo) active(userID uuid.UUID) []domain.Subscription {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	var active []domain.Subscription
+	for _, subscription := range r.subscriptions {
+		if subscription.UserID == userID && subscription.Status == domain.SubscriptionStatusActive {
+			active = append(active, subscription)
+		}
+	}
+	return active
+}
+
+type fakeUserRepo struct {
+	domain.UserRepository
+	users map[uuid.UUID]*domain.User
+}
+
+func (r *fakeUserRepo) FindByID(ctx context.Context, id uuid.UUID) (*domain.User, error) {
+	user, ok := r.users[id]
+	if !ok {
+		return nil, sql.ErrNoRows
+	}
+	return user, nil
+}
+
+type fakeNotificationRepo struct {
+	domain.PaymentNotificationRepository
+	mu      sync.Mutex
+	claimed map[string]uuid.UUID
+}
+
+func (r *fakeNotificationRepo) Claim(ctx context.Context, notification *domain.PaymentNotification) (bool, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	key := notification.SignatureKey + "/" + notification.TransactionStatus
+	if _, ok := r.claimed[key]; ok {
+		return false, nil
+	}
+	r.claimed[key] = notification.ID
+	return true, nil
+}
+
+func (r *fakeNotificationRepo) Release(ctx context.Context, id uuid.UUID) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	for key, claimedID := range r.claimed {
+		if claimedID == id {
+			delete(r.claimed, key)
+		}
+	}
+	return nil
+}
+
+type fakePaymentMethodRepo struct {
+	domain.PaymentMethodRepository
+	mu      sync.Mutex
+	methods []domain.PaymentMethod
+}
+
+func (r *fakePaymentMethodRepo) Upsert(ctx context.Context, method *domain.PaymentMethod) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.methods = append(r.methods, *method)
+	return nil
+}
+
+type fakeCacheRepo struct {
+	domain.CacheRepository
+}
+
+func (fakeCacheRepo) Delete(ctx context.Context, key string) error {
+	return nil
+}
+
+func (fakeCacheRepo) DeleteByPattern(ctx context.Context, pattern string) error {
+	return nil
+}
+
+type fakeReferralService struct {
+	domain.ReferralService
+}
+
+func (fakeReferralService) HandleSubscriptionCreated(ctx context.Context, userID uuid.UUID, subscription *domain.Subscription) error {
+	return nil
+}
+
+type fakeWebhookPublisher struct {
+	mu     sync.Mutex
+	events []domain.WebhookEvent
+}
+
+func (p *fakeWebhookPublisher) Publish(ctx context.Context, event domain.WebhookEvent, data interface{}) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.events = append(p.events, event)
+}
+
+type fakeJobEnqueuer struct {
+	mu   sync.Mutex
+	jobs []domain.PaymentNotificationJob
+	err  error
+}
+
+func (q *fakeJobEnqueuer) Enqueue(ctx context.Context, jobType string, payload interface{}) (string, error) {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	if q.err != nil {
+		return "", q.err
+	}
+	if job, ok := payload.(domain.PaymentNotificationJob); ok {
+		q.jobs = append(q.jobs, job)
+	}
+	return uuid.NewString(), nil
+}
+
+type transactionTestEnv struct {
+	service       *transactionService
+	gateway       *midtrans.Mock
+	transactions  *fakeTransactionRepo
+	subscriptions *fakeSubscriptionRepo
+	notifications *fakeNotificationRepo
+	methods       *fakePaymentMethodRepo
+	webhooks      *fakeWebhookPublisher
+	jobs          *fakeJobEnqueuer
+	user          *domain.User
+	plan          *domain.Plan
+}
+
+func newTransactionTestEnv(t *testing.T) *transactionTestEnv {
+	t.Helper()
+
+	durationDays := 30
+	env := &transactionTestEnv{
+		gateway:       midtrans.NewMock(testServerKey),
+		transactions:  &fakeTransactionRepo{transactions: make(map[uuid.UUID]domain.Transaction)},
+		subscriptions: &fakeSubscriptionRepo{},
+		notifications: &fakeNotificationRepo{claimed: make(map[string]uuid.UUID)},
+		methods:       &fakePaymentMethodRepo{},
+		webhooks:      &fakeWebhookPublisher{},
+		jobs:          &fakeJobEnqueuer{},
+		user: &domain.User{
+			ID:    uuid.New(),
+			Email: "user@example.com",
+			Name:  "Test User",
+		},
+		plan: &domain.Plan{
+			ID:           uuid.New(),
+			Name:         "pro",
+			DisplayName:  "Pro",
+			Price:        decimal.NewFromInt(50000),
+			DurationDays: &durationDays,
+			IsActive:     true,
+		},
+	}
+
+	env.service = NewTransactionService(
+		env.transactions,
+		&fakePlanRepo{plans: map[uuid.UUID]*domain.Plan{env.plan.ID: env.plan}},
+		nil,
+		nil,
+		env.subscriptions,
+		&fakeUserRepo{users: map[uuid.UUID]*domain.User{env.user.ID: env.user}},
+		nil,
+		env.notifications,
+		env.methods,
+		nil,
+		fakeCacheRepo{},
+		fakeReferralService{},
+		nil,
+		env.gateway,
+		env.webhooks,
+		env.jobs,
+		time.Hour,
+		true,
+	).(*transactionService)
+	return env
+}
+
+// createPending starts a purchase of the test plan and returns the pending
+// transaction it recorded.
+func (env *transactionTestEnv) createPending(t *testing.T) *domain.Transaction {
+	t.Helper()
+	resp, err := env.service.CreateTransaction(context.Background(), env.user.ID, &domain.CreateTransactionRequest{PlanID: env.plan.ID})
+	if err != nil {
+		t.Fatalf("CreateTransaction: %v", err)
+	}
+	return resp.Transaction
+}
+
+// notification builds a Midtrans notification for the order, signed with
+// the mock's server key.
+func (env *transactionTestEnv) notification(orderID, status string, at time.Time) map[string]interface{} {
+	statusCode, grossAmount := "200", "50000.00"
+	return map[string]interface{}{
+		"order_id":           orderID,
+		"status_code":        statusCode,
+		"gross_amount":       grossAmount,
+		"transaction_status": status,
+		"transaction_time":   midtrans.FormatTime(at),
+		"payment_type":       "bank_transfer",
+		"signature_key":      env.gateway.Sign(orderID, statusCode, grossAmount),
+	}
+}
+
+func TestCreateTransaction(t *testing.T) {
+	env := newTransactionTestEnv(t)
+
+	resp, err := env.service.CreateTransaction(context.Background(), env.user.ID, &domain.CreateTransactionRequest{PlanID: env.plan.ID})
+	if err != nil {
+		t.Fatalf("CreateTransaction: %v", err)
+	}
+
+	orderID := resp.Transaction.OrderID
+	if resp.SnapToken != "mock-token-"+orderID {
+		t.Errorf("snap token = %q, want the gateway's token for %s", resp.SnapToken, orderID)
+	}
+	if resp.Transaction.Status != domain.TransactionStatusPending {
+		t.Errorf("status = %s, want pending", resp.Transaction.Status)
+	}
+	if !resp.Transaction.GrossAmount.Equal(env.plan.Price) {
+		t.Errorf("gross amount = %s, want %s", resp.Transaction.GrossAmount, env.plan.Price)
+	}
+
+	stored, err := env.transactions.FindByOrderID(context.Background(), orderID)
+	if err != nil {
+		t.Fatalf("transaction was not stored: %v", err)
+	}
+	if stored.UserID != env.user.ID || stored.PlanID != env.plan.ID {
+		t.Errorf("stored transaction belongs to user %s plan %s", stored.UserID, stored.PlanID)
+	}
+
+	created := env.gateway.Created()
+	if len(created) != 1 {
+		t.Fatalf("gateway got %d snap requests, want 1", len(created))
+	}
+	if created[0].GrossAmount != 50000 || created[0].CustomerDetails.Email != env.user.Email {
+		t.Errorf("snap request = %+v", created[0])
+	}
+}
+
+func TestCreateTransactionRejectsCurrentPlan(t *testing.T) {
+	env := newTransactionTestEnv(t)
+	env.subscriptions.subscriptions = []domain.Subscription{{
+		ID:     uuid.New(),
+		UserID: env.user.ID,
+		PlanID: env.plan.ID,
+		Status: domain.SubscriptionStatusActive,
+	}}
+
+	_, err := env.service.CreateTransaction(context.Background(), env.user.ID, &domain.CreateTransactionRequest{PlanID: env.plan.ID})
+	if !errors.Is(err, ErrActiveSubscriptionExists) {
+		t.Fatalf("err = %v, want ErrActiveSubscriptionExists", err)
+	}
+	if len(env.gateway.Created()) != 0 {
+		t.Error("a snap transaction was created")
+	}
+}
+
+func TestCreateTransactionGatewayDown(t *testing.T) {
+	env := newTransactionTestEnv(t)
+	// The order ID is only known once it is created, so FailOrder cannot
+	// target it; fail every snap request instead.
+	env.service.paymentGateway = failingGateway{Mock: env.gateway}
+
+	_, err := env.service.CreateTransaction(context.Background(), env.user.ID, &domain.CreateTransactionRequest{PlanID: env.plan.ID})
+	if !errors.Is(err, ErrPaymentGatewayDown) {
+		t.Fatalf("err = %v, want ErrPaymentGatewayDown", err)
+	}
+	if len(env.transactions.transactions) != 0 {
+		t.Error("a transaction was stored")
+	}
+}
+
+type failingGateway struct {
+	*midtrans.Mock
+}
+
+func (failingGateway) CreateSnapTransaction(req midtrans.CreateTransactionRequest) (*midtrans.CreateTransactionResponse, error) {
+	return nil, midtrans.ErrUnavailable
+}
+
+func TestHandleWebhookRejectsUnverifiedNotifications(t *testing.T) {
+	tests := []struct {
+		name    string
+		payload func(env *transactionTestEnv, orderID string) map[string]interface{}
+		want    error
+	}{
+		{
+			name: "invalid signature",
+			payload: func(env *transactionTestEnv, orderID string) map[string]interface{} {
+				payload := env.notification(orderID, "settlement", time.Now())
+				payload["signature_key"] = "forged"
+				return payload
+			},
+			want: ErrInvalidSignature,
+		},
+		{
+			name: "tampered amount",
+			payload: func(env *transactionTestEnv, orderID string) map[string]interface{} {
+				payload := env.notification(orderID, "settlement", time.Now())
+				payload["gross_amount"] = "1.00"
+				return payload
+			},
+			want: ErrInvalidSignature,
+		},
+		{
+			name: "missing signature",
+			payload: func(env *transactionTestEnv, orderID string) map[string]interface{} {
+				payload := env.notification(orderID, "settlement", time.Now())
+				delete(payload, "signature_key")
+				return payload
+			},
+			want: ErrMissingSignature,
+		},
+		{
+			name: "stale",
+			payload: func(env *transactionTestEnv, orderID string) map[string]interface{} {
+				return env.notification(orderID, "settlement", time.Now().Add(-2*time.Hour))
+			},
+			want: ErrStaleNotification,
+		},
+		{
+			name: "from the future",
+			payload: func(env *transactionTestEnv, orderID string) map[string]interface{} {
+				return env.notification(orderID, "settlement", time.Now().Add(time.Hour))
+			},
+			want: ErrStaleNotification,
+		},
+		{
+			name: "no timestamp",
+			payload: func(env *transactionTestEnv, orderID string) map[string]interface{} {
+				payload := env.notification(orderID, "settlement", time.Now())
+				delete(payload, "transaction_time")
+				return payload
+			},
+			want: ErrStaleNotification,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			env := newTransactionTestEnv(t)
+			transaction := env.createPending(t)
+
+			err := env.service.HandleWebhook(context.Background(), tt.payload(env, transaction.OrderID))
+			if !errors.Is(err, tt.want) {
+				t.Fatalf("err = %v, want %v", err, tt.want)
+			}
+			if len(env.jobs.jobs) != 0 {
+				t.Error("a rejected notification was queued")
+			}
+		})
+	}
+}
+
+func TestHandleWebhookRejectsReplay(t *testing.T) {
+	env := newTransactionTestEnv(t)
+	transaction := env.createPending(t)
+	payload := env.notification(transaction.OrderID, "settlement", time.Now())
+
+	if err := env.service.HandleWebhook(context.Background(), payload); err != nil {
+		t.Fatalf("first delivery: %v", err)
+	}
+	if err := env.service.HandleWebhook(context.Background(), payload); !errors.Is(err, ErrDuplicateNotification) {
+		t.Fatalf("replayed delivery: err = %v, want ErrDuplicateNotification", err)
+	}
+	if len(env.jobs.jobs) != 1 {
+		t.Fatalf("queued %d jobs, want 1", len(env.jobs.jobs))
+	}
+}
+
+func TestHandleWebhookReleasesClaimWhenQueueFails(t *testing.T) {
+	env := newTransactionTestEnv(t)
+	transaction := env.createPending(t)
+	payload := env.notification(transaction.OrderID, "settlement", time.Now())
+
+	env.jobs.err = errors.New("redis down")
+	if err := env.service.HandleWebhook(context.Background(), payload); !errors.Is(err, ErrNotificationNotQueued) {
+		t.Fatalf("err = %v, want ErrNotificationNotQueued", err)
+	}
+
+	env.jobs.err = nil
+	if err := env.service.HandleWebhook(context.Background(), payload); err != nil {
+		t.Fatalf("Midtrans' retry was rejected: %v", err)
+	}
+}
+
+func TestProcessWebhookProvisionsSubscriptionOnSettlement(t *testing.T) {
+	env := newTransactionTestEnv(t)
+	transaction := env.createPending(t)
+	env.gateway.SetStatus(midtrans.TransactionStatusResponse{
+		TransactionID:     "mock-" + transaction.OrderID,
+		OrderID:           transaction.OrderID,
+		TransactionStatus: "settlement",
+		PaymentType:       "bank_transfer",
+		GrossAmount:       "50000.00",
+		StatusCode:        "200",
+	})
+
+	payload := env.notification(transaction.OrderID, "settlement", time.Now())
+	if err := env.service.HandleWebhook(context.Background(), payload); err != nil {
+		t.Fatalf("HandleWebhook: %v", err)
+	}
+	job := env.jobs.jobs[0]
+	if err := env.service.ProcessNotification(context.Background(), &job); err != nil {
+		t.Fatalf("ProcessNotification: %v", err)
+	}
+
+	paid, _ := env.transactions.FindByOrderID(context.Background(), transaction.OrderID)
+	if paid.Status != domain.TransactionStatusSuccess {
+		t.Errorf("status = %s, want success", paid.Status)
+	}
+	if paid.PaidAt == nil {
+		t.Error("paid_at was not set")
+	}
+	active := env.subscriptions.active(env.user.ID)
+	if len(active) != 1 {
+		t.Fatalf("user has %d active subscriptions, want 1", len(active))
+	}
+	if active[0].PlanID != env.plan.ID {
+		t.Errorf("subscription plan = %s, want %s", active[0].PlanID, env.plan.ID)
+	}
+	if paid.SubscriptionID == nil || *paid.SubscriptionID != active[0].ID {
+		t.Errorf("transaction subscription = %v, want %s", paid.SubscriptionID, active[0].ID)
+	}
+
+	// Midtrans delivers the same settlement again, e.g. because our
+	// acknowledgement was lost; the queue also retries jobs.
+	if err := env.service.ProcessNotification(context.Background(), &job); err != nil {
+		t.Fatalf("redelivered ProcessNotification: %v", err)
+	}
+	if got := len(env.subscriptions.subscriptions); got != 1 {
+		t.Errorf("redelivery created %d subscriptions in total, want 1", got)
+	}
+	if got := len(env.webhooks.events); got != 1 {
+		t.Errorf("published %d webhook events, want 1", got)
+	}
+}
+
+func TestProcessWebhookSavesCardFromVerifiedStatus(t *testing.T) {
+	env := newTransactionTestEnv(t)
+	transaction := env.createPending(t)
+	env.gateway.SetStatus(midtrans.TransactionStatusResponse{
+		TransactionID:         "mock-" + transaction.OrderID,
+		OrderID:               transaction.OrderID,
+		TransactionStatus:     "capture",
+		FraudStatus:           "accept",
+		PaymentType:           "credit_card",
+		StatusCode:            "200",
+		MaskedCard:            "481111-1114",
+		CardType:              "credit",
+		Bank:                  "bni",
+		SavedTokenID:          "verified-token",
+		SavedTokenIDExpiredAt: "2030-12-31 07:00:00",
+	})
+
+	// The card fields are not covered by the signature, so a tampered
+	// notification must not decide which token gets saved.
+	payload := env.notification(transaction.OrderID, "capture", time.Now())
+	payload["payment_type"] = "credit_card"
+	payload["saved_token_id"] = "forged-token"
+	payload["masked_card"] = "400000-0000"
+	job := domain.PaymentNotificationJob{OrderID: transaction.OrderID, Payload: payload}
+	if err := env.service.ProcessNotification(context.Background(), &job); err != nil {
+		t.Fatalf("ProcessNotification: %v", err)
+	}
+
+	if len(env.methods.methods) != 1 {
+		t.Fatalf("saved %d cards, want 1", len(env.methods.methods))
+	}
+	method := env.methods.methods[0]
+	if method.Token != "verified-token" || method.MaskedCard != "481111-1114" {
+		t.Errorf("saved card %s (%s), want the one from the status response", method.MaskedCard, method.Token)
+	}
+	if method.TokenExpiresAt == nil {
+		t.Error("token expiry was not saved")
+	}
+}
+
+func TestProcessWebhookLeavesPendingPaymentsAlone(t *testing.T) {
+	env := newTransactionTestEnv(t)
+	transaction := env.createPending(t)
+
+	// The notification claims settlement, but Midtrans still reports the
+	// payment as pending, and only Midtrans' answer counts.
+	env.gateway.SetStatus(midtrans.TransactionStatusResponse{
+		TransactionID:     "mock-" + transaction.OrderID,
+		OrderID:           transaction.OrderID,
+		TransactionStatus: "pending",
+		StatusCode:        "201",
+	})
+	job := domain.PaymentNotificationJob{
+		OrderID: transaction.OrderID,
+		Payload: env.notification(transaction.OrderID, "settlement", time.Now()),
+	}
+	if err := env.service.ProcessNotification(context.Background(), &job); err != nil {
+		t.Fatalf("ProcessNotification: %v", err)
+	}
+
+	stored, _ := env.transactions.FindByOrderID(context.Background(), transaction.OrderID)
+	if stored.Status != domain.TransactionStatusPending {
+		t.Errorf("status = %s, want pending", stored.Status)
+	}
+	if len(env.subscriptions.subscriptions) != 0 {
+		t.Error("a subscription was created for an unpaid order")
+	}
+}
+
+func TestProcessNotificationDropsUnknownOrder(t *testing.T) {
+	env := newTransactionTestEnv(t)
+	job := domain.PaymentNotificationJob{OrderID: "CAREERLY-unknown"}
+	if err := env.service.ProcessNotification(context.Background(), &job); err != nil {
+		t.Fatalf("err = %v, want the job dropped", err)
+	}
+}
+
+func TestProcessWebhookGatewayDown(t *testing.T) {
+	env := newTransactionTestEnv(t)
+	transaction := env.createPending(t)
+	env.gateway.FailOrder(transaction.OrderID, midtrans.ErrUnavailable)
+
+	job := domain.PaymentNotificationJob{
+		OrderID: transaction.OrderID,
+		Payload: env.notification(transaction.OrderID, "settlement", time.Now()),
+	}
+	if err := env.service.ProcessNotification(context.Background(), &job); !errors.Is(err, ErrPaymentGatewayDown) {
+		t.Fatalf("err = %v, want ErrPaymentGatewayDown so the job is retried", err)
+	}
+}
@@ -0,0 +1,43 @@
+package service
+
+import (
+	"context"
+	"time"
+
+	"github.com/raflytch/careerly-server/internal/domain"
+)
+
+type trashService struct {
+	resumeRepo    domain.ResumeRepository
+	interviewRepo domain.InterviewRepository
+	retention     time.Duration
+}
+
+func NewTrashService(resumeRepo domain.ResumeRepository, interviewRepo domain.InterviewRepository, retention time.Duration) domain.TrashService {
+	return &trashService{
+		resumeRepo:    resumeRepo,
+		interviewRepo: interviewRepo,
+		retention:     retention,
+	}
+}
+
+// Purge permanently deletes resumes and interviews that have been in the
+// trash for longer than the retention period.
+func (s *trashService) Purge(ctx context.Context) (*domain.TrashPurgeResult, error) {
+	cutoff := time.Now().Add(-s.retention)
+	result := &domain.TrashPurgeResult{}
+
+	resumes, err := s.resumeRepo.PurgeDeletedBefore(ctx, cutoff)
+	if err != nil {
+		return result, err
+	}
+	result.Resumes = resumes
+
+	interviews, err := s.interviewRepo.PurgeDeletedBefore(ctx, cutoff)
+	if err != nil {
+		return result, err
+	}
+	result.Interviews = interviews
+
+	return result, nil
+}
@@ -7,6 +7,7 @@ import (
 	"errors"
 	"fmt"
 	"strings"
+	"time"
 
 	"github.com/raflytch/careerly-server/internal/domain"
 
@@ -18,27 +19,40 @@ const (
 	deleteOTPPrefix   = "otp:delete:"
 	deleteOTPDuration = userCacheDuration
 	deleteOTPLength   = 6
+
+	contactEmailOTPPrefix   = "otp:contact_email:"
+	contactEmailOTPDuration = userCacheDuration
 )
 
 var (
-	ErrForbiddenAction = errors.New("only admin can perform this action")
+	ErrForbiddenAction       = errors.New("only admin can perform this action")
+	ErrContactEmailUnchanged = errors.New("this address is already your contact email")
 )
 
+type pendingContactEmail struct {
+	Email string `json:"email"`
+	OTP   string `json:"otp"`
+}
+
 type userService struct {
 	userRepo         domain.UserRepository
 	cacheRepo        domain.CacheRepository
 	subscriptionRepo domain.SubscriptionRepository
 	usageRepo        domain.UsageRepository
 	emailService     domain.EmailService
+	auditService     domain.AuditService
+	deletions        domain.AccountDeletionService
 }
 
-func NewUserService(userRepo domain.UserRepository, cacheRepo domain.CacheRepository, subscriptionRepo domain.SubscriptionRepository, usageRepo domain.UsageRepository, emailService domain.EmailService) domain.UserService {
+func NewUserService(userRepo domain.UserRepository, cacheRepo domain.CacheRepository, subscriptionRepo domain.SubscriptionRepository, usageRepo domain.UsageRepository, emailService domain.EmailService, auditService domain.AuditService, deletions domain.AccountDeletionService) domain.UserService {
 	return &userService{
 		userRepo:         userRepo,
 		cacheRepo:        cacheRepo,
 		subscriptionRepo: subscriptionRepo,
 		usageRepo:        usageRepo,
 		emailService:     emailService,
+		auditService:     auditService,
+		deletions:        deletions,
 	}
 }
 
@@ -75,13 +89,17 @@ func (s *userService) GetProfile(ctx context.Context, id uuid.UUID) (*domain.Use
 		return nil, err
 	}
 
+	loc := userLocation(user)
+	localizeUser(user, loc)
+
 	var subscription *domain.Subscription
 	sub, err := s.subscriptionRepo.FindActiveByUserID(ctx, id)
 	if err == nil {
 		subscription = sub
+		localizeSubscription(subscription, loc)
 	}
 
-	usages, err := s.usageRepo.GetAllCurrentMonthUsage(ctx, id)
+	usages, err := s.usageRepo.GetAllMonthUsage(ctx, id, usagePeriodMonth(time.Now(), loc))
 	if err != nil {
 		usages = []domain.Usage{}
 	}
@@ -93,7 +111,7 @@ func (s *userService) GetProfile(ctx context.Context, id uuid.UUID) (*domain.Use
 	}, nil
 }
 
-func (s *userService) GetAll(ctx context.Context, page, limit int) (*domain.PaginatedUsers, error) {
+func (s *userService) GetAll(ctx context.Context, filter domain.UserFilter, page, limit int) (*domain.PaginatedUsers, error) {
 	if page < 1 {
 		page = 1
 	}
@@ -106,12 +124,12 @@ func (s *userService) GetAll(ctx context.Context, page, limit int) (*domain.Pagi
 
 	offset := (page - 1) * limit
 
-	total, err := s.userRepo.Count(ctx)
+	total, err := s.userRepo.Count(ctx, filter)
 	if err != nil {
 		return nil, err
 	}
 
-	users, err := s.userRepo.FindAll(ctx, limit, offset)
+	users, err := s.userRepo.FindAll(ctx, filter, limit, offset)
 	if err != nil {
 		return nil, err
 	}
@@ -176,12 +194,56 @@ func (s *userService) UpdateAvatar(ctx context.Context, id uuid.UUID, avatarURL
 	return user, nil
 }
 
+func (s *userService) SetTwoFactor(ctx context.Context, id uuid.UUID, enabled bool) (*domain.User, error) {
+	user, err := s.userRepo.FindByID(ctx, id)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, domain.ErrUserNotFound
+		}
+		return nil, err
+	}
+
+	if err := s.userRepo.UpdateTwoFactor(ctx, id, enabled); err != nil {
+		return nil, err
+	}
+
+	user.TwoFactorEnabled = enabled
+
+	cacheKey := fmt.Sprintf("%s%s", userCachePrefix, id.String())
+	_ = s.cacheRepo.Delete(ctx, cacheKey)
+	_ = s.cacheRepo.DeleteByPattern(ctx, userListCacheKey+"*")
+
+	return user, nil
+}
+
+func (s *userService) SetTimezone(ctx context.Context, id uuid.UUID, timezone string) (*domain.User, error) {
+	user, err := s.userRepo.FindByID(ctx, id)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, domain.ErrUserNotFound
+		}
+		return nil, err
+	}
+
+	if err := s.userRepo.UpdateTimezone(ctx, id, timezone); err != nil {
+		return nil, err
+	}
+
+	user.Timezone = timezone
+
+	cacheKey := fmt.Sprintf("%s%s", userCachePrefix, id.String())
+	_ = s.cacheRepo.Delete(ctx, cacheKey)
+	_ = s.cacheRepo.DeleteByPattern(ctx, userListCacheKey+"*")
+
+	return user, nil
+}
+
 func (s *userService) Delete(ctx context.Context, id uuid.UUID, requestingUserRole domain.Role) error {
 	if requestingUserRole != domain.RoleAdmin {
 		return ErrForbiddenAction
 	}
 
-	_, err := s.userRepo.FindByID(ctx, id)
+	user, err := s.userRepo.FindByID(ctx, id)
 	if err != nil {
 		if errors.Is(err, sql.ErrNoRows) {
 			return domain.ErrUserNotFound
@@ -197,6 +259,8 @@ func (s *userService) Delete(ctx context.Context, id uuid.UUID, requestingUserRo
 	_ = s.cacheRepo.Delete(ctx, cacheKey)
 	_ = s.cacheRepo.DeleteByPattern(ctx, userListCacheKey+"*")
 
+	s.auditService.Record(ctx, domain.AuditActionUserDelete, domain.AuditTargetUser, id, user, nil)
+
 	return nil
 }
 
@@ -247,7 +311,13 @@ func (s *userService) VerifyDeleteOTP(ctx context.Context, user *domain.User, ot
 		return nil, domain.ErrInvalidOTP
 	}
 
+	deletion, err := s.deletions.Schedule(ctx, user)
+	if err != nil {
+		return nil, fmt.Errorf("failed to schedule account removal: %w", err)
+	}
+
 	if err := s.userRepo.SoftDelete(ctx, user.ID); err != nil {
+		_ = s.deletions.Cancel(ctx, user.ID)
 		return nil, fmt.Errorf("failed to delete account: %w", err)
 	}
 
@@ -258,7 +328,8 @@ func (s *userService) VerifyDeleteOTP(ctx context.Context, user *domain.User, ot
 	_ = s.cacheRepo.DeleteByPattern(ctx, userListCacheKey+"*")
 
 	return &domain.DeleteAccountResponse{
-		Message: "your account has been successfully deleted",
+		Message: "your account has been deleted and can be restored until it is permanently removed",
+		PurgeAt: &deletion.PurgeAt,
 	}, nil
 }
 
@@ -289,3 +360,96 @@ func (s *userService) ResendDeleteOTP(ctx context.Context, user *domain.User) (*
 		ExpiresIn: int(deleteOTPDuration.Seconds()),
 	}, nil
 }
+
+// RequestContactEmailChange sends an OTP to the new address. The login email
+// stays tied to the Google account; the contact email only changes where
+// notifications and receipts are sent, and only once the OTP is confirmed.
+func (s *userService) RequestContactEmailChange(ctx context.Context, user *domain.User, email string) (*domain.OTPResponse, error) {
+	email = strings.ToLower(strings.TrimSpace(email))
+	if email == contactEmail(user) {
+		return nil, ErrContactEmailUnchanged
+	}
+
+	otpKey := fmt.Sprintf("%s%s", contactEmailOTPPrefix, user.ID.String())
+	existing, err := s.cacheRepo.Get(ctx, otpKey)
+	if err == nil && existing != "" {
+		return nil, domain.ErrOTPAlreadySent
+	}
+
+	otp, err := GenerateOTP(deleteOTPLength)
+	if err != nil {
+		return nil, fmt.Errorf("failed to generate OTP: %w", err)
+	}
+
+	pending := pendingContactEmail{Email: email, OTP: otp}
+	if err := s.cacheRepo.Set(ctx, otpKey, pending, contactEmailOTPDuration); err != nil {
+		return nil, fmt.Errorf("failed to store OTP: %w", err)
+	}
+
+	if err := s.emailService.SendContactEmailOTP(ctx, email, otp); err != nil {
+		_ = s.cacheRepo.Delete(ctx, otpKey)
+		return nil, fmt.Errorf("failed to send OTP email: %w", err)
+	}
+
+	return &domain.OTPResponse{
+		Message:   "OTP has been sent to the new email address",
+		ExpiresIn: int(contactEmailOTPDuration.Seconds()),
+	}, nil
+}
+
+func (s *userService) VerifyContactEmailChange(ctx context.Context, user *domain.User, otp string) (*domain.User, error) {
+	otpKey := fmt.Sprintf("%s%s", contactEmailOTPPrefix, user.ID.String())
+	stored, err := s.cacheRepo.Get(ctx, otpKey)
+	if err != nil || stored == "" {
+		return nil, domain.ErrInvalidOTP
+	}
+
+	var pending pendingContactEmail
+	if err := json.Unmarshal([]byte(stored), &pending); err != nil || pending.OTP != otp {
+		return nil, domain.ErrInvalidOTP
+	}
+
+	contact := &pending.Email
+	if pending.Email == user.Email {
+		contact = nil
+	}
+
+	if err := s.userRepo.UpdateContactEmail(ctx, user.ID, contact); err != nil {
+		return nil, fmt.Errorf("failed to update contact email: %w", err)
+	}
+
+	_ = s.cacheRepo.Delete(ctx, otpKey)
+
+	updated := *user
+	updated.ContactEmail = contact
+
+	cacheKey := fmt.Sprintf("%s%s", userCachePrefix, user.ID.String())
+	_ = s.cacheRepo.Delete(ctx, cacheKey)
+	_ = s.cacheRepo.DeleteByPattern(ctx, userListCacheKey+"*")
+
+	return &updated, nil
+}
+
+func (s *userService) ClearContactEmail(ctx context.Context, user *domain.User) (*domain.User, error) {
+	if err := s.userRepo.UpdateContactEmail(ctx, user.ID, nil); err != nil {
+		return nil, fmt.Errorf("failed to clear contact email: %w", err)
+	}
+
+	updated := *user
+	updated.ContactEmail = nil
+
+	cacheKey := fmt.Sprintf("%s%s", userCachePrefix, user.ID.String())
+	_ = s.cacheRepo.Delete(ctx, cacheKey)
+	_ = s.cacheRepo.DeleteByPattern(ctx, userListCacheKey+"*")
+
+	return &updated, nil
+}
+
+// contactEmail is where notifications about the user's account should go:
+// the verified contact email when one is set, the login email otherwise.
+func contactEmail(user *domain.User) string {
+	if user.ContactEmail != nil && *user.ContactEmail != "" {
+		return *user.ContactEmail
+	}
+	return user.Email
+}
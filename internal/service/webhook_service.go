@@ -0,0 +1,456 @@
+package service
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"database/sql"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"log"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/raflytch/careerly-server/internal/domain"
+
+	"github.com/google/uuid"
+)
+
+const (
+	webhookMaxAttempts   = 8
+	webhookBaseDelay     = 1 * time.Minute
+	webhookMaxDelay      = 6 * time.Hour
+	webhookBatchSize     = 50
+	webhookTimeout       = 10 * time.Second
+	webhookMaxErrorBytes = 512
+	webhookSecretPrefix  = "whsec_"
+	webhookUserAgent     = "Careerly-Webhooks/1.0"
+)
+
+var (
+	ErrWebhookEndpointNotFound = errors.New("webhook endpoint not found")
+	ErrWebhookDeliveryNotFound = errors.New("webhook delivery not found")
+)
+
+type webhookService struct {
+	webhookRepo  domain.WebhookRepository
+	auditService domain.AuditService
+	httpClient   *http.Client
+}
+
+func NewWebhookService(webhookRepo domain.WebhookRepository, auditService domain.AuditService) domain.WebhookService {
+	return &webhookService{
+		webhookRepo:  webhookRepo,
+		auditService: auditService,
+		httpClient:   &http.Client{Timeout: webhookTimeout},
+	}
+}
+
+// Publish queues a delivery for every active endpoint subscribed to event.
+// Failures are logged rather than returned so that emitting an event never
+// breaks the operation that triggered it.
+func (s *webhookService) Publish(ctx context.Context, event domain.WebhookEvent, data interface{}) {
+	endpoints, err := s.webhookRepo.FindActiveEndpointsByEvent(ctx, event)
+	if err != nil {
+		log.Printf("Failed to load webhook endpoints for %s: %v", event, err)
+		return
+	}
+	if len(endpoints) == 0 {
+		return
+	}
+
+	now := time.Now()
+	payload, err := json.Marshal(domain.WebhookPayload{
+		ID:        uuid.New(),
+		Event:     event,
+		CreatedAt: now.UTC(),
+		Data:      data,
+	})
+	if err != nil {
+		log.Printf("Failed to encode webhook payload for %s: %v", event, err)
+		return
+	}
+
+	for _, endpoint := range endpoints {
+		delivery := &domain.WebhookDelivery{
+			ID:            uuid.New(),
+			EndpointID:    endpoint.ID,
+			Event:         event,
+			Payload:       payload,
+			Status:        domain.WebhookDeliveryStatusPending,
+			MaxAttempts:   webhookMaxAttempts,
+			NextAttemptAt: now,
+			CreatedAt:     now,
+			UpdatedAt:     now,
+		}
+		if err := s.webhookRepo.CreateDelivery(ctx, delivery); err != nil {
+			log.Printf("Failed to queue %s webhook for endpoint %s: %v", event, endpoint.ID, err)
+		}
+	}
+}
+
+func (s *webhookService) CreateEndpoint(ctx context.Context, createdBy uuid.UUID, req *domain.CreateWebhookEndpointRequest) (*domain.WebhookEndpointSecret, error) {
+	secret, err := generateWebhookSecret()
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	endpoint := &domain.WebhookEndpoint{
+		ID:          uuid.New(),
+		URL:         req.URL,
+		Description: req.Description,
+		Events:      toWebhookEvents(req.Events),
+		Secret:      secret,
+		IsActive:    true,
+		CreatedBy:   createdBy,
+		CreatedAt:   now,
+		UpdatedAt:   now,
+	}
+
+	if err := s.webhookRepo.CreateEndpoint(ctx, endpoint); err != nil {
+		return nil, err
+	}
+
+	s.auditService.Record(ctx, domain.AuditActionWebhookCreate, domain.AuditTargetWebhookEndpoint, endpoint.ID, nil, endpoint)
+
+	return &domain.WebhookEndpointSecret{Endpoint: endpoint, Secret: secret}, nil
+}
+
+func (s *webhookService) GetEndpoints(ctx context.Context, page, limit int) (*domain.PaginatedWebhookEndpoints, error) {
+	if page < 1 {
+		page = 1
+	}
+	if limit < 1 {
+		limit = 10
+	}
+	if limit > 100 {
+		limit = 100
+	}
+
+	offset := (page - 1) * limit
+
+	total, err := s.webhookRepo.CountEndpoints(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	endpoints, err := s.webhookRepo.FindEndpoints(ctx, limit, offset)
+	if err != nil {
+		return nil, err
+	}
+
+	totalPages := int(total) / limit
+	if int(total)%limit > 0 {
+		totalPages++
+	}
+
+	return &domain.PaginatedWebhookEndpoints{
+		Endpoints: endpoints,
+		Pagination: domain.Pagination{
+			Page:       page,
+			Limit:      limit,
+			Total:      total,
+			TotalPages: totalPages,
+		},
+	}, nil
+}
+
+func (s *webhookService) GetEndpoint(ctx context.Context, id uuid.UUID) (*domain.WebhookEndpoint, error) {
+	endpoint, err := s.webhookRepo.FindEndpointByID(ctx, id)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, ErrWebhookEndpointNotFound
+		}
+		return nil, err
+	}
+	return endpoint, nil
+}
+
+func (s *webhookService) UpdateEndpoint(ctx context.Context, id uuid.UUID, req *domain.UpdateWebhookEndpointRequest) (*domain.WebhookEndpoint, error) {
+	endpoint, err := s.GetEndpoint(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	before := *endpoint
+
+	if req.URL != nil {
+		endpoint.URL = *req.URL
+	}
+	if req.Description != nil {
+		endpoint.Description = *req.Description
+	}
+	if len(req.Events) > 0 {
+		endpoint.Events = toWebhookEvents(req.Events)
+	}
+	if req.IsActive != nil {
+		endpoint.IsActive = *req.IsActive
+	}
+	endpoint.UpdatedAt = time.Now()
+
+	if err := s.webhookRepo.UpdateEndpoint(ctx, endpoint); err != nil {
+		return nil, err
+	}
+
+	s.auditService.Record(ctx, domain.AuditActionWebhookUpdate, domain.AuditTargetWebhookEndpoint, endpoint.ID, before, endpoint)
+
+	return endpoint, nil
+}
+
+func (s *webhookService) RotateSecret(ctx context.Context, id uuid.UUID) (*domain.WebhookEndpointSecret, error) {
+	endpoint, err := s.GetEndpoint(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	secret, err := generateWebhookSecret()
+	if err != nil {
+		return nil, err
+	}
+	endpoint.Secret = secret
+	endpoint.UpdatedAt = time.Now()
+
+	if err := s.webhookRepo.UpdateEndpoint(ctx, endpoint); err != nil {
+		return nil, err
+	}
+
+	s.auditService.Record(ctx, domain.AuditActionWebhookRotate, domain.AuditTargetWebhookEndpoint, endpoint.ID, nil, nil)
+
+	return &domain.WebhookEndpointSecret{Endpoint: endpoint, Secret: secret}, nil
+}
+
+func (s *webhookService) DeleteEndpoint(ctx context.Context, id uuid.UUID) error {
+	endpoint, err := s.GetEndpoint(ctx, id)
+	if err != nil {
+		return err
+	}
+
+	if err := s.webhookRepo.SoftDeleteEndpoint(ctx, id); err != nil {
+		return err
+	}
+
+	s.auditService.Record(ctx, domain.AuditActionWebhookDelete, domain.AuditTargetWebhookEndpoint, id, endpoint, nil)
+
+	return nil
+}
+
+func (s *webhookService) GetDeliveries(ctx context.Context, endpointID uuid.UUID, status domain.WebhookDeliveryStatus, page, limit int) (*domain.PaginatedWebhookDeliveries, error) {
+	if _, err := s.GetEndpoint(ctx, endpointID); err != nil {
+		return nil, err
+	}
+
+	if page < 1 {
+		page = 1
+	}
+	if limit < 1 {
+		limit = 10
+	}
+	if limit > 100 {
+		limit = 100
+	}
+
+	offset := (page - 1) * limit
+
+	total, err := s.webhookRepo.CountDeliveries(ctx, endpointID, status)
+	if err != nil {
+		return nil, err
+	}
+
+	deliveries, err := s.webhookRepo.FindDeliveries(ctx, endpointID, status, limit, offset)
+	if err != nil {
+		return nil, err
+	}
+
+	totalPages := int(total) / limit
+	if int(total)%limit > 0 {
+		totalPages++
+	}
+
+	return &domain.PaginatedWebhookDeliveries{
+		Deliveries: deliveries,
+		Pagination: domain.Pagination{
+			Page:       page,
+			Limit:      limit,
+			Total:      total,
+			TotalPages: totalPages,
+		},
+	}, nil
+}
+
+func (s *webhookService) Redeliver(ctx context.Context, endpointID, deliveryID uuid.UUID) (*domain.WebhookDelivery, error) {
+	endpoint, err := s.GetEndpoint(ctx, endpointID)
+	if err != nil {
+		return nil, err
+	}
+
+	delivery, err := s.webhookRepo.FindDeliveryByID(ctx, deliveryID)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, ErrWebhookDeliveryNotFound
+		}
+		return nil, err
+	}
+	if delivery.EndpointID != endpoint.ID {
+		return nil, ErrWebhookDeliveryNotFound
+	}
+
+	if err := s.attempt(ctx, endpoint, delivery, true); err != nil {
+		return nil, err
+	}
+
+	return delivery, nil
+}
+
+func (s *webhookService) ProcessDue(ctx context.Context) (*domain.WebhookDispatchResult, error) {
+	deliveries, err := s.webhookRepo.FindDueDeliveries(ctx, time.Now(), webhookBatchSize)
+	if err != nil {
+		return nil, err
+	}
+
+	result := &domain.WebhookDispatchResult{}
+	endpoints := make(map[uuid.UUID]*domain.WebhookEndpoint)
+
+	for i := range deliveries {
+		delivery := &deliveries[i]
+
+		endpoint, ok := endpoints[delivery.EndpointID]
+		if !ok {
+			endpoint, err = s.webhookRepo.FindEndpointByID(ctx, delivery.EndpointID)
+			if err != nil && !errors.Is(err, sql.ErrNoRows) {
+				return result, err
+			}
+			endpoints[delivery.EndpointID] = endpoint
+		}
+
+		if endpoint == nil || !endpoint.IsActive {
+			if err := s.abandon(ctx, delivery, "endpoint was disabled or deleted"); err != nil {
+				return result, err
+			}
+			result.Processed++
+			result.Failed++
+			continue
+		}
+
+		if err := s.attempt(ctx, endpoint, delivery, false); err != nil {
+			return result, err
+		}
+
+		result.Processed++
+		switch delivery.Status {
+		case domain.WebhookDeliveryStatusSucceeded:
+			result.Succeeded++
+		case domain.WebhookDeliveryStatusFailed:
+			result.Failed++
+		}
+	}
+
+	return result, nil
+}
+
+// attempt sends one delivery and persists the outcome. A failed manual
+// redelivery of a delivery that is no longer pending marks it failed instead
+// of putting it back on the retry schedule.
+func (s *webhookService) attempt(ctx context.Context, endpoint *domain.WebhookEndpoint, delivery *domain.WebhookDelivery, manual bool) error {
+	now := time.Now()
+	delivery.Attempts++
+	delivery.UpdatedAt = now
+
+	statusCode, err := s.send(ctx, endpoint, delivery)
+	if statusCode > 0 {
+		delivery.ResponseStatus = &statusCode
+	}
+
+	if err == nil {
+		delivery.Status = domain.WebhookDeliveryStatusSucceeded
+		delivery.LastError = nil
+		delivery.DeliveredAt = &now
+		return s.webhookRepo.UpdateDelivery(ctx, delivery)
+	}
+
+	lastError := err.Error()
+	delivery.LastError = &lastError
+
+	switch {
+	case manual && delivery.Status != domain.WebhookDeliveryStatusPending:
+		delivery.Status = domain.WebhookDeliveryStatusFailed
+	case delivery.Attempts >= delivery.MaxAttempts:
+		delivery.Status = domain.WebhookDeliveryStatusFailed
+	default:
+		delivery.Status = domain.WebhookDeliveryStatusPending
+		delivery.NextAttemptAt = now.Add(exponentialBackoff(delivery.Attempts, webhookBaseDelay, webhookMaxDelay))
+	}
+
+	return s.webhookRepo.UpdateDelivery(ctx, delivery)
+}
+
+func (s *webhookService) abandon(ctx context.Context, delivery *domain.WebhookDelivery, reason string) error {
+	delivery.Status = domain.WebhookDeliveryStatusFailed
+	delivery.LastError = &reason
+	delivery.UpdatedAt = time.Now()
+	return s.webhookRepo.UpdateDelivery(ctx, delivery)
+}
+
+func (s *webhookService) send(ctx context.Context, endpoint *domain.WebhookEndpoint, delivery *domain.WebhookDelivery) (int, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, endpoint.URL, bytes.NewReader(delivery.Payload))
+	if err != nil {
+		return 0, err
+	}
+
+	timestamp := strconv.FormatInt(time.Now().Unix(), 10)
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("User-Agent", webhookUserAgent)
+	req.Header.Set("X-Careerly-Event", string(delivery.Event))
+	req.Header.Set("X-Careerly-Delivery", delivery.ID.String())
+	req.Header.Set("X-Careerly-Timestamp", timestamp)
+	req.Header.Set("X-Careerly-Signature", "v1="+signWebhookPayload(endpoint.Secret, timestamp, delivery.Payload))
+
+	resp, err := s.httpClient.Do(req)
+	if err != nil {
+		return 0, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode >= 200 && resp.StatusCode < 300 {
+		return resp.StatusCode, nil
+	}
+
+	body, _ := io.ReadAll(io.LimitReader(resp.Body, webhookMaxErrorBytes))
+	return resp.StatusCode, fmt.Errorf("endpoint responded with status %d: %s", resp.StatusCode, bytes.TrimSpace(body))
+}
+
+// signWebhookPayload returns the hex HMAC-SHA256 of "<timestamp>.<body>".
+// Receivers recompute it with their secret and should reject stale
+// timestamps to prevent replays.
+func signWebhookPayload(secret, timestamp string, body []byte) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte(timestamp))
+	mac.Write([]byte("."))
+	mac.Write(body)
+	return hex.EncodeToString(mac.Sum(nil))
+}
+
+func generateWebhookSecret() (string, error) {
+	b := make([]byte, 32)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+	return webhookSecretPrefix + hex.EncodeToString(b), nil
+}
+
+func toWebhookEvents(events []string) []domain.WebhookEvent {
+	seen := make(map[string]bool, len(events))
+	result := make([]domain.WebhookEvent, 0, len(events))
+	for _, event := range events {
+		if !seen[event] {
+			seen[event] = true
+			result = append(result, domain.WebhookEvent(event))
+		}
+	}
+	return result
+}
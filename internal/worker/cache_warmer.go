@@ -0,0 +1,28 @@
+package worker
+
+import (
+	"context"
+	"log"
+	"time"
+
+	"github.com/raflytch/careerly-server/internal/domain"
+)
+
+const cacheWarmTimeout = 2 * time.Minute
+
+// StartCacheWarmer warms hot caches once at startup and then on every
+// interval tick. A zero interval disables the periodic run.
+func StartCacheWarmer(ctx context.Context, warmService domain.CacheWarmService, interval time.Duration) {
+	runPeriodically(ctx, interval, cacheWarmTimeout, func(ctx context.Context) {
+		result, err := warmService.Warm(ctx)
+		if err != nil {
+			log.Printf("Cache warm failed: %v", err)
+			return
+		}
+
+		log.Printf("Cache warmed: %d plan pages, %d users in %dms", result.PlanPages, result.Users, result.DurationMs)
+		for _, e := range result.Errors {
+			log.Printf("Cache warm warning: %s", e)
+		}
+	})
+}
@@ -0,0 +1,27 @@
+package worker
+
+import (
+	"context"
+	"log"
+	"time"
+
+	"github.com/raflytch/careerly-server/internal/domain"
+)
+
+const interviewSchedulerTimeout = 1 * time.Minute
+
+// StartInterviewScheduler sends reminders for upcoming scheduled interviews
+// and flips them to ready once their scheduled time has passed.
+func StartInterviewScheduler(ctx context.Context, schedulerService domain.InterviewSchedulerService, interval time.Duration) {
+	runPeriodically(ctx, interval, interviewSchedulerTimeout, func(ctx context.Context) {
+		result, err := schedulerService.ProcessDue(ctx)
+		if err != nil {
+			log.Printf("Interview scheduler failed: %v", err)
+			return
+		}
+
+		if result.RemindersQueued > 0 || result.MarkedReady > 0 {
+			log.Printf("Interview scheduler: %d reminders queued, %d interviews ready", result.RemindersQueued, result.MarkedReady)
+		}
+	})
+}
@@ -0,0 +1,26 @@
+package worker
+
+import (
+	"context"
+	"log"
+	"time"
+
+	"github.com/raflytch/careerly-server/internal/domain"
+)
+
+const paymentReconcileTimeout = 2 * time.Minute
+
+// StartPaymentReconciler syncs long-pending transactions with Midtrans and
+// expires the ones that were never paid.
+func StartPaymentReconciler(ctx context.Context, transactionService domain.TransactionService, interval, olderThan time.Duration) {
+	runPeriodically(ctx, interval, paymentReconcileTimeout, func(ctx context.Context) {
+		result, err := transactionService.ReconcilePending(ctx, olderThan)
+		if err != nil {
+			log.Printf("Payment reconciliation failed: %v", err)
+		}
+
+		if result != nil && result.Updated > 0 {
+			log.Printf("Payment reconciliation: %d checked, %d updated, %d expired, %d failed", result.Checked, result.Updated, result.Expired, result.Failed)
+		}
+	})
+}
@@ -0,0 +1,27 @@
+package worker
+
+import (
+	"context"
+	"log"
+	"time"
+
+	"github.com/raflytch/careerly-server/internal/domain"
+)
+
+const provisioningRetryTimeout = 1 * time.Minute
+
+// StartProvisioningRetrier retries failed subscription provisioning jobs
+// whose backoff has elapsed.
+func StartProvisioningRetrier(ctx context.Context, provisioningService domain.ProvisioningService, interval time.Duration) {
+	runPeriodically(ctx, interval, provisioningRetryTimeout, func(ctx context.Context) {
+		result, err := provisioningService.ProcessDue(ctx)
+		if err != nil {
+			log.Printf("Provisioning retry failed: %v", err)
+			return
+		}
+
+		if result.Processed > 0 {
+			log.Printf("Provisioning retry: %d processed, %d succeeded, %d exhausted", result.Processed, result.Succeeded, result.Failed)
+		}
+	})
+}
@@ -0,0 +1,20 @@
+package worker
+
+import (
+	"context"
+	"time"
+
+	"github.com/raflytch/careerly-server/internal/database"
+)
+
+const replicaCheckTimeout = 5 * time.Second
+
+// StartReplicaMonitor health checks the read replica so list queries move to
+// the primary while it is down and back once it recovers.
+func StartReplicaMonitor(ctx context.Context, router *database.Router, interval time.Duration) {
+	if !router.HasReplica() {
+		return
+	}
+
+	runPeriodically(ctx, interval, replicaCheckTimeout, router.CheckReplica)
+}
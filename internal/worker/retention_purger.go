@@ -0,0 +1,27 @@
+package worker
+
+import (
+	"context"
+	"log"
+	"time"
+
+	"github.com/raflytch/careerly-server/internal/domain"
+)
+
+const retentionPurgeTimeout = 10 * time.Minute
+
+// StartRetentionPurger removes free-plan ATS checks and interviews that
+// have outlived the plan's history retention window.
+func StartRetentionPurger(ctx context.Context, retentionService domain.RetentionService, interval time.Duration) {
+	runPeriodically(ctx, interval, retentionPurgeTimeout, func(ctx context.Context) {
+		result, err := retentionService.Purge(ctx)
+		if err != nil {
+			log.Printf("Retention purge failed: %v", err)
+			return
+		}
+
+		if result.ATSChecks > 0 || result.Interviews > 0 {
+			log.Printf("Retention purge: %d ats checks, %d interviews permanently deleted", result.ATSChecks, result.Interviews)
+		}
+	})
+}
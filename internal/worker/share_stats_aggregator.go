@@ -0,0 +1,27 @@
+package worker
+
+import (
+	"context"
+	"log"
+	"time"
+
+	"github.com/raflytch/careerly-server/internal/domain"
+)
+
+const shareStatsAggregateTimeout = 5 * time.Minute
+
+// StartShareStatsAggregator rolls share link events from finished days into
+// the daily counts the resume analytics endpoint reads.
+func StartShareStatsAggregator(ctx context.Context, shareService domain.ResumeShareService, interval time.Duration) {
+	runPeriodically(ctx, interval, shareStatsAggregateTimeout, func(ctx context.Context) {
+		moved, err := shareService.AggregateAnalytics(ctx)
+		if err != nil {
+			log.Printf("Share stats aggregation failed: %v", err)
+			return
+		}
+
+		if moved > 0 {
+			log.Printf("Share stats aggregation: %d events rolled into daily counts", moved)
+		}
+	})
+}
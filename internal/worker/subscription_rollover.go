@@ -0,0 +1,27 @@
+package worker
+
+import (
+	"context"
+	"log"
+	"time"
+
+	"github.com/raflytch/careerly-server/internal/domain"
+)
+
+const subscriptionRolloverTimeout = 2 * time.Minute
+
+// StartSubscriptionRollover expires subscriptions whose period has ended and
+// starts any plan change the user scheduled for that moment.
+func StartSubscriptionRollover(ctx context.Context, subscriptionService domain.SubscriptionService, interval time.Duration) {
+	runPeriodically(ctx, interval, subscriptionRolloverTimeout, func(ctx context.Context) {
+		result, err := subscriptionService.Rollover(ctx)
+		if err != nil {
+			log.Printf("Subscription rollover failed: %v", err)
+			return
+		}
+
+		if result.Expired > 0 || result.Failed > 0 {
+			log.Printf("Subscription rollover: %d expired, %d switched plans, %d failed", result.Expired, result.Switched, result.Failed)
+		}
+	})
+}
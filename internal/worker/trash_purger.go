@@ -0,0 +1,27 @@
+package worker
+
+import (
+	"context"
+	"log"
+	"time"
+
+	"github.com/raflytch/careerly-server/internal/domain"
+)
+
+const trashPurgeTimeout = 5 * time.Minute
+
+// StartTrashPurger permanently removes soft-deleted resumes and interviews
+// once they have outlived the trash retention period.
+func StartTrashPurger(ctx context.Context, trashService domain.TrashService, interval time.Duration) {
+	runPeriodically(ctx, interval, trashPurgeTimeout, func(ctx context.Context) {
+		result, err := trashService.Purge(ctx)
+		if err != nil {
+			log.Printf("Trash purge failed: %v", err)
+			return
+		}
+
+		if result.Resumes > 0 || result.Interviews > 0 {
+			log.Printf("Trash purge: %d resumes, %d interviews permanently deleted", result.Resumes, result.Interviews)
+		}
+	})
+}
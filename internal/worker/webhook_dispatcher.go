@@ -0,0 +1,27 @@
+package worker
+
+import (
+	"context"
+	"log"
+	"time"
+
+	"github.com/raflytch/careerly-server/internal/domain"
+)
+
+const webhookDispatchTimeout = 2 * time.Minute
+
+// StartWebhookDispatcher delivers queued webhook events and retries failed
+// deliveries whose backoff has elapsed.
+func StartWebhookDispatcher(ctx context.Context, webhookService domain.WebhookService, interval time.Duration) {
+	runPeriodically(ctx, interval, webhookDispatchTimeout, func(ctx context.Context) {
+		result, err := webhookService.ProcessDue(ctx)
+		if err != nil {
+			log.Printf("Webhook dispatch failed: %v", err)
+			return
+		}
+
+		if result.Processed > 0 {
+			log.Printf("Webhook dispatch: %d processed, %d succeeded, %d failed", result.Processed, result.Succeeded, result.Failed)
+		}
+	})
+}
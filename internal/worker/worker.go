@@ -0,0 +1,37 @@
+package worker
+
+import (
+	"context"
+	"time"
+)
+
+// runPeriodically runs task immediately and then on every interval tick until
+// ctx is canceled. A non-positive interval runs the task only once.
+func runPeriodically(ctx context.Context, interval, timeout time.Duration, task func(ctx context.Context)) {
+	go func() {
+		runWithTimeout(ctx, timeout, task)
+
+		if interval <= 0 {
+			return
+		}
+
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+				runWithTimeout(ctx, timeout, task)
+			}
+		}
+	}()
+}
+
+func runWithTimeout(ctx context.Context, timeout time.Duration, task func(ctx context.Context)) {
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	task(ctx)
+}
@@ -0,0 +1,174 @@
+package circuitbreaker
+
+import (
+	"errors"
+	"sync"
+	"time"
+)
+
+var ErrOpen = errors.New("circuit breaker is open")
+
+type State int
+
+const (
+	StateClosed State = iota
+	StateOpen
+	StateHalfOpen
+)
+
+func (s State) String() string {
+	switch s {
+	case StateOpen:
+		return "open"
+	case StateHalfOpen:
+		return "half_open"
+	default:
+		return "closed"
+	}
+}
+
+type Config struct {
+	Name             string
+	FailureThreshold int
+	OpenTimeout      time.Duration
+	HalfOpenMaxCalls int
+}
+
+type Snapshot struct {
+	Name                string    `json:"name"`
+	State               string    `json:"state"`
+	StateValue          int       `json:"state_value"`
+	ConsecutiveFailures int       `json:"consecutive_failures"`
+	Rejected            int64     `json:"rejected"`
+	Opened              int64     `json:"opened"`
+	OpenedAt            time.Time `json:"opened_at,omitempty"`
+}
+
+// Breaker opens after FailureThreshold consecutive failures, rejects calls
+// for OpenTimeout, then lets up to HalfOpenMaxCalls probes through. A
+// successful probe closes it again; a failed one reopens it.
+type Breaker struct {
+	cfg Config
+
+	mu               sync.Mutex
+	state            State
+	failures         int
+	halfOpenInFlight int
+	openedAt         time.Time
+	rejected         int64
+	opened           int64
+}
+
+func New(cfg Config) *Breaker {
+	if cfg.FailureThreshold < 1 {
+		cfg.FailureThreshold = 5
+	}
+	if cfg.OpenTimeout <= 0 {
+		cfg.OpenTimeout = 30 * time.Second
+	}
+	if cfg.HalfOpenMaxCalls < 1 {
+		cfg.HalfOpenMaxCalls = 1
+	}
+	return &Breaker{cfg: cfg}
+}
+
+func (b *Breaker) Name() string {
+	return b.cfg.Name
+}
+
+// Allow reports whether a call may proceed. Every allowed call must be
+// followed by exactly one Success or Failure.
+func (b *Breaker) Allow() error {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if b.state == StateOpen && time.Since(b.openedAt) >= b.cfg.OpenTimeout {
+		b.state = StateHalfOpen
+		b.halfOpenInFlight = 0
+	}
+
+	switch b.state {
+	case StateOpen:
+		b.rejected++
+		return ErrOpen
+	case StateHalfOpen:
+		if b.halfOpenInFlight >= b.cfg.HalfOpenMaxCalls {
+			b.rejected++
+			return ErrOpen
+		}
+		b.halfOpenInFlight++
+	}
+
+	return nil
+}
+
+func (b *Breaker) Success() {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	b.failures = 0
+	if b.state == StateHalfOpen {
+		b.state = StateClosed
+		b.halfOpenInFlight = 0
+	}
+}
+
+func (b *Breaker) Failure() {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	b.failures++
+	if b.state == StateHalfOpen || b.failures >= b.cfg.FailureThreshold {
+		if b.state != StateOpen {
+			b.opened++
+		}
+		b.state = StateOpen
+		b.openedAt = time.Now()
+		b.halfOpenInFlight = 0
+	}
+}
+
+// Skip releases an allowed call without recording an outcome, for calls
+// that ended for reasons unrelated to the upstream's health.
+func (b *Breaker) Skip() {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if b.state == StateHalfOpen && b.halfOpenInFlight > 0 {
+		b.halfOpenInFlight--
+	}
+}
+
+// Available reports whether a call would currently be let through, without
+// reserving a half-open probe slot.
+func (b *Breaker) Available() bool {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	switch b.state {
+	case StateOpen:
+		return time.Since(b.openedAt) >= b.cfg.OpenTimeout
+	case StateHalfOpen:
+		return b.halfOpenInFlight < b.cfg.HalfOpenMaxCalls
+	default:
+		return true
+	}
+}
+
+func (b *Breaker) Snapshot() Snapshot {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	snapshot := Snapshot{
+		Name:                b.cfg.Name,
+		State:               b.state.String(),
+		StateValue:          int(b.state),
+		ConsecutiveFailures: b.failures,
+		Rejected:            b.rejected,
+		Opened:              b.opened,
+	}
+	if b.state != StateClosed {
+		snapshot.OpenedAt = b.openedAt
+	}
+	return snapshot
+}
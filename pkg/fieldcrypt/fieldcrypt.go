@@ -0,0 +1,153 @@
+package fieldcrypt
+
+import (
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"strings"
+)
+
+// prefix marks an encrypted value. The full format is
+// "enc:v1:<key id>:<base64(nonce || ciphertext)>".
+const prefix = "enc:v1:"
+
+const keySize = 32
+
+var (
+	ErrUnknownKey       = errors.New("fieldcrypt: value was encrypted with an unknown key")
+	ErrMalformedValue   = errors.New("fieldcrypt: malformed encrypted value")
+	ErrInvalidKey       = errors.New("fieldcrypt: keys must be 32 bytes")
+	ErrActiveKeyMissing = errors.New("fieldcrypt: active key is not in the key ring")
+)
+
+// Cipher seals individual string fields with AES-256-GCM. It encrypts with
+// the active key and decrypts with any key in its ring, which lets keys be
+// rotated without a flag day.
+type Cipher struct {
+	aeads    map[string]cipher.AEAD
+	activeID string
+}
+
+// New builds a Cipher from a key ring of 32-byte keys indexed by key ID.
+func New(activeID string, keys map[string][]byte) (*Cipher, error) {
+	aeads := make(map[string]cipher.AEAD, len(keys))
+	for id, key := range keys {
+		if len(key) != keySize {
+			return nil, fmt.Errorf("%w: key %q has %d bytes", ErrInvalidKey, id, len(key))
+		}
+		block, err := aes.NewCipher(key)
+		if err != nil {
+			return nil, err
+		}
+		aead, err := cipher.NewGCM(block)
+		if err != nil {
+			return nil, err
+		}
+		aeads[id] = aead
+	}
+
+	if _, ok := aeads[activeID]; !ok {
+		return nil, ErrActiveKeyMissing
+	}
+
+	return &Cipher{aeads: aeads, activeID: activeID}, nil
+}
+
+// ParseKeys parses a key ring spec of the form "id1:base64key,id2:base64key".
+func ParseKeys(spec string) (map[string][]byte, error) {
+	keys := make(map[string][]byte)
+	for _, entry := range strings.Split(spec, ",") {
+		entry = strings.TrimSpace(entry)
+		if entry == "" {
+			continue
+		}
+		id, encoded, ok := strings.Cut(entry, ":")
+		if !ok || id == "" {
+			return nil, fmt.Errorf("fieldcrypt: key entry %q must be id:base64key", entry)
+		}
+		key, err := base64.StdEncoding.DecodeString(encoded)
+		if err != nil {
+			return nil, fmt.Errorf("fieldcrypt: key %q is not valid base64: %w", id, err)
+		}
+		keys[id] = key
+	}
+	return keys, nil
+}
+
+// NewFromSpec builds a Cipher from a ParseKeys spec. An empty spec returns a
+// nil Cipher, which callers treat as encryption being disabled.
+func NewFromSpec(activeID, spec string) (*Cipher, error) {
+	if strings.TrimSpace(spec) == "" {
+		return nil, nil
+	}
+	keys, err := ParseKeys(spec)
+	if err != nil {
+		return nil, err
+	}
+	return New(activeID, keys)
+}
+
+// IsEncrypted reports whether value was produced by Encrypt.
+func IsEncrypted(value string) bool {
+	return strings.HasPrefix(value, prefix)
+}
+
+// Encrypt seals plaintext with the active key. Empty strings stay empty.
+func (c *Cipher) Encrypt(plaintext string) (string, error) {
+	if plaintext == "" {
+		return "", nil
+	}
+
+	aead := c.aeads[c.activeID]
+	nonce := make([]byte, aead.NonceSize())
+	if _, err := rand.Read(nonce); err != nil {
+		return "", err
+	}
+
+	sealed := aead.Seal(nonce, nonce, []byte(plaintext), []byte(c.activeID))
+	return prefix + c.activeID + ":" + base64.RawStdEncoding.EncodeToString(sealed), nil
+}
+
+// Decrypt opens a value produced by Encrypt. Values without the encryption
+// prefix are returned unchanged so rows written before encryption was
+// enabled remain readable.
+func (c *Cipher) Decrypt(value string) (string, error) {
+	if !IsEncrypted(value) {
+		return value, nil
+	}
+
+	id, encoded, ok := strings.Cut(strings.TrimPrefix(value, prefix), ":")
+	if !ok {
+		return "", ErrMalformedValue
+	}
+	aead, ok := c.aeads[id]
+	if !ok {
+		return "", fmt.Errorf("%w: %q", ErrUnknownKey, id)
+	}
+
+	sealed, err := base64.RawStdEncoding.DecodeString(encoded)
+	if err != nil || len(sealed) < aead.NonceSize() {
+		return "", ErrMalformedValue
+	}
+
+	plaintext, err := aead.Open(nil, sealed[:aead.NonceSize()], sealed[aead.NonceSize():], []byte(id))
+	if err != nil {
+		return "", ErrMalformedValue
+	}
+	return string(plaintext), nil
+}
+
+// NeedsRotation reports whether value should be rewritten: it is either
+// stored in plaintext or encrypted with a key other than the active one.
+func (c *Cipher) NeedsRotation(value string) bool {
+	if value == "" {
+		return false
+	}
+	if !IsEncrypted(value) {
+		return true
+	}
+	return !strings.HasPrefix(value, prefix+c.activeID+":")
+}
@@ -0,0 +1,90 @@
+package genai
+
+import (
+	"context"
+	"errors"
+)
+
+type provider interface {
+	Available() bool
+	GenerateText(ctx context.Context, prompt string) (string, error)
+	GenerateTextWithSystemPrompt(ctx context.Context, systemPrompt, userPrompt string) (string, error)
+	GenerateFromFile(ctx context.Context, file File, prompt string) (string, error)
+	GenerateFromFileWithSystemPrompt(ctx context.Context, file File, systemPrompt, userPrompt string) (string, error)
+	GenerateJSONFromData(ctx context.Context, data []byte, mimeType, prompt string) (string, error)
+	GenerateJSON(ctx context.Context, prompt string) (string, error)
+	GenerateJSONWithSystemPrompt(ctx context.Context, systemPrompt, userPrompt string) (string, error)
+}
+
+// Fallback sends every call to the primary provider and retries it on the
+// secondary when the primary fails. Calls rejected by the AI budget, or
+// whose context is already done, are not retried.
+type Fallback struct {
+	primary   provider
+	secondary provider
+}
+
+func NewFallback(primary, secondary provider) *Fallback {
+	return &Fallback{primary: primary, secondary: secondary}
+}
+
+func (f *Fallback) Available() bool {
+	return f.primary.Available() || f.secondary.Available()
+}
+
+func (f *Fallback) GenerateText(ctx context.Context, prompt string) (string, error) {
+	return f.do(ctx, func(p provider) (string, error) {
+		return p.GenerateText(ctx, prompt)
+	})
+}
+
+func (f *Fallback) GenerateTextWithSystemPrompt(ctx context.Context, systemPrompt, userPrompt string) (string, error) {
+	return f.do(ctx, func(p provider) (string, error) {
+		return p.GenerateTextWithSystemPrompt(ctx, systemPrompt, userPrompt)
+	})
+}
+
+func (f *Fallback) GenerateFromFile(ctx context.Context, file File, prompt string) (string, error) {
+	return f.do(ctx, func(p provider) (string, error) {
+		return p.GenerateFromFile(ctx, file, prompt)
+	})
+}
+
+func (f *Fallback) GenerateFromFileWithSystemPrompt(ctx context.Context, file File, systemPrompt, userPrompt string) (string, error) {
+	return f.do(ctx, func(p provider) (string, error) {
+		return p.GenerateFromFileWithSystemPrompt(ctx, file, systemPrompt, userPrompt)
+	})
+}
+
+func (f *Fallback) GenerateJSONFromData(ctx context.Context, data []byte, mimeType, prompt string) (string, error) {
+	return f.do(ctx, func(p provider) (string, error) {
+		return p.GenerateJSONFromData(ctx, data, mimeType, prompt)
+	})
+}
+
+func (f *Fallback) GenerateJSON(ctx context.Context, prompt string) (string, error) {
+	return f.do(ctx, func(p provider) (string, error) {
+		return p.GenerateJSON(ctx, prompt)
+	})
+}
+
+func (f *Fallback) GenerateJSONWithSystemPrompt(ctx context.Context, systemPrompt, userPrompt string) (string, error) {
+	return f.do(ctx, func(p provider) (string, error) {
+		return p.GenerateJSONWithSystemPrompt(ctx, systemPrompt, userPrompt)
+	})
+}
+
+// do returns both errors joined when the secondary fails too, so callers
+// can still match either provider's error with errors.Is.
+func (f *Fallback) do(ctx context.Context, call func(p provider) (string, error)) (string, error) {
+	result, err := call(f.primary)
+	if err == nil || errors.Is(err, ErrBudgetExceeded) || ctx.Err() != nil {
+		return result, err
+	}
+
+	result, fallbackErr := call(f.secondary)
+	if fallbackErr != nil {
+		return "", errors.Join(err, fallbackErr)
+	}
+	return result, nil
+}
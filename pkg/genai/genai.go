@@ -4,19 +4,35 @@ import (
 	"context"
 	"fmt"
 	"io"
-	"mime/multipart"
+	"time"
 
 	"google.golang.org/genai"
 )
 
 type Client struct {
+	caller
 	client *genai.Client
 	model  string
 }
 
+// File is an uploaded document sent to the model by reading Reader, which
+// is rewound before every attempt so a fallback provider can read it again.
+type File struct {
+	Reader   io.ReadSeeker
+	MIMEType string
+	Name     string
+}
+
 type Config struct {
 	APIKey string
 	Model  string
+	// Timeout bounds every model call; FeatureTimeouts overrides it for the
+	// feature set via WithCallMetadata. Zero disables the deadline.
+	Timeout         time.Duration
+	FeatureTimeouts map[string]time.Duration
+	// Breaker settings; zero values fall back to the circuitbreaker defaults.
+	BreakerFailureThreshold int
+	BreakerOpenTimeout      time.Duration
 }
 
 func NewClient(cfg Config) (*Client, error) {
@@ -34,18 +50,14 @@ func NewClient(cfg Config) (*Client, error) {
 	}
 
 	return &Client{
+		caller: newCaller("genai", cfg.Timeout, cfg.FeatureTimeouts, cfg.BreakerFailureThreshold, cfg.BreakerOpenTimeout),
 		client: client,
 		model:  model,
 	}, nil
 }
 
 func (c *Client) GenerateText(ctx context.Context, prompt string) (string, error) {
-	result, err := c.client.Models.GenerateContent(
-		ctx,
-		c.model,
-		genai.Text(prompt),
-		nil,
-	)
+	result, err := c.generate(ctx, genai.Text(prompt), nil)
 	if err != nil {
 		return "", fmt.Errorf("failed to generate content: %w", err)
 	}
@@ -61,75 +73,47 @@ func (c *Client) GenerateTextWithSystemPrompt(ctx context.Context, systemPrompt,
 		},
 	}
 
-	result, err := c.client.Models.GenerateContent(
-		ctx,
-		c.model,
-		genai.Text(userPrompt),
-		config,
-	)
+	result, err := c.generate(ctx, genai.Text(userPrompt), config)
 	if err != nil {
 		return "", fmt.Errorf("failed to generate content: %w", err)
 	}
 	return result.Text(), nil
 }
 
-func (c *Client) GenerateFromFile(ctx context.Context, file *multipart.FileHeader, prompt string) (string, error) {
-	f, err := file.Open()
-	if err != nil {
-		return "", fmt.Errorf("failed to open file: %w", err)
-	}
-	defer f.Close()
-
-	data, err := io.ReadAll(f)
+func (c *Client) GenerateFromFile(ctx context.Context, file File, prompt string) (string, error) {
+	result, err := c.generateFromFile(ctx, file, prompt, nil)
 	if err != nil {
-		return "", fmt.Errorf("failed to read file: %w", err)
+		return "", fmt.Errorf("failed to generate content from file: %w", err)
 	}
+	return result.Text(), nil
+}
 
-	contents := []*genai.Content{
-		{
+func (c *Client) GenerateFromFileWithSystemPrompt(ctx context.Context, file File, systemPrompt, userPrompt string) (string, error) {
+	config := &genai.GenerateContentConfig{
+		SystemInstruction: &genai.Content{
 			Parts: []*genai.Part{
-				{Text: prompt},
-				{
-					InlineData: &genai.Blob{
-						MIMEType: file.Header.Get("Content-Type"),
-						Data:     data,
-					},
-				},
+				{Text: systemPrompt},
 			},
 		},
 	}
 
-	result, err := c.client.Models.GenerateContent(
-		ctx,
-		c.model,
-		contents,
-		nil,
-	)
+	result, err := c.generateFromFile(ctx, file, userPrompt, config)
 	if err != nil {
 		return "", fmt.Errorf("failed to generate content from file: %w", err)
 	}
 	return result.Text(), nil
 }
 
-func (c *Client) GenerateFromFileWithSystemPrompt(ctx context.Context, file *multipart.FileHeader, systemPrompt, userPrompt string) (string, error) {
-	f, err := file.Open()
-	if err != nil {
-		return "", fmt.Errorf("failed to open file: %w", err)
-	}
-	defer f.Close()
-
-	data, err := io.ReadAll(f)
-	if err != nil {
-		return "", fmt.Errorf("failed to read file: %w", err)
-	}
-
+// GenerateJSONFromData sends raw media such as audio or video alongside the
+// prompt and asks for a JSON response.
+func (c *Client) GenerateJSONFromData(ctx context.Context, data []byte, mimeType, prompt string) (string, error) {
 	contents := []*genai.Content{
 		{
 			Parts: []*genai.Part{
-				{Text: userPrompt},
+				{Text: prompt},
 				{
 					InlineData: &genai.Blob{
-						MIMEType: file.Header.Get("Content-Type"),
+						MIMEType: mimeType,
 						Data:     data,
 					},
 				},
@@ -138,21 +122,12 @@ func (c *Client) GenerateFromFileWithSystemPrompt(ctx context.Context, file *mul
 	}
 
 	config := &genai.GenerateContentConfig{
-		SystemInstruction: &genai.Content{
-			Parts: []*genai.Part{
-				{Text: systemPrompt},
-			},
-		},
+		ResponseMIMEType: "application/json",
 	}
 
-	result, err := c.client.Models.GenerateContent(
-		ctx,
-		c.model,
-		contents,
-		config,
-	)
+	result, err := c.generate(ctx, contents, config)
 	if err != nil {
-		return "", fmt.Errorf("failed to generate content from file: %w", err)
+		return "", fmt.Errorf("failed to generate json content from data: %w", err)
 	}
 	return result.Text(), nil
 }
@@ -162,12 +137,7 @@ func (c *Client) GenerateJSON(ctx context.Context, prompt string) (string, error
 		ResponseMIMEType: "application/json",
 	}
 
-	result, err := c.client.Models.GenerateContent(
-		ctx,
-		c.model,
-		genai.Text(prompt),
-		config,
-	)
+	result, err := c.generate(ctx, genai.Text(prompt), config)
 	if err != nil {
 		return "", fmt.Errorf("failed to generate json content: %w", err)
 	}
@@ -184,12 +154,7 @@ func (c *Client) GenerateJSONWithSystemPrompt(ctx context.Context, systemPrompt,
 		},
 	}
 
-	result, err := c.client.Models.GenerateContent(
-		ctx,
-		c.model,
-		genai.Text(userPrompt),
-		config,
-	)
+	result, err := c.generate(ctx, genai.Text(userPrompt), config)
 	if err != nil {
 		return "", fmt.Errorf("failed to generate json content: %w", err)
 	}
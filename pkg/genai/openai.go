@@ -0,0 +1,296 @@
+package genai
+
+import (
+	"bytes"
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"time"
+)
+
+const (
+	defaultOpenAIBaseURL = "https://api.openai.com/v1"
+	defaultOpenAIModel   = "gpt-4o-mini"
+)
+
+var ErrUnsupportedInput = errors.New("input type is not supported by this provider")
+
+// OpenAIClient talks to any OpenAI-compatible chat completions API. It
+// offers the same calls as Client so it can stand in for Gemini.
+type OpenAIClient struct {
+	caller
+	httpClient *http.Client
+	baseURL    string
+	apiKey     string
+	model      string
+}
+
+type OpenAIConfig struct {
+	BaseURL string
+	APIKey  string
+	Model   string
+	// Timeout, FeatureTimeouts and the breaker settings behave as in Config.
+	Timeout                 time.Duration
+	FeatureTimeouts         map[string]time.Duration
+	BreakerFailureThreshold int
+	BreakerOpenTimeout      time.Duration
+}
+
+func NewOpenAIClient(cfg OpenAIConfig) *OpenAIClient {
+	baseURL := strings.TrimRight(cfg.BaseURL, "/")
+	if baseURL == "" {
+		baseURL = defaultOpenAIBaseURL
+	}
+	model := cfg.Model
+	if model == "" {
+		model = defaultOpenAIModel
+	}
+
+	return &OpenAIClient{
+		caller:     newCaller("openai", cfg.Timeout, cfg.FeatureTimeouts, cfg.BreakerFailureThreshold, cfg.BreakerOpenTimeout),
+		httpClient: &http.Client{},
+		baseURL:    baseURL,
+		apiKey:     cfg.APIKey,
+		model:      model,
+	}
+}
+
+type chatMessage struct {
+	Role    string `json:"role"`
+	Content any    `json:"content"`
+}
+
+type chatPart struct {
+	Type       string          `json:"type"`
+	Text       string          `json:"text,omitempty"`
+	ImageURL   *chatImageURL   `json:"image_url,omitempty"`
+	File       *chatFile       `json:"file,omitempty"`
+	InputAudio *chatInputAudio `json:"input_audio,omitempty"`
+}
+
+type chatImageURL struct {
+	URL string `json:"url"`
+}
+
+type chatFile struct {
+	Filename string `json:"filename"`
+	FileData string `json:"file_data"`
+}
+
+type chatInputAudio struct {
+	Data   string `json:"data"`
+	Format string `json:"format"`
+}
+
+type chatRequest struct {
+	Model          string         `json:"model"`
+	Messages       []chatMessage  `json:"messages"`
+	ResponseFormat map[string]any `json:"response_format,omitempty"`
+}
+
+type chatResponse struct {
+	Choices []struct {
+		Message struct {
+			Content string `json:"content"`
+		} `json:"message"`
+	} `json:"choices"`
+	Usage struct {
+		PromptTokens     int32 `json:"prompt_tokens"`
+		CompletionTokens int32 `json:"completion_tokens"`
+		TotalTokens      int32 `json:"total_tokens"`
+	} `json:"usage"`
+	Error *struct {
+		Message string `json:"message"`
+	} `json:"error"`
+}
+
+type openAIError struct {
+	StatusCode int
+	Message    string
+}
+
+func (e *openAIError) Error() string {
+	return fmt.Sprintf("openai api error %d: %s", e.StatusCode, e.Message)
+}
+
+func (c *OpenAIClient) GenerateText(ctx context.Context, prompt string) (string, error) {
+	result, err := c.complete(ctx, "", prompt, nil, false)
+	if err != nil {
+		return "", fmt.Errorf("failed to generate content: %w", err)
+	}
+	return result, nil
+}
+
+func (c *OpenAIClient) GenerateTextWithSystemPrompt(ctx context.Context, systemPrompt, userPrompt string) (string, error) {
+	result, err := c.complete(ctx, systemPrompt, userPrompt, nil, false)
+	if err != nil {
+		return "", fmt.Errorf("failed to generate content: %w", err)
+	}
+	return result, nil
+}
+
+func (c *OpenAIClient) GenerateFromFile(ctx context.Context, file File, prompt string) (string, error) {
+	return c.GenerateFromFileWithSystemPrompt(ctx, file, "", prompt)
+}
+
+// GenerateFromFileWithSystemPrompt base64-encodes the file as it is read,
+// since chat completions only take documents inline.
+func (c *OpenAIClient) GenerateFromFileWithSystemPrompt(ctx context.Context, file File, systemPrompt, userPrompt string) (string, error) {
+	if _, err := file.Reader.Seek(0, io.SeekStart); err != nil {
+		return "", fmt.Errorf("failed to rewind file: %w", err)
+	}
+
+	var encoded strings.Builder
+	encoder := base64.NewEncoder(base64.StdEncoding, &encoded)
+	if _, err := io.Copy(encoder, file.Reader); err != nil {
+		return "", fmt.Errorf("failed to read file: %w", err)
+	}
+	encoder.Close()
+
+	part, err := mediaPart(encoded.String(), file.MIMEType, file.Name)
+	if err != nil {
+		return "", err
+	}
+
+	result, err := c.complete(ctx, systemPrompt, userPrompt, part, false)
+	if err != nil {
+		return "", fmt.Errorf("failed to generate content from file: %w", err)
+	}
+	return result, nil
+}
+
+func (c *OpenAIClient) GenerateJSONFromData(ctx context.Context, data []byte, mimeType, prompt string) (string, error) {
+	part, err := mediaPart(base64.StdEncoding.EncodeToString(data), mimeType, "input")
+	if err != nil {
+		return "", err
+	}
+
+	result, err := c.complete(ctx, "", prompt, part, true)
+	if err != nil {
+		return "", fmt.Errorf("failed to generate json content from data: %w", err)
+	}
+	return result, nil
+}
+
+func (c *OpenAIClient) GenerateJSON(ctx context.Context, prompt string) (string, error) {
+	result, err := c.complete(ctx, "", prompt, nil, true)
+	if err != nil {
+		return "", fmt.Errorf("failed to generate json content: %w", err)
+	}
+	return result, nil
+}
+
+func (c *OpenAIClient) GenerateJSONWithSystemPrompt(ctx context.Context, systemPrompt, userPrompt string) (string, error) {
+	result, err := c.complete(ctx, systemPrompt, userPrompt, nil, true)
+	if err != nil {
+		return "", fmt.Errorf("failed to generate json content: %w", err)
+	}
+	return result, nil
+}
+
+func (c *OpenAIClient) complete(ctx context.Context, systemPrompt, userPrompt string, media *chatPart, jsonOutput bool) (string, error) {
+	req := chatRequest{Model: c.model}
+
+	if jsonOutput {
+		req.ResponseFormat = map[string]any{"type": "json_object"}
+		// JSON mode is rejected unless the messages ask for JSON.
+		if !strings.Contains(strings.ToLower(systemPrompt+userPrompt), "json") {
+			systemPrompt = strings.TrimSpace(systemPrompt + "\n\nRespond with a JSON object.")
+		}
+	}
+	if systemPrompt != "" {
+		req.Messages = append(req.Messages, chatMessage{Role: "system", Content: systemPrompt})
+	}
+	if media != nil {
+		req.Messages = append(req.Messages, chatMessage{Role: "user", Content: []chatPart{{Type: "text", Text: userPrompt}, *media}})
+	} else {
+		req.Messages = append(req.Messages, chatMessage{Role: "user", Content: userPrompt})
+	}
+
+	body, err := json.Marshal(req)
+	if err != nil {
+		return "", err
+	}
+
+	var text string
+	err = c.run(ctx, c.model, isOpenAIUpstreamFailure, func(callCtx context.Context) (tokenUsage, error) {
+		resp, err := c.send(callCtx, body)
+		if err != nil {
+			return tokenUsage{}, err
+		}
+		usage := tokenUsage{
+			prompt:     resp.Usage.PromptTokens,
+			completion: resp.Usage.CompletionTokens,
+			total:      resp.Usage.TotalTokens,
+		}
+		if len(resp.Choices) == 0 {
+			return usage, errors.New("openai api returned no choices")
+		}
+		text = resp.Choices[0].Message.Content
+		return usage, nil
+	})
+	return text, err
+}
+
+func (c *OpenAIClient) send(ctx context.Context, body []byte) (*chatResponse, error) {
+	httpReq, err := http.NewRequestWithContext(ctx, http.MethodPost, c.baseURL+"/chat/completions", bytes.NewReader(body))
+	if err != nil {
+		return nil, err
+	}
+	httpReq.Header.Set("Content-Type", "application/json")
+	httpReq.Header.Set("Authorization", "Bearer "+c.apiKey)
+
+	httpResp, err := c.httpClient.Do(httpReq)
+	if err != nil {
+		return nil, err
+	}
+	defer httpResp.Body.Close()
+
+	var resp chatResponse
+	decodeErr := json.NewDecoder(httpResp.Body).Decode(&resp)
+
+	if httpResp.StatusCode >= http.StatusBadRequest {
+		message := http.StatusText(httpResp.StatusCode)
+		if decodeErr == nil && resp.Error != nil {
+			message = resp.Error.Message
+		}
+		return nil, &openAIError{StatusCode: httpResp.StatusCode, Message: message}
+	}
+	if decodeErr != nil {
+		return nil, fmt.Errorf("failed to decode openai response: %w", decodeErr)
+	}
+	return &resp, nil
+}
+
+// mediaPart attaches base64 data the way chat completions expect for its
+// type. Images and PDFs are sent inline; audio only in the formats the API
+// accepts. Anything else, such as video, cannot be sent.
+func mediaPart(encoded, mimeType, filename string) (*chatPart, error) {
+	mimeType = strings.ToLower(strings.TrimSpace(strings.Split(mimeType, ";")[0]))
+
+	switch {
+	case strings.HasPrefix(mimeType, "image/"):
+		return &chatPart{Type: "image_url", ImageURL: &chatImageURL{URL: "data:" + mimeType + ";base64," + encoded}}, nil
+	case mimeType == "application/pdf":
+		return &chatPart{Type: "file", File: &chatFile{Filename: filename, FileData: "data:application/pdf;base64," + encoded}}, nil
+	case mimeType == "audio/wav" || mimeType == "audio/x-wav" || mimeType == "audio/wave":
+		return &chatPart{Type: "input_audio", InputAudio: &chatInputAudio{Data: encoded, Format: "wav"}}, nil
+	case mimeType == "audio/mpeg" || mimeType == "audio/mp3":
+		return &chatPart{Type: "input_audio", InputAudio: &chatInputAudio{Data: encoded, Format: "mp3"}}, nil
+	default:
+		return nil, fmt.Errorf("%w: %s", ErrUnsupportedInput, mimeType)
+	}
+}
+
+func isOpenAIUpstreamFailure(err error) bool {
+	var apiErr *openAIError
+	if errors.As(err, &apiErr) {
+		return apiErr.StatusCode >= 500 || apiErr.StatusCode == http.StatusTooManyRequests
+	}
+	return true
+}
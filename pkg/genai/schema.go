@@ -0,0 +1,198 @@
+package genai
+
+import (
+	"context"
+	"encoding"
+	"fmt"
+	"reflect"
+	"strings"
+	"sync"
+	"time"
+
+	"google.golang.org/genai"
+)
+
+// ResponseSchema constrains a model's JSON response to the shape of the Go
+// type it is unmarshalled into. Schemas are registered once by name and
+// attached to a call with WithResponseSchema.
+type ResponseSchema struct {
+	Name   string
+	schema *genai.Schema
+}
+
+var (
+	schemaMu sync.RWMutex
+	schemas  = make(map[string]*ResponseSchema)
+)
+
+// RegisterSchema derives the schema of v's type and registers it under
+// name. Like regexp.MustCompile it is meant for package-level variables
+// and panics on a duplicate name or a type it cannot describe.
+func RegisterSchema(name string, v any) *ResponseSchema {
+	schema, err := SchemaFor(v)
+	if err != nil {
+		panic(fmt.Sprintf("genai: schema %s: %v", name, err))
+	}
+
+	schemaMu.Lock()
+	defer schemaMu.Unlock()
+	if _, ok := schemas[name]; ok {
+		panic(fmt.Sprintf("genai: schema %s registered twice", name))
+	}
+	registered := &ResponseSchema{Name: name, schema: schema}
+	schemas[name] = registered
+	return registered
+}
+
+// LookupSchema returns the schema registered under name.
+func LookupSchema(name string) (*ResponseSchema, bool) {
+	schemaMu.RLock()
+	defer schemaMu.RUnlock()
+	schema, ok := schemas[name]
+	return schema, ok
+}
+
+// Schema returns the generated schema.
+func (s *ResponseSchema) Schema() *genai.Schema {
+	return s.schema
+}
+
+type responseSchemaKey struct{}
+
+// WithResponseSchema makes Gemini calls made with ctx answer in JSON
+// matching schema, including calls that would otherwise return text.
+// Providers without schema support ignore it.
+func WithResponseSchema(ctx context.Context, schema *ResponseSchema) context.Context {
+	return context.WithValue(ctx, responseSchemaKey{}, schema)
+}
+
+func responseSchemaFromContext(ctx context.Context) *ResponseSchema {
+	schema, _ := ctx.Value(responseSchemaKey{}).(*ResponseSchema)
+	return schema
+}
+
+// withResponseSchema returns config constrained to the schema on ctx.
+func withResponseSchema(ctx context.Context, config *genai.GenerateContentConfig) *genai.GenerateContentConfig {
+	schema := responseSchemaFromContext(ctx)
+	if schema == nil {
+		return config
+	}
+
+	constrained := &genai.GenerateContentConfig{}
+	if config != nil {
+		*constrained = *config
+	}
+	constrained.ResponseMIMEType = "application/json"
+	constrained.ResponseSchema = schema.schema
+	return constrained
+}
+
+var (
+	timeType          = reflect.TypeOf(time.Time{})
+	textMarshalerType = reflect.TypeOf((*encoding.TextMarshaler)(nil)).Elem()
+)
+
+// SchemaFor describes the JSON encoding of v's type. Fields tagged
+// omitempty and pointer fields are optional, pointers are nullable, and
+// types encoded as text, such as time.Time and uuid.UUID, are strings.
+func SchemaFor(v any) (*genai.Schema, error) {
+	return schemaOf(reflect.TypeOf(v), make(map[reflect.Type]bool))
+}
+
+func schemaOf(t reflect.Type, visiting map[reflect.Type]bool) (*genai.Schema, error) {
+	if t == nil {
+		return nil, fmt.Errorf("nil type")
+	}
+	if t.Kind() == reflect.Pointer {
+		schema, err := schemaOf(t.Elem(), visiting)
+		if err != nil {
+			return nil, err
+		}
+		schema.Nullable = genai.Ptr(true)
+		return schema, nil
+	}
+	if t == timeType {
+		return &genai.Schema{Type: genai.TypeString, Format: "date-time"}, nil
+	}
+	if t.Implements(textMarshalerType) || reflect.PointerTo(t).Implements(textMarshalerType) {
+		return &genai.Schema{Type: genai.TypeString}, nil
+	}
+
+	switch t.Kind() {
+	case reflect.String:
+		return &genai.Schema{Type: genai.TypeString}, nil
+	case reflect.Bool:
+		return &genai.Schema{Type: genai.TypeBoolean}, nil
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return &genai.Schema{Type: genai.TypeInteger}, nil
+	case reflect.Float32, reflect.Float64:
+		return &genai.Schema{Type: genai.TypeNumber}, nil
+	case reflect.Slice, reflect.Array:
+		if t.Elem().Kind() == reflect.Uint8 {
+			return &genai.Schema{Type: genai.TypeString}, nil
+		}
+		items, err := schemaOf(t.Elem(), visiting)
+		if err != nil {
+			return nil, err
+		}
+		return &genai.Schema{Type: genai.TypeArray, Items: items}, nil
+	case reflect.Struct:
+		return structSchema(t, visiting)
+	}
+	return nil, fmt.Errorf("unsupported type %s", t)
+}
+
+func structSchema(t reflect.Type, visiting map[reflect.Type]bool) (*genai.Schema, error) {
+	if visiting[t] {
+		return nil, fmt.Errorf("recursive type %s", t)
+	}
+	visiting[t] = true
+	defer delete(visiting, t)
+
+	schema := &genai.Schema{Type: genai.TypeObject, Properties: make(map[string]*genai.Schema)}
+	if err := addFields(schema, t, visiting); err != nil {
+		return nil, err
+	}
+	if len(schema.Properties) == 0 {
+		return nil, fmt.Errorf("struct %s has no JSON fields", t)
+	}
+	return schema, nil
+}
+
+// addFields adds the fields of t to schema, flattening untagged embedded
+// structs the way encoding/json does.
+func addFields(schema *genai.Schema, t reflect.Type, visiting map[reflect.Type]bool) error {
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		tag := field.Tag.Get("json")
+		if tag == "-" {
+			continue
+		}
+		name, options, _ := strings.Cut(tag, ",")
+
+		if field.Anonymous && name == "" && field.Type.Kind() == reflect.Struct {
+			if err := addFields(schema, field.Type, visiting); err != nil {
+				return err
+			}
+			continue
+		}
+		if !field.IsExported() {
+			continue
+		}
+		if name == "" {
+			name = field.Name
+		}
+
+		property, err := schemaOf(field.Type, visiting)
+		if err != nil {
+			return fmt.Errorf("%s.%s: %w", t.Name(), field.Name, err)
+		}
+		schema.Properties[name] = property
+		schema.PropertyOrdering = append(schema.PropertyOrdering, name)
+		if field.Type.Kind() != reflect.Pointer && !strings.Contains(","+options+",", ",omitempty,") {
+			schema.Required = append(schema.Required, name)
+		}
+	}
+	return nil
+}
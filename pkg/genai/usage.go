@@ -0,0 +1,237 @@
+package genai
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"time"
+
+	"github.com/raflytch/careerly-server/pkg/circuitbreaker"
+
+	"google.golang.org/genai"
+)
+
+var (
+	ErrBudgetExceeded = errors.New("ai budget exceeded")
+	ErrTimeout        = errors.New("ai request timed out")
+	ErrUnavailable    = errors.New("ai service is temporarily unavailable")
+)
+
+type CallMetadata struct {
+	Feature   string
+	UserID    string
+	QuotaCost int
+}
+
+type UsageRecord struct {
+	Feature          string
+	UserID           string
+	Model            string
+	PromptTokens     int32
+	CompletionTokens int32
+	TotalTokens      int32
+	Latency          time.Duration
+	QuotaCost        int
+	Success          bool
+	Error            string
+}
+
+type UsageHook interface {
+	BeforeCall(ctx context.Context, meta CallMetadata) error
+	AfterCall(ctx context.Context, record UsageRecord)
+}
+
+type callMetadataKey struct{}
+
+func WithCallMetadata(ctx context.Context, feature, userID string) context.Context {
+	return context.WithValue(ctx, callMetadataKey{}, CallMetadata{Feature: feature, UserID: userID})
+}
+
+// WithQuotaCost marks the call as consuming cost units of the user's plan
+// quota, so the usage log can show what each action cost them.
+func WithQuotaCost(ctx context.Context, cost int) context.Context {
+	meta := callMetadataFromContext(ctx)
+	meta.QuotaCost = cost
+	return context.WithValue(ctx, callMetadataKey{}, meta)
+}
+
+func callMetadataFromContext(ctx context.Context) CallMetadata {
+	if meta, ok := ctx.Value(callMetadataKey{}).(CallMetadata); ok {
+		return meta
+	}
+	return CallMetadata{Feature: "unknown"}
+}
+
+// caller holds what every provider shares around a model call: the usage
+// hook, the circuit breaker and the per-feature timeouts.
+type caller struct {
+	usageHook       UsageHook
+	timeout         time.Duration
+	featureTimeouts map[string]time.Duration
+	breaker         *circuitbreaker.Breaker
+}
+
+type tokenUsage struct {
+	prompt     int32
+	completion int32
+	total      int32
+}
+
+func newCaller(name string, timeout time.Duration, featureTimeouts map[string]time.Duration, failureThreshold int, openTimeout time.Duration) caller {
+	return caller{
+		timeout:         timeout,
+		featureTimeouts: featureTimeouts,
+		breaker: circuitbreaker.New(circuitbreaker.Config{
+			Name:             name,
+			FailureThreshold: failureThreshold,
+			OpenTimeout:      openTimeout,
+		}),
+	}
+}
+
+func (c *caller) SetUsageHook(hook UsageHook) {
+	c.usageHook = hook
+}
+
+func (c *caller) Breaker() *circuitbreaker.Breaker {
+	return c.breaker
+}
+
+// Available reports whether the circuit breaker would let a call through.
+func (c *caller) Available() bool {
+	return c.breaker.Available()
+}
+
+// run performs call under the usage hook, breaker and timeout. upstream
+// reports whether an error means the provider itself is unhealthy.
+func (c *caller) run(ctx context.Context, model string, upstream func(error) bool, call func(ctx context.Context) (tokenUsage, error)) error {
+	meta := callMetadataFromContext(ctx)
+
+	if c.usageHook != nil {
+		if err := c.usageHook.BeforeCall(ctx, meta); err != nil {
+			return err
+		}
+	}
+
+	if err := c.breaker.Allow(); err != nil {
+		return fmt.Errorf("%w: %v", ErrUnavailable, err)
+	}
+
+	callCtx := ctx
+	if timeout := c.timeoutFor(meta.Feature); timeout > 0 {
+		var cancel context.CancelFunc
+		callCtx, cancel = context.WithTimeout(ctx, timeout)
+		defer cancel()
+	}
+
+	start := time.Now()
+	usage, err := call(callCtx)
+	switch {
+	case err == nil:
+		c.breaker.Success()
+	case ctx.Err() != nil:
+		c.breaker.Skip()
+	case upstream(err):
+		c.breaker.Failure()
+	default:
+		c.breaker.Success()
+	}
+	if err != nil && errors.Is(callCtx.Err(), context.DeadlineExceeded) {
+		err = fmt.Errorf("%w: %v", ErrTimeout, err)
+	}
+
+	if c.usageHook != nil {
+		record := UsageRecord{
+			Feature:          meta.Feature,
+			UserID:           meta.UserID,
+			Model:            model,
+			QuotaCost:        meta.QuotaCost,
+			Latency:          time.Since(start),
+			Success:          err == nil,
+			PromptTokens:     usage.prompt,
+			CompletionTokens: usage.completion,
+			TotalTokens:      usage.total,
+		}
+		if err != nil {
+			record.Error = err.Error()
+		}
+		c.usageHook.AfterCall(ctx, record)
+	}
+
+	return err
+}
+
+func (c *Client) generate(ctx context.Context, contents []*genai.Content, config *genai.GenerateContentConfig) (*genai.GenerateContentResponse, error) {
+	config = withResponseSchema(ctx, config)
+	var result *genai.GenerateContentResponse
+	err := c.run(ctx, c.model, isUpstreamFailure, func(callCtx context.Context) (tokenUsage, error) {
+		var err error
+		result, err = c.client.Models.GenerateContent(callCtx, c.model, contents, config)
+		return responseUsage(result), err
+	})
+	return result, err
+}
+
+// generateFromFile streams the file to the Files API and refers to it by
+// URI, so the upload is never held in memory. The upload shares the call's
+// timeout and breaker accounting. Uploaded files expire on their own, so a
+// failed delete is ignored.
+func (c *Client) generateFromFile(ctx context.Context, file File, prompt string, config *genai.GenerateContentConfig) (*genai.GenerateContentResponse, error) {
+	config = withResponseSchema(ctx, config)
+	var result *genai.GenerateContentResponse
+	err := c.run(ctx, c.model, isUpstreamFailure, func(callCtx context.Context) (tokenUsage, error) {
+		if _, err := file.Reader.Seek(0, io.SeekStart); err != nil {
+			return tokenUsage{}, fmt.Errorf("failed to rewind file: %w", err)
+		}
+
+		uploaded, err := c.client.Files.Upload(callCtx, file.Reader, &genai.UploadFileConfig{
+			MIMEType:    file.MIMEType,
+			DisplayName: file.Name,
+		})
+		if err != nil {
+			return tokenUsage{}, fmt.Errorf("failed to upload file: %w", err)
+		}
+		defer func() {
+			_, _ = c.client.Files.Delete(context.WithoutCancel(callCtx), uploaded.Name, nil)
+		}()
+
+		contents := []*genai.Content{
+			genai.NewContentFromParts([]*genai.Part{
+				genai.NewPartFromText(prompt),
+				genai.NewPartFromURI(uploaded.URI, uploaded.MIMEType),
+			}, genai.RoleUser),
+		}
+		result, err = c.client.Models.GenerateContent(callCtx, c.model, contents, config)
+		return responseUsage(result), err
+	})
+	return result, err
+}
+
+func responseUsage(result *genai.GenerateContentResponse) tokenUsage {
+	if result == nil || result.UsageMetadata == nil {
+		return tokenUsage{}
+	}
+	return tokenUsage{
+		prompt:     result.UsageMetadata.PromptTokenCount,
+		completion: result.UsageMetadata.CandidatesTokenCount,
+		total:      result.UsageMetadata.TotalTokenCount,
+	}
+}
+
+// isUpstreamFailure reports whether err means Gemini itself is unhealthy,
+// as opposed to a rejected request that would fail on any attempt.
+func isUpstreamFailure(err error) bool {
+	var apiErr genai.APIError
+	if errors.As(err, &apiErr) {
+		return apiErr.Code >= 500 || apiErr.Code == 429
+	}
+	return true
+}
+
+func (c *caller) timeoutFor(feature string) time.Duration {
+	if timeout, ok := c.featureTimeouts[feature]; ok {
+		return timeout
+	}
+	return c.timeout
+}
@@ -0,0 +1,111 @@
+package geoip
+
+import (
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"net/netip"
+	"os"
+	"sort"
+	"strings"
+)
+
+type ipRange struct {
+	start   netip.Addr
+	end     netip.Addr
+	country string
+}
+
+// Database maps IP addresses to ISO 3166 country codes using a local range
+// file, such as the free DB-IP "IP to Country Lite" CSV. Each row is
+// start_ip,end_ip,country_code; IPv4 and IPv6 rows may be mixed.
+type Database struct {
+	ranges []ipRange
+}
+
+func Open(path string) (*Database, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	return Load(f)
+}
+
+func Load(r io.Reader) (*Database, error) {
+	reader := csv.NewReader(r)
+	reader.FieldsPerRecord = -1
+	reader.ReuseRecord = true
+
+	var ranges []ipRange
+	for line := 1; ; line++ {
+		record, err := reader.Read()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return nil, err
+		}
+		if len(record) < 3 {
+			return nil, fmt.Errorf("geoip: line %d: expected start_ip,end_ip,country", line)
+		}
+
+		start, err := netip.ParseAddr(strings.TrimSpace(record[0]))
+		if err != nil {
+			// Tolerate a header row
+			if line == 1 {
+				continue
+			}
+			return nil, fmt.Errorf("geoip: line %d: %w", line, err)
+		}
+		end, err := netip.ParseAddr(strings.TrimSpace(record[1]))
+		if err != nil {
+			return nil, fmt.Errorf("geoip: line %d: %w", line, err)
+		}
+		start, end = start.Unmap(), end.Unmap()
+		if start.Is4() != end.Is4() || end.Less(start) {
+			return nil, fmt.Errorf("geoip: line %d: invalid range %s-%s", line, start, end)
+		}
+
+		ranges = append(ranges, ipRange{
+			start:   start,
+			end:     end,
+			country: strings.ToUpper(strings.TrimSpace(record[2])),
+		})
+	}
+
+	sort.Slice(ranges, func(i, j int) bool {
+		return ranges[i].start.Less(ranges[j].start)
+	})
+
+	return &Database{ranges: ranges}, nil
+}
+
+// Country returns the country code for ip, or an empty string when the
+// address is invalid or not covered by the database.
+func (d *Database) Country(ip string) string {
+	addr, err := netip.ParseAddr(ip)
+	if err != nil {
+		return ""
+	}
+	addr = addr.Unmap()
+
+	i := sort.Search(len(d.ranges), func(i int) bool {
+		return addr.Less(d.ranges[i].start)
+	})
+	if i == 0 {
+		return ""
+	}
+
+	r := d.ranges[i-1]
+	if r.end.Less(addr) {
+		return ""
+	}
+	// DB-IP marks unassigned space as "ZZ"
+	if r.country == "ZZ" {
+		return ""
+	}
+	return r.country
+}
@@ -0,0 +1,244 @@
+package i18n
+
+var english = map[string]string{
+	"UNAUTHENTICATED":              "user not authenticated",
+	"UNAUTHORIZED":                 "unauthorized",
+	"INSUFFICIENT_PERMISSIONS":     "insufficient permissions",
+	"MISSING_AUTHORIZATION_HEADER": "missing authorization header",
+	"INVALID_AUTHORIZATION_HEADER": "invalid authorization header format",
+	"INVALID_TOKEN":                "invalid or expired token",
+	"INVALID_LINK":                 "invalid or expired link",
+	"INVALID_REQUEST_BODY":         "invalid request body",
+	"INVALID_QUERY":                "invalid query parameters",
+	"VALIDATION_FAILED":            "validation failed",
+	"REQUEST_TIMEOUT":              "request timed out, please try again",
+	"REQUEST_BODY_TOO_LARGE":       "request body is too large",
+	"CONTENT_LENGTH_REQUIRED":      "content length is required",
+	"WEBSOCKET_UPGRADE_REQUIRED":   "websocket upgrade required",
+	"SCHEMA_NOT_FOUND":             "schema resource not found",
+	"FILE_NOT_FOUND":               "file not found",
+	"INVALID_EMAIL":                "invalid email",
+	"INVALID_FROM_DATE":            "from must be in YYYY-MM-DD format",
+	"INVALID_TO_DATE":              "to must be in YYYY-MM-DD format",
+	"INVALID_DATE_RANGE":           "to must be after from",
+	"INVALID_MONTH":                "month must be in YYYY-MM format",
+	"INVALID_COUNTRY":              "country must be a two-letter ISO 3166 code",
+	"INVALID_FORMAT":               "format must be csv or json",
+
+	"INVALID_RESUME_ID":           "invalid resume id",
+	"INVALID_INTERVIEW_ID":        "invalid interview id",
+	"INVALID_USER_ID":             "invalid user id",
+	"INVALID_PLAN_ID":             "invalid plan id",
+	"INVALID_ADDON_ID":            "invalid add-on id",
+	"INVALID_JOB_ID":              "invalid job id",
+	"INVALID_ATS_CHECK_ID":        "invalid ats check id",
+	"INVALID_DRAFT_ID":            "invalid draft id",
+	"INVALID_INTERVIEW_PACK_ID":   "invalid interview pack id",
+	"INVALID_TRANSACTION_ID":      "invalid transaction id",
+	"INVALID_SHARE_ID":            "invalid share id",
+	"INVALID_COMMENT_ID":          "invalid comment id",
+	"INVALID_QUESTION_ID":         "invalid question id",
+	"INVALID_TASK_ID":             "invalid task id",
+	"INVALID_SESSION_ID":          "invalid session id",
+	"INVALID_LINKED_ACCOUNT_ID":   "invalid linked account id",
+	"INVALID_WEBHOOK_ENDPOINT_ID": "invalid webhook endpoint id",
+	"INVALID_WEBHOOK_DELIVERY_ID": "invalid webhook delivery id",
+	"INVALID_PROVISIONING_JOB_ID": "invalid provisioning job id",
+	"INVALID_QUOTA_OVERRIDE_ID":   "invalid quota override id",
+	"INVALID_PROMPT_VERSION":      "invalid prompt version",
+	"INVALID_EXPERIMENT_ID":       "invalid experiment id",
+	"INVALID_TARGET_ID":           "invalid target id",
+	"INVALID_ACTOR_ID":            "invalid actor id",
+
+	"USER_NOT_FOUND":             "user not found",
+	"USER_DELETED":               "user account has been deleted, please restore your account",
+	"USER_NOT_ACTIVE":            "user account is not active",
+	"USER_ALREADY_ACTIVE":        "user account is already active",
+	"NO_DELETED_USER":            "no deleted account found with this email",
+	"CANNOT_DELETE_ADMIN":        "admin account cannot be self-deleted",
+	"CANNOT_DELETE_SELF":         "cannot delete your own account",
+	"ADMIN_ONLY":                 "only admin can perform this action",
+	"CONTACT_EMAIL_UNCHANGED":    "this address is already your contact email",
+	"AVATAR_FILE_REQUIRED":       "avatar file is required",
+	"INVALID_OTP":                "invalid or expired OTP",
+	"OTP_ALREADY_SENT":           "OTP already sent, please wait before requesting again",
+	"TWO_FACTOR_SESSION_INVALID": "two-factor session is invalid or expired, please login again",
+	"TOKEN_EXCHANGE_FAILED":      "failed to exchange token",
+	"USER_INFO_FAILED":           "failed to get user info",
+	"SESSION_NOT_FOUND":          "session not found",
+	"SESSION_REVOKED":            "session has been revoked or expired",
+	"IDENTITY_NOT_FOUND":         "linked account not found",
+	"IDENTITY_IN_USE":            "this account is already linked to another user",
+	"PROVIDER_ALREADY_LINKED":    "a login of this provider is already linked, unlink it first",
+	"LAST_IDENTITY":              "cannot unlink the only login method of this account",
+	"UNSUPPORTED_PROVIDER":       "unsupported auth provider",
+	"INVALID_LINK_STATE":         "account link request is invalid or expired",
+	"EMAIL_BELONGS_TO_ANOTHER":   "an account with this email already exists, sign in and link this login from settings",
+	"CANNOT_IMPERSONATE_ADMIN":   "admin accounts cannot be impersonated",
+	"CANNOT_IMPERSONATE_SELF":    "cannot impersonate your own account",
+	"IMPERSONATION_FORBIDDEN":    "this action is not allowed while impersonating a user",
+	"REFERRAL_CODE_NOT_FOUND":    "referral code not found",
+	"SELF_REFERRAL":              "users cannot refer themselves",
+	"ALREADY_REFERRED":           "user has already been referred",
+
+	"RESUME_NOT_FOUND":            "resume not found",
+	"RESUME_ACCESS_DENIED":        "unauthorized access to resume",
+	"INVALID_SEARCH":              "search query must be between 1 and 200 characters",
+	"RESUME_NO_PHOTO":             "resume has no photo",
+	"DUPLICATE_RESUME":            "this resume is nearly identical to one you already have, set force to create it anyway",
+	"PHOTO_FILE_REQUIRED":         "photo file is required, use form field 'photo'",
+	"PDF_FILE_REQUIRED":           "pdf file is required, use form field 'file'",
+	"VIDEO_FILE_REQUIRED":         "video file is required, use form field 'video'",
+	"CUSTOM_BRANDING_NOT_ALLOWED": "custom pdf styling requires a plan with custom branding",
+	"OPTIMIZATION_NOT_FOUND":      "optimization not found or expired",
+	"BULLET_GENERATION_FAILED":    "could not generate bullet points, try describing the work in more detail",
+	"RESUME_DRAFT_NOT_FOUND":      "resume draft not found",
+	"RESUME_DRAFT_LIMIT":          "you have reached the maximum number of resume drafts",
+	"RESUME_DRAFT_INCOMPLETE":     "draft needs a title of at least 3 characters before it can be published",
+	"RESUME_TAG_NOT_FOUND":        "resume tag not found",
+	"RESUME_TAG_EXISTS":           "a tag with this name already exists",
+	"RESUME_FOLDER_NOT_FOUND":     "resume folder not found",
+	"RESUME_FOLDER_EXISTS":        "a folder with this name already exists",
+	"DUPLICATE_SECTION":           "duplicate section in section_order",
+	"UNKNOWN_SECTION":             "unknown section in section_order",
+	"DUPLICATE_CUSTOM_SECTION":    "duplicate custom section",
+	"SHARE_LINK_NOT_FOUND":        "share link not found",
+	"SHARE_LINK_EXPIRED":          "share link has expired or been revoked",
+	"COMMENT_NOT_FOUND":           "comment not found",
+
+	"INTERVIEW_NOT_FOUND":       "interview not found",
+	"INTERVIEW_ACCESS_DENIED":   "unauthorized access to interview",
+	"INTERVIEW_COMPLETED":       "interview already completed",
+	"INTERVIEW_NOT_READY":       "interview is scheduled and not ready yet",
+	"INTERVIEW_EVALUATING":      "interview answers are being evaluated",
+	"INTERVIEW_ADAPTIVE":        "adaptive interviews are answered round by round",
+	"INTERVIEW_NOT_ADAPTIVE":    "interview is not adaptive",
+	"INCOMPLETE_ROUND":          "all questions in the current round must be answered",
+	"INVALID_SCHEDULE_TIME":     "scheduled_at must be in the future and within 90 days",
+	"EVALUATION_JOB_NOT_FOUND":  "evaluation job not found",
+	"EVALUATION_NOT_FOUND":      "no evaluation found for this interview",
+	"VIDEO_ANSWERS_DISABLED":    "video answers are not available",
+	"VIDEO_ANSWER_NOT_ALLOWED":  "video answers are only accepted for unanswered essay questions",
+	"VIDEO_NO_SPEECH":           "no speech could be recognised in the video",
+	"INTERVIEW_PACK_NOT_FOUND":  "interview pack not found",
+	"INVALID_PACK_QUESTION":     "multiple choice questions need options and a correct answer matching one of their labels; essay questions take no options",
+	"INVALID_RUBRIC":            "rubric criteria must have unique names",
+	"BANK_QUESTION_NOT_FOUND":   "question not found in the question bank",
+	"QUESTION_IMPORT_HEADER":    "csv header must have question, type, correct_answer and position columns",
+	"QUESTION_IMPORT_EMPTY":     "csv has no question rows",
+	"QUESTION_IMPORT_TOO_LARGE": "csv has too many question rows",
+	"QUESTION_IMPORT_MALFORMED": "csv file could not be parsed",
+	"INTERVIEW_NOT_SHAREABLE":   "only completed interviews can be shared",
+	"SHARE_COMMENTS_DISABLED":   "comments are disabled for this share link",
+	"SHARE_COMMENT_LIMIT":       "comment limit reached for this share link",
+	"STUDY_PLAN_NOT_FOUND":      "study plan not found",
+	"STUDY_PLAN_NOT_AVAILABLE":  "a study plan can only be generated for a completed interview",
+	"STUDY_PLAN_TASK_NOT_FOUND": "study plan task not found",
+	"STUDY_PLAN_FAILED":         "could not generate a study plan, please try again",
+	"UNSUPPORTED_EXPORT_FORMAT": "unsupported export format, use json or markdown",
+
+	"ATS_CHECK_NOT_FOUND":      "ats check not found",
+	"ATS_CHECK_ACCESS_DENIED":  "unauthorized access to ats check",
+	"TOO_MANY_JOBS":            "too many job descriptions in batch",
+	"INVALID_JOB_DESCRIPTIONS": "job_descriptions must be a JSON array of {title, description}",
+	"ATS_CHECK_NO_SOURCE":      "no uploaded pdf is stored for this ats check",
+	"ATS_ANALYSIS_NOT_FOUND":   "ats analysis not found",
+	"PDF_NOT_ANNOTATABLE":      "this pdf cannot be annotated",
+	"PDF_NO_TEXT":              "this pdf has no extractable text, please upload a text-based pdf instead of a scan",
+
+	"AI_CLIENT_UNAVAILABLE":       "ai service is not available",
+	"AI_SERVICE_UNAVAILABLE":      "ai service is temporarily unavailable, please try again later",
+	"AI_TIMEOUT":                  "ai request timed out",
+	"AI_BUDGET_EXCEEDED":          "monthly AI budget exceeded",
+	"AI_CONCURRENCY_LIMIT":        "too many ai requests in progress, please wait for one to finish",
+	"INSUFFICIENT_INSIGHT_DATA":   "create a resume, interview or ats check before requesting insights",
+	"UNKNOWN_AI_FEEDBACK_FEATURE": "unknown ai feedback feature",
+	"AI_FEEDBACK_NOT_READY":       "there is no ai output to rate yet",
+
+	"PLAN_NOT_FOUND":                 "plan not found",
+	"PLAN_NAME_EXISTS":               "plan name already exists",
+	"INVALID_PLAN_DATA":              "invalid plan data",
+	"PLAN_IN_USE":                    "plan has subscriptions or transactions, archive it instead",
+	"PLAN_NOT_AVAILABLE":             "plan is not available for purchase",
+	"ADDON_NOT_FOUND":                "add-on not found",
+	"ADDON_NAME_EXISTS":              "add-on name already exists",
+	"ADDON_NOT_AVAILABLE":            "add-on is not available for purchase",
+	"ADDON_NOT_NEEDED":               "current plan already has unlimited usage for this feature",
+	"SUBSCRIPTION_REQUIRED":          "no active subscription found",
+	"ACTIVE_SUBSCRIPTION_EXISTS":     "you already have an active subscription for this plan",
+	"QUOTA_EXCEEDED":                 "quota exceeded for this feature",
+	"PLAN_CHANGE_NOT_DOWNGRADE":      "only free plans can be scheduled, paid plans must be purchased",
+	"PLAN_CHANGE_SAME_PLAN":          "subscription is already on this plan",
+	"NO_SCHEDULED_PLAN_CHANGE":       "no plan change is scheduled",
+	"GIFT_CODE_INVALID":              "invalid gift code",
+	"GIFT_ALREADY_REDEEMED":          "gift code has already been redeemed",
+	"GIFT_PAID_SUBSCRIPTION_ACTIVE":  "your current paid subscription must end before a gift can be redeemed",
+	"TRANSACTION_NOT_FOUND":          "transaction not found",
+	"TRANSACTION_ALREADY_PAID":       "transaction has already been paid",
+	"TRANSACTION_NOT_PAID":           "transaction has not been paid",
+	"INVALID_TRANSACTION_AMOUNT":     "transaction amount does not match plan price",
+	"INVALID_ORDER_ID":               "invalid order id format",
+	"INVALID_SIGNATURE":              "invalid signature",
+	"MISSING_SIGNATURE":              "signature is required",
+	"INVALID_WEBHOOK_PAYLOAD":        "invalid webhook payload",
+	"MISSING_ORDER_ID":               "missing order_id in payload",
+	"PAYMENT_GATEWAY_DOWN":           "payment gateway is temporarily unavailable, please try again later",
+	"PAYMENT_GATEWAY_NOT_CONFIGURED": "payment gateway is not configured",
+	"STALE_NOTIFICATION":             "notification is outside the accepted time window",
+	"DUPLICATE_NOTIFICATION":         "notification has already been processed",
+	"NOTIFICATION_NOT_QUEUED":        "notification could not be queued, please retry",
+	"PAYMENT_METHOD_NOT_FOUND":       "payment method not found",
+	"PAYMENT_METHOD_EXPIRED":         "saved card has expired, please pay with the card again to save it",
+	"SAVED_CARD_DECLINED":            "the saved card was declined",
+	"ORGANIZATION_NOT_FOUND":         "you are not a member of an organization",
+	"ALREADY_IN_ORGANIZATION":        "user is already a member of an organization",
+	"ORGANIZATION_OWNER_ONLY":        "only the organization owner can do this",
+	"ORGANIZATION_NO_SEATS":          "all seats of the organization are taken",
+	"ORGANIZATION_SEATS_TOO_FEW":     "seats cannot be fewer than the current members",
+	"ORGANIZATION_MEMBER_NOT_FOUND":  "organization member not found",
+	"ORGANIZATION_OWNER_LEAVE":       "the owner cannot leave or be removed from the organization",
+	"INVITATION_INVALID":             "invitation is invalid or has expired",
+	"INVITATION_EMAIL_MISMATCH":      "invitation was sent to a different email address",
+	"QUOTA_OVERRIDE_NOT_FOUND":       "quota override not found",
+	"QUOTA_OVERRIDE_AMOUNT":          "set either an amount or unlimited, not both",
+	"QUOTA_OVERRIDE_EXPIRY":          "expiry must be in the future",
+	"UNKNOWN_DATA_REGION":            "data region is not configured",
+	"DATA_REGION_LOCKED":             "data region cannot change once the user has resumes",
+	"PROVISIONING_JOB_NOT_FOUND":     "provisioning job not found",
+	"PROVISIONING_JOB_SUCCEEDED":     "provisioning job already succeeded",
+
+	"JOB_NOT_FOUND":                  "job not found",
+	"WEBHOOK_ENDPOINT_NOT_FOUND":     "webhook endpoint not found",
+	"WEBHOOK_DELIVERY_NOT_FOUND":     "webhook delivery not found",
+	"UNKNOWN_PROMPT":                 "unknown prompt key",
+	"PROMPT_VERSION_NOT_FOUND":       "prompt version not found",
+	"PROMPT_PLACEHOLDERS":            "prompt must keep the same placeholders, in the same order, as the default",
+	"PROMPT_VERSION_ACTIVE":          "the active prompt version cannot be deleted, activate another version or reset to the default first",
+	"PROMPT_VERSION_IN_EXPERIMENT":   "this prompt version is a variant of a running experiment, stop the experiment first",
+	"EXPERIMENT_NOT_FOUND":           "experiment not found",
+	"EXPERIMENT_RUNNING":             "an experiment is already running for this prompt, stop it first",
+	"EXPERIMENT_STOPPED":             "experiment has already been stopped",
+	"EXPERIMENT_VARIANTS":            "experiment variants must be distinct prompt versions",
+	"EMAIL_SUPPRESSED":               "email address is suppressed after a bounce or complaint",
+	"EMAIL_SUPPRESSION_NOT_FOUND":    "email suppression not found",
+	"INVALID_EMAIL_CALLBACK_TOKEN":   "invalid email callback token",
+	"UNSUPPORTED_EMAIL_PROVIDER":     "unsupported email provider",
+	"INVALID_EMAIL_CALLBACK_PAYLOAD": "invalid email callback payload",
+	"ARTIFACT_STORAGE_DISABLED":      "artifact storage is not configured",
+	"INVALID_DATA_BUNDLE":            "invalid data bundle",
+	"UNSUPPORTED_BUNDLE_VERSION":     "unsupported data bundle version",
+	"EMPTY_DATA_BUNDLE":              "data bundle has no content to import",
+
+	"VALIDATION_REQUIRED":   "{field} is required",
+	"VALIDATION_EMAIL":      "{field} must be a valid email address",
+	"VALIDATION_LEN":        "{field} must be exactly {param} characters",
+	"VALIDATION_MIN":        "{field} must be at least {param}",
+	"VALIDATION_MIN_ITEMS":  "{field} must contain at least {param} items",
+	"VALIDATION_MIN_LENGTH": "{field} must be at least {param} characters",
+	"VALIDATION_MAX":        "{field} must be at most {param}",
+	"VALIDATION_MAX_ITEMS":  "{field} must contain at most {param} items",
+	"VALIDATION_MAX_LENGTH": "{field} must be at most {param} characters",
+	"VALIDATION_ONEOF":      "{field} must be one of: {param}",
+	"VALIDATION_NUMERIC":    "{field} must contain only digits",
+	"VALIDATION_INVALID":    "{field} is invalid",
+}
@@ -0,0 +1,110 @@
+// Package i18n holds the message catalogs for API errors, keyed by stable
+// error codes, and picks a language from the Accept-Language header.
+package i18n
+
+import (
+	"sort"
+	"strconv"
+	"strings"
+)
+
+const (
+	English    = "en"
+	Indonesian = "id"
+
+	DefaultLanguage = English
+
+	// ContextKey is the fiber.Ctx locals key holding the negotiated language.
+	ContextKey = "locale"
+)
+
+var bundles = map[string]map[string]string{
+	English:    english,
+	Indonesian: indonesian,
+}
+
+var codesByMessage = func() map[string]string {
+	m := make(map[string]string, len(english))
+	for code, message := range english {
+		m[message] = code
+	}
+	return m
+}()
+
+func Supported() []string {
+	languages := make([]string, 0, len(bundles))
+	for lang := range bundles {
+		languages = append(languages, lang)
+	}
+	sort.Strings(languages)
+	return languages
+}
+
+// Translate returns the message for code in lang, falling back to English
+// and then to the code itself. Params fill {name} placeholders.
+func Translate(lang, code string, params map[string]string) string {
+	message, ok := bundles[lang][code]
+	if !ok {
+		message, ok = bundles[DefaultLanguage][code]
+	}
+	if !ok {
+		return code
+	}
+	for name, value := range params {
+		message = strings.ReplaceAll(message, "{"+name+"}", value)
+	}
+	return message
+}
+
+// CodeFor finds the code of an English catalog message, so errors raised
+// with plain text can still be sent with a code and translated.
+func CodeFor(message string) (string, bool) {
+	code, ok := codesByMessage[message]
+	return code, ok
+}
+
+// Negotiate picks the supported language with the highest q-value in an
+// Accept-Language header, matching on the primary subtag.
+func Negotiate(header string) string {
+	type candidate struct {
+		lang string
+		q    float64
+	}
+
+	var candidates []candidate
+	for _, part := range strings.Split(header, ",") {
+		tag, params, _ := strings.Cut(strings.TrimSpace(part), ";")
+		if tag == "" {
+			continue
+		}
+
+		q := 1.0
+		if value, ok := strings.CutPrefix(strings.TrimSpace(params), "q="); ok {
+			parsed, err := strconv.ParseFloat(value, 64)
+			if err != nil {
+				continue
+			}
+			q = parsed
+		}
+		if q <= 0 {
+			continue
+		}
+
+		primary, _, _ := strings.Cut(strings.ToLower(tag), "-")
+		candidates = append(candidates, candidate{lang: primary, q: q})
+	}
+
+	sort.SliceStable(candidates, func(i, j int) bool {
+		return candidates[i].q > candidates[j].q
+	})
+
+	for _, c := range candidates {
+		if c.lang == "*" {
+			return DefaultLanguage
+		}
+		if _, ok := bundles[c.lang]; ok {
+			return c.lang
+		}
+	}
+	return DefaultLanguage
+}
@@ -0,0 +1,244 @@
+package i18n
+
+var indonesian = map[string]string{
+	"UNAUTHENTICATED":              "pengguna belum terautentikasi",
+	"UNAUTHORIZED":                 "tidak memiliki akses",
+	"INSUFFICIENT_PERMISSIONS":     "izin tidak mencukupi",
+	"MISSING_AUTHORIZATION_HEADER": "header authorization tidak ditemukan",
+	"INVALID_AUTHORIZATION_HEADER": "format header authorization tidak valid",
+	"INVALID_TOKEN":                "token tidak valid atau sudah kedaluwarsa",
+	"INVALID_LINK":                 "tautan tidak valid atau sudah kedaluwarsa",
+	"INVALID_REQUEST_BODY":         "isi permintaan tidak valid",
+	"INVALID_QUERY":                "parameter query tidak valid",
+	"VALIDATION_FAILED":            "validasi gagal",
+	"REQUEST_TIMEOUT":              "permintaan melebihi batas waktu, silakan coba lagi",
+	"REQUEST_BODY_TOO_LARGE":       "ukuran isi permintaan terlalu besar",
+	"CONTENT_LENGTH_REQUIRED":      "header content length wajib diisi",
+	"WEBSOCKET_UPGRADE_REQUIRED":   "koneksi harus di-upgrade ke websocket",
+	"SCHEMA_NOT_FOUND":             "skema tidak ditemukan",
+	"FILE_NOT_FOUND":               "file tidak ditemukan",
+	"INVALID_EMAIL":                "email tidak valid",
+	"INVALID_FROM_DATE":            "from harus berformat YYYY-MM-DD",
+	"INVALID_TO_DATE":              "to harus berformat YYYY-MM-DD",
+	"INVALID_DATE_RANGE":           "to harus setelah from",
+	"INVALID_MONTH":                "month harus berformat YYYY-MM",
+	"INVALID_COUNTRY":              "country harus berupa kode negara ISO 3166 dua huruf",
+	"INVALID_FORMAT":               "format harus csv atau json",
+
+	"INVALID_RESUME_ID":           "ID resume tidak valid",
+	"INVALID_INTERVIEW_ID":        "ID interview tidak valid",
+	"INVALID_USER_ID":             "ID pengguna tidak valid",
+	"INVALID_PLAN_ID":             "ID paket tidak valid",
+	"INVALID_ADDON_ID":            "ID add-on tidak valid",
+	"INVALID_JOB_ID":              "ID job tidak valid",
+	"INVALID_ATS_CHECK_ID":        "ID pengecekan ATS tidak valid",
+	"INVALID_DRAFT_ID":            "ID draf tidak valid",
+	"INVALID_INTERVIEW_PACK_ID":   "ID paket interview tidak valid",
+	"INVALID_TRANSACTION_ID":      "ID transaksi tidak valid",
+	"INVALID_SHARE_ID":            "ID tautan berbagi tidak valid",
+	"INVALID_COMMENT_ID":          "ID komentar tidak valid",
+	"INVALID_QUESTION_ID":         "ID pertanyaan tidak valid",
+	"INVALID_TASK_ID":             "ID tugas tidak valid",
+	"INVALID_SESSION_ID":          "ID sesi tidak valid",
+	"INVALID_LINKED_ACCOUNT_ID":   "ID akun tertaut tidak valid",
+	"INVALID_WEBHOOK_ENDPOINT_ID": "ID endpoint webhook tidak valid",
+	"INVALID_WEBHOOK_DELIVERY_ID": "ID pengiriman webhook tidak valid",
+	"INVALID_PROVISIONING_JOB_ID": "ID job provisioning tidak valid",
+	"INVALID_QUOTA_OVERRIDE_ID":   "ID penyesuaian kuota tidak valid",
+	"INVALID_PROMPT_VERSION":      "versi prompt tidak valid",
+	"INVALID_EXPERIMENT_ID":       "ID eksperimen tidak valid",
+	"INVALID_TARGET_ID":           "ID target tidak valid",
+	"INVALID_ACTOR_ID":            "ID pelaku tidak valid",
+
+	"USER_NOT_FOUND":             "pengguna tidak ditemukan",
+	"USER_DELETED":               "akun pengguna telah dihapus, silakan pulihkan akun Anda",
+	"USER_NOT_ACTIVE":            "akun pengguna tidak aktif",
+	"USER_ALREADY_ACTIVE":        "akun pengguna sudah aktif",
+	"NO_DELETED_USER":            "tidak ada akun terhapus dengan email ini",
+	"CANNOT_DELETE_ADMIN":        "akun admin tidak dapat menghapus dirinya sendiri",
+	"CANNOT_DELETE_SELF":         "tidak dapat menghapus akun Anda sendiri",
+	"ADMIN_ONLY":                 "hanya admin yang dapat melakukan tindakan ini",
+	"CONTACT_EMAIL_UNCHANGED":    "alamat ini sudah menjadi email kontak Anda",
+	"AVATAR_FILE_REQUIRED":       "file avatar wajib diunggah",
+	"INVALID_OTP":                "OTP tidak valid atau sudah kedaluwarsa",
+	"OTP_ALREADY_SENT":           "OTP sudah dikirim, silakan tunggu sebelum meminta lagi",
+	"TWO_FACTOR_SESSION_INVALID": "sesi verifikasi dua langkah tidak valid atau sudah kedaluwarsa, silakan login kembali",
+	"TOKEN_EXCHANGE_FAILED":      "gagal menukar token",
+	"USER_INFO_FAILED":           "gagal mengambil informasi pengguna",
+	"SESSION_NOT_FOUND":          "sesi tidak ditemukan",
+	"SESSION_REVOKED":            "sesi telah dicabut atau kedaluwarsa",
+	"IDENTITY_NOT_FOUND":         "akun tertaut tidak ditemukan",
+	"IDENTITY_IN_USE":            "akun ini sudah ditautkan ke pengguna lain",
+	"PROVIDER_ALREADY_LINKED":    "login dari penyedia ini sudah ditautkan, lepaskan terlebih dahulu",
+	"LAST_IDENTITY":              "tidak dapat melepas satu-satunya metode login akun ini",
+	"UNSUPPORTED_PROVIDER":       "penyedia autentikasi tidak didukung",
+	"INVALID_LINK_STATE":         "permintaan penautan akun tidak valid atau sudah kedaluwarsa",
+	"EMAIL_BELONGS_TO_ANOTHER":   "akun dengan email ini sudah ada, masuk lalu tautkan login ini dari pengaturan",
+	"CANNOT_IMPERSONATE_ADMIN":   "akun admin tidak dapat diimpersonasi",
+	"CANNOT_IMPERSONATE_SELF":    "tidak dapat mengimpersonasi akun Anda sendiri",
+	"IMPERSONATION_FORBIDDEN":    "tindakan ini tidak diizinkan saat mengimpersonasi pengguna",
+	"REFERRAL_CODE_NOT_FOUND":    "kode referral tidak ditemukan",
+	"SELF_REFERRAL":              "pengguna tidak dapat mereferensikan dirinya sendiri",
+	"ALREADY_REFERRED":           "pengguna sudah pernah direferensikan",
+
+	"RESUME_NOT_FOUND":            "resume tidak ditemukan",
+	"RESUME_ACCESS_DENIED":        "tidak memiliki akses ke resume ini",
+	"INVALID_SEARCH":              "kata kunci pencarian harus terdiri dari 1 sampai 200 karakter",
+	"RESUME_NO_PHOTO":             "resume tidak memiliki foto",
+	"DUPLICATE_RESUME":            "resume ini hampir sama dengan resume yang sudah Anda miliki, atur force untuk tetap membuatnya",
+	"PHOTO_FILE_REQUIRED":         "file foto wajib diunggah, gunakan field form 'photo'",
+	"PDF_FILE_REQUIRED":           "file pdf wajib diunggah, gunakan field form 'file'",
+	"VIDEO_FILE_REQUIRED":         "file video wajib diunggah, gunakan field form 'video'",
+	"CUSTOM_BRANDING_NOT_ALLOWED": "gaya pdf kustom memerlukan paket dengan custom branding",
+	"OPTIMIZATION_NOT_FOUND":      "hasil optimasi tidak ditemukan atau sudah kedaluwarsa",
+	"BULLET_GENERATION_FAILED":    "tidak dapat membuat poin, coba jelaskan pekerjaan dengan lebih rinci",
+	"RESUME_DRAFT_NOT_FOUND":      "draf resume tidak ditemukan",
+	"RESUME_DRAFT_LIMIT":          "jumlah draf resume sudah mencapai batas maksimum",
+	"RESUME_DRAFT_INCOMPLETE":     "draf memerlukan judul minimal 3 karakter sebelum dapat diterbitkan",
+	"RESUME_TAG_NOT_FOUND":        "label resume tidak ditemukan",
+	"RESUME_TAG_EXISTS":           "label dengan nama ini sudah ada",
+	"RESUME_FOLDER_NOT_FOUND":     "folder resume tidak ditemukan",
+	"RESUME_FOLDER_EXISTS":        "folder dengan nama ini sudah ada",
+	"DUPLICATE_SECTION":           "bagian duplikat di section_order",
+	"UNKNOWN_SECTION":             "bagian tidak dikenal di section_order",
+	"DUPLICATE_CUSTOM_SECTION":    "bagian kustom duplikat",
+	"SHARE_LINK_NOT_FOUND":        "tautan berbagi tidak ditemukan",
+	"SHARE_LINK_EXPIRED":          "tautan berbagi sudah kedaluwarsa atau dicabut",
+	"COMMENT_NOT_FOUND":           "komentar tidak ditemukan",
+
+	"INTERVIEW_NOT_FOUND":       "interview tidak ditemukan",
+	"INTERVIEW_ACCESS_DENIED":   "tidak memiliki akses ke interview ini",
+	"INTERVIEW_COMPLETED":       "interview sudah selesai",
+	"INTERVIEW_NOT_READY":       "interview sudah dijadwalkan dan belum dapat dimulai",
+	"INTERVIEW_EVALUATING":      "jawaban interview sedang dievaluasi",
+	"INTERVIEW_ADAPTIVE":        "interview adaptif dijawab per ronde",
+	"INTERVIEW_NOT_ADAPTIVE":    "interview ini tidak adaptif",
+	"INCOMPLETE_ROUND":          "semua pertanyaan di ronde ini harus dijawab",
+	"INVALID_SCHEDULE_TIME":     "scheduled_at harus di masa depan dan paling lambat 90 hari lagi",
+	"EVALUATION_JOB_NOT_FOUND":  "job evaluasi tidak ditemukan",
+	"EVALUATION_NOT_FOUND":      "belum ada evaluasi untuk interview ini",
+	"VIDEO_ANSWERS_DISABLED":    "jawaban video tidak tersedia",
+	"VIDEO_ANSWER_NOT_ALLOWED":  "jawaban video hanya diterima untuk pertanyaan esai yang belum dijawab",
+	"VIDEO_NO_SPEECH":           "tidak ada suara yang dapat dikenali dalam video",
+	"INTERVIEW_PACK_NOT_FOUND":  "paket interview tidak ditemukan",
+	"INVALID_PACK_QUESTION":     "pertanyaan pilihan ganda memerlukan opsi dan jawaban benar yang sesuai dengan salah satu labelnya; pertanyaan esai tidak memiliki opsi",
+	"INVALID_RUBRIC":            "kriteria rubrik harus memiliki nama yang unik",
+	"BANK_QUESTION_NOT_FOUND":   "pertanyaan tidak ditemukan di bank soal",
+	"QUESTION_IMPORT_HEADER":    "header csv harus memiliki kolom question, type, correct_answer, dan position",
+	"QUESTION_IMPORT_EMPTY":     "csv tidak memiliki baris pertanyaan",
+	"QUESTION_IMPORT_TOO_LARGE": "csv memiliki terlalu banyak baris pertanyaan",
+	"QUESTION_IMPORT_MALFORMED": "file csv tidak dapat dibaca",
+	"INTERVIEW_NOT_SHAREABLE":   "hanya interview yang sudah selesai yang dapat dibagikan",
+	"SHARE_COMMENTS_DISABLED":   "komentar dinonaktifkan untuk tautan berbagi ini",
+	"SHARE_COMMENT_LIMIT":       "batas komentar untuk tautan berbagi ini sudah tercapai",
+	"STUDY_PLAN_NOT_FOUND":      "rencana belajar tidak ditemukan",
+	"STUDY_PLAN_NOT_AVAILABLE":  "rencana belajar hanya dapat dibuat untuk interview yang sudah selesai",
+	"STUDY_PLAN_TASK_NOT_FOUND": "tugas rencana belajar tidak ditemukan",
+	"STUDY_PLAN_FAILED":         "rencana belajar tidak dapat dibuat, silakan coba lagi",
+	"UNSUPPORTED_EXPORT_FORMAT": "format ekspor tidak didukung, gunakan json atau markdown",
+
+	"ATS_CHECK_NOT_FOUND":      "pengecekan ATS tidak ditemukan",
+	"ATS_CHECK_ACCESS_DENIED":  "tidak memiliki akses ke pengecekan ATS ini",
+	"TOO_MANY_JOBS":            "terlalu banyak deskripsi pekerjaan dalam satu batch",
+	"INVALID_JOB_DESCRIPTIONS": "job_descriptions harus berupa array JSON {title, description}",
+	"ATS_CHECK_NO_SOURCE":      "tidak ada pdf yang tersimpan untuk pengecekan ATS ini",
+	"ATS_ANALYSIS_NOT_FOUND":   "analisis ATS tidak ditemukan",
+	"PDF_NOT_ANNOTATABLE":      "pdf ini tidak dapat dianotasi",
+	"PDF_NO_TEXT":              "pdf ini tidak memiliki teks yang dapat dibaca, unggah pdf berbasis teks, bukan hasil pindaian",
+
+	"AI_CLIENT_UNAVAILABLE":       "layanan AI tidak tersedia",
+	"AI_SERVICE_UNAVAILABLE":      "layanan AI sedang tidak tersedia, silakan coba lagi nanti",
+	"AI_TIMEOUT":                  "permintaan AI melebihi batas waktu",
+	"AI_BUDGET_EXCEEDED":          "anggaran AI bulanan sudah habis",
+	"AI_CONCURRENCY_LIMIT":        "terlalu banyak permintaan AI yang sedang berjalan, tunggu hingga salah satunya selesai",
+	"INSUFFICIENT_INSIGHT_DATA":   "buat resume, interview, atau pengecekan ATS sebelum meminta insight",
+	"UNKNOWN_AI_FEEDBACK_FEATURE": "fitur umpan balik AI tidak dikenal",
+	"AI_FEEDBACK_NOT_READY":       "belum ada hasil AI yang dapat dinilai",
+
+	"PLAN_NOT_FOUND":                 "paket tidak ditemukan",
+	"PLAN_NAME_EXISTS":               "nama paket sudah digunakan",
+	"INVALID_PLAN_DATA":              "data paket tidak valid",
+	"PLAN_IN_USE":                    "paket memiliki langganan atau transaksi, arsipkan paket ini sebagai gantinya",
+	"PLAN_NOT_AVAILABLE":             "paket tidak tersedia untuk dibeli",
+	"ADDON_NOT_FOUND":                "add-on tidak ditemukan",
+	"ADDON_NAME_EXISTS":              "nama add-on sudah digunakan",
+	"ADDON_NOT_AVAILABLE":            "add-on tidak tersedia untuk dibeli",
+	"ADDON_NOT_NEEDED":               "paket Anda saat ini sudah tanpa batas untuk fitur ini",
+	"SUBSCRIPTION_REQUIRED":          "tidak ada langganan aktif",
+	"ACTIVE_SUBSCRIPTION_EXISTS":     "Anda sudah memiliki langganan aktif untuk paket ini",
+	"QUOTA_EXCEEDED":                 "kuota untuk fitur ini sudah habis",
+	"PLAN_CHANGE_NOT_DOWNGRADE":      "hanya paket gratis yang dapat dijadwalkan, paket berbayar harus dibeli",
+	"PLAN_CHANGE_SAME_PLAN":          "langganan sudah menggunakan paket ini",
+	"NO_SCHEDULED_PLAN_CHANGE":       "tidak ada perubahan paket yang dijadwalkan",
+	"GIFT_CODE_INVALID":              "kode hadiah tidak valid",
+	"GIFT_ALREADY_REDEEMED":          "kode hadiah sudah pernah digunakan",
+	"GIFT_PAID_SUBSCRIPTION_ACTIVE":  "langganan berbayar Anda saat ini harus berakhir sebelum hadiah dapat digunakan",
+	"TRANSACTION_NOT_FOUND":          "transaksi tidak ditemukan",
+	"TRANSACTION_ALREADY_PAID":       "transaksi sudah dibayar",
+	"TRANSACTION_NOT_PAID":           "transaksi belum dibayar",
+	"INVALID_TRANSACTION_AMOUNT":     "jumlah transaksi tidak sesuai dengan harga paket",
+	"INVALID_ORDER_ID":               "format order id tidak valid",
+	"INVALID_SIGNATURE":              "signature tidak valid",
+	"MISSING_SIGNATURE":              "signature wajib disertakan",
+	"INVALID_WEBHOOK_PAYLOAD":        "payload webhook tidak valid",
+	"MISSING_ORDER_ID":               "order_id tidak ada di payload",
+	"PAYMENT_GATEWAY_DOWN":           "payment gateway sedang tidak tersedia, silakan coba lagi nanti",
+	"PAYMENT_GATEWAY_NOT_CONFIGURED": "payment gateway belum dikonfigurasi",
+	"STALE_NOTIFICATION":             "notifikasi berada di luar rentang waktu yang diterima",
+	"DUPLICATE_NOTIFICATION":         "notifikasi sudah diproses",
+	"NOTIFICATION_NOT_QUEUED":        "notifikasi tidak dapat dimasukkan ke antrean, silakan coba lagi",
+	"PAYMENT_METHOD_NOT_FOUND":       "metode pembayaran tidak ditemukan",
+	"PAYMENT_METHOD_EXPIRED":         "kartu tersimpan sudah kedaluwarsa, silakan bayar dengan kartu tersebut lagi untuk menyimpannya",
+	"SAVED_CARD_DECLINED":            "kartu tersimpan ditolak",
+	"ORGANIZATION_NOT_FOUND":         "Anda bukan anggota organisasi",
+	"ALREADY_IN_ORGANIZATION":        "pengguna sudah menjadi anggota organisasi",
+	"ORGANIZATION_OWNER_ONLY":        "hanya pemilik organisasi yang dapat melakukan ini",
+	"ORGANIZATION_NO_SEATS":          "semua kursi organisasi sudah terisi",
+	"ORGANIZATION_SEATS_TOO_FEW":     "jumlah kursi tidak boleh kurang dari jumlah anggota saat ini",
+	"ORGANIZATION_MEMBER_NOT_FOUND":  "anggota organisasi tidak ditemukan",
+	"ORGANIZATION_OWNER_LEAVE":       "pemilik tidak dapat keluar atau dikeluarkan dari organisasi",
+	"INVITATION_INVALID":             "undangan tidak valid atau sudah kedaluwarsa",
+	"INVITATION_EMAIL_MISMATCH":      "undangan dikirim ke alamat email lain",
+	"QUOTA_OVERRIDE_NOT_FOUND":       "penyesuaian kuota tidak ditemukan",
+	"QUOTA_OVERRIDE_AMOUNT":          "isi jumlah atau unlimited, tidak keduanya",
+	"QUOTA_OVERRIDE_EXPIRY":          "waktu kedaluwarsa harus di masa depan",
+	"UNKNOWN_DATA_REGION":            "wilayah data tidak dikonfigurasi",
+	"DATA_REGION_LOCKED":             "wilayah data tidak dapat diubah setelah pengguna memiliki resume",
+	"PROVISIONING_JOB_NOT_FOUND":     "job provisioning tidak ditemukan",
+	"PROVISIONING_JOB_SUCCEEDED":     "job provisioning sudah berhasil",
+
+	"JOB_NOT_FOUND":                  "job tidak ditemukan",
+	"WEBHOOK_ENDPOINT_NOT_FOUND":     "endpoint webhook tidak ditemukan",
+	"WEBHOOK_DELIVERY_NOT_FOUND":     "pengiriman webhook tidak ditemukan",
+	"UNKNOWN_PROMPT":                 "key prompt tidak dikenal",
+	"PROMPT_VERSION_NOT_FOUND":       "versi prompt tidak ditemukan",
+	"PROMPT_PLACEHOLDERS":            "prompt harus mempertahankan placeholder yang sama, dengan urutan yang sama, seperti versi default",
+	"PROMPT_VERSION_ACTIVE":          "versi prompt yang aktif tidak dapat dihapus, aktifkan versi lain atau kembalikan ke default terlebih dahulu",
+	"PROMPT_VERSION_IN_EXPERIMENT":   "versi prompt ini adalah varian dari eksperimen yang sedang berjalan, hentikan eksperimen terlebih dahulu",
+	"EXPERIMENT_NOT_FOUND":           "eksperimen tidak ditemukan",
+	"EXPERIMENT_RUNNING":             "eksperimen untuk prompt ini sedang berjalan, hentikan terlebih dahulu",
+	"EXPERIMENT_STOPPED":             "eksperimen sudah dihentikan",
+	"EXPERIMENT_VARIANTS":            "varian eksperimen harus berupa versi prompt yang berbeda",
+	"EMAIL_SUPPRESSED":               "alamat email diblokir setelah bounce atau keluhan",
+	"EMAIL_SUPPRESSION_NOT_FOUND":    "blokir email tidak ditemukan",
+	"INVALID_EMAIL_CALLBACK_TOKEN":   "token callback email tidak valid",
+	"UNSUPPORTED_EMAIL_PROVIDER":     "penyedia email tidak didukung",
+	"INVALID_EMAIL_CALLBACK_PAYLOAD": "payload callback email tidak valid",
+	"ARTIFACT_STORAGE_DISABLED":      "penyimpanan artefak belum dikonfigurasi",
+	"INVALID_DATA_BUNDLE":            "bundel data tidak valid",
+	"UNSUPPORTED_BUNDLE_VERSION":     "versi bundel data tidak didukung",
+	"EMPTY_DATA_BUNDLE":              "bundel data tidak memiliki konten untuk diimpor",
+
+	"VALIDATION_REQUIRED":   "{field} wajib diisi",
+	"VALIDATION_EMAIL":      "{field} harus berupa alamat email yang valid",
+	"VALIDATION_LEN":        "{field} harus tepat {param} karakter",
+	"VALIDATION_MIN":        "{field} minimal {param}",
+	"VALIDATION_MIN_ITEMS":  "{field} harus berisi minimal {param} item",
+	"VALIDATION_MIN_LENGTH": "{field} minimal {param} karakter",
+	"VALIDATION_MAX":        "{field} maksimal {param}",
+	"VALIDATION_MAX_ITEMS":  "{field} harus berisi maksimal {param} item",
+	"VALIDATION_MAX_LENGTH": "{field} maksimal {param} karakter",
+	"VALIDATION_ONEOF":      "{field} harus salah satu dari: {param}",
+	"VALIDATION_NUMERIC":    "{field} hanya boleh berisi angka",
+	"VALIDATION_INVALID":    "{field} tidak valid",
+}
@@ -5,6 +5,8 @@ import (
 	"fmt"
 	"io"
 	"mime/multipart"
+	"net/url"
+	"path"
 	"path/filepath"
 	"strings"
 	"time"
@@ -22,8 +24,9 @@ type Config struct {
 }
 
 type Client struct {
-	ik        imagekit.Client
-	validator *validator.FileValidator
+	ik         imagekit.Client
+	validator  *validator.FileValidator
+	configured bool
 }
 
 type UploadResult struct {
@@ -41,8 +44,9 @@ func NewClient(config Config) *Client {
 	)
 
 	return &Client{
-		ik:        ik,
-		validator: validator.ImageValidator(),
+		ik:         ik,
+		validator:  validator.ImageValidator(),
+		configured: config.PrivateKey != "",
 	}
 }
 
@@ -98,3 +102,41 @@ func (c *Client) DeleteFile(ctx context.Context, fileID string) error {
 
 	return nil
 }
+
+// DeleteFileByURL deletes the file served at fileURL, for callers that kept
+// only the URL of an upload. URLs that are not ImageKit files are ignored,
+// as is everything when ImageKit is not configured.
+func (c *Client) DeleteFileByURL(ctx context.Context, fileURL string) error {
+	if fileURL == "" || !c.configured {
+		return nil
+	}
+
+	parsed, err := url.Parse(fileURL)
+	if err != nil {
+		return nil
+	}
+	name := path.Base(parsed.Path)
+	if name == "." || name == "/" {
+		return nil
+	}
+
+	files, err := c.ik.Assets.List(ctx, imagekit.AssetListParams{
+		SearchQuery: imagekit.String(fmt.Sprintf("name = %q", name)),
+	})
+	if err != nil {
+		return fmt.Errorf("failed to look up file in ImageKit: %w", err)
+	}
+	if files == nil {
+		return nil
+	}
+
+	for _, file := range *files {
+		if file.FileID == "" || file.URL != parsed.Scheme+"://"+parsed.Host+parsed.Path {
+			continue
+		}
+		if err := c.DeleteFile(ctx, file.FileID); err != nil {
+			return err
+		}
+	}
+	return nil
+}
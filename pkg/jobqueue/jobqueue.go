@@ -0,0 +1,594 @@
+package jobqueue
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log"
+	"math"
+	"os"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/redis/go-redis/v9"
+)
+
+const (
+	jobField          = "job"
+	readBlock         = 5 * time.Second
+	promoteInterval   = time.Second
+	promoteBatchSize  = 100
+	reclaimBatchSize  = 10
+	defaultTimeout    = 5 * time.Minute
+	defaultClaimIdle  = 10 * time.Minute
+	defaultWorkers    = 4
+	defaultKeyPrefix  = "jobs"
+	consumerGroupName = "workers"
+)
+
+var (
+	ErrJobNotFound    = errors.New("job not found")
+	ErrNoHandler      = errors.New("no handler registered for job type")
+	ErrAlreadyRunning = errors.New("job queue is already running")
+)
+
+// Job is one unit of work. Payload holds the JSON the job was enqueued with.
+type Job struct {
+	ID         string          `json:"id"`
+	Type       string          `json:"type"`
+	Payload    json.RawMessage `json:"payload"`
+	Attempts   int             `json:"attempts"`
+	LastError  string          `json:"last_error,omitempty"`
+	EnqueuedAt time.Time       `json:"enqueued_at"`
+	FailedAt   *time.Time      `json:"failed_at,omitempty"`
+}
+
+type Handler func(ctx context.Context, job *Job) error
+
+// Typed adapts a handler taking a decoded payload. Payloads that cannot be
+// decoded go straight to the dead-letter set since retrying cannot help.
+func Typed[T any](fn func(ctx context.Context, payload T) error) Handler {
+	return func(ctx context.Context, job *Job) error {
+		var payload T
+		if err := json.Unmarshal(job.Payload, &payload); err != nil {
+			return Permanent(fmt.Errorf("invalid payload: %w", err))
+		}
+		return fn(ctx, payload)
+	}
+}
+
+type permanentError struct {
+	err error
+}
+
+func (e *permanentError) Error() string { return e.err.Error() }
+func (e *permanentError) Unwrap() error { return e.err }
+
+// Permanent marks an error as not worth retrying.
+func Permanent(err error) error {
+	return &permanentError{err: err}
+}
+
+// RetryPolicy decides how often a failing job is attempted and how long to
+// wait between attempts. The wait doubles from BaseDelay up to MaxDelay.
+type RetryPolicy struct {
+	MaxAttempts int
+	BaseDelay   time.Duration
+	MaxDelay    time.Duration
+}
+
+var DefaultRetryPolicy = RetryPolicy{
+	MaxAttempts: 5,
+	BaseDelay:   10 * time.Second,
+	MaxDelay:    time.Hour,
+}
+
+func (p RetryPolicy) backoff(attempt int) time.Duration {
+	delay := time.Duration(float64(p.BaseDelay) * math.Pow(2, float64(attempt-1)))
+	if delay <= 0 || delay > p.MaxDelay {
+		return p.MaxDelay
+	}
+	return delay
+}
+
+type Config struct {
+	KeyPrefix string
+	Workers   int
+	Timeout   time.Duration
+	// ClaimIdle is how long a job may stay unacknowledged before another
+	// worker takes it over, which covers workers that died mid-job.
+	ClaimIdle time.Duration
+}
+
+type Stats struct {
+	Queued    int64 `json:"queued"`
+	Running   int64 `json:"running"`
+	Scheduled int64 `json:"scheduled"`
+	Dead      int64 `json:"dead"`
+}
+
+type registration struct {
+	handler Handler
+	policy  RetryPolicy
+}
+
+// Queue is a persistent job queue on Redis. Ready jobs live in a stream
+// read through a consumer group, delayed jobs and jobs waiting for a retry
+// in a sorted set scored by when they are due, and jobs that ran out of
+// attempts in a dead-letter hash indexed by a sorted set of failure times.
+// Delivery is at least once, so handlers must be safe to repeat.
+type Queue struct {
+	client       *redis.Client
+	streamKey    string
+	scheduledKey string
+	deadKey      string
+	deadIndexKey string
+	consumer     string
+	cfg          Config
+
+	mu       sync.RWMutex
+	handlers map[string]registration
+	running  bool
+}
+
+func New(client *redis.Client, cfg Config) *Queue {
+	if cfg.KeyPrefix == "" {
+		cfg.KeyPrefix = defaultKeyPrefix
+	}
+	if cfg.Workers <= 0 {
+		cfg.Workers = defaultWorkers
+	}
+	if cfg.Timeout <= 0 {
+		cfg.Timeout = defaultTimeout
+	}
+	if cfg.ClaimIdle <= 0 {
+		cfg.ClaimIdle = defaultClaimIdle
+	}
+
+	host, _ := os.Hostname()
+	return &Queue{
+		client:       client,
+		streamKey:    cfg.KeyPrefix + ":stream",
+		scheduledKey: cfg.KeyPrefix + ":scheduled",
+		deadKey:      cfg.KeyPrefix + ":dead",
+		deadIndexKey: cfg.KeyPrefix + ":dead:index",
+		consumer:     fmt.Sprintf("%s-%d", host, os.Getpid()),
+		cfg:          cfg,
+		handlers:     make(map[string]registration),
+	}
+}
+
+func (q *Queue) Register(jobType string, handler Handler, policy RetryPolicy) {
+	if policy.MaxAttempts <= 0 {
+		policy = DefaultRetryPolicy
+	}
+
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	q.handlers[jobType] = registration{handler: handler, policy: policy}
+}
+
+func (q *Queue) Enqueue(ctx context.Context, jobType string, payload interface{}) (string, error) {
+	data, err := json.Marshal(payload)
+	if err != nil {
+		return "", fmt.Errorf("failed to encode job payload: %w", err)
+	}
+
+	job := &Job{
+		ID:         uuid.NewString(),
+		Type:       jobType,
+		Payload:    data,
+		EnqueuedAt: time.Now(),
+	}
+	if err := q.push(ctx, job); err != nil {
+		return "", err
+	}
+	return job.ID, nil
+}
+
+// EnqueueAt queues a job that becomes ready at the given time.
+func (q *Queue) EnqueueAt(ctx context.Context, jobType string, payload interface{}, at time.Time) (string, error) {
+	data, err := json.Marshal(payload)
+	if err != nil {
+		return "", fmt.Errorf("failed to encode job payload: %w", err)
+	}
+
+	job := &Job{
+		ID:         uuid.NewString(),
+		Type:       jobType,
+		Payload:    data,
+		EnqueuedAt: time.Now(),
+	}
+	if err := q.schedule(ctx, job, at); err != nil {
+		return "", fmt.Errorf("failed to schedule job: %w", err)
+	}
+	return job.ID, nil
+}
+
+// Cancel removes a job that is still waiting in the scheduled set, whether
+// enqueued for later or waiting for a retry. Jobs already on the stream
+// cannot be recalled, so handlers of cancelable jobs should still check
+// that their work is wanted.
+func (q *Queue) Cancel(ctx context.Context, id string) error {
+	// Job encodes its ID first, so the member can be matched by prefix.
+	match := fmt.Sprintf(`{"id":%q,*`, id)
+	iter := q.client.ZScan(ctx, q.scheduledKey, 0, match, promoteBatchSize).Iterator()
+	for iter.Next(ctx) {
+		member := iter.Val()
+		// ZSCAN returns members and scores alternately.
+		if !iter.Next(ctx) {
+			break
+		}
+		removed, err := q.client.ZRem(ctx, q.scheduledKey, member).Result()
+		if err != nil {
+			return err
+		}
+		if removed > 0 {
+			return nil
+		}
+	}
+	if err := iter.Err(); err != nil {
+		return err
+	}
+	return ErrJobNotFound
+}
+
+// Start runs the workers in the background until ctx is canceled.
+func (q *Queue) Start(ctx context.Context) error {
+	q.mu.Lock()
+	if q.running {
+		q.mu.Unlock()
+		return ErrAlreadyRunning
+	}
+	q.running = true
+	q.mu.Unlock()
+
+	err := q.client.XGroupCreateMkStream(ctx, q.streamKey, consumerGroupName, "0").Err()
+	if err != nil && !strings.Contains(err.Error(), "BUSYGROUP") {
+		return fmt.Errorf("failed to create consumer group: %w", err)
+	}
+
+	for i := 0; i < q.cfg.Workers; i++ {
+		go q.work(ctx)
+	}
+	go q.promote(ctx)
+	go q.reclaim(ctx)
+	return nil
+}
+
+func (q *Queue) Stats(ctx context.Context) (*Stats, error) {
+	pipe := q.client.Pipeline()
+	queued := pipe.XLen(ctx, q.streamKey)
+	running := pipe.XPending(ctx, q.streamKey, consumerGroupName)
+	scheduled := pipe.ZCard(ctx, q.scheduledKey)
+	dead := pipe.HLen(ctx, q.deadKey)
+	if _, err := pipe.Exec(ctx); err != nil && !isMissingGroup(err) {
+		return nil, err
+	}
+
+	stats := &Stats{
+		Queued:    queued.Val(),
+		Scheduled: scheduled.Val(),
+		Dead:      dead.Val(),
+	}
+	if pending, err := running.Result(); err == nil {
+		stats.Running = pending.Count
+	}
+	stats.Queued -= stats.Running
+	return stats, nil
+}
+
+// DeadJobs lists jobs that ran out of attempts, most recent failure first.
+func (q *Queue) DeadJobs(ctx context.Context, offset, limit int) ([]Job, int64, error) {
+	total, err := q.client.ZCard(ctx, q.deadIndexKey).Result()
+	if err != nil {
+		return nil, 0, err
+	}
+
+	ids, err := q.client.ZRevRange(ctx, q.deadIndexKey, int64(offset), int64(offset+limit-1)).Result()
+	if err != nil {
+		return nil, 0, err
+	}
+
+	jobs := make([]Job, 0, len(ids))
+	if len(ids) == 0 {
+		return jobs, total, nil
+	}
+
+	values, err := q.client.HMGet(ctx, q.deadKey, ids...).Result()
+	if err != nil {
+		return nil, 0, err
+	}
+	for _, value := range values {
+		raw, ok := value.(string)
+		if !ok {
+			continue
+		}
+		var job Job
+		if err := json.Unmarshal([]byte(raw), &job); err == nil {
+			jobs = append(jobs, job)
+		}
+	}
+	return jobs, total, nil
+}
+
+func (q *Queue) DeadJob(ctx context.Context, id string) (*Job, error) {
+	raw, err := q.client.HGet(ctx, q.deadKey, id).Result()
+	if err != nil {
+		if errors.Is(err, redis.Nil) {
+			return nil, ErrJobNotFound
+		}
+		return nil, err
+	}
+
+	var job Job
+	if err := json.Unmarshal([]byte(raw), &job); err != nil {
+		return nil, err
+	}
+	return &job, nil
+}
+
+// RetryDead moves a dead job back onto the queue with a fresh set of
+// attempts.
+func (q *Queue) RetryDead(ctx context.Context, id string) (*Job, error) {
+	job, err := q.DeadJob(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	removed, err := q.removeDead(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if !removed {
+		return nil, ErrJobNotFound
+	}
+
+	job.Attempts = 0
+	job.LastError = ""
+	job.FailedAt = nil
+	if err := q.push(ctx, job); err != nil {
+		return nil, err
+	}
+	return job, nil
+}
+
+func (q *Queue) DeleteDead(ctx context.Context, id string) error {
+	removed, err := q.removeDead(ctx, id)
+	if err != nil {
+		return err
+	}
+	if !removed {
+		return ErrJobNotFound
+	}
+	return nil
+}
+
+func (q *Queue) push(ctx context.Context, job *Job) error {
+	data, err := json.Marshal(job)
+	if err != nil {
+		return err
+	}
+	err = q.client.XAdd(ctx, &redis.XAddArgs{
+		Stream: q.streamKey,
+		Values: map[string]interface{}{jobField: data},
+	}).Err()
+	if err != nil {
+		return fmt.Errorf("failed to enqueue job: %w", err)
+	}
+	return nil
+}
+
+func (q *Queue) work(ctx context.Context) {
+	for ctx.Err() == nil {
+		streams, err := q.client.XReadGroup(ctx, &redis.XReadGroupArgs{
+			Group:    consumerGroupName,
+			Consumer: q.consumer,
+			Streams:  []string{q.streamKey, ">"},
+			Count:    1,
+			Block:    readBlock,
+		}).Result()
+		if err != nil {
+			if errors.Is(err, redis.Nil) || ctx.Err() != nil {
+				continue
+			}
+			log.Printf("Job queue: read failed: %v", err)
+			sleep(ctx, time.Second)
+			continue
+		}
+
+		for _, stream := range streams {
+			for _, message := range stream.Messages {
+				q.process(ctx, message)
+			}
+		}
+	}
+}
+
+// promote moves scheduled jobs that are due onto the stream. A job
+// is only pushed by whoever removed it from the sorted set, so several
+// instances can promote at once without duplicating jobs.
+func (q *Queue) promote(ctx context.Context) {
+	ticker := time.NewTicker(promoteInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+
+		due, err := q.client.ZRangeByScore(ctx, q.scheduledKey, &redis.ZRangeBy{
+			Min:   "-inf",
+			Max:   fmt.Sprint(time.Now().UnixMilli()),
+			Count: promoteBatchSize,
+		}).Result()
+		if err != nil {
+			if ctx.Err() == nil {
+				log.Printf("Job queue: failed to read scheduled jobs: %v", err)
+			}
+			continue
+		}
+
+		for _, raw := range due {
+			removed, err := q.client.ZRem(ctx, q.scheduledKey, raw).Result()
+			if err != nil || removed == 0 {
+				continue
+			}
+			if err := q.client.XAdd(ctx, &redis.XAddArgs{
+				Stream: q.streamKey,
+				Values: map[string]interface{}{jobField: raw},
+			}).Err(); err != nil {
+				log.Printf("Job queue: failed to promote scheduled job: %v", err)
+			}
+		}
+	}
+}
+
+// reclaim takes over jobs left unacknowledged by workers that stopped
+// mid-job.
+func (q *Queue) reclaim(ctx context.Context) {
+	ticker := time.NewTicker(q.cfg.ClaimIdle / 2)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+
+		messages, _, err := q.client.XAutoClaim(ctx, &redis.XAutoClaimArgs{
+			Stream:   q.streamKey,
+			Group:    consumerGroupName,
+			Consumer: q.consumer,
+			MinIdle:  q.cfg.ClaimIdle,
+			Start:    "0-0",
+			Count:    reclaimBatchSize,
+		}).Result()
+		if err != nil {
+			if ctx.Err() == nil {
+				log.Printf("Job queue: failed to reclaim stalled jobs: %v", err)
+			}
+			continue
+		}
+
+		for _, message := range messages {
+			q.process(ctx, message)
+		}
+	}
+}
+
+func (q *Queue) process(ctx context.Context, message redis.XMessage) {
+	raw, _ := message.Values[jobField].(string)
+
+	var job Job
+	if err := json.Unmarshal([]byte(raw), &job); err != nil {
+		log.Printf("Job queue: dropping malformed message %s: %v", message.ID, err)
+		q.ack(ctx, message.ID)
+		return
+	}
+
+	q.mu.RLock()
+	reg, ok := q.handlers[job.Type]
+	q.mu.RUnlock()
+
+	var err error
+	if ok {
+		err = q.run(ctx, reg.handler, &job)
+	} else {
+		err = Permanent(fmt.Errorf("%w: %s", ErrNoHandler, job.Type))
+	}
+	if err == nil {
+		q.ack(ctx, message.ID)
+		return
+	}
+
+	job.Attempts++
+	job.LastError = err.Error()
+
+	var permanent *permanentError
+	if errors.As(err, &permanent) || job.Attempts >= reg.policy.MaxAttempts {
+		if err := q.bury(ctx, &job); err != nil {
+			log.Printf("Job queue: failed to dead-letter job %s: %v", job.ID, err)
+			return
+		}
+		log.Printf("Job queue: job %s (%s) failed permanently: %s", job.ID, job.Type, job.LastError)
+	} else if err := q.schedule(ctx, &job, time.Now().Add(reg.policy.backoff(job.Attempts))); err != nil {
+		log.Printf("Job queue: failed to schedule retry of job %s: %v", job.ID, err)
+		return
+	}
+
+	q.ack(ctx, message.ID)
+}
+
+func (q *Queue) run(ctx context.Context, handler Handler, job *Job) (err error) {
+	ctx, cancel := context.WithTimeout(ctx, q.cfg.Timeout)
+	defer cancel()
+
+	defer func() {
+		if r := recover(); r != nil {
+			err = fmt.Errorf("job panicked: %v", r)
+		}
+	}()
+
+	return handler(ctx, job)
+}
+
+func (q *Queue) schedule(ctx context.Context, job *Job, at time.Time) error {
+	data, err := json.Marshal(job)
+	if err != nil {
+		return err
+	}
+	return q.client.ZAdd(ctx, q.scheduledKey, redis.Z{Score: float64(at.UnixMilli()), Member: data}).Err()
+}
+
+func (q *Queue) bury(ctx context.Context, job *Job) error {
+	now := time.Now()
+	job.FailedAt = &now
+
+	data, err := json.Marshal(job)
+	if err != nil {
+		return err
+	}
+
+	pipe := q.client.TxPipeline()
+	pipe.HSet(ctx, q.deadKey, job.ID, data)
+	pipe.ZAdd(ctx, q.deadIndexKey, redis.Z{Score: float64(now.UnixMilli()), Member: job.ID})
+	_, err = pipe.Exec(ctx)
+	return err
+}
+
+func (q *Queue) removeDead(ctx context.Context, id string) (bool, error) {
+	pipe := q.client.TxPipeline()
+	removed := pipe.HDel(ctx, q.deadKey, id)
+	pipe.ZRem(ctx, q.deadIndexKey, id)
+	if _, err := pipe.Exec(ctx); err != nil {
+		return false, err
+	}
+	return removed.Val() > 0, nil
+}
+
+func (q *Queue) ack(ctx context.Context, messageID string) {
+	pipe := q.client.TxPipeline()
+	pipe.XAck(ctx, q.streamKey, consumerGroupName, messageID)
+	pipe.XDel(ctx, q.streamKey, messageID)
+	if _, err := pipe.Exec(ctx); err != nil && ctx.Err() == nil {
+		log.Printf("Job queue: failed to acknowledge message %s: %v", messageID, err)
+	}
+}
+
+func isMissingGroup(err error) bool {
+	return strings.Contains(err.Error(), "NOGROUP")
+}
+
+func sleep(ctx context.Context, d time.Duration) {
+	select {
+	case <-ctx.Done():
+	case <-time.After(d):
+	}
+}
@@ -0,0 +1,206 @@
+package jsonschema
+
+import (
+	"reflect"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/shopspring/decimal"
+)
+
+const Draft = "https://json-schema.org/draft/2020-12/schema"
+
+type Schema struct {
+	SchemaURI  string             `json:"$schema,omitempty"`
+	Title      string             `json:"title,omitempty"`
+	Type       string             `json:"type,omitempty"`
+	Format     string             `json:"format,omitempty"`
+	Nullable   bool               `json:"nullable,omitempty"`
+	Properties map[string]*Schema `json:"properties,omitempty"`
+	Required   []string           `json:"required,omitempty"`
+	Items      *Schema            `json:"items,omitempty"`
+	Enum       []string           `json:"enum,omitempty"`
+	MinLength  *int               `json:"minLength,omitempty"`
+	MaxLength  *int               `json:"maxLength,omitempty"`
+	Minimum    *float64           `json:"minimum,omitempty"`
+	Maximum    *float64           `json:"maximum,omitempty"`
+	MinItems   *int               `json:"minItems,omitempty"`
+	MaxItems   *int               `json:"maxItems,omitempty"`
+}
+
+var (
+	timeType    = reflect.TypeOf(time.Time{})
+	uuidType    = reflect.TypeOf(uuid.UUID{})
+	decimalType = reflect.TypeOf(decimal.Decimal{})
+)
+
+// Generate builds a JSON schema for v using its json tags for property names
+// and its go-playground validate tags for constraints.
+func Generate(title string, v interface{}) *Schema {
+	t := reflect.TypeOf(v)
+	s := fromType(t, "")
+	s.SchemaURI = Draft
+	s.Title = title
+	return s
+}
+
+// Of builds a schema for v without the $schema header, for embedding in
+// larger documents such as an OpenAPI spec.
+func Of(v interface{}) *Schema {
+	return fromType(reflect.TypeOf(v), "")
+}
+
+func fromType(t reflect.Type, rules string) *Schema {
+	nullable := false
+	for t.Kind() == reflect.Ptr {
+		t = t.Elem()
+		nullable = true
+	}
+
+	itemRules := ""
+	if idx := strings.Index(rules, "dive"); idx >= 0 {
+		itemRules = strings.TrimPrefix(rules[idx+len("dive"):], ",")
+		rules = strings.TrimSuffix(rules[:idx], ",")
+	}
+
+	var s *Schema
+	switch {
+	case t == timeType:
+		s = &Schema{Type: "string", Format: "date-time"}
+	case t == uuidType:
+		s = &Schema{Type: "string", Format: "uuid"}
+	case t == decimalType:
+		s = &Schema{Type: "number"}
+	default:
+		switch t.Kind() {
+		case reflect.String:
+			s = &Schema{Type: "string"}
+		case reflect.Bool:
+			s = &Schema{Type: "boolean"}
+		case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+			reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+			s = &Schema{Type: "integer"}
+		case reflect.Float32, reflect.Float64:
+			s = &Schema{Type: "number"}
+		case reflect.Slice, reflect.Array:
+			s = &Schema{Type: "array", Items: fromType(t.Elem(), itemRules)}
+		case reflect.Map:
+			s = &Schema{Type: "object"}
+		case reflect.Struct:
+			s = fromStruct(t)
+		default:
+			s = &Schema{}
+		}
+	}
+
+	s.Nullable = nullable
+	applyRules(s, rules)
+	return s
+}
+
+func fromStruct(t reflect.Type) *Schema {
+	s := &Schema{
+		Type:       "object",
+		Properties: make(map[string]*Schema),
+	}
+
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		if !field.IsExported() {
+			continue
+		}
+
+		name, skip := jsonName(field)
+		if skip {
+			continue
+		}
+
+		rules := field.Tag.Get("validate")
+		s.Properties[name] = fromType(field.Type, rules)
+
+		if hasRule(rules, "required") && !strings.Contains(rules, "omitempty") {
+			s.Required = append(s.Required, name)
+		}
+	}
+
+	return s
+}
+
+func jsonName(field reflect.StructField) (string, bool) {
+	tag := field.Tag.Get("json")
+	if tag == "-" {
+		return "", true
+	}
+	name := strings.Split(tag, ",")[0]
+	if name == "" {
+		name = field.Name
+	}
+	return name, false
+}
+
+func hasRule(rules, name string) bool {
+	for _, r := range strings.Split(rules, ",") {
+		if r == name {
+			return true
+		}
+	}
+	return false
+}
+
+func applyRules(s *Schema, rules string) {
+	if rules == "" {
+		return
+	}
+
+	for _, rule := range strings.Split(rules, ",") {
+		key, param, _ := strings.Cut(rule, "=")
+		switch key {
+		case "email":
+			s.Format = "email"
+		case "url":
+			s.Format = "uri"
+		case "uuid", "uuid4":
+			s.Format = "uuid"
+		case "oneof":
+			s.Enum = strings.Fields(param)
+		case "len":
+			setBound(s, param, true)
+			setBound(s, param, false)
+		case "min", "gte":
+			setBound(s, param, true)
+		case "max", "lte":
+			setBound(s, param, false)
+		}
+	}
+}
+
+func setBound(s *Schema, param string, lower bool) {
+	n, err := strconv.ParseFloat(param, 64)
+	if err != nil {
+		return
+	}
+	i := int(n)
+
+	switch s.Type {
+	case "string":
+		if lower {
+			s.MinLength = &i
+		} else {
+			s.MaxLength = &i
+		}
+	case "array":
+		if lower {
+			s.MinItems = &i
+		} else {
+			s.MaxItems = &i
+		}
+	case "integer", "number":
+		if lower {
+			s.Minimum = &n
+		} else {
+			s.Maximum = &n
+		}
+	}
+}
@@ -14,9 +14,12 @@ var (
 )
 
 type Claims struct {
-	UserID uuid.UUID `json:"user_id"`
-	Email  string    `json:"email"`
-	Role   string    `json:"role"`
+	UserID    uuid.UUID `json:"user_id"`
+	SessionID uuid.UUID `json:"sid"`
+	Email     string    `json:"email"`
+	Role      string    `json:"role"`
+	// ImpersonatorID is set on tokens an admin obtained to act as this user
+	ImpersonatorID *uuid.UUID `json:"imp,omitempty"`
 	jwt.RegisteredClaims
 }
 
@@ -32,15 +35,31 @@ func NewJWTManager(secret string, expiryHours int) *JWTManager {
 	}
 }
 
-func (m *JWTManager) Generate(userID uuid.UUID, email, role string) (string, error) {
+func (m *JWTManager) Generate(userID, sessionID uuid.UUID, email, role string) (string, error) {
 	claims := Claims{
-		UserID: userID,
-		Email:  email,
-		Role:   role,
-		RegisteredClaims: jwt.RegisteredClaims{
-			ExpiresAt: jwt.NewNumericDate(time.Now().Add(time.Duration(m.expiryHours) * time.Hour)),
-			IssuedAt:  jwt.NewNumericDate(time.Now()),
-		},
+		UserID:    userID,
+		SessionID: sessionID,
+		Email:     email,
+		Role:      role,
+	}
+	return m.sign(claims, time.Duration(m.expiryHours)*time.Hour)
+}
+
+func (m *JWTManager) GenerateImpersonation(userID, sessionID, impersonatorID uuid.UUID, email, role string, ttl time.Duration) (string, error) {
+	claims := Claims{
+		UserID:         userID,
+		SessionID:      sessionID,
+		Email:          email,
+		Role:           role,
+		ImpersonatorID: &impersonatorID,
+	}
+	return m.sign(claims, ttl)
+}
+
+func (m *JWTManager) sign(claims Claims, ttl time.Duration) (string, error) {
+	claims.RegisteredClaims = jwt.RegisteredClaims{
+		ExpiresAt: jwt.NewNumericDate(time.Now().Add(ttl)),
+		IssuedAt:  jwt.NewNumericDate(time.Now()),
 	}
 
 	token := jwt.NewWithClaims(jwt.SigningMethodHS256, claims)
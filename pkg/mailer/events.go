@@ -0,0 +1,180 @@
+package mailer
+
+import (
+	"encoding/json"
+	"strings"
+	"time"
+)
+
+type EventType string
+
+const (
+	EventDelivered  EventType = "delivered"
+	EventBounced    EventType = "bounced"
+	EventComplained EventType = "complained"
+	EventFailed     EventType = "failed"
+)
+
+// Event is a provider delivery status callback normalized across drivers.
+// Permanent is set for hard bounces that will fail again on every retry.
+type Event struct {
+	MessageID string
+	Recipient string
+	Type      EventType
+	Permanent bool
+	Detail    string
+	Timestamp time.Time
+}
+
+type sendGridEvent struct {
+	Email       string `json:"email"`
+	Event       string `json:"event"`
+	Type        string `json:"type"`
+	Reason      string `json:"reason"`
+	SGMessageID string `json:"sg_message_id"`
+	Timestamp   int64  `json:"timestamp"`
+}
+
+// ParseSendGridEvents parses a SendGrid Event Webhook batch, keeping only
+// the delivery related events.
+func ParseSendGridEvents(body []byte) ([]Event, error) {
+	var raw []sendGridEvent
+	if err := json.Unmarshal(body, &raw); err != nil {
+		return nil, err
+	}
+
+	events := make([]Event, 0, len(raw))
+	for _, e := range raw {
+		event := Event{
+			// X-Message-Id returned on send is the prefix of sg_message_id.
+			MessageID: strings.SplitN(e.SGMessageID, ".", 2)[0],
+			Recipient: e.Email,
+			Detail:    e.Reason,
+			Timestamp: time.Unix(e.Timestamp, 0).UTC(),
+		}
+		switch e.Event {
+		case "delivered":
+			event.Type = EventDelivered
+		case "bounce":
+			event.Type = EventBounced
+			event.Permanent = e.Type != "blocked"
+		case "blocked":
+			event.Type = EventBounced
+		case "dropped":
+			event.Type = EventFailed
+			event.Permanent = strings.Contains(e.Reason, "Bounced Address") || strings.Contains(e.Reason, "Invalid")
+		case "spamreport":
+			event.Type = EventComplained
+			event.Permanent = true
+		default:
+			continue
+		}
+		events = append(events, event)
+	}
+	return events, nil
+}
+
+// SNSMessage is the envelope Amazon SNS posts to HTTP subscribers, which is
+// how SES delivers bounce, complaint and delivery notifications.
+type SNSMessage struct {
+	Type         string `json:"Type"`
+	MessageID    string `json:"MessageId"`
+	TopicArn     string `json:"TopicArn"`
+	Message      string `json:"Message"`
+	SubscribeURL string `json:"SubscribeURL"`
+}
+
+const (
+	SNSSubscriptionConfirmation = "SubscriptionConfirmation"
+	SNSNotification             = "Notification"
+)
+
+func ParseSNSMessage(body []byte) (*SNSMessage, error) {
+	var msg SNSMessage
+	if err := json.Unmarshal(body, &msg); err != nil {
+		return nil, err
+	}
+	return &msg, nil
+}
+
+type sesRecipient struct {
+	EmailAddress   string `json:"emailAddress"`
+	DiagnosticCode string `json:"diagnosticCode"`
+}
+
+type sesNotification struct {
+	NotificationType string `json:"notificationType"`
+	EventType        string `json:"eventType"`
+	Mail             struct {
+		MessageID string `json:"messageId"`
+	} `json:"mail"`
+	Bounce struct {
+		BounceType        string         `json:"bounceType"`
+		BounceSubType     string         `json:"bounceSubType"`
+		BouncedRecipients []sesRecipient `json:"bouncedRecipients"`
+		Timestamp         time.Time      `json:"timestamp"`
+	} `json:"bounce"`
+	Complaint struct {
+		ComplainedRecipients  []sesRecipient `json:"complainedRecipients"`
+		ComplaintFeedbackType string         `json:"complaintFeedbackType"`
+		Timestamp             time.Time      `json:"timestamp"`
+	} `json:"complaint"`
+	Delivery struct {
+		Recipients []string  `json:"recipients"`
+		Timestamp  time.Time `json:"timestamp"`
+	} `json:"delivery"`
+}
+
+// ParseSESEvents parses the SES notification carried in an SNS message.
+// Both notification and event publishing formats are accepted.
+func ParseSESEvents(message string) ([]Event, error) {
+	var n sesNotification
+	if err := json.Unmarshal([]byte(message), &n); err != nil {
+		return nil, err
+	}
+
+	kind := n.NotificationType
+	if kind == "" {
+		kind = n.EventType
+	}
+
+	events := make([]Event, 0)
+	switch kind {
+	case "Bounce":
+		for _, r := range n.Bounce.BouncedRecipients {
+			detail := n.Bounce.BounceSubType
+			if r.DiagnosticCode != "" {
+				detail = r.DiagnosticCode
+			}
+			events = append(events, Event{
+				MessageID: n.Mail.MessageID,
+				Recipient: r.EmailAddress,
+				Type:      EventBounced,
+				Permanent: n.Bounce.BounceType == "Permanent",
+				Detail:    detail,
+				Timestamp: n.Bounce.Timestamp,
+			})
+		}
+	case "Complaint":
+		for _, r := range n.Complaint.ComplainedRecipients {
+			events = append(events, Event{
+				MessageID: n.Mail.MessageID,
+				Recipient: r.EmailAddress,
+				Type:      EventComplained,
+				Permanent: true,
+				Detail:    n.Complaint.ComplaintFeedbackType,
+				Timestamp: n.Complaint.Timestamp,
+			})
+		}
+	case "Delivery":
+		for _, recipient := range n.Delivery.Recipients {
+			events = append(events, Event{
+				MessageID: n.Mail.MessageID,
+				Recipient: recipient,
+				Type:      EventDelivered,
+				Timestamp: n.Delivery.Timestamp,
+			})
+		}
+	}
+	return events, nil
+}
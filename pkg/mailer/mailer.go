@@ -0,0 +1,92 @@
+package mailer
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"net/http"
+	"time"
+)
+
+const (
+	DriverSMTP     = "smtp"
+	DriverSendGrid = "sendgrid"
+	DriverSES      = "ses"
+)
+
+const httpTimeout = 15 * time.Second
+
+var ErrUnknownDriver = errors.New("unknown email driver")
+
+type Message struct {
+	To      string
+	Subject string
+	Text    string
+}
+
+// Sender delivers a message and returns the provider's message ID, which
+// delivery status callbacks refer back to.
+type Sender interface {
+	Driver() string
+	Send(ctx context.Context, msg Message) (string, error)
+}
+
+// PermanentError marks a failure that will not go away on retry, such as a
+// mailbox that does not exist.
+type PermanentError struct {
+	Err error
+}
+
+func (e *PermanentError) Error() string {
+	return e.Err.Error()
+}
+
+func (e *PermanentError) Unwrap() error {
+	return e.Err
+}
+
+func IsPermanent(err error) bool {
+	var permanent *PermanentError
+	return errors.As(err, &permanent)
+}
+
+type Config struct {
+	Driver string
+	From   string
+
+	SMTPHost     string
+	SMTPPort     int
+	SMTPUsername string
+	SMTPPassword string
+
+	SendGridAPIKey string
+
+	SESRegion          string
+	SESAccessKeyID     string
+	SESSecretAccessKey string
+}
+
+func New(cfg Config) (Sender, error) {
+	switch cfg.Driver {
+	case "", DriverSMTP:
+		return NewSMTPSender(cfg.SMTPHost, cfg.SMTPPort, cfg.SMTPUsername, cfg.SMTPPassword, cfg.From), nil
+	case DriverSendGrid:
+		return NewSendGridSender(cfg.SendGridAPIKey, cfg.From), nil
+	case DriverSES:
+		return NewSESSender(cfg.SESRegion, cfg.SESAccessKeyID, cfg.SESSecretAccessKey, cfg.From), nil
+	default:
+		return nil, fmt.Errorf("%w: %q", ErrUnknownDriver, cfg.Driver)
+	}
+}
+
+func newHTTPClient() *http.Client {
+	return &http.Client{Timeout: httpTimeout}
+}
+
+func randomID() string {
+	b := make([]byte, 16)
+	_, _ = rand.Read(b)
+	return hex.EncodeToString(b)
+}
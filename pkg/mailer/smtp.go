@@ -0,0 +1,67 @@
+package mailer
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/smtp"
+	"net/textproto"
+	"strings"
+)
+
+type SMTPSender struct {
+	host     string
+	port     int
+	username string
+	password string
+	from     string
+}
+
+func NewSMTPSender(host string, port int, username, password, from string) *SMTPSender {
+	return &SMTPSender{
+		host:     host,
+		port:     port,
+		username: username,
+		password: password,
+		from:     from,
+	}
+}
+
+func (s *SMTPSender) Driver() string {
+	return DriverSMTP
+}
+
+// Send relays the message through the SMTP server. SMTP reports bounces
+// asynchronously by mail, so only recipients the server rejects outright
+// are reported as permanent failures.
+func (s *SMTPSender) Send(_ context.Context, msg Message) (string, error) {
+	auth := smtp.PlainAuth("", s.username, s.password, s.host)
+
+	domain := s.host
+	if at := strings.LastIndex(s.from, "@"); at >= 0 {
+		domain = s.from[at+1:]
+	}
+	messageID := fmt.Sprintf("%s@%s", randomID(), domain)
+
+	body := fmt.Sprintf("From: %s\r\nTo: %s\r\nSubject: %s\r\nMessage-ID: <%s>\r\nMIME-Version: 1.0\r\nContent-Type: text/plain; charset=UTF-8\r\n\r\n%s",
+		s.from, msg.To, msg.Subject, messageID, msg.Text)
+
+	addr := fmt.Sprintf("%s:%d", s.host, s.port)
+	if err := smtp.SendMail(addr, auth, s.from, []string{msg.To}, []byte(body)); err != nil {
+		var protoErr *textproto.Error
+		if errors.As(err, &protoErr) && isRecipientRejected(protoErr.Code) {
+			return "", &PermanentError{Err: err}
+		}
+		return "", err
+	}
+
+	return messageID, nil
+}
+
+func isRecipientRejected(code int) bool {
+	switch code {
+	case 550, 551, 553:
+		return true
+	}
+	return false
+}
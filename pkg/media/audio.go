@@ -0,0 +1,71 @@
+package media
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+	"strings"
+)
+
+// AudioMIMEType is the format ExtractAudio produces.
+const AudioMIMEType = "audio/flac"
+
+var ErrFFmpegUnavailable = errors.New("ffmpeg is not available")
+
+// FFmpegPath resolves the ffmpeg binary to use, returning an empty string
+// when it cannot be found so callers can skip audio extraction.
+func FFmpegPath(configured string) string {
+	if configured == "" {
+		configured = "ffmpeg"
+	}
+	path, err := exec.LookPath(configured)
+	if err != nil {
+		return ""
+	}
+	return path
+}
+
+// ExtractAudio strips the video track and downmixes the audio to 16 kHz mono
+// FLAC, which is all transcription needs and far smaller than the video.
+// The input is written to a temporary file because containers such as MP4
+// may keep their index at the end, which ffmpeg cannot read from a pipe.
+func ExtractAudio(ctx context.Context, ffmpegPath string, video []byte) ([]byte, error) {
+	if ffmpegPath == "" {
+		return nil, ErrFFmpegUnavailable
+	}
+
+	input, err := os.CreateTemp("", "careerly-video-*")
+	if err != nil {
+		return nil, err
+	}
+	defer os.Remove(input.Name())
+
+	if _, err := input.Write(video); err != nil {
+		input.Close()
+		return nil, err
+	}
+	if err := input.Close(); err != nil {
+		return nil, err
+	}
+
+	var stdout, stderr bytes.Buffer
+	cmd := exec.CommandContext(ctx, ffmpegPath,
+		"-hide_banner", "-loglevel", "error",
+		"-i", input.Name(),
+		"-vn", "-ac", "1", "-ar", "16000",
+		"-c:a", "flac", "-f", "flac", "pipe:1",
+	)
+	cmd.Stdout = &stdout
+	cmd.Stderr = &stderr
+	if err := cmd.Run(); err != nil {
+		return nil, fmt.Errorf("ffmpeg failed: %w: %s", err, strings.TrimSpace(stderr.String()))
+	}
+	if stdout.Len() == 0 {
+		return nil, errors.New("ffmpeg produced no audio")
+	}
+
+	return stdout.Bytes(), nil
+}
@@ -4,6 +4,11 @@ import (
 	"crypto/sha512"
 	"encoding/hex"
 	"errors"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/raflytch/careerly-server/pkg/circuitbreaker"
 
 	"github.com/midtrans/midtrans-go"
 	"github.com/midtrans/midtrans-go/coreapi"
@@ -17,6 +22,9 @@ type Config struct {
 	IsSandbox     bool
 	WebhookURL    string
 	MerchantID    string
+	// Circuit breaker settings; zero values fall back to the circuitbreaker defaults
+	BreakerFailureThreshold int
+	BreakerOpenTimeout      time.Duration
 }
 
 // Client wraps Midtrans SDK clients
@@ -24,6 +32,7 @@ type Client struct {
 	config     Config
 	snapClient snap.Client
 	coreClient coreapi.Client
+	breaker    *circuitbreaker.Breaker
 }
 
 // NewClient creates a new Midtrans client with the provided configuration
@@ -46,6 +55,11 @@ func NewClient(cfg Config) *Client {
 		config:     cfg,
 		snapClient: s,
 		coreClient: c,
+		breaker: circuitbreaker.New(circuitbreaker.Config{
+			Name:             "midtrans",
+			FailureThreshold: cfg.BreakerFailureThreshold,
+			OpenTimeout:      cfg.BreakerOpenTimeout,
+		}),
 	}
 }
 
@@ -65,12 +79,38 @@ type CustomerDetail struct {
 	Phone     string
 }
 
-// CreateTransactionRequest represents request to create a Snap transaction
+// CreateTransactionRequest represents request to create a Snap transaction.
+// SaveCard asks Snap to offer saving the card for later charges; UserID is
+// the merchant's id for the customer the card is saved under.
 type CreateTransactionRequest struct {
 	OrderID         string
 	GrossAmount     int64
 	ItemDetails     []ItemDetail
 	CustomerDetails CustomerDetail
+	SaveCard        bool
+	UserID          string
+}
+
+// ChargeCardRequest represents a Core API card charge with a saved token
+type ChargeCardRequest struct {
+	OrderID         string
+	GrossAmount     int64
+	ItemDetails     []ItemDetail
+	CustomerDetails CustomerDetail
+	SavedTokenID    string
+}
+
+// ChargeResponse represents the response from a Core API charge. RedirectURL
+// is set when the bank requires 3D Secure before the charge completes.
+type ChargeResponse struct {
+	TransactionID     string
+	OrderID           string
+	TransactionStatus string
+	FraudStatus       string
+	PaymentType       string
+	StatusCode        string
+	StatusMessage     string
+	RedirectURL       string
 }
 
 // CreateTransactionResponse represents the response from Snap transaction creation
@@ -91,6 +131,22 @@ type TransactionStatusResponse struct {
 	SettlementTime    string
 	StatusCode        string
 	StatusMessage     string
+
+	// Card details, set for credit card payments. SavedTokenID is only
+	// issued when the user chose to save the card on the payment page.
+	MaskedCard            string
+	CardType              string
+	Bank                  string
+	SavedTokenID          string
+	SavedTokenIDExpiredAt string
+}
+
+// statusResponse adds the saved card token, which the status API returns
+// for credit card payments but the SDK does not decode.
+type statusResponse struct {
+	coreapi.TransactionStatusResponse
+	SavedTokenID          string `json:"saved_token_id"`
+	SavedTokenIDExpiredAt string `json:"saved_token_id_expired_at"`
 }
 
 // Errors that can be returned by the client
@@ -100,6 +156,9 @@ var (
 	ErrTransactionFailed  = errors.New("failed to create transaction")
 	ErrStatusCheckFailed  = errors.New("failed to check transaction status")
 	ErrInvalidSignature   = errors.New("invalid webhook signature")
+	ErrUnavailable        = errors.New("payment gateway is temporarily unavailable")
+	ErrOrderNotFound      = errors.New("order not found at payment gateway")
+	ErrEmptyTokenID       = errors.New("saved token id is required")
 )
 
 // CreateSnapTransaction creates a new Snap payment transaction
@@ -108,16 +167,7 @@ func (c *Client) CreateSnapTransaction(req CreateTransactionRequest) (*CreateTra
 		return nil, ErrEmptyOrderID
 	}
 
-	// Build Midtrans item details
-	itemDetails := make([]midtrans.ItemDetails, len(req.ItemDetails))
-	for i, item := range req.ItemDetails {
-		itemDetails[i] = midtrans.ItemDetails{
-			ID:    item.ID,
-			Name:  item.Name,
-			Price: item.Price,
-			Qty:   item.Quantity,
-		}
-	}
+	itemDetails := buildItemDetails(req.ItemDetails)
 
 	// Build Snap request
 	snapReq := &snap.Request{
@@ -125,17 +175,20 @@ func (c *Client) CreateSnapTransaction(req CreateTransactionRequest) (*CreateTra
 			OrderID:  req.OrderID,
 			GrossAmt: req.GrossAmount,
 		},
-		CustomerDetail: &midtrans.CustomerDetails{
-			FName: req.CustomerDetails.FirstName,
-			LName: req.CustomerDetails.LastName,
-			Email: req.CustomerDetails.Email,
-			Phone: req.CustomerDetails.Phone,
-		},
-		Items: &itemDetails,
+		CustomerDetail: buildCustomerDetails(req.CustomerDetails),
+		Items:          &itemDetails,
+	}
+	if req.SaveCard {
+		snapReq.CreditCard = &snap.CreditCardDetails{SaveCard: true, Secure: true}
+		snapReq.UserId = req.UserID
 	}
 
-	// Create Snap token
+	// Create Snap token, failing fast while the gateway is known to be down
+	if err := c.breaker.Allow(); err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrUnavailable, err)
+	}
 	snapResp, err := c.snapClient.CreateTransaction(snapReq)
+	c.recordOutcome(err)
 	if err != nil {
 		return nil, err
 	}
@@ -150,13 +203,34 @@ func (c *Client) CreateSnapTransaction(req CreateTransactionRequest) (*CreateTra
 	}, nil
 }
 
-// CheckTransaction checks the status of a transaction by order ID
-func (c *Client) CheckTransaction(orderID string) (*TransactionStatusResponse, error) {
-	if orderID == "" {
+// ChargeCard charges a card saved by an earlier payment through the Core API
+func (c *Client) ChargeCard(req ChargeCardRequest) (*ChargeResponse, error) {
+	if req.OrderID == "" {
 		return nil, ErrEmptyOrderID
 	}
+	if req.SavedTokenID == "" {
+		return nil, ErrEmptyTokenID
+	}
+
+	itemDetails := buildItemDetails(req.ItemDetails)
+	chargeReq := &coreapi.ChargeReq{
+		PaymentType: coreapi.PaymentTypeCreditCard,
+		TransactionDetails: midtrans.TransactionDetails{
+			OrderID:  req.OrderID,
+			GrossAmt: req.GrossAmount,
+		},
+		CustomerDetails: buildCustomerDetails(req.CustomerDetails),
+		Items:           &itemDetails,
+		CreditCard: &coreapi.CreditCardDetails{
+			TokenID: req.SavedTokenID,
+		},
+	}
 
-	resp, err := c.coreClient.CheckTransaction(orderID)
+	if err := c.breaker.Allow(); err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrUnavailable, err)
+	}
+	resp, err := c.coreClient.ChargeTransaction(chargeReq)
+	c.recordOutcome(err)
 	if err != nil {
 		return nil, err
 	}
@@ -165,28 +239,129 @@ func (c *Client) CheckTransaction(orderID string) (*TransactionStatusResponse, e
 		return nil, ErrNilResponse
 	}
 
-	return &TransactionStatusResponse{
+	return &ChargeResponse{
 		TransactionID:     resp.TransactionID,
 		OrderID:           resp.OrderID,
 		TransactionStatus: resp.TransactionStatus,
 		FraudStatus:       resp.FraudStatus,
 		PaymentType:       resp.PaymentType,
-		GrossAmount:       resp.GrossAmount,
-		TransactionTime:   resp.TransactionTime,
-		SettlementTime:    resp.SettlementTime,
 		StatusCode:        resp.StatusCode,
 		StatusMessage:     resp.StatusMessage,
+		RedirectURL:       resp.RedirectURL,
+	}, nil
+}
+
+func buildItemDetails(items []ItemDetail) []midtrans.ItemDetails {
+	itemDetails := make([]midtrans.ItemDetails, len(items))
+	for i, item := range items {
+		itemDetails[i] = midtrans.ItemDetails{
+			ID:    item.ID,
+			Name:  item.Name,
+			Price: item.Price,
+			Qty:   item.Quantity,
+		}
+	}
+	return itemDetails
+}
+
+func buildCustomerDetails(customer CustomerDetail) *midtrans.CustomerDetails {
+	return &midtrans.CustomerDetails{
+		FName: customer.FirstName,
+		LName: customer.LastName,
+		Email: customer.Email,
+		Phone: customer.Phone,
+	}
+}
+
+// CheckTransaction checks the status of a transaction by order ID
+func (c *Client) CheckTransaction(orderID string) (*TransactionStatusResponse, error) {
+	if orderID == "" {
+		return nil, ErrEmptyOrderID
+	}
+
+	if err := c.breaker.Allow(); err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrUnavailable, err)
+	}
+	resp := &statusResponse{}
+	err := c.coreClient.HttpClient.Call(
+		http.MethodGet,
+		fmt.Sprintf("%s/v2/%s/status", c.coreClient.Env.BaseUrl(), orderID),
+		&c.coreClient.ServerKey,
+		nil,
+		nil,
+		resp,
+	)
+	c.recordOutcome(err)
+	if err != nil {
+		if err.StatusCode == http.StatusNotFound {
+			return nil, fmt.Errorf("%w: %v", ErrOrderNotFound, err)
+		}
+		return nil, err
+	}
+
+	return &TransactionStatusResponse{
+		TransactionID:         resp.TransactionID,
+		OrderID:               resp.OrderID,
+		TransactionStatus:     resp.TransactionStatus,
+		FraudStatus:           resp.FraudStatus,
+		PaymentType:           resp.PaymentType,
+		GrossAmount:           resp.GrossAmount,
+		TransactionTime:       resp.TransactionTime,
+		SettlementTime:        resp.SettlementTime,
+		StatusCode:            resp.StatusCode,
+		StatusMessage:         resp.StatusMessage,
+		MaskedCard:            resp.MaskedCard,
+		CardType:              resp.CardType,
+		Bank:                  resp.Bank,
+		SavedTokenID:          resp.SavedTokenID,
+		SavedTokenIDExpiredAt: resp.SavedTokenIDExpiredAt,
 	}, nil
 }
 
+// recordOutcome feeds the circuit breaker. Only network errors, rate limits
+// and 5xx responses count as failures; 4xx means Midtrans is up but
+// rejected the request.
+func (c *Client) recordOutcome(err *midtrans.Error) {
+	if err != nil && (err.StatusCode == 0 || err.StatusCode == http.StatusTooManyRequests || err.StatusCode >= http.StatusInternalServerError) {
+		c.breaker.Failure()
+		return
+	}
+	c.breaker.Success()
+}
+
+// Breaker returns the circuit breaker guarding calls to Midtrans
+func (c *Client) Breaker() *circuitbreaker.Breaker {
+	return c.breaker
+}
+
 // VerifySignatureKey verifies the webhook signature from Midtrans
 // Signature = SHA512(order_id+status_code+gross_amount+server_key)
 func (c *Client) VerifySignatureKey(orderID, statusCode, grossAmount, signatureKey string) bool {
+	return c.Sign(orderID, statusCode, grossAmount) == signatureKey
+}
+
+// Sign returns the signature Midtrans sends with a notification for the order
+func (c *Client) Sign(orderID, statusCode, grossAmount string) string {
 	rawSignature := orderID + statusCode + grossAmount + c.config.ServerKey
 	hash := sha512.New()
 	hash.Write([]byte(rawSignature))
-	calculatedSignature := hex.EncodeToString(hash.Sum(nil))
-	return calculatedSignature == signatureKey
+	return hex.EncodeToString(hash.Sum(nil))
+}
+
+// timeLayout is the format Midtrans uses for transaction_time, settlement_time
+// and expiry_time. Values carry no offset and are always in WIB (UTC+7).
+const timeLayout = "2006-01-02 15:04:05"
+
+var wib = time.FixedZone("WIB", 7*60*60)
+
+// ParseTime parses a Midtrans timestamp such as "2024-01-31 15:04:05"
+func ParseTime(value string) (time.Time, error) {
+	return time.ParseInLocation(timeLayout, value, wib)
+}
+
+// FormatTime formats t the way Midtrans timestamps are sent, in WIB
+func FormatTime(t time.Time) string {
+	return t.In(wib).Format(timeLayout)
 }
 
 // GetClientKey returns the client key for frontend use
@@ -0,0 +1,143 @@
+package midtrans
+
+import (
+	"crypto/sha512"
+	"encoding/hex"
+	"fmt"
+	"sync"
+)
+
+// Mock is an in-memory stand-in for Client, for exercising payment flows
+// without calling Midtrans. Snap transactions always succeed and status
+// checks return whatever was last set for the order with SetStatus.
+// Signatures are computed the same way Midtrans does, using ServerKey.
+type Mock struct {
+	ServerKey string
+
+	mu       sync.Mutex
+	created  []CreateTransactionRequest
+	statuses map[string]TransactionStatusResponse
+	errors   map[string]error
+}
+
+func NewMock(serverKey string) *Mock {
+	return &Mock{
+		ServerKey: serverKey,
+		statuses:  make(map[string]TransactionStatusResponse),
+		errors:    make(map[string]error),
+	}
+}
+
+func (m *Mock) CreateSnapTransaction(req CreateTransactionRequest) (*CreateTransactionResponse, error) {
+	if req.OrderID == "" {
+		return nil, ErrEmptyOrderID
+	}
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if err := m.errors[req.OrderID]; err != nil {
+		return nil, err
+	}
+	m.created = append(m.created, req)
+
+	return &CreateTransactionResponse{
+		Token:       "mock-token-" + req.OrderID,
+		RedirectURL: "https://app.sandbox.midtrans.com/snap/v2/vtweb/mock-token-" + req.OrderID,
+	}, nil
+}
+
+// ChargeCard settles the charge at once and records it, so a following
+// CheckTransaction for the order reports it as paid.
+func (m *Mock) ChargeCard(req ChargeCardRequest) (*ChargeResponse, error) {
+	if req.OrderID == "" {
+		return nil, ErrEmptyOrderID
+	}
+	if req.SavedTokenID == "" {
+		return nil, ErrEmptyTokenID
+	}
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if err := m.errors[req.OrderID]; err != nil {
+		return nil, err
+	}
+
+	status := TransactionStatusResponse{
+		TransactionID:     "mock-charge-" + req.OrderID,
+		OrderID:           req.OrderID,
+		TransactionStatus: "capture",
+		FraudStatus:       "accept",
+		PaymentType:       "credit_card",
+		GrossAmount:       fmt.Sprintf("%d.00", req.GrossAmount),
+		StatusCode:        "200",
+		StatusMessage:     "Success, Credit Card transaction is successful",
+	}
+	m.statuses[req.OrderID] = status
+
+	return &ChargeResponse{
+		TransactionID:     status.TransactionID,
+		OrderID:           status.OrderID,
+		TransactionStatus: status.TransactionStatus,
+		FraudStatus:       status.FraudStatus,
+		PaymentType:       status.PaymentType,
+		StatusCode:        status.StatusCode,
+		StatusMessage:     status.StatusMessage,
+	}, nil
+}
+
+func (m *Mock) CheckTransaction(orderID string) (*TransactionStatusResponse, error) {
+	if orderID == "" {
+		return nil, ErrEmptyOrderID
+	}
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if err := m.errors[orderID]; err != nil {
+		return nil, err
+	}
+	status, ok := m.statuses[orderID]
+	if !ok {
+		return nil, ErrOrderNotFound
+	}
+	return &status, nil
+}
+
+func (m *Mock) VerifySignatureKey(orderID, statusCode, grossAmount, signatureKey string) bool {
+	return m.Sign(orderID, statusCode, grossAmount) == signatureKey
+}
+
+// Sign returns the signature Midtrans would send with a notification for
+// the given order, for building webhook payloads that pass verification.
+func (m *Mock) Sign(orderID, statusCode, grossAmount string) string {
+	hash := sha512.Sum512([]byte(orderID + statusCode + grossAmount + m.ServerKey))
+	return hex.EncodeToString(hash[:])
+}
+
+// SetStatus sets the response CheckTransaction returns for the order.
+func (m *Mock) SetStatus(status TransactionStatusResponse) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.statuses[status.OrderID] = status
+}
+
+// FailOrder makes every call for the order return err, e.g. ErrUnavailable.
+// Passing a nil error clears it.
+func (m *Mock) FailOrder(orderID string, err error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if err == nil {
+		delete(m.errors, orderID)
+		return
+	}
+	m.errors[orderID] = err
+}
+
+// Created returns the Snap transactions created so far, oldest first.
+func (m *Mock) Created() []CreateTransactionRequest {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return append([]CreateTransactionRequest(nil), m.created...)
+}
@@ -0,0 +1,294 @@
+package openapi
+
+import (
+	"net/http"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/raflytch/careerly-server/pkg/jsonschema"
+)
+
+const Version = "3.0.3"
+
+const bearerAuth = "bearerAuth"
+
+type Document struct {
+	OpenAPI    string              `json:"openapi"`
+	Info       Info                `json:"info"`
+	Tags       []Tag               `json:"tags,omitempty"`
+	Paths      map[string]PathItem `json:"paths"`
+	Components Components          `json:"components"`
+}
+
+type Info struct {
+	Title       string `json:"title"`
+	Description string `json:"description,omitempty"`
+	Version     string `json:"version"`
+}
+
+type Tag struct {
+	Name string `json:"name"`
+}
+
+type PathItem map[string]*Operation
+
+type Operation struct {
+	Tags        []string              `json:"tags,omitempty"`
+	Summary     string                `json:"summary,omitempty"`
+	OperationID string                `json:"operationId,omitempty"`
+	Parameters  []Parameter           `json:"parameters,omitempty"`
+	RequestBody *RequestBody          `json:"requestBody,omitempty"`
+	Responses   map[string]Response   `json:"responses"`
+	Security    []map[string][]string `json:"security,omitempty"`
+}
+
+type Parameter struct {
+	Name        string             `json:"name"`
+	In          string             `json:"in"`
+	Description string             `json:"description,omitempty"`
+	Required    bool               `json:"required,omitempty"`
+	Schema      *jsonschema.Schema `json:"schema"`
+}
+
+type RequestBody struct {
+	Required bool                 `json:"required"`
+	Content  map[string]MediaType `json:"content"`
+}
+
+type MediaType struct {
+	Schema *jsonschema.Schema `json:"schema"`
+}
+
+type Response struct {
+	Description string               `json:"description"`
+	Content     map[string]MediaType `json:"content,omitempty"`
+}
+
+type Components struct {
+	SecuritySchemes map[string]SecurityScheme `json:"securitySchemes"`
+}
+
+type SecurityScheme struct {
+	Type         string `json:"type"`
+	Scheme       string `json:"scheme"`
+	BearerFormat string `json:"bearerFormat,omitempty"`
+}
+
+// Param describes a query parameter. Type is a JSON schema type and
+// defaults to string.
+type Param struct {
+	Name        string
+	Type        string
+	Description string
+}
+
+// Route describes one endpoint. Path uses Fiber syntax (":id"); path
+// parameters are derived from it. Request and Response are zero values of
+// the payload types and are turned into schemas via their json and
+// validate tags. Form lists multipart fields, with "binary" marking files.
+type Route struct {
+	Method      string
+	Path        string
+	Tag         string
+	Summary     string
+	Auth        bool
+	Status      int
+	Query       []Param
+	Request     interface{}
+	Form        map[string]string
+	Response    interface{}
+	ContentType string
+}
+
+func New(title, description, version string) *Document {
+	return &Document{
+		OpenAPI: Version,
+		Info: Info{
+			Title:       title,
+			Description: description,
+			Version:     version,
+		},
+		Paths: make(map[string]PathItem),
+		Components: Components{
+			SecuritySchemes: map[string]SecurityScheme{
+				bearerAuth: {Type: "http", Scheme: "bearer", BearerFormat: "JWT"},
+			},
+		},
+	}
+}
+
+func (d *Document) Add(routes ...Route) {
+	for _, route := range routes {
+		d.add(route)
+	}
+}
+
+func (d *Document) add(route Route) {
+	path, pathParams := convertPath(route.Path)
+	method := strings.ToLower(route.Method)
+
+	op := &Operation{
+		Summary:     route.Summary,
+		OperationID: operationID(method, path),
+		Responses:   make(map[string]Response),
+	}
+	if route.Tag != "" {
+		op.Tags = []string{route.Tag}
+		d.addTag(route.Tag)
+	}
+	if route.Auth {
+		op.Security = []map[string][]string{{bearerAuth: {}}}
+	}
+
+	for _, name := range pathParams {
+		op.Parameters = append(op.Parameters, Parameter{
+			Name:     name,
+			In:       "path",
+			Required: true,
+			Schema:   &jsonschema.Schema{Type: "string"},
+		})
+	}
+	for _, param := range route.Query {
+		typ := param.Type
+		if typ == "" {
+			typ = "string"
+		}
+		op.Parameters = append(op.Parameters, Parameter{
+			Name:        param.Name,
+			In:          "query",
+			Description: param.Description,
+			Schema:      &jsonschema.Schema{Type: typ},
+		})
+	}
+
+	switch {
+	case route.Form != nil:
+		op.RequestBody = &RequestBody{
+			Required: true,
+			Content:  map[string]MediaType{"multipart/form-data": {Schema: formSchema(route.Form)}},
+		}
+	case route.Request != nil:
+		op.RequestBody = &RequestBody{
+			Required: true,
+			Content:  map[string]MediaType{"application/json": {Schema: schemaOf(route.Request)}},
+		}
+	}
+
+	status := route.Status
+	if status == 0 {
+		status = http.StatusOK
+	}
+
+	success := Response{Description: http.StatusText(status)}
+	switch {
+	case route.ContentType != "":
+		success.Content = map[string]MediaType{route.ContentType: {Schema: &jsonschema.Schema{Type: "string", Format: "binary"}}}
+	case status < http.StatusMultipleChoices:
+		success.Content = map[string]MediaType{"application/json": {Schema: envelope(route.Response)}}
+	}
+	op.Responses[strconv.Itoa(status)] = success
+	op.Responses["default"] = Response{
+		Description: "Error",
+		Content:     map[string]MediaType{"application/json": {Schema: errorEnvelope()}},
+	}
+
+	item, ok := d.Paths[path]
+	if !ok {
+		item = make(PathItem)
+		d.Paths[path] = item
+	}
+	item[method] = op
+}
+
+func (d *Document) addTag(name string) {
+	for _, tag := range d.Tags {
+		if tag.Name == name {
+			return
+		}
+	}
+	d.Tags = append(d.Tags, Tag{Name: name})
+	sort.Slice(d.Tags, func(i, j int) bool { return d.Tags[i].Name < d.Tags[j].Name })
+}
+
+func convertPath(path string) (string, []string) {
+	segments := strings.Split(path, "/")
+	params := make([]string, 0)
+	for i, segment := range segments {
+		if strings.HasPrefix(segment, ":") {
+			name := strings.TrimSuffix(segment[1:], "?")
+			params = append(params, name)
+			segments[i] = "{" + name + "}"
+		}
+	}
+	converted := strings.Join(segments, "/")
+	if len(converted) > 1 {
+		converted = strings.TrimSuffix(converted, "/")
+	}
+	return converted, params
+}
+
+func operationID(method, path string) string {
+	var b strings.Builder
+	b.WriteString(method)
+	for _, segment := range strings.FieldsFunc(path, func(r rune) bool { return r == '/' || r == '-' || r == '{' || r == '}' }) {
+		b.WriteString(strings.ToUpper(segment[:1]) + segment[1:])
+	}
+	return b.String()
+}
+
+func schemaOf(v interface{}) *jsonschema.Schema {
+	s := jsonschema.Of(v)
+	t := reflect.TypeOf(v)
+	for t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+	if t.Kind() == reflect.Struct {
+		s.Title = t.Name()
+	}
+	return s
+}
+
+func formSchema(fields map[string]string) *jsonschema.Schema {
+	s := &jsonschema.Schema{Type: "object", Properties: make(map[string]*jsonschema.Schema, len(fields))}
+	for name, kind := range fields {
+		if kind == "binary" {
+			s.Properties[name] = &jsonschema.Schema{Type: "string", Format: "binary"}
+			s.Required = append(s.Required, name)
+			continue
+		}
+		s.Properties[name] = &jsonschema.Schema{Type: kind}
+	}
+	sort.Strings(s.Required)
+	return s
+}
+
+// envelope mirrors response.Response for successful calls.
+func envelope(data interface{}) *jsonschema.Schema {
+	s := &jsonschema.Schema{
+		Type: "object",
+		Properties: map[string]*jsonschema.Schema{
+			"success": {Type: "boolean"},
+			"message": {Type: "string"},
+		},
+		Required: []string{"success"},
+	}
+	if data != nil {
+		s.Properties["data"] = schemaOf(data)
+	}
+	return s
+}
+
+func errorEnvelope() *jsonschema.Schema {
+	return &jsonschema.Schema{
+		Type: "object",
+		Properties: map[string]*jsonschema.Schema{
+			"success": {Type: "boolean"},
+			"code":    {Type: "string"},
+			"error":   {Type: "string"},
+			"errors":  {Type: "object"},
+		},
+		Required: []string{"success", "code", "error"},
+	}
+}
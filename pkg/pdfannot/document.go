@@ -0,0 +1,619 @@
+package pdfannot
+
+import (
+	"bytes"
+	"compress/zlib"
+	"errors"
+	"fmt"
+	"io"
+	"strconv"
+)
+
+const maxPageTreeDepth = 64
+
+var (
+	ErrEncrypted   = errors.New("pdfannot: encrypted pdfs are not supported")
+	ErrUnsupported = errors.New("pdfannot: unsupported pdf feature")
+)
+
+type xrefEntry struct {
+	// compressed entries live at index inside object stream offset
+	compressed bool
+	offset     int
+	index      int
+	gen        int
+}
+
+type document struct {
+	data     []byte
+	xref     map[int]xrefEntry
+	trailer  dict
+	lastXref int
+	objStms  map[int]*objStm
+	cache    map[int]object
+}
+
+type objStm struct {
+	data    []byte
+	offsets []int
+}
+
+type page struct {
+	ref    ref
+	dict   dict
+	box    [4]float64
+	rotate int
+}
+
+func openDocument(data []byte) (*document, error) {
+	d := &document{
+		data:    data,
+		xref:    make(map[int]xrefEntry),
+		objStms: make(map[int]*objStm),
+		cache:   make(map[int]object),
+	}
+
+	idx := bytes.LastIndex(data, []byte("startxref"))
+	if idx < 0 {
+		return nil, errSyntax
+	}
+	p := &parser{data: data, pos: idx + len("startxref")}
+	offset, err := p.readInt()
+	if err != nil || offset <= 0 || offset >= len(data) {
+		return nil, errSyntax
+	}
+	d.lastXref = offset
+
+	if err := d.readXref(offset, map[int]bool{}); err != nil {
+		return nil, err
+	}
+	if d.trailer == nil {
+		return nil, errSyntax
+	}
+	if _, ok := d.trailer[name("Encrypt")]; ok {
+		return nil, ErrEncrypted
+	}
+	return d, nil
+}
+
+// readXref loads one cross-reference section and the older ones it points
+// to. Newer sections are read first, so an entry already present wins.
+func (d *document) readXref(offset int, seen map[int]bool) error {
+	if seen[offset] || offset < 0 || offset >= len(d.data) {
+		return errSyntax
+	}
+	seen[offset] = true
+
+	p := &parser{data: d.data, pos: offset}
+	p.skipSpace()
+
+	var trailer dict
+	if p.hasPrefix("xref") {
+		p.pos += len("xref")
+		if err := d.readXrefTable(p); err != nil {
+			return err
+		}
+		obj, err := p.parseObject()
+		if err != nil {
+			return err
+		}
+		t, ok := obj.(dict)
+		if !ok {
+			return errSyntax
+		}
+		trailer = t
+		// Hybrid files keep some entries in a separate xref stream
+		if stm, ok := trailer[name("XRefStm")].(number); ok {
+			if n, err := strconv.Atoi(string(stm)); err == nil {
+				if err := d.readXrefStream(n); err != nil {
+					return err
+				}
+			}
+		}
+	} else {
+		t, err := d.readXrefStreamAt(offset)
+		if err != nil {
+			return err
+		}
+		trailer = t
+	}
+
+	if d.trailer == nil {
+		d.trailer = trailer
+	}
+
+	if prev, ok := trailer[name("Prev")].(number); ok {
+		n, err := strconv.Atoi(string(prev))
+		if err != nil {
+			return errSyntax
+		}
+		return d.readXref(n, seen)
+	}
+	return nil
+}
+
+func (d *document) readXrefTable(p *parser) error {
+	for {
+		p.skipSpace()
+		if p.hasPrefix("trailer") {
+			p.pos += len("trailer")
+			return nil
+		}
+		start, err := p.readInt()
+		if err != nil {
+			return err
+		}
+		count, err := p.readInt()
+		if err != nil {
+			return err
+		}
+		for i := 0; i < count; i++ {
+			offset, err := p.readInt()
+			if err != nil {
+				return err
+			}
+			gen, err := p.readInt()
+			if err != nil {
+				return err
+			}
+			p.skipSpace()
+			kind := p.token()
+			if kind != "n" && kind != "f" {
+				return errSyntax
+			}
+			if _, ok := d.xref[start+i]; !ok && kind == "n" {
+				d.xref[start+i] = xrefEntry{offset: offset, gen: gen}
+			}
+		}
+	}
+}
+
+func (d *document) readXrefStream(offset int) error {
+	_, err := d.readXrefStreamAt(offset)
+	return err
+}
+
+func (d *document) readXrefStreamAt(offset int) (dict, error) {
+	p := &parser{data: d.data, pos: offset}
+	_, obj, err := p.parseIndirect(d.directLength)
+	if err != nil {
+		return nil, err
+	}
+	s, ok := obj.(stream)
+	if !ok || s.dict[name("Type")] != name("XRef") {
+		return nil, errSyntax
+	}
+
+	data, err := decodeStream(s)
+	if err != nil {
+		return nil, err
+	}
+
+	widths, ok := s.dict[name("W")].(array)
+	if !ok || len(widths) != 3 {
+		return nil, errSyntax
+	}
+	var w [3]int
+	for i, value := range widths {
+		n, ok := intValue(value)
+		if !ok || n < 0 || n > 8 {
+			return nil, errSyntax
+		}
+		w[i] = n
+	}
+	rowLen := w[0] + w[1] + w[2]
+	if rowLen == 0 {
+		return nil, errSyntax
+	}
+
+	size, _ := intValue(s.dict[name("Size")])
+	index := []int{0, size}
+	if idx, ok := s.dict[name("Index")].(array); ok {
+		index = index[:0]
+		for _, value := range idx {
+			n, ok := intValue(value)
+			if !ok {
+				return nil, errSyntax
+			}
+			index = append(index, n)
+		}
+	}
+
+	pos := 0
+	for i := 0; i+1 < len(index); i += 2 {
+		for num := index[i]; num < index[i]+index[i+1]; num++ {
+			if pos+rowLen > len(data) {
+				return nil, errSyntax
+			}
+			row := data[pos : pos+rowLen]
+			pos += rowLen
+
+			kind := 1
+			if w[0] > 0 {
+				kind = readField(row[:w[0]])
+			}
+			field2 := readField(row[w[0] : w[0]+w[1]])
+			field3 := readField(row[w[0]+w[1]:])
+
+			if _, ok := d.xref[num]; ok {
+				continue
+			}
+			switch kind {
+			case 1:
+				d.xref[num] = xrefEntry{offset: field2, gen: field3}
+			case 2:
+				d.xref[num] = xrefEntry{compressed: true, offset: field2, index: field3}
+			}
+		}
+	}
+
+	return s.dict, nil
+}
+
+func readField(b []byte) int {
+	n := 0
+	for _, c := range b {
+		n = n<<8 | int(c)
+	}
+	return n
+}
+
+// directLength reads /Length while the xref is still being loaded, when
+// indirect lengths cannot be resolved yet.
+func (d *document) directLength(obj object) (int, bool) {
+	return intValue(obj)
+}
+
+func (d *document) length(obj object) (int, bool) {
+	if r, ok := obj.(ref); ok {
+		resolved, err := d.resolve(r)
+		if err != nil {
+			return 0, false
+		}
+		obj = resolved
+	}
+	return intValue(obj)
+}
+
+func (d *document) resolve(obj object) (object, error) {
+	r, ok := obj.(ref)
+	if !ok {
+		return obj, nil
+	}
+	if cached, ok := d.cache[r.num]; ok {
+		return cached, nil
+	}
+
+	entry, ok := d.xref[r.num]
+	if !ok {
+		// A reference to a missing object is the null object
+		return nil, nil
+	}
+
+	var value object
+	if entry.compressed {
+		stm, err := d.objStm(entry.offset)
+		if err != nil {
+			return nil, err
+		}
+		if entry.index >= len(stm.offsets) {
+			return nil, errSyntax
+		}
+		p := &parser{data: stm.data, pos: stm.offsets[entry.index]}
+		value, err = p.parseObject()
+		if err != nil {
+			return nil, err
+		}
+	} else {
+		if entry.offset <= 0 || entry.offset >= len(d.data) {
+			return nil, errSyntax
+		}
+		p := &parser{data: d.data, pos: entry.offset}
+		num, obj, err := p.parseIndirect(d.length)
+		if err != nil {
+			return nil, err
+		}
+		if num != r.num {
+			return nil, fmt.Errorf("%w: object %d not found at its offset", errSyntax, r.num)
+		}
+		value = obj
+	}
+
+	d.cache[r.num] = value
+	return value, nil
+}
+
+func (d *document) objStm(num int) (*objStm, error) {
+	if stm, ok := d.objStms[num]; ok {
+		return stm, nil
+	}
+
+	obj, err := d.resolve(ref{num: num})
+	if err != nil {
+		return nil, err
+	}
+	s, ok := obj.(stream)
+	if !ok {
+		return nil, errSyntax
+	}
+	data, err := decodeStream(s)
+	if err != nil {
+		return nil, err
+	}
+
+	n, ok := intValue(s.dict[name("N")])
+	if !ok {
+		return nil, errSyntax
+	}
+	first, ok := intValue(s.dict[name("First")])
+	if !ok || first > len(data) {
+		return nil, errSyntax
+	}
+
+	header := &parser{data: data[:first]}
+	offsets := make([]int, n)
+	for i := 0; i < n; i++ {
+		if _, err := header.readInt(); err != nil {
+			return nil, err
+		}
+		offset, err := header.readInt()
+		if err != nil {
+			return nil, err
+		}
+		if first+offset > len(data) {
+			return nil, errSyntax
+		}
+		offsets[i] = first + offset
+	}
+
+	stm := &objStm{data: data, offsets: offsets}
+	d.objStms[num] = stm
+	return stm, nil
+}
+
+// pages walks the page tree in order. MediaBox, CropBox and Rotate are
+// inherited from ancestors when a page does not set them.
+func (d *document) pages() ([]page, error) {
+	root, err := d.resolve(d.trailer[name("Root")])
+	if err != nil {
+		return nil, err
+	}
+	catalog, ok := root.(dict)
+	if !ok {
+		return nil, errSyntax
+	}
+	pagesRef, ok := catalog[name("Pages")].(ref)
+	if !ok {
+		return nil, errSyntax
+	}
+
+	var pages []page
+	visited := map[int]bool{}
+	var walk func(r ref, inherited dict, depth int) error
+	walk = func(r ref, inherited dict, depth int) error {
+		if depth > maxPageTreeDepth || visited[r.num] {
+			return errSyntax
+		}
+		visited[r.num] = true
+
+		obj, err := d.resolve(r)
+		if err != nil {
+			return err
+		}
+		node, ok := obj.(dict)
+		if !ok {
+			return errSyntax
+		}
+
+		attrs := dict{}
+		for k, v := range inherited {
+			attrs[k] = v
+		}
+		for _, key := range []name{"MediaBox", "CropBox", "Rotate"} {
+			if v, ok := node[key]; ok {
+				attrs[key] = v
+			}
+		}
+
+		if node[name("Type")] == name("Page") {
+			pg, err := d.newPage(r, node, attrs)
+			if err != nil {
+				return err
+			}
+			pages = append(pages, pg)
+			return nil
+		}
+
+		kidsObj, err := d.resolve(node[name("Kids")])
+		if err != nil {
+			return err
+		}
+		kids, _ := kidsObj.(array)
+		for _, kid := range kids {
+			kidRef, ok := kid.(ref)
+			if !ok {
+				return errSyntax
+			}
+			if err := walk(kidRef, attrs, depth+1); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+
+	if err := walk(pagesRef, dict{}, 0); err != nil {
+		return nil, err
+	}
+	return pages, nil
+}
+
+func (d *document) newPage(r ref, node, attrs dict) (page, error) {
+	pg := page{ref: r, dict: node, box: [4]float64{0, 0, 612, 792}}
+
+	boxObj := attrs[name("CropBox")]
+	if boxObj == nil {
+		boxObj = attrs[name("MediaBox")]
+	}
+	boxObj, err := d.resolve(boxObj)
+	if err != nil {
+		return pg, err
+	}
+	if box, ok := boxObj.(array); ok && len(box) == 4 {
+		for i, v := range box {
+			resolved, err := d.resolve(v)
+			if err != nil {
+				return pg, err
+			}
+			f, ok := floatValue(resolved)
+			if !ok {
+				return pg, errSyntax
+			}
+			pg.box[i] = f
+		}
+		if pg.box[0] > pg.box[2] {
+			pg.box[0], pg.box[2] = pg.box[2], pg.box[0]
+		}
+		if pg.box[1] > pg.box[3] {
+			pg.box[1], pg.box[3] = pg.box[3], pg.box[1]
+		}
+	}
+
+	if rotate, err := d.resolve(attrs[name("Rotate")]); err == nil {
+		if n, ok := intValue(rotate); ok {
+			pg.rotate = ((n % 360) + 360) % 360
+		}
+	}
+	return pg, nil
+}
+
+func intValue(obj object) (int, bool) {
+	n, ok := obj.(number)
+	if !ok {
+		return 0, false
+	}
+	i, err := strconv.Atoi(string(n))
+	if err != nil {
+		return 0, false
+	}
+	return i, true
+}
+
+func floatValue(obj object) (float64, bool) {
+	n, ok := obj.(number)
+	if !ok {
+		return 0, false
+	}
+	f, err := strconv.ParseFloat(string(n), 64)
+	if err != nil {
+		return 0, false
+	}
+	return f, true
+}
+
+// decodeStream undoes FlateDecode, the only filter used by the xref and
+// object streams this package has to read.
+func decodeStream(s stream) ([]byte, error) {
+	var filter name
+	var parms dict
+	switch f := s.dict[name("Filter")].(type) {
+	case nil:
+		return s.data, nil
+	case name:
+		filter = f
+		parms, _ = s.dict[name("DecodeParms")].(dict)
+	case array:
+		if len(f) == 0 {
+			return s.data, nil
+		}
+		if len(f) != 1 {
+			return nil, ErrUnsupported
+		}
+		filter, _ = f[0].(name)
+		if p, ok := s.dict[name("DecodeParms")].(array); ok && len(p) == 1 {
+			parms, _ = p[0].(dict)
+		}
+	}
+	if filter != "FlateDecode" {
+		return nil, fmt.Errorf("%w: %s filter", ErrUnsupported, filter)
+	}
+
+	r, err := zlib.NewReader(bytes.NewReader(s.data))
+	if err != nil {
+		return nil, err
+	}
+	defer r.Close()
+	data, err := io.ReadAll(r)
+	if err != nil && !errors.Is(err, io.ErrUnexpectedEOF) {
+		return nil, err
+	}
+
+	predictor, _ := intValue(parms[name("Predictor")])
+	if predictor < 10 {
+		return data, nil
+	}
+	columns, ok := intValue(parms[name("Columns")])
+	if !ok || columns <= 0 {
+		columns = 1
+	}
+	return unpredictPNG(data, columns)
+}
+
+// unpredictPNG reverses the PNG row filters xref streams are usually
+// compressed with. Rows are one byte per column.
+func unpredictPNG(data []byte, columns int) ([]byte, error) {
+	rowLen := columns + 1
+	if len(data)%rowLen != 0 {
+		return nil, errSyntax
+	}
+
+	out := make([]byte, 0, len(data)/rowLen*columns)
+	prev := make([]byte, columns)
+	for pos := 0; pos < len(data); pos += rowLen {
+		filter := data[pos]
+		row := append([]byte(nil), data[pos+1:pos+rowLen]...)
+		for i := range row {
+			var left, upLeft byte
+			if i > 0 {
+				left = row[i-1]
+				upLeft = prev[i-1]
+			}
+			up := prev[i]
+			switch filter {
+			case 0:
+			case 1:
+				row[i] += left
+			case 2:
+				row[i] += up
+			case 3:
+				row[i] += byte((int(left) + int(up)) / 2)
+			case 4:
+				row[i] += paeth(left, up, upLeft)
+			default:
+				return nil, errSyntax
+			}
+		}
+		out = append(out, row...)
+		prev = row
+	}
+	return out, nil
+}
+
+func paeth(a, b, c byte) byte {
+	p := int(a) + int(b) - int(c)
+	pa, pb, pc := abs(p-int(a)), abs(p-int(b)), abs(p-int(c))
+	switch {
+	case pa <= pb && pa <= pc:
+		return a
+	case pb <= pc:
+		return b
+	default:
+		return c
+	}
+}
+
+func abs(n int) int {
+	if n < 0 {
+		return -n
+	}
+	return n
+}
@@ -0,0 +1,348 @@
+package pdfannot
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"sort"
+	"strconv"
+)
+
+// The object model only needs to read the document structure and write
+// dictionaries back, so strings and numbers keep their original spelling.
+type (
+	object  interface{}
+	name    string
+	keyword string
+	number  string
+	// rawString is a literal or hex string exactly as it appears in the file.
+	rawString []byte
+	array     []object
+	dict      map[name]object
+	ref       struct{ num, gen int }
+	stream    struct {
+		dict dict
+		data []byte
+	}
+)
+
+var errSyntax = errors.New("pdfannot: malformed pdf")
+
+func isWhitespace(c byte) bool {
+	return c == 0 || c == '\t' || c == '\n' || c == '\f' || c == '\r' || c == ' '
+}
+
+func isDelimiter(c byte) bool {
+	switch c {
+	case '(', ')', '<', '>', '[', ']', '{', '}', '/', '%':
+		return true
+	}
+	return false
+}
+
+func isRegular(c byte) bool {
+	return !isWhitespace(c) && !isDelimiter(c)
+}
+
+type parser struct {
+	data []byte
+	pos  int
+}
+
+func (p *parser) skipSpace() {
+	for p.pos < len(p.data) {
+		c := p.data[p.pos]
+		if isWhitespace(c) {
+			p.pos++
+			continue
+		}
+		if c == '%' {
+			for p.pos < len(p.data) && p.data[p.pos] != '\n' && p.data[p.pos] != '\r' {
+				p.pos++
+			}
+			continue
+		}
+		return
+	}
+}
+
+func (p *parser) hasPrefix(s string) bool {
+	return bytes.HasPrefix(p.data[p.pos:], []byte(s))
+}
+
+// token reads a run of regular characters, such as a number or keyword.
+func (p *parser) token() string {
+	start := p.pos
+	for p.pos < len(p.data) && isRegular(p.data[p.pos]) {
+		p.pos++
+	}
+	return string(p.data[start:p.pos])
+}
+
+func (p *parser) readInt() (int, error) {
+	p.skipSpace()
+	n, err := strconv.Atoi(p.token())
+	if err != nil {
+		return 0, errSyntax
+	}
+	return n, nil
+}
+
+func (p *parser) parseObject() (object, error) {
+	p.skipSpace()
+	if p.pos >= len(p.data) {
+		return nil, errSyntax
+	}
+
+	switch c := p.data[p.pos]; {
+	case c == '/':
+		return p.parseName()
+	case p.hasPrefix("<<"):
+		return p.parseDict()
+	case c == '<':
+		return p.parseHexString()
+	case c == '(':
+		return p.parseLiteralString()
+	case c == '[':
+		return p.parseArray()
+	case c == '+' || c == '-' || c == '.' || (c >= '0' && c <= '9'):
+		return p.parseNumberOrRef()
+	case isRegular(c):
+		return keyword(p.token()), nil
+	default:
+		return nil, errSyntax
+	}
+}
+
+func (p *parser) parseName() (object, error) {
+	p.pos++
+	raw := p.token()
+	var decoded []byte
+	for i := 0; i < len(raw); i++ {
+		if raw[i] == '#' && i+2 < len(raw) {
+			if b, err := strconv.ParseUint(raw[i+1:i+3], 16, 8); err == nil {
+				decoded = append(decoded, byte(b))
+				i += 2
+				continue
+			}
+		}
+		decoded = append(decoded, raw[i])
+	}
+	return name(decoded), nil
+}
+
+func (p *parser) parseDict() (object, error) {
+	p.pos += 2
+	d := dict{}
+	for {
+		p.skipSpace()
+		if p.pos >= len(p.data) {
+			return nil, errSyntax
+		}
+		if p.hasPrefix(">>") {
+			p.pos += 2
+			return d, nil
+		}
+		key, err := p.parseObject()
+		if err != nil {
+			return nil, err
+		}
+		k, ok := key.(name)
+		if !ok {
+			return nil, errSyntax
+		}
+		value, err := p.parseObject()
+		if err != nil {
+			return nil, err
+		}
+		d[k] = value
+	}
+}
+
+func (p *parser) parseArray() (object, error) {
+	p.pos++
+	var a array
+	for {
+		p.skipSpace()
+		if p.pos >= len(p.data) {
+			return nil, errSyntax
+		}
+		if p.data[p.pos] == ']' {
+			p.pos++
+			return a, nil
+		}
+		value, err := p.parseObject()
+		if err != nil {
+			return nil, err
+		}
+		a = append(a, value)
+	}
+}
+
+func (p *parser) parseHexString() (object, error) {
+	end := bytes.IndexByte(p.data[p.pos:], '>')
+	if end < 0 {
+		return nil, errSyntax
+	}
+	s := rawString(p.data[p.pos : p.pos+end+1])
+	p.pos += end + 1
+	return s, nil
+}
+
+func (p *parser) parseLiteralString() (object, error) {
+	start := p.pos
+	depth := 0
+	for p.pos < len(p.data) {
+		switch p.data[p.pos] {
+		case '\\':
+			p.pos++
+		case '(':
+			depth++
+		case ')':
+			depth--
+			if depth == 0 {
+				p.pos++
+				return rawString(p.data[start:p.pos]), nil
+			}
+		}
+		p.pos++
+	}
+	return nil, errSyntax
+}
+
+// parseNumberOrRef reads a number, or an indirect reference "num gen R".
+func (p *parser) parseNumberOrRef() (object, error) {
+	first := p.token()
+	num, err := strconv.Atoi(first)
+	if err != nil {
+		if _, err := strconv.ParseFloat(first, 64); err != nil {
+			return nil, errSyntax
+		}
+		return number(first), nil
+	}
+
+	save := p.pos
+	p.skipSpace()
+	if gen, err := strconv.Atoi(p.token()); err == nil {
+		p.skipSpace()
+		if p.pos < len(p.data) && p.data[p.pos] == 'R' && (p.pos+1 == len(p.data) || !isRegular(p.data[p.pos+1])) {
+			p.pos++
+			return ref{num: num, gen: gen}, nil
+		}
+	}
+	p.pos = save
+	return number(first), nil
+}
+
+// parseIndirect reads "num gen obj ... endobj" at the current position. The
+// length lookup resolves a stream's /Length, which may itself be indirect.
+func (p *parser) parseIndirect(length func(object) (int, bool)) (int, object, error) {
+	num, err := p.readInt()
+	if err != nil {
+		return 0, nil, err
+	}
+	if _, err := p.readInt(); err != nil {
+		return 0, nil, err
+	}
+	p.skipSpace()
+	if p.token() != "obj" {
+		return 0, nil, errSyntax
+	}
+
+	value, err := p.parseObject()
+	if err != nil {
+		return 0, nil, err
+	}
+
+	d, ok := value.(dict)
+	if !ok {
+		return num, value, nil
+	}
+	p.skipSpace()
+	if !p.hasPrefix("stream") {
+		return num, value, nil
+	}
+	p.pos += len("stream")
+	if p.hasPrefix("\r\n") {
+		p.pos += 2
+	} else if p.hasPrefix("\n") || p.hasPrefix("\r") {
+		p.pos++
+	}
+
+	n, ok := length(d[name("Length")])
+	if !ok || n < 0 || p.pos+n > len(p.data) || !bytes.Contains(p.data[p.pos+n:min(p.pos+n+32, len(p.data))], []byte("endstream")) {
+		// Fall back to scanning when /Length is missing or wrong
+		end := bytes.Index(p.data[p.pos:], []byte("endstream"))
+		if end < 0 {
+			return 0, nil, errSyntax
+		}
+		n = end
+		for n > 0 && (p.data[p.pos+n-1] == '\n' || p.data[p.pos+n-1] == '\r') {
+			n--
+		}
+	}
+	s := stream{dict: d, data: p.data[p.pos : p.pos+n]}
+	p.pos += n
+	return num, s, nil
+}
+
+func writeObject(buf *bytes.Buffer, obj object) {
+	switch v := obj.(type) {
+	case nil:
+		buf.WriteString("null")
+	case name:
+		writeName(buf, v)
+	case keyword:
+		buf.WriteString(string(v))
+	case number:
+		buf.WriteString(string(v))
+	case rawString:
+		buf.Write(v)
+	case ref:
+		fmt.Fprintf(buf, "%d %d R", v.num, v.gen)
+	case array:
+		buf.WriteByte('[')
+		for i, item := range v {
+			if i > 0 {
+				buf.WriteByte(' ')
+			}
+			writeObject(buf, item)
+		}
+		buf.WriteByte(']')
+	case dict:
+		keys := make([]string, 0, len(v))
+		for k := range v {
+			keys = append(keys, string(k))
+		}
+		sort.Strings(keys)
+		buf.WriteString("<<")
+		for _, k := range keys {
+			writeName(buf, name(k))
+			buf.WriteByte(' ')
+			writeObject(buf, v[name(k)])
+		}
+		buf.WriteString(">>")
+	case stream:
+		d := dict{}
+		for k, value := range v.dict {
+			d[k] = value
+		}
+		d[name("Length")] = number(strconv.Itoa(len(v.data)))
+		writeObject(buf, d)
+		buf.WriteString("\nstream\n")
+		buf.Write(v.data)
+		buf.WriteString("\nendstream")
+	}
+}
+
+func writeName(buf *bytes.Buffer, n name) {
+	buf.WriteByte('/')
+	for i := 0; i < len(n); i++ {
+		c := n[i]
+		if c < '!' || c > '~' || c == '#' || isDelimiter(c) {
+			fmt.Fprintf(buf, "#%02X", c)
+			continue
+		}
+		buf.WriteByte(c)
+	}
+}
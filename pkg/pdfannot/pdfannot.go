@@ -0,0 +1,340 @@
+// Package pdfannot adds highlight and comment annotations to an existing PDF
+// by appending an incremental update, leaving the original bytes untouched.
+// It can also tell whether a PDF has any text at all.
+package pdfannot
+
+import (
+	"bytes"
+	"fmt"
+	"math"
+	"sort"
+	"strconv"
+	"unicode/utf16"
+)
+
+const (
+	bandMargin        = 0.04
+	minBandHeight     = 10.0
+	commentIconSize   = 20.0
+	commentIconMargin = 8.0
+	author            = "Careerly"
+)
+
+type Color struct {
+	R, G, B float64
+}
+
+// Band is a horizontal strip of the page as it is displayed, measured as
+// fractions of the page height from the top edge.
+type Band struct {
+	Top    float64
+	Bottom float64
+}
+
+// Note is a highlight over Band with Contents shown as its comment, or a
+// comment icon in the top-right corner when Band is nil. Page is 1-based.
+type Note struct {
+	Page     int
+	Band     *Band
+	Title    string
+	Contents string
+	Color    Color
+}
+
+// Annotate returns src with notes added. Notes for pages the document does
+// not have are skipped.
+func Annotate(src []byte, notes []Note) ([]byte, error) {
+	doc, err := openDocument(src)
+	if err != nil {
+		return nil, err
+	}
+	pages, err := doc.pages()
+	if err != nil {
+		return nil, err
+	}
+
+	size, ok := intValue(doc.trailer[name("Size")])
+	if !ok {
+		return nil, errSyntax
+	}
+	for num := range doc.xref {
+		size = max(size, num+1)
+	}
+
+	w := &writer{buf: bytes.NewBuffer(append([]byte(nil), src...)), next: size, offsets: map[int]int{}}
+	if !bytes.HasSuffix(src, []byte("\n")) {
+		w.buf.WriteByte('\n')
+	}
+
+	byPage := map[int][]Note{}
+	for _, note := range notes {
+		if note.Page < 1 || note.Page > len(pages) {
+			continue
+		}
+		byPage[note.Page] = append(byPage[note.Page], note)
+	}
+
+	pageNumbers := make([]int, 0, len(byPage))
+	for n := range byPage {
+		pageNumbers = append(pageNumbers, n)
+	}
+	sort.Ints(pageNumbers)
+
+	for _, n := range pageNumbers {
+		pg := pages[n-1]
+
+		var annots array
+		existing, err := doc.resolve(pg.dict[name("Annots")])
+		if err != nil {
+			return nil, err
+		}
+		if existingAnnots, ok := existing.(array); ok {
+			annots = append(annots, existingAnnots...)
+		}
+
+		for _, note := range byPage[n] {
+			annots = append(annots, w.addNote(pg, note))
+		}
+
+		updated := dict{}
+		for k, v := range pg.dict {
+			updated[k] = v
+		}
+		updated[name("Annots")] = annots
+		w.writeObject(pg.ref, updated)
+	}
+
+	trailer := dict{
+		name("Size"): number(strconv.Itoa(w.next)),
+		name("Root"): doc.trailer[name("Root")],
+		name("Prev"): number(strconv.Itoa(doc.lastXref)),
+	}
+	for _, key := range []name{"Info", "ID"} {
+		if v, ok := doc.trailer[key]; ok {
+			trailer[key] = v
+		}
+	}
+	w.finish(trailer)
+
+	return w.buf.Bytes(), nil
+}
+
+type writer struct {
+	buf     *bytes.Buffer
+	next    int
+	offsets map[int]int
+	gens    map[int]int
+}
+
+func (w *writer) allocate() ref {
+	r := ref{num: w.next}
+	w.next++
+	return r
+}
+
+func (w *writer) writeObject(r ref, obj object) {
+	w.offsets[r.num] = w.buf.Len()
+	if r.gen != 0 {
+		if w.gens == nil {
+			w.gens = map[int]int{}
+		}
+		w.gens[r.num] = r.gen
+	}
+	fmt.Fprintf(w.buf, "%d %d obj\n", r.num, r.gen)
+	writeObject(w.buf, obj)
+	w.buf.WriteString("\nendobj\n")
+}
+
+// finish appends a classic xref section for the new objects and the
+// trailer pointing back at the original cross-reference data.
+func (w *writer) finish(trailer dict) {
+	nums := make([]int, 0, len(w.offsets))
+	for num := range w.offsets {
+		nums = append(nums, num)
+	}
+	sort.Ints(nums)
+
+	xrefOffset := w.buf.Len()
+	w.buf.WriteString("xref\n")
+	for i := 0; i < len(nums); {
+		j := i
+		for j+1 < len(nums) && nums[j+1] == nums[j]+1 {
+			j++
+		}
+		fmt.Fprintf(w.buf, "%d %d\n", nums[i], j-i+1)
+		for _, num := range nums[i : j+1] {
+			fmt.Fprintf(w.buf, "%010d %05d n\r\n", w.offsets[num], w.gens[num])
+		}
+		i = j + 1
+	}
+
+	w.buf.WriteString("trailer\n")
+	writeObject(w.buf, trailer)
+	fmt.Fprintf(w.buf, "\nstartxref\n%d\n%%%%EOF\n", xrefOffset)
+}
+
+func (w *writer) addNote(pg page, note Note) ref {
+	annotRef := w.allocate()
+	color := array{num(note.Color.R), num(note.Color.G), num(note.Color.B)}
+
+	annot := dict{
+		name("Type"):     name("Annot"),
+		name("P"):        pg.ref,
+		name("T"):        textString(author),
+		name("Contents"): textString(noteText(note)),
+		name("C"):        color,
+		// Print
+		name("F"): number("4"),
+	}
+
+	if note.Band == nil {
+		viewWidth, viewHeight := viewSize(pg)
+		iconWidth, iconHeight := commentIconSize/viewWidth, commentIconSize/viewHeight
+		marginX, marginY := commentIconMargin/viewWidth, commentIconMargin/viewHeight
+		annot[name("Subtype")] = name("Text")
+		annot[name("Name")] = name("Comment")
+		annot[name("Open")] = keyword("false")
+		annot[name("Rect")] = rect(viewRect(pg, 1-marginX-iconWidth, marginY, 1-marginX, marginY+iconHeight))
+		w.writeObject(annotRef, annot)
+		return annotRef
+	}
+
+	x1, y1, x2, y2 := bandRect(pg, *note.Band)
+	appearanceRef := w.allocate()
+	width, height := x2-x1, y2-y1
+	content := fmt.Sprintf("/GS0 gs\n%s %s %s rg\n%s %s %s RG\n1 w\n0.5 0.5 %s %s re\nB\n",
+		num(note.Color.R), num(note.Color.G), num(note.Color.B),
+		num(note.Color.R*0.7), num(note.Color.G*0.7), num(note.Color.B*0.7),
+		num(width-1), num(height-1))
+	w.writeObject(appearanceRef, stream{
+		dict: dict{
+			name("Type"):    name("XObject"),
+			name("Subtype"): name("Form"),
+			name("BBox"):    rect(0, 0, width, height),
+			name("Resources"): dict{
+				name("ExtGState"): dict{
+					name("GS0"): dict{
+						name("Type"): name("ExtGState"),
+						name("ca"):   number("0.25"),
+						name("CA"):   number("0.8"),
+					},
+				},
+			},
+		},
+		data: []byte(content),
+	})
+
+	annot[name("Subtype")] = name("Square")
+	annot[name("Rect")] = rect(x1, y1, x2, y2)
+	annot[name("IC")] = color
+	annot[name("CA")] = number("0.6")
+	annot[name("BS")] = dict{name("W"): number("1")}
+	annot[name("AP")] = dict{name("N"): appearanceRef}
+	w.writeObject(annotRef, annot)
+	return annotRef
+}
+
+// bandRect spans the displayed page width, minus a small margin, over the
+// band, keeping it at least minBandHeight points tall.
+func bandRect(pg page, band Band) (float64, float64, float64, float64) {
+	top := clamp(band.Top)
+	bottom := clamp(band.Bottom)
+	if bottom < top {
+		top, bottom = bottom, top
+	}
+
+	_, viewHeight := viewSize(pg)
+	if (bottom-top)*viewHeight < minBandHeight {
+		mid := (top + bottom) / 2
+		half := minBandHeight / viewHeight / 2
+		top, bottom = clamp(mid-half), clamp(mid+half)
+	}
+
+	return viewRect(pg, bandMargin, top, 1-bandMargin, bottom)
+}
+
+// viewSize is the page size as displayed, after /Rotate.
+func viewSize(pg page) (float64, float64) {
+	width, height := pg.box[2]-pg.box[0], pg.box[3]-pg.box[1]
+	if pg.rotate == 90 || pg.rotate == 270 {
+		return height, width
+	}
+	return width, height
+}
+
+// viewRect maps a rectangle given as fractions of the displayed page, from
+// its top-left corner, onto page space.
+func viewRect(pg page, left, top, right, bottom float64) (float64, float64, float64, float64) {
+	x1, y1, x2, y2 := pg.box[0], pg.box[1], pg.box[2], pg.box[3]
+	width, height := x2-x1, y2-y1
+
+	switch pg.rotate {
+	case 90:
+		return x1 + top*width, y1 + left*height, x1 + bottom*width, y1 + right*height
+	case 180:
+		return x2 - right*width, y1 + top*height, x2 - left*width, y1 + bottom*height
+	case 270:
+		return x2 - bottom*width, y2 - right*height, x2 - top*width, y2 - left*height
+	default:
+		return x1 + left*width, y2 - bottom*height, x1 + right*width, y2 - top*height
+	}
+}
+
+func noteText(note Note) string {
+	if note.Title == "" {
+		return note.Contents
+	}
+	if note.Contents == "" {
+		return note.Title
+	}
+	return note.Title + "\n\n" + note.Contents
+}
+
+func clamp(f float64) float64 {
+	return max(0, min(1, f))
+}
+
+func rect(x1, y1, x2, y2 float64) array {
+	return array{num(x1), num(y1), num(x2), num(y2)}
+}
+
+func num(f float64) number {
+	return number(strconv.FormatFloat(math.Round(f*100)/100, 'f', -1, 64))
+}
+
+// textString encodes s as a PDF text string: a literal when it is plain
+// ASCII, UTF-16BE with a byte order mark otherwise.
+func textString(s string) rawString {
+	ascii := true
+	for _, r := range s {
+		if r > '~' || (r < ' ' && r != '\n') {
+			ascii = false
+			break
+		}
+	}
+
+	var buf bytes.Buffer
+	if ascii {
+		buf.WriteByte('(')
+		for i := 0; i < len(s); i++ {
+			switch c := s[i]; c {
+			case '(', ')', '\\':
+				buf.WriteByte('\\')
+				buf.WriteByte(c)
+			case '\n':
+				buf.WriteString(`\n`)
+			default:
+				buf.WriteByte(c)
+			}
+		}
+		buf.WriteByte(')')
+		return rawString(buf.Bytes())
+	}
+
+	buf.WriteString("<FEFF")
+	for _, unit := range utf16.Encode([]rune(s)) {
+		fmt.Fprintf(&buf, "%04X", unit)
+	}
+	buf.WriteByte('>')
+	return rawString(buf.Bytes())
+}
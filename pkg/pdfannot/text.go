@@ -0,0 +1,152 @@
+package pdfannot
+
+import (
+	"bytes"
+)
+
+const maxFormDepth = 8
+
+// HasText reports whether any page of src draws text. A scanned resume is
+// usually one image per page and has none, so there is nothing to extract.
+func HasText(src []byte) (bool, error) {
+	doc, err := openDocument(src)
+	if err != nil {
+		return false, err
+	}
+	pages, err := doc.pages()
+	if err != nil {
+		return false, err
+	}
+
+	for _, pg := range pages {
+		contents, err := doc.resolve(pg.dict[name("Contents")])
+		if err != nil {
+			return false, err
+		}
+		streams, ok := contents.(array)
+		if !ok {
+			streams = array{contents}
+		}
+
+		for _, s := range streams {
+			found, err := doc.streamHasText(s, pg.dict[name("Resources")], 0)
+			if err != nil || found {
+				return found, err
+			}
+		}
+	}
+	return false, nil
+}
+
+// streamHasText scans a content stream for a text-showing operator with a
+// non-blank string, following the form XObjects it draws.
+func (d *document) streamHasText(obj, resources object, depth int) (bool, error) {
+	if depth > maxFormDepth {
+		return false, errSyntax
+	}
+	obj, err := d.resolve(obj)
+	if err != nil {
+		return false, err
+	}
+	s, ok := obj.(stream)
+	if !ok {
+		return false, nil
+	}
+	data, err := decodeStream(s)
+	if err != nil {
+		return false, err
+	}
+
+	var operands []object
+	p := &parser{data: data}
+	for {
+		p.skipSpace()
+		if p.pos >= len(data) {
+			return false, nil
+		}
+		op, err := p.parseObject()
+		if err != nil {
+			return false, err
+		}
+		kw, ok := op.(keyword)
+		if !ok {
+			operands = append(operands, op)
+			continue
+		}
+
+		switch kw {
+		case "Tj", "'", "\"":
+			if len(operands) > 0 && !blankString(operands[len(operands)-1]) {
+				return true, nil
+			}
+		case "TJ":
+			if len(operands) > 0 {
+				if parts, ok := operands[len(operands)-1].(array); ok {
+					for _, part := range parts {
+						if !blankString(part) {
+							return true, nil
+						}
+					}
+				}
+			}
+		case "Do":
+			if len(operands) > 0 {
+				if xobjName, ok := operands[len(operands)-1].(name); ok {
+					found, err := d.formHasText(resources, xobjName, depth)
+					if err != nil || found {
+						return found, err
+					}
+				}
+			}
+		case "ID":
+			// Inline image data is binary; skip to the EI that ends it
+			end := bytes.Index(data[p.pos:], []byte("EI"))
+			if end < 0 {
+				return false, nil
+			}
+			p.pos += end + 2
+		}
+		operands = operands[:0]
+	}
+}
+
+func (d *document) formHasText(resources object, xobjName name, depth int) (bool, error) {
+	resObj, err := d.resolve(resources)
+	if err != nil {
+		return false, err
+	}
+	res, _ := resObj.(dict)
+	xobjsObj, err := d.resolve(res[name("XObject")])
+	if err != nil {
+		return false, err
+	}
+	xobjs, _ := xobjsObj.(dict)
+
+	xobj, err := d.resolve(xobjs[xobjName])
+	if err != nil {
+		return false, err
+	}
+	form, ok := xobj.(stream)
+	if !ok || form.dict[name("Subtype")] != name("Form") {
+		return false, nil
+	}
+
+	formResources := form.dict[name("Resources")]
+	if formResources == nil {
+		formResources = resources
+	}
+	return d.streamHasText(form, formResources, depth+1)
+}
+
+// blankString reports whether obj is not a string or holds only whitespace.
+func blankString(obj object) bool {
+	s, ok := obj.(rawString)
+	if !ok || len(s) < 2 {
+		return true
+	}
+	body := s[1 : len(s)-1]
+	if s[0] == '<' {
+		return len(bytes.Trim(body, " \t\r\n")) == 0
+	}
+	return len(bytes.TrimSpace(body)) == 0
+}
@@ -1,12 +1,22 @@
 package response
 
-import "github.com/gofiber/fiber/v2"
+import (
+	"strings"
+
+	"github.com/raflytch/careerly-server/pkg/i18n"
+	"github.com/raflytch/careerly-server/pkg/validator"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/gofiber/fiber/v2/utils"
+)
 
 type Response struct {
 	Success bool        `json:"success"`
 	Message string      `json:"message,omitempty"`
 	Data    interface{} `json:"data,omitempty"`
+	Code    string      `json:"code,omitempty"`
 	Error   string      `json:"error,omitempty"`
+	Errors  interface{} `json:"errors,omitempty"`
 }
 
 func Success(c *fiber.Ctx, statusCode int, message string, data interface{}) error {
@@ -17,13 +27,62 @@ func Success(c *fiber.Ctx, statusCode int, message string, data interface{}) err
 	})
 }
 
+// Error sends message with its catalog code, translated to the request
+// locale. Messages outside the catalog are sent as is, under a code named
+// after the status.
 func Error(c *fiber.Ctx, statusCode int, message string) error {
+	if code, ok := i18n.CodeFor(message); ok {
+		return ErrorCode(c, statusCode, code)
+	}
 	return c.Status(statusCode).JSON(Response{
 		Success: false,
+		Code:    statusCodeName(statusCode),
 		Error:   message,
 	})
 }
 
+func ErrorCode(c *fiber.Ctx, statusCode int, code string) error {
+	return c.Status(statusCode).JSON(Response{
+		Success: false,
+		Code:    code,
+		Error:   i18n.Translate(Locale(c), code, nil),
+	})
+}
+
+// ErrorCodeWithData is ErrorCode for errors that come with data the client
+// acts on, such as the id of a conflicting record.
+func ErrorCodeWithData(c *fiber.Ctx, statusCode int, code string, data interface{}) error {
+	return c.Status(statusCode).JSON(Response{
+		Success: false,
+		Code:    code,
+		Error:   i18n.Translate(Locale(c), code, nil),
+		Data:    data,
+	})
+}
+
+// ErrorWithCode sends code with a message of the caller's, for errors that
+// carry detail the catalog message does not.
+func ErrorWithCode(c *fiber.Ctx, statusCode int, code, message string) error {
+	return c.Status(statusCode).JSON(Response{
+		Success: false,
+		Code:    code,
+		Error:   message,
+	})
+}
+
+func ValidationError(c *fiber.Ctx, errors interface{}) error {
+	lang := Locale(c)
+	if fieldErrors, ok := errors.(validator.ValidationErrors); ok {
+		errors = fieldErrors.Localize(lang)
+	}
+	return c.Status(fiber.StatusBadRequest).JSON(Response{
+		Success: false,
+		Code:    "VALIDATION_FAILED",
+		Error:   i18n.Translate(lang, "VALIDATION_FAILED", nil),
+		Errors:  errors,
+	})
+}
+
 func BadRequest(c *fiber.Ctx, message string) error {
 	return Error(c, fiber.StatusBadRequest, message)
 }
@@ -43,3 +102,20 @@ func NotFound(c *fiber.Ctx, message string) error {
 func InternalError(c *fiber.Ctx, message string) error {
 	return Error(c, fiber.StatusInternalServerError, message)
 }
+
+// Locale is the language negotiated for the request, or the default when
+// the locale middleware did not run.
+func Locale(c *fiber.Ctx) string {
+	if lang, ok := c.Locals(i18n.ContextKey).(string); ok && lang != "" {
+		return lang
+	}
+	return i18n.DefaultLanguage
+}
+
+func statusCodeName(statusCode int) string {
+	name := strings.ToUpper(strings.ReplaceAll(utils.StatusMessage(statusCode), " ", "_"))
+	if name == "" {
+		return "ERROR"
+	}
+	return strings.ReplaceAll(name, "'", "")
+}
@@ -0,0 +1,60 @@
+// Package semaphore limits how many holders of a key may run at once across
+// every instance sharing a Redis server.
+package semaphore
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/redis/go-redis/v9"
+)
+
+const defaultKeyPrefix = "semaphore"
+
+// acquireScript drops leases older than the lease time, so a holder that
+// died without releasing frees its slot, then takes a slot if one is left.
+var acquireScript = redis.NewScript(`
+redis.call('ZREMRANGEBYSCORE', KEYS[1], '-inf', tonumber(ARGV[1]) - tonumber(ARGV[2]))
+if redis.call('ZCARD', KEYS[1]) < tonumber(ARGV[3]) then
+	redis.call('ZADD', KEYS[1], ARGV[1], ARGV[4])
+	redis.call('PEXPIRE', KEYS[1], ARGV[2])
+	return 1
+end
+return 0
+`)
+
+type Semaphore struct {
+	client    *redis.Client
+	keyPrefix string
+	lease     time.Duration
+}
+
+// New returns a semaphore whose slots are held for at most lease, which
+// should outlast the longest operation it guards.
+func New(client *redis.Client, keyPrefix string, lease time.Duration) *Semaphore {
+	if keyPrefix == "" {
+		keyPrefix = defaultKeyPrefix
+	}
+	return &Semaphore{client: client, keyPrefix: keyPrefix, lease: lease}
+}
+
+// Acquire takes one of limit slots for key. When acquired is false every
+// slot is taken; otherwise release must be called to give the slot back.
+func (s *Semaphore) Acquire(ctx context.Context, key string, limit int) (release func(), acquired bool, err error) {
+	redisKey := s.keyPrefix + ":" + key
+	token := uuid.NewString()
+
+	ok, err := acquireScript.Run(ctx, s.client, []string{redisKey},
+		time.Now().UnixMilli(), s.lease.Milliseconds(), limit, token).Int()
+	if err != nil {
+		return nil, false, err
+	}
+	if ok == 0 {
+		return nil, false, nil
+	}
+
+	return func() {
+		s.client.ZRem(context.Background(), redisKey, token)
+	}, true, nil
+}
@@ -0,0 +1,69 @@
+package signedtoken
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"errors"
+	"strconv"
+	"strings"
+	"time"
+)
+
+var (
+	ErrInvalidToken = errors.New("invalid token")
+	ErrExpiredToken = errors.New("token has expired")
+)
+
+// Signer issues compact, URL-safe tokens of the form
+// "<subject>.<expiry>.<signature>" that can be verified without a lookup.
+type Signer struct {
+	secret []byte
+}
+
+func New(secret string) *Signer {
+	return &Signer{secret: []byte(secret)}
+}
+
+func (s *Signer) Sign(subject string, expiresAt time.Time) string {
+	payload := base64.RawURLEncoding.EncodeToString([]byte(subject)) + "." + strconv.FormatInt(expiresAt.Unix(), 10)
+	return payload + "." + s.signature(payload)
+}
+
+func (s *Signer) Verify(token string) (string, error) {
+	idx := strings.LastIndex(token, ".")
+	if idx < 0 {
+		return "", ErrInvalidToken
+	}
+	payload, sig := token[:idx], token[idx+1:]
+
+	if !hmac.Equal([]byte(sig), []byte(s.signature(payload))) {
+		return "", ErrInvalidToken
+	}
+
+	encodedSubject, expiry, ok := strings.Cut(payload, ".")
+	if !ok {
+		return "", ErrInvalidToken
+	}
+
+	expiresAt, err := strconv.ParseInt(expiry, 10, 64)
+	if err != nil {
+		return "", ErrInvalidToken
+	}
+	if time.Now().Unix() > expiresAt {
+		return "", ErrExpiredToken
+	}
+
+	subject, err := base64.RawURLEncoding.DecodeString(encodedSubject)
+	if err != nil {
+		return "", ErrInvalidToken
+	}
+
+	return string(subject), nil
+}
+
+func (s *Signer) signature(payload string) string {
+	mac := hmac.New(sha256.New, s.secret)
+	mac.Write([]byte(payload))
+	return base64.RawURLEncoding.EncodeToString(mac.Sum(nil))
+}
@@ -0,0 +1,60 @@
+package storage
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"path"
+
+	"github.com/imagekit-developer/imagekit-go/v2"
+	"github.com/imagekit-developer/imagekit-go/v2/option"
+)
+
+type ImageKitStorage struct {
+	ik imagekit.Client
+}
+
+func NewImageKitStorage(privateKey string) *ImageKitStorage {
+	return &ImageKitStorage{
+		ik: imagekit.NewClient(option.WithPrivateKey(privateKey)),
+	}
+}
+
+func (s *ImageKitStorage) Driver() string {
+	return DriverImageKit
+}
+
+// Put uploads data under the folder and file name taken from key. ImageKit
+// files are deleted by the file ID it assigns, which is returned as the
+// object's ID.
+func (s *ImageKitStorage) Put(ctx context.Context, key string, data []byte, contentType string) (*Object, error) {
+	resp, err := s.ik.Files.Upload(ctx, imagekit.FileUploadParams{
+		File:              bytes.NewReader(data),
+		FileName:          path.Base(key),
+		Folder:            imagekit.String("/" + path.Dir(key)),
+		UseUniqueFileName: imagekit.Bool(false),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to upload file to ImageKit: %w", err)
+	}
+
+	return &Object{
+		ID:          resp.FileID,
+		URL:         resp.URL,
+		Size:        int64(len(data)),
+		ContentType: contentType,
+	}, nil
+}
+
+func (s *ImageKitStorage) Delete(ctx context.Context, id string) error {
+	if id == "" {
+		return nil
+	}
+	var apiErr *imagekit.Error
+	if err := s.ik.Files.Delete(ctx, id); err != nil && !(errors.As(err, &apiErr) && apiErr.StatusCode == http.StatusNotFound) {
+		return fmt.Errorf("failed to delete file from ImageKit: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,84 @@
+package storage
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"time"
+)
+
+const (
+	DriverImageKit = "imagekit"
+	DriverS3       = "s3"
+	DriverGCS      = "gcs"
+	DriverLocal    = "local"
+)
+
+const httpTimeout = 60 * time.Second
+
+var ErrUnknownDriver = errors.New("unknown storage driver")
+
+// Object describes a stored file. ID is the handle Delete expects, which is
+// the key for S3, GCS and local disk but a provider-assigned file ID for
+// ImageKit. Local objects have no public URL. Deleting an object that is
+// already gone succeeds.
+type Object struct {
+	ID          string
+	URL         string
+	Size        int64
+	ContentType string
+}
+
+type Storage interface {
+	Driver() string
+	Put(ctx context.Context, key string, data []byte, contentType string) (*Object, error)
+	Delete(ctx context.Context, id string) error
+}
+
+// URLSigner is implemented by drivers that can hand out short-lived links to
+// objects that are not public.
+type URLSigner interface {
+	SignedURL(ctx context.Context, key string, ttl time.Duration) (string, error)
+}
+
+type Config struct {
+	Driver string
+
+	ImageKitPrivateKey string
+
+	S3Endpoint        string
+	S3Region          string
+	S3Bucket          string
+	S3AccessKeyID     string
+	S3SecretAccessKey string
+	S3PublicURL       string
+
+	GCSBucket          string
+	GCSAccessKeyID     string
+	GCSSecretAccessKey string
+	GCSPublicURL       string
+
+	LocalDir           string
+	LocalBaseURL       string
+	LocalSigningSecret string
+}
+
+func New(cfg Config) (Storage, error) {
+	switch cfg.Driver {
+	case "", DriverImageKit:
+		return NewImageKitStorage(cfg.ImageKitPrivateKey), nil
+	case DriverS3:
+		return NewS3Storage(cfg.S3Endpoint, cfg.S3Region, cfg.S3Bucket, cfg.S3AccessKeyID, cfg.S3SecretAccessKey, cfg.S3PublicURL), nil
+	case DriverGCS:
+		return NewGCSStorage(cfg.GCSBucket, cfg.GCSAccessKeyID, cfg.GCSSecretAccessKey, cfg.GCSPublicURL), nil
+	case DriverLocal:
+		return NewLocalStorage(cfg.LocalDir, cfg.LocalBaseURL, cfg.LocalSigningSecret), nil
+	default:
+		return nil, fmt.Errorf("%w: %q", ErrUnknownDriver, cfg.Driver)
+	}
+}
+
+func newHTTPClient() *http.Client {
+	return &http.Client{Timeout: httpTimeout}
+}
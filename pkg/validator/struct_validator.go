@@ -0,0 +1,140 @@
+package validator
+
+import (
+	"errors"
+	"reflect"
+	"strings"
+
+	"github.com/raflytch/careerly-server/pkg/i18n"
+
+	playground "github.com/go-playground/validator/v10"
+)
+
+type FieldError struct {
+	Field   string `json:"field"`
+	Rule    string `json:"rule"`
+	Message string `json:"message"`
+
+	code   string
+	params map[string]string
+}
+
+type ValidationErrors []FieldError
+
+func (v ValidationErrors) Error() string {
+	messages := make([]string, len(v))
+	for i, e := range v {
+		messages[i] = e.Message
+	}
+	return strings.Join(messages, "; ")
+}
+
+// Localize returns a copy with each message translated to lang.
+func (v ValidationErrors) Localize(lang string) ValidationErrors {
+	localized := make(ValidationErrors, len(v))
+	for i, e := range v {
+		localized[i] = e
+		if e.code != "" {
+			localized[i].Message = i18n.Translate(lang, e.code, e.params)
+		}
+	}
+	return localized
+}
+
+var structValidator = newStructValidator()
+
+func newStructValidator() *playground.Validate {
+	v := playground.New()
+	v.RegisterTagNameFunc(func(field reflect.StructField) string {
+		name := strings.SplitN(field.Tag.Get("json"), ",", 2)[0]
+		if name == "-" {
+			return ""
+		}
+		if name == "" {
+			return field.Name
+		}
+		return name
+	})
+	return v
+}
+
+func ValidateStruct(s interface{}) error {
+	err := structValidator.Struct(s)
+	if err == nil {
+		return nil
+	}
+
+	var validationErrors playground.ValidationErrors
+	if !errors.As(err, &validationErrors) {
+		return err
+	}
+
+	result := make(ValidationErrors, 0, len(validationErrors))
+	for _, e := range validationErrors {
+		code := fieldMessageCode(e)
+		params := map[string]string{"field": fieldPath(e), "param": e.Param()}
+		result = append(result, FieldError{
+			Field:   fieldPath(e),
+			Rule:    e.Tag(),
+			Message: i18n.Translate(i18n.DefaultLanguage, code, params),
+			code:    code,
+			params:  params,
+		})
+	}
+	return result
+}
+
+func fieldPath(e playground.FieldError) string {
+	namespace := e.Namespace()
+	if idx := strings.Index(namespace, "."); idx >= 0 {
+		return namespace[idx+1:]
+	}
+	return e.Field()
+}
+
+func fieldMessageCode(e playground.FieldError) string {
+	switch e.Tag() {
+	case "required":
+		return "VALIDATION_REQUIRED"
+	case "email":
+		return "VALIDATION_EMAIL"
+	case "len":
+		return "VALIDATION_LEN"
+	case "min":
+		if isNumeric(e.Kind()) {
+			return "VALIDATION_MIN"
+		}
+		if isCollection(e.Kind()) {
+			return "VALIDATION_MIN_ITEMS"
+		}
+		return "VALIDATION_MIN_LENGTH"
+	case "max":
+		if isNumeric(e.Kind()) {
+			return "VALIDATION_MAX"
+		}
+		if isCollection(e.Kind()) {
+			return "VALIDATION_MAX_ITEMS"
+		}
+		return "VALIDATION_MAX_LENGTH"
+	case "oneof":
+		return "VALIDATION_ONEOF"
+	case "numeric":
+		return "VALIDATION_NUMERIC"
+	default:
+		return "VALIDATION_INVALID"
+	}
+}
+
+func isNumeric(kind reflect.Kind) bool {
+	switch kind {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64,
+		reflect.Float32, reflect.Float64:
+		return true
+	}
+	return false
+}
+
+func isCollection(kind reflect.Kind) bool {
+	return kind == reflect.Slice || kind == reflect.Array || kind == reflect.Map
+}